@@ -18,6 +18,7 @@ import (
 	"github.com/steveyegge/beads/internal/beads"
 	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/debug"
+	"github.com/steveyegge/beads/internal/importer"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/syncbranch"
 	"github.com/steveyegge/beads/internal/types"
@@ -568,7 +569,7 @@ func writeJSONLAtomic(jsonlPath string, issues []*types.Issue) ([]string, error)
 	}()
 
 	// Write all issues as JSONL (timestamp-only deduplication DISABLED)
-	encoder := json.NewEncoder(f)
+	encoder := types.NewIssueEncoder(f)
 	skippedCount := 0
 	exportedIDs := make([]string, 0, len(issues))
 
@@ -661,10 +662,10 @@ func readExistingJSONL(jsonlPath string) (map[string]*types.Issue, error) {
 		if line == "" {
 			continue
 		}
-		var issue types.Issue
-		if err := json.Unmarshal([]byte(line), &issue); err == nil {
+		// Preserve unknown fields, so issues that are not re-fetched keep them
+		if issue, err := importer.DecodeIssue([]byte(line), importer.UnknownFieldsPreserve); err == nil {
 			issue.SetDefaults() // Apply defaults for omitted fields (beads-399)
-			issueMap[issue.ID] = &issue
+			issueMap[issue.ID] = issue
 		} else {
 			// Warn about malformed JSONL lines
 			fmt.Fprintf(os.Stderr, "Warning: skipping malformed JSONL line %d: %v\n", lineNum, err)
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/importer"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -281,3 +282,67 @@ func TestFetchAndMergeIssues_IncludesMultipleComments(t *testing.T) {
 		t.Errorf("fetchAndMergeIssues: expected 2 comments, got %d", len(fetchedIssue.Comments))
 	}
 }
+
+// TestWriteJSONLAtomic_PreservesUnknownFields verifies that fields this version
+// does not know survive an auto-flush, both for the flushed issue and for
+// issues carried over unchanged from the existing JSONL.
+func TestWriteJSONLAtomic_PreservesUnknownFields(t *testing.T) {
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+	store := newTestStore(t, filepath.Join(tmpDir, ".beads", "beads.db"))
+	defer store.Close()
+
+	var issues []*types.Issue
+	for _, record := range []string{
+		`{"id":"test-1","title":"Dirty","status":"open","priority":2,"issue_type":"task","x_sprint":"S12"}`,
+		`{"id":"test-2","title":"Clean","status":"open","priority":2,"issue_type":"task","x_team":{"name":"core"}}`,
+	} {
+		issue, err := importer.DecodeIssue([]byte(record), importer.UnknownFieldsPreserve)
+		if err != nil {
+			t.Fatalf("DecodeIssue failed: %v", err)
+		}
+		issues = append(issues, issue)
+	}
+	if _, err := importer.ImportIssues(ctx, store.Path(), store, issues, importer.Options{}); err != nil {
+		t.Fatalf("ImportIssues failed: %v", err)
+	}
+
+	// A full flush, then an incremental one where only test-1 is dirty
+	jsonlPath := filepath.Join(tmpDir, ".beads", "issues.jsonl")
+	issueMap := make(map[string]*types.Issue)
+	if err := fetchAndMergeIssues(ctx, store, []string{"test-1", "test-2"}, issueMap); err != nil {
+		t.Fatalf("fetchAndMergeIssues failed: %v", err)
+	}
+	if _, err := writeJSONLAtomic(jsonlPath, filterWisps(issueMap)); err != nil {
+		t.Fatalf("writeJSONLAtomic failed: %v", err)
+	}
+	issueMap, err := readExistingJSONL(jsonlPath)
+	if err != nil {
+		t.Fatalf("readExistingJSONL failed: %v", err)
+	}
+	if err := fetchAndMergeIssues(ctx, store, []string{"test-1"}, issueMap); err != nil {
+		t.Fatalf("fetchAndMergeIssues failed: %v", err)
+	}
+	if _, err := writeJSONLAtomic(jsonlPath, filterWisps(issueMap)); err != nil {
+		t.Fatalf("writeJSONLAtomic failed: %v", err)
+	}
+
+	// Importing the flushed file brings both fields back
+	flushed, err := readExistingJSONL(jsonlPath)
+	if err != nil {
+		t.Fatalf("readExistingJSONL failed: %v", err)
+	}
+	target := newTestStore(t, filepath.Join(tmpDir, "target", "beads.db"))
+	defer target.Close()
+	if _, err := importer.ImportIssues(ctx, target.Path(), target, filterWisps(flushed), importer.Options{}); err != nil {
+		t.Fatalf("ImportIssues of the flushed JSONL failed: %v", err)
+	}
+	want := map[string]string{"test-1": `"S12"`, "test-2": `{"name":"core"}`}
+	names := map[string]string{"test-1": "x_sprint", "test-2": "x_team"}
+	for id, value := range want {
+		got, _ := target.GetIssue(ctx, id)
+		if got == nil || string(got.CustomFields[names[id]]) != value {
+			t.Errorf("expected %s=%s on %s after the round trip, got %+v", names[id], value, id, got)
+		}
+	}
+}
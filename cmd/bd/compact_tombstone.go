@@ -85,7 +85,7 @@ func pruneExpiredTombstones(customTTL time.Duration) (*TombstonePruneResult, err
 	}
 	tempPath := tempFile.Name()
 
-	encoder := json.NewEncoder(tempFile)
+	encoder := types.NewIssueEncoder(tempFile)
 	for _, issue := range kept {
 		if err := encoder.Encode(issue); err != nil {
 			_ = tempFile.Close()
@@ -410,7 +410,7 @@ func purgeTombstonesByDependency(dryRun bool) (*PurgeTombstonesResult, error) {
 	}
 	tempPath := tempFile.Name()
 
-	encoder := json.NewEncoder(tempFile)
+	encoder := types.NewIssueEncoder(tempFile)
 	for _, issue := range kept {
 		if err := encoder.Encode(issue); err != nil {
 			_ = tempFile.Close()
@@ -851,7 +851,7 @@ func performAtomicExport(_ context.Context, jsonlPath string, issues []*types.Is
 	}
 
 	// Write issues as JSONL
-	encoder := json.NewEncoder(tempFile)
+	encoder := types.NewIssueEncoder(tempFile)
 	for _, issue := range issues {
 		if err := encoder.Encode(issue); err != nil {
 			_ = tempFile.Close()
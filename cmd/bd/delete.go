@@ -403,7 +403,7 @@ func removeIssueFromJSONL(issueID string) error {
 	if err != nil {
 		return fmt.Errorf("failed to create temp file: %w", err)
 	}
-	enc := json.NewEncoder(out)
+	enc := types.NewIssueEncoder(out)
 	for _, iss := range issues {
 		if err := enc.Encode(iss); err != nil {
 			_ = out.Close()
@@ -164,7 +164,7 @@ func ExpiredTombstones(path string) error {
 	}
 	tempPath := tempFile.Name()
 
-	encoder := json.NewEncoder(tempFile)
+	encoder := types.NewIssueEncoder(tempFile)
 	for _, issue := range kept {
 		if err := encoder.Encode(issue); err != nil {
 			_ = tempFile.Close()
@@ -510,7 +510,7 @@ Examples:
 			}
 		} else {
 			// Write JSONL (timestamp-only deduplication DISABLED due to bd-160)
-			encoder := json.NewEncoder(out)
+			encoder := types.NewIssueEncoder(out)
 			for _, issue := range issues {
 				if err := encoder.Encode(issue); err != nil {
 					fmt.Fprintf(os.Stderr, "Error encoding issue %s: %v\n", issue.ID, err)
@@ -2,12 +2,14 @@ package main
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
 	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/steveyegge/beads/internal/importer"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -702,3 +704,52 @@ func TestExportCommand(t *testing.T) {
 		}
 	})
 }
+
+func TestExportCommand_PreservesUnknownFields(t *testing.T) {
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+	testDB := filepath.Join(tmpDir, "test.db")
+	s := newTestStore(t, testDB)
+	defer s.Close()
+
+	// Import a record carrying a field this version does not know
+	record := []byte(`{"id":"test-1","title":"From a newer bd","status":"open","priority":2,"issue_type":"task","x_sprint":"S12"}`)
+	issue, err := importer.DecodeIssue(record, importer.UnknownFieldsPreserve)
+	if err != nil {
+		t.Fatalf("DecodeIssue failed: %v", err)
+	}
+	if _, err := importer.ImportIssues(ctx, testDB, s, []*types.Issue{issue}, importer.Options{}); err != nil {
+		t.Fatalf("ImportIssues failed: %v", err)
+	}
+
+	exportPath := filepath.Join(tmpDir, "export.jsonl")
+	store = s
+	dbPath = testDB
+	rootCtx = ctx
+	defer func() { rootCtx = nil }()
+	exportCmd.Flags().Set("output", exportPath)
+	exportCmd.Run(exportCmd, []string{})
+
+	data, err := os.ReadFile(exportPath)
+	if err != nil {
+		t.Fatalf("Failed to read export: %v", err)
+	}
+	exported, err := importer.DecodeIssue(bytes.TrimSpace(data), importer.UnknownFieldsPreserve)
+	if err != nil {
+		t.Fatalf("Failed to parse export %q: %v", data, err)
+	}
+	if got := string(exported.CustomFields["x_sprint"]); got != `"S12"` {
+		t.Fatalf("expected x_sprint to survive bd export, got %q in %s", got, data)
+	}
+
+	// The export imports back with the field intact
+	targetDB := filepath.Join(tmpDir, "target.db")
+	target := newTestStore(t, targetDB)
+	defer target.Close()
+	if _, err := importer.ImportIssues(ctx, targetDB, target, []*types.Issue{exported}, importer.Options{}); err != nil {
+		t.Fatalf("ImportIssues of the export failed: %v", err)
+	}
+	if got, _ := target.GetIssue(ctx, "test-1"); got == nil || string(got.CustomFields["x_sprint"]) != `"S12"` {
+		t.Errorf("expected x_sprint after re-import, got %+v", got)
+	}
+}
@@ -15,7 +15,9 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/debug"
+	"github.com/steveyegge/beads/internal/importer"
 	"github.com/steveyegge/beads/internal/storage/factory"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/utils"
@@ -128,6 +130,13 @@ NOTE: Import requires direct database access and does not work with daemon mode.
 		protectLeftSnapshot, _ := cmd.Flags().GetBool("protect-left-snapshot")
 		noGitHistory, _ := cmd.Flags().GetBool("no-git-history")
 		_ = noGitHistory // Accepted for compatibility with bd sync subprocess calls
+		conflictsOut, _ := cmd.Flags().GetString("conflicts-out")
+		unknownFieldsFlag, _ := cmd.Flags().GetString("unknown-fields")
+		unknownFields, err := importer.ParseUnknownFieldsPolicy(unknownFieldsFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
 
 		// Check if stdin is being used interactively (not piped)
 		if input == "" && term.IsTerminal(int(os.Stdin.Fd())) {
@@ -160,7 +169,8 @@ NOTE: Import requires direct database access and does not work with daemon mode.
 
 		// Phase 1: Read and parse all JSONL
 		ctx := rootCtx
-		scanner := bufio.NewScanner(in)
+		maxLineSize := config.GetInt("import.max-line-bytes")
+		scanner := utils.NewJSONLScanner(in, maxLineSize)
 
 		var allIssues []*types.Issue
 		var deletionMarkers []*DeletionMarker
@@ -218,7 +228,7 @@ NOTE: Import requires direct database access and does not work with daemon mode.
 						}
 					}()
 					in = f
-					scanner = bufio.NewScanner(in)
+					scanner = utils.NewJSONLScanner(in, maxLineSize)
 					allIssues = nil        // Reset issues list
 					deletionMarkers = nil  // Reset deletion markers list
 					lineNum = 0            // Reset line counter
@@ -241,11 +251,12 @@ NOTE: Import requires direct database access and does not work with daemon mode.
 			}
 
 			// Parse JSON as regular issue
-			var issue types.Issue
-			if err := json.Unmarshal([]byte(line), &issue); err != nil {
+			parsed, err := importer.DecodeIssue([]byte(line), unknownFields)
+			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error parsing line %d: %v\n", lineNum, err)
 				os.Exit(1)
 			}
+			issue := *parsed
 			issue.SetDefaults() // Apply defaults for omitted fields (beads-399)
 
 			// Migrate old JSONL format: auto-correct deleted status to tombstone
@@ -360,6 +371,12 @@ NOTE: Import requires direct database access and does not work with daemon mode.
 
 		result, err := importIssuesCore(ctx, dbPath, store, allIssues, opts)
 
+		if result != nil && conflictsOut != "" {
+			if werr := writeConflictReport(conflictsOut, result.Conflicts); werr != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", werr)
+			}
+		}
+
 		// Check for uncommitted changes in JSONL after import
 		// Only check if we have an input file path (not stdin) and it's the default beads file
 		if result != nil && input != "" && (input == ".beads/issues.jsonl" || input == ".beads/beads.jsonl") {
@@ -880,6 +897,19 @@ func detectPrefixFromIssues(issues []*types.Issue) string {
 	return commonPrefix
 }
 
+// writeConflictReport writes report to path as indented JSON.
+func writeConflictReport(path string, report *importer.ConflictReport) error {
+	f, err := os.Create(path) // #nosec G304 -- path is the user's --conflicts-out flag
+	if err != nil {
+		return fmt.Errorf("failed to create conflict report: %w", err)
+	}
+	if err := importer.WriteConflictReport(f, report); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
 func init() {
 	importCmd.Flags().StringP("input", "i", "", "Input file (default: stdin)")
 	importCmd.Flags().BoolP("skip-existing", "s", false, "Skip existing issues instead of updating them")
@@ -892,6 +922,8 @@ func init() {
 	importCmd.Flags().Bool("force", false, "Force metadata update even when database is already in sync with JSONL")
 	importCmd.Flags().Bool("protect-left-snapshot", false, "Protect issues in left snapshot from git-history-backfill")
 	importCmd.Flags().Bool("no-git-history", false, "Skip git history backfill for deletions (passed by bd sync)")
+	importCmd.Flags().String("unknown-fields", "", "How to handle issue fields this version does not know: drop/error/preserve (default: drop)")
+	importCmd.Flags().String("conflicts-out", "", "Write import conflicts (hash collisions, skips, remaps) as versioned JSON to this path")
 	importCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output import statistics in JSON format")
 	rootCmd.AddCommand(importCmd)
 }
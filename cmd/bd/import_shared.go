@@ -184,6 +184,8 @@ type ImportResult struct {
 	ExpectedPrefix      string            // Database configured prefix
 	MismatchPrefixes    map[string]int    // Map of mismatched prefixes to count
 	SkippedDependencies []string          // Dependencies skipped due to FK constraint violations
+
+	Conflicts *importer.ConflictReport // Machine-readable conflicts, written by --conflicts-out
 }
 
 // importIssuesCore handles the core import logic used by both manual and auto-import.
@@ -247,6 +249,7 @@ func importIssuesCore(ctx context.Context, dbPath string, store storage.Storage,
 		ExpectedPrefix:      result.ExpectedPrefix,
 		MismatchPrefixes:    result.MismatchPrefixes,
 		SkippedDependencies: result.SkippedDependencies,
+		Conflicts:           result.ConflictReport(),
 	}, nil
 }
 
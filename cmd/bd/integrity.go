@@ -7,7 +7,6 @@ import (
 	"crypto/sha256"
 	"database/sql"
 	"encoding/hex"
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -465,7 +464,7 @@ func computeDBHash(ctx context.Context, store storage.Storage) (string, error) {
 
 	// Serialize to JSON and hash
 	var buf bytes.Buffer
-	encoder := json.NewEncoder(&buf)
+	encoder := types.NewIssueEncoder(&buf)
 	for _, issue := range issues {
 		if err := encoder.Encode(issue); err != nil {
 			return "", fmt.Errorf("failed to encode issue %s: %w", issue.ID, err)
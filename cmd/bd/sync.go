@@ -16,6 +16,7 @@ import (
 	"github.com/steveyegge/beads/internal/debug"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/syncbranch"
+	"github.com/steveyegge/beads/internal/types"
 )
 
 // SyncBranchContext holds sync-branch configuration detected from the store.
@@ -688,7 +689,7 @@ func writeMergedStateToJSONL(path string, issues []*beads.Issue) error {
 		return err
 	}
 
-	encoder := json.NewEncoder(file)
+	encoder := types.NewIssueEncoder(file)
 	encoder.SetEscapeHTML(false)
 
 	for _, issue := range issues {
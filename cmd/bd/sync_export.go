@@ -251,7 +251,7 @@ func exportToJSONLDeferred(ctx context.Context, jsonlPath string) (*ExportResult
 	}()
 
 	// Write JSONL and collect content hashes (GH#1278)
-	encoder := json.NewEncoder(tempFile)
+	encoder := types.NewIssueEncoder(tempFile)
 	exportedIDs := make([]string, 0, len(issues))
 	issueContentHashes := make(map[string]string, len(issues))
 	for _, issue := range issues {
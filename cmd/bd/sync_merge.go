@@ -11,6 +11,7 @@ import (
 
 	"github.com/steveyegge/beads/internal/beads"
 	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/types"
 )
 
 // MergeResult contains the outcome of a 3-way merge
@@ -692,7 +693,7 @@ func saveBaseState(beadsDir string, issues []*beads.Issue) error {
 		return err
 	}
 
-	encoder := json.NewEncoder(file)
+	encoder := types.NewIssueEncoder(file)
 	encoder.SetEscapeHTML(false)
 
 	for _, issue := range issues {
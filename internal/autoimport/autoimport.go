@@ -1,7 +1,6 @@
 package autoimport
 
 import (
-	"bufio"
 	"bytes"
 	"context"
 	"crypto/sha256"
@@ -12,6 +11,7 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/debug"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
@@ -218,8 +218,7 @@ func checkForMergeConflicts(jsonlData []byte, jsonlPath string) error {
 }
 
 func parseJSONL(jsonlData []byte, notify Notifier) ([]*types.Issue, error) {
-	scanner := bufio.NewScanner(bytes.NewReader(jsonlData))
-	scanner.Buffer(make([]byte, 0, 1024), 2*1024*1024)
+	scanner := utils.NewJSONLScanner(bytes.NewReader(jsonlData), config.GetInt("import.max-line-bytes"))
 	var allIssues []*types.Issue
 	lineNo := 0
 
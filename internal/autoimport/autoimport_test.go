@@ -444,6 +444,20 @@ func TestParseJSONL(t *testing.T) {
 		}
 	})
 
+	t.Run("line larger than default scanner buffer", func(t *testing.T) {
+		description := strings.Repeat("x", 3*1024*1024)
+		data := `{"id":"test-1","title":"Huge","description":"` + description + `","status":"open","priority":1,"issue_type":"task","created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-01T00:00:00Z"}
+{"id":"test-2","title":"Issue 2","status":"open","priority":1,"issue_type":"task","created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-01T00:00:00Z"}`
+
+		issues, err := parseJSONL([]byte(data), notify)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if len(issues) != 2 || len(issues[0].Description) != len(description) {
+			t.Errorf("Expected 2 issues with the full description, got %d", len(issues))
+		}
+	})
+
 	t.Run("invalid json", func(t *testing.T) {
 		data := `{"id":"test-1","title":"Issue 1"}
 not valid json`
@@ -157,6 +157,11 @@ func Initialize() error {
 	// Default matches types.MaxHierarchyDepth constant
 	v.SetDefault("hierarchy.max-depth", 3)
 
+	// Import configuration defaults
+	// Largest JSONL record (bytes) accepted when reading issues.jsonl
+	// Default matches utils.DefaultMaxJSONLLineSize constant
+	v.SetDefault("import.max-line-bytes", 64*1024*1024)
+
 	// Git configuration defaults (GH#600)
 	v.SetDefault("git.author", "")         // Override commit author (e.g., "beads-bot <beads@example.com>")
 	v.SetDefault("git.no-gpg-sign", false) // Disable GPG signing for beads commits
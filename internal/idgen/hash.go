@@ -53,6 +53,13 @@ func EncodeBase36(data []byte, length int) string {
 // Uses base36 encoding (0-9, a-z) for better information density than hex.
 // The length parameter is expected to be 3-8; other values fall back to a 3-char byte width.
 func GenerateHashID(prefix, title, description, creator string, timestamp time.Time, length, nonce int) string {
+	return GenerateHashIDWithSeparator(prefix, "-", title, description, creator, timestamp, length, nonce)
+}
+
+// GenerateHashIDWithSeparator is GenerateHashID with a configurable separator
+// between the prefix and the hash (e.g. "web-app_a3f8e9" with separator "_").
+// The hash itself does not depend on the prefix or separator.
+func GenerateHashIDWithSeparator(prefix, separator, title, description, creator string, timestamp time.Time, length, nonce int) string {
 	// Combine inputs into a stable content string
 	// Include nonce to handle hash collisions
 	content := fmt.Sprintf("%s|%s|%s|%d|%d", title, description, creator, timestamp.UnixNano(), nonce)
@@ -81,5 +88,5 @@ func GenerateHashID(prefix, title, description, creator string, timestamp time.T
 
 	shortHash := EncodeBase36(hash[:numBytes], length)
 
-	return fmt.Sprintf("%s-%s", prefix, shortHash)
+	return prefix + separator + shortHash
 }
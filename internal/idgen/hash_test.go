@@ -28,3 +28,12 @@ func TestGenerateHashIDMatchesJiraVector(t *testing.T) {
 		}
 	}
 }
+
+func TestGenerateHashIDWithSeparator(t *testing.T) {
+	timestamp := time.Date(2024, 1, 2, 3, 4, 5, 6*1_000_000, time.UTC)
+
+	got := GenerateHashIDWithSeparator("web-app", "_", "Fix login", "Details", "jira-import", timestamp, 6, 0)
+	if got != "web-app_8bi3tk" {
+		t.Fatalf("got %s, want web-app_8bi3tk", got)
+	}
+}
@@ -0,0 +1,79 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ActorMapper translates an actor identity from the source system (e.g. an
+// email) to the local one (e.g. a username). It returns "" for identities it
+// does not know.
+type ActorMapper func(actor string) string
+
+// ErrUnmappedActor is matched (via errors.Is) by the ValidationError returned
+// under Options.ActorMapStrict for an actor the ActorMap does not know.
+var ErrUnmappedActor = errors.New("unmapped actor")
+
+// applyActorMap rewrites every actor on issues and opts.Events through
+// opts.ActorMap: each issue's created_by, deleted_by and actor, its comment
+// authors and dependency creators, and each event's actor. Assignees, owners
+// and watchers are not actors and are left alone. Actors the map does not know
+// are kept as they are, or fail the import under opts.ActorMapStrict. It runs
+// before hashing, so mapped creators are part of the content hash.
+func applyActorMap(issues []*types.Issue, opts Options) error {
+	if opts.ActorMap == nil {
+		if opts.ActorMapStrict {
+			return fmt.Errorf("ActorMapStrict requires an ActorMap")
+		}
+		return nil
+	}
+	mapActor := func(issueID, field string, actor *string) error {
+		if *actor == "" {
+			return nil
+		}
+		if local := opts.ActorMap(*actor); local != "" {
+			*actor = local
+			return nil
+		}
+		if opts.ActorMapStrict {
+			return &ValidationError{IssueID: issueID, Err: fmt.Errorf("%w %q in %s", ErrUnmappedActor, *actor, field)}
+		}
+		return nil
+	}
+
+	for _, issue := range issues {
+		if err := mapActor(issue.ID, "created_by", &issue.CreatedBy); err != nil {
+			return err
+		}
+		if err := mapActor(issue.ID, "deleted_by", &issue.DeletedBy); err != nil {
+			return err
+		}
+		if err := mapActor(issue.ID, "actor", &issue.Actor); err != nil {
+			return err
+		}
+		for _, comment := range issue.Comments {
+			if comment != nil {
+				if err := mapActor(issue.ID, "comment author", &comment.Author); err != nil {
+					return err
+				}
+			}
+		}
+		for _, dep := range issue.Dependencies {
+			if dep != nil {
+				if err := mapActor(issue.ID, "dependency created_by", &dep.CreatedBy); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	for _, event := range opts.Events {
+		if event != nil {
+			if err := mapActor(event.IssueID, "event actor", &event.Actor); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestImportIssues_ActorMap(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+	newIssue := func(createdBy, commenter string) *types.Issue {
+		return &types.Issue{ID: "test-1", Title: "Migrated", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask,
+			CreatedAt: now, UpdatedAt: now, CreatedBy: createdBy,
+			Comments: []*types.Comment{{Author: commenter, Text: "From the old tracker", CreatedAt: now}}}
+	}
+	directory := map[string]string{"alice@example.com": "alice", "bob@example.com": "bob"}
+	actorMap := func(actor string) string { return directory[actor] }
+	comments := func(store *sqlite.SQLiteStorage) []*types.Comment {
+		t.Helper()
+		got, err := store.GetIssueComments(ctx, "test-1")
+		if err != nil {
+			t.Fatalf("GetIssueComments failed: %v", err)
+		}
+		return got
+	}
+
+	t.Run("mapped", func(t *testing.T) {
+		store := newTestStore(t)
+		promoted := "promoted"
+		events := []*types.Event{{IssueID: "test-1", EventType: types.EventUpdated, Actor: "bob@example.com", NewValue: &promoted, CreatedAt: now}}
+		opts := Options{ActorMap: actorMap, Events: events}
+		if _, err := ImportIssues(ctx, "", store, []*types.Issue{newIssue("alice@example.com", "bob@example.com")}, opts); err != nil {
+			t.Fatalf("ImportIssues failed: %v", err)
+		}
+		issue, err := store.GetIssue(ctx, "test-1")
+		if err != nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		if issue.CreatedBy != "alice" {
+			t.Errorf("CreatedBy = %q, want alice", issue.CreatedBy)
+		}
+		if got := comments(store); len(got) != 1 || got[0].Author != "bob" {
+			t.Errorf("comments = %+v, want one by bob", got)
+		}
+		history, err := store.GetEvents(ctx, "test-1", 0)
+		if err != nil {
+			t.Fatalf("GetEvents failed: %v", err)
+		}
+		var actors []string
+		for _, ev := range history {
+			if ev.EventType == types.EventUpdated {
+				actors = append(actors, ev.Actor)
+			}
+		}
+		if len(actors) != 1 || actors[0] != "bob" {
+			t.Errorf("imported event actors = %v, want [bob]", actors)
+		}
+	})
+
+	t.Run("pass-through", func(t *testing.T) {
+		store := newTestStore(t)
+		if _, err := ImportIssues(ctx, "", store, []*types.Issue{newIssue("carol@example.com", "alice@example.com")}, Options{ActorMap: actorMap}); err != nil {
+			t.Fatalf("ImportIssues failed: %v", err)
+		}
+		issue, err := store.GetIssue(ctx, "test-1")
+		if err != nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		if issue.CreatedBy != "carol@example.com" {
+			t.Errorf("unknown actor should pass through, CreatedBy = %q", issue.CreatedBy)
+		}
+		if got := comments(store); len(got) != 1 || got[0].Author != "alice" {
+			t.Errorf("comments = %+v, want one by alice", got)
+		}
+	})
+
+	t.Run("strict unmapped", func(t *testing.T) {
+		store := newTestStore(t)
+		opts := Options{ActorMap: actorMap, ActorMapStrict: true}
+		_, err := ImportIssues(ctx, "", store, []*types.Issue{newIssue("alice@example.com", "carol@example.com")}, opts)
+		if !errors.Is(err, ErrUnmappedActor) {
+			t.Fatalf("expected ErrUnmappedActor, got %v", err)
+		}
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) || validationErr.IssueID != "test-1" {
+			t.Errorf("expected error naming test-1, got %v", err)
+		}
+		if got, _ := store.GetIssue(ctx, "test-1"); got != nil {
+			t.Error("expected nothing to be imported")
+		}
+	})
+}
@@ -0,0 +1,161 @@
+package importer
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// ArchiveManifestName is the manifest's path within an export archive.
+const ArchiveManifestName = "manifest.json"
+
+// maxArchiveEntrySize bounds how much of any one archive entry is read into
+// memory, so a small archive that decompresses to a huge entry fails instead
+// of exhausting memory.
+var maxArchiveEntrySize int64 = 512 << 20
+
+// ErrArchiveCorrupt is returned (wrapped) by ImportFromArchive for an archive
+// that cannot be read or does not match its manifest.
+var ErrArchiveCorrupt = errors.New("corrupt export archive")
+
+// ImportFromArchive imports an export archive: a tar.gz holding
+// ArchiveManifestName (a types.ArchiveManifest) and the JSONL shards it lists.
+// The whole archive is read and every shard checked against its manifest
+// checksum and issue count before anything is written, so a corrupt archive
+// fails with ErrArchiveCorrupt and leaves the database alone. The shards are
+// then imported together as one ImportIssues call, parents before children
+// regardless of which shard they came from.
+func ImportFromArchive(ctx context.Context, dbPath string, store storage.Storage, archivePath string, opts Options) (*Result, error) {
+	issues, err := readArchive(archivePath, opts.MaxIssues)
+	if err != nil {
+		return nil, err
+	}
+	SortByDepth(issues)
+	return ImportIssues(ctx, dbPath, store, issues, opts)
+}
+
+// readArchive returns the issues of every shard in the archive at path,
+// after verifying them against the manifest. maxIssues is checked against the
+// manifest's counts before any shard is parsed.
+func readArchive(archivePath string, maxIssues int) ([]*types.Issue, error) {
+	f, err := os.Open(archivePath) // #nosec G304 -- caller-supplied import path
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrArchiveCorrupt, err)
+	}
+	defer func() { _ = gz.Close() }()
+
+	files := make(map[string][]byte)
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrArchiveCorrupt, err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := path.Clean(hdr.Name)
+		if _, dup := files[name]; dup {
+			return nil, fmt.Errorf("%w: %s appears more than once", ErrArchiveCorrupt, name)
+		}
+		data, err := io.ReadAll(io.LimitReader(tr, maxArchiveEntrySize+1))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrArchiveCorrupt, name, err)
+		}
+		if int64(len(data)) > maxArchiveEntrySize {
+			return nil, fmt.Errorf("%w: %s is larger than %d bytes", ErrArchiveCorrupt, name, maxArchiveEntrySize)
+		}
+		files[name] = data
+	}
+
+	raw, ok := files[ArchiveManifestName]
+	if !ok {
+		return nil, fmt.Errorf("%w: no %s", ErrArchiveCorrupt, ArchiveManifestName)
+	}
+	var manifest types.ArchiveManifest
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		return nil, fmt.Errorf("%w: invalid %s: %v", ErrArchiveCorrupt, ArchiveManifestName, err)
+	}
+	if manifest.FormatVersion < 1 || manifest.FormatVersion > types.ArchiveFormatVersion {
+		return nil, fmt.Errorf("%w: format version %d (this version reads up to %d)", ErrArchiveCorrupt, manifest.FormatVersion, types.ArchiveFormatVersion)
+	}
+
+	total := 0
+	for _, shard := range manifest.Shards {
+		total += shard.IssueCount
+	}
+	if err := checkMaxIssues(total, maxIssues); err != nil {
+		return nil, err
+	}
+
+	var issues []*types.Issue
+	seen := make(map[string]string) // issue ID -> shard
+	for _, shard := range manifest.Shards {
+		data, ok := files[path.Clean(shard.Name)]
+		if !ok {
+			return nil, fmt.Errorf("%w: shard %s is missing", ErrArchiveCorrupt, shard.Name)
+		}
+		sum := sha256.Sum256(data)
+		if got := hex.EncodeToString(sum[:]); got != shard.SHA256 {
+			return nil, fmt.Errorf("%w: shard %s hashes to %s, manifest says %s", ErrArchiveCorrupt, shard.Name, got, shard.SHA256)
+		}
+		shardIssues, err := parseShard(data)
+		if err != nil {
+			return nil, fmt.Errorf("%w: shard %s: %v", ErrArchiveCorrupt, shard.Name, err)
+		}
+		if len(shardIssues) != shard.IssueCount {
+			return nil, fmt.Errorf("%w: shard %s holds %d issues, manifest says %d", ErrArchiveCorrupt, shard.Name, len(shardIssues), shard.IssueCount)
+		}
+		for _, issue := range shardIssues {
+			if other, dup := seen[issue.ID]; dup {
+				return nil, fmt.Errorf("%w: issue %s is in both %s and %s", ErrArchiveCorrupt, issue.ID, other, shard.Name)
+			}
+			seen[issue.ID] = shard.Name
+		}
+		issues = append(issues, shardIssues...)
+	}
+	return issues, nil
+}
+
+func parseShard(data []byte) ([]*types.Issue, error) {
+	var issues []*types.Issue
+	scanner := utils.NewJSONLScanner(bytes.NewReader(data), 0)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var issue types.Issue
+		if err := json.Unmarshal(line, &issue); err != nil {
+			return nil, fmt.Errorf("line %d: %w", scanner.Line(), err)
+		}
+		issue.SetDefaults()
+		issues = append(issues, &issue)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return issues, nil
+}
@@ -0,0 +1,146 @@
+package importer
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// writeTestArchive writes shards (name -> issues) and a manifest for them to
+// a tar.gz, letting tamper edit the manifest before it is written.
+func writeTestArchive(t *testing.T, shards map[string][]*types.Issue, order []string, tamper func(*types.ArchiveManifest)) string {
+	t.Helper()
+	manifest := types.ArchiveManifest{FormatVersion: types.ArchiveFormatVersion, ExportedAt: time.Now()}
+	contents := make(map[string][]byte)
+	for _, name := range order {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		for _, issue := range shards[name] {
+			if err := enc.Encode(issue); err != nil {
+				t.Fatalf("encode: %v", err)
+			}
+		}
+		sum := sha256.Sum256(buf.Bytes())
+		manifest.Shards = append(manifest.Shards, types.ArchiveShard{Name: name, SHA256: hex.EncodeToString(sum[:]), IssueCount: len(shards[name])})
+		contents[name] = buf.Bytes()
+	}
+	if tamper != nil {
+		tamper(&manifest)
+	}
+	manifestData, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatalf("marshal manifest: %v", err)
+	}
+
+	archivePath := filepath.Join(t.TempDir(), "export.tar.gz")
+	f, err := os.Create(archivePath)
+	if err != nil {
+		t.Fatalf("create archive: %v", err)
+	}
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	add := func(name string, data []byte) {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(data)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatalf("tar header: %v", err)
+		}
+		if _, err := tw.Write(data); err != nil {
+			t.Fatalf("tar write: %v", err)
+		}
+	}
+	add(ArchiveManifestName, manifestData)
+	for _, name := range order {
+		add(name, contents[name])
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("tar close: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("gzip close: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	return archivePath
+}
+
+func TestImportFromArchive(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	issue := func(id, title string) *types.Issue {
+		return &types.Issue{ID: id, Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now}
+	}
+	// The child's shard comes first, so the import must reorder across shards
+	shards := map[string][]*types.Issue{
+		"shards/children.jsonl": {issue("test-a.1", "Child"), issue("test-a.1.1", "Grandchild")},
+		"shards/roots.jsonl":    {issue("test-a", "Parent"), issue("test-b", "Other")},
+	}
+	order := []string{"shards/children.jsonl", "shards/roots.jsonl"}
+	t.Run("imports all shards", func(t *testing.T) {
+		store := newTestStore(t)
+		archivePath := writeTestArchive(t, shards, order, nil)
+		result, err := ImportFromArchive(ctx, "", store, archivePath, Options{OrphanHandling: OrphanStrict})
+		if err != nil {
+			t.Fatalf("ImportFromArchive failed: %v", err)
+		}
+		if result.Created != 4 {
+			t.Errorf("Created = %d, want 4", result.Created)
+		}
+		for _, id := range []string{"test-a", "test-a.1", "test-a.1.1", "test-b"} {
+			if got, _ := store.GetIssue(ctx, id); got == nil {
+				t.Errorf("expected %s to be imported", id)
+			}
+		}
+	})
+
+	corrupt := map[string]func(*types.ArchiveManifest){
+		"checksum mismatch": func(m *types.ArchiveManifest) { m.Shards[1].SHA256 = hex.EncodeToString(make([]byte, 32)) },
+		"missing shard": func(m *types.ArchiveManifest) {
+			m.Shards = append(m.Shards, types.ArchiveShard{Name: "shards/gone.jsonl"})
+		},
+		"wrong count":   func(m *types.ArchiveManifest) { m.Shards[0].IssueCount = 3 },
+		"future format": func(m *types.ArchiveManifest) { m.FormatVersion = types.ArchiveFormatVersion + 1 },
+	}
+	for name, tamper := range corrupt {
+		t.Run(name, func(t *testing.T) {
+			store := newTestStore(t)
+			archivePath := writeTestArchive(t, shards, order, tamper)
+			if _, err := ImportFromArchive(ctx, "", store, archivePath, Options{}); !errors.Is(err, ErrArchiveCorrupt) {
+				t.Fatalf("expected ErrArchiveCorrupt, got %v", err)
+			}
+			if got, _ := store.GetIssue(ctx, "test-b"); got != nil {
+				t.Error("expected nothing to be imported from a corrupt archive")
+			}
+		})
+	}
+
+	t.Run("oversized entry", func(t *testing.T) {
+		oldSize := maxArchiveEntrySize
+		maxArchiveEntrySize = 64
+		defer func() { maxArchiveEntrySize = oldSize }()
+		archivePath := writeTestArchive(t, shards, order, nil)
+		if _, err := ImportFromArchive(ctx, "", newTestStore(t), archivePath, Options{}); !errors.Is(err, ErrArchiveCorrupt) {
+			t.Fatalf("expected ErrArchiveCorrupt, got %v", err)
+		}
+	})
+
+	t.Run("not gzip", func(t *testing.T) {
+		archivePath := filepath.Join(t.TempDir(), "export.tar.gz")
+		if err := os.WriteFile(archivePath, []byte("not an archive"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ImportFromArchive(ctx, "", newTestStore(t), archivePath, Options{}); !errors.Is(err, ErrArchiveCorrupt) {
+			t.Fatalf("expected ErrArchiveCorrupt, got %v", err)
+		}
+	})
+}
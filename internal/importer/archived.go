@@ -0,0 +1,49 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// skipArchivedIssues leaves out incoming issues that the storage.ArchiveStore
+// capability of store holds in its archive, unless Options.RestoreArchived is
+// set, so re-importing an export taken before archival does not bring them
+// back into the active table. Backends without an archive have nothing to skip.
+func skipArchivedIssues(ctx context.Context, store interface{}, issues []*types.Issue, opts Options, result *Result) ([]*types.Issue, error) {
+	if opts.RestoreArchived || len(issues) == 0 {
+		return issues, nil
+	}
+	archiveStore, ok := store.(storage.ArchiveStore)
+	if !ok {
+		return issues, nil
+	}
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	archivedIDs, err := archiveStore.FilterArchivedIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check the archive: %w", err)
+	}
+	if len(archivedIDs) == 0 {
+		return issues, nil
+	}
+	archived := make(map[string]bool, len(archivedIDs))
+	for _, id := range archivedIDs {
+		archived[id] = true
+	}
+
+	kept := issues[:0:0]
+	for _, issue := range issues {
+		if archived[issue.ID] {
+			result.Archived = append(result.Archived, issue.ID)
+			result.note(ImportEventSkipped, issue.ID)
+			continue
+		}
+		kept = append(kept, issue)
+	}
+	return kept, nil
+}
@@ -0,0 +1,51 @@
+package importer
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestImportIssues_ArchivedIssues(t *testing.T) {
+	ctx := context.Background()
+	longAgo := time.Now().AddDate(0, 0, -100).Truncate(time.Second)
+	input := func() []*types.Issue {
+		closedAt := longAgo
+		return []*types.Issue{
+			{ID: "test-1", Title: "Shipped long ago", Status: types.StatusClosed, ClosedAt: &closedAt, Priority: 2, IssueType: types.TypeTask, CreatedAt: longAgo, UpdatedAt: longAgo},
+			{ID: "test-2", Title: "Current", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: longAgo, UpdatedAt: longAgo},
+		}
+	}
+
+	store := newTestStore(t)
+	if _, err := ImportIssues(ctx, "", store, input(), Options{}); err != nil {
+		t.Fatalf("ImportIssues failed: %v", err)
+	}
+	if archived, err := store.ArchiveClosedIssues(ctx, time.Now().AddDate(0, 0, -30)); err != nil || strings.Join(archived, ",") != "test-1" {
+		t.Fatalf("ArchiveClosedIssues = %v, %v; want test-1", archived, err)
+	}
+
+	// By default the import leaves archived issues in the archive
+	result, err := ImportIssues(ctx, "", store, input(), Options{})
+	if err != nil {
+		t.Fatalf("ImportIssues failed: %v", err)
+	}
+	if strings.Join(result.Archived, ",") != "test-1" || result.Skipped != 1 || result.Created != 0 {
+		t.Errorf("result = %+v, want test-1 skipped as archived and nothing created", result)
+	}
+	if got, _ := store.GetIssue(ctx, "test-1"); got != nil {
+		t.Error("an archived issue was brought back into the active table")
+	}
+
+	// RestoreArchived recreates it
+	result, err = ImportIssues(ctx, "", store, input(), Options{RestoreArchived: true})
+	if err != nil {
+		t.Fatalf("ImportIssues failed: %v", err)
+	}
+	if result.Created != 1 || len(result.Archived) != 0 {
+		t.Errorf("result = %+v, want test-1 recreated", result)
+	}
+}
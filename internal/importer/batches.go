@@ -0,0 +1,269 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// importCursorKeyPrefix namespaces persisted batch import cursors in config.
+// The full key is importCursorKeyPrefix + Options.IdempotencyKey.
+const importCursorKeyPrefix = "import.cursor."
+
+// importBatches imports issues in transactions of opts.BatchSize issues each.
+//
+// Issues are processed in depth order (parents before children), which is
+// deterministic for a given input, so a re-run after a crash sees the same
+// batches. When opts.IdempotencyKey is set, the number of committed issues is
+// stored in config in the same transaction as each batch, and a re-run with the
+// same key starts after the last committed batch. The cursor also records the
+// ID and content hash of the last committed issue, and a re-run whose input
+// does not have that issue at that position is refused rather than skipping
+// issues it never imported. Parents from skipped batches
+// are already in the database, so orphan handling in later batches still finds
+// them.
+//
+// With opts.DeferOrphans, issues are instead processed in input order, as they
+// would arrive from a stream. A hierarchical child whose parent is neither in
+// the database nor in its batch is held back and retried in the final
+// transaction, after every other issue has landed, so opts.OrphanHandling only
+// applies to children whose parent never arrived. The cursor then stops at the
+// first batch that held anything back, so a resumed run retries those children.
+//
+// Dependencies may reference issues in any batch, so they are imported in a
+// final transaction once all issues exist. That step is idempotent and is
+// simply repeated if the import crashes before the cursor is cleared.
+func importBatches(ctx context.Context, store storage.Storage, issues []*types.Issue, opts Options, result *Result) error {
+	issues, err := dedupeImportBatch(issues, opts, result)
+	if err != nil {
+		return err
+	}
+	if !opts.DeferOrphans {
+		SortByDepth(issues)
+	}
+
+	cursorKey := ""
+	start := 0
+	var cursors []string
+	if opts.IdempotencyKey != "" {
+		cursorKey = importCursorKeyPrefix + opts.IdempotencyKey
+		// Taken before importing, since renames rewrite IDs in place
+		cursors = make([]string, len(issues)+1)
+		for i := range cursors {
+			cursors[i] = importCursor(issues, i)
+		}
+		value, err := store.GetConfig(ctx, cursorKey)
+		if err != nil {
+			return fmt.Errorf("failed to read import cursor: %w", err)
+		}
+		if value != "" {
+			committed, err := parseImportCursor(value)
+			if err != nil {
+				return fmt.Errorf("invalid import cursor %q for key %s: %w", value, opts.IdempotencyKey, err)
+			}
+			if committed > len(issues) || cursors[committed] != value {
+				return fmt.Errorf("import cursor %q for key %s does not match the input: it changed since the interrupted run; "+
+					"re-run with a new idempotency key or clear config key %s", value, opts.IdempotencyKey, cursorKey)
+			}
+			start = committed
+			result.Resumed = start
+		}
+	}
+
+	var deferred []*types.Issue
+	for offset := start; offset < len(issues); offset += opts.BatchSize {
+		end := min(offset+opts.BatchSize, len(issues))
+		batch := issues[offset:end]
+		var waiting []*types.Issue
+		if err := store.RunInTransaction(ctx, func(tx storage.Transaction) error {
+			ready := batch
+			if opts.DeferOrphans {
+				var err error
+				if ready, waiting, err = splitDeferredOrphans(ctx, tx, batch); err != nil {
+					return err
+				}
+			}
+			if err := importIssueContentTx(ctx, tx, store, ready, opts, result); err != nil {
+				return err
+			}
+			if cursorKey == "" || len(deferred) > 0 {
+				return nil
+			}
+			if len(waiting) > 0 {
+				// Resume from this batch so held-back children are retried
+				return tx.SetConfig(ctx, cursorKey, cursors[offset])
+			}
+			return tx.SetConfig(ctx, cursorKey, cursors[end])
+		}); err != nil {
+			return err
+		}
+		deferred = append(deferred, waiting...)
+	}
+
+	if err := store.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		if len(deferred) > 0 {
+			SortByDepth(deferred)
+			if err := importIssueContentTx(ctx, tx, store, deferred, opts, result); err != nil {
+				return err
+			}
+		}
+		if err := importDependenciesTx(ctx, tx, issues, opts, result); err != nil {
+			return err
+		}
+		edges, err := relationshipEdges(ctx, tx.GetIssue, opts, result)
+		if err != nil {
+			return err
+		}
+		if err := importDependenciesTx(ctx, tx, edges, opts, result); err != nil {
+			return err
+		}
+		if err := dateCreatedEvents(ctx, tx, opts, result); err != nil {
+			return err
+		}
+		if err := importEventHistory(ctx, tx, tx.GetIssue, opts, result); err != nil {
+			return err
+		}
+		if err := importTemplates(ctx, tx, opts, result); err != nil {
+			return err
+		}
+		if err := importMilestones(ctx, tx, opts, result); err != nil {
+			return err
+		}
+		return importIDAliasesTx(ctx, tx, result.IDMapping)
+	}); err != nil {
+		return err
+	}
+
+	if cursorKey != "" {
+		if err := store.DeleteConfig(ctx, cursorKey); err != nil {
+			return fmt.Errorf("failed to clear import cursor: %w", err)
+		}
+	}
+	return nil
+}
+
+// importCursor encodes the resume cursor after the first committed issues:
+// the count, then the content hash and ID of the last of them
+// ("6:3f9a...:bd-a1b2"), or just "0" when nothing is committed.
+func importCursor(issues []*types.Issue, committed int) string {
+	if committed == 0 {
+		return "0"
+	}
+	last := issues[committed-1]
+	return strconv.Itoa(committed) + ":" + last.ContentHash + ":" + last.ID
+}
+
+// parseImportCursor returns the committed count of a cursor written by
+// importCursor.
+func parseImportCursor(value string) (int, error) {
+	parts := strings.SplitN(value, ":", 3)
+	committed, err := strconv.Atoi(parts[0])
+	if err != nil || committed < 0 {
+		return 0, fmt.Errorf("bad issue count %q", parts[0])
+	}
+	if committed > 0 && len(parts) != 3 {
+		return 0, fmt.Errorf("missing last issue fingerprint")
+	}
+	return committed, nil
+}
+
+// importIssueContentTx upserts issues with their labels, comments, watchers
+// and checklists, then checks per-prefix quotas and verifies the issues it
+// created at opts.Verify. A batch that would cross a quota rolls back; earlier
+// batches stay committed.
+func importIssueContentTx(ctx context.Context, tx storage.Transaction, store storage.Storage, issues []*types.Issue, opts Options, result *Result) error {
+	created := len(result.created)
+	registered, err := registerCustomTypes(ctx, tx, issues, opts, result)
+	if err != nil {
+		return err
+	}
+	if err := upsertIssuesTx(ctx, tx, store, issues, opts, result); err != nil {
+		return err
+	}
+	issues = result.withoutRejected(issues)
+	if err := checkIDSuffixes(ctx, tx, result.created[created:], opts); err != nil {
+		return err
+	}
+	if err := recordTypeRegistrations(ctx, tx, tx.GetIssue, issues, registered, result); err != nil {
+		return err
+	}
+	if err := importLabelsTx(ctx, tx, issues, opts); err != nil {
+		return err
+	}
+	if err := importCommentsTx(ctx, tx, issues, opts); err != nil {
+		return err
+	}
+	if err := importWatchers(ctx, tx, issues, opts); err != nil {
+		return err
+	}
+	if err := importChecklists(ctx, tx, issues, opts, result); err != nil {
+		return err
+	}
+	if err := checkQuotas(ctx, tx, result.created[created:]); err != nil {
+		return err
+	}
+	return verifyCreatedTx(ctx, tx, result.created[created:], opts.Verify, opts.hashOptions)
+}
+
+// splitDeferredOrphans separates the hierarchical children in batch whose
+// parent is neither in the database nor (ready) in batch. Children of a held
+// back parent are held back too.
+func splitDeferredOrphans(ctx context.Context, tx storage.Transaction, batch []*types.Issue) (ready, waiting []*types.Issue, err error) {
+	sorted := make([]*types.Issue, len(batch))
+	copy(sorted, batch)
+	SortByDepth(sorted)
+
+	present := make(map[string]bool, len(batch))
+	for _, issue := range sorted {
+		if isHier, parentID := isHierarchicalID(issue.ID); isHier && !present[parentID] {
+			parent, err := tx.GetIssue(ctx, parentID)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to look up parent %s: %w", parentID, err)
+			}
+			if parent == nil {
+				waiting = append(waiting, issue)
+				continue
+			}
+		}
+		present[issue.ID] = true
+		ready = append(ready, issue)
+	}
+	return ready, waiting, nil
+}
+
+// dedupeImportBatch drops repeated content hashes and IDs, keeping the first
+// occurrence in input order. A single-transaction import does this while
+// upserting; batching has to do it up front, since a duplicate in a later batch
+// would otherwise match the committed copy and be treated as a rename. Hash
+// collisions are resolved by opts.HashCollisions.
+func dedupeImportBatch(issues []*types.Issue, opts Options, result *Result) ([]*types.Issue, error) {
+	seenHashes := make(map[string]*types.Issue, len(issues))
+	seenIDs := make(map[string]bool, len(issues))
+	deduped := make([]*types.Issue, 0, len(issues))
+	for _, issue := range issues {
+		if seen := seenHashes[issue.ContentHash]; seen != nil {
+			dup, err := isHashDuplicate(seen, issue, opts, result)
+			if err != nil {
+				return nil, err
+			}
+			if dup {
+				result.note(ImportEventSkipped, issue.ID)
+				continue
+			}
+		}
+		if seenIDs[issue.ID] {
+			result.note(ImportEventSkipped, issue.ID)
+			continue
+		}
+		if seenHashes[issue.ContentHash] == nil {
+			seenHashes[issue.ContentHash] = issue
+		}
+		seenIDs[issue.ID] = true
+		deduped = append(deduped, issue)
+	}
+	return deduped, nil
+}
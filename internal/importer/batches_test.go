@@ -0,0 +1,182 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// crashingStore fails every transaction after the first commits succeed,
+// simulating a connection lost partway through a long import.
+type crashingStore struct {
+	storage.Storage
+	commits int
+}
+
+func (s *crashingStore) RunInTransaction(ctx context.Context, fn func(tx storage.Transaction) error) error {
+	if s.commits == 0 {
+		return errors.New("connection lost")
+	}
+	s.commits--
+	return s.Storage.RunInTransaction(ctx, fn)
+}
+
+func TestImportIssues_ResumesAfterCrash(t *testing.T) {
+	ctx := context.Background()
+
+	store := newTestStore(t)
+
+	now := time.Now()
+	newIssue := func(id string) *types.Issue {
+		return &types.Issue{
+			ID:        id,
+			Title:     "Issue " + id,
+			Status:    types.StatusOpen,
+			Priority:  2,
+			IssueType: types.TypeTask,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+	}
+	// Depth order gives batches [a1 a2] [a3 a4] [p1 p1.1] [p1.2]
+	inputs := func() []*types.Issue {
+		issues := []*types.Issue{
+			newIssue("test-p1.2"), newIssue("test-a1"), newIssue("test-p1.1"),
+			newIssue("test-a3"), newIssue("test-p1"), newIssue("test-a2"), newIssue("test-a4"),
+		}
+		// a1 depends on the last batch, so dependencies must wait for all issues
+		issues[1].Dependencies = []*types.Dependency{{IssueID: "test-a1", DependsOnID: "test-p1.2", Type: types.DepBlocks}}
+		return issues
+	}
+	opts := Options{BatchSize: 2, IdempotencyKey: "nightly-sync", OrphanHandling: OrphanStrict}
+
+	if _, err := ImportIssues(ctx, store.Path(), &crashingStore{Storage: store, commits: 3}, inputs(), opts); err == nil {
+		t.Fatal("expected the import to fail after 3 batches")
+	}
+
+	cursor, err := store.GetConfig(ctx, importCursorKeyPrefix+"nightly-sync")
+	if err != nil {
+		t.Fatalf("GetConfig failed: %v", err)
+	}
+	if !strings.HasPrefix(cursor, "6:") || !strings.HasSuffix(cursor, ":test-p1.1") {
+		t.Fatalf("expected cursor at 6 committed issues ending with test-p1.1, got %q", cursor)
+	}
+	if issue, _ := store.GetIssue(ctx, "test-p1.2"); issue != nil {
+		t.Fatal("issue from the uncommitted batch should not exist")
+	}
+
+	// An input that no longer matches the committed issues is not resumed
+	changed := inputs()
+	changed[2].Title = "Edited since the crash"
+	if _, err := ImportIssues(ctx, store.Path(), store, changed, opts); err == nil || !strings.Contains(err.Error(), "does not match") {
+		t.Fatalf("expected resuming with changed input to be refused, got %v", err)
+	}
+	shorter := inputs()[:5]
+	if _, err := ImportIssues(ctx, store.Path(), store, shorter, opts); err == nil {
+		t.Fatal("expected resuming with fewer issues than committed to be refused")
+	}
+	if got, _ := store.GetConfig(ctx, importCursorKeyPrefix+"nightly-sync"); got != cursor {
+		t.Fatalf("expected refused resumes to keep the cursor, got %q", got)
+	}
+
+	// Resume: only the last batch is left, and its parent came from the crashed run
+	result, err := ImportIssues(ctx, store.Path(), store, inputs(), opts)
+	if err != nil {
+		t.Fatalf("Resumed import failed: %v", err)
+	}
+	if result.Resumed != 6 || result.Created != 1 {
+		t.Errorf("expected 6 resumed and 1 created, got resumed=%d created=%d", result.Resumed, result.Created)
+	}
+
+	for i := 1; i <= 4; i++ {
+		if issue, _ := store.GetIssue(ctx, fmt.Sprintf("test-a%d", i)); issue == nil {
+			t.Errorf("expected test-a%d to exist", i)
+		}
+	}
+	for _, id := range []string{"test-p1", "test-p1.1", "test-p1.2"} {
+		if issue, _ := store.GetIssue(ctx, id); issue == nil {
+			t.Errorf("expected %s to exist", id)
+		}
+	}
+
+	deps, err := store.GetDependencyRecords(ctx, "test-a1")
+	if err != nil {
+		t.Fatalf("GetDependencyRecords failed: %v", err)
+	}
+	if len(deps) != 1 || deps[0].DependsOnID != "test-p1.2" {
+		t.Errorf("expected test-a1 to depend on test-p1.2, got %v", deps)
+	}
+
+	if cursor, _ := store.GetConfig(ctx, importCursorKeyPrefix+"nightly-sync"); cursor != "" {
+		t.Errorf("expected cursor to be cleared after completion, got %q", cursor)
+	}
+}
+
+func TestImportIssues_DeferOrphans(t *testing.T) {
+	ctx := context.Background()
+
+	now := time.Now()
+	newIssue := func(id string) *types.Issue {
+		return &types.Issue{
+			ID:        id,
+			Title:     "Issue " + id,
+			Status:    types.StatusOpen,
+			Priority:  2,
+			IssueType: types.TypeTask,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+	}
+	// Children arrive batches ahead of their parents; test-x never arrives
+	stream := func() []*types.Issue {
+		return []*types.Issue{
+			newIssue("test-p1.1.1"), newIssue("test-x.1"),
+			newIssue("test-p1.1"), newIssue("test-a"),
+			newIssue("test-p1"),
+		}
+	}
+	t.Run("skip applies only to unresolved children", func(t *testing.T) {
+		store := newTestStore(t)
+		opts := Options{BatchSize: 2, DeferOrphans: true, OrphanHandling: OrphanSkip}
+		result, err := ImportIssues(ctx, store.Path(), store, stream(), opts)
+		if err != nil {
+			t.Fatalf("Import failed: %v", err)
+		}
+		if result.Created != 4 || result.Skipped != 1 {
+			t.Errorf("expected 4 created and 1 skipped, got created=%d skipped=%d", result.Created, result.Skipped)
+		}
+		for _, id := range []string{"test-p1", "test-p1.1", "test-p1.1.1", "test-a"} {
+			if issue, _ := store.GetIssue(ctx, id); issue == nil {
+				t.Errorf("expected %s to exist", id)
+			}
+		}
+		if issue, _ := store.GetIssue(ctx, "test-x.1"); issue != nil {
+			t.Error("orphan with no parent in the stream should be skipped")
+		}
+	})
+
+	t.Run("strict fails only on unresolved children", func(t *testing.T) {
+		store := newTestStore(t)
+		opts := Options{BatchSize: 2, DeferOrphans: true, OrphanHandling: OrphanStrict}
+		if _, err := ImportIssues(ctx, store.Path(), store, stream()[2:], opts); err != nil {
+			t.Fatalf("Import with late parents failed: %v", err)
+		}
+		_, err := ImportIssues(ctx, store.Path(), store, stream()[:2], opts)
+		if err == nil || !strings.Contains(err.Error(), "test-x") {
+			t.Errorf("expected strict failure for missing parent test-x, got %v", err)
+		}
+	})
+
+	t.Run("requires batch size", func(t *testing.T) {
+		store := newTestStore(t)
+		if _, err := ImportIssues(ctx, store.Path(), store, stream(), Options{DeferOrphans: true}); err == nil {
+			t.Error("expected DeferOrphans without BatchSize to be rejected")
+		}
+	})
+}
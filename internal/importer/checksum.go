@@ -0,0 +1,61 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+var (
+	// ErrChecksumMissing is returned by VerifyExportChecksum for an export
+	// whose last line is not a types.ExportChecksum.
+	ErrChecksumMissing = errors.New("export checksum missing")
+
+	// ErrChecksumMismatch is returned (wrapped) when the content does not hash
+	// to the checksum the export carries.
+	ErrChecksumMismatch = errors.New("export checksum mismatch")
+)
+
+// VerifyExportChecksum reads an export written by sqlite.ExportWithChecksum
+// and checks that every byte before the trailing checksum line hashes to it.
+// It streams, holding only one line at a time, so callers verify first and
+// then parse the export again (or tee it) to import.
+func VerifyExportChecksum(r io.Reader) error {
+	h := sha256.New()
+	br := bufio.NewReader(r)
+	var last []byte
+	for {
+		line, err := br.ReadBytes('\n')
+		if len(line) > 0 {
+			if last != nil {
+				h.Write(last)
+			}
+			last = line
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read export: %w", err)
+		}
+	}
+
+	var trailer types.ExportChecksum
+	if last == nil || json.Unmarshal(bytes.TrimSpace(last), &trailer) != nil || trailer.Checksum == "" {
+		return ErrChecksumMissing
+	}
+	if trailer.Algorithm != "sha256" {
+		return fmt.Errorf("%w: unsupported algorithm %q", ErrChecksumMismatch, trailer.Algorithm)
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); got != trailer.Checksum {
+		return fmt.Errorf("%w: content hashes to %s, export says %s", ErrChecksumMismatch, got, trailer.Checksum)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestVerifyExportChecksum(t *testing.T) {
+	ctx := context.Background()
+	export := func(store *sqlite.SQLiteStorage) ([]byte, string) {
+		t.Helper()
+		var buf bytes.Buffer
+		sum, err := store.ExportWithChecksum(ctx, &buf, types.IssueFilter{})
+		if err != nil {
+			t.Fatalf("ExportWithChecksum failed: %v", err)
+		}
+		return buf.Bytes(), sum
+	}
+
+	created := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	source := newTestStore(t)
+	var issues []*types.Issue
+	for _, id := range []string{"test-2", "test-1"} {
+		issues = append(issues, &types.Issue{ID: id, Title: "Issue " + id, Status: types.StatusOpen, Priority: 2,
+			IssueType: types.TypeTask, Labels: []string{"b", "a"}, CreatedAt: created, UpdatedAt: created})
+	}
+	if _, err := ImportIssues(ctx, "", source, issues, Options{}); err != nil {
+		t.Fatalf("ImportIssues failed: %v", err)
+	}
+	data, sum := export(source)
+
+	if err := VerifyExportChecksum(bytes.NewReader(data)); err != nil {
+		t.Fatalf("expected export to verify, got %v", err)
+	}
+
+	// The same export imported elsewhere exports identically
+	var restored []*types.Issue
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var issue types.Issue
+		if err := json.Unmarshal(scanner.Bytes(), &issue); err != nil {
+			t.Fatalf("failed to parse export: %v", err)
+		}
+		if issue.ID != "" {
+			restored = append(restored, &issue)
+		}
+	}
+	target := newTestStore(t)
+	if _, err := ImportIssues(ctx, "", target, restored, Options{PreserveRowIDs: true}); err != nil {
+		t.Fatalf("re-import failed: %v", err)
+	}
+	if _, again := export(target); again != sum {
+		t.Errorf("expected identical data to export with identical checksum, got %s and %s", sum, again)
+	}
+
+	tampered := bytes.Replace(data, []byte("Issue test-1"), []byte("Issue test-X"), 1)
+	if err := VerifyExportChecksum(bytes.NewReader(tampered)); !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("expected ErrChecksumMismatch for tampered export, got %v", err)
+	}
+
+	body := data[:bytes.LastIndexByte(data[:len(data)-1], '\n')+1]
+	if err := VerifyExportChecksum(bytes.NewReader(body)); !errors.Is(err, ErrChecksumMissing) {
+		t.Errorf("expected ErrChecksumMissing without trailer, got %v", err)
+	}
+	if err := VerifyExportChecksum(strings.NewReader("")); !errors.Is(err, ErrChecksumMissing) {
+		t.Errorf("expected ErrChecksumMissing for empty input, got %v", err)
+	}
+}
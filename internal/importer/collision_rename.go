@@ -0,0 +1,85 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// renameCollidingIDsTx implements Options.RenameOnCollision within tx, before
+// any issue is written: every incoming issue whose ID is held by an existing
+// issue (tombstones included) with different content is given a fresh ID under
+// its own prefix, and references to the old ID anywhere in the import follow
+// it. The renames land in result.IDMapping, so importIDAliasesTx records each
+// old ID as an alias of the new one and imported events and relationships are
+// attached to the renamed issue. Incoming issues the upsert would match to an
+// existing one anyway (same content hash, or same external_ref) keep their IDs.
+func renameCollidingIDsTx(ctx context.Context, tx storage.Transaction, issues []*types.Issue, opts Options, result *Result) error {
+	if !opts.RenameOnCollision || len(issues) == 0 {
+		return nil
+	}
+	gen, ok := tx.(storage.IssueIDGenerator)
+	if !ok {
+		return fmt.Errorf("storage backend does not support RenameOnCollision")
+	}
+
+	dbIssues, err := tx.SearchIssues(ctx, "", types.IssueFilter{IncludeTombstones: true})
+	if err != nil {
+		return fmt.Errorf("failed to get DB issues: %w", err)
+	}
+	dbByID := buildIDMap(dbIssues)
+	dbByHash := buildHashMap(dbIssues)
+	dbRefs := make(map[string]bool)
+	for _, issue := range dbIssues {
+		if issue.ExternalRef != nil && *issue.ExternalRef != "" {
+			dbRefs[*issue.ExternalRef] = true
+		}
+	}
+	taken := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		taken[issue.ID] = true
+	}
+	sep, _ := tx.GetConfig(ctx, storage.IDSeparatorConfigKey)
+
+	idMapping := make(map[string]string)
+	for _, incoming := range issues {
+		if dbByID[incoming.ID] == nil || idMapping[incoming.ID] != "" {
+			continue
+		}
+		if dbByHash[incoming.ContentHash] != nil {
+			continue
+		}
+		if incoming.ExternalRef != nil && dbRefs[*incoming.ExternalRef] {
+			continue
+		}
+		prefix := utils.ExtractIssuePrefixWithSeparator(incoming.ID, sep)
+		if prefix == "" {
+			return &ValidationError{IssueID: incoming.ID, Err: fmt.Errorf("cannot rename colliding ID: no prefix")}
+		}
+		newID, err := gen.GenerateIssueID(ctx, prefix, incoming, "import")
+		if err != nil {
+			return fmt.Errorf("failed to generate ID for colliding issue %s: %w", incoming.ID, err)
+		}
+		if taken[newID] {
+			return fmt.Errorf("generated ID %s for colliding issue %s is already used by the import", newID, incoming.ID)
+		}
+		taken[newID] = true
+		idMapping[incoming.ID] = newID
+	}
+	if len(idMapping) == 0 {
+		return nil
+	}
+
+	applyIDMapping(issues, idMapping)
+	// Rewritten references change the content, so rehash
+	for _, issue := range issues {
+		issue.ContentHash = issueContentHash(issue, opts)
+	}
+	for oldID, newID := range idMapping {
+		result.IDMapping[oldID] = newID
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package importer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestImportIssues_RenameOnCollision(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	now := time.Now().Truncate(time.Second)
+	newIssue := func(id, title, description string) *types.Issue {
+		return &types.Issue{ID: id, Title: title, Description: description, Status: types.StatusOpen, Priority: 2,
+			IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now}
+	}
+	local := newIssue("test-1", "Local issue", "")
+	kept := newIssue("test-2", "Shared issue", "")
+	if _, err := ImportIssues(ctx, "", store, []*types.Issue{local, kept}, Options{}); err != nil {
+		t.Fatalf("initial import failed: %v", err)
+	}
+
+	later := now.Add(time.Minute)
+	remote := newIssue("test-1", "Remote issue", "")
+	remote.UpdatedAt = later
+	follower := newIssue("test-3", "Follow-up", "Continues test-1")
+	follower.Dependencies = []*types.Dependency{{IssueID: "test-3", DependsOnID: "test-1", Type: types.DepBlocks, CreatedAt: now}}
+	result, err := ImportIssues(ctx, "", store, []*types.Issue{remote, newIssue("test-2", "Shared issue", ""), follower}, Options{RenameOnCollision: true})
+	if err != nil {
+		t.Fatalf("ImportIssues failed: %v", err)
+	}
+
+	newID := result.IDMapping["test-1"]
+	if newID == "" || newID == "test-1" || len(result.IDMapping) != 1 {
+		t.Fatalf("IDMapping = %v, want only test-1 renamed", result.IDMapping)
+	}
+	if got, err := store.GetIssue(ctx, "test-1"); err != nil || got == nil || got.Title != "Local issue" {
+		t.Errorf("existing test-1 = %+v, %v; want it untouched", got, err)
+	}
+	if got, err := store.GetIssue(ctx, newID); err != nil || got == nil || got.Title != "Remote issue" {
+		t.Errorf("renamed %s = %+v, %v; want the incoming issue", newID, got, err)
+	}
+	if got, err := store.ResolveAlias(ctx, "test-1"); err != nil || got != newID {
+		t.Errorf("ResolveAlias(test-1) = %q, %v; want %s", got, err, newID)
+	}
+
+	// References within the import follow the rename
+	got, err := store.GetIssue(ctx, "test-3")
+	if err != nil || got == nil {
+		t.Fatalf("GetIssue(test-3) = %v, %v", got, err)
+	}
+	if got.Description != "Continues "+newID {
+		t.Errorf("description = %q, want the reference rewritten to %s", got.Description, newID)
+	}
+	deps, err := store.GetDependencyRecords(ctx, "test-3")
+	if err != nil {
+		t.Fatalf("GetDependencyRecords failed: %v", err)
+	}
+	if len(deps) != 1 || deps[0].DependsOnID != newID {
+		t.Errorf("dependencies = %+v, want one on %s", deps, newID)
+	}
+	if result.Created != 2 || result.Unchanged != 1 {
+		t.Errorf("created %d, unchanged %d; want 2 and 1", result.Created, result.Unchanged)
+	}
+}
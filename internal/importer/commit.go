@@ -0,0 +1,70 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// ImportStats are the final counts of an import, as passed to Options.OnCommit
+// and posted to Options.Webhook.
+type ImportStats struct {
+	Created   int `json:"created"`
+	Updated   int `json:"updated"`
+	Unchanged int `json:"unchanged"`
+	Skipped   int `json:"skipped"`
+	Deleted   int `json:"deleted"`
+}
+
+// CommitHook is called with the final counts of an import inside its
+// transaction, just before commit (see Options.OnCommit). Returning an error
+// rolls the import back, which gives outbox-style "exactly once with the
+// commit" delivery for external notifications.
+type CommitHook func(ctx context.Context, stats ImportStats) error
+
+// ImportAssertion checks project-specific invariants against the imported
+// data through tx, after every write of the import and before OnCommit (see
+// Options.PostImportAssert). Returning an error rolls the import back.
+type ImportAssertion func(ctx context.Context, tx storage.Transaction) error
+
+// Stats returns the counts of r.
+func (r *Result) Stats() ImportStats {
+	return ImportStats{
+		Created:   r.Created,
+		Updated:   r.Updated,
+		Unchanged: r.Unchanged,
+		Skipped:   r.Skipped,
+		Deleted:   r.Deleted,
+	}
+}
+
+// validateTxHooks rejects Options.OnCommit and Options.PostImportAssert with
+// options that commit in more than one transaction, where no single commit
+// could carry the hooks.
+func validateTxHooks(opts Options) error {
+	if (opts.OnCommit != nil || opts.PostImportAssert != nil) && (opts.BatchSize > 0 || opts.IsolatePrefixes) {
+		return fmt.Errorf("OnCommit and PostImportAssert are not supported with BatchSize or IsolatePrefixes")
+	}
+	return nil
+}
+
+// runTxHooks calls Options.PostImportAssert and then Options.OnCommit as the
+// last steps of the import transaction, so an error from either rolls the
+// whole import back.
+func runTxHooks(ctx context.Context, tx storage.Transaction, opts Options, result *Result) error {
+	if opts.DryRun {
+		return nil
+	}
+	if opts.PostImportAssert != nil {
+		if err := opts.PostImportAssert(ctx, tx); err != nil {
+			return fmt.Errorf("post-import assertion failed: %w", err)
+		}
+	}
+	if opts.OnCommit != nil {
+		if err := opts.OnCommit(ctx, result.Stats()); err != nil {
+			return fmt.Errorf("import commit hook failed: %w", err)
+		}
+	}
+	return nil
+}
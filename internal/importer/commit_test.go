@@ -0,0 +1,175 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestImportIssues_OnCommit(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	newIssue := func(id string) *types.Issue {
+		return &types.Issue{ID: id, Title: "Issue " + id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now}
+	}
+	t.Run("hook sees the final counts", func(t *testing.T) {
+		store := newTestStore(t)
+		if _, err := ImportIssues(ctx, "", store, []*types.Issue{newIssue("test-1")}, Options{}); err != nil {
+			t.Fatalf("ImportIssues failed: %v", err)
+		}
+		changed := newIssue("test-1")
+		changed.Title = "Renamed"
+		changed.UpdatedAt = now.Add(time.Minute)
+
+		var calls []ImportStats
+		opts := Options{OnCommit: func(ctx context.Context, stats ImportStats) error {
+			calls = append(calls, stats)
+			return nil
+		}}
+		if _, err := ImportIssues(ctx, "", store, []*types.Issue{changed, newIssue("test-2"), newIssue("test-3")}, opts); err != nil {
+			t.Fatalf("ImportIssues failed: %v", err)
+		}
+		want := ImportStats{Created: 2, Updated: 1}
+		if len(calls) != 1 || calls[0] != want {
+			t.Errorf("OnCommit calls = %+v, want one call with %+v", calls, want)
+		}
+	})
+
+	t.Run("hook error rolls the import back", func(t *testing.T) {
+		store := newTestStore(t)
+		if _, err := ImportIssues(ctx, "", store, []*types.Issue{newIssue("test-1")}, Options{}); err != nil {
+			t.Fatalf("ImportIssues failed: %v", err)
+		}
+		changed := newIssue("test-1")
+		changed.Title = "Renamed"
+		changed.UpdatedAt = now.Add(time.Minute)
+
+		errBus := errors.New("message bus unavailable")
+		opts := Options{OnCommit: func(ctx context.Context, stats ImportStats) error { return errBus }}
+		_, err := ImportIssues(ctx, "", store, []*types.Issue{changed, newIssue("test-2")}, opts)
+		if !errors.Is(err, errBus) {
+			t.Fatalf("expected the hook error, got %v", err)
+		}
+
+		if created, err := store.GetIssue(ctx, "test-2"); err != nil || created != nil {
+			t.Errorf("test-2 should not exist after rollback: %v, %v", created, err)
+		}
+		kept, err := store.GetIssue(ctx, "test-1")
+		if err != nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		if kept.Title != "Issue test-1" {
+			t.Errorf("update should have rolled back, title = %q", kept.Title)
+		}
+	})
+
+	t.Run("replace import counts removals", func(t *testing.T) {
+		store := newTestStore(t)
+		if _, err := ImportIssues(ctx, "", store, []*types.Issue{newIssue("test-1"), newIssue("test-2")}, Options{}); err != nil {
+			t.Fatalf("ImportIssues failed: %v", err)
+		}
+		var got ImportStats
+		opts := Options{OnCommit: func(ctx context.Context, stats ImportStats) error {
+			got = stats
+			return nil
+		}}
+		if _, err := ReplaceAllImport(ctx, store, []*types.Issue{newIssue("test-1")}, "test-user", opts); err != nil {
+			t.Fatalf("ReplaceAllImport failed: %v", err)
+		}
+		if want := (ImportStats{Unchanged: 1, Deleted: 1}); got != want {
+			t.Errorf("OnCommit stats = %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("multi-transaction imports are rejected", func(t *testing.T) {
+		store := newTestStore(t)
+		opts := Options{BatchSize: 10, OnCommit: func(context.Context, ImportStats) error { return nil }}
+		if _, err := ImportIssues(ctx, "", store, []*types.Issue{newIssue("test-1")}, opts); err == nil {
+			t.Error("expected OnCommit with BatchSize to be rejected")
+		}
+	})
+}
+
+// everyEpicHasAChild is a project rule of the kind PostImportAssert encodes.
+func everyEpicHasAChild(ctx context.Context, tx storage.Transaction) error {
+	issues, err := tx.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		return err
+	}
+	hasChild := make(map[string]bool)
+	for _, issue := range issues {
+		deps, err := tx.GetDependencyRecords(ctx, issue.ID)
+		if err != nil {
+			return err
+		}
+		for _, dep := range deps {
+			if dep.Type == types.DepParentChild {
+				hasChild[dep.DependsOnID] = true
+			}
+		}
+	}
+	for _, issue := range issues {
+		if issue.IssueType == types.TypeEpic && !hasChild[issue.ID] {
+			return fmt.Errorf("epic %s has no children", issue.ID)
+		}
+	}
+	return nil
+}
+
+func TestImportIssues_PostImportAssert(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	newIssue := func(id string, issueType types.IssueType) *types.Issue {
+		return &types.Issue{ID: id, Title: "Issue " + id, Status: types.StatusOpen, Priority: 2, IssueType: issueType, CreatedAt: now, UpdatedAt: now}
+	}
+	t.Run("hierarchy satisfying the rule commits", func(t *testing.T) {
+		store := newTestStore(t)
+		child := newIssue("test-1.1", types.TypeTask)
+		child.Dependencies = []*types.Dependency{{IssueID: "test-1.1", DependsOnID: "test-1", Type: types.DepParentChild}}
+		opts := Options{PostImportAssert: everyEpicHasAChild}
+		if _, err := ImportIssues(ctx, "", store, []*types.Issue{newIssue("test-1", types.TypeEpic), child}, opts); err != nil {
+			t.Fatalf("ImportIssues failed: %v", err)
+		}
+		if got, err := store.GetIssue(ctx, "test-1.1"); err != nil || got == nil {
+			t.Errorf("child should have been imported: %v, %v", got, err)
+		}
+	})
+
+	t.Run("violation rolls the import back", func(t *testing.T) {
+		store := newTestStore(t)
+		var committed bool
+		opts := Options{
+			PostImportAssert: everyEpicHasAChild,
+			OnCommit: func(context.Context, ImportStats) error {
+				committed = true
+				return nil
+			},
+		}
+		_, err := ImportIssues(ctx, "", store, []*types.Issue{newIssue("test-1", types.TypeEpic), newIssue("test-2", types.TypeTask)}, opts)
+		if err == nil {
+			t.Fatal("expected the assertion to fail the import")
+		}
+		if committed {
+			t.Error("OnCommit should not run after a failed assertion")
+		}
+		for _, id := range []string{"test-1", "test-2"} {
+			if got, err := store.GetIssue(ctx, id); err != nil || got != nil {
+				t.Errorf("%s should not exist after rollback: %v, %v", id, got, err)
+			}
+		}
+	})
+
+	t.Run("assertion errors are wrapped", func(t *testing.T) {
+		store := newTestStore(t)
+		errRule := errors.New("rule violated")
+		opts := Options{PostImportAssert: func(context.Context, storage.Transaction) error { return errRule }}
+		if _, err := ImportIssues(ctx, "", store, []*types.Issue{newIssue("test-1", types.TypeTask)}, opts); !errors.Is(err, errRule) {
+			t.Errorf("expected the assertion error, got %v", err)
+		}
+	})
+}
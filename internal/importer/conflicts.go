@@ -0,0 +1,72 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// ConflictReportVersion is the schema version written in ConflictReport.Version.
+// It is bumped only for incompatible changes; new fields may be added within a
+// version, so readers should ignore fields they do not know.
+const ConflictReportVersion = 1
+
+// ConflictReport is the machine-readable form of an import's conflicts, for
+// CI and dashboards (bd import --conflicts-out). Every list is present, empty
+// rather than null, and sorted, so reports of equal imports are byte-identical.
+type ConflictReport struct {
+	Version             int             `json:"version"`
+	HashCollisions      []string        `json:"hash_collisions"`      // Same content hash, different content
+	IDCollisions        []string        `json:"id_collisions"`        // Incoming IDs that collided with existing issues
+	Remaps              []ConflictRemap `json:"remaps"`               // IDs rewritten by the import, ordered by From
+	Skipped             int             `json:"skipped"`              // Issues skipped (duplicates, policies, errors)
+	SkippedDependencies []string        `json:"skipped_dependencies"` // Dependencies dropped for missing references
+	DependencyConflicts []string        `json:"dependency_conflicts"` // Contradictory (inverted) dependencies and which edge was kept
+	MismatchPrefixes    map[string]int  `json:"mismatch_prefixes"`    // Foreign prefixes and their issue counts
+}
+
+// ConflictRemap is one ID the import rewrote.
+type ConflictRemap struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// ConflictReport returns the conflicts recorded in r.
+func (r *Result) ConflictReport() *ConflictReport {
+	report := &ConflictReport{
+		Version:             ConflictReportVersion,
+		HashCollisions:      sortedCopy(r.HashCollisions),
+		IDCollisions:        sortedCopy(r.CollisionIDs),
+		Remaps:              make([]ConflictRemap, 0, len(r.IDMapping)),
+		Skipped:             r.Skipped,
+		SkippedDependencies: sortedCopy(r.SkippedDependencies),
+		DependencyConflicts: sortedCopy(r.DependencyConflicts),
+		MismatchPrefixes:    make(map[string]int, len(r.MismatchPrefixes)),
+	}
+	for from, to := range r.IDMapping {
+		report.Remaps = append(report.Remaps, ConflictRemap{From: from, To: to})
+	}
+	sort.Slice(report.Remaps, func(i, j int) bool { return report.Remaps[i].From < report.Remaps[j].From })
+	for prefix, count := range r.MismatchPrefixes {
+		report.MismatchPrefixes[prefix] = count
+	}
+	return report
+}
+
+// WriteConflictReport writes report to w as indented JSON.
+func WriteConflictReport(w io.Writer, report *ConflictReport) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(report); err != nil {
+		return fmt.Errorf("failed to write conflict report: %w", err)
+	}
+	return nil
+}
+
+func sortedCopy(values []string) []string {
+	out := append([]string{}, values...)
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,77 @@
+package importer
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteConflictReport(t *testing.T) {
+	result := &Result{
+		Skipped:             2,
+		CollisionIDs:        []string{"test-b", "test-a"},
+		HashCollisions:      []string{"test-y and test-x share content hash abc"},
+		IDMapping:           map[string]string{"old-2": "test-2", "old-1": "test-1"},
+		SkippedDependencies: []string{"test-a -> test-missing (blocks)"},
+		DependencyConflicts: []string{"test-b → test-a (blocks) inverts existing test-a → test-b: kept existing"},
+		MismatchPrefixes:    map[string]int{"old": 2},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteConflictReport(&buf, result.ConflictReport()); err != nil {
+		t.Fatalf("WriteConflictReport failed: %v", err)
+	}
+	want := `{
+  "version": 1,
+  "hash_collisions": [
+    "test-y and test-x share content hash abc"
+  ],
+  "id_collisions": [
+    "test-a",
+    "test-b"
+  ],
+  "remaps": [
+    {
+      "from": "old-1",
+      "to": "test-1"
+    },
+    {
+      "from": "old-2",
+      "to": "test-2"
+    }
+  ],
+  "skipped": 2,
+  "skipped_dependencies": [
+    "test-a -> test-missing (blocks)"
+  ],
+  "dependency_conflicts": [
+    "test-b → test-a (blocks) inverts existing test-a → test-b: kept existing"
+  ],
+  "mismatch_prefixes": {
+    "old": 2
+  }
+}
+`
+	if got := buf.String(); got != want {
+		t.Errorf("unexpected report:\n%s\nwant:\n%s", got, want)
+	}
+
+	// A clean import still writes every field, with empty lists
+	buf.Reset()
+	if err := WriteConflictReport(&buf, (&Result{}).ConflictReport()); err != nil {
+		t.Fatalf("WriteConflictReport failed: %v", err)
+	}
+	want = `{
+  "version": 1,
+  "hash_collisions": [],
+  "id_collisions": [],
+  "remaps": [],
+  "skipped": 0,
+  "skipped_dependencies": [],
+  "dependency_conflicts": [],
+  "mismatch_prefixes": {}
+}
+`
+	if got := buf.String(); got != want {
+		t.Errorf("unexpected empty report:\n%s\nwant:\n%s", got, want)
+	}
+}
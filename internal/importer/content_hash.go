@@ -0,0 +1,39 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ContentHashField is the top-level JSON field from which DecodeIssue reads
+// the content hash computed by the exporting tool. types.Issue never encodes
+// its hash, so only other tools' exports carry it.
+const ContentHashField = "content_hash"
+
+// ErrMissingContentHash is matched (via errors.Is) by the ValidationError
+// returned under Options.RequireContentHash for an issue that arrives without
+// a content hash.
+var ErrMissingContentHash = errors.New("issue has no content hash")
+
+// requireContentHashes fails under opts.RequireContentHash on the first issue
+// without a ContentHash, so exports from tools that do not hash are refused
+// instead of hashed silently. The hash stored is still the one computed
+// locally, so deduplication against existing issues is unaffected. Under
+// Options.ContinueOnError an issue without a hash is rejected instead.
+func requireContentHashes(ctx context.Context, issues []*types.Issue, opts Options, result *Result) error {
+	if !opts.RequireContentHash {
+		return nil
+	}
+	for _, issue := range issues {
+		if issue.ContentHash == "" {
+			err := &ValidationError{IssueID: issue.ID, Err: fmt.Errorf("%w (the export must include %s)", ErrMissingContentHash, ContentHashField)}
+			if err := rejectIssue(ctx, issue, err, opts, result); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestImportIssues_RequireContentHash(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	const jsonl = `{"id":"test-1","title":"Hashed","status":"open","priority":2,"issue_type":"task","created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-01T00:00:00Z","content_hash":"abc123"}
+{"id":"test-2","title":"Unhashed","status":"open","priority":2,"issue_type":"task","created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-01T00:00:00Z"}
+`
+	parse := func() []*types.Issue {
+		t.Helper()
+		issues, err := ParseIssues(strings.NewReader(jsonl), ParseOptions{UnknownFields: UnknownFieldsError})
+		if err != nil {
+			t.Fatalf("ParseIssues failed: %v", err)
+		}
+		return issues
+	}
+	if issues := parse(); issues[0].ContentHash != "abc123" || issues[1].ContentHash != "" {
+		t.Fatalf("parsed hashes %q, %q; want abc123 and none", issues[0].ContentHash, issues[1].ContentHash)
+	}
+
+	_, err := ImportIssues(ctx, "", store, parse(), Options{RequireContentHash: true})
+	var verr *ValidationError
+	if !errors.Is(err, ErrMissingContentHash) || !errors.As(err, &verr) || verr.IssueID != "test-2" {
+		t.Fatalf("expected ErrMissingContentHash for test-2, got %v", err)
+	}
+	if got, _ := store.GetIssue(ctx, "test-1"); got != nil {
+		t.Errorf("expected nothing imported, found %s", got.ID)
+	}
+
+	// The default computes the missing hash, and stored hashes are always local
+	if _, err := ImportIssues(ctx, "", store, parse(), Options{}); err != nil {
+		t.Fatalf("ImportIssues failed: %v", err)
+	}
+	for _, id := range []string{"test-1", "test-2"} {
+		got, err := store.GetIssue(ctx, id)
+		if err != nil || got == nil {
+			t.Fatalf("GetIssue(%s) failed: %v", id, err)
+		}
+		want := *got
+		want.ContentHash = ""
+		if got.ContentHash != want.ComputeContentHash() {
+			t.Errorf("%s stored hash %q, want the locally computed one", id, got.ContentHash)
+		}
+	}
+}
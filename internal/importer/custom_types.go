@@ -0,0 +1,102 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// customTypesConfigKey holds the comma-separated custom issue types.
+const customTypesConfigKey = "types.custom"
+
+// configStore is satisfied by both storage.Storage and storage.Transaction.
+type configStore interface {
+	GetConfig(ctx context.Context, key string) (string, error)
+	SetConfig(ctx context.Context, key, value string) error
+}
+
+// autoCreateCustomTypes registers, under Options.AutoCreateCustomTypes, every
+// issue type used by issues that is neither built in nor already a custom
+// type, so the issues pass validation. It returns the newly registered types
+// in first-use order and appends them to result.RegisteredTypes.
+func autoCreateCustomTypes(ctx context.Context, cfg configStore, issues []*types.Issue, opts Options, result *Result) ([]string, error) {
+	if !opts.AutoCreateCustomTypes {
+		return nil, nil
+	}
+
+	value, err := cfg.GetConfig(ctx, customTypesConfigKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get custom types: %w", err)
+	}
+	var known []string
+	for _, t := range strings.Split(value, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			known = append(known, t)
+		}
+	}
+	if len(known) == 0 {
+		// Keep types defined only in config.yaml once the database key exists
+		known = config.GetCustomTypesFromYAML()
+	}
+
+	var registered []string
+	for _, issue := range issues {
+		t := strings.TrimSpace(string(issue.IssueType))
+		if t == "" || issue.IssueType.IsValidWithCustom(known) {
+			continue
+		}
+		known = append(known, t)
+		registered = append(registered, t)
+	}
+	if len(registered) == 0 {
+		return nil, nil
+	}
+	if err := cfg.SetConfig(ctx, customTypesConfigKey, strings.Join(known, ",")); err != nil {
+		return nil, fmt.Errorf("failed to register custom types %s: %w", strings.Join(registered, ", "), err)
+	}
+	result.RegisteredTypes = append(result.RegisteredTypes, registered...)
+	return registered, nil
+}
+
+// recordTypeRegistrations records a types.EventTypeRegistered event on the
+// first imported issue of each type in registered, through the
+// storage.EventImporter capability of store if it has one.
+func recordTypeRegistrations(ctx context.Context, store interface{}, getIssue func(context.Context, string) (*types.Issue, error), issues []*types.Issue, registered []string, result *Result) error {
+	if len(registered) == 0 {
+		return nil
+	}
+	importer, ok := store.(storage.EventImporter)
+	if !ok {
+		return nil
+	}
+
+	pending := make(map[string]bool, len(registered))
+	for _, t := range registered {
+		pending[t] = true
+	}
+	now := time.Now()
+	for _, issue := range issues {
+		t := string(issue.IssueType)
+		if !pending[t] {
+			continue
+		}
+		id := issue.ID
+		if newID, ok := result.IDMapping[id]; ok {
+			id = newID
+		}
+		if stored, err := getIssue(ctx, id); err != nil || stored == nil {
+			continue
+		}
+		event := &types.Event{IssueID: id, EventType: types.EventTypeRegistered, Actor: "import", NewValue: &t, CreatedAt: now}
+		if err := importer.ImportEvents(ctx, id, []*types.Event{event}, false); err != nil {
+			return fmt.Errorf("failed to record registration of custom type %s: %w", t, err)
+		}
+		delete(pending, t)
+	}
+	return nil
+}
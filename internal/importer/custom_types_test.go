@@ -0,0 +1,66 @@
+package importer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestImportIssues_AutoCreateCustomTypes(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	if err := store.SetConfig(ctx, sqlite.CustomTypeConfigKey, "spike"); err != nil {
+		t.Fatalf("Failed to set custom types: %v", err)
+	}
+
+	now := time.Now()
+	inputs := func() []*types.Issue {
+		return []*types.Issue{
+			{ID: "test-a", Title: "Incident", Status: types.StatusOpen, Priority: 1, IssueType: "incident", CreatedAt: now, UpdatedAt: now},
+			{ID: "test-b", Title: "Spike", Status: types.StatusOpen, Priority: 2, IssueType: "spike", CreatedAt: now, UpdatedAt: now},
+			{ID: "test-c", Title: "Another incident", Status: types.StatusOpen, Priority: 1, IssueType: "incident", CreatedAt: now, UpdatedAt: now},
+		}
+	}
+
+	// Off: unknown types still fail validation
+	if _, err := ImportIssues(ctx, "", store, inputs(), Options{}); err == nil {
+		t.Fatal("expected an unknown custom type to fail the import")
+	}
+	if issue, _ := store.GetIssue(ctx, "test-b"); issue != nil {
+		t.Error("expected the failed import rolled back")
+	}
+
+	result, err := ImportIssues(ctx, "", store, inputs(), Options{AutoCreateCustomTypes: true})
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if result.Created != 3 || len(result.RegisteredTypes) != 1 || result.RegisteredTypes[0] != "incident" {
+		t.Errorf("expected 3 created and incident registered, got %+v", result)
+	}
+	custom, err := store.GetCustomTypes(ctx)
+	if err != nil || len(custom) != 2 || custom[0] != "spike" || custom[1] != "incident" {
+		t.Errorf("expected custom types [spike incident], got %v (err %v)", custom, err)
+	}
+
+	var registrations int
+	for _, id := range []string{"test-a", "test-c"} {
+		events, err := store.GetEvents(ctx, id, 0)
+		if err != nil {
+			t.Fatalf("GetEvents failed: %v", err)
+		}
+		for _, e := range events {
+			if e.EventType == types.EventTypeRegistered {
+				registrations++
+				if id != "test-a" || e.NewValue == nil || *e.NewValue != "incident" {
+					t.Errorf("unexpected registration event on %s: %+v", id, e)
+				}
+			}
+		}
+	}
+	if registrations != 1 {
+		t.Errorf("expected one registration event, got %d", registrations)
+	}
+}
@@ -0,0 +1,104 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// applyDefaultIDPrefix implements Options.DefaultIDPrefix: every issue without
+// an IDPrefix, as in a flat single-repo export, is given it and moved under the
+// composed prefix ("bd" + "web" → "bd-web-a1b2" for "proj-a1b2"), keeping its
+// suffix. IDs already under the composed prefix keep their ID. References to a
+// moved ID anywhere in the import follow it, and the renames land in
+// result.IDMapping like RenameOnImport renames, so imported events and
+// relationships follow too. Issues that carry their own IDPrefix are left
+// alone. Under Options.ContinueOnError an issue that cannot be moved is
+// rejected instead of failing the import, and keeps its ID.
+func applyDefaultIDPrefix(ctx context.Context, cfg configStore, issues []*types.Issue, opts Options, result *Result) error {
+	if opts.DefaultIDPrefix == "" {
+		return nil
+	}
+	configPrefix, err := cfg.GetConfig(ctx, "issue_prefix")
+	if err != nil {
+		return fmt.Errorf("failed to get issue prefix: %w", err)
+	}
+	if configPrefix == "" {
+		return fmt.Errorf("cannot apply default ID prefix %q: issue_prefix not configured in database", opts.DefaultIDPrefix)
+	}
+	sep, _ := cfg.GetConfig(ctx, storage.IDSeparatorConfigKey)
+	if sep == "" {
+		sep = utils.DefaultIDSeparator
+	}
+	if strings.Contains(opts.DefaultIDPrefix, sep) {
+		return fmt.Errorf("default ID prefix %q must not contain the ID separator %q", opts.DefaultIDPrefix, sep)
+	}
+	target := configPrefix + sep + opts.DefaultIDPrefix
+
+	idMapping := make(map[string]string)
+	renamedFrom := make(map[string]string)
+	for _, issue := range issues {
+		if issue.ID == "" || issue.IDPrefix != "" {
+			continue
+		}
+		issue.IDPrefix = opts.DefaultIDPrefix
+		if strings.HasPrefix(issue.ID, target+sep) {
+			continue
+		}
+		oldPrefix := utils.ExtractIssuePrefixWithSeparator(issue.ID, sep)
+		suffix := strings.TrimPrefix(issue.ID, oldPrefix+sep)
+		if oldPrefix == "" || suffix == "" || !isValidIDSuffix(suffix) {
+			err := &PrefixError{IssueID: issue.ID, Prefix: oldPrefix, Expected: target,
+				Err: fmt.Errorf("cannot apply default ID prefix: invalid ID suffix %q", suffix)}
+			if err := rejectIssue(ctx, issue, err, opts, result); err != nil {
+				return err
+			}
+			continue
+		}
+		newID := target + sep + suffix
+		if other, ok := renamedFrom[newID]; ok {
+			err := &PrefixError{IssueID: issue.ID, Prefix: oldPrefix, Expected: target,
+				Err: fmt.Errorf("cannot apply default ID prefix: %s and %s would both become %s", other, issue.ID, newID)}
+			if err := rejectIssue(ctx, issue, err, opts, result); err != nil {
+				return err
+			}
+			continue
+		}
+		renamedFrom[newID] = issue.ID
+		idMapping[issue.ID] = newID
+	}
+	// An ID already under the composed prefix is never renamed itself
+	byID := make(map[string]*types.Issue, len(issues))
+	for _, issue := range issues {
+		byID[issue.ID] = issue
+	}
+	for _, issue := range issues {
+		old, ok := renamedFrom[issue.ID]
+		if !ok {
+			continue
+		}
+		err := &PrefixError{IssueID: old, Expected: target,
+			Err: fmt.Errorf("cannot apply default ID prefix: %s would become %s, which the import already holds", old, issue.ID)}
+		if err := rejectIssue(ctx, byID[old], err, opts, result); err != nil {
+			return err
+		}
+		delete(idMapping, old)
+	}
+	if len(idMapping) == 0 {
+		return nil
+	}
+
+	applyIDMapping(issues, idMapping)
+	// Rewritten references change the content, so rehash
+	for _, issue := range issues {
+		issue.ContentHash = issueContentHash(issue, opts)
+	}
+	for oldID, newID := range idMapping {
+		result.IDMapping[oldID] = newID
+	}
+	return nil
+}
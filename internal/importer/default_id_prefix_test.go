@@ -0,0 +1,99 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestImportIssues_DefaultIDPrefix(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+	flatExport := func() []*types.Issue {
+		return []*types.Issue{
+			{ID: "proj-a1b2", Title: "Parent", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeEpic, CreatedAt: now, UpdatedAt: now},
+			{ID: "proj-c3d4", Title: "Child", Description: "Split out of proj-a1b2", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now,
+				Dependencies: []*types.Dependency{{IssueID: "proj-c3d4", DependsOnID: "proj-a1b2", Type: types.DepParentChild}}},
+		}
+	}
+
+	newStore := func(t *testing.T) *sqlite.SQLiteStorage {
+		store := newTestStore(t)
+		if err := store.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+			t.Fatalf("Failed to set prefix: %v", err)
+		}
+		return store
+	}
+
+	store := newStore(t)
+	issues := append(flatExport(), &types.Issue{ID: "bd-api-e5f6", IDPrefix: "api", Title: "Own prefix", Status: types.StatusOpen,
+		Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now})
+	result, err := ImportIssues(ctx, "", store, issues, Options{DefaultIDPrefix: "web"})
+	if err != nil {
+		t.Fatalf("ImportIssues failed: %v", err)
+	}
+	if result.Created != 3 || len(result.IDMapping) != 2 || result.IDMapping["proj-a1b2"] != "bd-web-a1b2" || result.IDMapping["proj-c3d4"] != "bd-web-c3d4" {
+		t.Errorf("created %d, mapping %v; want both flat issues moved under bd-web", result.Created, result.IDMapping)
+	}
+	if got, _ := store.GetIssue(ctx, "bd-api-e5f6"); got == nil {
+		t.Error("issue with its own IDPrefix should keep its ID")
+	}
+	child, err := store.GetIssue(ctx, "bd-web-c3d4")
+	if err != nil || child == nil {
+		t.Fatalf("GetIssue(bd-web-c3d4) = %v, %v", child, err)
+	}
+	if child.Description != "Split out of bd-web-a1b2" {
+		t.Errorf("description = %q, want the reference rewritten", child.Description)
+	}
+	deps, err := store.GetDependencyRecords(ctx, "bd-web-c3d4")
+	if err != nil || len(deps) != 1 || deps[0].DependsOnID != "bd-web-a1b2" {
+		t.Errorf("dependencies = %v, %v; want the parent under bd-web", deps, err)
+	}
+	if got, _ := store.GetIssue(ctx, "proj-a1b2"); got != nil {
+		t.Error("the flat ID should not be created")
+	}
+
+	// Re-importing the same export matches the moved issues
+	again, err := ImportIssues(ctx, "", store, flatExport(), Options{DefaultIDPrefix: "web"})
+	if err != nil {
+		t.Fatalf("second ImportIssues failed: %v", err)
+	}
+	if again.Created != 0 || again.Unchanged != 2 {
+		t.Errorf("second import created %d, unchanged %d; want the 2 issues unchanged", again.Created, again.Unchanged)
+	}
+
+	t.Run("already under prefix", func(t *testing.T) {
+		store := newStore(t)
+		issues := []*types.Issue{{ID: "bd-web-a1b2", Title: "Moved", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now}}
+		result, err := ImportIssues(ctx, "", store, issues, Options{DefaultIDPrefix: "web"})
+		if err != nil || result.Created != 1 || len(result.IDMapping) != 0 {
+			t.Errorf("result = %+v, %v; want the ID kept", result, err)
+		}
+	})
+
+	t.Run("clashing suffixes", func(t *testing.T) {
+		issues := flatExport()
+		issues[1].ID = "other-a1b2"
+		issues[1].Dependencies = nil
+		_, err := ImportIssues(ctx, "", newStore(t), issues, Options{DefaultIDPrefix: "web", SkipPrefixValidation: true})
+		var prefixErr *PrefixError
+		if !errors.As(err, &prefixErr) || !strings.Contains(err.Error(), "would both become bd-web-a1b2") {
+			t.Errorf("err = %v, want a PrefixError naming the clash", err)
+		}
+	})
+
+	for name, opts := range map[string]Options{
+		"separator":        {DefaultIDPrefix: "web-"},
+		"inner separator":  {DefaultIDPrefix: "my-web"},
+		"isolate prefixes": {DefaultIDPrefix: "web", IsolatePrefixes: true},
+	} {
+		if _, err := ImportIssues(ctx, "", newStore(t), flatExport(), opts); err == nil {
+			t.Errorf("%s: expected the options to be rejected", name)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// applyImportDefaults fills in Options.DefaultStatus and Options.DefaultType
+// on issues that have no status or type, so sparse exports from other systems
+// import instead of failing validation. The defaults are checked first: each
+// must be built in or registered in cfg as a custom status or type.
+//
+// With Options.DeriveStatus, an issue without a status is first given one
+// from its timestamps: tombstone if it has deleted_at, closed if it has
+// closed_at, and otherwise DefaultStatus, or open when that is unset.
+func applyImportDefaults(ctx context.Context, cfg configStore, issues []*types.Issue, opts Options) error {
+	if opts.DefaultStatus == "" && opts.DefaultType == "" && !opts.DeriveStatus {
+		return nil
+	}
+	if opts.DefaultStatus != "" {
+		customStatuses := customConfigList(ctx, cfg, "status.custom", nil)
+		if !opts.DefaultStatus.IsValidWithCustom(customStatuses) {
+			return fmt.Errorf("invalid default status %q: not a built-in or custom status", opts.DefaultStatus)
+		}
+	}
+	if opts.DefaultType != "" {
+		customTypes := customConfigList(ctx, cfg, customTypesConfigKey, config.GetCustomTypesFromYAML)
+		if !opts.DefaultType.IsValidWithCustom(customTypes) {
+			return fmt.Errorf("invalid default type %q: not a built-in or custom type", opts.DefaultType)
+		}
+	}
+
+	for _, issue := range issues {
+		if issue.Status == "" && opts.DeriveStatus {
+			switch {
+			case issue.DeletedAt != nil:
+				issue.Status = types.StatusTombstone
+			case issue.ClosedAt != nil:
+				issue.Status = types.StatusClosed
+			case opts.DefaultStatus == "":
+				issue.Status = types.StatusOpen
+			}
+		}
+		if issue.Status == "" && opts.DefaultStatus != "" {
+			issue.Status = opts.DefaultStatus
+		}
+		if issue.IssueType == "" && opts.DefaultType != "" {
+			issue.IssueType = opts.DefaultType
+		}
+	}
+	return nil
+}
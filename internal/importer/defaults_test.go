@@ -0,0 +1,97 @@
+package importer
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestImportIssues_DefaultStatusAndType(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	if err := store.SetConfig(ctx, "types.custom", "incident"); err != nil {
+		t.Fatalf("Failed to set custom types: %v", err)
+	}
+
+	now := time.Now()
+	sparse := &types.Issue{ID: "test-1", Title: "Sparse", Priority: 2, CreatedAt: now, UpdatedAt: now}
+	typed := &types.Issue{ID: "test-2", Title: "Typed", Status: types.StatusClosed, ClosedAt: &now, Priority: 2,
+		IssueType: types.TypeBug, CreatedAt: now, UpdatedAt: now}
+	opts := Options{DefaultStatus: types.StatusDeferred, DefaultType: "incident"}
+	if _, err := ImportIssues(ctx, "", store, []*types.Issue{sparse, typed}, opts); err != nil {
+		t.Fatalf("ImportIssues failed: %v", err)
+	}
+
+	got, err := store.GetIssue(ctx, "test-1")
+	if err != nil || got == nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Status != types.StatusDeferred || got.IssueType != "incident" {
+		t.Errorf("expected defaults deferred/incident, got %s/%s", got.Status, got.IssueType)
+	}
+	want := *got
+	want.ContentHash = ""
+	if got.ContentHash != want.ComputeContentHash() {
+		t.Errorf("expected content hash to cover the defaulted fields")
+	}
+	if got, _ := store.GetIssue(ctx, "test-2"); got.Status != types.StatusClosed || got.IssueType != types.TypeBug {
+		t.Errorf("expected explicit values to be kept, got %s/%s", got.Status, got.IssueType)
+	}
+
+	for _, bad := range []Options{{DefaultStatus: "bogus"}, {DefaultType: "bogus"}} {
+		issue := &types.Issue{ID: "test-3", Title: "Sparse", Priority: 2, CreatedAt: now, UpdatedAt: now}
+		if _, err := ImportIssues(ctx, "", store, []*types.Issue{issue}, bad); err == nil {
+			t.Errorf("expected invalid default %+v to be rejected", bad)
+		}
+	}
+}
+
+func TestImportIssues_DeriveStatus(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	const jsonl = `{"id":"test-1","title":"Closed","priority":2,"issue_type":"task","created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-02T00:00:00Z","closed_at":"2024-01-02T00:00:00Z"}
+{"id":"test-2","title":"Deleted","priority":2,"issue_type":"task","created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-03T00:00:00Z","closed_at":"2024-01-02T00:00:00Z","deleted_at":"2024-01-03T00:00:00Z"}
+{"id":"test-3","title":"Untimed","priority":2,"issue_type":"task","created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-01T00:00:00Z"}
+{"id":"test-4","title":"Explicit","status":"blocked","priority":2,"issue_type":"task","created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-02T00:00:00Z","deleted_at":"2024-01-02T00:00:00Z"}
+`
+	issues, err := ParseIssues(strings.NewReader(jsonl), ParseOptions{EmptyStatus: true})
+	if err != nil {
+		t.Fatalf("ParseIssues failed: %v", err)
+	}
+	if issues[0].Status != "" || issues[3].Status != types.StatusBlocked {
+		t.Fatalf("parsed statuses %q and %q, want absent left empty and explicit kept", issues[0].Status, issues[3].Status)
+	}
+	// An explicit status is never overridden, even when it then fails validation
+	if err := applyImportDefaults(ctx, store, issues, Options{DeriveStatus: true}); err != nil {
+		t.Fatalf("applyImportDefaults failed: %v", err)
+	}
+	for i, want := range []types.Status{types.StatusClosed, types.StatusTombstone, types.StatusOpen, types.StatusBlocked} {
+		if issues[i].Status != want {
+			t.Errorf("%s derived status %s, want %s", issues[i].ID, issues[i].Status, want)
+		}
+	}
+
+	if _, err := ImportIssues(ctx, "", store, issues[:3], Options{DeriveStatus: true}); err != nil {
+		t.Fatalf("ImportIssues failed: %v", err)
+	}
+	for id, want := range map[string]types.Status{"test-1": types.StatusClosed, "test-2": types.StatusTombstone, "test-3": types.StatusOpen} {
+		got, err := store.GetIssue(ctx, id)
+		if err != nil || got == nil || got.Status != want {
+			t.Errorf("GetIssue(%s) = %+v, %v; want status %s", id, got, err, want)
+		}
+	}
+
+	// Without closed_at or deleted_at, DefaultStatus wins over open
+	untimed := &types.Issue{ID: "test-5", Title: "Untimed", Priority: 2, IssueType: types.TypeTask,
+		CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	if err := applyImportDefaults(ctx, store, []*types.Issue{untimed}, Options{DeriveStatus: true, DefaultStatus: types.StatusDeferred}); err != nil {
+		t.Fatalf("applyImportDefaults failed: %v", err)
+	}
+	if untimed.Status != types.StatusDeferred {
+		t.Errorf("untimed status %s, want deferred", untimed.Status)
+	}
+}
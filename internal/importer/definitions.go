@@ -0,0 +1,218 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+const (
+	// customStatusesConfigKey holds the comma-separated custom statuses.
+	customStatusesConfigKey = "status.custom"
+
+	// typeStatusesConfigKey holds the per-type status restrictions as a JSON
+	// types.TypeStatuses (see sqlite.TypeStatusesConfigKey).
+	typeStatusesConfigKey = "validation.type_statuses"
+)
+
+// ErrDefinitionConflict is matched (via errors.Is) by every
+// DefinitionConflictError.
+var ErrDefinitionConflict = errors.New("conflicting custom definitions")
+
+// DefinitionConflict is an issue type that Options.Definitions restricts to
+// other statuses than the database does.
+type DefinitionConflict struct {
+	Type     types.IssueType
+	Stored   []types.Status // Statuses the database restricts the type to
+	Incoming []types.Status // Statuses the definitions restrict it to
+}
+
+// DefinitionConflictError lists every conflict between Options.Definitions
+// and the database. Nothing is registered when there is one.
+type DefinitionConflictError struct {
+	Conflicts []DefinitionConflict
+}
+
+func (e *DefinitionConflictError) Error() string {
+	parts := make([]string, len(e.Conflicts))
+	for i, c := range e.Conflicts {
+		parts[i] = fmt.Sprintf("type %s is restricted to %s in the database but to %s in the import",
+			c.Type, joinStatuses(c.Stored), joinStatuses(c.Incoming))
+	}
+	return fmt.Sprintf("%s: %s", ErrDefinitionConflict, strings.Join(parts, "; "))
+}
+
+func (e *DefinitionConflictError) Unwrap() error { return ErrDefinitionConflict }
+
+// ParseSelfDescribing reads a self-describing export: an optional
+// types.Definitions line first, then issue JSONL as for ParseIssues. The
+// definitions are nil when the first line is an issue; pass them to
+// Options.Definitions so the import registers them. Line numbers in errors
+// count the definitions line.
+func ParseSelfDescribing(r io.Reader, opts ParseOptions) (*types.Definitions, []*types.Issue, error) {
+	lines, err := readLines(r, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	var defs *types.Definitions
+	if len(lines) > 0 {
+		var marker struct {
+			Definitions bool `json:"_definitions"`
+		}
+		if err := json.Unmarshal(lines[0].data, &marker); err != nil {
+			return nil, nil, fmt.Errorf("line %d: %w", lines[0].num, err)
+		}
+		if marker.Definitions {
+			defs = &types.Definitions{}
+			if err := json.Unmarshal(lines[0].data, defs); err != nil {
+				return nil, nil, fmt.Errorf("line %d: invalid definitions: %w", lines[0].num, err)
+			}
+			lines = lines[1:]
+		}
+	}
+	issues, err := decodeLines(lines, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return defs, issues, nil
+}
+
+// registerCustomTypes applies Options.Definitions and then
+// Options.AutoCreateCustomTypes, returning the issue types either registered.
+func registerCustomTypes(ctx context.Context, cfg configStore, issues []*types.Issue, opts Options, result *Result) ([]string, error) {
+	defined, err := applyDefinitions(ctx, cfg, opts, result)
+	if err != nil {
+		return nil, err
+	}
+	registered, err := autoCreateCustomTypes(ctx, cfg, issues, opts, result)
+	if err != nil {
+		return nil, err
+	}
+	return append(defined, registered...), nil
+}
+
+// applyDefinitions reconciles Options.Definitions with the database's custom
+// types, custom statuses and type-status restrictions before any issue is
+// written: missing statuses, types and restrictions are registered, and a type
+// restricted to other statuses than the database restricts it to fails the
+// import with a DefinitionConflictError listing every such type. Definitions
+// already matching the database change nothing, so applying them again (as
+// each batch of a BatchSize import does) is harmless. It returns the newly
+// registered types and appends them, and the statuses, to result.
+func applyDefinitions(ctx context.Context, cfg configStore, opts Options, result *Result) ([]string, error) {
+	defs := opts.Definitions
+	if defs == nil {
+		return nil, nil
+	}
+
+	customTypes := customConfigList(ctx, cfg, customTypesConfigKey, config.GetCustomTypesFromYAML)
+	customStatuses := customConfigList(ctx, cfg, customStatusesConfigKey, config.GetCustomStatusesFromYAML)
+	rules := types.TypeStatuses{}
+	raw, err := cfg.GetConfig(ctx, typeStatusesConfigKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get type statuses: %w", err)
+	}
+	if raw != "" {
+		if err := json.Unmarshal([]byte(raw), &rules); err != nil {
+			return nil, fmt.Errorf("invalid %s config: %w", typeStatusesConfigKey, err)
+		}
+	}
+
+	var newStatuses, newTypes []string
+	for _, status := range defs.Statuses {
+		name := strings.TrimSpace(string(status))
+		if name == "" {
+			return nil, fmt.Errorf("definitions list an empty status")
+		}
+		if !types.Status(name).IsValidWithCustom(customStatuses) {
+			customStatuses = append(customStatuses, name)
+			newStatuses = append(newStatuses, name)
+		}
+	}
+
+	rulesChanged := false
+	var conflicts []DefinitionConflict
+	for _, def := range defs.Types {
+		name := strings.TrimSpace(string(def.Name))
+		if name == "" {
+			return nil, fmt.Errorf("definitions list a type without a name")
+		}
+		if !types.IssueType(name).IsValidWithCustom(customTypes) {
+			customTypes = append(customTypes, name)
+			newTypes = append(newTypes, name)
+		}
+		if len(def.Statuses) == 0 {
+			continue
+		}
+		for _, status := range def.Statuses {
+			if !status.IsValidWithCustom(customStatuses) {
+				return nil, fmt.Errorf("definitions restrict type %s to unknown status %q", name, status)
+			}
+		}
+		stored, ok := rules[types.IssueType(name)]
+		if !ok {
+			rules[types.IssueType(name)] = def.Statuses
+			rulesChanged = true
+			continue
+		}
+		if !sameStatuses(stored, def.Statuses) {
+			conflicts = append(conflicts, DefinitionConflict{Type: types.IssueType(name), Stored: stored, Incoming: def.Statuses})
+		}
+	}
+	if len(conflicts) > 0 {
+		return nil, &DefinitionConflictError{Conflicts: conflicts}
+	}
+
+	if len(newStatuses) > 0 {
+		if err := cfg.SetConfig(ctx, customStatusesConfigKey, strings.Join(customStatuses, ",")); err != nil {
+			return nil, fmt.Errorf("failed to register custom statuses %s: %w", strings.Join(newStatuses, ", "), err)
+		}
+	}
+	if len(newTypes) > 0 {
+		if err := cfg.SetConfig(ctx, customTypesConfigKey, strings.Join(customTypes, ",")); err != nil {
+			return nil, fmt.Errorf("failed to register custom types %s: %w", strings.Join(newTypes, ", "), err)
+		}
+	}
+	if rulesChanged {
+		data, err := json.Marshal(rules)
+		if err != nil {
+			return nil, err
+		}
+		if err := cfg.SetConfig(ctx, typeStatusesConfigKey, string(data)); err != nil {
+			return nil, fmt.Errorf("failed to register type statuses: %w", err)
+		}
+	}
+	result.RegisteredTypes = append(result.RegisteredTypes, newTypes...)
+	result.RegisteredStatuses = append(result.RegisteredStatuses, newStatuses...)
+	return newTypes, nil
+}
+
+// sameStatuses reports whether a and b hold the same statuses in any order.
+func sameStatuses(a, b []types.Status) bool {
+	set := make(map[types.Status]bool, len(a))
+	for _, s := range a {
+		set[s] = true
+	}
+	other := make(map[types.Status]bool, len(b))
+	for _, s := range b {
+		if !set[s] {
+			return false
+		}
+		other[s] = true
+	}
+	return len(other) == len(set)
+}
+
+func joinStatuses(statuses []types.Status) string {
+	names := make([]string, len(statuses))
+	for i, s := range statuses {
+		names[i] = string(s)
+	}
+	return strings.Join(names, ", ")
+}
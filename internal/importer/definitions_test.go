@@ -0,0 +1,90 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestImportIssues_SelfDescribingExport(t *testing.T) {
+	ctx := context.Background()
+	const export = `{"_definitions":true,"types":[{"name":"spike","statuses":["open","review"]},{"name":"chore"}],"statuses":["review"]}
+{"id":"test-1","title":"Investigate","status":"review","priority":2,"issue_type":"spike","created_at":"2026-01-01T00:00:00Z","updated_at":"2026-01-01T00:00:00Z"}
+{"id":"test-2","title":"Tidy","status":"review","priority":2,"issue_type":"chore","created_at":"2026-01-01T00:00:00Z","updated_at":"2026-01-01T00:00:00Z"}
+`
+	parse := func(t *testing.T) (*types.Definitions, []*types.Issue) {
+		t.Helper()
+		defs, issues, err := ParseSelfDescribing(strings.NewReader(export), ParseOptions{})
+		if err != nil {
+			t.Fatalf("ParseSelfDescribing failed: %v", err)
+		}
+		if defs == nil || len(defs.Types) != 2 || len(issues) != 2 {
+			t.Fatalf("parsed %+v and %d issues, want the definitions and 2 issues", defs, len(issues))
+		}
+		return defs, issues
+	}
+
+	store := newTestStore(t)
+	defs, issues := parse(t)
+	if _, err := ImportIssues(ctx, "", store, issues, Options{}); err == nil {
+		t.Fatal("expected issues of an unknown type and status to fail without their definitions")
+	}
+	result, err := ImportIssues(ctx, "", store, issues, Options{Definitions: defs})
+	if err != nil {
+		t.Fatalf("ImportIssues failed: %v", err)
+	}
+	if result.Created != 2 || strings.Join(result.RegisteredTypes, ",") != "spike" || strings.Join(result.RegisteredStatuses, ",") != "review" {
+		t.Errorf("created %d, registered types %v and statuses %v; want spike and review", result.Created, result.RegisteredTypes, result.RegisteredStatuses)
+	}
+	if got, _ := store.GetConfig(ctx, sqlite.TypeStatusesConfigKey); got != `{"spike":["open","review"]}` {
+		t.Errorf("type statuses = %s, want the spike restriction", got)
+	}
+	if got, _ := store.GetIssue(ctx, "test-1"); got == nil || got.IssueType != "spike" || got.Status != "review" {
+		t.Errorf("test-1 = %+v, want a spike in review", got)
+	}
+
+	// Matching definitions register nothing again
+	defs, issues = parse(t)
+	again, err := ImportIssues(ctx, "", store, issues, Options{Definitions: defs})
+	if err != nil || len(again.RegisteredTypes) != 0 || len(again.RegisteredStatuses) != 0 {
+		t.Errorf("re-import = %+v, %v; want nothing registered", again, err)
+	}
+
+	t.Run("conflict", func(t *testing.T) {
+		store := newTestStore(t)
+		if err := store.SetConfig(ctx, sqlite.TypeStatusesConfigKey, `{"spike":["open","blocked"]}`); err != nil {
+			t.Fatalf("SetConfig failed: %v", err)
+		}
+		defs, issues := parse(t)
+		_, err := ImportIssues(ctx, "", store, issues, Options{Definitions: defs})
+		var conflictErr *DefinitionConflictError
+		if !errors.Is(err, ErrDefinitionConflict) || !errors.As(err, &conflictErr) || len(conflictErr.Conflicts) != 1 || conflictErr.Conflicts[0].Type != "spike" {
+			t.Fatalf("err = %v, want a DefinitionConflictError for spike", err)
+		}
+		if !strings.Contains(err.Error(), "restricted to open, blocked in the database but to open, review in the import") {
+			t.Errorf("err = %v, want both restrictions named", err)
+		}
+		if got, _ := store.GetConfig(ctx, "status.custom"); got != "" {
+			t.Errorf("status.custom = %q, want nothing registered on conflict", got)
+		}
+		if got, _ := store.GetIssue(ctx, "test-2"); got != nil {
+			t.Error("a conflicting import should not create its issues")
+		}
+	})
+
+	t.Run("plain export", func(t *testing.T) {
+		lines := strings.SplitN(export, "\n", 2)
+		defs, issues, err := ParseSelfDescribing(strings.NewReader(lines[1]), ParseOptions{})
+		if err != nil || defs != nil || len(issues) != 2 {
+			t.Errorf("parsed %+v, %d issues, %v; want no definitions and 2 issues", defs, len(issues), err)
+		}
+		bad := lines[0] + "\n{\"id\":\n"
+		if _, _, err := ParseSelfDescribing(strings.NewReader(bad), ParseOptions{}); err == nil || !strings.Contains(err.Error(), "line 2") {
+			t.Errorf("err = %v, want the bad issue reported on line 2", err)
+		}
+	})
+}
@@ -0,0 +1,96 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// DepInversionPolicy decides what an import does with a dependency that
+// inverts an existing one: X depends on Y while Y already depends on X with
+// the same type, as when two merged databases recorded the edge in opposite
+// directions. The pair is contradictory: flag keeps both edges and leaves the
+// conflict for a person to settle, the other policies pick one or fail.
+type DepInversionPolicy string
+
+const (
+	DepInversionFlag        DepInversionPolicy = "flag"         // Keep both edges and record the conflict in Result.DependencyConflicts (default)
+	DepInversionError       DepInversionPolicy = "error"        // Fail the import with ErrDependencyInversion
+	DepInversionPreferNewer DepInversionPolicy = "prefer-newer" // Keep whichever edge has the later CreatedAt (the existing one on a tie) and record the conflict
+)
+
+// ErrDependencyInversion is returned (wrapped) under DepInversionError.
+var ErrDependencyInversion = errors.New("dependency inverts an existing dependency")
+
+// validateDepInversionPolicy rejects an unknown dependency inversion policy
+// before the import writes anything.
+func validateDepInversionPolicy(policy DepInversionPolicy) error {
+	switch policy {
+	case "", DepInversionFlag, DepInversionError, DepInversionPreferNewer:
+		return nil
+	default:
+		return fmt.Errorf("unknown dependency inversion policy %q (want flag, error or prefer-newer)", policy)
+	}
+}
+
+// dependencyStore is satisfied by both storage.Storage and storage.Transaction.
+type dependencyStore interface {
+	GetDependencyRecords(ctx context.Context, issueID string) ([]*types.Dependency, error)
+	RemoveDependency(ctx context.Context, issueID, dependsOnID string, actor string) error
+}
+
+// resolveDependencyInversion looks for the inverse of dep and, if there is
+// one, applies opts.DependencyInversions. It reports whether dep should still
+// be added, and returns the context to add it with: under flag one that lets
+// the backend store the cycle the pair forms; under prefer-newer a newer dep
+// first removes the existing edge. Bidirectional relates-to links are never
+// inversions.
+func resolveDependencyInversion(ctx context.Context, store dependencyStore, dep *types.Dependency, opts Options, result *Result) (context.Context, bool, error) {
+	if dep.Type == types.DepRelatesTo {
+		return ctx, true, nil
+	}
+	reverse, err := store.GetDependencyRecords(ctx, dep.DependsOnID)
+	if err != nil {
+		return ctx, false, fmt.Errorf("error checking dependencies for %s: %w", dep.DependsOnID, err)
+	}
+	var inverse *types.Dependency
+	for _, r := range reverse {
+		if r.DependsOnID == dep.IssueID && r.Type == dep.Type {
+			inverse = r
+			break
+		}
+	}
+	if inverse == nil {
+		return ctx, true, nil
+	}
+
+	desc := fmt.Sprintf("%s → %s (%s) inverts existing %s → %s", dep.IssueID, dep.DependsOnID, dep.Type, inverse.IssueID, inverse.DependsOnID)
+	add := true
+	switch opts.DependencyInversions {
+	case "", DepInversionFlag:
+		ctx = storage.WithoutCycleCheck(ctx)
+		desc += ": kept both"
+	case DepInversionError:
+		return ctx, false, fmt.Errorf("%w: %s", ErrDependencyInversion, desc)
+	case DepInversionPreferNewer:
+		if add = dep.CreatedAt.After(inverse.CreatedAt); add {
+			if err := store.RemoveDependency(ctx, inverse.IssueID, inverse.DependsOnID, "import"); err != nil {
+				return ctx, false, fmt.Errorf("error removing inverted dependency %s → %s: %w", inverse.IssueID, inverse.DependsOnID, err)
+			}
+			desc += ": kept incoming"
+		} else {
+			desc += ": kept existing"
+		}
+	default:
+		return ctx, false, fmt.Errorf("unknown dependency inversion policy %q (want flag, error or prefer-newer)", opts.DependencyInversions)
+	}
+	fmt.Fprintf(os.Stderr, "Warning: dependency conflict: %s\n", desc)
+	if result != nil {
+		result.DependencyConflicts = append(result.DependencyConflicts, desc)
+	}
+	return ctx, add, nil
+}
@@ -0,0 +1,142 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestImportIssues_DependencyInversions(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+	newIssue := func(id string, deps ...*types.Dependency) *types.Issue {
+		return &types.Issue{ID: id, Title: "Issue " + id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask,
+			CreatedAt: now, UpdatedAt: now, Dependencies: deps}
+	}
+	blocks := func(from, to string, at time.Time) *types.Dependency {
+		return &types.Dependency{IssueID: from, DependsOnID: to, Type: types.DepBlocks, CreatedAt: at}
+	}
+	// newStore holds test-2 depending on test-1, recorded at now
+	newStore := func() *sqlite.SQLiteStorage {
+		t.Helper()
+		store := newTestStore(t)
+		issues := []*types.Issue{newIssue("test-1"), newIssue("test-2", blocks("test-2", "test-1", now))}
+		if _, err := ImportIssues(ctx, "", store, issues, Options{}); err != nil {
+			t.Fatalf("ImportIssues failed: %v", err)
+		}
+		return store
+	}
+	// inverted is the other database's view: test-1 depending on test-2
+	inverted := func(at time.Time) []*types.Issue {
+		return []*types.Issue{newIssue("test-1", blocks("test-1", "test-2", at)), newIssue("test-2")}
+	}
+	dependsOn := func(store *sqlite.SQLiteStorage, id string) []string {
+		t.Helper()
+		deps, err := store.GetDependencyRecords(ctx, id)
+		if err != nil {
+			t.Fatalf("GetDependencyRecords failed: %v", err)
+		}
+		var ids []string
+		for _, dep := range deps {
+			ids = append(ids, dep.DependsOnID)
+		}
+		return ids
+	}
+	checkEdge := func(store *sqlite.SQLiteStorage, from, to string) {
+		t.Helper()
+		if got := dependsOn(store, from); len(got) != 1 || got[0] != to {
+			t.Errorf("%s depends on %v, want [%s]", from, got, to)
+		}
+		if got := dependsOn(store, to); len(got) != 0 {
+			t.Errorf("%s depends on %v, want nothing", to, got)
+		}
+	}
+
+	t.Run("flag keeps both edges", func(t *testing.T) {
+		store := newStore()
+		result, err := ImportIssues(ctx, "", store, inverted(now.Add(time.Hour)), Options{})
+		if err != nil {
+			t.Fatalf("ImportIssues failed: %v", err)
+		}
+		for from, to := range map[string]string{"test-1": "test-2", "test-2": "test-1"} {
+			if got := dependsOn(store, from); len(got) != 1 || got[0] != to {
+				t.Errorf("%s depends on %v, want [%s]", from, got, to)
+			}
+		}
+		if len(result.DependencyConflicts) != 1 || !strings.HasSuffix(result.DependencyConflicts[0], "kept both") {
+			t.Errorf("DependencyConflicts = %v, want one conflict keeping both edges", result.DependencyConflicts)
+		}
+		if got := result.ConflictReport().DependencyConflicts; len(got) != 1 {
+			t.Errorf("conflict report dependency_conflicts = %v, want the conflict", got)
+		}
+		if len(result.SkippedDependencies) != 0 {
+			t.Errorf("SkippedDependencies = %v, want none", result.SkippedDependencies)
+		}
+	})
+
+	t.Run("unknown policy fails before writing", func(t *testing.T) {
+		store := newStore()
+		issues := append(inverted(now.Add(time.Hour)), newIssue("test-3"))
+		if _, err := ImportIssues(ctx, "", store, issues, Options{DependencyInversions: "keep-all"}); err == nil || !strings.Contains(err.Error(), "unknown dependency inversion policy") {
+			t.Fatalf("expected an unknown policy error, got %v", err)
+		}
+		if got, _ := store.GetIssue(ctx, "test-3"); got != nil {
+			t.Error("an unknown policy should not import anything")
+		}
+		checkEdge(store, "test-2", "test-1")
+	})
+
+	t.Run("error fails the import", func(t *testing.T) {
+		store := newStore()
+		_, err := ImportIssues(ctx, "", store, inverted(now.Add(time.Hour)), Options{DependencyInversions: DepInversionError})
+		if !errors.Is(err, ErrDependencyInversion) {
+			t.Fatalf("expected ErrDependencyInversion, got %v", err)
+		}
+		checkEdge(store, "test-2", "test-1")
+	})
+
+	t.Run("prefer-newer replaces an older edge", func(t *testing.T) {
+		store := newStore()
+		result, err := ImportIssues(ctx, "", store, inverted(now.Add(time.Hour)), Options{DependencyInversions: DepInversionPreferNewer})
+		if err != nil {
+			t.Fatalf("ImportIssues failed: %v", err)
+		}
+		checkEdge(store, "test-1", "test-2")
+		if len(result.DependencyConflicts) != 1 || !strings.HasSuffix(result.DependencyConflicts[0], "kept incoming") {
+			t.Errorf("DependencyConflicts = %v, want one conflict keeping the incoming edge", result.DependencyConflicts)
+		}
+	})
+
+	t.Run("prefer-newer keeps a newer edge", func(t *testing.T) {
+		store := newStore()
+		result, err := ImportIssues(ctx, "", store, inverted(now.Add(-time.Hour)), Options{DependencyInversions: DepInversionPreferNewer})
+		if err != nil {
+			t.Fatalf("ImportIssues failed: %v", err)
+		}
+		checkEdge(store, "test-2", "test-1")
+		if len(result.DependencyConflicts) != 1 || !strings.HasSuffix(result.DependencyConflicts[0], "kept existing") {
+			t.Errorf("DependencyConflicts = %v, want one conflict keeping the existing edge", result.DependencyConflicts)
+		}
+	})
+
+	t.Run("contradictions within one import", func(t *testing.T) {
+		store := newStore()
+		issues := []*types.Issue{
+			newIssue("test-3", blocks("test-3", "test-4", now)),
+			newIssue("test-4", blocks("test-4", "test-3", now.Add(time.Hour))),
+		}
+		result, err := ImportIssues(ctx, "", store, issues, Options{DependencyInversions: DepInversionPreferNewer})
+		if err != nil {
+			t.Fatalf("ImportIssues failed: %v", err)
+		}
+		checkEdge(store, "test-4", "test-3")
+		if len(result.DependencyConflicts) != 1 {
+			t.Errorf("DependencyConflicts = %v, want one conflict", result.DependencyConflicts)
+		}
+	})
+}
@@ -0,0 +1,87 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ImportFromDirectory imports a one-file-per-issue directory as written by
+// SQLiteStorage.ExportToDirectory: every regular file in dir ending in
+// types.IssueFileExt holds one issue, named by its ID, apart from files that
+// are not issues at all, which are skipped. Fields this version
+// does not know are kept (UnknownFieldsPreserve), so the directory round-trips.
+// All files are read and checked before anything is written; the issues are
+// then imported as one ImportIssues call, parents before children, with the
+// usual orphan handling. Tombstone files delete their issues like tombstones
+// in JSONL.
+func ImportFromDirectory(ctx context.Context, dbPath string, store storage.Storage, dir string, opts Options) (*Result, error) {
+	issues, err := readDirectory(dir, opts.MaxIssues)
+	if err != nil {
+		return nil, err
+	}
+	SortByDepth(issues)
+	return ImportIssues(ctx, dbPath, store, issues, opts)
+}
+
+// readDirectory returns the issues of every issue file in dir, in file name
+// order. Files ending in types.IssueFileExt that do not hold a JSON object with
+// an "id", such as a stray config.json, are not issue files and are skipped,
+// as ExportToDirectory leaves them alone. maxIssues is checked against the
+// number of issue files before any is decoded.
+func readDirectory(dir string, maxIssues int) ([]*types.Issue, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read issue directory: %w", err)
+	}
+	var names []string
+	var files [][]byte
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.Type().IsRegular() || !strings.HasSuffix(name, types.IssueFileExt) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, name)) // #nosec G304 -- file in caller-supplied import directory
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		if !isIssueFile(data) {
+			continue
+		}
+		names = append(names, name)
+		files = append(files, data)
+	}
+	if err := checkMaxIssues(len(names), maxIssues); err != nil {
+		return nil, err
+	}
+
+	issues := make([]*types.Issue, 0, len(names))
+	for i, name := range names {
+		issue, err := DecodeIssue(files[i], UnknownFieldsPreserve)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if want, _ := types.IssueFileName(issue.ID); want != name {
+			return nil, fmt.Errorf("%s holds issue %q; its file should be named %s", name, issue.ID, want)
+		}
+		issue.SetDefaults()
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
+
+// isIssueFile reports whether data is a JSON object with an "id" field.
+func isIssueFile(data []byte) bool {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return false
+	}
+	_, ok := fields["id"]
+	return ok
+}
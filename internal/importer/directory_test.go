@@ -0,0 +1,110 @@
+package importer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestImportFromDirectory_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	src := newTestStore(t)
+	epic := &types.Issue{ID: "test-1", Title: "Epic", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeEpic}
+	child := &types.Issue{ID: "test-1.1", Title: "Child", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	gone := &types.Issue{ID: "test-2", Title: "Gone", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeBug}
+	for _, issue := range []*types.Issue{epic, child, gone} {
+		if err := src.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue(%s) failed: %v", issue.ID, err)
+		}
+	}
+	if err := src.AddDependency(ctx, &types.Dependency{IssueID: child.ID, DependsOnID: epic.ID, Type: types.DepParentChild}, "test-user"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+
+	dir := t.TempDir()
+	if err := src.ExportToDirectory(ctx, dir); err != nil {
+		t.Fatalf("ExportToDirectory failed: %v", err)
+	}
+	dst := newTestStore(t)
+	result, err := ImportFromDirectory(ctx, "", dst, dir, Options{})
+	if err != nil {
+		t.Fatalf("ImportFromDirectory failed: %v", err)
+	}
+	if result.Created != 3 {
+		t.Errorf("Created = %d, want 3", result.Created)
+	}
+	for _, want := range []*types.Issue{epic, child, gone} {
+		got, err := dst.GetIssue(ctx, want.ID)
+		if err != nil || got == nil || got.Title != want.Title || got.IssueType != want.IssueType {
+			t.Errorf("GetIssue(%s) = %+v, %v; want %q", want.ID, got, err, want.Title)
+		}
+	}
+	deps, err := dst.GetDependencyRecords(ctx, child.ID)
+	if err != nil || len(deps) != 1 || deps[0].DependsOnID != epic.ID || deps[0].Type != types.DepParentChild {
+		t.Errorf("GetDependencyRecords(%s) = %+v, %v; want its parent", child.ID, deps, err)
+	}
+
+	// A deletion travels as a tombstone file
+	if err := src.DeleteIssue(ctx, gone.ID); err != nil {
+		t.Fatalf("DeleteIssue failed: %v", err)
+	}
+	if err := src.ExportToDirectory(ctx, dir); err != nil {
+		t.Fatalf("second ExportToDirectory failed: %v", err)
+	}
+	if _, err := ImportFromDirectory(ctx, "", dst, dir, Options{}); err != nil {
+		t.Fatalf("second ImportFromDirectory failed: %v", err)
+	}
+	if got, err := dst.GetIssue(ctx, gone.ID); err != nil || got == nil || got.Status != types.StatusTombstone {
+		t.Errorf("GetIssue(%s) = %+v, %v; want a tombstone", gone.ID, got, err)
+	}
+}
+
+func TestImportFromDirectory_MisnamedFile(t *testing.T) {
+	dir := t.TempDir()
+	data := `{"id": "test-1", "title": "Renamed", "status": "open", "priority": 2, "issue_type": "task"}`
+	if err := os.WriteFile(filepath.Join(dir, "test-9.json"), []byte(data), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	_, err := readDirectory(dir, 0)
+	if err == nil || !strings.Contains(err.Error(), "should be named test-1.json") {
+		t.Errorf("expected misnamed file error, got %v", err)
+	}
+}
+
+func TestImportFromDirectory_SkipsNonIssueFiles(t *testing.T) {
+	ctx := context.Background()
+	src := newTestStore(t)
+	issue := &types.Issue{ID: "test-1", Title: "Kept", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := src.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	dir := t.TempDir()
+	for name, data := range map[string]string{
+		"config.json":   `{"sync": {"branch": "main"}}`,
+		"notes.json":    `not json at all`,
+		"versions.json": `["v1", "v2"]`,
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o600); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+	}
+	if err := src.ExportToDirectory(ctx, dir); err != nil {
+		t.Fatalf("ExportToDirectory failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "config.json")); err != nil {
+		t.Fatalf("expected ExportToDirectory to leave config.json alone: %v", err)
+	}
+
+	dst := newTestStore(t)
+	result, err := ImportFromDirectory(ctx, "", dst, dir, Options{MaxIssues: 1})
+	if err != nil {
+		t.Fatalf("ImportFromDirectory failed: %v", err)
+	}
+	if result.Created != 1 {
+		t.Errorf("Created = %d, want 1", result.Created)
+	}
+}
@@ -0,0 +1,51 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// DuplicateDepPolicy decides what an import does with a dependency edge its
+// issues list more than once (same issue, target and type), as hand-merged or
+// concatenated JSONL can carry. Edges identical to one already stored are
+// always left as they are, so re-importing the same data is idempotent.
+type DuplicateDepPolicy string
+
+const (
+	DuplicateDepsIgnore DuplicateDepPolicy = "ignore" // Add the edge once and ignore its repeats (default)
+	DuplicateDepsError  DuplicateDepPolicy = "error"  // Fail the import with a ValidationError wrapping ErrDuplicateDependency
+)
+
+// ErrDuplicateDependency is matched (via errors.Is) by the ValidationError
+// returned for a repeated dependency edge under DuplicateDepsError.
+var ErrDuplicateDependency = errors.New("dependency edge listed more than once")
+
+func validateDuplicateDepPolicy(policy DuplicateDepPolicy) error {
+	switch policy {
+	case "", DuplicateDepsIgnore, DuplicateDepsError:
+		return nil
+	default:
+		return fmt.Errorf("unknown duplicate dependency policy %q (want ignore or error)", policy)
+	}
+}
+
+// dependencyEdges tracks the dependency edges an import has already handled,
+// so repeats are told apart from edges that were stored before it.
+type dependencyEdges map[string]bool
+
+// repeated records dep and reports whether the same edge was already listed,
+// failing instead under DuplicateDepsError.
+func (seen dependencyEdges) repeated(dep *types.Dependency, opts Options) (bool, error) {
+	key := dep.IssueID + "|" + dep.DependsOnID + "|" + string(dep.Type)
+	if !seen[key] {
+		seen[key] = true
+		return false, nil
+	}
+	if opts.DuplicateDependencies == DuplicateDepsError {
+		return false, &ValidationError{IssueID: dep.IssueID,
+			Err: fmt.Errorf("%w: %s → %s (%s)", ErrDuplicateDependency, dep.IssueID, dep.DependsOnID, dep.Type)}
+	}
+	return true, nil
+}
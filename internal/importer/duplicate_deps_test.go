@@ -0,0 +1,65 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestImportIssues_DuplicateDependencies(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+	input := func() []*types.Issue {
+		dep := func() *types.Dependency {
+			return &types.Dependency{IssueID: "test-2", DependsOnID: "test-1", Type: types.DepBlocks, CreatedAt: now}
+		}
+		return []*types.Issue{
+			{ID: "test-1", Title: "Blocker", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now},
+			{ID: "test-2", Title: "Blocked", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now,
+				Dependencies: []*types.Dependency{dep(), dep()}},
+		}
+	}
+
+	store := newTestStore(t)
+	for run := 1; run <= 2; run++ {
+		result, err := ImportIssues(ctx, "", store, input(), Options{Strict: true})
+		if err != nil {
+			t.Fatalf("import %d failed: %v", run, err)
+		}
+		if len(result.SkippedDependencies) != 0 {
+			t.Errorf("import %d skipped dependencies %v, want the repeat ignored", run, result.SkippedDependencies)
+		}
+		deps, err := store.GetDependencyRecords(ctx, "test-2")
+		if err != nil || len(deps) != 1 || deps[0].DependsOnID != "test-1" {
+			t.Errorf("after import %d dependencies = %v, %v; want the single edge", run, deps, err)
+		}
+	}
+
+	t.Run("error", func(t *testing.T) {
+		store := newTestStore(t)
+		_, err := ImportIssues(ctx, "", store, input(), Options{DuplicateDependencies: DuplicateDepsError})
+		var valErr *ValidationError
+		if !errors.Is(err, ErrDuplicateDependency) || !errors.As(err, &valErr) || valErr.IssueID != "test-2" {
+			t.Fatalf("err = %v, want a ValidationError for test-2 wrapping ErrDuplicateDependency", err)
+		}
+		if got, _ := store.GetIssue(ctx, "test-1"); got != nil {
+			t.Error("a failed import should not create its issues")
+		}
+
+		// A single listing of a stored edge is not a duplicate
+		issues := input()
+		issues[1].Dependencies = issues[1].Dependencies[:1]
+		for run := 1; run <= 2; run++ {
+			if _, err := ImportIssues(ctx, "", store, issues, Options{DuplicateDependencies: DuplicateDepsError}); err != nil {
+				t.Fatalf("import %d of stored edges failed: %v", run, err)
+			}
+		}
+	})
+
+	if _, err := ImportIssues(ctx, "", newTestStore(t), input(), Options{DuplicateDependencies: "merge"}); err == nil {
+		t.Error("expected an unknown policy to be rejected")
+	}
+}
@@ -0,0 +1,102 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// The error types below classify import failures so callers can tell them
+// apart with errors.As (e.g. to print category-specific guidance). Each one
+// names the offending issue and wraps the underlying cause.
+
+// PrefixError reports an issue whose ID prefix the database does not accept,
+// or whose ID cannot be rewritten to the configured prefix.
+type PrefixError struct {
+	IssueID  string // First offending issue
+	Prefix   string // Prefix found on IssueID, if it has one
+	Expected string // Configured issue_prefix
+	Err      error
+}
+
+func (e *PrefixError) Error() string { return issueErrorString(e.IssueID, e.Err) }
+func (e *PrefixError) Unwrap() error { return e.Err }
+
+// ValidationError reports an issue whose content fails validation, e.g. a
+// missing title, an unknown status or type, or an out-of-range priority.
+type ValidationError struct {
+	IssueID string
+	Err     error
+}
+
+func (e *ValidationError) Error() string { return issueErrorString(e.IssueID, e.Err) }
+func (e *ValidationError) Unwrap() error { return e.Err }
+
+// OrphanError reports a hierarchical child whose parent neither exists nor
+// could be supplied under the configured OrphanHandling.
+type OrphanError struct {
+	IssueID  string // The child
+	ParentID string // Its missing parent
+	Err      error
+}
+
+func (e *OrphanError) Error() string { return issueErrorString(e.IssueID, e.Err) }
+func (e *OrphanError) Unwrap() error { return e.Err }
+
+// DuplicateIDError reports an identifier claimed by more than one issue in
+// the batch. IssueID is the first claimant and Duplicates lists the rest; the
+// message lists every duplicated value, so it is not prefixed with IssueID.
+type DuplicateIDError struct {
+	IssueID    string
+	Field      string // Duplicated field, e.g. "external_ref"
+	Value      string
+	Duplicates []string
+	Err        error
+}
+
+func (e *DuplicateIDError) Error() string { return e.Err.Error() }
+func (e *DuplicateIDError) Unwrap() error { return e.Err }
+
+func issueErrorString(issueID string, err error) string {
+	if issueID == "" {
+		return err.Error()
+	}
+	return fmt.Sprintf("issue %s: %v", issueID, err)
+}
+
+// attributeValidationError turns err, returned while storing issues, into a
+// ValidationError for the first of issues that fails validation against the
+// custom statuses and types in cfg. err is returned as is when every issue
+// validates, since the failure lies elsewhere.
+func attributeValidationError(ctx context.Context, cfg configStore, issues []*types.Issue, err error) error {
+	if err == nil {
+		return nil
+	}
+	customStatuses := customConfigList(ctx, cfg, "status.custom", nil)
+	customTypes := customConfigList(ctx, cfg, customTypesConfigKey, config.GetCustomTypesFromYAML)
+	for _, issue := range issues {
+		if issue.ValidateWithCustom(customStatuses, customTypes) != nil {
+			return &ValidationError{IssueID: issue.ID, Err: err}
+		}
+	}
+	return err
+}
+
+// customConfigList reads a comma-separated custom status or type list,
+// falling back to fromYAML (if set) like the storage layer does for types.
+func customConfigList(ctx context.Context, cfg configStore, key string, fromYAML func() []string) []string {
+	value, _ := cfg.GetConfig(ctx, key)
+	var list []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	if len(list) == 0 && fromYAML != nil {
+		return fromYAML()
+	}
+	return list
+}
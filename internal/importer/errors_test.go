@@ -0,0 +1,124 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestImportIssues_ErrorCategories(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	newIssue := func(id string) *types.Issue {
+		return &types.Issue{ID: id, Title: "Issue " + id, Status: types.StatusOpen, Priority: 2,
+			IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now}
+	}
+	ref := "JIRA-1"
+
+	tests := []struct {
+		name   string
+		issues func() []*types.Issue
+		opts   Options
+		check  func(t *testing.T, err error) bool
+	}{
+		{
+			name:   "prefix",
+			issues: func() []*types.Issue { return []*types.Issue{newIssue("test-1"), newIssue("other-1")} },
+			check: func(t *testing.T, err error) bool {
+				var target *PrefixError
+				if !errors.As(err, &target) {
+					return false
+				}
+				if target.Prefix != "other" || target.Expected != "test" {
+					t.Errorf("unexpected prefixes in %+v", target)
+				}
+				return target.IssueID == "other-1"
+			},
+		},
+		{
+			name: "validation",
+			issues: func() []*types.Issue {
+				bad := newIssue("test-2")
+				bad.Priority = 9
+				return []*types.Issue{newIssue("test-1"), bad}
+			},
+			check: func(t *testing.T, err error) bool {
+				var target *ValidationError
+				return errors.As(err, &target) && target.IssueID == "test-2"
+			},
+		},
+		{
+			name:   "orphan",
+			issues: func() []*types.Issue { return []*types.Issue{newIssue("test-abc.1")} },
+			opts:   Options{OrphanHandling: OrphanStrict},
+			check: func(t *testing.T, err error) bool {
+				var target *OrphanError
+				if !errors.As(err, &target) {
+					return false
+				}
+				if target.ParentID != "test-abc" {
+					t.Errorf("expected parent test-abc, got %s", target.ParentID)
+				}
+				return target.IssueID == "test-abc.1"
+			},
+		},
+		{
+			name: "duplicate",
+			issues: func() []*types.Issue {
+				a, b := newIssue("test-1"), newIssue("test-2")
+				a.ExternalRef, b.ExternalRef = &ref, &ref
+				return []*types.Issue{a, b}
+			},
+			check: func(t *testing.T, err error) bool {
+				var target *DuplicateIDError
+				if !errors.As(err, &target) {
+					return false
+				}
+				if target.Field != "external_ref" || target.Value != ref || len(target.Duplicates) != 1 || target.Duplicates[0] != "test-2" {
+					t.Errorf("unexpected duplicate details %+v", target)
+				}
+				return target.IssueID == "test-1"
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestStore(t)
+
+			_, err := ImportIssues(ctx, "", store, tt.issues(), tt.opts)
+			if err == nil {
+				t.Fatal("expected import to fail")
+			}
+			if !tt.check(t, err) {
+				t.Fatalf("expected %s error, got %T: %v", tt.name, err, err)
+			}
+
+			// No other category matches
+			var prefixErr *PrefixError
+			var validationErr *ValidationError
+			var orphanErr *OrphanError
+			var duplicateErr *DuplicateIDError
+			matched := 0
+			for _, ok := range []bool{errors.As(err, &prefixErr), errors.As(err, &validationErr), errors.As(err, &orphanErr), errors.As(err, &duplicateErr)} {
+				if ok {
+					matched++
+				}
+			}
+			if matched != 1 {
+				t.Errorf("expected exactly one error category to match, got %d: %v", matched, err)
+			}
+		})
+	}
+}
+
+func TestRenameImportedIssuePrefixes_PrefixError(t *testing.T) {
+	err := RenameImportedIssuePrefixes([]*types.Issue{{ID: "nohyphen", Title: "Invalid"}}, "new")
+	var target *PrefixError
+	if !errors.As(err, &target) || target.IssueID != "nohyphen" || target.Expected != "new" {
+		t.Fatalf("expected PrefixError for nohyphen, got %v", err)
+	}
+}
@@ -0,0 +1,46 @@
+package importer
+
+// ImportEventKind is what an import did with one issue.
+type ImportEventKind string
+
+const (
+	ImportEventCreated   ImportEventKind = "created"
+	ImportEventUpdated   ImportEventKind = "updated"
+	ImportEventUnchanged ImportEventKind = "unchanged"
+	ImportEventSkipped   ImportEventKind = "skipped"
+	ImportEventConflict  ImportEventKind = "conflict" // Content hash collision (see Options.HashCollisions); a created/skipped event may follow
+)
+
+// ImportEvent reports the outcome for one issue as the import processes it.
+type ImportEvent struct {
+	Kind    ImportEventKind
+	IssueID string
+}
+
+// note counts an outcome for issueID in r and streams it to Options.ImportEvents.
+// Conflicts are only streamed; they are tallied in HashCollisions.
+//
+// Sends never block: the import holds a write transaction, so waiting on a
+// slow consumer would stall every other writer. When the channel is full the
+// event is dropped and counted in DroppedEvents, so callers that need every
+// event should give the channel a buffer at least as large as the import.
+func (r *Result) note(kind ImportEventKind, issueID string) {
+	switch kind {
+	case ImportEventCreated:
+		r.Created++
+	case ImportEventUpdated:
+		r.Updated++
+	case ImportEventUnchanged:
+		r.Unchanged++
+	case ImportEventSkipped:
+		r.Skipped++
+	}
+	if r.events == nil {
+		return
+	}
+	select {
+	case r.events <- ImportEvent{Kind: kind, IssueID: issueID}:
+	default:
+		r.DroppedEvents++
+	}
+}
@@ -0,0 +1,68 @@
+package importer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestImportIssues_ImportEvents(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	store := newTestStore(t)
+
+	existing := &types.Issue{ID: "test-1", Title: "Existing", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now}
+	same := &types.Issue{ID: "test-2", Title: "Same", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now}
+	for _, issue := range []*types.Issue{existing, same} {
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	issues := []*types.Issue{
+		{ID: "test-1", Title: "Existing, renamed", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now.Add(time.Minute)},
+		{ID: "test-2", Title: "Same", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now},
+		{ID: "test-3", Title: "New", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now},
+	}
+
+	t.Run("buffered", func(t *testing.T) {
+		events := make(chan ImportEvent, len(issues))
+		result, err := ImportIssues(ctx, "", store, issues, Options{ImportEvents: events})
+		if err != nil {
+			t.Fatalf("ImportIssues failed: %v", err)
+		}
+		got := make(map[string]ImportEventKind)
+		for event := range events { // closed by ImportIssues
+			got[event.IssueID] = event.Kind
+		}
+		want := map[string]ImportEventKind{
+			"test-1": ImportEventUpdated,
+			"test-2": ImportEventUnchanged,
+			"test-3": ImportEventCreated,
+		}
+		for id, kind := range want {
+			if got[id] != kind {
+				t.Errorf("event for %s = %q, want %q", id, got[id], kind)
+			}
+		}
+		if len(got) != len(want) || result.DroppedEvents != 0 {
+			t.Errorf("got events %v with %d dropped, want %v", got, result.DroppedEvents, want)
+		}
+	})
+
+	t.Run("full channel drops instead of blocking", func(t *testing.T) {
+		events := make(chan ImportEvent) // nobody receives
+		result, err := ImportIssues(ctx, "", store, issues, Options{ImportEvents: events})
+		if err != nil {
+			t.Fatalf("ImportIssues failed: %v", err)
+		}
+		if result.DroppedEvents != len(issues) {
+			t.Errorf("DroppedEvents = %d, want %d", result.DroppedEvents, len(issues))
+		}
+		if _, ok := <-events; ok {
+			t.Error("expected the channel to be closed")
+		}
+	})
+}
@@ -0,0 +1,44 @@
+package importer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ExpiredImportPolicy decides what an import does with issues whose ExpiresAt
+// has already passed.
+type ExpiredImportPolicy string
+
+const (
+	ExpiredImportKeep      ExpiredImportPolicy = "keep"      // Import as-is; the next expiry sweep tombstones them (default)
+	ExpiredImportTombstone ExpiredImportPolicy = "tombstone" // Import them as tombstones straight away
+)
+
+// applyExpiredImportPolicy enforces policy on issues as of now. Under
+// ExpiredImportTombstone, expired issues are converted to tombstones the way
+// the expiry sweep would, before their content hashes are computed.
+func applyExpiredImportPolicy(issues []*types.Issue, policy ExpiredImportPolicy, now time.Time) error {
+	switch policy {
+	case "", ExpiredImportKeep:
+		return nil
+	case ExpiredImportTombstone:
+	default:
+		return fmt.Errorf("unknown expired import policy %q (want keep or tombstone)", policy)
+	}
+
+	for _, issue := range issues {
+		if issue.ExpiresAt == nil || issue.ExpiresAt.After(now) || issue.Status == types.StatusTombstone {
+			continue
+		}
+		deletedAt := now
+		issue.OriginalType = string(issue.IssueType)
+		issue.Status = types.StatusTombstone
+		issue.ClosedAt = nil
+		issue.DeletedAt = &deletedAt
+		issue.DeletedBy = "import"
+		issue.DeleteReason = "expired"
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package importer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestImportIssues_ExpiredOnImport(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	past := time.Now().Add(-time.Hour).UTC()
+	future := time.Now().Add(24 * time.Hour).UTC()
+	input := func(id string, expiresAt time.Time) []*types.Issue {
+		return []*types.Issue{{ID: id, Title: "Ephemeral " + id, Status: types.StatusOpen, Priority: 2,
+			IssueType: types.TypeTask, ExpiresAt: &expiresAt}}
+	}
+
+	// The field is preserved
+	if _, err := ImportIssues(ctx, "", store, input("test-live", future), Options{}); err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	live, _ := store.GetIssue(ctx, "test-live")
+	if live == nil || live.ExpiresAt == nil || !live.ExpiresAt.Equal(future) {
+		t.Fatalf("expected ExpiresAt %v preserved, got %+v", future, live)
+	}
+
+	// By default an already-expired issue imports live and waits for the sweep
+	if _, err := ImportIssues(ctx, "", store, input("test-kept", past), Options{}); err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	if kept, _ := store.GetIssue(ctx, "test-kept"); kept == nil || kept.Status != types.StatusOpen {
+		t.Errorf("keep policy: expected open issue, got %+v", kept)
+	}
+
+	if _, err := ImportIssues(ctx, "", store, input("test-gone", past), Options{ExpiredOnImport: ExpiredImportTombstone}); err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	gone, _ := store.GetIssue(ctx, "test-gone")
+	if gone == nil || gone.Status != types.StatusTombstone || gone.DeleteReason != "expired" || gone.OriginalType != string(types.TypeTask) {
+		t.Errorf("tombstone policy: expected expired tombstone, got %+v", gone)
+	}
+
+	// Unexpired issues are unaffected by the tombstone policy
+	if _, err := ImportIssues(ctx, "", store, input("test-later", future), Options{ExpiredOnImport: ExpiredImportTombstone}); err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	if later, _ := store.GetIssue(ctx, "test-later"); later == nil || later.Status != types.StatusOpen {
+		t.Errorf("tombstone policy: expected unexpired issue open, got %+v", later)
+	}
+
+	if _, err := ImportIssues(ctx, "", store, input("test-bad", past), Options{ExpiredOnImport: "drop"}); err == nil {
+		t.Error("expected error for unknown policy")
+	}
+}
+
+func TestImportIssues_UpdatesExpiresAt(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	created := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	input := func(updatedAt time.Time, expiresAt *time.Time) []*types.Issue {
+		return []*types.Issue{{ID: "test-1", Title: "Ephemeral", Status: types.StatusOpen, Priority: 2,
+			IssueType: types.TypeTask, CreatedAt: created, UpdatedAt: updatedAt, ExpiresAt: expiresAt}}
+	}
+	if _, err := ImportIssues(ctx, "", store, input(created, nil), Options{}); err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+
+	// expires_at is the only field that changes, on each re-import
+	for i, offset := range []time.Duration{24 * time.Hour, 48 * time.Hour} {
+		expiresAt := time.Now().Add(offset).UTC().Truncate(time.Second)
+		result, err := ImportIssues(ctx, "", store, input(time.Now().Add(time.Duration(i+1)*time.Minute), &expiresAt), Options{})
+		if err != nil {
+			t.Fatalf("import failed: %v", err)
+		}
+		if result.Updated != 1 {
+			t.Errorf("expected the new expiry to update the issue, got %+v", result)
+		}
+		got, _ := store.GetIssue(ctx, "test-1")
+		if got == nil || got.ExpiresAt == nil || !got.ExpiresAt.Equal(expiresAt) {
+			t.Fatalf("expected ExpiresAt %v stored, got %+v", expiresAt, got)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// FieldLintReport is the result of LintIssueFields: the keys of a sample of
+// issue objects that map to no issue field, and would be dropped on import.
+type FieldLintReport struct {
+	Objects int                 `json:"objects"` // Objects examined
+	Unknown []UnknownFieldCount `json:"unknown"` // Unrecognized keys, most frequent first
+}
+
+// UnknownFieldCount is one unrecognized key and the number of objects that
+// carry it.
+type UnknownFieldCount struct {
+	Field      string `json:"field"`
+	Count      int    `json:"count"`
+	Suggestion string `json:"suggestion,omitempty"` // Closest known field, when the key looks like a typo or variant of one
+}
+
+// LintIssueFields reports the top-level keys of objects that are not issue
+// fields (see types.IssueJSONFields), with the number of objects each appears
+// in, so integrators can catch mapping mistakes before an import silently
+// drops the data. Keys starting with "_" are export record markers and are not
+// reported. Every object must be a JSON object.
+func LintIssueFields(objects []json.RawMessage) (*FieldLintReport, error) {
+	counts := make(map[string]int)
+	for i, raw := range objects {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			return nil, fmt.Errorf("object %d: %w", i+1, err)
+		}
+		for name := range fields {
+			if !strings.HasPrefix(name, "_") && !types.IsIssueJSONField(name) {
+				counts[name]++
+			}
+		}
+	}
+
+	report := &FieldLintReport{Objects: len(objects), Unknown: []UnknownFieldCount{}}
+	for name, count := range counts {
+		report.Unknown = append(report.Unknown, UnknownFieldCount{Field: name, Count: count, Suggestion: suggestIssueField(name)})
+	}
+	sort.Slice(report.Unknown, func(i, j int) bool {
+		if report.Unknown[i].Count != report.Unknown[j].Count {
+			return report.Unknown[i].Count > report.Unknown[j].Count
+		}
+		return report.Unknown[i].Field < report.Unknown[j].Field
+	})
+	return report, nil
+}
+
+// suggestIssueField returns the known field name closest to name: one that
+// differs only in case and separators (createdAt for created_at), or else one
+// within two edits of a key at least that long. It returns "" when nothing is
+// that close.
+func suggestIssueField(name string) string {
+	normalize := func(s string) string {
+		return strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(s))
+	}
+	target := normalize(name)
+	best, bestDistance := "", 3
+	for _, field := range types.IssueJSONFields() {
+		candidate := normalize(field)
+		if candidate == target {
+			return field
+		}
+		if d := editDistance(candidate, target); d < bestDistance && 2*d < len(target) {
+			best, bestDistance = field, d
+		}
+	}
+	return best
+}
+
+// editDistance is the Levenshtein distance between a and b, by byte.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
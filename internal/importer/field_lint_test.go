@@ -0,0 +1,42 @@
+package importer
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestLintIssueFields(t *testing.T) {
+	objects := []json.RawMessage{
+		json.RawMessage(`{"id":"ext-1","title":"A","status":"open","assignee":"alice"}`),
+		json.RawMessage(`{"id":"ext-2","titel":"B","createdAt":"2026-01-01T00:00:00Z","sprint_name":"s1"}`),
+		json.RawMessage(`{"id":"ext-3","titel":"C","_deleted":true,"sprint_name":"s2"}`),
+	}
+	report, err := LintIssueFields(objects)
+	if err != nil {
+		t.Fatalf("LintIssueFields failed: %v", err)
+	}
+	want := &FieldLintReport{
+		Objects: 3,
+		Unknown: []UnknownFieldCount{
+			{Field: "sprint_name", Count: 2},
+			{Field: "titel", Count: 2, Suggestion: "title"},
+			{Field: "createdAt", Count: 1, Suggestion: "created_at"},
+		},
+	}
+	if !reflect.DeepEqual(report, want) {
+		t.Errorf("LintIssueFields = %+v, want %+v", report, want)
+	}
+
+	clean, err := LintIssueFields(objects[:1])
+	if err != nil {
+		t.Fatalf("LintIssueFields failed: %v", err)
+	}
+	if len(clean.Unknown) != 0 {
+		t.Errorf("known fields reported: %+v", clean.Unknown)
+	}
+
+	if _, err := LintIssueFields([]json.RawMessage{json.RawMessage(`["not", "an", "object"]`)}); err == nil {
+		t.Error("expected a non-object to be rejected")
+	}
+}
@@ -0,0 +1,74 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// FutureTimestampPolicy decides what an import does with issues whose
+// created_at, updated_at, closed_at or deleted_at lies in the future. Due and
+// defer dates (and expiry times) are meant to be in the future and are never
+// checked.
+type FutureTimestampPolicy string
+
+const (
+	FutureTimestampsAccept FutureTimestampPolicy = "accept" // Import as-is (default)
+	FutureTimestampsError  FutureTimestampPolicy = "error"  // Fail the import with ErrFutureTimestamp
+	FutureTimestampsClamp  FutureTimestampPolicy = "clamp"  // Move future timestamps back to the import time, with a warning
+)
+
+// futureTimestampSkew is how far ahead of the importing clock a timestamp may
+// be before it counts as future-dated, absorbing ordinary clock drift.
+const futureTimestampSkew = time.Minute
+
+// ErrFutureTimestamp is returned (wrapped) under FutureTimestampsError.
+var ErrFutureTimestamp = errors.New("future-dated timestamp")
+
+// applyFutureTimestampPolicy enforces policy on issues as of now. Under
+// FutureTimestampsClamp the IDs of clamped issues are added to
+// result.ClampedTimestamps.
+func applyFutureTimestampPolicy(issues []*types.Issue, policy FutureTimestampPolicy, now time.Time, result *Result) error {
+	switch policy {
+	case "", FutureTimestampsAccept:
+		return nil
+	case FutureTimestampsError, FutureTimestampsClamp:
+	default:
+		return fmt.Errorf("unknown future timestamp policy %q (want accept, error or clamp)", policy)
+	}
+
+	limit := now.Add(futureTimestampSkew)
+	for _, issue := range issues {
+		var future []string
+		for _, field := range []struct {
+			name string
+			t    *time.Time
+		}{
+			{"created_at", &issue.CreatedAt},
+			{"updated_at", &issue.UpdatedAt},
+			{"closed_at", issue.ClosedAt},
+			{"deleted_at", issue.DeletedAt},
+		} {
+			if field.t == nil || !field.t.After(limit) {
+				continue
+			}
+			future = append(future, fmt.Sprintf("%s %s", field.name, field.t.Format(time.RFC3339)))
+			if policy == FutureTimestampsClamp {
+				*field.t = now
+			}
+		}
+		if len(future) == 0 {
+			continue
+		}
+		if policy == FutureTimestampsError {
+			return fmt.Errorf("%w: issue %s has %s (import time %s)", ErrFutureTimestamp, issue.ID, strings.Join(future, ", "), now.Format(time.RFC3339))
+		}
+		fmt.Fprintf(os.Stderr, "Warning: Clamped future-dated %s of %s to import time\n", strings.Join(future, ", "), issue.ID)
+		result.ClampedTimestamps = append(result.ClampedTimestamps, issue.ID)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestImportIssues_FutureTimestamps(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	future := time.Now().Add(30 * 24 * time.Hour)
+	input := func(id string) []*types.Issue {
+		return []*types.Issue{{ID: id, Title: "From the future " + id, Status: types.StatusOpen, Priority: 2,
+			IssueType: types.TypeTask, CreatedAt: future, UpdatedAt: future}}
+	}
+
+	if _, err := ImportIssues(ctx, "", store, input("test-err"), Options{FutureTimestamps: FutureTimestampsError}); !errors.Is(err, ErrFutureTimestamp) {
+		t.Fatalf("error policy: expected ErrFutureTimestamp, got %v", err)
+	}
+	if issue, _ := store.GetIssue(ctx, "test-err"); issue != nil {
+		t.Error("error policy: expected nothing imported")
+	}
+
+	before := time.Now()
+	result, err := ImportIssues(ctx, "", store, input("test-clamp"), Options{FutureTimestamps: FutureTimestampsClamp})
+	if err != nil {
+		t.Fatalf("clamp policy: import failed: %v", err)
+	}
+	if len(result.ClampedTimestamps) != 1 || result.ClampedTimestamps[0] != "test-clamp" {
+		t.Errorf("clamp policy: expected test-clamp reported, got %v", result.ClampedTimestamps)
+	}
+	clamped, _ := store.GetIssue(ctx, "test-clamp")
+	if clamped == nil || clamped.CreatedAt.Before(before.Add(-time.Second)) || clamped.CreatedAt.After(time.Now()) {
+		t.Errorf("clamp policy: expected created_at clamped to import time, got %+v", clamped)
+	}
+
+	if _, err := ImportIssues(ctx, "", store, input("test-accept"), Options{FutureTimestamps: FutureTimestampsAccept}); err != nil {
+		t.Fatalf("accept policy: import failed: %v", err)
+	}
+	accepted, _ := store.GetIssue(ctx, "test-accept")
+	if accepted == nil || accepted.CreatedAt.Sub(future).Abs() > time.Second {
+		t.Errorf("accept policy: expected created_at kept at %v, got %+v", future, accepted)
+	}
+
+	// Small clock drift is not future-dated
+	drift := []*types.Issue{{ID: "test-drift", Title: "Drift", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask,
+		CreatedAt: time.Now().Add(10 * time.Second), UpdatedAt: time.Now()}}
+	if _, err := ImportIssues(ctx, "", store, drift, Options{FutureTimestamps: FutureTimestampsError}); err != nil {
+		t.Errorf("expected drift within tolerance accepted, got %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// HashCollisionPolicy decides what an import does when an incoming issue has
+// the same content hash as an existing (or earlier incoming) issue but
+// different content, a genuine hash collision.
+type HashCollisionPolicy string
+
+const (
+	HashCollisionConflict HashCollisionPolicy = "conflict" // Treat the issues as different content: update by ID or create (default)
+	HashCollisionError    HashCollisionPolicy = "error"    // Fail the import with ErrHashCollision
+	HashCollisionIgnore   HashCollisionPolicy = "ignore"   // Dedup on the hash as if the content matched (still logged)
+)
+
+// ErrHashCollision is returned (wrapped) under HashCollisionError.
+var ErrHashCollision = errors.New("content hash collision")
+
+// computeContentHash computes the unsalted content hash of incoming issues;
+// tests replace it to force collisions.
+var computeContentHash = (*types.Issue).ComputeContentHash
+
+// loadHashOptions reads the content hash settings of the target database.
+func loadHashOptions(ctx context.Context, store configStore) (types.ContentHashOptions, error) {
+	salt, err := store.GetConfig(ctx, storage.HashSaltConfigKey)
+	if err != nil {
+		return types.ContentHashOptions{}, fmt.Errorf("failed to get hash salt: %w", err)
+	}
+	display, err := store.GetConfig(ctx, storage.HashDisplayFieldsConfigKey)
+	if err != nil {
+		return types.ContentHashOptions{}, fmt.Errorf("failed to get %s: %w", storage.HashDisplayFieldsConfigKey, err)
+	}
+	return types.ContentHashOptions{Salt: salt, DisplayFields: display == "true"}, nil
+}
+
+// issueContentHash hashes an incoming issue the way the target database
+// hashes its own, under its salt and display settings (see
+// storage.HashSaltConfigKey and storage.HashDisplayFieldsConfigKey).
+func issueContentHash(issue *types.Issue, opts Options) string {
+	if opts.hashOptions == (types.ContentHashOptions{}) {
+		return computeContentHash(issue)
+	}
+	return issue.ComputeContentHashWith(opts.hashOptions)
+}
+
+// sameDisplayMetadata reports whether a and b agree on the display metadata,
+// which content hashes leave out unless configured.
+func sameDisplayMetadata(a, b *types.Issue) bool {
+	return a.Color == b.Color && a.DisplayOrder == b.DisplayOrder && a.Rank == b.Rank
+}
+
+// isHashDuplicate reports whether incoming, whose content hash matches that
+// of matched, is really a duplicate of it. A collision (same hash, different
+// content) is logged and recorded in result.HashCollisions, then resolved by
+// opts.HashCollisions. Issues that differ only in display metadata are not
+// duplicates, so one that was only recolored or moved is still updated.
+//
+// Stored issues are not always loaded with every hashed field, so matched is
+// only compared when its loaded fields reproduce its stored hash; otherwise
+// the hash is trusted.
+func isHashDuplicate(matched, incoming *types.Issue, opts Options, result *Result) (bool, error) {
+	if !sameDisplayMetadata(matched, incoming) {
+		return false, nil
+	}
+	if matched.Equal(incoming) || issueContentHash(matched, opts) != matched.ContentHash {
+		return true, nil
+	}
+
+	desc := fmt.Sprintf("%s and %s share content hash %s", incoming.ID, matched.ID, incoming.ContentHash)
+	fmt.Fprintf(os.Stderr, "Warning: content hash collision: %s but differ in content\n", desc)
+	result.HashCollisions = append(result.HashCollisions, desc)
+	result.note(ImportEventConflict, incoming.ID)
+
+	switch opts.HashCollisions {
+	case "", HashCollisionConflict:
+		return false, nil
+	case HashCollisionError:
+		return false, fmt.Errorf("%w: %s", ErrHashCollision, desc)
+	case HashCollisionIgnore:
+		return true, nil
+	default:
+		return false, fmt.Errorf("unknown hash collision policy %q (want conflict, error or ignore)", opts.HashCollisions)
+	}
+}
@@ -0,0 +1,133 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// stubContentHash makes every issue hash to the same value for the test.
+func stubContentHash(t *testing.T) {
+	t.Helper()
+	orig := computeContentHash
+	computeContentHash = func(*types.Issue) string { return "collide" }
+	t.Cleanup(func() { computeContentHash = orig })
+}
+
+func TestImportIssues_HashCollision(t *testing.T) {
+	stubContentHash(t)
+	ctx := context.Background()
+	newStore := func(t *testing.T) *sqlite.SQLiteStorage {
+		store := newTestStore(t)
+		local := &types.Issue{ID: "test-1", ContentHash: "collide", Title: "Local", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, local, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return store
+	}
+	incoming := func(title string) []*types.Issue {
+		now := time.Now().Add(time.Second)
+		return []*types.Issue{{ID: "test-1", Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now}}
+	}
+
+	t.Run("conflict updates instead of dedup", func(t *testing.T) {
+		store := newStore(t)
+		result, err := ImportIssues(ctx, "", store, incoming("Upstream"), Options{})
+		if err != nil {
+			t.Fatalf("ImportIssues failed: %v", err)
+		}
+		if result.Updated != 1 || result.Unchanged != 0 || len(result.HashCollisions) != 1 {
+			t.Errorf("expected 1 updated and 1 collision, got %+v", result)
+		}
+		if issue, _ := store.GetIssue(ctx, "test-1"); issue.Title != "Upstream" {
+			t.Errorf("expected colliding update applied, got title %q", issue.Title)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		store := newStore(t)
+		if _, err := ImportIssues(ctx, "", store, incoming("Upstream"), Options{HashCollisions: HashCollisionError}); !errors.Is(err, ErrHashCollision) {
+			t.Fatalf("expected ErrHashCollision, got %v", err)
+		}
+		if issue, _ := store.GetIssue(ctx, "test-1"); issue.Title != "Local" {
+			t.Errorf("expected local issue untouched, got title %q", issue.Title)
+		}
+	})
+
+	t.Run("ignore keeps legacy dedup", func(t *testing.T) {
+		store := newStore(t)
+		result, err := ImportIssues(ctx, "", store, incoming("Upstream"), Options{HashCollisions: HashCollisionIgnore})
+		if err != nil {
+			t.Fatalf("ImportIssues failed: %v", err)
+		}
+		if result.Unchanged != 1 || len(result.HashCollisions) != 1 {
+			t.Errorf("expected unchanged with the collision logged, got %+v", result)
+		}
+	})
+
+	t.Run("real duplicate is still a dedup", func(t *testing.T) {
+		store := newStore(t)
+		result, err := ImportIssues(ctx, "", store, incoming("Local"), Options{})
+		if err != nil {
+			t.Fatalf("ImportIssues failed: %v", err)
+		}
+		if result.Unchanged != 1 || len(result.HashCollisions) != 0 {
+			t.Errorf("expected unchanged without collision, got %+v", result)
+		}
+	})
+
+	t.Run("within the batch", func(t *testing.T) {
+		store := newStore(t)
+		batch := []*types.Issue{
+			{ID: "test-2", Title: "Second", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+			{ID: "test-3", Title: "Third", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeBug},
+		}
+		result, err := ImportIssues(ctx, "", store, batch, Options{})
+		if err != nil {
+			t.Fatalf("ImportIssues failed: %v", err)
+		}
+		if result.Created != 2 {
+			t.Errorf("expected both colliding issues created, got %+v", result)
+		}
+	})
+}
+
+func TestImportIssues_HashSalt(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	if err := store.SetConfig(ctx, storage.HashSaltConfigKey, "salt-a"); err != nil {
+		t.Fatalf("Failed to set hash salt: %v", err)
+	}
+
+	now := time.Now()
+	newIssue := func() *types.Issue {
+		return &types.Issue{ID: "test-1", Title: "Salted", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now}
+	}
+	opts := Options{Verify: VerifyFull}
+	if _, err := ImportIssues(ctx, "", store, []*types.Issue{newIssue()}, opts); err != nil {
+		t.Fatalf("ImportIssues failed: %v", err)
+	}
+	stored, err := store.GetIssue(ctx, "test-1")
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if want := stored.ComputeSaltedContentHash("salt-a"); stored.ContentHash != want {
+		t.Errorf("content_hash = %s, want salted %s", stored.ContentHash, want)
+	}
+
+	// Re-importing the same content matches the salted hash
+	again := newIssue()
+	again.UpdatedAt = now.Add(time.Minute)
+	result, err := ImportIssues(ctx, "", store, []*types.Issue{again}, opts)
+	if err != nil {
+		t.Fatalf("re-import failed: %v", err)
+	}
+	if result.Unchanged != 1 || result.Updated != 0 || result.Created != 0 {
+		t.Errorf("re-import: created %d, updated %d, unchanged %d; want 1 unchanged", result.Created, result.Updated, result.Unchanged)
+	}
+}
@@ -0,0 +1,120 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// ParseHistory reads a history export (issue lines, each followed by its
+// types.EventRecord lines) and returns the issues and, oldest first, their
+// events, ready for ImportIssues with Options.Events. Export summary lines are
+// skipped. maxLineSize bounds a single line (0 uses the default).
+func ParseHistory(r io.Reader, maxLineSize int) ([]*types.Issue, []*types.Event, error) {
+	var issues []*types.Issue
+	var events []*types.Event
+
+	scanner := utils.NewJSONLScanner(r, maxLineSize)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var marker struct {
+			Event   *types.Event `json:"_event"`
+			Summary bool         `json:"_summary"`
+		}
+		if err := json.Unmarshal(line, &marker); err != nil {
+			return nil, nil, fmt.Errorf("line %d: %w", scanner.Line(), err)
+		}
+		if marker.Summary {
+			continue
+		}
+		if marker.Event != nil {
+			events = append(events, marker.Event)
+			continue
+		}
+
+		var issue types.Issue
+		if err := json.Unmarshal(line, &issue); err != nil {
+			return nil, nil, fmt.Errorf("line %d: %w", scanner.Line(), err)
+		}
+		issue.SetDefaults()
+		issues = append(issues, &issue)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+	return issues, events, nil
+}
+
+// dateCreatedEvents moves the creation event of each issue this import
+// created to the issue's CreatedAt under opts.HistoricalCreatedEvents, through
+// the storage.CreatedEventDater capability of store if it has one.
+func dateCreatedEvents(ctx context.Context, store interface{}, opts Options, result *Result) error {
+	if !opts.HistoricalCreatedEvents {
+		return nil
+	}
+	dater, ok := store.(storage.CreatedEventDater)
+	if !ok {
+		return nil
+	}
+	for _, issue := range result.created {
+		if err := dater.SetCreatedEventTime(ctx, issue.ID, issue.CreatedAt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// importEventHistory records opts.Events on their issues through the
+// storage.EventImporter capability of store, if it has one. Issues this import
+// created get the imported history in place of the events synthesized on
+// creation; existing issues gain only the events they don't already have.
+// Events for issues that don't exist are skipped.
+func importEventHistory(ctx context.Context, store interface{}, getIssue func(context.Context, string) (*types.Issue, error), opts Options, result *Result) error {
+	if len(opts.Events) == 0 {
+		return nil
+	}
+	importer, ok := store.(storage.EventImporter)
+	if !ok {
+		return nil
+	}
+
+	created := make(map[string]bool, len(result.created))
+	for _, issue := range result.created {
+		created[issue.ID] = true
+	}
+
+	var order []string
+	byIssue := make(map[string][]*types.Event)
+	for _, event := range opts.Events {
+		id := event.IssueID
+		if newID, ok := result.IDMapping[id]; ok {
+			id = newID
+		}
+		if _, seen := byIssue[id]; !seen {
+			order = append(order, id)
+		}
+		byIssue[id] = append(byIssue[id], event)
+	}
+
+	for _, id := range order {
+		issue, err := getIssue(ctx, id)
+		if err != nil {
+			return fmt.Errorf("error checking issue %s for event history: %w", id, err)
+		}
+		if issue == nil {
+			continue
+		}
+		if err := importer.ImportEvents(ctx, id, byIssue[id], created[id]); err != nil {
+			return fmt.Errorf("error importing event history for %s: %w", id, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestImportIssues_EventHistoryRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	source := newTestStore(t)
+	issue := &types.Issue{ID: "test-1", Title: "Flaky login", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeBug}
+	if err := source.CreateIssue(ctx, issue, "alice"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := source.CloseIssue(ctx, issue.ID, "fixed", "bob", ""); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	if err := source.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": string(types.StatusOpen)}, "carol"); err != nil {
+		t.Fatalf("reopen failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := source.ExportWithHistory(ctx, &buf); err != nil {
+		t.Fatalf("ExportWithHistory failed: %v", err)
+	}
+	issues, events, err := ParseHistory(bytes.NewReader(buf.Bytes()), 0)
+	if err != nil {
+		t.Fatalf("ParseHistory failed: %v", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 issue, got %d", len(issues))
+	}
+
+	// History exports list events oldest first
+	history := func(store *sqlite.SQLiteStorage) []string {
+		t.Helper()
+		var buf bytes.Buffer
+		if err := store.ExportWithHistory(ctx, &buf); err != nil {
+			t.Fatalf("ExportWithHistory failed: %v", err)
+		}
+		_, events, err := ParseHistory(&buf, 0)
+		if err != nil {
+			t.Fatalf("ParseHistory failed: %v", err)
+		}
+		var out []string
+		for _, event := range events {
+			out = append(out, string(event.EventType)+"/"+event.Actor)
+		}
+		return out
+	}
+	want := []string{"created/alice", "closed/bob", "reopened/carol"}
+	if got := history(source); strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Fatalf("expected source history %v, got %v", want, got)
+	}
+
+	target := newTestStore(t)
+	opts := Options{Events: events}
+	if _, err := ImportIssues(ctx, "", target, issues, opts); err != nil {
+		t.Fatalf("ImportIssues failed: %v", err)
+	}
+	assertHistory := func(label string) {
+		t.Helper()
+		got := history(target)
+		if len(got) != len(want) {
+			t.Fatalf("%s: got history %v, want %v", label, got, want)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("%s: event %d = %s, want %s", label, i, got[i], want[i])
+			}
+		}
+	}
+	assertHistory("import")
+
+	// Re-importing the same history doesn't duplicate it
+	if _, err := ImportIssues(ctx, "", target, issues, opts); err != nil {
+		t.Fatalf("re-import failed: %v", err)
+	}
+	assertHistory("re-import")
+}
+
+func TestImportIssues_HistoricalCreatedEvents(t *testing.T) {
+	ctx := context.Background()
+	createdAt := time.Date(2023, 5, 17, 9, 30, 0, 0, time.UTC)
+
+	for _, historical := range []bool{true, false} {
+		store := newTestStore(t)
+
+		issue := &types.Issue{ID: "test-1", Title: "Old issue", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: createdAt, UpdatedAt: createdAt}
+		if _, err := ImportIssues(ctx, "", store, []*types.Issue{issue}, Options{HistoricalCreatedEvents: historical}); err != nil {
+			t.Fatalf("ImportIssues failed: %v", err)
+		}
+		events, err := store.GetEvents(ctx, "test-1", 0)
+		if err != nil {
+			t.Fatalf("GetEvents failed: %v", err)
+		}
+		if len(events) != 1 || events[0].EventType != types.EventCreated {
+			t.Fatalf("expected one created event, got %v", events)
+		}
+		if got := events[0].CreatedAt; got.Equal(createdAt) != historical {
+			t.Errorf("historical=%v: created event at %v, issue created at %v", historical, got, createdAt)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// IDPrefixPolicy decides what an import does with an issue whose ID does not
+// start with the prefix composed from the config prefix and its IDPrefix
+// ("bd" + "api" → "bd-api-…"), as corrupt multi-repo exports produce.
+type IDPrefixPolicy string
+
+const (
+	IDPrefixError     IDPrefixPolicy = "error"     // Fail the import with a PrefixError wrapping ErrIDPrefixMismatch (default)
+	IDPrefixNormalize IDPrefixPolicy = "normalize" // Keep the ID, clear the disagreeing IDPrefix and record the issue in Result.IDPrefixesCleared
+	IDPrefixIgnore    IDPrefixPolicy = "ignore"    // Leave the pair as it is, checked only by prefix validation when that is enabled
+)
+
+// ErrIDPrefixMismatch is matched (via errors.Is) by the PrefixError returned
+// for an issue whose ID disagrees with its IDPrefix under IDPrefixError.
+var ErrIDPrefixMismatch = errors.New("issue ID does not match its ID prefix")
+
+// applyIDPrefixPolicy cross-checks the ID of every issue that carries an
+// IDPrefix against the config prefix and that IDPrefix, before any rename.
+// Unlike prefix validation it is not relaxed in multi-repo mode, where the
+// mismatch would otherwise go unnoticed. Issues without an ID or an IDPrefix,
+// and databases without a config prefix, are left alone. Under
+// Options.ContinueOnError a mismatched issue is rejected instead of failing
+// the import.
+func applyIDPrefixPolicy(ctx context.Context, cfg configStore, issues []*types.Issue, opts Options, result *Result) error {
+	switch opts.IDPrefixMismatches {
+	case "", IDPrefixError, IDPrefixNormalize:
+	case IDPrefixIgnore:
+		return nil
+	default:
+		return fmt.Errorf("unknown ID prefix policy %q (want error, normalize or ignore)", opts.IDPrefixMismatches)
+	}
+
+	configPrefix, err := cfg.GetConfig(ctx, "issue_prefix")
+	if err != nil {
+		return fmt.Errorf("failed to get issue prefix: %w", err)
+	}
+	if configPrefix == "" {
+		return nil
+	}
+	sep, _ := cfg.GetConfig(ctx, storage.IDSeparatorConfigKey)
+	if sep == "" {
+		sep = utils.DefaultIDSeparator
+	}
+
+	for _, issue := range issues {
+		if issue.ID == "" || issue.IDPrefix == "" {
+			continue
+		}
+		want := configPrefix + sep + issue.IDPrefix
+		if strings.HasPrefix(issue.ID, want+sep) {
+			continue
+		}
+		if opts.IDPrefixMismatches == IDPrefixNormalize {
+			issue.IDPrefix = ""
+			result.IDPrefixesCleared = append(result.IDPrefixesCleared, issue.ID)
+			continue
+		}
+		found := "lacks the config prefix " + configPrefix
+		if strings.HasPrefix(issue.ID, configPrefix+sep) {
+			found = "lacks the ID prefix " + issue.IDPrefix
+		}
+		err := &PrefixError{
+			IssueID:  issue.ID,
+			Prefix:   utils.ExtractIssuePrefixWithSeparator(issue.ID, sep),
+			Expected: want,
+			Err:      fmt.Errorf("%w %s (ID %s)", ErrIDPrefixMismatch, want, found),
+		}
+		if err := rejectIssue(ctx, issue, err, opts, result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
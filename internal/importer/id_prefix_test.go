@@ -0,0 +1,95 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestImportIssues_IDPrefixMismatches(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+	issue := func(id, idPrefix string) *types.Issue {
+		return &types.Issue{ID: id, IDPrefix: idPrefix, Title: id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now}
+	}
+
+	newStore := func(t *testing.T) *sqlite.SQLiteStorage {
+		store := newTestStore(t)
+		if err := store.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+			t.Fatalf("Failed to set prefix: %v", err)
+		}
+		return store
+	}
+
+	t.Run("matching pairs", func(t *testing.T) {
+		store := newStore(t)
+		issues := []*types.Issue{issue("bd-api-a1", "api"), issue("bd-b2", "")}
+		if _, err := ImportIssues(ctx, "", store, issues, Options{}); err != nil {
+			t.Fatalf("ImportIssues failed: %v", err)
+		}
+		if got, _ := store.GetIssue(ctx, "bd-api-a1"); got == nil {
+			t.Error("matching issue was not imported")
+		}
+	})
+
+	mismatches := []struct {
+		name   string
+		issue  *types.Issue
+		reason string
+	}{
+		{"other ID prefix", issue("bd-web-a1", "api"), "lacks the ID prefix api"},
+		{"other config prefix", issue("web-api-a1", "api"), "lacks the config prefix bd"},
+	}
+	for _, tt := range mismatches {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newStore(t)
+			issues := []*types.Issue{issue("bd-api-ok", "api"), tt.issue}
+			_, err := ImportIssues(ctx, "", store, issues, Options{SkipPrefixValidation: true})
+			var prefixErr *PrefixError
+			if !errors.Is(err, ErrIDPrefixMismatch) || !errors.As(err, &prefixErr) || prefixErr.IssueID != tt.issue.ID || prefixErr.Expected != "bd-api" {
+				t.Fatalf("err = %v, want a PrefixError for %s expecting bd-api", err, tt.issue.ID)
+			}
+			if !strings.Contains(err.Error(), tt.reason) {
+				t.Errorf("err = %v, want it to say the ID %s", err, tt.reason)
+			}
+			if got, _ := store.GetIssue(ctx, "bd-api-ok"); got != nil {
+				t.Error("a failed import should not create its other issues")
+			}
+		})
+	}
+
+	t.Run("normalize", func(t *testing.T) {
+		store := newStore(t)
+		issues := []*types.Issue{issue("bd-api-ok", "api"), issue("bd-web-a1", "api"), issue("web-api-a1", "api")}
+		result, err := ImportIssues(ctx, "", store, issues, Options{SkipPrefixValidation: true, IDPrefixMismatches: IDPrefixNormalize})
+		if err != nil {
+			t.Fatalf("ImportIssues failed: %v", err)
+		}
+		if got := strings.Join(result.IDPrefixesCleared, ","); got != "bd-web-a1,web-api-a1" {
+			t.Errorf("IDPrefixesCleared = %s, want bd-web-a1,web-api-a1", got)
+		}
+		if issues[0].IDPrefix != "api" || issues[1].IDPrefix != "" {
+			t.Errorf("IDPrefix = %q, %q; want only the mismatch cleared", issues[0].IDPrefix, issues[1].IDPrefix)
+		}
+		if result.Created != 3 {
+			t.Errorf("Created = %d, want 3", result.Created)
+		}
+	})
+
+	t.Run("ignore", func(t *testing.T) {
+		store := newStore(t)
+		result, err := ImportIssues(ctx, "", store, []*types.Issue{issue("web-api-a1", "api")}, Options{SkipPrefixValidation: true, IDPrefixMismatches: IDPrefixIgnore})
+		if err != nil || result.Created != 1 {
+			t.Fatalf("ImportIssues = %+v, %v; want the issue created", result, err)
+		}
+	})
+
+	if _, err := ImportIssues(ctx, "", newStore(t), nil, Options{IDPrefixMismatches: "rewrite"}); err == nil {
+		t.Error("expected an unknown policy to fail")
+	}
+}
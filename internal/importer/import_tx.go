@@ -0,0 +1,74 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ImportIssuesTx imports issues within tx, a transaction the caller already
+// holds, so the import commits or rolls back together with the caller's other
+// writes:
+//
+//	err := store.RunInTransaction(ctx, func(tx storage.Transaction) error {
+//		if _, err := importer.ImportIssuesTx(ctx, store, tx, issues, importer.Options{}); err != nil {
+//			return err
+//		}
+//		return tx.SetConfig(ctx, "last_import_source", source)
+//	})
+//
+// It matches, creates and updates issues exactly as ImportIssues does, with
+// every read and write going through tx; store must be the storage tx belongs
+// to and is only read for prefix configuration and its path. Options that
+// manage their own transactions (BatchSize, IsolatePrefixes) and DryRun are
+// rejected. Export hashes are left alone; changed issues still export because
+// their content hashes change.
+func ImportIssuesTx(ctx context.Context, store storage.Storage, tx storage.Transaction, issues []*types.Issue, opts Options) (*Result, error) {
+	if opts.ImportEvents != nil {
+		defer close(opts.ImportEvents)
+	}
+	if err := checkMaxIssues(len(issues), opts.MaxIssues); err != nil {
+		return nil, err
+	}
+	if store == nil || tx == nil {
+		return nil, fmt.Errorf("import requires an initialized storage backend and transaction")
+	}
+	if opts.DryRun || opts.BatchSize > 0 || opts.IsolatePrefixes {
+		return nil, fmt.Errorf("DryRun, BatchSize and IsolatePrefixes are not supported in a caller-supplied transaction")
+	}
+	if opts.CheckpointWAL {
+		return nil, fmt.Errorf("CheckpointWAL is not supported in a caller-supplied transaction, which commits after the import returns")
+	}
+	if opts.Webhook != nil {
+		return nil, fmt.Errorf("Webhook is not supported in a caller-supplied transaction, which commits after the import returns")
+	}
+	if err := validateOptions(ctx, store, opts); err != nil {
+		return nil, err
+	}
+
+	result := &Result{
+		IDMapping:        make(map[string]string),
+		MismatchPrefixes: make(map[string]int),
+		events:           opts.ImportEvents,
+	}
+
+	ctx = importContext(ctx, opts)
+	issues, opts, err := prepareImport(ctx, store, tx, issues, opts, result)
+	if err != nil {
+		return result, err
+	}
+	if err := applyDeletions(ctx, tx, opts, result); err != nil {
+		return result, err
+	}
+
+	issues, err = detectUpdates(ctx, tx, issues, opts, result)
+	if err != nil {
+		return result, err
+	}
+	if err := importTx(ctx, tx, store, issues, opts, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
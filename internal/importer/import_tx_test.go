@@ -0,0 +1,115 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestImportIssuesTx_ComposesWithCallerWrites(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	now := time.Now()
+	inputs := func() []*types.Issue {
+		return []*types.Issue{
+			{ID: "test-a", Title: "Imported a", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now},
+			{ID: "test-b", Title: "Imported b", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now,
+				Dependencies: []*types.Dependency{{IssueID: "test-b", DependsOnID: "test-a", Type: types.DepBlocks}}},
+		}
+	}
+
+	// A failing write after the import rolls the import back with it
+	errAbort := errors.New("abort")
+	err := store.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		if _, err := ImportIssuesTx(ctx, store, tx, inputs(), Options{}); err != nil {
+			return err
+		}
+		if err := tx.SetConfig(ctx, "last_import_source", "upstream"); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("expected errAbort, got %v", err)
+	}
+	if issue, _ := store.GetIssue(ctx, "test-a"); issue != nil {
+		t.Error("expected the import rolled back with the caller's transaction")
+	}
+	if value, _ := store.GetConfig(ctx, "last_import_source"); value != "" {
+		t.Errorf("expected caller write rolled back, got %q", value)
+	}
+
+	// Both commit together
+	var result *Result
+	err = store.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		var err error
+		if result, err = ImportIssuesTx(ctx, store, tx, inputs(), Options{}); err != nil {
+			return err
+		}
+		return tx.SetConfig(ctx, "last_import_source", "upstream")
+	})
+	if err != nil {
+		t.Fatalf("RunInTransaction failed: %v", err)
+	}
+	if result.Created != 2 {
+		t.Errorf("expected 2 created, got %+v", result)
+	}
+	if value, _ := store.GetConfig(ctx, "last_import_source"); value != "upstream" {
+		t.Errorf("expected caller write committed, got %q", value)
+	}
+	deps, err := store.GetDependencies(ctx, "test-b")
+	if err != nil || len(deps) != 1 || deps[0].ID != "test-a" {
+		t.Errorf("expected test-b to depend on test-a, got %v (err %v)", deps, err)
+	}
+
+	err = store.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		_, err := ImportIssuesTx(ctx, store, tx, inputs(), Options{BatchSize: 10})
+		return err
+	})
+	if err == nil {
+		t.Error("expected BatchSize to be rejected in a caller-supplied transaction")
+	}
+}
+
+func TestImportIssuesTx_ValidatesOptionsLikeImportIssues(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	now := time.Now()
+	input := func() []*types.Issue {
+		return []*types.Issue{{ID: "test-a", Title: "Imported", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now}}
+	}
+	tests := []struct {
+		name string
+		opts Options
+	}{
+		{"update fields", Options{UpdateFields: []string{"no_such_field"}}},
+		{"redact fields", Options{Redact: []string{"no_such_field"}}},
+		{"unknown tombstones", Options{UnknownTombstones: "bogus"}},
+		{"duplicate dependencies", Options{DuplicateDependencies: "bogus"}},
+		{"dependency inversions", Options{DependencyInversions: "bogus"}},
+		{"verify level", Options{Verify: "bogus"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ImportIssues(ctx, "", store, input(), tt.opts); err == nil {
+				t.Fatal("ImportIssues accepted the options")
+			}
+			err := store.RunInTransaction(ctx, func(tx storage.Transaction) error {
+				_, err := ImportIssuesTx(ctx, store, tx, input(), tt.opts)
+				return err
+			})
+			if err == nil {
+				t.Error("ImportIssuesTx accepted options ImportIssues rejects")
+			}
+			if issue, _ := store.GetIssue(ctx, "test-a"); issue != nil {
+				t.Error("expected nothing written")
+			}
+		})
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"sort"
@@ -34,31 +36,147 @@ const (
 
 // Options contains import configuration
 type Options struct {
-	DryRun                     bool                 // Preview changes without applying them
-	SkipUpdate                 bool                 // Skip updating existing issues (create-only mode)
-	Strict                     bool                 // Fail on any error (dependencies, labels, etc.)
-	RenameOnImport             bool                 // Rename imported issues to match database prefix
-	SkipPrefixValidation       bool                 // Skip prefix validation (for auto-import)
-	OrphanHandling             OrphanHandling       // How to handle missing parent issues (default: allow)
-	ClearDuplicateExternalRefs bool                 // Clear duplicate external_ref values instead of erroring
-	ProtectLocalExportIDs      map[string]time.Time // IDs from left snapshot with timestamps for timestamp-aware protection (GH#865)
-	DeletionIDs                []string             // IDs to delete (from JSONL deletion markers)
+	DryRun                     bool                   // Preview changes without applying them
+	SkipUpdate                 bool                   // Skip updating existing issues (create-only mode)
+	Strict                     bool                   // Fail on any error (dependencies, labels, etc.)
+	RenameOnImport             bool                   // Rename imported issues to match database prefix
+	SkipPrefixValidation       bool                   // Skip prefix validation (for auto-import)
+	OrphanHandling             OrphanHandling         // How to handle missing parent issues (default: allow)
+	MaxResurrectDepth          int                    // With OrphanResurrect, when > 0, recreate at most this many missing ancestor levels above an issue; deeper gaps are handled per ResurrectFallback
+	ResurrectFallback          OrphanHandling         // What to do with issues beyond MaxResurrectDepth: OrphanStrict (default) fails the import, OrphanSkip skips them and their descendants
+	ClearDuplicateExternalRefs bool                   // Clear duplicate external_ref values instead of erroring
+	ProtectLocalExportIDs      map[string]time.Time   // IDs from left snapshot with timestamps for timestamp-aware protection (GH#865)
+	DeletionIDs                []string               // IDs to delete (from JSONL deletion markers)
+	ProvenanceSource           string                 // When set, record this source as the last writer of each created/updated field (transactional imports only); also labels ShadowImport runs
+	BatchSize                  int                    // When > 0, commit issues in transactions of this many issues instead of one
+	IdempotencyKey             string                 // With BatchSize, persist progress under this key so a re-run resumes after the last committed batch (refused if the input no longer matches what was committed)
+	DeferOrphans               bool                   // With BatchSize, import in input order and retry children whose parent has not arrived yet at the end, applying OrphanHandling only to those still unresolved
+	IsolatePrefixes            bool                   // Import each ID prefix in its own transaction so one repo's failure doesn't roll back the others (gives up whole-import atomicity)
+	NormalizeTimestampsUTC     bool                   // Convert every incoming timestamp to UTC, and synthesize missing ones in UTC, before importing
+	Verify                     VerifyLevel            // Re-read created issues before commit and roll back if their content hashes don't match (transactional imports only)
+	Relationships              []*types.Relationship  // Typed links between issues, imported as dependencies after the issues themselves
+	RelationshipTypes          []types.DependencyType // Custom relationship types accepted in addition to types.RelationshipTypes
+	Events                     []*types.Event         // Event history to record on the imported issues (see ParseHistory), replacing the events synthesized for issues this import creates
+	UpdateFields               []string               // When set, updates of existing issues write only these columns (e.g. "status", "assignee"), leaving the rest as they are locally; new issues are still created in full
+	AutoCreateCustomTypes      bool                   // Register issue types used by the import that are not yet known as custom types instead of failing validation
+	Definitions                *types.Definitions     // Custom types, statuses and type-status restrictions the issues rely on (see ParseSelfDescribing), registered before any issue is written; a restriction that differs from the database's fails with a DefinitionConflictError
+	FutureTimestamps           FutureTimestampPolicy  // What to do with future-dated created/updated/closed/deleted timestamps (default: accept)
+	AllowedTypes               []types.IssueType      // When set, import only issues of these types (plus the parents they need); others are handled per DisallowedTypes
+	DisallowedTypes            TypeFilterHandling     // What to do with issues whose type is not in AllowedTypes (default: skip)
+	ReplaceHardDelete          bool                   // With ReplaceAllImport, delete issues missing from the import instead of tombstoning them
+	ExpiredOnImport            ExpiredImportPolicy    // What to do with issues whose ExpiresAt has already passed (default: keep)
+	HashCollisions             HashCollisionPolicy    // What to do when content hashes match but content differs (default: conflict)
+	NormalizeNewlines          bool                   // Convert CRLF and CR line endings in issue and comment text to LF before hashing (changes the stored text)
+	TrimTrailingWhitespace     bool                   // Strip trailing whitespace from each line of issue and comment text before hashing (changes the stored text)
+	Concurrency                int                    // With IsolatePrefixes, import up to this many prefixes at once, each in its own transaction (default 1)
+	PreserveRowIDs             bool                   // Create issues under their exported RowID instead of a fresh one, failing if another issue holds it; existing issues keep theirs
+	StatusMap                  map[string]string      // Remaps incoming statuses (e.g. "Done" to "closed") before validation and hashing, setting or clearing closed_at and deleted_at to match; unmapped statuses are validated as usual
+	DefaultStatus              types.Status           // Status given to issues that have none, before validation and hashing (must be built in or a custom status)
+	DeriveStatus               bool                   // Give issues that have no status one from their timestamps before validation and hashing: tombstone with deleted_at, closed with closed_at, otherwise DefaultStatus (or open); explicit statuses are kept (see ParseOptions.EmptyStatus)
+	DefaultType                types.IssueType        // Issue type given to issues that have none, before validation and hashing (must be built in or a custom type)
+	SourceSystem               string                 // Source system given to issues that have none, before hashing (checked against SourceRegistryConfigKey)
+	ActorMap                   ActorMapper            // Translates foreign actor identities (creators, comment authors, event actors) to local ones before hashing; unknown actors are kept
+	ActorMapStrict             bool                   // With ActorMap, fail with a ValidationError wrapping ErrUnmappedActor on any actor the map does not know
+	BypassTypeStatuses         bool                   // Accept statuses the type-status allowlist (sqlite.TypeStatusesConfigKey) forbids for an issue's type, treating the import as authoritative
+	BypassParentTypes          bool                   // Accept parent-child dependencies the parent type matrix (sqlite.ParentTypesConfigKey) forbids, treating the import as authoritative
+	SynthesizeParents          bool                   // Create missing hierarchical ancestors (foo-1.2 for foo-1.2.3) as open placeholder issues, each with an EventSynthesized event, before OrphanHandling applies
+	SelfParents                SelfParentPolicy       // What to do with issues that list themselves as parent (default: error)
+	DependencyInversions       DepInversionPolicy     // What to do with a dependency whose inverse (same type, opposite direction) already exists (default: flag, keeping both)
+	DuplicateDependencies      DuplicateDepPolicy     // What to do with a dependency edge an issue lists more than once (default: ignore); edges already stored are always left as they are
+	HistoricalCreatedEvents    bool                   // Date the creation event of each issue this import creates at the issue's CreatedAt instead of the import time
+	RestrictToPrefix           string                 // When set, fail with a PrefixError instead of creating, updating or deleting any issue whose ID (after renaming) lacks this prefix
+	RenameOnCollision          bool                   // Keep both issues when an incoming ID is held by an existing issue with other content: the incoming one gets a fresh ID, its old ID becomes an alias, and references to it within the import follow (transactional imports only; not with BatchSize)
+	UniqueIDSuffixes           bool                   // Roll back with an IDSuffixError when a created issue's ID suffix (the part after the prefix) is already used under any other prefix (transactional imports only)
+	RequireContentHash         bool                   // Fail with a ValidationError wrapping ErrMissingContentHash on any issue that arrives without a content hash (see ContentHashField) instead of computing one; the stored hash is still computed locally
+	MaxIssues                  int                    // When > 0, refuse imports of more issues than this with a TooManyIssuesError before doing any work
+	Redact                     []string               // Fields blanked on every incoming issue before hashing (see types.RedactableFields), e.g. to keep assignees out of a shared database
+	ImportEvents               chan<- ImportEvent     // Receives the outcome for each issue as it is processed, and is closed when the import returns; sends never block (see Result.DroppedEvents)
+	Milestones                 []*types.Milestone     // Milestones to store alongside the issues (see ParseMilestones), replacing milestones with the same IDs; issues referencing a milestone that is neither stored nor imported are handled per OrphanHandling
+	Templates                  []*types.Template      // Issue templates to store alongside the issues (see ParseTemplates), replacing templates with the same IDs
+	PostImportAssert           ImportAssertion        // Called inside the import transaction after every write, before OnCommit, to check invariants; an error rolls the import back (same restrictions as OnCommit)
+	CheckpointWAL              bool                   // After a successful import, fold the write-ahead log back into the database and truncate it (PRAGMA wal_checkpoint(TRUNCATE)) instead of waiting for an automatic checkpoint; failures are warnings
+	ContinueOnError            bool                   // Leave out issues that fail a per-issue check (a ValidationError or PrefixError) or that the storage layer refuses to create or update (validation or constraint failures) and carry on, listing them in Result.Rejected, instead of rolling the whole import back (transactional imports only)
+	QuarantineWriter           io.Writer              // With ContinueOnError, receives each rejected issue as a re-importable JSONL line carrying its error in QuarantineErrorField, as the rejection happens (so even if the import later rolls back)
+	Webhook                    *ImportWebhook         // After a successful import, POST a WebhookPayload with its stats and conflict counts here, retrying per the webhook's settings; failures are warnings (not with DryRun or ImportIssuesTx)
+	UnknownTombstones          UnknownTombstonePolicy // What to do with incoming tombstones for issues the database has never had (default: create)
+	IDPrefixMismatches         IDPrefixPolicy         // What to do with issues whose ID does not start with the config prefix plus their IDPrefix (default: error)
+	DefaultIDPrefix            string                 // IDPrefix given to issues that have none, as in flat single-repo exports, moving their IDs under the composed prefix ("bd-web-a1b2") and reporting the renames in Result.IDMapping (not with IsolatePrefixes)
+	RestoreArchived            bool                   // Recreate incoming issues that are held in the archive (see sqlite.ArchiveClosedIssues); by default they are left out, so imports target the active table only
+	OnConflict                 ConflictResolver       // Called for each existing issue an incoming one with the same ID and different content would update, and applied instead of the newer-UpdatedAt-wins rule and ProtectLocalExportIDs; nil keeps that rule
+	OnCommit                   CommitHook             // Called inside the import transaction after every write, just before commit; an error rolls the import back (transactional imports only; not with BatchSize or IsolatePrefixes)
+
+	exportHashesCleared bool                     // export_hashes were already cleared by the caller (per-prefix imports)
+	importEventsShared  bool                     // ImportEvents belongs to the caller's import, which closes it (per-prefix imports)
+	restrictedSep       string                   // ID separator, set while RestrictToPrefix is enforced
+	hashOptions         types.ContentHashOptions // Content hash settings of the target database, set on entry
 }
 
 // Result contains statistics about the import operation
 type Result struct {
-	Created             int               // New issues created
-	Updated             int               // Existing issues updated
-	Unchanged           int               // Existing issues that matched exactly (idempotent)
-	Skipped             int               // Issues skipped (duplicates, errors)
-	Deleted             int               // Issues deleted (from deletion markers)
-	Collisions          int               // Collisions detected
-	IDMapping           map[string]string // Mapping of remapped IDs (old -> new)
-	CollisionIDs        []string          // IDs that collided
-	PrefixMismatch      bool              // Prefix mismatch detected
-	ExpectedPrefix      string            // Database configured prefix
-	MismatchPrefixes    map[string]int    // Map of mismatched prefixes to count
-	SkippedDependencies []string          // Dependencies skipped due to FK constraint violations
+	Created             int                      // New issues created
+	Updated             int                      // Existing issues updated
+	Unchanged           int                      // Existing issues that matched exactly (idempotent)
+	Skipped             int                      // Issues skipped (duplicates, errors)
+	Deleted             int                      // Issues deleted (from deletion markers)
+	Collisions          int                      // Collisions detected
+	IDMapping           map[string]string        // Mapping of remapped IDs (old -> new)
+	CollisionIDs        []string                 // IDs that collided
+	PrefixMismatch      bool                     // Prefix mismatch detected
+	ExpectedPrefix      string                   // Database configured prefix
+	MismatchPrefixes    map[string]int           // Map of mismatched prefixes to count
+	SkippedDependencies []string                 // Dependencies skipped due to FK constraint violations
+	SkippedChecklists   []string                 // Issues whose checklist failed to import (not under Strict, which fails instead)
+	Resumed             int                      // Issues skipped because an earlier run with the same IdempotencyKey committed them
+	PrefixResults       map[string]*PrefixResult // Per-prefix outcomes when Options.IsolatePrefixes is set
+	RegisteredTypes     []string                 // Custom types registered under Options.AutoCreateCustomTypes or from Options.Definitions
+	RegisteredStatuses  []string                 // Custom statuses registered from Options.Definitions
+	ClampedTimestamps   []string                 // Issues whose future-dated timestamps were clamped under FutureTimestampsClamp
+	TypeFiltered        int                      // Issues skipped by Options.AllowedTypes (also counted in Skipped)
+	HashCollisions      []string                 // Content hash collisions detected (same hash, different content)
+	ShadowRun           int64                    // Shadow import log run recorded by ShadowImport
+	SelfParents         []string                 // Issues whose self-parent dependency was dropped under SelfParentDrop
+	DroppedEvents       int                      // Events not sent on Options.ImportEvents because its buffer was full
+	Templates           int                      // Templates stored from Options.Templates
+	Milestones          int                      // Milestones stored from Options.Milestones, including placeholders added under OrphanResurrect
+	Resurrected         []string                 // Synthetic parents recreated as closed tombstones under OrphanResurrect (also counted in Created)
+	Synthesized         []string                 // Placeholder ancestors created under Options.SynthesizeParents (also counted in Created)
+	DependencyConflicts []string                 // Dependencies that inverted an existing one, and which edges were kept (see Options.DependencyInversions)
+	SkippedTombstones   []string                 // Tombstones for absent issues left out under UnknownTombstoneSkip (also counted in Skipped)
+	IDPrefixesCleared   []string                 // Issues whose disagreeing IDPrefix was cleared under IDPrefixNormalize
+	Archived            []string                 // Incoming issues left out because they are archived (see Options.RestoreArchived) (also counted in Skipped)
+	Rejected            []string                 // Issues left out under Options.ContinueOnError because a check or their write failed (also counted in Skipped)
+
+	created []*types.Issue     // Issues created so far, for Options.Verify and HistoricalCreatedEvents
+	events  chan<- ImportEvent // Options.ImportEvents
+}
+
+// ErrForeignKey is matched (via errors.Is) by every ForeignKeyError.
+var ErrForeignKey = errors.New("foreign key violation")
+
+// ForeignKeyError reports an imported reference to an issue that does not
+// exist, tied to the issue that carries the reference.
+type ForeignKeyError struct {
+	IssueID   string // Issue whose record holds the dangling reference
+	Field     string // Referencing field, e.g. "dependencies"
+	MissingID string // Referenced issue ID that does not exist
+	DepType   types.DependencyType
+	Err       error // Underlying storage error, if any
+}
+
+func (e *ForeignKeyError) Error() string {
+	msg := fmt.Sprintf("%s: issue %s %s references missing issue %s", ErrForeignKey, e.IssueID, e.Field, e.MissingID)
+	if e.DepType != "" {
+		msg += fmt.Sprintf(" (%s)", e.DepType)
+	}
+	return msg
+}
+
+// Unwrap returns ErrForeignKey and the underlying storage error.
+func (e *ForeignKeyError) Unwrap() []error {
+	if e.Err == nil {
+		return []error{ErrForeignKey}
+	}
+	return []error{ErrForeignKey, e.Err}
 }
 
 // ImportIssues handles the core import logic used by both manual and auto-import.
@@ -80,112 +198,60 @@ type Result struct {
 // - issues: Parsed issues from JSONL
 // - opts: Import options
 func ImportIssues(ctx context.Context, dbPath string, store storage.Storage, issues []*types.Issue, opts Options) (*Result, error) {
+	result, err := importIssues(ctx, dbPath, store, issues, opts)
+	if err == nil && opts.CheckpointWAL && !opts.DryRun {
+		truncateWAL(ctx, store)
+	}
+	if err == nil && opts.Webhook != nil && !opts.DryRun {
+		notifyWebhook(ctx, opts.Webhook, result)
+	}
+	return result, err
+}
+
+// importIssues implements ImportIssues, apart from Options.CheckpointWAL and
+// Options.Webhook.
+func importIssues(ctx context.Context, dbPath string, store storage.Storage, issues []*types.Issue, opts Options) (*Result, error) {
+	if opts.ImportEvents != nil && !opts.importEventsShared {
+		defer close(opts.ImportEvents)
+	}
+	if err := checkMaxIssues(len(issues), opts.MaxIssues); err != nil {
+		return nil, err
+	}
 	result := &Result{
 		IDMapping:        make(map[string]string),
 		MismatchPrefixes: make(map[string]int),
+		events:           opts.ImportEvents,
 	}
 
 	if store == nil {
 		return nil, fmt.Errorf("import requires an initialized storage backend")
 	}
-
-	// Normalize Linear external_refs to canonical form to avoid slug-based duplicates.
-	for _, issue := range issues {
-		if issue.ExternalRef == nil || *issue.ExternalRef == "" {
-			continue
-		}
-		if linear.IsLinearExternalRef(*issue.ExternalRef) {
-			if canonical, ok := linear.CanonicalizeLinearExternalRef(*issue.ExternalRef); ok {
-				issue.ExternalRef = &canonical
-			}
-		}
-	}
-
-	// Compute content hashes for all incoming issues
-	// Always recompute to avoid stale/incorrect JSONL hashes
-	for _, issue := range issues {
-		issue.ContentHash = issue.ComputeContentHash()
+	if err := validateOptions(ctx, store, opts); err != nil {
+		return nil, err
 	}
 
-	// Auto-detect wisps by ID pattern and set ephemeral flag
-	// This prevents orphaned wisp entries in JSONL from polluting bd ready
-	// Pattern: *-wisp-* indicates ephemeral patrol/workflow instances
-	for _, issue := range issues {
-		if strings.Contains(issue.ID, "-wisp-") && !issue.Ephemeral {
-			issue.Ephemeral = true
-		}
+	if opts.IsolatePrefixes {
+		return importIsolatedPrefixes(ctx, dbPath, store, issues, opts)
 	}
 
-	// GH#686: In multi-repo mode, skip prefix validation for all issues.
-	// Issues from additional repos have their own prefixes which are expected and correct.
-	if config.GetMultiRepoConfig() != nil && !opts.SkipPrefixValidation {
-		opts.SkipPrefixValidation = true
+	ctx = importContext(ctx, opts)
+	issues, opts, err := prepareImport(ctx, store, store, issues, opts, result)
+	if err != nil {
+		return result, err
 	}
 
 	// Clear export_hashes before import to prevent staleness
 	// Import operations may add/update issues, so export_hashes entries become invalid
-	if !opts.DryRun {
+	if !opts.DryRun && !opts.exportHashesCleared {
 		if err := store.ClearAllExportHashes(ctx); err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: failed to clear export_hashes before import: %v\n", err)
 		}
 	}
 
-	// Read orphan handling from config if not explicitly set
-	if opts.OrphanHandling == "" {
-		value, err := store.GetConfig(ctx, "import.orphan_handling")
-		if err == nil && value != "" {
-			switch OrphanHandling(value) {
-			case OrphanStrict, OrphanResurrect, OrphanSkip, OrphanAllow:
-				opts.OrphanHandling = OrphanHandling(value)
-			default:
-				opts.OrphanHandling = OrphanAllow
-			}
-		} else {
-			opts.OrphanHandling = OrphanAllow
-		}
-	}
-
-	// Check and handle prefix mismatches
-	var err error
-	issues, err = handlePrefixMismatch(ctx, store, issues, opts, result)
-	if err != nil {
-		return result, err
-	}
-
-	// Validate no duplicate external_ref values in batch
-	if err := validateNoDuplicateExternalRefs(issues, opts.ClearDuplicateExternalRefs, result); err != nil {
-		return result, err
-	}
-
 	// Process deletion markers before issue upserts
 	// This ensures deletions are applied before any updates that might conflict
-	if len(opts.DeletionIDs) > 0 {
-		if opts.DryRun {
-			// In dry-run mode, just count how many would be deleted
-			for _, id := range opts.DeletionIDs {
-				// Check if issue exists
-				if _, err := store.GetIssue(ctx, id); err == nil {
-					result.Deleted++
-				}
-			}
-		} else {
-			for _, id := range opts.DeletionIDs {
-				// Check if issue exists before trying to delete
-				if _, err := store.GetIssue(ctx, id); err != nil {
-					// Issue doesn't exist - might already be deleted, skip silently
-					continue
-				}
-				if err := store.DeleteIssue(ctx, id); err != nil {
-					if opts.Strict {
-						return result, fmt.Errorf("failed to delete issue %s: %w", id, err)
-					}
-					// Non-strict mode: log warning and continue
-					fmt.Fprintf(os.Stderr, "Warning: failed to delete issue %s: %v\n", id, err)
-					continue
-				}
-				result.Deleted++
-			}
-		}
+	if err := applyDeletions(ctx, store, opts, result); err != nil {
+		return result, err
 	}
 
 	// Detect and resolve collisions
@@ -197,41 +263,86 @@ func ImportIssues(ctx context.Context, dbPath string, store storage.Storage, iss
 		return result, nil
 	}
 
-	// Apply changes atomically when transactions are supported.
-	if err := store.RunInTransaction(ctx, func(tx storage.Transaction) error {
-		// Upsert issues (create new or update existing)
-		if err := upsertIssuesTx(ctx, tx, store, issues, opts, result); err != nil {
-			return err
-		}
-		// Import dependencies
-		if err := importDependenciesTx(ctx, tx, issues, opts, result); err != nil {
-			return err
-		}
-		// Import labels
-		if err := importLabelsTx(ctx, tx, issues, opts); err != nil {
-			return err
+	// Large imports can commit in batches and resume after a crash.
+	if opts.BatchSize > 0 && !opts.DryRun {
+		err := importBatches(ctx, store, issues, opts, result)
+		if err == nil {
+			return result, nil
 		}
-		// Import comments (timestamp-preserving)
-		if err := importCommentsTx(ctx, tx, issues, opts); err != nil {
-			return err
+		if !strings.Contains(err.Error(), "not supported") {
+			return nil, err
 		}
-		return nil
+		// Backend has no transactions: fall through to the non-transactional path
+	}
+
+	// Apply changes atomically when transactions are supported.
+	if err := store.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		return importTx(ctx, tx, store, issues, opts, result)
 	}); err != nil {
 		// Some backends (e.g., --no-db) don't support transactions.
 		// Fall back to non-transactional behavior in that case.
 		if strings.Contains(err.Error(), "not supported") {
+			if opts.OnCommit != nil || opts.PostImportAssert != nil {
+				return nil, fmt.Errorf("OnCommit and PostImportAssert require a backend with transactions: %w", err)
+			}
+			if opts.UniqueIDSuffixes {
+				return nil, fmt.Errorf("UniqueIDSuffixes requires a backend with transactions: %w", err)
+			}
+			if opts.RenameOnCollision {
+				return nil, fmt.Errorf("RenameOnCollision requires a backend with transactions: %w", err)
+			}
+			if opts.ContinueOnError {
+				return nil, fmt.Errorf("ContinueOnError requires a backend with transactions: %w", err)
+			}
+			if limited, qerr := quotasConfigured(ctx, store); qerr != nil {
+				return nil, qerr
+			} else if limited {
+				return nil, fmt.Errorf("per-prefix quotas require a backend with transactions: %w", err)
+			}
+			registered, err := registerCustomTypes(ctx, store, issues, opts, result)
+			if err != nil {
+				return nil, err
+			}
 			if err := upsertIssues(ctx, store, issues, opts, result); err != nil {
 				return nil, err
 			}
+			if err := recordTypeRegistrations(ctx, store, store.GetIssue, issues, registered, result); err != nil {
+				return nil, err
+			}
 			if err := importDependencies(ctx, store, issues, opts, result); err != nil {
 				return nil, err
 			}
+			edges, err := relationshipEdges(ctx, store.GetIssue, opts, result)
+			if err != nil {
+				return nil, err
+			}
+			if err := importDependencies(ctx, store, edges, opts, result); err != nil {
+				return nil, err
+			}
 			if err := importLabels(ctx, store, issues, opts); err != nil {
 				return nil, err
 			}
 			if err := importComments(ctx, store, issues, opts); err != nil {
 				return nil, err
 			}
+			if err := importWatchers(ctx, store, issues, opts); err != nil {
+				return nil, err
+			}
+			if err := importChecklists(ctx, store, issues, opts, result); err != nil {
+				return nil, err
+			}
+			if err := importTemplates(ctx, store, opts, result); err != nil {
+				return nil, err
+			}
+			if err := importMilestones(ctx, store, opts, result); err != nil {
+				return nil, err
+			}
+			if err := dateCreatedEvents(ctx, store, opts, result); err != nil {
+				return nil, err
+			}
+			if err := importEventHistory(ctx, store, store.GetIssue, opts, result); err != nil {
+				return nil, err
+			}
 		} else {
 			return nil, err
 		}
@@ -240,6 +351,162 @@ func ImportIssues(ctx context.Context, dbPath string, store storage.Storage, iss
 	return result, nil
 }
 
+// importTx writes already-prepared issues and everything attached to them
+// within tx. store is consulted only for its path (OrphanResurrect).
+func importTx(ctx context.Context, tx storage.Transaction, store storage.Storage, issues []*types.Issue, opts Options, result *Result) error {
+	// Move incoming issues off IDs held by other issues
+	if err := renameCollidingIDsTx(ctx, tx, issues, opts, result); err != nil {
+		return err
+	}
+	created := len(result.created)
+	registered, err := registerCustomTypes(ctx, tx, issues, opts, result)
+	if err != nil {
+		return err
+	}
+	// Upsert issues (create new or update existing)
+	if err := upsertIssuesTx(ctx, tx, store, issues, opts, result); err != nil {
+		return err
+	}
+	issues = result.withoutRejected(issues)
+	if err := checkIDSuffixes(ctx, tx, result.created[created:], opts); err != nil {
+		return err
+	}
+	if err := recordTypeRegistrations(ctx, tx, tx.GetIssue, issues, registered, result); err != nil {
+		return err
+	}
+	// Import dependencies
+	if err := importDependenciesTx(ctx, tx, issues, opts, result); err != nil {
+		return err
+	}
+	// Import relationships once both endpoints are written
+	edges, err := relationshipEdges(ctx, tx.GetIssue, opts, result)
+	if err != nil {
+		return err
+	}
+	if err := importDependenciesTx(ctx, tx, edges, opts, result); err != nil {
+		return err
+	}
+	// Import labels
+	if err := importLabelsTx(ctx, tx, issues, opts); err != nil {
+		return err
+	}
+	// Import comments (timestamp-preserving)
+	if err := importCommentsTx(ctx, tx, issues, opts); err != nil {
+		return err
+	}
+	// Import watchers
+	if err := importWatchers(ctx, tx, issues, opts); err != nil {
+		return err
+	}
+	// Import checklists
+	if err := importChecklists(ctx, tx, issues, opts, result); err != nil {
+		return err
+	}
+	// Import templates
+	if err := importTemplates(ctx, tx, opts, result); err != nil {
+		return err
+	}
+	// Import milestones
+	if err := importMilestones(ctx, tx, opts, result); err != nil {
+		return err
+	}
+	// Record imported event history
+	if err := dateCreatedEvents(ctx, tx, opts, result); err != nil {
+		return err
+	}
+	if err := importEventHistory(ctx, tx, tx.GetIssue, opts, result); err != nil {
+		return err
+	}
+	// Record original IDs of remapped issues as aliases
+	if err := importIDAliasesTx(ctx, tx, result.IDMapping); err != nil {
+		return err
+	}
+	// Enforce per-prefix quotas before the caller commits
+	if err := checkQuotas(ctx, tx, result.created[created:]); err != nil {
+		return err
+	}
+	if err := verifyCreatedTx(ctx, tx, result.created[created:], opts.Verify, opts.hashOptions); err != nil {
+		return err
+	}
+	// Check the caller's invariants, then hand the final counts to its hook
+	return runTxHooks(ctx, tx, opts, result)
+}
+
+// prepareIssues normalizes incoming issues before they are matched against
+// the database.
+func prepareIssues(issues []*types.Issue, opts Options) {
+	if len(opts.Redact) > 0 {
+		for _, issue := range issues {
+			_ = issue.Redact(opts.Redact, opts.hashOptions) // validated on entry
+		}
+	}
+	if opts.NormalizeTimestampsUTC {
+		normalizeTimestampsUTC(issues, time.Now().UTC())
+	}
+	if !opts.PreserveRowIDs {
+		for _, issue := range issues {
+			issue.RowID = 0
+		}
+	}
+	if opts.NormalizeNewlines || opts.TrimTrailingWhitespace {
+		normalizeIssueText(issues, opts.NormalizeNewlines, opts.TrimTrailingWhitespace)
+	}
+
+	// Normalize Linear external_refs to canonical form to avoid slug-based duplicates.
+	for _, issue := range issues {
+		if issue.ExternalRef == nil || *issue.ExternalRef == "" {
+			continue
+		}
+		if linear.IsLinearExternalRef(*issue.ExternalRef) {
+			if canonical, ok := linear.CanonicalizeLinearExternalRef(*issue.ExternalRef); ok {
+				issue.ExternalRef = &canonical
+			}
+		}
+	}
+
+	// Checklists are hashed in item order, which is stored by position
+	for _, issue := range issues {
+		sort.SliceStable(issue.Checklist, func(i, j int) bool {
+			return issue.Checklist[i].Position < issue.Checklist[j].Position
+		})
+	}
+
+	// Compute content hashes for all incoming issues
+	// Always recompute to avoid stale/incorrect JSONL hashes
+	for _, issue := range issues {
+		issue.ContentHash = issueContentHash(issue, opts)
+	}
+
+	// Auto-detect wisps by ID pattern and set ephemeral flag
+	// This prevents orphaned wisp entries in JSONL from polluting bd ready
+	// Pattern: *-wisp-* indicates ephemeral patrol/workflow instances
+	for _, issue := range issues {
+		if strings.Contains(issue.ID, "-wisp-") && !issue.Ephemeral {
+			issue.Ephemeral = true
+		}
+	}
+}
+
+// resolveOrphanHandling returns handling, or the import.orphan_handling
+// config value when handling is unset, defaulting to OrphanAllow.
+func resolveOrphanHandling(ctx context.Context, cfg interface {
+	GetConfig(ctx context.Context, key string) (string, error)
+}, handling OrphanHandling) OrphanHandling {
+	if handling != "" {
+		return handling
+	}
+	value, err := cfg.GetConfig(ctx, "import.orphan_handling")
+	if err != nil || value == "" {
+		return OrphanAllow
+	}
+	switch OrphanHandling(value) {
+	case OrphanStrict, OrphanResurrect, OrphanSkip, OrphanAllow:
+		return OrphanHandling(value)
+	default:
+		return OrphanAllow
+	}
+}
+
 // handlePrefixMismatch checks and handles prefix mismatches.
 // Returns a filtered issues slice with tombstoned issues having wrong prefixes removed.
 func handlePrefixMismatch(ctx context.Context, store storage.Storage, issues []*types.Issue, opts Options, result *Result) ([]*types.Issue, error) {
@@ -258,6 +525,12 @@ func handlePrefixMismatch(ctx context.Context, store storage.Storage, issues []*
 
 	result.ExpectedPrefix = configuredPrefix
 
+	// IDs join prefix and hash with the configured separator ("-" by default)
+	sep, _ := store.GetConfig(ctx, storage.IDSeparatorConfigKey)
+	if sep == "" {
+		sep = utils.DefaultIDSeparator
+	}
+
 	// Read allowed_prefixes config for additional valid prefixes (e.g., mol-*)
 	allowedPrefixesConfig, _ := store.GetConfig(ctx, "allowed_prefixes")
 
@@ -275,6 +548,8 @@ func handlePrefixMismatch(ctx context.Context, store storage.Storage, issues []*
 	// Track tombstones separately - they don't count as "real" mismatches
 	tombstoneMismatchPrefixes := make(map[string]int)
 	nonTombstoneMismatchCount := 0
+	var firstMismatch, firstMismatchPrefix string
+	var mismatched []*types.Issue
 
 	// Also track which tombstones have wrong prefixes for filtering
 	var filteredIssues []*types.Issue
@@ -287,22 +562,26 @@ func handlePrefixMismatch(ctx context.Context, store storage.Storage, issues []*
 		// Also check against allowed_prefixes config
 		prefixMatches := false
 		for prefix := range allowedPrefixes {
-			if strings.HasPrefix(issue.ID, prefix+"-") {
+			if strings.HasPrefix(issue.ID, prefix+sep) {
 				prefixMatches = true
 				break
 			}
 		}
 		if !prefixMatches {
 			// Extract prefix for error reporting (best effort)
-			prefix := utils.ExtractIssuePrefix(issue.ID)
+			prefix := utils.ExtractIssuePrefixWithSeparator(issue.ID, sep)
 			if issue.IsTombstone() {
 				tombstoneMismatchPrefixes[prefix]++
 				tombstonesToRemove = append(tombstonesToRemove, issue.ID)
 				// Don't add to filtered list - we'll remove these
 			} else {
+				if !result.PrefixMismatch {
+					firstMismatch, firstMismatchPrefix = issue.ID, prefix
+				}
 				result.PrefixMismatch = true
 				result.MismatchPrefixes[prefix]++
 				nonTombstoneMismatchCount++
+				mismatched = append(mismatched, issue)
 				filteredIssues = append(filteredIssues, issue)
 			}
 		} else {
@@ -331,16 +610,39 @@ func handlePrefixMismatch(ctx context.Context, store storage.Storage, issues []*
 	// but still report the error for non-tombstones
 	if result.PrefixMismatch {
 		// If not handling the mismatch, return error
+		if !opts.RenameOnImport && !opts.DryRun && !opts.SkipPrefixValidation && opts.ContinueOnError {
+			// Leave out each mismatched issue, and the mismatched tombstones
+			// that would otherwise have failed the import with them
+			for _, issue := range mismatched {
+				err := &PrefixError{IssueID: issue.ID, Prefix: utils.ExtractIssuePrefixWithSeparator(issue.ID, sep), Expected: configuredPrefix,
+					Err: fmt.Errorf("prefix mismatch detected: database uses '%s-' (use --rename-on-import to automatically fix)", configuredPrefix)}
+				if err := rejectIssue(ctx, issue, err, opts, result); err != nil {
+					return nil, err
+				}
+			}
+			return result.withoutRejected(filteredIssues), nil
+		}
 		if !opts.RenameOnImport && !opts.DryRun && !opts.SkipPrefixValidation {
-			return nil, fmt.Errorf("prefix mismatch detected: database uses '%s-' but found issues with prefixes: %v (use --rename-on-import to automatically fix)", configuredPrefix, GetPrefixList(result.MismatchPrefixes))
+			return nil, &PrefixError{IssueID: firstMismatch, Prefix: firstMismatchPrefix, Expected: configuredPrefix,
+				Err: fmt.Errorf("prefix mismatch detected: database uses '%s-' but found issues with prefixes: %v (use --rename-on-import to automatically fix)", configuredPrefix, GetPrefixList(result.MismatchPrefixes))}
 		}
 	}
 
 	// Handle rename-on-import if requested
 	if result.PrefixMismatch && opts.RenameOnImport && !opts.DryRun {
+		originalIDs := make([]string, len(issues))
+		for i, issue := range issues {
+			originalIDs[i] = issue.ID
+		}
 		if err := RenameImportedIssuePrefixes(issues, configuredPrefix); err != nil {
 			return nil, fmt.Errorf("failed to rename prefixes: %w", err)
 		}
+		// Remember original IDs so they can be stored as aliases
+		for i, issue := range issues {
+			if originalIDs[i] != issue.ID {
+				result.IDMapping[originalIDs[i]] = issue.ID
+			}
+		}
 		// After renaming, clear the mismatch flags since we fixed them
 		result.PrefixMismatch = false
 		result.MismatchPrefixes = make(map[string]int)
@@ -352,7 +654,7 @@ func handlePrefixMismatch(ctx context.Context, store storage.Storage, issues []*
 }
 
 // detectUpdates detects same-ID scenarios (which are updates with hash IDs, not collisions)
-func detectUpdates(ctx context.Context, store storage.Storage, issues []*types.Issue, opts Options, result *Result) ([]*types.Issue, error) {
+func detectUpdates(ctx context.Context, store issueSearcher, issues []*types.Issue, opts Options, result *Result) ([]*types.Issue, error) {
 	// Backend-agnostic collision detection:
 	// "collision" here means: same ID exists but content hash differs.
 	dbIssues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeTombstones: true})
@@ -371,7 +673,7 @@ func detectUpdates(ctx context.Context, store storage.Storage, issues []*types.I
 			continue
 		}
 		// Exact content match is idempotent.
-		if existing.ContentHash != "" && incoming.ContentHash != "" && existing.ContentHash == incoming.ContentHash {
+		if existing.ContentHash != "" && incoming.ContentHash != "" && existing.ContentHash == incoming.ContentHash && sameDisplayMetadata(existing, incoming) {
 			exactCount++
 			continue
 		}
@@ -594,28 +896,35 @@ func upsertIssues(ctx context.Context, store storage.Storage, issues []*types.Is
 
 	// Track what we need to create
 	var newIssues []*types.Issue
-	seenHashes := make(map[string]bool)
+	seenHashes := make(map[string]*types.Issue)
 	seenIDs := make(map[string]bool) // Track IDs to prevent UNIQUE constraint errors
 
 	for _, incoming := range issues {
 		hash := incoming.ContentHash
 		if hash == "" {
 			// Shouldn't happen (computed earlier), but be defensive
-			hash = incoming.ComputeContentHash()
+			hash = issueContentHash(incoming, opts)
 			incoming.ContentHash = hash
 		}
 
 		// Skip duplicates within incoming batch (by content hash)
-		if seenHashes[hash] {
-			result.Skipped++
-			continue
+		if seen := seenHashes[hash]; seen != nil {
+			dup, err := isHashDuplicate(seen, incoming, opts, result)
+			if err != nil {
+				return err
+			}
+			if dup {
+				result.note(ImportEventSkipped, incoming.ID)
+				continue
+			}
+		} else {
+			seenHashes[hash] = incoming
 		}
-		seenHashes[hash] = true
 
 		// Skip duplicates by ID to prevent UNIQUE constraint violations
 		// This handles JSONL files with multiple versions of the same issue
 		if seenIDs[incoming.ID] {
-			result.Skipped++
+			result.note(ImportEventSkipped, incoming.ID)
 			continue
 		}
 		seenIDs[incoming.ID] = true
@@ -625,7 +934,7 @@ func upsertIssues(ctx context.Context, store storage.Storage, issues []*types.Is
 		// If this ID has a tombstone in the DB, skip importing it entirely.
 		if existingByID, found := dbByID[incoming.ID]; found {
 			if existingByID.Status == types.StatusTombstone {
-				result.Skipped++
+				result.note(ImportEventSkipped, incoming.ID)
 				continue
 			}
 		}
@@ -640,13 +949,13 @@ func upsertIssues(ctx context.Context, store storage.Storage, issues []*types.Is
 					// If local snapshot has a newer version, protect it from being overwritten
 					if shouldProtectFromUpdate(existing.ID, incoming.UpdatedAt, opts.ProtectLocalExportIDs) {
 						debugLogProtection(existing.ID, opts.ProtectLocalExportIDs[existing.ID], incoming.UpdatedAt)
-						result.Skipped++
+						result.note(ImportEventSkipped, incoming.ID)
 						continue
 					}
 					// Check timestamps - only update if incoming is newer
 					if !incoming.UpdatedAt.After(existing.UpdatedAt) {
 						// Local version is newer or same - skip update
-						result.Unchanged++
+						result.note(ImportEventUnchanged, incoming.ID)
 						continue
 					}
 
@@ -661,11 +970,21 @@ func upsertIssues(ctx context.Context, store storage.Storage, issues []*types.Is
 					updates["acceptance_criteria"] = incoming.AcceptanceCriteria
 					updates["notes"] = incoming.Notes
 					updates["closed_at"] = incoming.ClosedAt
+					updates["due_at"] = incoming.DueAt
+					updates["expires_at"] = incoming.ExpiresAt
+					updates["color"] = incoming.Color
+					updates["display_order"] = incoming.DisplayOrder
+					updates["rank"] = incoming.Rank
+					updates["milestone_id"] = incoming.MilestoneID
+					updates["source_system"] = incoming.SourceSystem
 					// Pinned field: Only update if explicitly true in JSONL
 					// (omitempty means false values are absent, so false = don't change existing)
 					if incoming.Pinned {
 						updates["pinned"] = incoming.Pinned
 					}
+					if incoming.CustomFields != nil {
+						updates["custom_fields"] = incoming.CustomFields
+					}
 
 					if incoming.Assignee != "" {
 						updates["assignee"] = incoming.Assignee
@@ -679,28 +998,39 @@ func upsertIssues(ctx context.Context, store storage.Storage, issues []*types.Is
 						updates["external_ref"] = nil
 					}
 
+					updates = projectUpdates(updates, incoming, opts.UpdateFields)
+
 					// Only update if data actually changed
 					if IssueDataChanged(existing, updates) {
+						if err := checkRestrictedID(opts, existing.ID); err != nil {
+							return err
+						}
 						if err := store.UpdateIssue(ctx, existing.ID, updates, "import"); err != nil {
-							return fmt.Errorf("error updating issue %s (matched by external_ref): %w", existing.ID, err)
+							return attributeValidationError(ctx, store, []*types.Issue{incoming}, fmt.Errorf("error updating issue %s (matched by external_ref): %w", existing.ID, err))
 						}
-						result.Updated++
+						result.note(ImportEventUpdated, incoming.ID)
 					} else {
-						result.Unchanged++
+						result.note(ImportEventUnchanged, incoming.ID)
 					}
 				} else {
-					result.Skipped++
+					result.note(ImportEventSkipped, incoming.ID)
 				}
 				continue
 			}
 		}
 
 		// Phase 1: Match by content hash
-		if existing, found := dbByHash[hash]; found {
+		existing, found := dbByHash[hash]
+		if found {
+			if found, err = isHashDuplicate(existing, incoming, opts, result); err != nil {
+				return err
+			}
+		}
+		if found {
 			// Same content exists
 			if existing.ID == incoming.ID {
 				// Exact match (same content, same ID) - idempotent case
-				result.Unchanged++
+				result.note(ImportEventUnchanged, incoming.ID)
 			} else {
 				// Same content, different ID - check if this is a rename or cross-prefix duplicate
 				existingPrefix := utils.ExtractIssuePrefix(existing.ID)
@@ -711,9 +1041,13 @@ func upsertIssues(ctx context.Context, store storage.Storage, issues []*types.Is
 					// This is NOT a rename - it's a duplicate from another project.
 					// Skip the incoming issue and keep the existing one unchanged.
 					// Calling handleRename would fail because CreateIssue validates prefix.
-					result.Skipped++
-				} else if !opts.SkipUpdate {
+					result.note(ImportEventSkipped, incoming.ID)
+				} else if !opts.SkipUpdate && len(opts.UpdateFields) == 0 {
 					// Same prefix, different ID suffix - this is a true rename
+					// (a column-subset import never renames)
+					if err := checkRestrictedID(opts, existing.ID); err != nil {
+						return err
+					}
 					deletedID, err := handleRename(ctx, store, existing, incoming)
 					if err != nil {
 						return fmt.Errorf("failed to handle rename %s -> %s: %w", existing.ID, incoming.ID, err)
@@ -722,9 +1056,9 @@ func upsertIssues(ctx context.Context, store storage.Storage, issues []*types.Is
 					if deletedID != "" {
 						delete(dbByID, deletedID)
 					}
-					result.Updated++
+					result.note(ImportEventUpdated, incoming.ID)
 				} else {
-					result.Skipped++
+					result.note(ImportEventSkipped, incoming.ID)
 				}
 			}
 			continue
@@ -734,25 +1068,28 @@ func upsertIssues(ctx context.Context, store storage.Storage, issues []*types.Is
 		if existingWithID, found := dbByID[incoming.ID]; found {
 			// Skip tombstones - don't try to update or resurrect deleted issues
 			if existingWithID.Status == types.StatusTombstone {
-				result.Skipped++
+				result.note(ImportEventSkipped, incoming.ID)
 				continue
 			}
 			// ID exists but different content - this is a collision
 			// The update should have been detected earlier by detectUpdates
 			// If we reach here, it means collision wasn't resolved - treat as update
 			if !opts.SkipUpdate {
-				// GH#865: Check timestamp-aware protection first
-				// If local snapshot has a newer version, protect it from being overwritten
-				if shouldProtectFromUpdate(incoming.ID, incoming.UpdatedAt, opts.ProtectLocalExportIDs) {
-					debugLogProtection(incoming.ID, opts.ProtectLocalExportIDs[incoming.ID], incoming.UpdatedAt)
-					result.Skipped++
-					continue
-				}
-				// Check timestamps - only update if incoming is newer
-				if !incoming.UpdatedAt.After(existingWithID.UpdatedAt) {
-					// Local version is newer or same - skip update
-					result.Unchanged++
-					continue
+				// An OnConflict callback decides instead of the timestamps
+				if opts.OnConflict == nil {
+					// GH#865: Check timestamp-aware protection first
+					// If local snapshot has a newer version, protect it from being overwritten
+					if shouldProtectFromUpdate(incoming.ID, incoming.UpdatedAt, opts.ProtectLocalExportIDs) {
+						debugLogProtection(incoming.ID, opts.ProtectLocalExportIDs[incoming.ID], incoming.UpdatedAt)
+						result.note(ImportEventSkipped, incoming.ID)
+						continue
+					}
+					// Check timestamps - only update if incoming is newer
+					if !incoming.UpdatedAt.After(existingWithID.UpdatedAt) {
+						// Local version is newer or same - skip update
+						result.note(ImportEventUnchanged, incoming.ID)
+						continue
+					}
 				}
 
 				// Build updates map
@@ -766,11 +1103,21 @@ func upsertIssues(ctx context.Context, store storage.Storage, issues []*types.Is
 				updates["acceptance_criteria"] = incoming.AcceptanceCriteria
 				updates["notes"] = incoming.Notes
 				updates["closed_at"] = incoming.ClosedAt
+				updates["due_at"] = incoming.DueAt
+				updates["expires_at"] = incoming.ExpiresAt
+				updates["color"] = incoming.Color
+				updates["display_order"] = incoming.DisplayOrder
+				updates["rank"] = incoming.Rank
+				updates["milestone_id"] = incoming.MilestoneID
+				updates["source_system"] = incoming.SourceSystem
 				// Pinned field: Only update if explicitly true in JSONL
 				// (omitempty means false values are absent, so false = don't change existing)
 				if incoming.Pinned {
 					updates["pinned"] = incoming.Pinned
 				}
+				if incoming.CustomFields != nil {
+					updates["custom_fields"] = incoming.CustomFields
+				}
 
 				if incoming.Assignee != "" {
 					updates["assignee"] = incoming.Assignee
@@ -784,25 +1131,42 @@ func upsertIssues(ctx context.Context, store storage.Storage, issues []*types.Is
 					updates["external_ref"] = nil
 				}
 
+				updates = projectUpdates(updates, incoming, opts.UpdateFields)
+				if opts.OnConflict != nil {
+					if updates, err = resolveConflict(existingWithID, incoming, updates, opts); err != nil {
+						return err
+					}
+				}
+
 				// Only update if data actually changed
 				if IssueDataChanged(existingWithID, updates) {
 					if err := store.UpdateIssue(ctx, incoming.ID, updates, "import"); err != nil {
-						return fmt.Errorf("error updating issue %s: %w", incoming.ID, err)
+						return attributeValidationError(ctx, store, []*types.Issue{incoming}, fmt.Errorf("error updating issue %s: %w", incoming.ID, err))
 					}
-					result.Updated++
+					result.note(ImportEventUpdated, incoming.ID)
 				} else {
-					result.Unchanged++
+					result.note(ImportEventUnchanged, incoming.ID)
 				}
 			} else {
-				result.Skipped++
+				result.note(ImportEventSkipped, incoming.ID)
 			}
 		} else {
 			// Truly new issue
-			newIssues = append(newIssues, incoming)
+			if ok, err := admitNewIssue(incoming, opts, result); err != nil {
+				return err
+			} else if ok {
+				newIssues = append(newIssues, incoming)
+			}
 		}
 	}
 
 	// Filter out orphaned issues if orphan_handling is set to skip
+	// Synthesized placeholders complete the hierarchy before orphan handling
+	synthesized := len(result.Synthesized)
+	if opts.SynthesizeParents {
+		newIssues = addSyntheticParents(dbByID, newIssues, opts, result)
+	}
+
 	// Pre-filter before batch creation to prevent orphans from being created then ID-cleared
 	if opts.OrphanHandling == OrphanSkip {
 		var filteredNewIssues []*types.Issue
@@ -829,7 +1193,7 @@ func upsertIssues(ctx context.Context, store storage.Storage, issues []*types.Is
 
 				if !parentExists {
 					// Skip this orphaned issue
-					result.Skipped++
+					result.note(ImportEventSkipped, issue.ID)
 					continue
 				}
 			}
@@ -847,7 +1211,7 @@ func upsertIssues(ctx context.Context, store storage.Storage, issues []*types.Is
 		for _, issue := range newIssues {
 			if isHierarchical, parentID := isHierarchicalID(issue.ID); isHierarchical {
 				if dbByID[parentID] == nil && !newIDSet[parentID] {
-					return fmt.Errorf("parent issue %s does not exist (strict mode)", parentID)
+					return &OrphanError{IssueID: issue.ID, ParentID: parentID, Err: fmt.Errorf("parent issue %s does not exist (strict mode)", parentID)}
 				}
 			}
 		}
@@ -856,22 +1220,17 @@ func upsertIssues(ctx context.Context, store storage.Storage, issues []*types.Is
 	// OrphanResurrect: if any hierarchical parents are missing, attempt to resurrect them
 	// from local JSONL history by creating tombstone parents (status=closed).
 	if opts.OrphanHandling == OrphanResurrect {
-		if err := addResurrectedParents(store, dbByID, issues, &newIssues); err != nil {
+		var err error
+		if newIssues, err = addResurrectedParents(store, dbByID, issues, newIssues, opts, result); err != nil {
 			return err
 		}
 	}
 
 	// Batch create all new issues
-	// Sort by hierarchy depth to ensure parents are created before children
+	// Sort by hierarchy depth to ensure parents are created before children,
+	// and deterministically within a depth
 	if len(newIssues) > 0 {
-		sort.Slice(newIssues, func(i, j int) bool {
-			depthI := hierarchyDepth(newIssues[i].ID)
-			depthJ := hierarchyDepth(newIssues[j].ID)
-			if depthI != depthJ {
-				return depthI < depthJ // Shallower first
-			}
-			return newIssues[i].ID < newIssues[j].ID // Stable sort
-		})
+		sortForCreate(newIssues)
 
 		// Create in batches by depth level (max depth 3)
 		for depth := 0; depth <= 3; depth++ {
@@ -888,16 +1247,19 @@ func upsertIssues(ctx context.Context, store storage.Storage, issues []*types.Is
 					SkipPrefixValidation: opts.SkipPrefixValidation,
 				}
 				if err := store.CreateIssuesWithFullOptions(ctx, batchForDepth, "import", batchOpts); err != nil {
-					return fmt.Errorf("error creating depth-%d issues: %w", depth, err)
+					return attributeValidationError(ctx, store, batchForDepth, fmt.Errorf("error creating depth-%d issues: %w", depth, err))
 				}
-				result.Created += len(batchForDepth)
+				for _, issue := range batchForDepth {
+					result.note(ImportEventCreated, issue.ID)
+				}
+				result.created = append(result.created, batchForDepth...)
 			}
 		}
 	}
 
 	// REMOVED: Counter sync after import - no longer needed with hash IDs
 
-	return nil
+	return recordSyntheticParents(ctx, store, result.Synthesized[synthesized:])
 }
 
 // upsertIssuesTx performs upsert using a transaction for atomicity.
@@ -926,31 +1288,38 @@ func upsertIssuesTx(ctx context.Context, tx storage.Transaction, store storage.S
 
 	// Track what we need to create
 	var newIssues []*types.Issue
-	seenHashes := make(map[string]bool)
+	seenHashes := make(map[string]*types.Issue)
 	seenIDs := make(map[string]bool)
 
 	for _, incoming := range issues {
 		hash := incoming.ContentHash
 		if hash == "" {
-			hash = incoming.ComputeContentHash()
+			hash = issueContentHash(incoming, opts)
 			incoming.ContentHash = hash
 		}
 
-		if seenHashes[hash] {
-			result.Skipped++
-			continue
+		if seen := seenHashes[hash]; seen != nil {
+			dup, err := isHashDuplicate(seen, incoming, opts, result)
+			if err != nil {
+				return err
+			}
+			if dup {
+				result.note(ImportEventSkipped, incoming.ID)
+				continue
+			}
+		} else {
+			seenHashes[hash] = incoming
 		}
-		seenHashes[hash] = true
 
 		if seenIDs[incoming.ID] {
-			result.Skipped++
+			result.note(ImportEventSkipped, incoming.ID)
 			continue
 		}
 		seenIDs[incoming.ID] = true
 
 		// Never resurrect over tombstones.
 		if existingByID, found := dbByID[incoming.ID]; found && existingByID != nil && existingByID.Status == types.StatusTombstone {
-			result.Skipped++
+			result.note(ImportEventSkipped, incoming.ID)
 			continue
 		}
 
@@ -960,11 +1329,11 @@ func upsertIssuesTx(ctx context.Context, tx storage.Transaction, store storage.S
 				if !opts.SkipUpdate {
 					if shouldProtectFromUpdate(existing.ID, incoming.UpdatedAt, opts.ProtectLocalExportIDs) {
 						debugLogProtection(existing.ID, opts.ProtectLocalExportIDs[existing.ID], incoming.UpdatedAt)
-						result.Skipped++
+						result.note(ImportEventSkipped, incoming.ID)
 						continue
 					}
 					if !incoming.UpdatedAt.After(existing.UpdatedAt) {
-						result.Unchanged++
+						result.note(ImportEventUnchanged, incoming.ID)
 						continue
 					}
 					updates := map[string]interface{}{
@@ -977,10 +1346,20 @@ func upsertIssuesTx(ctx context.Context, tx storage.Transaction, store storage.S
 						"acceptance_criteria": incoming.AcceptanceCriteria,
 						"notes":               incoming.Notes,
 						"closed_at":           incoming.ClosedAt,
+						"due_at":              incoming.DueAt,
+						"expires_at":          incoming.ExpiresAt,
+						"color":               incoming.Color,
+						"display_order":       incoming.DisplayOrder,
+						"rank":                incoming.Rank,
+						"milestone_id":        incoming.MilestoneID,
+						"source_system":       incoming.SourceSystem,
 					}
 					if incoming.Pinned {
 						updates["pinned"] = incoming.Pinned
 					}
+					if incoming.CustomFields != nil {
+						updates["custom_fields"] = incoming.CustomFields
+					}
 					if incoming.Assignee != "" {
 						updates["assignee"] = incoming.Assignee
 					} else {
@@ -991,31 +1370,51 @@ func upsertIssuesTx(ctx context.Context, tx storage.Transaction, store storage.S
 					} else {
 						updates["external_ref"] = nil
 					}
+					updates = projectUpdates(updates, incoming, opts.UpdateFields)
 					if IssueDataChanged(existing, updates) {
-						if err := tx.UpdateIssue(ctx, existing.ID, updates, "import"); err != nil {
-							return fmt.Errorf("error updating issue %s (matched by external_ref): %w", existing.ID, err)
+						if err := checkRestrictedID(opts, existing.ID); err != nil {
+							return err
 						}
-						result.Updated++
+						if err := writeIssueTx(ctx, tx, opts, func() error { return tx.UpdateIssue(ctx, existing.ID, updates, "import") }); err != nil {
+							err = attributeValidationError(ctx, tx, []*types.Issue{incoming}, fmt.Errorf("error updating issue %s (matched by external_ref): %w", existing.ID, err))
+							if err := rejectIssue(ctx, incoming, err, opts, result); err != nil {
+								return err
+							}
+							continue
+						}
+						if err := recordProvenanceTx(ctx, tx, existing.ID, ChangedFields(existing, updates), incoming, opts); err != nil {
+							return err
+						}
+						result.note(ImportEventUpdated, incoming.ID)
 					} else {
-						result.Unchanged++
+						result.note(ImportEventUnchanged, incoming.ID)
 					}
 				} else {
-					result.Skipped++
+					result.note(ImportEventSkipped, incoming.ID)
 				}
 				continue
 			}
 		}
 
 		// Phase 1: content hash
-		if existing, found := dbByHash[hash]; found && existing != nil {
+		existing, found := dbByHash[hash]
+		if found && existing != nil {
+			if found, err = isHashDuplicate(existing, incoming, opts, result); err != nil {
+				return err
+			}
+		}
+		if found && existing != nil {
 			if existing.ID == incoming.ID {
-				result.Unchanged++
+				result.note(ImportEventUnchanged, incoming.ID)
 			} else {
 				existingPrefix := utils.ExtractIssuePrefix(existing.ID)
 				incomingPrefix := utils.ExtractIssuePrefix(incoming.ID)
 				if existingPrefix != incomingPrefix {
-					result.Skipped++
-				} else if !opts.SkipUpdate {
+					result.note(ImportEventSkipped, incoming.ID)
+				} else if !opts.SkipUpdate && len(opts.UpdateFields) == 0 {
+					if err := checkRestrictedID(opts, existing.ID); err != nil {
+						return err
+					}
 					deletedID, err := handleRenameTx(ctx, tx, existing, incoming)
 					if err != nil {
 						return fmt.Errorf("failed to handle rename %s -> %s: %w", existing.ID, incoming.ID, err)
@@ -1023,9 +1422,9 @@ func upsertIssuesTx(ctx context.Context, tx storage.Transaction, store storage.S
 					if deletedID != "" {
 						delete(dbByID, deletedID)
 					}
-					result.Updated++
+					result.note(ImportEventUpdated, incoming.ID)
 				} else {
-					result.Skipped++
+					result.note(ImportEventSkipped, incoming.ID)
 				}
 			}
 			continue
@@ -1034,18 +1433,20 @@ func upsertIssuesTx(ctx context.Context, tx storage.Transaction, store storage.S
 		// Phase 2: same ID exists -> update candidate
 		if existingWithID, found := dbByID[incoming.ID]; found && existingWithID != nil {
 			if existingWithID.Status == types.StatusTombstone {
-				result.Skipped++
+				result.note(ImportEventSkipped, incoming.ID)
 				continue
 			}
 			if !opts.SkipUpdate {
-				if shouldProtectFromUpdate(incoming.ID, incoming.UpdatedAt, opts.ProtectLocalExportIDs) {
-					debugLogProtection(incoming.ID, opts.ProtectLocalExportIDs[incoming.ID], incoming.UpdatedAt)
-					result.Skipped++
-					continue
-				}
-				if !incoming.UpdatedAt.After(existingWithID.UpdatedAt) {
-					result.Unchanged++
-					continue
+				if opts.OnConflict == nil {
+					if shouldProtectFromUpdate(incoming.ID, incoming.UpdatedAt, opts.ProtectLocalExportIDs) {
+						debugLogProtection(incoming.ID, opts.ProtectLocalExportIDs[incoming.ID], incoming.UpdatedAt)
+						result.note(ImportEventSkipped, incoming.ID)
+						continue
+					}
+					if !incoming.UpdatedAt.After(existingWithID.UpdatedAt) {
+						result.note(ImportEventUnchanged, incoming.ID)
+						continue
+					}
 				}
 				updates := map[string]interface{}{
 					"title":               incoming.Title,
@@ -1057,10 +1458,20 @@ func upsertIssuesTx(ctx context.Context, tx storage.Transaction, store storage.S
 					"acceptance_criteria": incoming.AcceptanceCriteria,
 					"notes":               incoming.Notes,
 					"closed_at":           incoming.ClosedAt,
+					"due_at":              incoming.DueAt,
+					"expires_at":          incoming.ExpiresAt,
+					"color":               incoming.Color,
+					"display_order":       incoming.DisplayOrder,
+					"rank":                incoming.Rank,
+					"milestone_id":        incoming.MilestoneID,
+					"source_system":       incoming.SourceSystem,
 				}
 				if incoming.Pinned {
 					updates["pinned"] = incoming.Pinned
 				}
+				if incoming.CustomFields != nil {
+					updates["custom_fields"] = incoming.CustomFields
+				}
 				if incoming.Assignee != "" {
 					updates["assignee"] = incoming.Assignee
 				} else {
@@ -1071,22 +1482,44 @@ func upsertIssuesTx(ctx context.Context, tx storage.Transaction, store storage.S
 				} else {
 					updates["external_ref"] = nil
 				}
+				updates = projectUpdates(updates, incoming, opts.UpdateFields)
+				if opts.OnConflict != nil {
+					if updates, err = resolveConflict(existingWithID, incoming, updates, opts); err != nil {
+						return err
+					}
+				}
 				if IssueDataChanged(existingWithID, updates) {
-					if err := tx.UpdateIssue(ctx, incoming.ID, updates, "import"); err != nil {
-						return fmt.Errorf("error updating issue %s: %w", incoming.ID, err)
+					if err := writeIssueTx(ctx, tx, opts, func() error { return tx.UpdateIssue(ctx, incoming.ID, updates, "import") }); err != nil {
+						err = attributeValidationError(ctx, tx, []*types.Issue{incoming}, fmt.Errorf("error updating issue %s: %w", incoming.ID, err))
+						if err := rejectIssue(ctx, incoming, err, opts, result); err != nil {
+							return err
+						}
+						continue
+					}
+					if err := recordProvenanceTx(ctx, tx, incoming.ID, ChangedFields(existingWithID, updates), incoming, opts); err != nil {
+						return err
 					}
-					result.Updated++
+					result.note(ImportEventUpdated, incoming.ID)
 				} else {
-					result.Unchanged++
+					result.note(ImportEventUnchanged, incoming.ID)
 				}
 			} else {
-				result.Skipped++
+				result.note(ImportEventSkipped, incoming.ID)
 			}
 		} else {
-			newIssues = append(newIssues, incoming)
+			if ok, err := admitNewIssue(incoming, opts, result); err != nil {
+				return err
+			} else if ok {
+				newIssues = append(newIssues, incoming)
+			}
 		}
 	}
 
+	synthesized := len(result.Synthesized)
+	if opts.SynthesizeParents {
+		newIssues = addSyntheticParents(dbByID, newIssues, opts, result)
+	}
+
 	// OrphanSkip/Strict/Resurrect handled using the same helpers as non-tx path
 	if opts.OrphanHandling == OrphanSkip {
 		var filtered []*types.Issue
@@ -1102,7 +1535,7 @@ func upsertIssuesTx(ctx context.Context, tx storage.Transaction, store storage.S
 						}
 					}
 					if !found {
-						result.Skipped++
+						result.note(ImportEventSkipped, issue.ID)
 						continue
 					}
 				}
@@ -1119,49 +1552,51 @@ func upsertIssuesTx(ctx context.Context, tx storage.Transaction, store storage.S
 		for _, issue := range newIssues {
 			if isHier, parentID := isHierarchicalID(issue.ID); isHier {
 				if dbByID[parentID] == nil && !newIDSet[parentID] {
-					return fmt.Errorf("parent issue %s does not exist (strict mode)", parentID)
+					return &OrphanError{IssueID: issue.ID, ParentID: parentID, Err: fmt.Errorf("parent issue %s does not exist (strict mode)", parentID)}
 				}
 			}
 		}
 	}
 	if opts.OrphanHandling == OrphanResurrect {
-		if err := addResurrectedParents(store, dbByID, issues, &newIssues); err != nil {
+		var err error
+		if newIssues, err = addResurrectedParents(store, dbByID, issues, newIssues, opts, result); err != nil {
 			return err
 		}
 	}
 
 	// Create new issues in deterministic depth order using tx.
 	if len(newIssues) > 0 {
-		sort.Slice(newIssues, func(i, j int) bool {
-			di := hierarchyDepth(newIssues[i].ID)
-			dj := hierarchyDepth(newIssues[j].ID)
-			if di != dj {
-				return di < dj
-			}
-			return newIssues[i].ID < newIssues[j].ID
-		})
+		sortForCreate(newIssues)
 
 		type importCreator interface {
 			CreateIssueImport(ctx context.Context, issue *types.Issue, actor string, skipPrefixValidation bool) error
 		}
 		for _, iss := range newIssues {
-			if ic, ok := tx.(importCreator); ok {
-				if err := ic.CreateIssueImport(ctx, iss, "import", opts.SkipPrefixValidation); err != nil {
-					return err
+			err := writeIssueTx(ctx, tx, opts, func() error {
+				if ic, ok := tx.(importCreator); ok {
+					return ic.CreateIssueImport(ctx, iss, "import", opts.SkipPrefixValidation)
 				}
-			} else {
-				if err := tx.CreateIssue(ctx, iss, "import"); err != nil {
+				return tx.CreateIssue(ctx, iss, "import")
+			})
+			if err != nil {
+				if err := rejectIssue(ctx, iss, attributeValidationError(ctx, tx, []*types.Issue{iss}, err), opts, result); err != nil {
 					return err
 				}
+				continue
 			}
-			result.Created++
+			if err := recordProvenanceTx(ctx, tx, iss.ID, provenanceTrackedFields, iss, opts); err != nil {
+				return err
+			}
+			result.note(ImportEventCreated, iss.ID)
+			result.created = append(result.created, iss)
 		}
 	}
 
-	return nil
+	return recordSyntheticParents(ctx, tx, result.Synthesized[synthesized:])
 }
 
 func importDependenciesTx(ctx context.Context, tx storage.Transaction, issues []*types.Issue, opts Options, result *Result) error {
+	seen := make(dependencyEdges)
 	for _, issue := range issues {
 		if len(issue.Dependencies) == 0 {
 			continue
@@ -1178,11 +1613,26 @@ func importDependenciesTx(ctx context.Context, tx storage.Transaction, issues []
 		}
 
 		for _, dep := range issue.Dependencies {
+			repeat, err := seen.repeated(dep, opts)
+			if err != nil {
+				return err
+			}
+			if repeat {
+				continue
+			}
 			key := fmt.Sprintf("%s|%s", dep.DependsOnID, dep.Type)
 			if existingSet[key] {
 				continue
 			}
-			if err := tx.AddDependency(ctx, dep, "import"); err != nil {
+			addCtx, add, err := resolveDependencyInversion(ctx, tx, dep, opts, result)
+			if err != nil {
+				return err
+			}
+			if !add {
+				continue
+			}
+			if err := tx.AddDependency(addCtx, dep, "import"); err != nil {
+				err = dependencyForeignKeyError(ctx, tx, dep, err)
 				if opts.Strict {
 					return fmt.Errorf("error adding dependency %s → %s: %w", dep.IssueID, dep.DependsOnID, err)
 				}
@@ -1198,6 +1648,78 @@ func importDependenciesTx(ctx context.Context, tx storage.Transaction, issues []
 	return nil
 }
 
+// recordProvenanceTx records opts.ProvenanceSource as the last writer of fields
+// on issueID, timestamped with the incoming issue's updated_at. No-op unless
+// provenance tracking is enabled and the backend supports it.
+func recordProvenanceTx(ctx context.Context, tx storage.Transaction, issueID string, fields []string, incoming *types.Issue, opts Options) error {
+	if opts.ProvenanceSource == "" || len(fields) == 0 {
+		return nil
+	}
+	type provenanceRecorder interface {
+		RecordFieldProvenance(ctx context.Context, issueID string, fields []string, source string, at time.Time) error
+	}
+	recorder, ok := tx.(provenanceRecorder)
+	if !ok {
+		return nil
+	}
+	at := incoming.UpdatedAt
+	if at.IsZero() {
+		at = time.Now()
+	}
+	if err := recorder.RecordFieldProvenance(ctx, issueID, fields, opts.ProvenanceSource, at); err != nil {
+		return fmt.Errorf("failed to record provenance for %s: %w", issueID, err)
+	}
+	return nil
+}
+
+// provenanceTrackedFields are the fields an import sets on a new issue, all of
+// which are attributed to the importing source.
+var provenanceTrackedFields = []string{
+	"acceptance_criteria", "assignee", "description", "design", "external_ref",
+	"issue_type", "notes", "pinned", "priority", "status", "title",
+}
+
+// issueGetter is the subset of storage.Storage and storage.Transaction used to
+// check whether a referenced issue exists.
+type issueGetter interface {
+	GetIssue(ctx context.Context, id string) (*types.Issue, error)
+}
+
+// issueSearcher is satisfied by both storage.Storage and storage.Transaction.
+type issueSearcher interface {
+	SearchIssues(ctx context.Context, query string, filter types.IssueFilter) ([]*types.Issue, error)
+}
+
+// dependencyForeignKeyError converts a failed dependency insert into a
+// *ForeignKeyError when either endpoint is missing; other errors pass through.
+func dependencyForeignKeyError(ctx context.Context, tx issueGetter, dep *types.Dependency, err error) error {
+	if issue, getErr := tx.GetIssue(ctx, dep.IssueID); getErr == nil && issue == nil {
+		return &ForeignKeyError{IssueID: dep.IssueID, Field: "dependencies", MissingID: dep.IssueID, DepType: dep.Type, Err: err}
+	}
+	if strings.HasPrefix(dep.DependsOnID, "external:") {
+		return err
+	}
+	if target, getErr := tx.GetIssue(ctx, dep.DependsOnID); getErr == nil && target == nil {
+		return &ForeignKeyError{IssueID: dep.IssueID, Field: "dependencies", MissingID: dep.DependsOnID, DepType: dep.Type, Err: err}
+	}
+	return err
+}
+
+// importIDAliasesTx stores each remapped issue's original ID as an alias of its
+// new ID, when the backend supports aliases.
+func importIDAliasesTx(ctx context.Context, tx storage.Transaction, idMapping map[string]string) error {
+	recorder, ok := tx.(storage.IDAliasRecorder)
+	if !ok || len(idMapping) == 0 {
+		return nil
+	}
+	for oldID, newID := range idMapping {
+		if err := recorder.AddIDAlias(ctx, oldID, newID); err != nil {
+			return fmt.Errorf("failed to record alias %s for %s: %w", oldID, newID, err)
+		}
+	}
+	return nil
+}
+
 func importLabelsTx(ctx context.Context, tx storage.Transaction, issues []*types.Issue, opts Options) error {
 	for _, issue := range issues {
 		if len(issue.Labels) == 0 {
@@ -1254,21 +1776,130 @@ func importCommentsTx(ctx context.Context, tx storage.Transaction, issues []*typ
 	return nil
 }
 
+// importWatchers adds watchers missing from each issue, when the backend (or
+// transaction) tracks watchers. Existing watchers are left in place.
+func importWatchers(ctx context.Context, store interface{}, issues []*types.Issue, opts Options) error {
+	watcherStore, ok := store.(storage.WatcherStore)
+	if !ok {
+		return nil
+	}
+	for _, issue := range issues {
+		if len(issue.Watchers) == 0 {
+			continue
+		}
+		current, err := watcherStore.GetWatchers(ctx, issue.ID)
+		if err != nil {
+			return fmt.Errorf("error getting watchers for %s: %w", issue.ID, err)
+		}
+		set := make(map[string]bool, len(current))
+		for _, w := range current {
+			set[w] = true
+		}
+		for _, watcher := range issue.Watchers {
+			if set[watcher] {
+				continue
+			}
+			set[watcher] = true
+			if err := watcherStore.AddWatcher(ctx, issue.ID, watcher, "import"); err != nil {
+				if opts.Strict {
+					return fmt.Errorf("error adding watcher %s to %s: %w", watcher, issue.ID, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// importChecklists replaces the checklist of each issue that carries one,
+// when the backend (or transaction) keeps checklists and the stored list
+// differs in order, text or done state. Issues imported without a checklist
+// keep the one they have, since sparse sources may not export checklists.
+// Outside Strict mode a checklist that fails to store is warned about and
+// recorded in result.SkippedChecklists instead of failing the import.
+func importChecklists(ctx context.Context, store interface{}, issues []*types.Issue, opts Options, result *Result) error {
+	checklistStore, ok := store.(storage.ChecklistStore)
+	if !ok {
+		return nil
+	}
+	for _, issue := range issues {
+		if len(issue.Checklist) == 0 {
+			continue
+		}
+		current, err := checklistStore.GetChecklist(ctx, issue.ID)
+		if err != nil {
+			return fmt.Errorf("error getting checklist for %s: %w", issue.ID, err)
+		}
+		if sameChecklist(current, issue.Checklist) {
+			continue
+		}
+		if err := checklistStore.SetChecklist(ctx, issue.ID, issue.Checklist, "import"); err != nil {
+			if opts.Strict {
+				return fmt.Errorf("error setting checklist of %s: %w", issue.ID, err)
+			}
+			fmt.Fprintf(os.Stderr, "Warning: Skipping checklist of %s due to error: %v\n", issue.ID, err)
+			result.SkippedChecklists = append(result.SkippedChecklists, issue.ID)
+		}
+	}
+	return nil
+}
+
+// sameChecklist reports whether two checklists hold the same items in the
+// same order. Positions only order the items, so they are not compared.
+func sameChecklist(a, b []*types.ChecklistItem) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Text != b[i].Text || a[i].Done != b[i].Done {
+			return false
+		}
+	}
+	return true
+}
+
 // addResurrectedParents ensures missing hierarchical parents exist by adding "tombstone parent"
-// issues to newIssues (if needed). Parents are sourced from the local JSONL file when possible.
-func addResurrectedParents(store storage.Storage, dbByID map[string]*types.Issue, allIncoming []*types.Issue, newIssues *[]*types.Issue) error {
+// issues to newIssues (if needed), and returns the updated slice. Parents are sourced from the
+// local JSONL file when possible. Issues more than opts.MaxResurrectDepth missing levels below
+// their nearest existing ancestor are handled per opts.ResurrectFallback instead.
+func addResurrectedParents(store storage.Storage, dbByID map[string]*types.Issue, allIncoming []*types.Issue, newIssues []*types.Issue, opts Options, result *Result) ([]*types.Issue, error) {
+	switch opts.ResurrectFallback {
+	case "", OrphanStrict, OrphanSkip:
+	default:
+		return nil, fmt.Errorf("unknown resurrect fallback %q (want strict or skip)", opts.ResurrectFallback)
+	}
+
 	// Track which IDs will exist after creation
-	willExist := make(map[string]bool, len(dbByID)+len(*newIssues))
+	willExist := make(map[string]bool, len(dbByID)+len(newIssues))
 	for id, iss := range dbByID {
 		if iss != nil {
 			willExist[id] = true
 		}
 	}
-	for _, iss := range *newIssues {
+	for _, iss := range newIssues {
 		willExist[iss.ID] = true
 	}
+	incoming := make(map[string]bool, len(allIncoming))
+	for _, iss := range allIncoming {
+		incoming[iss.ID] = true
+	}
+
+	// missingLevels counts the ancestors, starting at parentID, that would have
+	// to be resurrected.
+	missingLevels := func(parentID string) int {
+		levels := 0
+		for id := parentID; !willExist[id] && !incoming[id]; {
+			levels++
+			isHier, grandParent := isHierarchicalID(id)
+			if !isHier {
+				break
+			}
+			id = grandParent
+		}
+		return levels
+	}
 
 	// Helper to ensure a single parent exists (and its ancestors).
+	var resurrected []*types.Issue
 	var ensureParent func(parentID string) error
 	ensureParent = func(parentID string) error {
 		if willExist[parentID] {
@@ -1282,11 +1913,9 @@ func addResurrectedParents(store storage.Storage, dbByID map[string]*types.Issue
 		}
 
 		// Try to find the issue in the incoming batch first (already being imported)
-		for _, iss := range allIncoming {
-			if iss.ID == parentID {
-				willExist[parentID] = true
-				return nil
-			}
+		if incoming[parentID] {
+			willExist[parentID] = true
+			return nil
 		}
 
 		// Try local JSONL history
@@ -1314,22 +1943,61 @@ func addResurrectedParents(store storage.Storage, dbByID map[string]*types.Issue
 			Description: "[RESURRECTED] Recreated as closed to preserve hierarchical structure.",
 		}
 		// Compute hash (ImportIssues computed hashes for original slice only)
-		tombstone.ContentHash = tombstone.ComputeContentHash()
+		tombstone.ContentHash = tombstone.ComputeContentHashWith(opts.hashOptions)
 
-		*newIssues = append(*newIssues, tombstone)
+		resurrected = append(resurrected, tombstone)
 		willExist[parentID] = true
 		return nil
 	}
 
-	// Walk newIssues and ensure parents exist
-	for _, iss := range *newIssues {
-		if isHier, parentID := isHierarchicalID(iss.ID); isHier {
-			if err := ensureParent(parentID); err != nil {
-				return err
+	// Walk newIssues shallowest first, so a skipped issue's descendants are
+	// seen after it, and ensure parents exist
+	ordered := append([]*types.Issue(nil), newIssues...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return hierarchyDepth(ordered[i].ID) < hierarchyDepth(ordered[j].ID)
+	})
+	skipped := make(map[string]bool)
+	underSkipped := func(id string) bool {
+		for isHier := true; isHier; isHier, id = isHierarchicalID(id) {
+			if skipped[id] {
+				return true
 			}
 		}
+		return false
 	}
-	return nil
+	for _, iss := range ordered {
+		isHier, parentID := isHierarchicalID(iss.ID)
+		if !isHier {
+			continue
+		}
+		if underSkipped(parentID) {
+			skipped[iss.ID] = true
+			continue
+		}
+		if levels := missingLevels(parentID); opts.MaxResurrectDepth > 0 && levels > opts.MaxResurrectDepth {
+			if opts.ResurrectFallback == OrphanSkip {
+				skipped[iss.ID] = true
+				continue
+			}
+			return nil, &OrphanError{IssueID: iss.ID, ParentID: parentID, Err: fmt.Errorf("%d missing ancestor levels exceed the resurrect depth limit of %d", levels, opts.MaxResurrectDepth)}
+		}
+		if err := ensureParent(parentID); err != nil {
+			return nil, &OrphanError{IssueID: iss.ID, ParentID: parentID, Err: err}
+		}
+	}
+
+	kept := make([]*types.Issue, 0, len(newIssues)+len(resurrected))
+	for _, iss := range newIssues {
+		if skipped[iss.ID] {
+			result.note(ImportEventSkipped, iss.ID)
+			continue
+		}
+		kept = append(kept, iss)
+	}
+	for _, tombstone := range resurrected {
+		result.Resurrected = append(result.Resurrected, tombstone.ID)
+	}
+	return append(kept, resurrected...), nil
 }
 
 func findIssueInLocalJSONL(jsonlPath, issueID string) (*types.Issue, error) {
@@ -1389,6 +2057,7 @@ func importDependencies(ctx context.Context, store storage.Storage, issues []*ty
 		}
 	}
 
+	seen := make(dependencyEdges)
 	for _, issue := range issues {
 		if len(issue.Dependencies) == 0 {
 			continue
@@ -1408,11 +2077,24 @@ func importDependencies(ctx context.Context, store storage.Storage, issues []*ty
 		}
 
 		for _, dep := range issue.Dependencies {
+			repeat, err := seen.repeated(dep, opts)
+			if err != nil {
+				return err
+			}
+			if repeat {
+				continue
+			}
+
 			// Validate referenced issues exist (after upsert). Tombstones count as existing.
 			if !exists[dep.IssueID] || !exists[dep.DependsOnID] {
 				depDesc := fmt.Sprintf("%s → %s (%s)", dep.IssueID, dep.DependsOnID, dep.Type)
 				if opts.Strict {
-					return fmt.Errorf("missing reference for dependency: %s", depDesc)
+					missing := dep.DependsOnID
+					if !exists[dep.IssueID] {
+						missing = dep.IssueID
+					}
+					return fmt.Errorf("missing reference for dependency: %s: %w", depDesc,
+						&ForeignKeyError{IssueID: dep.IssueID, Field: "dependencies", MissingID: missing, DepType: dep.Type})
 				}
 				fmt.Fprintf(os.Stderr, "Warning: Skipping dependency due to missing reference: %s\n", depDesc)
 				if result != nil {
@@ -1427,8 +2109,18 @@ func importDependencies(ctx context.Context, store storage.Storage, issues []*ty
 				continue
 			}
 
+			// Resolve contradictory edges before the cycle check rejects them
+			addCtx, add, err := resolveDependencyInversion(ctx, store, dep, opts, result)
+			if err != nil {
+				return err
+			}
+			if !add {
+				continue
+			}
+
 			// Add dependency
-			if err := store.AddDependency(ctx, dep, "import"); err != nil {
+			if err := store.AddDependency(addCtx, dep, "import"); err != nil {
+				err = dependencyForeignKeyError(ctx, store, dep, err)
 				// Backend-agnostic: treat dependency insert errors as non-fatal unless strict mode is enabled.
 				if opts.Strict {
 					return fmt.Errorf("error adding dependency %s → %s: %w", dep.IssueID, dep.DependsOnID, err)
@@ -1569,8 +2261,12 @@ func validateNoDuplicateExternalRefs(issues []*types.Issue, clearDuplicates bool
 
 	var duplicates []string
 	duplicateIssueIDs := make(map[string]bool)
+	var first *DuplicateIDError
 	for ref, issueIDs := range seen {
 		if len(issueIDs) > 1 {
+			if first == nil || issueIDs[0] < first.IssueID {
+				first = &DuplicateIDError{IssueID: issueIDs[0], Field: "external_ref", Value: ref, Duplicates: issueIDs[1:]}
+			}
 			duplicates = append(duplicates, fmt.Sprintf("external_ref '%s' appears in issues: %v", ref, issueIDs))
 			// Track all duplicate issue IDs except the first one (keep first, clear rest)
 			for i := 1; i < len(issueIDs); i++ {
@@ -1595,7 +2291,8 @@ func validateNoDuplicateExternalRefs(issues []*types.Issue, clearDuplicates bool
 		}
 
 		sort.Strings(duplicates)
-		return fmt.Errorf("batch import contains duplicate external_ref values:\n%s\n\nUse --clear-duplicate-external-refs to automatically clear duplicates", strings.Join(duplicates, "\n"))
+		first.Err = fmt.Errorf("batch import contains duplicate external_ref values:\n%s\n\nUse --clear-duplicate-external-refs to automatically clear duplicates", strings.Join(duplicates, "\n"))
+		return first
 	}
 
 	return nil
@@ -5,6 +5,9 @@ package importer
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -12,6 +15,7 @@ import (
 	"time"
 
 	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -1751,3 +1755,788 @@ func TestBuildAllowedPrefixSet(t *testing.T) {
 		}
 	})
 }
+
+func TestImportIssues_BypassesStatusTransitionRules(t *testing.T) {
+	ctx := context.Background()
+
+	store := newTestStore(t)
+
+	existing := &types.Issue{
+		ID:        "test-abc123",
+		Title:     "Task",
+		Status:    types.StatusOpen,
+		Priority:  1,
+		IssueType: types.TypeTask,
+	}
+	if err := store.CreateIssue(ctx, existing, "test"); err != nil {
+		t.Fatalf("Failed to create issue: %v", err)
+	}
+
+	// Forbid open -> closed for interactive edits
+	if err := store.AddStatusTransition(ctx, types.StatusOpen, types.StatusInProgress); err != nil {
+		t.Fatalf("Failed to add transition rule: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, existing.ID, map[string]interface{}{"status": types.StatusClosed}, "test"); !sqlite.IsIllegalTransition(err) {
+		t.Fatalf("Expected illegal transition outside import, got %v", err)
+	}
+
+	closedAt := time.Now().Add(time.Hour)
+	incoming := &types.Issue{
+		ID:        "test-abc123",
+		Title:     "Task",
+		Status:    types.StatusClosed,
+		Priority:  1,
+		IssueType: types.TypeTask,
+		CreatedAt: time.Now(),
+		UpdatedAt: closedAt,
+		ClosedAt:  &closedAt,
+	}
+	if _, err := ImportIssues(ctx, store.Path(), store, []*types.Issue{incoming}, Options{}); err != nil {
+		t.Fatalf("Import should bypass transition rules: %v", err)
+	}
+
+	retrieved, err := store.GetIssue(ctx, existing.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve issue: %v", err)
+	}
+	if retrieved.Status != types.StatusClosed {
+		t.Errorf("Expected imported status closed, got %s", retrieved.Status)
+	}
+}
+
+func TestImportIssues_RemapRecordsAliases(t *testing.T) {
+	ctx := context.Background()
+
+	store := newTestStore(t)
+
+	// Issues from another project, remapped into this one on import
+	issues := []*types.Issue{
+		{ID: "other-abc1", Title: "Parent", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeEpic},
+		{ID: "other-abc1.1", Title: "Child", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask},
+	}
+
+	result, err := ImportIssues(ctx, store.Path(), store, issues, Options{RenameOnImport: true})
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if result.IDMapping["other-abc1"] != "test-abc1" {
+		t.Errorf("Expected IDMapping other-abc1 -> test-abc1, got %v", result.IDMapping)
+	}
+
+	for oldID, wantID := range map[string]string{"other-abc1": "test-abc1", "other-abc1.1": "test-abc1.1"} {
+		gotID, err := store.ResolveAlias(ctx, oldID)
+		if err != nil {
+			t.Fatalf("ResolveAlias(%s) failed: %v", oldID, err)
+		}
+		if gotID != wantID {
+			t.Errorf("Expected %s to resolve to %s, got %s", oldID, wantID, gotID)
+		}
+	}
+}
+
+func TestImportIssues_StrictDanglingDependencyForeignKeyError(t *testing.T) {
+	ctx := context.Background()
+
+	store := newTestStore(t)
+	if err := store.SetForeignKeyEnforcement(true); err != nil {
+		t.Fatalf("SetForeignKeyEnforcement failed: %v", err)
+	}
+
+	issues := []*types.Issue{
+		{
+			ID:        "test-abc1",
+			Title:     "Depends on a ghost",
+			Status:    types.StatusOpen,
+			Priority:  1,
+			IssueType: types.TypeTask,
+			Dependencies: []*types.Dependency{
+				{IssueID: "test-abc1", DependsOnID: "test-ghost", Type: types.DepBlocks},
+			},
+		},
+	}
+
+	_, err := ImportIssues(ctx, store.Path(), store, issues, Options{Strict: true})
+	if err == nil {
+		t.Fatal("Expected strict import to fail on dangling dependency")
+	}
+	var fkErr *ForeignKeyError
+	if !errors.As(err, &fkErr) {
+		t.Fatalf("Expected ForeignKeyError, got %T: %v", err, err)
+	}
+	if fkErr.IssueID != "test-abc1" || fkErr.MissingID != "test-ghost" || fkErr.DepType != types.DepBlocks {
+		t.Errorf("Unexpected ForeignKeyError fields: %+v", fkErr)
+	}
+	if !errors.Is(err, ErrForeignKey) {
+		t.Error("Expected errors.Is(err, ErrForeignKey)")
+	}
+
+	// Strict import is transactional: the issue must not have been created
+	if got, _ := store.GetIssue(ctx, "test-abc1"); got != nil {
+		t.Error("Expected strict import to be rolled back")
+	}
+}
+
+func TestImportIssues_FieldProvenance(t *testing.T) {
+	ctx := context.Background()
+
+	store := newTestStore(t)
+
+	base := time.Now().Add(-time.Hour).Truncate(time.Second)
+	fromA := &types.Issue{
+		ID:          "test-abc1",
+		Title:       "Title from A",
+		Description: "Description from A",
+		Status:      types.StatusOpen,
+		Priority:    2,
+		IssueType:   types.TypeTask,
+		CreatedAt:   base,
+		UpdatedAt:   base,
+	}
+	if _, err := ImportIssues(ctx, store.Path(), store, []*types.Issue{fromA}, Options{ProvenanceSource: "source-a"}); err != nil {
+		t.Fatalf("Import from source-a failed: %v", err)
+	}
+
+	// Source B changes only the title (and is newer)
+	fromB := &types.Issue{
+		ID:          "test-abc1",
+		Title:       "Title from B",
+		Description: "Description from A",
+		Status:      types.StatusOpen,
+		Priority:    2,
+		IssueType:   types.TypeTask,
+		CreatedAt:   base,
+		UpdatedAt:   base.Add(30 * time.Minute),
+	}
+	if _, err := ImportIssues(ctx, store.Path(), store, []*types.Issue{fromB}, Options{ProvenanceSource: "source-b"}); err != nil {
+		t.Fatalf("Import from source-b failed: %v", err)
+	}
+
+	prov, err := store.GetFieldProvenance(ctx, "test-abc1")
+	if err != nil {
+		t.Fatalf("GetFieldProvenance failed: %v", err)
+	}
+	if got := prov["title"]; got.Source != "source-b" || !got.UpdatedAt.Equal(fromB.UpdatedAt) {
+		t.Errorf("Expected title provenance source-b at %v, got %+v", fromB.UpdatedAt, got)
+	}
+	for _, field := range []string{"description", "priority", "status"} {
+		if got := prov[field]; got.Source != "source-a" {
+			t.Errorf("Expected %s provenance source-a, got %+v", field, got)
+		}
+	}
+
+	// Provenance is opt-in: an untracked import records nothing new
+	fromC := *fromB
+	fromC.Description = "Description from C"
+	fromC.UpdatedAt = base.Add(45 * time.Minute)
+	if _, err := ImportIssues(ctx, store.Path(), store, []*types.Issue{&fromC}, Options{}); err != nil {
+		t.Fatalf("Untracked import failed: %v", err)
+	}
+	prov, err = store.GetFieldProvenance(ctx, "test-abc1")
+	if err != nil {
+		t.Fatalf("GetFieldProvenance failed: %v", err)
+	}
+	if got := prov["description"]; got.Source != "source-a" {
+		t.Errorf("Expected untracked import to leave provenance alone, got %+v", got)
+	}
+}
+
+func TestImportIssues_ExportedSubtreeRoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	src := newTestStore(t)
+
+	create := func(id string) *types.Issue {
+		issue := &types.Issue{ID: id, Title: "Issue " + id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := src.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue(%s) failed: %v", id, err)
+		}
+		return issue
+	}
+	root := create("test-root")
+	create("test-root.1")
+	linked := create("test-linked")
+	other := create("test-other")
+	for _, dep := range []*types.Dependency{
+		{IssueID: linked.ID, DependsOnID: root.ID, Type: types.DepParentChild},
+		{IssueID: "test-root.1", DependsOnID: linked.ID, Type: types.DepBlocks},
+		{IssueID: linked.ID, DependsOnID: other.ID, Type: types.DepBlocks},
+	} {
+		if err := src.AddDependency(ctx, dep, "test-user"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+	if err := src.AddLabel(ctx, linked.ID, "feature-x", "test-user"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+
+	var buf strings.Builder
+	if err := src.ExportSubtree(ctx, root.ID, &buf, sqlite.ExportSubtreeOptions{IncludeDependencies: true}); err != nil {
+		t.Fatalf("ExportSubtree failed: %v", err)
+	}
+	var issues []*types.Issue
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var issue types.Issue
+		if err := json.Unmarshal([]byte(line), &issue); err != nil {
+			t.Fatalf("invalid export line %q: %v", line, err)
+		}
+		issues = append(issues, &issue)
+	}
+
+	dst := newTestStore(t)
+	result, err := ImportIssues(ctx, dst.Path(), dst, issues, Options{Strict: true, OrphanHandling: OrphanStrict})
+	if err != nil {
+		t.Fatalf("Import of exported subtree failed: %v", err)
+	}
+	if result.Created != 3 || len(result.SkippedDependencies) != 0 {
+		t.Errorf("expected 3 created and no skipped deps, got created=%d skipped=%v", result.Created, result.SkippedDependencies)
+	}
+	if issue, _ := dst.GetIssue(ctx, other.ID); issue != nil {
+		t.Error("issue outside the subtree should not be imported")
+	}
+	deps, err := dst.GetDependencyRecords(ctx, "test-root.1")
+	if err != nil {
+		t.Fatalf("GetDependencyRecords failed: %v", err)
+	}
+	if len(deps) != 1 || deps[0].DependsOnID != linked.ID {
+		t.Errorf("expected blocks dependency to survive round trip, got %v", deps)
+	}
+	labels, err := dst.GetLabels(ctx, linked.ID)
+	if err != nil {
+		t.Fatalf("GetLabels failed: %v", err)
+	}
+	if len(labels) != 1 || labels[0] != "feature-x" {
+		t.Errorf("expected labels to survive round trip, got %v", labels)
+	}
+}
+
+func TestImportIssues_Watchers(t *testing.T) {
+	ctx := context.Background()
+
+	store := newTestStore(t)
+
+	now := time.Now()
+	issue := func(watchers ...string) *types.Issue {
+		return &types.Issue{
+			ID:        "test-w1",
+			Title:     "Watched issue",
+			Status:    types.StatusOpen,
+			Priority:  2,
+			IssueType: types.TypeTask,
+			Watchers:  watchers,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+	}
+
+	if _, err := ImportIssues(ctx, store.Path(), store, []*types.Issue{issue("bob", "alice", "bob")}, Options{Strict: true}); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	// Re-import with a new watcher merges into the existing set
+	if _, err := ImportIssues(ctx, store.Path(), store, []*types.Issue{issue("carol", "alice")}, Options{Strict: true}); err != nil {
+		t.Fatalf("Re-import failed: %v", err)
+	}
+
+	got, err := store.GetIssue(ctx, "test-w1")
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	want := []string{"alice", "bob", "carol"}
+	if strings.Join(got.Watchers, ",") != strings.Join(want, ",") {
+		t.Errorf("expected watchers %v, got %v", want, got.Watchers)
+	}
+}
+
+func TestImportIssues_ChecklistRoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	now := time.Now().Add(-time.Hour)
+	issue := &types.Issue{ID: "test-c1", Title: "Checklist", Status: types.StatusOpen, Priority: 2,
+		IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now,
+		Checklist: []*types.ChecklistItem{
+			{Position: 3, Text: "ship"},
+			{Position: 1, Text: "design", Done: true},
+			{Position: 2, Text: "build"},
+		}}
+	source := newTestStore(t)
+	if _, err := ImportIssues(ctx, "", source, []*types.Issue{issue}, Options{Strict: true}); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+
+	var buf strings.Builder
+	if err := source.StreamExport(ctx, &buf, types.IssueFilter{}); err != nil {
+		t.Fatalf("StreamExport failed: %v", err)
+	}
+	var exported types.Issue
+	if err := json.Unmarshal([]byte(strings.SplitN(buf.String(), "\n", 2)[0]), &exported); err != nil {
+		t.Fatalf("failed to parse export: %v", err)
+	}
+	target := newTestStore(t)
+	if _, err := ImportIssues(ctx, "", target, []*types.Issue{&exported}, Options{Strict: true}); err != nil {
+		t.Fatalf("Re-import failed: %v", err)
+	}
+
+	a, _ := source.GetIssue(ctx, "test-c1")
+	b, _ := target.GetIssue(ctx, "test-c1")
+	var texts []string
+	for _, item := range b.Checklist {
+		texts = append(texts, fmt.Sprintf("%d:%s:%v", item.Position, item.Text, item.Done))
+	}
+	if got, want := strings.Join(texts, ","), "1:design:true,2:build:false,3:ship:false"; got != want {
+		t.Errorf("checklist = %s, want %s", got, want)
+	}
+	if a.ContentHash != b.ContentHash || b.ContentHash != b.ComputeContentHash() {
+		t.Errorf("expected matching content hashes over the checklist, got %s and %s", a.ContentHash, b.ContentHash)
+	}
+	if !b.UpdatedAt.Equal(a.UpdatedAt) {
+		t.Errorf("expected updated_at to survive the round trip, got %v and %v", a.UpdatedAt, b.UpdatedAt)
+	}
+
+	// Re-importing the same export is a no-op; a toggled item is an update
+	result, err := ImportIssues(ctx, "", target, []*types.Issue{&exported}, Options{Strict: true})
+	if err != nil || result.Unchanged != 1 {
+		t.Fatalf("expected unchanged re-import, got %+v, %v", result, err)
+	}
+	exported.Checklist[2].Done = true
+	if _, err := ImportIssues(ctx, "", target, []*types.Issue{&exported}, Options{Strict: true}); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if b, _ = target.GetIssue(ctx, "test-c1"); !b.Checklist[2].Done || b.ContentHash != b.ComputeContentHash() {
+		t.Errorf("expected imported done state and fresh hash, got %+v", b.Checklist[2])
+	}
+}
+
+// failingChecklistStore keeps no checklists and fails every write.
+type failingChecklistStore struct{}
+
+func (failingChecklistStore) SetChecklist(context.Context, string, []*types.ChecklistItem, string) error {
+	return errors.New("disk full")
+}
+
+func (failingChecklistStore) GetChecklist(context.Context, string) ([]*types.ChecklistItem, error) {
+	return nil, nil
+}
+
+func TestImportChecklists_RecordsFailedWrites(t *testing.T) {
+	ctx := context.Background()
+	issues := []*types.Issue{{ID: "test-c1", Checklist: []*types.ChecklistItem{{Position: 1, Text: "ship"}}}}
+
+	result := &Result{}
+	if err := importChecklists(ctx, failingChecklistStore{}, issues, Options{}, result); err != nil {
+		t.Fatalf("expected a lenient import to carry on, got %v", err)
+	}
+	if len(result.SkippedChecklists) != 1 || result.SkippedChecklists[0] != "test-c1" {
+		t.Errorf("expected the failed checklist to be recorded, got %v", result.SkippedChecklists)
+	}
+	if err := importChecklists(ctx, failingChecklistStore{}, issues, Options{Strict: true}, &Result{}); err == nil {
+		t.Error("expected a strict import to fail on the checklist write")
+	}
+}
+
+func TestImportIssues_DisplayMetadataRoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	now := time.Now().Add(-time.Hour)
+	issue := &types.Issue{ID: "test-d1", Title: "Card", Status: types.StatusOpen, Priority: 2,
+		IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now, Color: "#1d76db", DisplayOrder: 7}
+	source := newTestStore(t)
+	if _, err := ImportIssues(ctx, "", source, []*types.Issue{issue}, Options{Strict: true}); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+
+	var buf strings.Builder
+	if err := source.StreamExport(ctx, &buf, types.IssueFilter{}); err != nil {
+		t.Fatalf("StreamExport failed: %v", err)
+	}
+	var exported types.Issue
+	if err := json.Unmarshal([]byte(strings.SplitN(buf.String(), "\n", 2)[0]), &exported); err != nil {
+		t.Fatalf("failed to parse export: %v", err)
+	}
+	target := newTestStore(t)
+	if _, err := ImportIssues(ctx, "", target, []*types.Issue{&exported}, Options{Strict: true}); err != nil {
+		t.Fatalf("Re-import failed: %v", err)
+	}
+	a, _ := source.GetIssue(ctx, "test-d1")
+	b, _ := target.GetIssue(ctx, "test-d1")
+	if b.Color != "#1d76db" || b.DisplayOrder != 7 {
+		t.Errorf("expected display fields to survive the round trip, got %q/%d", b.Color, b.DisplayOrder)
+	}
+	if a.ContentHash != b.ContentHash {
+		t.Errorf("expected matching content hashes, got %s and %s", a.ContentHash, b.ContentHash)
+	}
+
+	// A newer export that only moves the card updates it
+	exported.DisplayOrder = 2
+	exported.Color = ""
+	exported.UpdatedAt = now.Add(time.Minute)
+	result, err := ImportIssues(ctx, "", target, []*types.Issue{&exported}, Options{Strict: true})
+	if err != nil || result.Updated != 1 {
+		t.Fatalf("expected one update, got %+v, %v", result, err)
+	}
+	if b, _ = target.GetIssue(ctx, "test-d1"); b.DisplayOrder != 2 || b.Color != "" || b.ContentHash != b.ComputeContentHash() {
+		t.Errorf("expected moved card with a fresh hash, got %q/%d", b.Color, b.DisplayOrder)
+	}
+
+	// Display metadata is hashed only where the database asks for it
+	for _, hashed := range []bool{false, true} {
+		store := newTestStore(t)
+		if hashed {
+			if err := store.SetConfig(ctx, storage.HashDisplayFieldsConfigKey, "true"); err != nil {
+				t.Fatalf("SetConfig failed: %v", err)
+			}
+		}
+		card := *issue
+		card.ContentHash = ""
+		if _, err := ImportIssues(ctx, "", store, []*types.Issue{&card}, Options{Strict: true}); err != nil {
+			t.Fatalf("hashed=%v: import failed: %v", hashed, err)
+		}
+		got, _ := store.GetIssue(ctx, "test-d1")
+		if want := got.ComputeContentHashWith(types.ContentHashOptions{DisplayFields: hashed}); got.ContentHash != want {
+			t.Errorf("hashed=%v: content hash %s, want %s", hashed, got.ContentHash, want)
+		}
+		if includes := got.ContentHash != got.ComputeContentHash(); includes != hashed {
+			t.Errorf("hashed=%v: display metadata in the hash = %v", hashed, includes)
+		}
+	}
+}
+
+func TestImportIssues_RankRoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	// Ranks from other tools are kept verbatim, whatever their form
+	now := time.Now().Add(-time.Hour)
+	var issues []*types.Issue
+	for id, rank := range map[string]string{"test-r1": "0|hzzzzz:", "test-r2": "0|i0000f:", "test-r3": ""} {
+		issues = append(issues, &types.Issue{ID: id, Title: "Backlog item", Status: types.StatusOpen, Priority: 2,
+			IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now, Rank: rank})
+	}
+	source := newTestStore(t)
+	if _, err := ImportIssues(ctx, "", source, issues, Options{Strict: true}); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+
+	var buf strings.Builder
+	if err := source.StreamExport(ctx, &buf, types.IssueFilter{}); err != nil {
+		t.Fatalf("StreamExport failed: %v", err)
+	}
+	var exported []*types.Issue
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var issue types.Issue
+		if err := json.Unmarshal([]byte(line), &issue); err != nil {
+			t.Fatalf("failed to parse export: %v", err)
+		}
+		if issue.ID != "" { // not the summary line
+			exported = append(exported, &issue)
+		}
+	}
+	target := newTestStore(t)
+	if _, err := ImportIssues(ctx, "", target, exported, Options{Strict: true}); err != nil {
+		t.Fatalf("Re-import failed: %v", err)
+	}
+	backlog, err := target.ListIssuesByRank(ctx)
+	if err != nil {
+		t.Fatalf("ListIssuesByRank failed: %v", err)
+	}
+	if len(backlog) != 2 || backlog[0].ID != "test-r1" || backlog[0].Rank != "0|hzzzzz:" || backlog[1].Rank != "0|i0000f:" {
+		t.Fatalf("expected ranks to survive the round trip in order, got %+v", backlog)
+	}
+	for _, issue := range backlog {
+		orig, _ := source.GetIssue(ctx, issue.ID)
+		if orig.ContentHash != issue.ContentHash {
+			t.Errorf("%s: expected matching content hashes, got %s and %s", issue.ID, orig.ContentHash, issue.ContentHash)
+		}
+	}
+
+	// A newer export that only re-ranks an issue updates it
+	reranked := *exported[0]
+	reranked.Rank = "1|a:"
+	reranked.UpdatedAt = now.Add(time.Minute)
+	result, err := ImportIssues(ctx, "", target, []*types.Issue{&reranked}, Options{Strict: true})
+	if err != nil || result.Updated != 1 {
+		t.Fatalf("expected one update, got %+v, %v", result, err)
+	}
+	if b, _ := target.GetIssue(ctx, reranked.ID); b.Rank != "1|a:" || b.ContentHash != b.ComputeContentHash() {
+		t.Errorf("expected re-ranked issue with a fresh hash, got %q", b.Rank)
+	}
+}
+
+func TestImportIssues_DeduplicatesLargeDescriptions(t *testing.T) {
+	ctx := context.Background()
+
+	store := newTestStore(t)
+	if err := store.SetConfig(ctx, sqlite.DescriptionBlobThresholdConfigKey, "100"); err != nil {
+		t.Fatalf("Failed to enable description blobs: %v", err)
+	}
+
+	now := time.Now()
+	body := strings.Repeat("Shared template text. ", 20)
+	var issues []*types.Issue
+	for _, id := range []string{"test-t1", "test-t2", "test-t3"} {
+		issues = append(issues, &types.Issue{
+			ID:          id,
+			Title:       "Templated " + id,
+			Description: body,
+			Status:      types.StatusOpen,
+			Priority:    2,
+			IssueType:   types.TypeTask,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		})
+	}
+	if _, err := ImportIssues(ctx, store.Path(), store, issues, Options{Strict: true}); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+
+	var blobs int
+	if err := store.UnderlyingDB().QueryRowContext(ctx, `SELECT COUNT(*) FROM description_blobs`).Scan(&blobs); err != nil {
+		t.Fatalf("Failed to count blobs: %v", err)
+	}
+	if blobs != 1 {
+		t.Errorf("expected identical bodies to share 1 blob, got %d", blobs)
+	}
+	for _, want := range issues {
+		got, err := store.GetIssue(ctx, want.ID)
+		if err != nil || got == nil {
+			t.Fatalf("GetIssue(%s) failed: %v", want.ID, err)
+		}
+		if got.Description != body {
+			t.Errorf("expected %s description to round-trip", want.ID)
+		}
+	}
+}
+
+func TestImportIssues_GeneratedExternalRef(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	if err := store.SetConfig(ctx, sqlite.ExternalRefGeneratorConfigKey, "uuid"); err != nil {
+		t.Fatalf("Failed to set generator: %v", err)
+	}
+
+	now := time.Now()
+	ref := "gh-42"
+	issues := []*types.Issue{
+		{ID: "test-a", Title: "Without ref", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now},
+		{ID: "test-b", Title: "With ref", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now, ExternalRef: &ref},
+	}
+	if _, err := ImportIssues(ctx, "", store, issues, Options{Verify: VerifyFull}); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+
+	generated, _ := store.GetIssue(ctx, "test-a")
+	if generated == nil || generated.ExternalRef == nil || len(*generated.ExternalRef) != 36 {
+		t.Fatalf("expected a generated external_ref on test-a, got %+v", generated)
+	}
+	if generated.ContentHash != generated.ComputeContentHash() {
+		t.Error("expected test-a content_hash to include the generated external_ref")
+	}
+	if kept, _ := store.GetIssue(ctx, "test-b"); kept == nil || kept.ExternalRef == nil || *kept.ExternalRef != ref {
+		t.Errorf("expected incoming external_ref %s preserved, got %+v", ref, kept)
+	}
+}
+
+func TestImportIssues_NormalizeText(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	created := time.Now().Add(-time.Hour)
+	issue := func(description string, updated time.Time) *types.Issue {
+		return &types.Issue{ID: "test-1", Title: "Line endings", Description: description,
+			Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: created, UpdatedAt: updated}
+	}
+	if _, err := ImportIssues(ctx, "", store, []*types.Issue{issue("first\nsecond", created)}, Options{}); err != nil {
+		t.Fatalf("initial import failed: %v", err)
+	}
+
+	opts := Options{NormalizeNewlines: true, TrimTrailingWhitespace: true}
+	result, err := ImportIssues(ctx, "", store, []*types.Issue{issue("first  \r\nsecond\r\n", time.Now())}, opts)
+	if err != nil {
+		t.Fatalf("normalized import failed: %v", err)
+	}
+	if result.Unchanged != 1 || result.Updated != 0 {
+		t.Errorf("expected CRLF description to dedup against LF, got %+v", result)
+	}
+
+	result, err = ImportIssues(ctx, "", store, []*types.Issue{issue("first\r\nsecond", time.Now())}, Options{})
+	if err != nil {
+		t.Fatalf("raw import failed: %v", err)
+	}
+	if result.Updated != 1 {
+		t.Errorf("expected CRLF to count as a change without normalization, got %+v", result)
+	}
+}
+
+func TestNormalizeIssueText(t *testing.T) {
+	issues := []*types.Issue{{Title: "Title \t", Description: "a\r\nb \r\nc\rd\n\n",
+		Comments: []*types.Comment{{Text: "note\r\n"}}}}
+	normalizeIssueText(issues, true, false)
+	if got := issues[0].Description; got != "a\nb \nc\nd\n\n" {
+		t.Errorf("newlines only: got %q", got)
+	}
+	if got := issues[0].Title; got != "Title \t" {
+		t.Errorf("expected whitespace kept without trim, got %q", got)
+	}
+
+	normalizeIssueText(issues, false, true)
+	if got := issues[0].Description; got != "a\nb\nc\nd" {
+		t.Errorf("trim: got %q", got)
+	}
+	if got := issues[0].Title; got != "Title" {
+		t.Errorf("trim: got title %q", got)
+	}
+	if got := issues[0].Comments[0].Text; got != "note" {
+		t.Errorf("expected comment text normalized, got %q", got)
+	}
+}
+
+func TestImportIssues_PreserveRowIDs(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	newIssue := func(id string, rowID int64) *types.Issue {
+		return &types.Issue{ID: id, RowID: rowID, Title: "Issue " + id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	}
+	rowIDOf := func(id string) int64 {
+		t.Helper()
+		var rowID int64
+		if err := store.UnderlyingDB().QueryRowContext(ctx, `SELECT rowid FROM issues WHERE id = ?`, id).Scan(&rowID); err != nil {
+			t.Fatalf("failed to read rowid of %s: %v", id, err)
+		}
+		return rowID
+	}
+
+	if _, err := ImportIssues(ctx, "", store, []*types.Issue{newIssue("test-1", 100), newIssue("test-2", 205)}, Options{PreserveRowIDs: true}); err != nil {
+		t.Fatalf("ImportIssues failed: %v", err)
+	}
+	for id, want := range map[string]int64{"test-1": 100, "test-2": 205} {
+		if got := rowIDOf(id); got != want {
+			t.Errorf("%s: expected rowid %d preserved, got %d", id, want, got)
+		}
+	}
+
+	var buf strings.Builder
+	if err := store.StreamExport(ctx, &buf, types.IssueFilter{}); err != nil {
+		t.Fatalf("StreamExport failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"rowid":205`) {
+		t.Errorf("expected export to carry rowids, got %s", buf.String())
+	}
+
+	_, err := ImportIssues(ctx, "", store, []*types.Issue{newIssue("test-3", 100)}, Options{PreserveRowIDs: true})
+	if !errors.Is(err, sqlite.ErrConflict) {
+		t.Fatalf("expected ErrConflict for a taken rowid, got %v", err)
+	}
+	if issue, _ := store.GetIssue(ctx, "test-3"); issue != nil {
+		t.Error("expected colliding issue not to be created")
+	}
+
+	if _, err := ImportIssues(ctx, "", store, []*types.Issue{newIssue("test-4", 100)}, Options{}); err != nil {
+		t.Fatalf("ImportIssues without PreserveRowIDs failed: %v", err)
+	}
+	if got := rowIDOf("test-4"); got == 100 {
+		t.Error("expected rowid auto-assigned without PreserveRowIDs")
+	}
+}
+
+func TestImportIssues_DueAtRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	dueAt := func(store *sqlite.SQLiteStorage) *time.Time {
+		t.Helper()
+		issue, err := store.GetIssue(ctx, "test-1")
+		if err != nil || issue == nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		return issue.DueAt
+	}
+
+	due := time.Date(2026, 3, 1, 17, 0, 0, 0, time.UTC)
+	created := time.Now().Add(-time.Hour)
+	source := newTestStore(t)
+	issue := &types.Issue{ID: "test-1", Title: "Deadline", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask,
+		DueAt: &due, CreatedAt: created, UpdatedAt: created}
+	if _, err := ImportIssues(ctx, "", source, []*types.Issue{issue}, Options{}); err != nil {
+		t.Fatalf("ImportIssues failed: %v", err)
+	}
+
+	var buf strings.Builder
+	if err := source.StreamExport(ctx, &buf, types.IssueFilter{}); err != nil {
+		t.Fatalf("StreamExport failed: %v", err)
+	}
+	var exported types.Issue
+	if err := json.Unmarshal([]byte(strings.SplitN(buf.String(), "\n", 2)[0]), &exported); err != nil {
+		t.Fatalf("failed to parse export: %v", err)
+	}
+	target := newTestStore(t)
+	if _, err := ImportIssues(ctx, "", target, []*types.Issue{&exported}, Options{}); err != nil {
+		t.Fatalf("re-import failed: %v", err)
+	}
+	if got := dueAt(target); got == nil || !got.Equal(due) {
+		t.Errorf("expected due date %v to survive export and import, got %v", due, got)
+	}
+
+	// A due-date-only change is an update, including clearing it.
+	moved := due.Add(48 * time.Hour)
+	for _, next := range []*time.Time{&moved, nil} {
+		update := *issue
+		update.DueAt = next
+		update.UpdatedAt = time.Now()
+		result, err := ImportIssues(ctx, "", target, []*types.Issue{&update}, Options{})
+		if err != nil {
+			t.Fatalf("ImportIssues failed: %v", err)
+		}
+		if result.Updated != 1 {
+			t.Errorf("expected due date change %v to update, got %+v", next, result)
+		}
+		if got := dueAt(target); (got == nil) != (next == nil) || (got != nil && !got.Equal(*next)) {
+			t.Errorf("expected due date %v, got %v", next, got)
+		}
+	}
+}
+
+func TestImportIssues_BypassTypeStatuses(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	if err := store.SetConfig(ctx, sqlite.TypeStatusesConfigKey, `{"epic": ["open"]}`); err != nil {
+		t.Fatalf("Failed to set type statuses: %v", err)
+	}
+
+	now := time.Now().Truncate(time.Second)
+	issues := func() []*types.Issue {
+		return []*types.Issue{{ID: "test-1", Title: "Blocked epic", Status: types.StatusBlocked, Priority: 2,
+			IssueType: types.TypeEpic, CreatedAt: now, UpdatedAt: now}}
+	}
+	if _, err := ImportIssues(ctx, "", store, issues(), Options{}); err == nil || !strings.Contains(err.Error(), "not allowed for issue type epic") {
+		t.Fatalf("expected type-status error, got %v", err)
+	}
+	if _, err := ImportIssues(ctx, "", store, issues(), Options{BypassTypeStatuses: true}); err != nil {
+		t.Fatalf("expected bypassed import to succeed: %v", err)
+	}
+	if got, err := store.GetIssue(ctx, "test-1"); err != nil || got == nil || got.Status != types.StatusBlocked {
+		t.Errorf("GetIssue(test-1) = %+v, %v; want the blocked epic", got, err)
+	}
+}
+
+func TestImportIssues_BypassParentTypes(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	if err := store.SetConfig(ctx, sqlite.ParentTypesConfigKey, `{"epic": ["task"]}`); err != nil {
+		t.Fatalf("Failed to set parent types: %v", err)
+	}
+
+	now := time.Now().Truncate(time.Second)
+	issues := func() []*types.Issue {
+		return []*types.Issue{
+			{ID: "test-1", Title: "Epic", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeEpic, CreatedAt: now, UpdatedAt: now},
+			{ID: "test-2", Title: "Bug", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeBug, CreatedAt: now, UpdatedAt: now,
+				Dependencies: []*types.Dependency{{IssueID: "test-2", DependsOnID: "test-1", Type: types.DepParentChild}}},
+		}
+	}
+	if _, err := ImportIssues(ctx, "", store, issues(), Options{Strict: true}); err == nil || !strings.Contains(err.Error(), "issue type bug cannot be a child of issue type epic") {
+		t.Fatalf("expected parent type error, got %v", err)
+	}
+	if _, err := ImportIssues(ctx, "", store, issues(), Options{Strict: true, BypassParentTypes: true}); err != nil {
+		t.Fatalf("expected bypassed import to succeed: %v", err)
+	}
+	deps, err := store.GetDependencyRecords(ctx, "test-2")
+	if err != nil || len(deps) != 1 || deps[0].DependsOnID != "test-1" {
+		t.Errorf("GetDependencyRecords(test-2) = %+v, %v; want the parent-child dependency", deps, err)
+	}
+}
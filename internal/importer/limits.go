@@ -0,0 +1,33 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrTooManyIssues is matched (via errors.Is) by every TooManyIssuesError.
+var ErrTooManyIssues = errors.New("too many issues")
+
+// TooManyIssuesError reports an import aborted because it holds more issues
+// than Options.MaxIssues (or SnapshotOptions.MaxIssues) allows. Decoders stop
+// at the first issue over the limit, so Count is then Limit+1 rather than the
+// size of the whole input.
+type TooManyIssuesError struct {
+	Limit int
+	Count int // Issues seen when the import was aborted
+}
+
+func (e *TooManyIssuesError) Error() string {
+	return fmt.Sprintf("%s: import holds at least %d issues, limit is %d", ErrTooManyIssues, e.Count, e.Limit)
+}
+
+func (e *TooManyIssuesError) Unwrap() error { return ErrTooManyIssues }
+
+// checkMaxIssues returns a TooManyIssuesError when count exceeds limit. A
+// limit of 0 or less means unlimited.
+func checkMaxIssues(count, limit int) error {
+	if limit > 0 && count > limit {
+		return &TooManyIssuesError{Limit: limit, Count: count}
+	}
+	return nil
+}
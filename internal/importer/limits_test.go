@@ -0,0 +1,65 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// endlessJSONL yields issue lines forever, like a runaway export.
+type endlessJSONL struct {
+	n   int
+	buf []byte
+}
+
+func (r *endlessJSONL) Read(p []byte) (int, error) {
+	if len(r.buf) == 0 {
+		r.n++
+		r.buf = []byte(fmt.Sprintf(`{"id":"test-%d","title":"Issue %d","status":"open","priority":2,"issue_type":"task"}`+"\n", r.n, r.n))
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func TestParseSnapshot_MaxIssues(t *testing.T) {
+	_, _, err := ParseSnapshot(&endlessJSONL{}, SnapshotOptions{AllowLegacy: true, MaxIssues: 10})
+	var tooMany *TooManyIssuesError
+	if !errors.As(err, &tooMany) || !errors.Is(err, ErrTooManyIssues) {
+		t.Fatalf("expected TooManyIssuesError, got %v", err)
+	}
+	if tooMany.Limit != 10 || tooMany.Count != 11 {
+		t.Errorf("expected abort at issue 11 of limit 10, got %+v", tooMany)
+	}
+
+	// A header promising too many issues fails before any record is read
+	header := `{"_header":true,"schema_version":1,"issue_count":50}` + "\n"
+	if _, _, err := ParseSnapshot(strings.NewReader(header), SnapshotOptions{MaxIssues: 10}); !errors.Is(err, ErrTooManyIssues) {
+		t.Errorf("expected header count to be checked, got %v", err)
+	}
+}
+
+func TestImportIssues_MaxIssues(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	now := time.Now()
+	var issues []*types.Issue
+	for i := 1; i <= 3; i++ {
+		issues = append(issues, &types.Issue{ID: fmt.Sprintf("test-%d", i), Title: "Issue", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now})
+	}
+	if _, err := ImportIssues(ctx, "", store, issues, Options{MaxIssues: 2}); !errors.Is(err, ErrTooManyIssues) {
+		t.Fatalf("expected ErrTooManyIssues, got %v", err)
+	}
+	if got, _ := store.GetIssue(ctx, "test-1"); got != nil {
+		t.Error("expected nothing to be imported")
+	}
+	if _, err := ImportIssues(ctx, "", store, issues, Options{MaxIssues: 3}); err != nil {
+		t.Fatalf("expected import at the limit to succeed: %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// ParseMilestones reads a milestone export (types.MilestoneRecord lines, see
+// sqlite.ExportMilestones) and returns the milestones, ready for ImportIssues
+// with Options.Milestones. Lines that are not milestone records are skipped,
+// so milestones can share a file with issues. maxLineSize bounds a single
+// line (0 uses the default).
+func ParseMilestones(r io.Reader, maxLineSize int) ([]*types.Milestone, error) {
+	var milestones []*types.Milestone
+
+	scanner := utils.NewJSONLScanner(r, maxLineSize)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var record types.MilestoneRecord
+		if err := json.Unmarshal(line, &record); err != nil {
+			return nil, fmt.Errorf("line %d: %w", scanner.Line(), err)
+		}
+		if record.Milestone == nil {
+			continue
+		}
+		if err := record.Milestone.Validate(); err != nil {
+			return nil, fmt.Errorf("line %d: %w", scanner.Line(), err)
+		}
+		milestones = append(milestones, record.Milestone)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return milestones, nil
+}
+
+// applyMilestoneRefs validates opts.Milestones and applies opts.OrphanHandling
+// to issues whose MilestoneID names a milestone that neither exists in store
+// nor is being imported: OrphanStrict fails with a ForeignKeyError, OrphanSkip
+// drops the issue, OrphanResurrect adds a placeholder milestone (named after
+// its ID, without dates) to the returned opts, and OrphanAllow keeps the
+// reference as it is.
+func applyMilestoneRefs(ctx context.Context, store interface{}, issues []*types.Issue, opts Options, result *Result) ([]*types.Issue, Options, error) {
+	known := make(map[string]bool, len(opts.Milestones))
+	for _, m := range opts.Milestones {
+		if err := m.Validate(); err != nil {
+			return nil, opts, err
+		}
+		known[m.ID] = true
+	}
+	milestoneStore, _ := store.(storage.MilestoneStore)
+	exists := func(id string) (bool, error) {
+		if known[id] {
+			return true, nil
+		}
+		if milestoneStore == nil {
+			return false, nil
+		}
+		m, err := milestoneStore.GetMilestone(ctx, id)
+		if err != nil {
+			return false, err
+		}
+		known[id] = m != nil
+		return known[id], nil
+	}
+
+	kept := issues[:0:0]
+	for _, issue := range issues {
+		if issue.MilestoneID == "" {
+			kept = append(kept, issue)
+			continue
+		}
+		ok, err := exists(issue.MilestoneID)
+		if err != nil {
+			return nil, opts, fmt.Errorf("failed to look up milestone %s: %w", issue.MilestoneID, err)
+		}
+		if ok {
+			kept = append(kept, issue)
+			continue
+		}
+		switch opts.OrphanHandling {
+		case OrphanStrict:
+			return nil, opts, &ForeignKeyError{IssueID: issue.ID, Field: "milestone_id", MissingID: issue.MilestoneID}
+		case OrphanSkip:
+			fmt.Fprintf(os.Stderr, "Warning: Skipping %s, which references missing milestone %s\n", issue.ID, issue.MilestoneID)
+			result.note(ImportEventSkipped, issue.ID)
+			continue
+		case OrphanResurrect:
+			opts.Milestones = append(opts.Milestones[:len(opts.Milestones):len(opts.Milestones)], &types.Milestone{ID: issue.MilestoneID, Name: issue.MilestoneID})
+			known[issue.MilestoneID] = true
+		}
+		kept = append(kept, issue)
+	}
+	return kept, opts, nil
+}
+
+// importMilestones stores opts.Milestones through the storage.MilestoneStore
+// capability of store, replacing milestones with the same IDs. Backends
+// without milestones fail the import rather than dropping them.
+func importMilestones(ctx context.Context, store interface{}, opts Options, result *Result) error {
+	if len(opts.Milestones) == 0 || opts.DryRun {
+		return nil
+	}
+	milestoneStore, ok := store.(storage.MilestoneStore)
+	if !ok {
+		return fmt.Errorf("storage backend does not support milestones")
+	}
+	for _, m := range opts.Milestones {
+		if err := milestoneStore.SaveMilestone(ctx, m); err != nil {
+			return err
+		}
+		result.Milestones++
+	}
+	return nil
+}
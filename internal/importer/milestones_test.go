@@ -0,0 +1,108 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestImportIssues_Milestones(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+	newIssue := func(id, milestoneID string) *types.Issue {
+		return &types.Issue{ID: id, Title: "Issue " + id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask,
+			MilestoneID: milestoneID, CreatedAt: now, UpdatedAt: now}
+	}
+
+	source := newTestStore(t)
+	end := now.AddDate(0, 0, 14)
+	if err := source.SaveMilestone(ctx, &types.Milestone{ID: "sprint-1", Name: "Sprint 1", StartAt: &now, EndAt: &end}); err != nil {
+		t.Fatalf("SaveMilestone failed: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := source.ExportMilestones(ctx, &buf); err != nil {
+		t.Fatalf("ExportMilestones failed: %v", err)
+	}
+	milestones, err := ParseMilestones(strings.NewReader(`{"id":"test-9","title":"Not a milestone"}`+"\n"+buf.String()), 0)
+	if err != nil {
+		t.Fatalf("ParseMilestones failed: %v", err)
+	}
+	if len(milestones) != 1 || milestones[0].ID != "sprint-1" {
+		t.Fatalf("ParseMilestones = %+v, want sprint-1", milestones)
+	}
+
+	t.Run("milestones import with their issues", func(t *testing.T) {
+		target := newTestStore(t)
+		result, err := ImportIssues(ctx, "", target, []*types.Issue{newIssue("test-1", "sprint-1"), newIssue("test-2", "sprint-1"), newIssue("test-3", "")},
+			Options{Milestones: milestones, OrphanHandling: OrphanStrict})
+		if err != nil {
+			t.Fatalf("ImportIssues failed: %v", err)
+		}
+		if result.Milestones != 1 || result.Created != 3 {
+			t.Errorf("milestones %d, created %d; want 1 and 3", result.Milestones, result.Created)
+		}
+		got, err := target.GetMilestone(ctx, "sprint-1")
+		if err != nil || got == nil || got.Name != "Sprint 1" || got.EndAt == nil || !got.EndAt.Equal(end) {
+			t.Errorf("GetMilestone = %+v, %v", got, err)
+		}
+		issues, err := target.ListIssuesByMilestone(ctx, "sprint-1")
+		if err != nil {
+			t.Fatalf("ListIssuesByMilestone failed: %v", err)
+		}
+		if len(issues) != 2 || issues[0].ID != "test-1" || issues[1].ID != "test-2" {
+			t.Errorf("ListIssuesByMilestone = %v, want test-1 and test-2", issues)
+		}
+
+		// Moving an issue to a stored milestone needs no milestone in the import
+		moved := newIssue("test-3", "sprint-1")
+		moved.UpdatedAt = now.Add(time.Minute)
+		if _, err := ImportIssues(ctx, "", target, []*types.Issue{moved}, Options{OrphanHandling: OrphanStrict}); err != nil {
+			t.Fatalf("re-import failed: %v", err)
+		}
+		if issues, _ := target.ListIssuesByMilestone(ctx, "sprint-1"); len(issues) != 3 {
+			t.Errorf("after the move, sprint-1 has %d issues, want 3", len(issues))
+		}
+	})
+
+	t.Run("missing milestones follow orphan handling", func(t *testing.T) {
+		incoming := func() []*types.Issue { return []*types.Issue{newIssue("test-1", "sprint-9"), newIssue("test-2", "")} }
+
+		_, err := ImportIssues(ctx, "", newTestStore(t), incoming(), Options{OrphanHandling: OrphanStrict})
+		var fkErr *ForeignKeyError
+		if !errors.As(err, &fkErr) || fkErr.Field != "milestone_id" || fkErr.MissingID != "sprint-9" {
+			t.Errorf("strict: err = %v, want a ForeignKeyError on milestone_id", err)
+		}
+
+		store := newTestStore(t)
+		result, err := ImportIssues(ctx, "", store, incoming(), Options{OrphanHandling: OrphanSkip})
+		if err != nil || result.Created != 1 || result.Skipped != 1 {
+			t.Errorf("skip: result = %+v, %v; want test-1 skipped", result, err)
+		}
+
+		store = newTestStore(t)
+		result, err = ImportIssues(ctx, "", store, incoming(), Options{OrphanHandling: OrphanResurrect})
+		if err != nil || result.Created != 2 || result.Milestones != 1 {
+			t.Fatalf("resurrect: result = %+v, %v; want a placeholder milestone", result, err)
+		}
+		if got, err := store.GetMilestone(ctx, "sprint-9"); err != nil || got == nil || got.Name != "sprint-9" || got.StartAt != nil {
+			t.Errorf("placeholder = %+v, %v", got, err)
+		}
+
+		store = newTestStore(t)
+		if _, err := ImportIssues(ctx, "", store, incoming(), Options{OrphanHandling: OrphanAllow}); err != nil {
+			t.Fatalf("allow: %v", err)
+		}
+		if issues, err := store.ListIssuesByMilestone(ctx, "sprint-9"); err != nil || len(issues) != 1 {
+			t.Errorf("allow: ListIssuesByMilestone = %v, %v; want the dangling reference kept", issues, err)
+		}
+	})
+
+	if _, err := ParseMilestones(strings.NewReader(`{"_milestone":{"id":"x"}}`), 0); err == nil {
+		t.Error("expected a milestone without a name to be rejected")
+	}
+}
@@ -0,0 +1,94 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// numericIDFields are the ID-valued fields of an issue record, and of the
+// records nested in it, that ParseOptions.NumericIDs accepts as JSON numbers.
+var numericIDFields = map[string][]string{
+	"":             {"id", "milestone_id"},
+	"dependencies": {"issue_id", "depends_on_id"},
+	"comments":     {"issue_id"},
+	"checklist":    {"issue_id"},
+}
+
+// quoteNumericIDs rewrites the ID fields of an issue record that are JSON
+// numbers (as some exporters write numeric IDs) to strings holding their
+// exact decimal text, so they decode into the string ID fields. Numbers are
+// read as json.Number, never through float64, so large values keep every
+// digit; fractions and exponents cannot name an ID exactly and are rejected.
+// Records without numeric IDs are returned unchanged.
+func quoteNumericIDs(data []byte) ([]byte, error) {
+	var record map[string]json.RawMessage
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, err
+	}
+	changed, err := quoteNumericFields(record, numericIDFields[""])
+	if err != nil {
+		return nil, err
+	}
+	for field, names := range numericIDFields {
+		raw, ok := record[field]
+		if field == "" || !ok || bytes.Equal(raw, []byte("null")) {
+			continue
+		}
+		var items []map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return nil, fmt.Errorf("%s: %w", field, err)
+		}
+		itemsChanged := false
+		for _, item := range items {
+			c, err := quoteNumericFields(item, names)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", field, err)
+			}
+			itemsChanged = itemsChanged || c
+		}
+		if itemsChanged {
+			if record[field], err = json.Marshal(items); err != nil {
+				return nil, err
+			}
+			changed = true
+		}
+	}
+	if !changed {
+		return data, nil
+	}
+	return json.Marshal(record)
+}
+
+// quoteNumericFields quotes the numeric values of names in record in place
+// and reports whether any was changed.
+func quoteNumericFields(record map[string]json.RawMessage, names []string) (bool, error) {
+	changed := false
+	for _, name := range names {
+		raw, ok := record[name]
+		if !ok {
+			continue
+		}
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.UseNumber()
+		var value interface{}
+		if err := dec.Decode(&value); err != nil {
+			return false, fmt.Errorf("%s: %w", name, err)
+		}
+		n, ok := value.(json.Number)
+		if !ok {
+			continue
+		}
+		if strings.ContainsAny(n.String(), ".eE") {
+			return false, fmt.Errorf("%s: numeric ID %s is not a whole number and cannot be kept exactly", name, n)
+		}
+		quoted, err := json.Marshal(n.String())
+		if err != nil {
+			return false, err
+		}
+		record[name] = quoted
+		changed = true
+	}
+	return changed, nil
+}
@@ -0,0 +1,53 @@
+package importer
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestParseIssues_NumericIDs(t *testing.T) {
+	// Both IDs have more digits than a float64 can hold
+	const (
+		bigID   = "98765432109876543210987"
+		otherID = "12345678901234567890123"
+	)
+	data := `{"id":` + otherID + `,"title":"Blocker","status":"open","priority":2,"issue_type":"task","created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-01T00:00:00Z"}
+{"id":` + bigID + `,"title":"Big","status":"open","priority":2,"issue_type":"task","created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-01T00:00:00Z","dependencies":[{"issue_id":` + bigID + `,"depends_on_id":` + otherID + `,"type":"blocks","created_at":"2025-01-01T00:00:00Z"}],"comments":[{"id":1,"issue_id":` + bigID + `,"author":"a","text":"hi","created_at":"2025-01-01T00:00:00Z"}]}
+`
+	if _, err := ParseIssues(strings.NewReader(data), ParseOptions{}); err == nil {
+		t.Fatal("expected numeric IDs to fail without NumericIDs")
+	}
+	issues, err := ParseIssues(strings.NewReader(data), ParseOptions{NumericIDs: true})
+	if err != nil {
+		t.Fatalf("ParseIssues failed: %v", err)
+	}
+	big := issues[1]
+	if big.ID != bigID || len(big.Dependencies) != 1 || big.Dependencies[0].IssueID != bigID || big.Dependencies[0].DependsOnID != otherID {
+		t.Fatalf("issue = %s with dependencies %+v, want the exact IDs", big.ID, big.Dependencies)
+	}
+	if len(big.Comments) != 1 || big.Comments[0].IssueID != bigID || big.Comments[0].ID != 1 {
+		t.Errorf("comments = %+v, want the exact issue ID", big.Comments)
+	}
+
+	ctx := context.Background()
+	store := newTestStore(t)
+	if _, err := ImportIssues(ctx, "", store, issues, Options{SkipPrefixValidation: true}); err != nil {
+		t.Fatalf("ImportIssues failed: %v", err)
+	}
+	got, err := store.GetIssue(ctx, bigID)
+	if err != nil || got == nil || got.Title != "Big" {
+		t.Fatalf("GetIssue(%s) = %+v, %v", bigID, got, err)
+	}
+	deps, err := store.GetDependencyRecords(ctx, bigID)
+	if err != nil || len(deps) != 1 || deps[0].DependsOnID != otherID {
+		t.Errorf("dependencies = %+v, %v; want one on %s", deps, err, otherID)
+	}
+
+	for _, bad := range []string{`1.5`, `1e30`} {
+		line := `{"id":` + bad + `,"title":"Lossy","status":"open","priority":2,"issue_type":"task"}`
+		if _, err := ParseIssues(strings.NewReader(line), ParseOptions{NumericIDs: true}); err == nil || !strings.Contains(err.Error(), "whole number") {
+			t.Errorf("id %s: err = %v, want it rejected", bad, err)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package importer
+
+import (
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ConflictAction is how a ConflictResolver settles one conflict.
+type ConflictAction string
+
+const (
+	ConflictKeepLocal    ConflictAction = "keep-local"    // Leave the existing issue as it is
+	ConflictTakeIncoming ConflictAction = "take-incoming" // Overwrite the existing issue with the incoming one
+	ConflictMergeFields  ConflictAction = "merge-fields"  // Take Resolution.Fields from the incoming issue and keep the rest
+)
+
+// Resolution is a ConflictResolver's answer for one conflicting issue.
+type Resolution struct {
+	Action ConflictAction
+	Fields []string // With ConflictMergeFields, the columns taken from the incoming issue (same names as Options.UpdateFields)
+}
+
+// ConflictResolver decides a conflict between an existing issue and an
+// incoming one with the same ID but a different content hash (see
+// Options.OnConflict). Neither issue may be modified.
+type ConflictResolver func(existing, incoming *types.Issue) Resolution
+
+// resolveConflict asks opts.OnConflict how to settle the conflict between
+// existing and incoming and narrows updates, built from incoming, to what
+// the resolution writes. Keeping the local issue writes nothing.
+func resolveConflict(existing, incoming *types.Issue, updates map[string]interface{}, opts Options) (map[string]interface{}, error) {
+	resolution := opts.OnConflict(existing, incoming)
+	switch resolution.Action {
+	case ConflictKeepLocal:
+		return nil, nil
+	case ConflictTakeIncoming:
+		return updates, nil
+	case ConflictMergeFields:
+		if err := validateUpdateFields(resolution.Fields); err != nil {
+			return nil, fmt.Errorf("conflict resolution for %s: %w", incoming.ID, err)
+		}
+		if len(resolution.Fields) == 0 {
+			return nil, nil
+		}
+		return projectUpdates(updates, incoming, resolution.Fields), nil
+	default:
+		return nil, fmt.Errorf("conflict resolution for %s: unknown action %q (want keep-local, take-incoming or merge-fields)", incoming.ID, resolution.Action)
+	}
+}
@@ -0,0 +1,88 @@
+package importer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestImportIssues_OnConflict(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	now := time.Now().Truncate(time.Second)
+	newIssue := func(id, title string, status types.Status, updated time.Time) *types.Issue {
+		return &types.Issue{ID: id, Title: title, Status: status, Priority: 2, IssueType: types.TypeTask,
+			CreatedAt: now, UpdatedAt: updated}
+	}
+	var local []*types.Issue
+	for _, id := range []string{"test-1", "test-2", "test-3", "test-4"} {
+		local = append(local, newIssue(id, "Local "+id, types.StatusOpen, now))
+	}
+	if _, err := ImportIssues(ctx, "", store, local, Options{}); err != nil {
+		t.Fatalf("initial import failed: %v", err)
+	}
+
+	// test-2 is older than the local copy, so only the callback lets it win
+	earlier, later := now.Add(-time.Hour), now.Add(time.Hour)
+	incoming := []*types.Issue{
+		newIssue("test-1", "Remote test-1", types.StatusClosed, later),
+		newIssue("test-2", "Remote test-2", types.StatusInProgress, earlier),
+		newIssue("test-3", "Remote test-3", types.StatusInProgress, later),
+		newIssue("test-4", "Local test-4", types.StatusOpen, now),
+	}
+	incoming[0].ClosedAt = &later
+	resolutions := map[string]Resolution{
+		"test-1": {Action: ConflictKeepLocal},
+		"test-2": {Action: ConflictTakeIncoming},
+		"test-3": {Action: ConflictMergeFields, Fields: []string{"status"}},
+	}
+	var asked []string
+	result, err := ImportIssues(ctx, "", store, incoming, Options{
+		OnConflict: func(existing, incoming *types.Issue) Resolution {
+			if existing.ID != incoming.ID || existing.Title != "Local "+existing.ID {
+				t.Errorf("callback got existing %+v for incoming %s", existing, incoming.ID)
+			}
+			asked = append(asked, incoming.ID)
+			return resolutions[incoming.ID]
+		},
+	})
+	if err != nil {
+		t.Fatalf("ImportIssues failed: %v", err)
+	}
+	// test-4 has the local content, so it is no conflict
+	if len(asked) != 3 {
+		t.Errorf("callback asked about %v, want test-1, test-2 and test-3", asked)
+	}
+	if result.Updated != 2 || result.Unchanged != 2 {
+		t.Errorf("updated %d, unchanged %d; want 2 and 2", result.Updated, result.Unchanged)
+	}
+
+	want := map[string]struct {
+		title  string
+		status types.Status
+	}{
+		"test-1": {"Local test-1", types.StatusOpen},
+		"test-2": {"Remote test-2", types.StatusInProgress},
+		"test-3": {"Local test-3", types.StatusInProgress},
+	}
+	for id, w := range want {
+		got, err := store.GetIssue(ctx, id)
+		if err != nil || got == nil {
+			t.Fatalf("GetIssue(%s) = %v, %v", id, got, err)
+		}
+		if got.Title != w.title || got.Status != w.status {
+			t.Errorf("%s = %q/%s, want %q/%s", id, got.Title, got.Status, w.title, w.status)
+		}
+	}
+
+	// An unknown action fails the import
+	_, err = ImportIssues(ctx, "", store, []*types.Issue{newIssue("test-1", "Remote again", types.StatusOpen, later)}, Options{
+		OnConflict: func(existing, incoming *types.Issue) Resolution { return Resolution{Action: "coin-flip"} },
+	})
+	if err == nil {
+		t.Error("expected an unknown resolution to fail the import")
+	}
+}
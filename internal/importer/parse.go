@@ -0,0 +1,115 @@
+package importer
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// ParseOptions controls ParseIssues.
+type ParseOptions struct {
+	MaxLineSize   int                 // Longest accepted line (0 uses the default)
+	UnknownFields UnknownFieldsPolicy // Handling of issue fields this version does not know (see DecodeIssue); "" drops them
+	DecodeWorkers int                 // When > 1, decode lines on this many goroutines; issues are still returned in line order
+	NumericIDs    bool                // Accept IDs written as JSON numbers, keeping their exact digits (see quoteNumericIDs); otherwise they fail to decode
+	EmptyStatus   bool                // Leave an absent status empty instead of defaulting it to open, so Options.DeriveStatus or Options.DefaultStatus can fill it in
+}
+
+// ParseIssues reads issue JSONL, one issue per non-empty line, and returns the
+// issues in line order with defaults applied, ready for ImportIssues.
+//
+// Reading stays sequential, but with opts.DecodeWorkers above 1 the JSON
+// decoding, which dominates on large files, is spread over a worker pool.
+// Each line keeps its index, so the result (and the first error reported,
+// which is always the one on the earliest bad line) is the same as a serial
+// parse. The caller's import, which sorts and writes the issues, stays serial.
+func ParseIssues(r io.Reader, opts ParseOptions) ([]*types.Issue, error) {
+	lines, err := readLines(r, opts)
+	if err != nil {
+		return nil, err
+	}
+	return decodeLines(lines, opts)
+}
+
+// rawLine is a non-empty JSONL line and its 1-based line number.
+type rawLine struct {
+	num  int
+	data []byte
+}
+
+// readLines reads the non-empty lines of r.
+func readLines(r io.Reader, opts ParseOptions) ([]rawLine, error) {
+	var lines []rawLine
+	scanner := utils.NewJSONLScanner(r, opts.MaxLineSize)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		lines = append(lines, rawLine{num: scanner.Line(), data: append([]byte(nil), line...)})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// decodeLines decodes one issue per line as ParseIssues describes.
+func decodeLines(lines []rawLine, opts ParseOptions) ([]*types.Issue, error) {
+	issues := make([]*types.Issue, len(lines))
+	errs := make([]error, len(lines))
+	decode := func(i int) {
+		data := lines[i].data
+		if opts.NumericIDs {
+			var err error
+			if data, err = quoteNumericIDs(data); err != nil {
+				errs[i] = fmt.Errorf("line %d: %w", lines[i].num, err)
+				return
+			}
+		}
+		issue, err := DecodeIssue(data, opts.UnknownFields)
+		if err != nil {
+			errs[i] = fmt.Errorf("line %d: %w", lines[i].num, err)
+			return
+		}
+		missing := issue.Status == ""
+		issue.SetDefaults()
+		if missing && opts.EmptyStatus {
+			issue.Status = ""
+		}
+		issues[i] = issue
+	}
+
+	workers := min(opts.DecodeWorkers, len(lines))
+	if workers <= 1 {
+		for i := range lines {
+			if decode(i); errs[i] != nil {
+				return nil, errs[i]
+			}
+		}
+		return issues, nil
+	}
+
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := int(next.Add(1) - 1); i < len(lines); i = int(next.Add(1) - 1) {
+				decode(i)
+			}
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return issues, nil
+}
@@ -0,0 +1,75 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// issueJSONL returns n issue lines, each with enough text to make decoding
+// the dominant cost.
+func issueJSONL(tb testing.TB, n int) []byte {
+	tb.Helper()
+	var buf bytes.Buffer
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < n; i++ {
+		issue := &types.Issue{ID: fmt.Sprintf("test-%d", i), Title: fmt.Sprintf("Issue %d", i),
+			Description: strings.Repeat("Some description text. ", 20), Status: types.StatusOpen, Priority: i % 5,
+			IssueType: types.TypeTask, Labels: []string{"alpha", "beta"}, CreatedAt: now, UpdatedAt: now}
+		line, err := json.Marshal(issue)
+		if err != nil {
+			tb.Fatalf("marshal: %v", err)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
+
+func TestParseIssues_DecodeWorkers(t *testing.T) {
+	data := issueJSONL(t, 500)
+	serial, err := ParseIssues(bytes.NewReader(data), ParseOptions{})
+	if err != nil {
+		t.Fatalf("serial ParseIssues failed: %v", err)
+	}
+	concurrent, err := ParseIssues(bytes.NewReader(data), ParseOptions{DecodeWorkers: 8})
+	if err != nil {
+		t.Fatalf("concurrent ParseIssues failed: %v", err)
+	}
+	if len(serial) != 500 || len(concurrent) != 500 {
+		t.Fatalf("parsed %d and %d issues, want 500", len(serial), len(concurrent))
+	}
+	for i := range serial {
+		if serial[i].ID != concurrent[i].ID || concurrent[i].ID != fmt.Sprintf("test-%d", i) {
+			t.Fatalf("issue %d: serial %s, concurrent %s; want line order", i, serial[i].ID, concurrent[i].ID)
+		}
+	}
+
+	// The earliest bad line is reported, whichever worker fails first
+	bad := strings.Replace(string(data), `"id":"test-100"`, `"id":100`, 1)
+	bad = strings.Replace(bad, `"id":"test-400"`, `"id":400`, 1)
+	_, err = ParseIssues(strings.NewReader(bad), ParseOptions{DecodeWorkers: 8})
+	if err == nil || !strings.HasPrefix(err.Error(), "line 101:") {
+		t.Errorf("expected an error on line 101, got %v", err)
+	}
+}
+
+func BenchmarkParseIssues(b *testing.B) {
+	data := issueJSONL(b, 20000)
+	for _, workers := range []int{1, max(runtime.NumCPU(), 2)} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			b.SetBytes(int64(len(data)))
+			for i := 0; i < b.N; i++ {
+				if _, err := ParseIssues(bytes.NewReader(data), ParseOptions{DecodeWorkers: workers}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// PrefixResult is the outcome of importing one ID prefix under
+// Options.IsolatePrefixes.
+type PrefixResult struct {
+	Result *Result // Counts for this prefix
+	Err    error   // Why this prefix failed; none of its changes were committed
+}
+
+// importIsolatedPrefixes imports each ID prefix ("web" in "web-a1b2") as an
+// independent ImportIssues call, so a failure in one repo's issues rolls back
+// only that prefix while the others commit. The import as a whole is no longer
+// atomic: on error the returned Result still reflects the prefixes that
+// committed, and Result.PrefixResults says which failed and why.
+//
+// Dependencies between prefixes are held back from the per-prefix imports and
+// added once every prefix has been attempted, for source prefixes that
+// committed, together with opts.Relationships. A dependency on an issue whose prefix failed is then skipped
+// (or, with Strict, reported) like any other missing target.
+//
+// With opts.Concurrency above 1, up to that many prefixes are imported at
+// once, each on its own connection and transaction. Results are merged in
+// prefix order, so they do not depend on which prefix finished first.
+func importIsolatedPrefixes(ctx context.Context, dbPath string, store storage.Storage, issues []*types.Issue, opts Options) (*Result, error) {
+	sep, _ := store.GetConfig(ctx, storage.IDSeparatorConfigKey)
+	prefixOf := func(id string) string {
+		return utils.ExtractIssuePrefixWithSeparator(id, sep)
+	}
+
+	groups := make(map[string][]*types.Issue)
+	var cross []*types.Issue
+	for _, issue := range issues {
+		prefix := prefixOf(issue.ID)
+		local := *issue
+		local.Dependencies = nil
+		var held []*types.Dependency
+		for _, dep := range issue.Dependencies {
+			if strings.HasPrefix(dep.DependsOnID, "external:") || prefixOf(dep.DependsOnID) == prefix {
+				local.Dependencies = append(local.Dependencies, dep)
+			} else {
+				held = append(held, dep)
+			}
+		}
+		if len(held) > 0 {
+			cross = append(cross, &types.Issue{ID: issue.ID, Dependencies: held})
+		}
+		groups[prefix] = append(groups[prefix], &local)
+	}
+	events := make(map[string][]*types.Event)
+	for _, event := range opts.Events {
+		prefix := prefixOf(event.IssueID)
+		events[prefix] = append(events[prefix], event)
+	}
+	deletions := make(map[string][]string)
+	for _, id := range opts.DeletionIDs {
+		prefix := prefixOf(id)
+		deletions[prefix] = append(deletions[prefix], id)
+		if _, ok := groups[prefix]; !ok {
+			groups[prefix] = nil
+		}
+	}
+
+	prefixes := make([]string, 0, len(groups))
+	for prefix := range groups {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+
+	result := &Result{
+		IDMapping:        make(map[string]string),
+		MismatchPrefixes: make(map[string]int),
+		PrefixResults:    make(map[string]*PrefixResult, len(prefixes)),
+		events:           opts.ImportEvents,
+	}
+	// Shared rows are written once here rather than by every prefix: the
+	// export hash sweep runs up front, and each prefix resumes from its own
+	// import cursor. Milestones are stored before any prefix checks the
+	// references to them. Remaining config writes (custom type registration)
+	// happen inside each prefix's write transaction, which SQLite serializes.
+	if !opts.DryRun {
+		if err := store.ClearAllExportHashes(ctx); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to clear export_hashes before import: %v\n", err)
+		}
+	}
+	if err := importMilestones(storage.WithImport(ctx), store, opts, result); err != nil {
+		return nil, err
+	}
+
+	if opts.QuarantineWriter != nil {
+		opts.QuarantineWriter = &lockedWriter{w: opts.QuarantineWriter}
+	}
+	subs := make([]*PrefixResult, len(prefixes))
+	workers := max(opts.Concurrency, 1)
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	for i, prefix := range prefixes {
+		prefixOpts := opts
+		prefixOpts.IsolatePrefixes = false
+		prefixOpts.DeletionIDs = deletions[prefix]
+		prefixOpts.Relationships = nil
+		prefixOpts.Templates = nil
+		prefixOpts.Milestones = nil
+		prefixOpts.Events = events[prefix]
+		prefixOpts.exportHashesCleared = true
+		prefixOpts.importEventsShared = true
+		prefixOpts.CheckpointWAL = false
+		if opts.IdempotencyKey != "" {
+			prefixOpts.IdempotencyKey = opts.IdempotencyKey + "/" + prefix
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			sub, err := ImportIssues(ctx, dbPath, store, groups[prefix], prefixOpts)
+			subs[i] = &PrefixResult{Result: sub, Err: err}
+		}()
+	}
+	wg.Wait()
+
+	var errs []error
+	for i, prefix := range prefixes {
+		result.PrefixResults[prefix] = subs[i]
+		if subs[i].Err != nil {
+			errs = append(errs, fmt.Errorf("prefix %s: %w", prefix, subs[i].Err))
+			continue
+		}
+		result.merge(subs[i].Result)
+	}
+
+	if !opts.DryRun {
+		var ready []*types.Issue
+		for _, issue := range cross {
+			if pr := result.PrefixResults[prefixOf(issue.ID)]; pr != nil && pr.Err == nil {
+				ready = append(ready, issue)
+			}
+		}
+		if len(ready) > 0 || len(opts.Relationships) > 0 || len(opts.Templates) > 0 {
+			importCtx := importContext(ctx, opts)
+			err := store.RunInTransaction(importCtx, func(tx storage.Transaction) error {
+				if err := importDependenciesTx(importCtx, tx, ready, opts, result); err != nil {
+					return err
+				}
+				edges, err := relationshipEdges(importCtx, tx.GetIssue, opts, result)
+				if err != nil {
+					return err
+				}
+				if err := importDependenciesTx(importCtx, tx, edges, opts, result); err != nil {
+					return err
+				}
+				return importTemplates(importCtx, tx, opts, result)
+			})
+			if err != nil && strings.Contains(err.Error(), "not supported") {
+				err = importDependencies(importCtx, store, ready, opts, result)
+				if err == nil {
+					var edges []*types.Issue
+					if edges, err = relationshipEdges(importCtx, store.GetIssue, opts, result); err == nil {
+						err = importDependencies(importCtx, store, edges, opts, result)
+					}
+					if err == nil {
+						err = importTemplates(importCtx, store, opts, result)
+					}
+				}
+			}
+			if err != nil {
+				errs = append(errs, fmt.Errorf("cross-prefix dependencies: %w", err))
+			}
+		}
+	}
+
+	return result, errors.Join(errs...)
+}
+
+// merge adds the counts and mappings of a per-prefix result into r. Every
+// field is merged except PrefixResults, which the caller fills in, and
+// ShadowRun, which only ShadowImport sets.
+func (r *Result) merge(other *Result) {
+	if other == nil {
+		return
+	}
+	r.Created += other.Created
+	r.Updated += other.Updated
+	r.Unchanged += other.Unchanged
+	r.Skipped += other.Skipped
+	r.Deleted += other.Deleted
+	r.Collisions += other.Collisions
+	r.Resumed += other.Resumed
+	r.TypeFiltered += other.TypeFiltered
+	r.DroppedEvents += other.DroppedEvents
+	r.Templates += other.Templates
+	r.Milestones += other.Milestones
+	r.CollisionIDs = append(r.CollisionIDs, other.CollisionIDs...)
+	r.SkippedDependencies = append(r.SkippedDependencies, other.SkippedDependencies...)
+	r.SkippedChecklists = append(r.SkippedChecklists, other.SkippedChecklists...)
+	r.RegisteredTypes = append(r.RegisteredTypes, other.RegisteredTypes...)
+	r.RegisteredStatuses = append(r.RegisteredStatuses, other.RegisteredStatuses...)
+	r.ClampedTimestamps = append(r.ClampedTimestamps, other.ClampedTimestamps...)
+	r.HashCollisions = append(r.HashCollisions, other.HashCollisions...)
+	r.SelfParents = append(r.SelfParents, other.SelfParents...)
+	r.Resurrected = append(r.Resurrected, other.Resurrected...)
+	r.Synthesized = append(r.Synthesized, other.Synthesized...)
+	r.DependencyConflicts = append(r.DependencyConflicts, other.DependencyConflicts...)
+	r.SkippedTombstones = append(r.SkippedTombstones, other.SkippedTombstones...)
+	r.IDPrefixesCleared = append(r.IDPrefixesCleared, other.IDPrefixesCleared...)
+	r.Archived = append(r.Archived, other.Archived...)
+	r.Rejected = append(r.Rejected, other.Rejected...)
+	r.created = append(r.created, other.created...)
+	for oldID, newID := range other.IDMapping {
+		r.IDMapping[oldID] = newID
+	}
+	if other.PrefixMismatch {
+		r.PrefixMismatch = true
+		r.ExpectedPrefix = other.ExpectedPrefix
+	}
+	for prefix, n := range other.MismatchPrefixes {
+		r.MismatchPrefixes[prefix] += n
+	}
+}
@@ -0,0 +1,309 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestImportIssues_IsolatePrefixes(t *testing.T) {
+	ctx := context.Background()
+
+	store := newTestStore(t)
+
+	if err := store.SetConfig(ctx, "issue_prefix", "api"); err != nil {
+		t.Fatalf("Failed to set prefix: %v", err)
+	}
+
+	now := time.Now()
+	newIssue := func(id string, priority int) *types.Issue {
+		return &types.Issue{
+			ID:        id,
+			Title:     "Issue " + id,
+			Status:    types.StatusOpen,
+			Priority:  priority,
+			IssueType: types.TypeTask,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+	}
+	api1 := newIssue("api-1", 2)
+	api1.Dependencies = []*types.Dependency{
+		{IssueID: "api-1", DependsOnID: "ops-1", Type: types.DepBlocks},
+		{IssueID: "api-1", DependsOnID: "web-1", Type: types.DepRelated},
+	}
+	issues := []*types.Issue{
+		api1,
+		newIssue("web-1", 2),
+		newIssue("web-2", 9), // invalid priority fails the whole "web" prefix
+		newIssue("ops-1", 1),
+	}
+
+	result, err := ImportIssues(ctx, store.Path(), store, issues, Options{IsolatePrefixes: true, SkipPrefixValidation: true})
+	if err == nil {
+		t.Fatal("expected an error for the failed web prefix")
+	}
+	if result == nil {
+		t.Fatal("expected a result for the prefixes that committed")
+	}
+
+	if pr := result.PrefixResults["web"]; pr == nil || pr.Err == nil {
+		t.Errorf("expected web prefix to fail, got %+v", pr)
+	}
+	for _, prefix := range []string{"api", "ops"} {
+		if pr := result.PrefixResults[prefix]; pr == nil || pr.Err != nil || pr.Result.Created != 1 {
+			t.Errorf("expected %s prefix to commit 1 issue, got %+v", prefix, pr)
+		}
+	}
+	if result.Created != 2 {
+		t.Errorf("expected 2 created in total, got %d", result.Created)
+	}
+
+	for id, want := range map[string]bool{"api-1": true, "ops-1": true, "web-1": false, "web-2": false} {
+		issue, _ := store.GetIssue(ctx, id)
+		if (issue != nil) != want {
+			t.Errorf("%s exists = %v, want %v", id, issue != nil, want)
+		}
+	}
+
+	deps, err := store.GetDependencyRecords(ctx, "api-1")
+	if err != nil {
+		t.Fatalf("GetDependencyRecords failed: %v", err)
+	}
+	if len(deps) != 1 || deps[0].DependsOnID != "ops-1" {
+		t.Errorf("expected only the cross-prefix dependency on ops-1, got %v", deps)
+	}
+	if len(result.SkippedDependencies) != 1 {
+		t.Errorf("expected the dependency on failed web-1 to be skipped, got %v", result.SkippedDependencies)
+	}
+}
+
+func TestImportIssues_ConcurrentPrefixes(t *testing.T) {
+	ctx := context.Background()
+
+	store := newTestStore(t)
+
+	if err := store.SetConfig(ctx, "issue_prefix", "api"); err != nil {
+		t.Fatalf("Failed to set prefix: %v", err)
+	}
+
+	now := time.Now()
+	prefixes := []string{"api", "web", "ops", "cli"}
+	var issues []*types.Issue
+	for _, prefix := range prefixes {
+		for i := 1; i <= 12; i++ {
+			id := fmt.Sprintf("%s-%d", prefix, i)
+			issues = append(issues, &types.Issue{
+				ID: id, Title: "Issue " + id, Status: types.StatusOpen, Priority: 2,
+				IssueType: types.IssueType(prefix + "-work"), CreatedAt: now, UpdatedAt: now,
+			})
+		}
+		child := prefix + "-1.1"
+		issues = append(issues, &types.Issue{
+			ID: child, Title: "Child " + child, Status: types.StatusOpen, Priority: 2,
+			IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now,
+		})
+	}
+	issues[0].Dependencies = []*types.Dependency{{IssueID: "api-1", DependsOnID: "cli-2", Type: types.DepBlocks}}
+
+	result, err := ImportIssues(ctx, store.Path(), store, issues, Options{
+		IsolatePrefixes:       true,
+		Concurrency:           len(prefixes),
+		SkipPrefixValidation:  true,
+		AutoCreateCustomTypes: true,
+		BatchSize:             5,
+		IdempotencyKey:        "multi",
+	})
+	if err != nil {
+		t.Fatalf("concurrent import failed: %v", err)
+	}
+	if want := len(issues); result.Created != want {
+		t.Errorf("expected %d created, got %d", want, result.Created)
+	}
+	for _, prefix := range prefixes {
+		if pr := result.PrefixResults[prefix]; pr == nil || pr.Err != nil || pr.Result.Created != 13 {
+			t.Errorf("expected %s prefix to commit 13 issues, got %+v", prefix, pr)
+		}
+	}
+
+	stored, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(stored) != len(issues) {
+		t.Errorf("expected %d stored issues, got %d", len(issues), len(stored))
+	}
+
+	custom, err := store.GetConfig(ctx, customTypesConfigKey)
+	if err != nil {
+		t.Fatalf("GetConfig failed: %v", err)
+	}
+	for _, prefix := range prefixes {
+		if !strings.Contains(custom, prefix+"-work") {
+			t.Errorf("expected custom type %s-work registered, got %q", prefix, custom)
+		}
+	}
+
+	deps, err := store.GetDependencyRecords(ctx, "api-1")
+	if err != nil {
+		t.Fatalf("GetDependencyRecords failed: %v", err)
+	}
+	if len(deps) != 1 || deps[0].DependsOnID != "cli-2" {
+		t.Errorf("expected cross-prefix dependency on cli-2, got %+v", deps)
+	}
+}
+
+func TestImportIssues_IsolatePrefixesMergesResult(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+	newIssue := func(id string, deps ...*types.Dependency) *types.Issue {
+		return &types.Issue{ID: id, Title: "Issue " + id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask,
+			CreatedAt: now, UpdatedAt: now, Dependencies: deps}
+	}
+	blocks := func(from, to string) *types.Dependency {
+		return &types.Dependency{IssueID: from, DependsOnID: to, Type: types.DepBlocks, CreatedAt: now}
+	}
+
+	tests := []struct {
+		name   string
+		setup  func(t *testing.T, store *sqlite.SQLiteStorage, dir string)
+		issues func() []*types.Issue
+		opts   Options
+		field  func(r *Result) []string
+	}{
+		{
+			name: "TypeFiltered",
+			issues: func() []*types.Issue {
+				bug := newIssue("test-1")
+				bug.IssueType = types.TypeBug
+				return []*types.Issue{bug}
+			},
+			opts: Options{AllowedTypes: []types.IssueType{types.TypeTask}},
+			field: func(r *Result) []string {
+				if r.TypeFiltered == 0 {
+					return nil
+				}
+				return []string{fmt.Sprint(r.TypeFiltered)}
+			},
+		},
+		{
+			name: "ClampedTimestamps",
+			issues: func() []*types.Issue {
+				future := newIssue("test-1")
+				future.UpdatedAt = now.Add(30 * 24 * time.Hour)
+				return []*types.Issue{future}
+			},
+			opts:  Options{FutureTimestamps: FutureTimestampsClamp},
+			field: func(r *Result) []string { return r.ClampedTimestamps },
+		},
+		{
+			name: "RegisteredTypes",
+			issues: func() []*types.Issue {
+				spike := newIssue("test-1")
+				spike.IssueType = "spike"
+				return []*types.Issue{spike}
+			},
+			opts:  Options{AutoCreateCustomTypes: true},
+			field: func(r *Result) []string { return r.RegisteredTypes },
+		},
+		{
+			name: "RegisteredStatuses",
+			issues: func() []*types.Issue {
+				review := newIssue("test-1")
+				review.Status = "review"
+				return []*types.Issue{review}
+			},
+			opts:  Options{Definitions: &types.Definitions{Statuses: []types.Status{"review"}}},
+			field: func(r *Result) []string { return r.RegisteredStatuses },
+		},
+		{
+			name: "HashCollisions",
+			setup: func(t *testing.T, store *sqlite.SQLiteStorage, dir string) {
+				stubContentHash(t)
+				local := &types.Issue{ID: "test-1", ContentHash: "collide", Title: "Local", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+				if err := store.CreateIssue(ctx, local, "test"); err != nil {
+					t.Fatalf("CreateIssue failed: %v", err)
+				}
+			},
+			issues: func() []*types.Issue {
+				upstream := newIssue("test-1")
+				upstream.UpdatedAt = time.Now().Add(time.Second)
+				return []*types.Issue{upstream}
+			},
+			field: func(r *Result) []string { return r.HashCollisions },
+		},
+		{
+			name: "Resurrected",
+			setup: func(t *testing.T, store *sqlite.SQLiteStorage, dir string) {
+				// The deleted parent, still in the local JSONL history
+				f, err := os.Create(filepath.Join(dir, "issues.jsonl"))
+				if err != nil {
+					t.Fatalf("Failed to create JSONL: %v", err)
+				}
+				defer f.Close()
+				if err := json.NewEncoder(f).Encode(newIssue("test-1")); err != nil {
+					t.Fatalf("Failed to write JSONL: %v", err)
+				}
+			},
+			issues: func() []*types.Issue { return []*types.Issue{newIssue("test-1.1")} },
+			opts:   Options{OrphanHandling: OrphanResurrect},
+			field:  func(r *Result) []string { return r.Resurrected },
+		},
+		{
+			name:   "Synthesized",
+			issues: func() []*types.Issue { return []*types.Issue{newIssue("test-1.1")} },
+			opts:   Options{OrphanHandling: OrphanStrict, SynthesizeParents: true},
+			field:  func(r *Result) []string { return r.Synthesized },
+		},
+		{
+			name: "DependencyConflicts",
+			setup: func(t *testing.T, store *sqlite.SQLiteStorage, dir string) {
+				existing := []*types.Issue{newIssue("test-1"), newIssue("test-2", blocks("test-2", "test-1"))}
+				if _, err := ImportIssues(ctx, "", store, existing, Options{}); err != nil {
+					t.Fatalf("ImportIssues failed: %v", err)
+				}
+			},
+			issues: func() []*types.Issue {
+				return []*types.Issue{newIssue("test-1", blocks("test-1", "test-2")), newIssue("test-2")}
+			},
+			field: func(r *Result) []string { return r.DependencyConflicts },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestStore(t)
+			dir := filepath.Dir(store.Path())
+			if tt.setup != nil {
+				tt.setup(t, store, dir)
+			}
+
+			opts := tt.opts
+			opts.IsolatePrefixes = true
+			result, err := ImportIssues(ctx, "", store, tt.issues(), opts)
+			if err != nil {
+				t.Fatalf("ImportIssues failed: %v", err)
+			}
+			pr := result.PrefixResults["test"]
+			if pr == nil || pr.Err != nil {
+				t.Fatalf("test prefix result = %+v, want a committed import", pr)
+			}
+			want := tt.field(pr.Result)
+			if len(want) == 0 {
+				t.Fatalf("the test prefix reported no %s; the case does not exercise it", tt.name)
+			}
+			if got := tt.field(result); !reflect.DeepEqual(got, want) {
+				t.Errorf("merged %s = %v, want the prefix's %v", tt.name, got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// importDB is the part of storage.Storage and storage.Transaction that an
+// import's preparation reads and deletes through: the store for ImportIssues,
+// the caller's transaction for ImportIssuesTx.
+type importDB interface {
+	configStore
+	issueGetter
+	issueSearcher
+	DeleteIssue(ctx context.Context, id string) error
+}
+
+// validateOptions checks opts before an import reads or writes anything, for
+// ImportIssues and ImportIssuesTx alike.
+func validateOptions(ctx context.Context, store storage.Storage, opts Options) error {
+	if err := checkWritable(ctx, store, opts); err != nil {
+		return err
+	}
+	if err := checkSchemaVersion(ctx, store); err != nil {
+		return err
+	}
+	if err := validateUpdateFields(opts.UpdateFields); err != nil {
+		return err
+	}
+	if err := types.ValidateRedactFields(opts.Redact); err != nil {
+		return err
+	}
+	if err := validateTxHooks(opts); err != nil {
+		return err
+	}
+	if err := validateUnknownTombstonePolicy(opts.UnknownTombstones); err != nil {
+		return err
+	}
+	if err := validateDuplicateDepPolicy(opts.DuplicateDependencies); err != nil {
+		return err
+	}
+	if err := validateDepInversionPolicy(opts.DependencyInversions); err != nil {
+		return err
+	}
+	if err := validateVerifyLevel(opts.Verify); err != nil {
+		return err
+	}
+	if err := validateWebhook(opts.Webhook); err != nil {
+		return err
+	}
+	if err := validateQuarantine(opts); err != nil {
+		return err
+	}
+	if opts.DeferOrphans && opts.BatchSize <= 0 {
+		return fmt.Errorf("DeferOrphans requires BatchSize")
+	}
+	if opts.RenameOnCollision && opts.BatchSize > 0 {
+		return fmt.Errorf("RenameOnCollision is not supported with BatchSize")
+	}
+	if opts.OnConflict != nil && opts.IsolatePrefixes && opts.Concurrency > 1 {
+		return fmt.Errorf("OnConflict is not supported with Concurrency above 1")
+	}
+	if opts.DefaultIDPrefix != "" && opts.IsolatePrefixes {
+		return fmt.Errorf("DefaultIDPrefix is not supported with IsolatePrefixes")
+	}
+	return nil
+}
+
+// importContext marks ctx as an import, bypassing edit-time rules such as
+// status transitions, plus the rule sets opts bypasses.
+func importContext(ctx context.Context, opts Options) context.Context {
+	ctx = storage.WithImport(ctx)
+	if opts.BypassTypeStatuses {
+		ctx = storage.WithoutTypeStatusRules(ctx)
+	}
+	if opts.BypassParentTypes {
+		ctx = storage.WithoutParentTypeRules(ctx)
+	}
+	return ctx
+}
+
+// prepareImport runs the steps ImportIssues and ImportIssuesTx share before
+// matching incoming issues against the database: per-issue validation and
+// policies, defaults, normalization and the prefix checks. ctx must come from
+// importContext. Reads go through db, apart from prefix configuration and the
+// archive, which come from store. Returns the issues left to import and opts
+// with the settings resolved along the way.
+func prepareImport(ctx context.Context, store storage.Storage, db importDB, issues []*types.Issue, opts Options, result *Result) ([]*types.Issue, Options, error) {
+	if err := rejectZeroTimestamps(ctx, issues, opts, result); err != nil {
+		return nil, opts, err
+	}
+	if err := requireContentHashes(ctx, issues, opts, result); err != nil {
+		return nil, opts, err
+	}
+	if err := applyFutureTimestampPolicy(issues, opts.FutureTimestamps, time.Now(), result); err != nil {
+		return nil, opts, err
+	}
+	if err := applyExpiredImportPolicy(issues, opts.ExpiredOnImport, time.Now()); err != nil {
+		return nil, opts, err
+	}
+	if err := applySelfParentPolicy(ctx, issues, opts, result); err != nil {
+		return nil, opts, err
+	}
+	// Issues rejected under ContinueOnError so far are left out from here on
+	issues = result.withoutRejected(issues)
+	if err := applyStatusMap(ctx, db, issues, opts); err != nil {
+		return nil, opts, err
+	}
+	if err := applyImportDefaults(ctx, db, issues, opts); err != nil {
+		return nil, opts, err
+	}
+	if err := applySourceSystem(ctx, db, issues, opts); err != nil {
+		return nil, opts, err
+	}
+	if err := applyActorMap(issues, opts); err != nil {
+		return nil, opts, err
+	}
+	issues, err := applyTypeAllowList(issues, opts, result)
+	if err != nil {
+		return nil, opts, err
+	}
+	if opts.hashOptions, err = loadHashOptions(ctx, db); err != nil {
+		return nil, opts, err
+	}
+	prepareIssues(issues, opts)
+
+	// GH#686: In multi-repo mode, skip prefix validation for all issues.
+	// Issues from additional repos have their own prefixes which are expected and correct.
+	if config.GetMultiRepoConfig() != nil && !opts.SkipPrefixValidation {
+		opts.SkipPrefixValidation = true
+	}
+	// Read orphan handling from config if not explicitly set
+	opts.OrphanHandling = resolveOrphanHandling(ctx, db, opts.OrphanHandling)
+
+	if err := applyDefaultIDPrefix(ctx, db, issues, opts, result); err != nil {
+		return nil, opts, err
+	}
+	if err := applyIDPrefixPolicy(ctx, db, issues, opts, result); err != nil {
+		return nil, opts, err
+	}
+	issues = result.withoutRejected(issues)
+	// Check and handle prefix mismatches
+	if issues, err = handlePrefixMismatch(ctx, store, issues, opts, result); err != nil {
+		return nil, opts, err
+	}
+	if opts, err = applyPrefixRestriction(ctx, store, issues, opts, result); err != nil {
+		return nil, opts, err
+	}
+	issues = result.withoutRejected(issues)
+	if issues, opts, err = applyMilestoneRefs(ctx, db, issues, opts, result); err != nil {
+		return nil, opts, err
+	}
+	if issues, err = skipArchivedIssues(ctx, store, issues, opts, result); err != nil {
+		return nil, opts, err
+	}
+	// Validate no duplicate external_ref values in batch
+	if err := validateNoDuplicateExternalRefs(issues, opts.ClearDuplicateExternalRefs, result); err != nil {
+		return nil, opts, err
+	}
+	return issues, opts, nil
+}
+
+// applyDeletions deletes the issues named by opts.DeletionIDs through db,
+// before any upserts that might conflict with them, or with DryRun only counts
+// those that exist. Issues already gone are skipped; other failures are
+// warnings unless opts.Strict.
+func applyDeletions(ctx context.Context, db importDB, opts Options, result *Result) error {
+	for _, id := range opts.DeletionIDs {
+		existing, err := db.GetIssue(ctx, id)
+		if err != nil || existing == nil {
+			continue
+		}
+		if opts.DryRun {
+			result.Deleted++
+			continue
+		}
+		if err := db.DeleteIssue(ctx, id); err != nil {
+			if opts.Strict {
+				return fmt.Errorf("failed to delete issue %s: %w", id, err)
+			}
+			fmt.Fprintf(os.Stderr, "Warning: failed to delete issue %s: %v\n", id, err)
+			continue
+		}
+		result.Deleted++
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package importer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// projectableFields lists the columns Options.UpdateFields may name: the
+// fields a full import update writes on an existing issue.
+var projectableFields = map[string]bool{
+	"title":               true,
+	"description":         true,
+	"status":              true,
+	"priority":            true,
+	"issue_type":          true,
+	"design":              true,
+	"acceptance_criteria": true,
+	"notes":               true,
+	"closed_at":           true,
+	"due_at":              true,
+	"expires_at":          true,
+	"color":               true,
+	"display_order":       true,
+	"rank":                true,
+	"milestone_id":        true,
+	"source_system":       true,
+	"pinned":              true,
+	"assignee":            true,
+	"external_ref":        true,
+}
+
+// validateUpdateFields rejects Options.UpdateFields entries that an import
+// update cannot write.
+func validateUpdateFields(fields []string) error {
+	var unknown []string
+	for _, field := range fields {
+		if !projectableFields[field] {
+			unknown = append(unknown, field)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("unsupported update field(s) %s (supported: title, description, status, priority, issue_type, design, acceptance_criteria, notes, closed_at, due_at, color, display_order, rank, milestone_id, source_system, pinned, assignee, external_ref)", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// projectUpdates narrows the updates built from incoming to fields. An empty
+// fields list keeps every update (full upsert). pinned is written as given
+// when projected, since a full update only ever sets it.
+func projectUpdates(updates map[string]interface{}, incoming *types.Issue, fields []string) map[string]interface{} {
+	if len(fields) == 0 {
+		return updates
+	}
+	projected := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		if field == "pinned" {
+			projected[field] = incoming.Pinned
+			continue
+		}
+		if v, ok := updates[field]; ok {
+			projected[field] = v
+		}
+	}
+	return projected
+}
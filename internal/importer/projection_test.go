@@ -0,0 +1,68 @@
+package importer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestImportIssues_UpdateFields(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	past := time.Now().Add(-time.Hour)
+	for _, id := range []string{"test-a", "test-b"} {
+		issue := &types.Issue{ID: id, Title: "Local " + id, Description: "Edited locally", Status: types.StatusOpen,
+			Priority: 1, IssueType: types.TypeTask, Assignee: "alice", CreatedAt: past, UpdatedAt: past}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("Failed to create %s: %v", id, err)
+		}
+	}
+
+	now := time.Now()
+	upstream := []*types.Issue{
+		{ID: "test-a", Title: "Upstream a", Description: "Upstream text", Status: types.StatusInProgress,
+			Priority: 3, IssueType: types.TypeBug, Assignee: "bob", CreatedAt: past, UpdatedAt: now},
+		{ID: "test-b", Title: "Upstream b", Status: types.StatusClosed, Priority: 3, IssueType: types.TypeBug,
+			CreatedAt: past, UpdatedAt: now, ClosedAt: &now},
+		{ID: "test-c", Title: "Brand new", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask,
+			CreatedAt: now, UpdatedAt: now},
+	}
+	result, err := ImportIssues(ctx, "", store, upstream, Options{UpdateFields: []string{"status"}})
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if result.Updated != 2 || result.Created != 1 {
+		t.Errorf("expected 2 updated and 1 created, got %+v", result)
+	}
+
+	wantStatus := map[string]types.Status{"test-a": types.StatusInProgress, "test-b": types.StatusClosed}
+	for id, status := range wantStatus {
+		got, err := store.GetIssue(ctx, id)
+		if err != nil || got == nil {
+			t.Fatalf("GetIssue(%s) failed: %v", id, err)
+		}
+		if got.Status != status {
+			t.Errorf("%s: status = %s, want %s", id, got.Status, status)
+		}
+		if got.Title != "Local "+id || got.Description != "Edited locally" || got.Priority != 1 ||
+			got.IssueType != types.TypeTask || got.Assignee != "alice" {
+			t.Errorf("%s: expected non-projected fields untouched, got %+v", id, got)
+		}
+		if got.ContentHash != got.ComputeContentHash() {
+			t.Errorf("%s: content_hash %s not recomputed from merged fields (want %s)", id, got.ContentHash, got.ComputeContentHash())
+		}
+	}
+	if closed, _ := store.GetIssue(ctx, "test-b"); closed.ClosedAt == nil {
+		t.Error("test-b: expected closed_at set when status projected to closed")
+	}
+	if created, _ := store.GetIssue(ctx, "test-c"); created == nil || created.Title != "Brand new" {
+		t.Errorf("expected new issue created in full, got %+v", created)
+	}
+
+	if _, err := ImportIssues(ctx, "", store, upstream, Options{UpdateFields: []string{"status", "created_at"}}); err == nil {
+		t.Error("expected an unsupported update field to be rejected")
+	}
+}
@@ -0,0 +1,121 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// QuarantineErrorField is the field in which each line written to
+// Options.QuarantineWriter carries the error that rejected the issue.
+// DecodeIssue ignores it under every UnknownFieldsPolicy, so quarantine output
+// re-imports as it is once the issues are fixed.
+const QuarantineErrorField = "_import_error"
+
+// validateQuarantine rejects Options.QuarantineWriter without
+// Options.ContinueOnError, under which no issue is ever rejected.
+func validateQuarantine(opts Options) error {
+	if opts.QuarantineWriter != nil && !opts.ContinueOnError {
+		return fmt.Errorf("QuarantineWriter requires ContinueOnError")
+	}
+	return nil
+}
+
+// rejectIssue implements Options.ContinueOnError for an issue that failed a
+// per-issue check or whose write failed with err: unless the import is being canceled, the issue is left out
+// of the rest of the import, recorded in result.Rejected and written to
+// Options.QuarantineWriter, and nil is returned so the import carries on.
+// Without ContinueOnError err is returned as it is. A quarantine line that
+// cannot be written fails the import, so no rejected issue goes unrecorded.
+func rejectIssue(ctx context.Context, issue *types.Issue, err error, opts Options, result *Result) error {
+	if !opts.ContinueOnError || ctx.Err() != nil {
+		return err
+	}
+	if opts.QuarantineWriter != nil {
+		if werr := writeQuarantine(opts.QuarantineWriter, issue, err); werr != nil {
+			return fmt.Errorf("failed to quarantine issue %s: %w (rejected for: %v)", issue.ID, werr, err)
+		}
+	}
+	result.Rejected = append(result.Rejected, issue.ID)
+	result.note(ImportEventSkipped, issue.ID)
+	return nil
+}
+
+// savepointer is implemented by transactions that can roll back part of their
+// writes, such as SQLite's.
+type savepointer interface {
+	WithSavepoint(ctx context.Context, fn func() error) error
+}
+
+// writeIssueTx runs write, which stores one issue through tx. Under
+// Options.ContinueOnError it runs in a savepoint when tx supports them, so a
+// write that fails partway leaves nothing behind once rejectIssue leaves the
+// issue out. Otherwise a failed write rolls the whole import back anyway.
+func writeIssueTx(ctx context.Context, tx storage.Transaction, opts Options, write func() error) error {
+	if sp, ok := tx.(savepointer); ok && opts.ContinueOnError {
+		return sp.WithSavepoint(ctx, write)
+	}
+	return write()
+}
+
+// withoutRejected returns issues minus those rejectIssue left out, so their
+// labels, comments and other attachments are not written.
+func (r *Result) withoutRejected(issues []*types.Issue) []*types.Issue {
+	if len(r.Rejected) == 0 {
+		return issues
+	}
+	rejected := make(map[string]bool, len(r.Rejected))
+	for _, id := range r.Rejected {
+		rejected[id] = true
+	}
+	kept := make([]*types.Issue, 0, len(issues))
+	for _, issue := range issues {
+		if !rejected[issue.ID] {
+			kept = append(kept, issue)
+		}
+	}
+	return kept
+}
+
+// writeQuarantine writes issue to w as one JSONL line, led by
+// QuarantineErrorField holding cause.
+func writeQuarantine(w io.Writer, issue *types.Issue, cause error) error {
+	data, err := types.MarshalIssue(issue)
+	if err != nil {
+		return err
+	}
+	key, _ := json.Marshal(QuarantineErrorField)
+	msg, err := json.Marshal(cause.Error())
+	if err != nil {
+		return err
+	}
+	var line bytes.Buffer
+	line.WriteByte('{')
+	line.Write(key)
+	line.WriteByte(':')
+	line.Write(msg)
+	line.WriteByte(',')
+	line.Write(data[1:])
+	line.WriteByte('\n')
+	_, err = w.Write(line.Bytes())
+	return err
+}
+
+// lockedWriter serializes writes to w, for a QuarantineWriter shared by the
+// concurrent per-prefix imports of Options.IsolatePrefixes.
+type lockedWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Write(p)
+}
@@ -0,0 +1,115 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestImportIssues_QuarantineWriter(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+	input := func() []*types.Issue {
+		return []*types.Issue{
+			{ID: "test-1", Title: "Fine", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now},
+			{ID: "test-2", Title: "Bad status", Status: "bogus", Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now,
+				Labels: []string{"dirty"}},
+			{ID: "test-3", Title: "Bad priority", Status: types.StatusOpen, Priority: 9, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now},
+			{ID: "test-4", Title: "Depends on a reject", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now,
+				Labels: []string{"clean"}, Dependencies: []*types.Dependency{{IssueID: "test-4", DependsOnID: "test-2", Type: types.DepBlocks}}},
+		}
+	}
+	if _, err := ImportIssues(ctx, "", newTestStore(t), input(), Options{}); err == nil {
+		t.Fatal("expected the invalid issues to fail the import without ContinueOnError")
+	}
+
+	store := newTestStore(t)
+	var quarantine bytes.Buffer
+	result, err := ImportIssues(ctx, "", store, input(), Options{ContinueOnError: true, QuarantineWriter: &quarantine})
+	if err != nil {
+		t.Fatalf("ImportIssues failed: %v", err)
+	}
+	if result.Created != 2 || result.Skipped != 2 || strings.Join(result.Rejected, ",") != "test-2,test-3" {
+		t.Errorf("created %d, skipped %d, rejected %v; want test-2 and test-3 rejected", result.Created, result.Skipped, result.Rejected)
+	}
+	if got, _ := store.GetIssue(ctx, "test-4"); got == nil || len(got.Labels) != 1 {
+		t.Errorf("test-4 = %+v, want it created with its label", got)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(quarantine.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("quarantine = %q, want 2 lines", quarantine.String())
+	}
+	for i, want := range []string{"status", "priority"} {
+		var record map[string]interface{}
+		if err := json.Unmarshal([]byte(lines[i]), &record); err != nil {
+			t.Fatalf("quarantine line %d is not JSON: %v", i+1, err)
+		}
+		if msg, _ := record[QuarantineErrorField].(string); !strings.Contains(msg, want) {
+			t.Errorf("line %d error = %q, want it to mention %s", i+1, msg, want)
+		}
+	}
+
+	// The quarantine re-imports once the rejects are fixed
+	fixed, err := ParseIssues(strings.NewReader(quarantine.String()), ParseOptions{UnknownFields: UnknownFieldsError})
+	if err != nil {
+		t.Fatalf("ParseIssues(quarantine) failed: %v", err)
+	}
+	if len(fixed) != 2 || fixed[0].ID != "test-2" || fixed[0].Labels[0] != "dirty" || fixed[1].ID != "test-3" {
+		t.Fatalf("quarantined issues = %+v, want test-2 and test-3 as written", fixed)
+	}
+	fixed[0].Status, fixed[1].Priority = types.StatusOpen, 2
+	again, err := ImportIssues(ctx, "", store, fixed, Options{ContinueOnError: true, QuarantineWriter: &quarantine})
+	if err != nil || again.Created != 2 || len(again.Rejected) != 0 {
+		t.Errorf("re-import = %+v, %v; want both created", again, err)
+	}
+
+	t.Run("updates", func(t *testing.T) {
+		later := now.Add(time.Hour)
+		update := []*types.Issue{{ID: "test-1", Title: "Now bad", Status: types.StatusOpen, Priority: 9, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: later}}
+		var quarantine bytes.Buffer
+		result, err := ImportIssues(ctx, "", store, update, Options{ContinueOnError: true, QuarantineWriter: &quarantine})
+		if err != nil || len(result.Rejected) != 1 || !strings.Contains(quarantine.String(), `"id":"test-1"`) {
+			t.Errorf("result = %+v, %v, quarantine %q; want the update rejected", result, err, quarantine.String())
+		}
+		if got, _ := store.GetIssue(ctx, "test-1"); got == nil || got.Title != "Fine" {
+			t.Errorf("test-1 = %+v, want it unchanged", got)
+		}
+	})
+
+	t.Run("checks", func(t *testing.T) {
+		var zero time.Time
+		checked := []*types.Issue{
+			{ID: "test-5", Title: "Checked", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now},
+			{ID: "test-6", Title: "Zero closed_at", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now, ClosedAt: &zero},
+			{ID: "test-7", Title: "Own parent", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now,
+				Dependencies: []*types.Dependency{{IssueID: "test-7", DependsOnID: "test-7", Type: types.DepParentChild}}},
+			{ID: "other-1", Title: "Wrong prefix", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now},
+		}
+		var quarantine bytes.Buffer
+		result, err := ImportIssues(ctx, "", store, checked, Options{ContinueOnError: true, QuarantineWriter: &quarantine})
+		if err != nil {
+			t.Fatalf("ImportIssues failed: %v", err)
+		}
+		if result.Created != 1 || strings.Join(result.Rejected, ",") != "test-6,test-7,other-1" {
+			t.Errorf("created %d, rejected %v; want test-6, test-7 and other-1 rejected", result.Created, result.Rejected)
+		}
+		if n := strings.Count(quarantine.String(), "\n"); n != 3 {
+			t.Errorf("quarantine has %d lines, want 3: %q", n, quarantine.String())
+		}
+		for _, id := range []string{"test-6", "test-7", "other-1"} {
+			if got, _ := store.GetIssue(ctx, id); got != nil {
+				t.Errorf("%s was imported", id)
+			}
+		}
+	})
+
+	if _, err := ImportIssues(ctx, "", newTestStore(t), input(), Options{QuarantineWriter: &quarantine}); err == nil {
+		t.Error("expected QuarantineWriter without ContinueOnError to be rejected")
+	}
+}
@@ -0,0 +1,105 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// QuotaConfigPrefix prefixes the config keys holding per-prefix issue quotas:
+// "import.quota.acme" = "500" caps prefix acme at 500 issues. Prefixes without
+// a key are unlimited.
+const QuotaConfigPrefix = "import.quota."
+
+// ErrQuotaExceeded is matched (via errors.Is) by every QuotaExceededError.
+var ErrQuotaExceeded = errors.New("issue quota exceeded")
+
+// QuotaExceededError reports an import rolled back because it would leave a
+// prefix with more issues than its quota.
+type QuotaExceededError struct {
+	Prefix string
+	Quota  int
+	Count  int // Issues the prefix would hold, existing plus created
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("%s: prefix %s would hold %d issues, quota is %d", ErrQuotaExceeded, e.Prefix, e.Count, e.Quota)
+}
+
+func (e *QuotaExceededError) Unwrap() error { return ErrQuotaExceeded }
+
+// quotasConfigured reports whether any prefix has a quota. Quotas can only be
+// enforced inside a transaction, so imports without one refuse to run then.
+func quotasConfigured(ctx context.Context, store storage.Storage) (bool, error) {
+	config, err := store.GetAllConfig(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to read quotas: %w", err)
+	}
+	for key, value := range config {
+		if strings.HasPrefix(key, QuotaConfigPrefix) && strings.TrimSpace(value) != "" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// checkQuotas counts, within tx and after the import's writes, the issues of
+// every prefix in which created holds an issue, and returns a
+// QuotaExceededError for the first prefix over its quota. Running before the
+// commit, under the import's write lock, makes the check race-free against
+// concurrent imports. Tombstones do not count.
+func checkQuotas(ctx context.Context, tx storage.Transaction, created []*types.Issue) error {
+	if len(created) == 0 {
+		return nil
+	}
+	sep, _ := tx.GetConfig(ctx, storage.IDSeparatorConfigKey)
+	if sep == "" {
+		sep = utils.DefaultIDSeparator
+	}
+	affected := make(map[string]bool)
+	for _, issue := range created {
+		affected[utils.ExtractIssuePrefixWithSeparator(issue.ID, sep)] = true
+	}
+	prefixes := make([]string, 0, len(affected))
+	for prefix := range affected {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+
+	for _, prefix := range prefixes {
+		value, err := tx.GetConfig(ctx, QuotaConfigPrefix+prefix)
+		if err != nil {
+			return fmt.Errorf("failed to read quota for prefix %s: %w", prefix, err)
+		}
+		if value = strings.TrimSpace(value); value == "" {
+			continue
+		}
+		quota, err := strconv.Atoi(value)
+		if err != nil || quota < 0 {
+			return fmt.Errorf("invalid %s%s %q: want a non-negative issue count", QuotaConfigPrefix, prefix, value)
+		}
+		// IDPrefix also matches longer prefixes (acme- matches acme-web-1),
+		// so count only the IDs whose prefix is exactly this one.
+		issues, err := tx.SearchIssues(ctx, "", types.IssueFilter{IDPrefix: prefix + sep})
+		if err != nil {
+			return fmt.Errorf("failed to count issues for prefix %s: %w", prefix, err)
+		}
+		count := 0
+		for _, issue := range issues {
+			if utils.ExtractIssuePrefixWithSeparator(issue.ID, sep) == prefix {
+				count++
+			}
+		}
+		if count > quota {
+			return &QuotaExceededError{Prefix: prefix, Quota: quota, Count: count}
+		}
+	}
+	return nil
+}
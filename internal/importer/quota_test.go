@@ -0,0 +1,91 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestImportIssues_PrefixQuota(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	newIssue := func(id string) *types.Issue {
+		return &types.Issue{ID: id, Title: "Issue " + id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now}
+	}
+
+	store := newTestStore(t)
+	if err := store.SetConfig(ctx, QuotaConfigPrefix+"test", "3"); err != nil {
+		t.Fatalf("Failed to set quota: %v", err)
+	}
+	opts := Options{SkipPrefixValidation: true}
+
+	// Two, then a third: right up to the quota
+	if _, err := ImportIssues(ctx, "", store, []*types.Issue{newIssue("test-1"), newIssue("test-2")}, opts); err != nil {
+		t.Fatalf("ImportIssues failed: %v", err)
+	}
+	if _, err := ImportIssues(ctx, "", store, []*types.Issue{newIssue("test-3")}, opts); err != nil {
+		t.Fatalf("import reaching the quota failed: %v", err)
+	}
+
+	// Updating existing issues never counts against the quota
+	updated := newIssue("test-1")
+	updated.Title = "Renamed"
+	updated.UpdatedAt = now.Add(time.Minute)
+	if _, err := ImportIssues(ctx, "", store, []*types.Issue{updated}, opts); err != nil {
+		t.Fatalf("update at the quota failed: %v", err)
+	}
+
+	// A fourth crosses it: the whole import rolls back, including the issue
+	// of an unlimited prefix imported alongside
+	_, err := ImportIssues(ctx, "", store, []*types.Issue{newIssue("other-1"), newIssue("test-4")}, opts)
+	var quotaErr *QuotaExceededError
+	if !errors.As(err, &quotaErr) || !errors.Is(err, ErrQuotaExceeded) {
+		t.Fatalf("expected QuotaExceededError, got %v", err)
+	}
+	if quotaErr.Prefix != "test" || quotaErr.Quota != 3 || quotaErr.Count != 4 {
+		t.Errorf("unexpected quota error %+v", quotaErr)
+	}
+	for _, id := range []string{"test-4", "other-1"} {
+		if got, _ := store.GetIssue(ctx, id); got != nil {
+			t.Errorf("expected %s rolled back, got it stored", id)
+		}
+	}
+
+	// Other prefixes stay unlimited
+	if _, err := ImportIssues(ctx, "", store, []*types.Issue{newIssue("other-1"), newIssue("other-2")}, opts); err != nil {
+		t.Errorf("import of an unlimited prefix failed: %v", err)
+	}
+}
+
+func TestImportIssues_PrefixQuotaBatched(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	store := newTestStore(t)
+	if err := store.SetConfig(ctx, QuotaConfigPrefix+"test", "3"); err != nil {
+		t.Fatalf("Failed to set quota: %v", err)
+	}
+
+	var issues []*types.Issue
+	for _, id := range []string{"test-1", "test-2", "test-3", "test-4", "test-5"} {
+		issues = append(issues, &types.Issue{ID: id, Title: "Issue " + id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now})
+	}
+	_, err := ImportIssues(ctx, "", store, issues, Options{SkipPrefixValidation: true, BatchSize: 2})
+	var quotaErr *QuotaExceededError
+	if !errors.As(err, &quotaErr) || quotaErr.Count != 4 {
+		t.Fatalf("expected QuotaExceededError at 4 issues, got %v", err)
+	}
+
+	// The batch crossing the quota rolls back; the batch before it committed
+	for id, want := range map[string]bool{"test-1": true, "test-2": true, "test-3": false, "test-4": false, "test-5": false} {
+		got, err := store.GetIssue(ctx, id)
+		if err != nil {
+			t.Fatalf("GetIssue(%s) failed: %v", id, err)
+		}
+		if (got != nil) != want {
+			t.Errorf("%s stored = %v, want %v", id, got != nil, want)
+		}
+	}
+}
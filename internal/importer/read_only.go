@@ -0,0 +1,21 @@
+package importer
+
+import (
+	"context"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// checkWritable fails an import up front, before anything is read or
+// written, when store reports through storage.WriteChecker that it cannot be
+// written (the SQLite backend returns sqlite.ErrReadOnly). Dry runs write
+// nothing and are let through.
+func checkWritable(ctx context.Context, store storage.Storage, opts Options) error {
+	if opts.DryRun {
+		return nil
+	}
+	if checker, ok := store.(storage.WriteChecker); ok {
+		return checker.CheckWritable(ctx)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestImportIssues_ReadOnlyDatabase(t *testing.T) {
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	store, err := sqlite.New(ctx, dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	if err := store.SetConfig(ctx, "issue_prefix", "test"); err != nil {
+		t.Fatalf("Failed to set prefix: %v", err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatalf("Failed to close store: %v", err)
+	}
+
+	ro, err := sqlite.NewReadOnly(ctx, dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open read-only: %v", err)
+	}
+	defer ro.Close()
+
+	now := time.Now()
+	issues := []*types.Issue{{ID: "test-1", Title: "Blocked by maintenance", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now}}
+	events := make(chan ImportEvent, 1)
+	result, err := ImportIssues(ctx, "", ro, issues, Options{ImportEvents: events})
+	if !errors.Is(err, sqlite.ErrReadOnly) {
+		t.Fatalf("expected ErrReadOnly, got %v", err)
+	}
+	if result != nil {
+		t.Errorf("expected no result, got %+v", result)
+	}
+	if _, ok := <-events; ok {
+		t.Error("no issue should have been processed before the read-only check")
+	}
+
+	if _, err := ReplaceAllImport(ctx, ro, issues, "test-user", Options{}); !errors.Is(err, sqlite.ErrReadOnly) {
+		t.Errorf("ReplaceAllImport: expected ErrReadOnly, got %v", err)
+	}
+	if _, err := ImportIssues(ctx, "", ro, issues, Options{DryRun: true}); errors.Is(err, sqlite.ErrReadOnly) {
+		t.Errorf("dry runs should not be refused: %v", err)
+	}
+}
@@ -0,0 +1,103 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestImportIssues_RedactedExportRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	parse := func(data string) []*types.Issue {
+		t.Helper()
+		var issues []*types.Issue
+		for _, line := range strings.Split(strings.TrimSpace(data), "\n") {
+			if strings.Contains(line, `"_summary"`) {
+				continue
+			}
+			var issue types.Issue
+			if err := json.Unmarshal([]byte(line), &issue); err != nil {
+				t.Fatalf("failed to parse export line %q: %v", line, err)
+			}
+			issues = append(issues, &issue)
+		}
+		return issues
+	}
+
+	now := time.Now().Add(-time.Hour)
+	source := newTestStore(t)
+	issues := []*types.Issue{
+		{ID: "test-1", Title: "Breach", Description: "customer list in logs", Status: types.StatusOpen, Priority: 0,
+			IssueType: types.TypeBug, Assignee: "alice@example.com", Labels: []string{"security"}, CreatedAt: now, UpdatedAt: now},
+		{ID: "test-2", Title: "Rotate keys", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask,
+			Assignee: "bob@example.com", CreatedAt: now, UpdatedAt: now,
+			Dependencies: []*types.Dependency{{IssueID: "test-2", DependsOnID: "test-1", Type: types.DepBlocks}}},
+	}
+	if _, err := ImportIssues(ctx, "", source, issues, Options{Strict: true}); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if err := source.AddComment(ctx, "test-1", "alice@example.com", "the bucket is s3://secret"); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+
+	redact := []string{"assignee", "description", "comments"}
+	var buf strings.Builder
+	if err := source.StreamExportRedacted(ctx, &buf, types.IssueFilter{}, redact); err != nil {
+		t.Fatalf("StreamExportRedacted failed: %v", err)
+	}
+	for _, secret := range []string{"alice", "bob", "customer list", "s3://secret"} {
+		if strings.Contains(buf.String(), secret) {
+			t.Errorf("redacted export leaks %q:\n%s", secret, buf.String())
+		}
+	}
+	if err := source.StreamExportRedacted(ctx, &buf, types.IssueFilter{}, []string{"id"}); err == nil {
+		t.Error("expected redacting the ID to be rejected")
+	}
+
+	// The redacted file imports cleanly, keeps its structure and hashes its
+	// own content
+	target := newTestStore(t)
+	redacted := parse(buf.String())
+	if _, err := ImportIssues(ctx, "", target, redacted, Options{Strict: true}); err != nil {
+		t.Fatalf("Import of redacted export failed: %v", err)
+	}
+	got, err := target.GetIssue(ctx, "test-1")
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Assignee != "" || got.Description != "" || got.ContentHash != got.ComputeContentHash() {
+		t.Errorf("expected redacted fields blank with a self-consistent hash, got %+v", got)
+	}
+	if labels, _ := target.GetLabels(ctx, "test-1"); len(labels) != 1 {
+		t.Errorf("expected labels kept, got %v", labels)
+	}
+	if deps, _ := target.GetDependencies(ctx, "test-2"); len(deps) != 1 || deps[0].ID != "test-1" {
+		t.Errorf("expected the dependency kept, got %v", deps)
+	}
+	result, err := ImportIssues(ctx, "", target, parse(buf.String()), Options{Strict: true})
+	if err != nil || result.Unchanged != 2 {
+		t.Errorf("expected re-importing the redacted export to be a no-op, got %+v, %v", result, err)
+	}
+
+	// Redacting on import yields the same content as importing a redacted export
+	var full strings.Builder
+	if err := source.StreamExport(ctx, &full, types.IssueFilter{}); err != nil {
+		t.Fatalf("StreamExport failed: %v", err)
+	}
+	other := newTestStore(t)
+	if _, err := ImportIssues(ctx, "", other, parse(full.String()), Options{Strict: true, Redact: redact}); err != nil {
+		t.Fatalf("Import with Redact failed: %v", err)
+	}
+	a, _ := target.GetIssue(ctx, "test-2")
+	b, _ := other.GetIssue(ctx, "test-2")
+	if a.ContentHash != b.ContentHash || b.Assignee != "" {
+		t.Errorf("expected import-side redaction to match the redacted export, got %s and %s", a.ContentHash, b.ContentHash)
+	}
+	if _, err := ImportIssues(ctx, "", other, nil, Options{Redact: []string{"title"}}); err == nil {
+		t.Error("expected redacting the title on import to be rejected")
+	}
+}
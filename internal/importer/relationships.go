@@ -0,0 +1,93 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// relationshipEdges validates opts.Relationships and groups them by source
+// issue as dependency edges ready for importDependenciesTx. Every relationship
+// must have a registered type (types.RelationshipTypes or
+// opts.RelationshipTypes); an unregistered type fails the import.
+//
+// Endpoints are checked with getIssue, after the imported issues have been
+// written. A relationship with a missing endpoint fails the import under
+// OrphanStrict or Strict and is otherwise skipped and reported in
+// result.SkippedDependencies. ToID may be an "external:" reference.
+func relationshipEdges(ctx context.Context, getIssue func(context.Context, string) (*types.Issue, error), opts Options, result *Result) ([]*types.Issue, error) {
+	if len(opts.Relationships) == 0 {
+		return nil, nil
+	}
+
+	exists := make(map[string]bool)
+	present := func(id string) (bool, error) {
+		if ok, seen := exists[id]; seen {
+			return ok, nil
+		}
+		issue, err := getIssue(ctx, id)
+		if err != nil {
+			return false, fmt.Errorf("failed to check relationship endpoint %s: %w", id, err)
+		}
+		exists[id] = issue != nil
+		return issue != nil, nil
+	}
+
+	var order []string
+	byFrom := make(map[string]*types.Issue)
+	for _, rel := range opts.Relationships {
+		if err := rel.Validate(opts.RelationshipTypes...); err != nil {
+			return nil, err
+		}
+		// Follow issues renamed by this import
+		r := *rel
+		if newID, ok := result.IDMapping[r.FromID]; ok {
+			r.FromID = newID
+		}
+		if newID, ok := result.IDMapping[r.ToID]; ok {
+			r.ToID = newID
+		}
+		rel := &r
+
+		var missing string
+		for _, id := range []string{rel.FromID, rel.ToID} {
+			if id == rel.ToID && strings.HasPrefix(id, "external:") {
+				continue
+			}
+			ok, err := present(id)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				missing = id
+				break
+			}
+		}
+		if missing != "" {
+			desc := fmt.Sprintf("%s → %s (%s)", rel.FromID, rel.ToID, rel.Type)
+			if opts.Strict || opts.OrphanHandling == OrphanStrict {
+				return nil, &ForeignKeyError{IssueID: rel.FromID, Field: "relationships", MissingID: missing, DepType: rel.Type}
+			}
+			fmt.Fprintf(os.Stderr, "Warning: Skipping relationship with missing issue %s: %s\n", missing, desc)
+			result.SkippedDependencies = append(result.SkippedDependencies, desc)
+			continue
+		}
+
+		edges, ok := byFrom[rel.FromID]
+		if !ok {
+			edges = &types.Issue{ID: rel.FromID}
+			byFrom[rel.FromID] = edges
+			order = append(order, rel.FromID)
+		}
+		edges.Dependencies = append(edges.Dependencies, rel.Dependency())
+	}
+
+	grouped := make([]*types.Issue, 0, len(order))
+	for _, id := range order {
+		grouped = append(grouped, byFrom[id])
+	}
+	return grouped, nil
+}
@@ -0,0 +1,107 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestImportIssues_Relationships(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	var issues []*types.Issue
+	for _, id := range []string{"test-api", "test-db", "test-dup", "test-orig"} {
+		issues = append(issues, &types.Issue{ID: id, Title: "Issue " + id, Status: types.StatusOpen,
+			Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now})
+	}
+	relationships := []*types.Relationship{
+		{FromID: "test-api", ToID: "test-db", Type: types.DepBlocks},
+		{FromID: "test-dup", ToID: "test-orig", Type: types.DepDuplicates},
+		{FromID: "test-api", ToID: "test-gone", Type: types.DepRelatesTo},
+	}
+
+	source := newTestStore(t)
+	result, err := ImportIssues(ctx, "", source, issues, Options{Relationships: relationships})
+	if err != nil {
+		t.Fatalf("ImportIssues failed: %v", err)
+	}
+	if len(result.SkippedDependencies) != 1 {
+		t.Errorf("expected the relationship to a missing issue skipped, got %v", result.SkippedDependencies)
+	}
+
+	assertEdge := func(store *sqlite.SQLiteStorage, from, to string, depType types.DependencyType) {
+		t.Helper()
+		deps, err := store.GetDependencyRecords(ctx, from)
+		if err != nil {
+			t.Fatalf("GetDependencyRecords failed: %v", err)
+		}
+		for _, dep := range deps {
+			if dep.DependsOnID == to && dep.Type == depType {
+				return
+			}
+		}
+		t.Errorf("expected %s %s %s, got %d edges", from, depType, to, len(deps))
+	}
+	assertEdge(source, "test-api", "test-db", types.DepBlocks)
+	assertEdge(source, "test-dup", "test-orig", types.DepDuplicates)
+
+	// Round-trip through export into a fresh database
+	var buf bytes.Buffer
+	if err := source.StreamExport(ctx, &buf, types.IssueFilter{}); err != nil {
+		t.Fatalf("StreamExport failed: %v", err)
+	}
+	var exported []*types.Issue
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var issue types.Issue
+		if err := json.Unmarshal(scanner.Bytes(), &issue); err != nil {
+			t.Fatalf("failed to decode export line: %v", err)
+		}
+		if issue.ID != "" { // skip the summary line
+			exported = append(exported, &issue)
+		}
+	}
+	target := newTestStore(t)
+	if _, err := ImportIssues(ctx, "", target, exported, Options{}); err != nil {
+		t.Fatalf("re-import failed: %v", err)
+	}
+	assertEdge(target, "test-api", "test-db", types.DepBlocks)
+	assertEdge(target, "test-dup", "test-orig", types.DepDuplicates)
+}
+
+func TestImportIssues_RelationshipValidation(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	now := time.Now()
+	issues := func() []*types.Issue {
+		return []*types.Issue{
+			{ID: "test-a", Title: "A", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now},
+			{ID: "test-b", Title: "B", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now},
+		}
+	}
+
+	custom := []*types.Relationship{{FromID: "test-a", ToID: "test-b", Type: "mentors"}}
+	if _, err := ImportIssues(ctx, "", store, issues(), Options{Relationships: custom}); err == nil {
+		t.Error("expected unregistered relationship type to fail the import")
+	}
+	if issue, _ := store.GetIssue(ctx, "test-a"); issue != nil {
+		t.Error("expected the failed import rolled back")
+	}
+	if _, err := ImportIssues(ctx, "", store, issues(), Options{Relationships: custom, RelationshipTypes: []types.DependencyType{"mentors"}}); err != nil {
+		t.Fatalf("expected registered custom type to import, got %v", err)
+	}
+
+	orphan := []*types.Relationship{{FromID: "test-a", ToID: "test-gone", Type: types.DepBlocks}}
+	_, err := ImportIssues(ctx, "", store, issues(), Options{Relationships: orphan, OrphanHandling: OrphanStrict})
+	var fkErr *ForeignKeyError
+	if !errors.As(err, &fkErr) || fkErr.MissingID != "test-gone" {
+		t.Errorf("expected ForeignKeyError for test-gone under OrphanStrict, got %v", err)
+	}
+}
@@ -0,0 +1,81 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// replaceReason is recorded on issues tombstoned by ReplaceAllImport.
+const replaceReason = "removed by replace import"
+
+// ReplaceAllImport makes the database mirror issues: within one transaction it
+// imports issues as ImportIssuesTx does, then tombstones (or, with
+// opts.ReplaceHardDelete, deletes) every live issue the import did not carry.
+// Readers see either the old contents or the new ones, never a mix, and a
+// failure anywhere leaves the database untouched. Removed issues are counted
+// in Result.Deleted.
+//
+// Config, including issue_prefix, is kept. Tombstoned parents keep their
+// child counters, so child IDs are not reused; hard-deleted ones lose them
+// along with the issue. Tombstones already present are left as they are.
+func ReplaceAllImport(ctx context.Context, store storage.Storage, issues []*types.Issue, actor string, opts Options) (*Result, error) {
+	if store == nil {
+		return nil, fmt.Errorf("import requires an initialized storage backend")
+	}
+	if err := checkWritable(ctx, store, opts); err != nil {
+		return nil, err
+	}
+
+	var result *Result
+	err := store.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		existing, err := tx.SearchIssues(ctx, "", types.IssueFilter{})
+		if err != nil {
+			return fmt.Errorf("failed to list existing issues: %w", err)
+		}
+
+		// The hooks run once the removals below are done too
+		importOpts := opts
+		importOpts.OnCommit = nil
+		importOpts.PostImportAssert = nil
+		r, err := ImportIssuesTx(ctx, store, tx, issues, importOpts)
+		if err != nil {
+			return err
+		}
+
+		keep := make(map[string]bool, len(issues))
+		for _, issue := range issues {
+			keep[issue.ID] = true
+		}
+		for oldID, newID := range r.IDMapping {
+			keep[oldID] = true
+			keep[newID] = true
+		}
+
+		tombstoner, canTombstone := tx.(interface {
+			CreateTombstone(ctx context.Context, id string, actor string, reason string) error
+		})
+		for _, issue := range existing {
+			if keep[issue.ID] {
+				continue
+			}
+			if opts.ReplaceHardDelete || !canTombstone {
+				err = tx.DeleteIssue(ctx, issue.ID)
+			} else {
+				err = tombstoner.CreateTombstone(ctx, issue.ID, actor, replaceReason)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to remove %s: %w", issue.ID, err)
+			}
+			r.Deleted++
+		}
+		result = r
+		return runTxHooks(ctx, tx, opts, r)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
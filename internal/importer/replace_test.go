@@ -0,0 +1,91 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestReplaceAllImport(t *testing.T) {
+	ctx := context.Background()
+	newPopulated := func(t *testing.T) *sqlite.SQLiteStorage {
+		store := newTestStore(t)
+		for i := 1; i <= 5; i++ {
+			issue := &types.Issue{ID: fmt.Sprintf("test-%d", i), Title: fmt.Sprintf("Local %d", i), Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+			if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+				t.Fatalf("CreateIssue failed: %v", err)
+			}
+		}
+		return store
+	}
+	upstream := func() []*types.Issue {
+		now := time.Now()
+		return []*types.Issue{
+			{ID: "test-2", Title: "Upstream 2", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now},
+			{ID: "test-9", Title: "Upstream 9", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeBug, CreatedAt: now, UpdatedAt: now},
+		}
+	}
+
+	t.Run("tombstones missing issues", func(t *testing.T) {
+		store := newPopulated(t)
+		result, err := ReplaceAllImport(ctx, store, upstream(), "mirror", Options{})
+		if err != nil {
+			t.Fatalf("ReplaceAllImport failed: %v", err)
+		}
+		if result.Created != 1 || result.Updated != 1 || result.Deleted != 4 {
+			t.Errorf("expected 1 created, 1 updated, 4 removed; got %+v", result)
+		}
+
+		live, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+		if err != nil {
+			t.Fatalf("SearchIssues failed: %v", err)
+		}
+		if len(live) != 2 {
+			t.Errorf("expected 2 live issues, got %d", len(live))
+		}
+		if updated, _ := store.GetIssue(ctx, "test-2"); updated == nil || updated.Title != "Upstream 2" {
+			t.Errorf("expected test-2 replaced by upstream, got %+v", updated)
+		}
+		gone, _ := store.GetIssue(ctx, "test-1")
+		if gone == nil || gone.Status != types.StatusTombstone || gone.DeletedBy != "mirror" {
+			t.Errorf("expected test-1 tombstoned by mirror, got %+v", gone)
+		}
+		if prefix, _ := store.GetConfig(ctx, "issue_prefix"); prefix != "test" {
+			t.Errorf("expected issue_prefix kept, got %q", prefix)
+		}
+	})
+
+	t.Run("hard delete", func(t *testing.T) {
+		store := newPopulated(t)
+		if _, err := ReplaceAllImport(ctx, store, upstream(), "mirror", Options{ReplaceHardDelete: true}); err != nil {
+			t.Fatalf("ReplaceAllImport failed: %v", err)
+		}
+		for _, id := range []string{"test-1", "test-3", "test-4", "test-5"} {
+			if issue, _ := store.GetIssue(ctx, id); issue != nil {
+				t.Errorf("expected %s deleted, got status %s", id, issue.Status)
+			}
+		}
+	})
+
+	t.Run("failure leaves contents untouched", func(t *testing.T) {
+		store := newPopulated(t)
+		bad := append(upstream(), &types.Issue{ID: "test-10", Title: "", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask})
+		if _, err := ReplaceAllImport(ctx, store, bad, "mirror", Options{}); err == nil {
+			t.Fatal("expected invalid issue to fail the replace")
+		}
+		live, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+		if err != nil {
+			t.Fatalf("SearchIssues failed: %v", err)
+		}
+		if len(live) != 5 {
+			t.Errorf("expected the original 5 issues, got %d", len(live))
+		}
+		if issue, _ := store.GetIssue(ctx, "test-9"); issue != nil {
+			t.Error("expected test-9 not imported")
+		}
+	})
+}
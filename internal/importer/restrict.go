@@ -0,0 +1,63 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// ErrPrefixRestricted is wrapped by the PrefixError returned when an import
+// under Options.RestrictToPrefix would create, modify or delete an issue
+// outside that prefix.
+var ErrPrefixRestricted = errors.New("issue outside the restricted prefix")
+
+// applyPrefixRestriction enforces opts.RestrictToPrefix on the resolved IDs
+// (after any rename) of issues and on opts.DeletionIDs, and returns opts set up
+// for checkRestrictedID, which the upsert also applies to existing issues, since a match
+// by external_ref or content can update an issue with another ID. Unlike
+// prefix validation this is never relaxed by SkipPrefixValidation or
+// multi-repo mode.
+func applyPrefixRestriction(ctx context.Context, cfg configStore, issues []*types.Issue, opts Options, result *Result) (Options, error) {
+	if opts.RestrictToPrefix == "" {
+		return opts, nil
+	}
+	sep, _ := cfg.GetConfig(ctx, storage.IDSeparatorConfigKey)
+	if sep == "" {
+		sep = utils.DefaultIDSeparator
+	}
+	opts.restrictedSep = sep
+
+	for _, issue := range issues {
+		if err := checkRestrictedID(opts, issue.ID); err != nil {
+			if err := rejectIssue(ctx, issue, err, opts, result); err != nil {
+				return opts, err
+			}
+		}
+	}
+	for _, id := range opts.DeletionIDs {
+		if err := checkRestrictedID(opts, id); err != nil {
+			return opts, err
+		}
+	}
+	return opts, nil
+}
+
+// checkRestrictedID returns a PrefixError if id falls outside the prefix set
+// up by applyPrefixRestriction.
+func checkRestrictedID(opts Options, id string) error {
+	prefix := strings.TrimSuffix(opts.RestrictToPrefix, opts.restrictedSep)
+	if opts.restrictedSep == "" || strings.HasPrefix(id, prefix+opts.restrictedSep) {
+		return nil
+	}
+	return &PrefixError{
+		IssueID:  id,
+		Prefix:   utils.ExtractIssuePrefixWithSeparator(id, opts.restrictedSep),
+		Expected: prefix,
+		Err:      fmt.Errorf("%w %s", ErrPrefixRestricted, prefix),
+	}
+}
@@ -0,0 +1,78 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestImportIssues_RestrictToPrefix(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	newIssue := func(id string) *types.Issue {
+		return &types.Issue{ID: id, Title: "Issue " + id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now}
+	}
+	newStore := func() *sqlite.SQLiteStorage {
+		store := newTestStore(t)
+		if err := store.SetConfig(ctx, "issue_prefix", "alpha"); err != nil {
+			t.Fatalf("Failed to set prefix: %v", err)
+		}
+		return store
+	}
+
+	t.Run("mistyped prefix is rejected", func(t *testing.T) {
+		store := newStore()
+		// Prefix validation is off, as for multi-repo imports, so only the
+		// restriction stands between the typo and the database
+		issues := []*types.Issue{newIssue("alpha-1"), newIssue("alhpa-2")}
+		_, err := ImportIssues(ctx, "", store, issues, Options{RestrictToPrefix: "alpha", SkipPrefixValidation: true})
+		if !errors.Is(err, ErrPrefixRestricted) {
+			t.Fatalf("expected ErrPrefixRestricted, got %v", err)
+		}
+		var prefixErr *PrefixError
+		if !errors.As(err, &prefixErr) || prefixErr.IssueID != "alhpa-2" || prefixErr.Expected != "alpha" {
+			t.Errorf("expected PrefixError naming alhpa-2, got %v", err)
+		}
+		if got, _ := store.GetIssue(ctx, "alpha-1"); got != nil {
+			t.Error("expected nothing to be imported")
+		}
+
+		result, err := ImportIssues(ctx, "", store, issues[:1], Options{RestrictToPrefix: "alpha", SkipPrefixValidation: true})
+		if err != nil || result.Created != 1 {
+			t.Fatalf("expected in-prefix import to succeed, got %+v, %v", result, err)
+		}
+	})
+
+	t.Run("external_ref match into another prefix is rejected", func(t *testing.T) {
+		store := newStore()
+		ref := "gh-5"
+		other := newIssue("beta-1")
+		other.ExternalRef = &ref
+		if _, err := ImportIssues(ctx, "", store, []*types.Issue{other}, Options{SkipPrefixValidation: true}); err != nil {
+			t.Fatalf("seed import failed: %v", err)
+		}
+
+		incoming := newIssue("alpha-9")
+		incoming.ExternalRef = &ref
+		incoming.Title = "Retitled"
+		incoming.UpdatedAt = now.Add(time.Minute)
+		if _, err := ImportIssues(ctx, "", store, []*types.Issue{incoming}, Options{RestrictToPrefix: "alpha", SkipPrefixValidation: true}); !errors.Is(err, ErrPrefixRestricted) {
+			t.Fatalf("expected ErrPrefixRestricted, got %v", err)
+		}
+		if got, _ := store.GetIssue(ctx, "beta-1"); got == nil || got.Title != other.Title {
+			t.Errorf("expected beta-1 untouched, got %+v", got)
+		}
+	})
+
+	t.Run("deletions outside the prefix are rejected", func(t *testing.T) {
+		store := newStore()
+		_, err := ImportIssues(ctx, "", store, []*types.Issue{newIssue("alpha-1")}, Options{RestrictToPrefix: "alpha", DeletionIDs: []string{"beta-7"}})
+		if !errors.Is(err, ErrPrefixRestricted) {
+			t.Fatalf("expected ErrPrefixRestricted, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,97 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestImportIssues_MaxResurrectDepth(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	newIssue := func(id string) *types.Issue {
+		return &types.Issue{ID: id, Title: "Issue " + id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now}
+	}
+	newStore := func() *sqlite.SQLiteStorage {
+		t.Helper()
+		store := newTestStore(t)
+		dir := filepath.Dir(store.Path())
+		// Deleted ancestors, still in the local JSONL history
+		f, err := os.Create(filepath.Join(dir, "issues.jsonl"))
+		if err != nil {
+			t.Fatalf("Failed to create JSONL: %v", err)
+		}
+		defer f.Close()
+		for _, id := range []string{"test-1", "test-1.1", "test-1.1.1", "test-2"} {
+			if err := json.NewEncoder(f).Encode(newIssue(id)); err != nil {
+				t.Fatalf("Failed to write JSONL: %v", err)
+			}
+		}
+		return store
+	}
+	// test-1.1.1.1 is missing three levels of ancestors; test-2.1 just one
+	deep := func() []*types.Issue {
+		return []*types.Issue{newIssue("test-1.1.1.1"), newIssue("test-1.1.1.1.1"), newIssue("test-2.1")}
+	}
+
+	t.Run("chain beyond the limit fails the import", func(t *testing.T) {
+		store := newStore()
+		opts := Options{OrphanHandling: OrphanResurrect, MaxResurrectDepth: 1}
+		_, err := ImportIssues(ctx, "", store, deep(), opts)
+		var orphanErr *OrphanError
+		if !errors.As(err, &orphanErr) || orphanErr.IssueID != "test-1.1.1.1" {
+			t.Fatalf("expected an OrphanError for test-1.1.1.1, got %v", err)
+		}
+		for _, id := range []string{"test-1", "test-2", "test-2.1"} {
+			if got, err := store.GetIssue(ctx, id); err != nil || got != nil {
+				t.Errorf("%s should not exist after the failed import: %v, %v", id, got, err)
+			}
+		}
+	})
+
+	t.Run("skip fallback resurrects only within the limit", func(t *testing.T) {
+		store := newStore()
+		opts := Options{OrphanHandling: OrphanResurrect, MaxResurrectDepth: 1, ResurrectFallback: OrphanSkip}
+		result, err := ImportIssues(ctx, "", store, deep(), opts)
+		if err != nil {
+			t.Fatalf("ImportIssues failed: %v", err)
+		}
+		if want := []string{"test-2"}; !reflect.DeepEqual(result.Resurrected, want) {
+			t.Errorf("Resurrected = %v, want %v", result.Resurrected, want)
+		}
+		if result.Created != 2 || result.Skipped != 2 {
+			t.Errorf("Created = %d, Skipped = %d, want 2 and 2 (the child and its descendant)", result.Created, result.Skipped)
+		}
+		parent, err := store.GetIssue(ctx, "test-2")
+		if err != nil || parent == nil {
+			t.Fatalf("test-2 should have been resurrected: %v, %v", parent, err)
+		}
+		if parent.Status != types.StatusClosed {
+			t.Errorf("resurrected parent status = %s, want closed", parent.Status)
+		}
+		for _, id := range []string{"test-1", "test-1.1", "test-1.1.1", "test-1.1.1.1", "test-1.1.1.1.1"} {
+			if got, err := store.GetIssue(ctx, id); err != nil || got != nil {
+				t.Errorf("%s should not have been created: %v, %v", id, got, err)
+			}
+		}
+	})
+
+	t.Run("no limit resurrects the whole chain", func(t *testing.T) {
+		store := newStore()
+		result, err := ImportIssues(ctx, "", store, deep(), Options{OrphanHandling: OrphanResurrect})
+		if err != nil {
+			t.Fatalf("ImportIssues failed: %v", err)
+		}
+		if want := []string{"test-2", "test-1", "test-1.1", "test-1.1.1"}; !reflect.DeepEqual(result.Resurrected, want) {
+			t.Errorf("Resurrected = %v, want %v", result.Resurrected, want)
+		}
+	})
+}
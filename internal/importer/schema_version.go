@@ -0,0 +1,41 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// ErrSchemaTooNew is returned (wrapped) when the database was migrated by a
+// newer build than this one, whose tables may hold columns an import would
+// not know to preserve.
+var ErrSchemaTooNew = errors.New("database schema is newer than this build")
+
+// schemaVersioner is implemented by backends that record the schema version
+// they were migrated to (see storage.SchemaVersionConfigKey) and know the
+// newest version this build migrates to.
+type schemaVersioner interface {
+	SchemaVersion(ctx context.Context) (int, error)
+	SupportedSchemaVersion() int
+}
+
+// checkSchemaVersion refuses to import into a database whose recorded schema
+// version is newer than this build migrates to. Backends without a version are
+// not checked.
+func checkSchemaVersion(ctx context.Context, store storage.Storage) error {
+	versioner, ok := store.(schemaVersioner)
+	if !ok {
+		return nil
+	}
+	version, err := versioner.SchemaVersion(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+	if current := versioner.SupportedSchemaVersion(); version > current {
+		return fmt.Errorf("%w: database is at schema version %d, this build supports up to %d; upgrade bd before importing",
+			ErrSchemaTooNew, version, current)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestImportIssues_RefusesNewerSchema(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	now := time.Now()
+	issues := func() []*types.Issue {
+		return []*types.Issue{{ID: "test-1", Title: "Issue", Status: types.StatusOpen, Priority: 2,
+			IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now}}
+	}
+
+	if _, err := ImportIssues(ctx, "", store, issues(), Options{}); err != nil {
+		t.Fatalf("Import at the current schema version failed: %v", err)
+	}
+
+	newer := strconv.Itoa(sqlite.CurrentSchemaVersion() + 1)
+	if err := store.SetConfig(ctx, storage.SchemaVersionConfigKey, newer); err != nil {
+		t.Fatalf("SetConfig(%s) failed: %v", storage.SchemaVersionConfigKey, err)
+	}
+	if _, err := ImportIssues(ctx, "", store, issues(), Options{}); !errors.Is(err, ErrSchemaTooNew) {
+		t.Errorf("expected ErrSchemaTooNew, got %v", err)
+	}
+}
@@ -0,0 +1,60 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// SelfParentPolicy decides what an import does with an issue that lists
+// itself as its parent (a parent-child dependency on its own ID).
+type SelfParentPolicy string
+
+const (
+	SelfParentError SelfParentPolicy = "error" // Fail the import with ErrSelfParent (default)
+	SelfParentDrop  SelfParentPolicy = "drop"  // Drop the self-referencing dependency and record the issue in Result.SelfParents
+)
+
+// ErrSelfParent is matched (via errors.Is) by the ValidationError returned
+// for a self-parented issue under SelfParentError.
+var ErrSelfParent = errors.New("issue is its own parent")
+
+// applySelfParentPolicy finds issues whose dependencies make them their own
+// parent. This single-node cycle is a common data-entry mistake, so it is
+// caught up front with an error naming the issue instead of surfacing later
+// as a failed dependency insert. Under Options.ContinueOnError a self-parented
+// issue that SelfParentError would fail on is rejected instead.
+func applySelfParentPolicy(ctx context.Context, issues []*types.Issue, opts Options, result *Result) error {
+	policy := opts.SelfParents
+	switch policy {
+	case "", SelfParentError, SelfParentDrop:
+	default:
+		return fmt.Errorf("unknown self-parent policy %q (want error or drop)", policy)
+	}
+
+	for _, issue := range issues {
+		var kept []*types.Dependency
+		found := false
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.Type == types.DepParentChild && dep.DependsOnID == issue.ID {
+				found = true
+				continue
+			}
+			kept = append(kept, dep)
+		}
+		if !found {
+			continue
+		}
+		if policy != SelfParentDrop {
+			if err := rejectIssue(ctx, issue, &ValidationError{IssueID: issue.ID, Err: ErrSelfParent}, opts, result); err != nil {
+				return err
+			}
+			continue
+		}
+		issue.Dependencies = kept
+		result.SelfParents = append(result.SelfParents, issue.ID)
+	}
+	return nil
+}
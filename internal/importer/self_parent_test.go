@@ -0,0 +1,63 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestImportIssues_SelfParent(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	newIssues := func() []*types.Issue {
+		return []*types.Issue{
+			{ID: "test-1", Title: "Parent", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeEpic, CreatedAt: now, UpdatedAt: now},
+			{ID: "test-2", Title: "Own parent", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now,
+				Dependencies: []*types.Dependency{
+					{IssueID: "test-2", DependsOnID: "test-2", Type: types.DepParentChild},
+					{IssueID: "test-2", DependsOnID: "test-1", Type: types.DepBlocks},
+				}},
+		}
+	}
+	t.Run("error", func(t *testing.T) {
+		store := newTestStore(t)
+		_, err := ImportIssues(ctx, "", store, newIssues(), Options{})
+		if !errors.Is(err, ErrSelfParent) {
+			t.Fatalf("expected ErrSelfParent, got %v", err)
+		}
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) || validationErr.IssueID != "test-2" {
+			t.Errorf("expected error naming test-2, got %v", err)
+		}
+		if got, _ := store.GetIssue(ctx, "test-1"); got != nil {
+			t.Error("expected nothing to be imported")
+		}
+	})
+
+	t.Run("drop", func(t *testing.T) {
+		store := newTestStore(t)
+		result, err := ImportIssues(ctx, "", store, newIssues(), Options{SelfParents: SelfParentDrop})
+		if err != nil {
+			t.Fatalf("ImportIssues failed: %v", err)
+		}
+		if result.Created != 2 || len(result.SelfParents) != 1 || result.SelfParents[0] != "test-2" {
+			t.Errorf("unexpected result %+v", result)
+		}
+		deps, err := store.GetDependencyRecords(ctx, "test-2")
+		if err != nil {
+			t.Fatalf("GetDependencyRecords failed: %v", err)
+		}
+		if len(deps) != 1 || deps[0].DependsOnID != "test-1" {
+			t.Errorf("expected only the blocks dependency to remain, got %+v", deps)
+		}
+	})
+
+	t.Run("unknown policy", func(t *testing.T) {
+		if _, err := ImportIssues(ctx, "", newTestStore(t), newIssues(), Options{SelfParents: "bogus"}); err == nil {
+			t.Error("expected unknown policy to be rejected")
+		}
+	})
+}
@@ -0,0 +1,107 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ShadowImport works out what ImportIssues would do with issues and appends
+// the plan to the store's shadow import log (storage.ShadowLog) as one run
+// for source opts.ProvenanceSource, without changing any live issue. Unlike
+// DryRun the plan is kept, so a new sync source can be reviewed over many
+// runs before it is trusted.
+//
+// Issues go through the same preparation as a real import (timestamp, expiry
+// and type policies, content hashing) and are matched to live issues by ID;
+// prefix renames are not planned. Result counts what would have happened,
+// streaming each outcome to opts.ImportEvents as a real import does, and
+// Result.ShadowRun is the recorded run.
+func ShadowImport(ctx context.Context, store storage.Storage, issues []*types.Issue, opts Options) (*Result, error) {
+	if store == nil {
+		return nil, fmt.Errorf("import requires an initialized storage backend")
+	}
+	log, ok := store.(storage.ShadowLog)
+	if !ok {
+		return nil, fmt.Errorf("shadow import is not supported by this storage backend")
+	}
+	if err := validateUpdateFields(opts.UpdateFields); err != nil {
+		return nil, err
+	}
+	if err := types.ValidateRedactFields(opts.Redact); err != nil {
+		return nil, err
+	}
+
+	result := &Result{
+		IDMapping:        make(map[string]string),
+		MismatchPrefixes: make(map[string]int),
+		events:           opts.ImportEvents,
+	}
+	if err := applyFutureTimestampPolicy(issues, opts.FutureTimestamps, time.Now(), result); err != nil {
+		return nil, err
+	}
+	if err := applyExpiredImportPolicy(issues, opts.ExpiredOnImport, time.Now()); err != nil {
+		return nil, err
+	}
+	issues, err := applyTypeAllowList(issues, opts, result)
+	if err != nil {
+		return nil, err
+	}
+	if opts.hashOptions, err = loadHashOptions(ctx, store); err != nil {
+		return nil, err
+	}
+	prepareIssues(issues, opts)
+
+	ops := make([]*types.ShadowOperation, 0, len(issues)+len(opts.DeletionIDs))
+	for _, id := range opts.DeletionIDs {
+		existing, err := store.GetIssue(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up %s: %w", id, err)
+		}
+		if existing != nil {
+			ops = append(ops, &types.ShadowOperation{IssueID: id, Operation: types.ShadowDelete, OldHash: existing.ContentHash})
+			result.Deleted++
+		}
+	}
+	for _, issue := range issues {
+		existing, err := store.GetIssue(ctx, issue.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up %s: %w", issue.ID, err)
+		}
+		op := &types.ShadowOperation{IssueID: issue.ID, NewHash: issue.ContentHash, Issue: issue}
+		switch {
+		case existing == nil:
+			op.Operation = types.ShadowCreate
+			result.note(ImportEventCreated, issue.ID)
+		case existing.ContentHash == issue.ContentHash && sameDisplayMetadata(existing, issue):
+			op.Operation = types.ShadowUnchanged
+			result.note(ImportEventUnchanged, issue.ID)
+		case opts.SkipUpdate:
+			op.Operation, op.Reason = types.ShadowSkip, "updates disabled (SkipUpdate)"
+			result.note(ImportEventSkipped, issue.ID)
+		case shouldProtectFromUpdate(existing.ID, issue.UpdatedAt, opts.ProtectLocalExportIDs):
+			op.Operation, op.Reason = types.ShadowSkip, "protected by a newer local export"
+			result.note(ImportEventSkipped, issue.ID)
+		case !issue.UpdatedAt.After(existing.UpdatedAt):
+			op.Operation, op.Reason = types.ShadowSkip, "live issue is as new or newer"
+			result.note(ImportEventUnchanged, issue.ID)
+		default:
+			op.Operation = types.ShadowUpdate
+			result.note(ImportEventUpdated, issue.ID)
+		}
+		if existing != nil {
+			op.OldHash = existing.ContentHash
+		}
+		ops = append(ops, op)
+	}
+
+	run, err := log.RecordShadowRun(ctx, opts.ProvenanceSource, ops)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record shadow import: %w", err)
+	}
+	result.ShadowRun = run
+	return result, nil
+}
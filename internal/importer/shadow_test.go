@@ -0,0 +1,91 @@
+package importer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestShadowImport(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	live := &types.Issue{ID: "test-1", Title: "Live", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, live, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	later := time.Now().Add(time.Minute)
+	newIssue := func(id, title string) *types.Issue {
+		return &types.Issue{ID: id, Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask,
+			CreatedAt: later, UpdatedAt: later}
+	}
+
+	events := make(chan ImportEvent, 2)
+	first, err := ShadowImport(ctx, store, []*types.Issue{newIssue("test-1", "From source"), newIssue("test-2", "New")},
+		Options{ProvenanceSource: "jira", ImportEvents: events})
+	if err != nil {
+		t.Fatalf("ShadowImport failed: %v", err)
+	}
+	if first.Created != 1 || first.Updated != 1 || first.ShadowRun != 1 {
+		t.Errorf("expected run 1 with 1 create and 1 update, got %+v", first)
+	}
+	close(events)
+	var streamed []ImportEvent
+	for event := range events {
+		streamed = append(streamed, event)
+	}
+	if len(streamed) != 2 || streamed[0] != (ImportEvent{Kind: ImportEventUpdated, IssueID: "test-1"}) ||
+		streamed[1] != (ImportEvent{Kind: ImportEventCreated, IssueID: "test-2"}) {
+		t.Errorf("expected the planned outcomes streamed in order, got %+v", streamed)
+	}
+	second, err := ShadowImport(ctx, store, []*types.Issue{newIssue("test-3", "Another")},
+		Options{ProvenanceSource: "jira", DeletionIDs: []string{"test-1", "test-missing"}})
+	if err != nil {
+		t.Fatalf("ShadowImport failed: %v", err)
+	}
+	if second.Created != 1 || second.Deleted != 1 || second.ShadowRun != 2 {
+		t.Errorf("expected run 2 with 1 create and 1 delete, got %+v", second)
+	}
+
+	if issue, _ := store.GetIssue(ctx, "test-1"); issue == nil || issue.Title != "Live" {
+		t.Errorf("expected live issue untouched, got %+v", issue)
+	}
+	for _, id := range []string{"test-2", "test-3"} {
+		if issue, _ := store.GetIssue(ctx, id); issue != nil {
+			t.Errorf("expected %s not to be created", id)
+		}
+	}
+
+	ops, err := store.GetShadowOperations(ctx, "jira")
+	if err != nil {
+		t.Fatalf("GetShadowOperations failed: %v", err)
+	}
+	want := []struct {
+		run int64
+		id  string
+		op  types.ShadowOp
+	}{
+		{1, "test-1", types.ShadowUpdate},
+		{1, "test-2", types.ShadowCreate},
+		{2, "test-1", types.ShadowDelete},
+		{2, "test-3", types.ShadowCreate},
+	}
+	if len(ops) != len(want) {
+		t.Fatalf("expected %d logged operations, got %d: %+v", len(want), len(ops), ops)
+	}
+	for i, w := range want {
+		if ops[i].Run != w.run || ops[i].IssueID != w.id || ops[i].Operation != w.op {
+			t.Errorf("entry %d: got run %d %s %s, want run %d %s %s", i, ops[i].Run, ops[i].IssueID, ops[i].Operation, w.run, w.id, w.op)
+		}
+	}
+	if ops[0].OldHash != live.ContentHash || ops[0].Issue == nil || ops[0].Issue.Title != "From source" {
+		t.Errorf("expected update entry to carry live hash and incoming issue, got %+v", ops[0])
+	}
+
+	if other, _ := store.GetShadowOperations(ctx, "github"); len(other) != 0 {
+		t.Errorf("expected no entries for another source, got %d", len(other))
+	}
+}
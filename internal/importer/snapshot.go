@@ -0,0 +1,127 @@
+package importer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var (
+	// ErrSnapshotHeaderMissing is returned by ParseSnapshot for an export
+	// without a types.SnapshotHeader unless SnapshotOptions.AllowLegacy is set.
+	ErrSnapshotHeaderMissing = errors.New("snapshot header missing")
+
+	// ErrSnapshotIncompatible is returned (wrapped) for a header this version
+	// cannot import.
+	ErrSnapshotIncompatible = errors.New("incompatible snapshot")
+
+	// ErrSnapshotIncomplete is returned (wrapped) when the records read don't
+	// match the count the export promised.
+	ErrSnapshotIncomplete = errors.New("incomplete snapshot")
+)
+
+// SnapshotOptions controls ParseSnapshot.
+type SnapshotOptions struct {
+	AllowLegacy bool // Accept headerless exports (written before snapshot headers existed)
+	MaxLineSize int  // Longest accepted line (0 uses the default)
+	MaxIssues   int  // When > 0, stop with a TooManyIssuesError at the first issue over this many (also checked against the header's count)
+
+	UnknownFields UnknownFieldsPolicy // Handling of issue fields this version does not know (see DecodeIssue); "" drops them
+}
+
+// ParseSnapshot reads a snapshot export (see sqlite.ExportSnapshot): a
+// types.SnapshotHeader line, issue lines and an optional trailing summary. The
+// header is validated before any record is read, so callers can size the
+// import from header.IssueCount. The issue count is checked against the
+// trailing summary when there is one, or else against the header.
+//
+// A headerless export fails with ErrSnapshotHeaderMissing unless
+// opts.AllowLegacy is set, in which case its issues are returned with a nil
+// header.
+func ParseSnapshot(r io.Reader, opts SnapshotOptions) (*types.SnapshotHeader, []*types.Issue, error) {
+	var header *types.SnapshotHeader
+	var issues []*types.Issue
+	summaryCount := -1
+	first := true
+
+	scanner := utils.NewJSONLScanner(r, opts.MaxLineSize)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var marker struct {
+			Header  bool `json:"_header"`
+			Summary bool `json:"_summary"`
+			Count   int  `json:"count"`
+		}
+		if err := json.Unmarshal(line, &marker); err != nil {
+			return nil, nil, fmt.Errorf("line %d: %w", scanner.Line(), err)
+		}
+
+		if first {
+			first = false
+			if marker.Header {
+				var h types.SnapshotHeader
+				if err := json.Unmarshal(line, &h); err != nil {
+					return nil, nil, fmt.Errorf("line %d: invalid snapshot header: %w", scanner.Line(), err)
+				}
+				if err := validateSnapshotHeader(&h); err != nil {
+					return nil, nil, err
+				}
+				if err := checkMaxIssues(h.IssueCount, opts.MaxIssues); err != nil {
+					return nil, nil, err
+				}
+				header = &h
+				continue
+			}
+			if !opts.AllowLegacy {
+				return nil, nil, ErrSnapshotHeaderMissing
+			}
+		} else if marker.Header {
+			return nil, nil, fmt.Errorf("line %d: unexpected snapshot header", scanner.Line())
+		}
+
+		if marker.Summary {
+			summaryCount = marker.Count
+			continue
+		}
+		issue, err := DecodeIssue(line, opts.UnknownFields)
+		if err != nil {
+			return nil, nil, fmt.Errorf("line %d: %w", scanner.Line(), err)
+		}
+		issue.SetDefaults()
+		issues = append(issues, issue)
+		if err := checkMaxIssues(len(issues), opts.MaxIssues); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+	if header == nil && !opts.AllowLegacy {
+		return nil, nil, ErrSnapshotHeaderMissing
+	}
+
+	switch {
+	case summaryCount >= 0 && summaryCount != len(issues):
+		return nil, nil, fmt.Errorf("%w: summary promises %d issues, read %d", ErrSnapshotIncomplete, summaryCount, len(issues))
+	case summaryCount < 0 && header != nil && header.IssueCount != len(issues):
+		return nil, nil, fmt.Errorf("%w: header promises %d issues, read %d", ErrSnapshotIncomplete, header.IssueCount, len(issues))
+	}
+	return header, issues, nil
+}
+
+func validateSnapshotHeader(h *types.SnapshotHeader) error {
+	if h.SchemaVersion < 1 || h.SchemaVersion > types.SnapshotFormatVersion {
+		return fmt.Errorf("%w: schema version %d (this version reads up to %d)", ErrSnapshotIncompatible, h.SchemaVersion, types.SnapshotFormatVersion)
+	}
+	if h.IssueCount < 0 {
+		return fmt.Errorf("%w: negative issue count %d", ErrSnapshotIncompatible, h.IssueCount)
+	}
+	return nil
+}
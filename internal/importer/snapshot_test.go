@@ -0,0 +1,144 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestParseSnapshot_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	for _, title := range []string{"First", "Second"} {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := store.ExportSnapshot(ctx, &buf, "repo/main"); err != nil {
+		t.Fatalf("ExportSnapshot failed: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), `{"_header":true`) {
+		t.Fatalf("snapshot should start with the header, got %q", strings.SplitN(buf.String(), "\n", 2)[0])
+	}
+
+	header, issues, err := ParseSnapshot(&buf, SnapshotOptions{})
+	if err != nil {
+		t.Fatalf("ParseSnapshot failed: %v", err)
+	}
+	if header.SchemaVersion != types.SnapshotFormatVersion || header.Prefix != "test" || header.Source != "repo/main" {
+		t.Errorf("unexpected header %+v", header)
+	}
+	if header.IssueCount != 2 || len(issues) != 2 {
+		t.Errorf("header count %d, parsed %d issues; want 2", header.IssueCount, len(issues))
+	}
+	if header.ExportedAt.IsZero() {
+		t.Error("header should record the export time")
+	}
+
+	// The snapshot imports like any other export
+	target, err := sqlite.New(ctx, t.TempDir()+"/target.db")
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer target.Close()
+	if err := target.SetConfig(ctx, "issue_prefix", header.Prefix); err != nil {
+		t.Fatalf("Failed to set prefix: %v", err)
+	}
+	result, err := ImportIssues(ctx, "", target, issues, Options{})
+	if err != nil {
+		t.Fatalf("ImportIssues failed: %v", err)
+	}
+	if result.Created != 2 {
+		t.Errorf("expected 2 created, got %d", result.Created)
+	}
+}
+
+func snapshotLines(t *testing.T, records ...interface{}) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, r := range records {
+		if err := enc.Encode(r); err != nil {
+			t.Fatalf("encode: %v", err)
+		}
+	}
+	return &buf
+}
+
+func TestParseSnapshot_HeaderValidation(t *testing.T) {
+	issue := &types.Issue{ID: "test-1", Title: "One", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	header := func(version, count int) types.SnapshotHeader {
+		return types.SnapshotHeader{Header: true, SchemaVersion: version, Prefix: "test", IssueCount: count}
+	}
+
+	tests := []struct {
+		name    string
+		records []interface{}
+		wantErr error
+	}{
+		{"valid", []interface{}{header(1, 1), issue, sqlite.ExportSummary{Summary: true, Count: 1}}, nil},
+		{"no summary uses header count", []interface{}{header(1, 1), issue}, nil},
+		{"newer version", []interface{}{header(types.SnapshotFormatVersion+1, 1), issue}, ErrSnapshotIncompatible},
+		{"zero version", []interface{}{header(0, 1), issue}, ErrSnapshotIncompatible},
+		{"truncated", []interface{}{header(1, 2), issue}, ErrSnapshotIncomplete},
+		{"summary mismatch", []interface{}{header(1, 1), issue, sqlite.ExportSummary{Summary: true, Count: 3}}, ErrSnapshotIncomplete},
+		{"missing header", []interface{}{issue}, ErrSnapshotHeaderMissing},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := ParseSnapshot(snapshotLines(t, tt.records...), SnapshotOptions{})
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	// A second header is never valid
+	if _, _, err := ParseSnapshot(snapshotLines(t, header(1, 1), issue, header(1, 1)), SnapshotOptions{}); err == nil {
+		t.Error("expected error for repeated header")
+	}
+	if _, _, err := ParseSnapshot(strings.NewReader(""), SnapshotOptions{}); !errors.Is(err, ErrSnapshotHeaderMissing) {
+		t.Errorf("expected ErrSnapshotHeaderMissing for empty input, got %v", err)
+	}
+}
+
+func TestParseSnapshot_LegacyFallback(t *testing.T) {
+	issues := []interface{}{
+		&types.Issue{ID: "test-1", Title: "One", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+		&types.Issue{ID: "test-2", Title: "Two", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+	}
+
+	header, parsed, err := ParseSnapshot(snapshotLines(t, append(issues, sqlite.ExportSummary{Summary: true, Count: 2})...), SnapshotOptions{AllowLegacy: true})
+	if err != nil {
+		t.Fatalf("ParseSnapshot failed: %v", err)
+	}
+	if header != nil {
+		t.Errorf("legacy export should have no header, got %+v", header)
+	}
+	if len(parsed) != 2 {
+		t.Errorf("expected 2 issues, got %d", len(parsed))
+	}
+
+	// The summary still guards legacy exports against truncation
+	if _, _, err := ParseSnapshot(snapshotLines(t, issues[0], sqlite.ExportSummary{Summary: true, Count: 2}), SnapshotOptions{AllowLegacy: true}); !errors.Is(err, ErrSnapshotIncomplete) {
+		t.Errorf("expected ErrSnapshotIncomplete, got %v", err)
+	}
+
+	// Headered snapshots are still validated with AllowLegacy
+	bad := types.SnapshotHeader{Header: true, SchemaVersion: types.SnapshotFormatVersion + 1}
+	if _, _, err := ParseSnapshot(snapshotLines(t, bad), SnapshotOptions{AllowLegacy: true}); !errors.Is(err, ErrSnapshotIncompatible) {
+		t.Errorf("expected ErrSnapshotIncompatible, got %v", err)
+	}
+}
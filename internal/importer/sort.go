@@ -31,6 +31,24 @@ func SortByDepth(issues []*types.Issue) {
 	})
 }
 
+// sortForCreate orders new issues for creation: parents before children (by
+// hierarchyDepth), then by CreatedAt, then by ID. Issues tying on all three
+// (an ID listed more than once) keep their input line order. Creation order
+// fixes row and event IDs, so an export whose issues share a CreatedAt, as
+// bulk-created ones do, imports in the same order on every run.
+func sortForCreate(issues []*types.Issue) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		di, dj := hierarchyDepth(issues[i].ID), hierarchyDepth(issues[j].ID)
+		if di != dj {
+			return di < dj
+		}
+		if !issues[i].CreatedAt.Equal(issues[j].CreatedAt) {
+			return issues[i].CreatedAt.Before(issues[j].CreatedAt)
+		}
+		return issues[i].ID < issues[j].ID
+	})
+}
+
 // GroupByDepth groups issues into buckets by hierarchy depth.
 // Returns a map where keys are depth levels and values are slices of issues at that depth.
 // Maximum supported depth is 3 (as per beads spec).
@@ -1,8 +1,17 @@
 package importer
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -115,3 +124,71 @@ func TestGroupByDepth(t *testing.T) {
 		t.Errorf("Depth 2: got %q, want bd-abc.1.1", groups[2][0].ID)
 	}
 }
+
+func TestImportIssues_SameTimestampOrder(t *testing.T) {
+	ctx := context.Background()
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// importOrder imports issues into a fresh database and returns their IDs
+	// in creation order, as the event log records it.
+	importOrder := func(issues []*types.Issue) []string {
+		t.Helper()
+		store, err := sqlite.New(ctx, filepath.Join(t.TempDir(), "test.db"))
+		if err != nil {
+			t.Fatalf("Failed to create store: %v", err)
+		}
+		defer store.Close()
+		if err := store.SetConfig(ctx, "issue_prefix", "test"); err != nil {
+			t.Fatalf("Failed to set prefix: %v", err)
+		}
+		if _, err := ImportIssues(ctx, "", store, issues, Options{}); err != nil {
+			t.Fatalf("ImportIssues failed: %v", err)
+		}
+		var buf bytes.Buffer
+		if _, err := store.ExportEventsSince(ctx, 0, &buf); err != nil {
+			t.Fatalf("ExportEventsSince failed: %v", err)
+		}
+		var ids []string
+		dec := json.NewDecoder(&buf)
+		for dec.More() {
+			var record types.EventRecord
+			if err := dec.Decode(&record); err != nil {
+				t.Fatalf("decode event: %v", err)
+			}
+			if record.Event.EventType == types.EventCreated {
+				ids = append(ids, record.Event.IssueID)
+			}
+		}
+		return ids
+	}
+	issues := func(order []int) []*types.Issue {
+		var out []*types.Issue
+		for _, n := range order {
+			at := created
+			if n == 0 {
+				at = created.Add(-time.Hour) // created first despite sorting last by ID
+			}
+			out = append(out, &types.Issue{ID: fmt.Sprintf("test-z%02d", 20-n), Title: fmt.Sprintf("Bulk %d", n),
+				Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: at, UpdatedAt: created})
+		}
+		return out
+	}
+
+	forward := make([]int, 20)
+	backward := make([]int, 20)
+	for i := range forward {
+		forward[i] = i
+		backward[i] = 19 - i
+	}
+	first := importOrder(issues(forward))
+	second := importOrder(issues(backward))
+	if strings.Join(first, ",") != strings.Join(second, ",") {
+		t.Fatalf("creation order depends on input order:\n%v\n%v", first, second)
+	}
+	if len(first) != 20 || first[0] != "test-z20" {
+		t.Fatalf("creation order = %v, want the earliest issue first", first)
+	}
+	if !sort.StringsAreSorted(first[1:]) {
+		t.Errorf("same-timestamp issues created as %v, want ID order", first[1:])
+	}
+}
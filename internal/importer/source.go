@@ -0,0 +1,48 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// SourceRegistryConfigKey is the config key holding the comma-separated
+// registry of known source systems (e.g. "jira,github,manual"). When it is
+// set, imports reject issues from any other source; when empty, any source is
+// accepted.
+const SourceRegistryConfigKey = "sources.known"
+
+// ErrUnknownSource is returned (wrapped) for an issue whose SourceSystem is not
+// in the registry under SourceRegistryConfigKey.
+var ErrUnknownSource = errors.New("unknown source system")
+
+// applySourceSystem gives issues without a SourceSystem Options.SourceSystem,
+// then checks every source against the registry in cfg. It runs before
+// hashing, so defaulted sources are part of the content hash.
+func applySourceSystem(ctx context.Context, cfg configStore, issues []*types.Issue, opts Options) error {
+	if opts.SourceSystem != "" {
+		for _, issue := range issues {
+			if issue.SourceSystem == "" {
+				issue.SourceSystem = opts.SourceSystem
+			}
+		}
+	}
+
+	known := customConfigList(ctx, cfg, SourceRegistryConfigKey, nil)
+	if len(known) == 0 {
+		return nil
+	}
+	registered := make(map[string]bool, len(known))
+	for _, source := range known {
+		registered[source] = true
+	}
+	for _, issue := range issues {
+		if issue.SourceSystem != "" && !registered[issue.SourceSystem] {
+			return fmt.Errorf("%w %q on issue %s (known: %s)", ErrUnknownSource, issue.SourceSystem, issue.ID, strings.Join(known, ", "))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestImportIssues_SourceSystem(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	newIssue := func(id, source string) *types.Issue {
+		return &types.Issue{ID: id, Title: "Issue " + id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, SourceSystem: source, CreatedAt: now, UpdatedAt: now}
+	}
+	t.Run("per-issue sources and the default round-trip", func(t *testing.T) {
+		store := newTestStore(t)
+		issues := []*types.Issue{newIssue("test-1", "jira"), newIssue("test-2", "")}
+		if _, err := ImportIssues(ctx, "", store, issues, Options{SourceSystem: "manual"}); err != nil {
+			t.Fatalf("ImportIssues failed: %v", err)
+		}
+		for id, want := range map[string]string{"test-1": "jira", "test-2": "manual"} {
+			got, err := store.GetIssue(ctx, id)
+			if err != nil {
+				t.Fatalf("GetIssue failed: %v", err)
+			}
+			if got.SourceSystem != want {
+				t.Errorf("%s: source system = %q, want %q", id, got.SourceSystem, want)
+			}
+		}
+
+		// Re-importing with a new origin updates it
+		rerouted := newIssue("test-1", "github")
+		rerouted.UpdatedAt = now.Add(time.Minute)
+		result, err := ImportIssues(ctx, "", store, []*types.Issue{rerouted}, Options{})
+		if err != nil {
+			t.Fatalf("ImportIssues failed: %v", err)
+		}
+		if result.Updated != 1 {
+			t.Errorf("expected the source change to update the issue, got %+v", result)
+		}
+		moved, err := store.ListIssuesBySource(ctx, "github")
+		if err != nil {
+			t.Fatalf("ListIssuesBySource failed: %v", err)
+		}
+		if len(moved) != 1 || moved[0].ID != "test-1" {
+			t.Errorf("expected test-1 listed under github, got %v", moved)
+		}
+	})
+
+	t.Run("registry rejects unknown sources", func(t *testing.T) {
+		store := newTestStore(t)
+		if err := store.SetConfig(ctx, SourceRegistryConfigKey, "jira, github"); err != nil {
+			t.Fatalf("SetConfig failed: %v", err)
+		}
+		_, err := ImportIssues(ctx, "", store, []*types.Issue{newIssue("test-1", "jira"), newIssue("test-2", "linear")}, Options{})
+		if !errors.Is(err, ErrUnknownSource) {
+			t.Fatalf("expected ErrUnknownSource, got %v", err)
+		}
+		if got, _ := store.GetIssue(ctx, "test-1"); got != nil {
+			t.Errorf("expected nothing imported after a rejected source, got %s", got.ID)
+		}
+
+		// The default is checked too; issues without a source are allowed
+		if _, err := ImportIssues(ctx, "", store, []*types.Issue{newIssue("test-3", "")}, Options{SourceSystem: "manual"}); !errors.Is(err, ErrUnknownSource) {
+			t.Errorf("expected unregistered default rejected, got %v", err)
+		}
+		if _, err := ImportIssues(ctx, "", store, []*types.Issue{newIssue("test-4", ""), newIssue("test-5", "github")}, Options{}); err != nil {
+			t.Errorf("expected sourceless and registered issues accepted, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,62 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// applyStatusMap rewrites the status of each issue found in opts.StatusMap
+// (e.g. Jira's "Done" to "closed"), before validation and hashing, so imports
+// from systems with other status vocabularies land in the local set. Every
+// target must be built in or registered in cfg as a custom status. Statuses
+// not in the map are left for validation as usual.
+//
+// closed_at and deleted_at follow the remapped status as they would the
+// stored one: an issue remapped to closed without closed_at is closed a second
+// after its last timestamp, one remapped to tombstone gets a deletion time the
+// same way, and remapping out of either drops the timestamp that no longer
+// applies.
+func applyStatusMap(ctx context.Context, cfg configStore, issues []*types.Issue, opts Options) error {
+	if len(opts.StatusMap) == 0 {
+		return nil
+	}
+	customStatuses := customConfigList(ctx, cfg, customStatusesConfigKey, nil)
+	for from, to := range opts.StatusMap {
+		if !types.Status(to).IsValidWithCustom(customStatuses) {
+			return fmt.Errorf("invalid status map target %q for %q: not a built-in or custom status", to, from)
+		}
+	}
+
+	for _, issue := range issues {
+		to, ok := opts.StatusMap[string(issue.Status)]
+		if !ok || types.Status(to) == issue.Status {
+			continue
+		}
+		issue.Status = types.Status(to)
+
+		synthesized := issue.CreatedAt
+		if issue.UpdatedAt.After(synthesized) {
+			synthesized = issue.UpdatedAt
+		}
+		synthesized = synthesized.Add(time.Second)
+		switch issue.Status {
+		case types.StatusClosed:
+			if issue.ClosedAt == nil {
+				issue.ClosedAt = &synthesized
+			}
+			issue.DeletedAt = nil
+		case types.StatusTombstone:
+			issue.ClosedAt = nil
+			if issue.DeletedAt == nil {
+				issue.DeletedAt = &synthesized
+			}
+		default:
+			issue.ClosedAt = nil
+			issue.DeletedAt = nil
+		}
+	}
+	return nil
+}
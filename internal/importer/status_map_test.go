@@ -0,0 +1,58 @@
+package importer
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestImportIssues_StatusMap(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	now := time.Now().Truncate(time.Second)
+	closedAt := now.Add(-time.Hour)
+	done := &types.Issue{ID: "test-1", Title: "Done in Jira", Status: "Done", Priority: 2,
+		IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now}
+	reopened := &types.Issue{ID: "test-2", Title: "Reopened", Status: "Reopened", ClosedAt: &closedAt, Priority: 2,
+		IssueType: types.TypeBug, CreatedAt: now, UpdatedAt: now}
+	native := &types.Issue{ID: "test-3", Title: "Native", Status: types.StatusBlocked, Priority: 2,
+		IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now}
+	opts := Options{StatusMap: map[string]string{"Done": "closed", "Reopened": "open"}}
+	if _, err := ImportIssues(ctx, "", store, []*types.Issue{done, reopened, native}, opts); err != nil {
+		t.Fatalf("ImportIssues failed: %v", err)
+	}
+
+	got, err := store.GetIssue(ctx, "test-1")
+	if err != nil || got == nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Status != types.StatusClosed || got.ClosedAt == nil || !got.ClosedAt.After(now) {
+		t.Errorf("expected Done to import closed with a synthesized closed_at, got %s at %v", got.Status, got.ClosedAt)
+	}
+	want := *got
+	want.ContentHash = ""
+	if got.ContentHash != want.ComputeContentHash() {
+		t.Errorf("expected content hash to cover the remapped status")
+	}
+	if got, _ := store.GetIssue(ctx, "test-2"); got.Status != types.StatusOpen || got.ClosedAt != nil {
+		t.Errorf("expected Reopened to import open without closed_at, got %s at %v", got.Status, got.ClosedAt)
+	}
+	if got, _ := store.GetIssue(ctx, "test-3"); got.Status != types.StatusBlocked {
+		t.Errorf("expected unmapped status to be kept, got %s", got.Status)
+	}
+
+	// Unmapped foreign statuses fail validation as before
+	issue := &types.Issue{ID: "test-4", Title: "Triage", Status: "Triage", Priority: 2,
+		IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now}
+	if _, err := ImportIssues(ctx, "", store, []*types.Issue{issue}, opts); err == nil {
+		t.Errorf("expected unmapped unknown status to be rejected")
+	}
+	bad := Options{StatusMap: map[string]string{"Done": "finished"}}
+	if _, err := ImportIssues(ctx, "", store, []*types.Issue{done}, bad); err == nil || !strings.Contains(err.Error(), `invalid status map target "finished"`) {
+		t.Errorf("expected invalid map target to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// syntheticParentDescription marks placeholder parents created under
+// Options.SynthesizeParents, so they can be found and filled in later.
+const syntheticParentDescription = "[PLACEHOLDER] Synthesized from the ID structure of its children; fill in or replace."
+
+// addSyntheticParents implements Options.SynthesizeParents: every ancestor of
+// an issue in newIssues that neither exists (dbByID) nor is being created is
+// appended to newIssues as an open placeholder, so foo-1.2.3 brings foo-1.2
+// (and foo-1, if missing) with it. Unlike OrphanResurrect nothing is looked up
+// in JSONL history; placeholders carry only what the child's ID implies.
+func addSyntheticParents(dbByID map[string]*types.Issue, newIssues []*types.Issue, opts Options, result *Result) []*types.Issue {
+	willExist := make(map[string]bool, len(newIssues))
+	for _, iss := range newIssues {
+		willExist[iss.ID] = true
+	}
+	now := time.Now().UTC()
+	var synthesized []*types.Issue
+	for _, iss := range newIssues {
+		for isHier, parentID := isHierarchicalID(iss.ID); isHier; isHier, parentID = isHierarchicalID(parentID) {
+			if dbByID[parentID] != nil || willExist[parentID] {
+				continue
+			}
+			parent := &types.Issue{
+				ID:          parentID,
+				Title:       "Placeholder for " + parentID,
+				Description: syntheticParentDescription,
+				Status:      types.StatusOpen,
+				Priority:    2,
+				IssueType:   types.TypeTask,
+				CreatedAt:   iss.CreatedAt,
+				UpdatedAt:   now,
+			}
+			parent.ContentHash = parent.ComputeContentHashWith(opts.hashOptions)
+			synthesized = append(synthesized, parent)
+			willExist[parentID] = true
+		}
+	}
+	for _, parent := range synthesized {
+		result.Synthesized = append(result.Synthesized, parent.ID)
+	}
+	return append(newIssues, synthesized...)
+}
+
+// recordSyntheticParents records a types.EventSynthesized event on each
+// placeholder in ids once it has been created, through the
+// storage.EventImporter capability of store if it has one.
+func recordSyntheticParents(ctx context.Context, store interface{}, ids []string) error {
+	importer, ok := store.(storage.EventImporter)
+	if !ok || len(ids) == 0 {
+		return nil
+	}
+	now := time.Now()
+	for _, id := range ids {
+		comment := "placeholder parent synthesized from child IDs"
+		event := &types.Event{IssueID: id, EventType: types.EventSynthesized, Actor: "import", Comment: &comment, CreatedAt: now}
+		if err := importer.ImportEvents(ctx, id, []*types.Event{event}, false); err != nil {
+			return fmt.Errorf("failed to record synthesized parent %s: %w", id, err)
+		}
+	}
+	return nil
+}
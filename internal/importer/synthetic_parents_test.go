@@ -0,0 +1,53 @@
+package importer
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestImportIssues_SynthesizeParents(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	now := time.Now().Truncate(time.Second)
+	child := &types.Issue{ID: "test-abc.1.2", Title: "Deep child", Status: types.StatusOpen, Priority: 1,
+		IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now}
+	opts := Options{SynthesizeParents: true, OrphanHandling: OrphanStrict}
+	result, err := ImportIssues(ctx, "", store, []*types.Issue{child}, opts)
+	if err != nil {
+		t.Fatalf("ImportIssues failed: %v", err)
+	}
+
+	if strings.Join(result.Synthesized, ",") != "test-abc.1,test-abc" || result.Created != 3 {
+		t.Errorf("synthesized %v, created %d; want [test-abc.1 test-abc] and 3", result.Synthesized, result.Created)
+	}
+	for _, id := range []string{"test-abc", "test-abc.1"} {
+		parent, err := store.GetIssue(ctx, id)
+		if err != nil || parent == nil {
+			t.Fatalf("GetIssue(%s) = %v, %v", id, parent, err)
+		}
+		if parent.Status != types.StatusOpen || parent.Description != syntheticParentDescription {
+			t.Errorf("%s = %q (%s), want an open placeholder", id, parent.Description, parent.Status)
+		}
+		events, err := store.GetEvents(ctx, id, 0)
+		if err != nil {
+			t.Fatalf("GetEvents(%s) failed: %v", id, err)
+		}
+		var synthesized int
+		for _, ev := range events {
+			if ev.EventType == types.EventSynthesized {
+				synthesized++
+			}
+		}
+		if synthesized != 1 {
+			t.Errorf("%s has %d synthesized events, want 1", id, synthesized)
+		}
+	}
+	if got, err := store.GetIssue(ctx, "test-abc.1.2"); err != nil || got == nil || got.Title != "Deep child" {
+		t.Errorf("GetIssue(test-abc.1.2) = %+v, %v", got, err)
+	}
+}
@@ -0,0 +1,64 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// ParseTemplates reads a template export (types.TemplateRecord lines, see
+// sqlite.ExportTemplates) and returns the templates, ready for ImportIssues
+// with Options.Templates. Lines that are not template records are skipped, so
+// templates can share a file with issues. maxLineSize bounds a single line (0
+// uses the default).
+func ParseTemplates(r io.Reader, maxLineSize int) ([]*types.Template, error) {
+	var templates []*types.Template
+
+	scanner := utils.NewJSONLScanner(r, maxLineSize)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var record types.TemplateRecord
+		if err := json.Unmarshal(line, &record); err != nil {
+			return nil, fmt.Errorf("line %d: %w", scanner.Line(), err)
+		}
+		if record.Template == nil {
+			continue
+		}
+		if err := record.Template.Validate(); err != nil {
+			return nil, fmt.Errorf("line %d: %w", scanner.Line(), err)
+		}
+		templates = append(templates, record.Template)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+// importTemplates stores opts.Templates through the storage.TemplateStore
+// capability of store, replacing templates with the same IDs. Backends
+// without templates fail the import rather than dropping them.
+func importTemplates(ctx context.Context, store interface{}, opts Options, result *Result) error {
+	if len(opts.Templates) == 0 || opts.DryRun {
+		return nil
+	}
+	templateStore, ok := store.(storage.TemplateStore)
+	if !ok {
+		return fmt.Errorf("storage backend does not support templates")
+	}
+	for _, tmpl := range opts.Templates {
+		if err := templateStore.SaveTemplate(ctx, tmpl); err != nil {
+			return err
+		}
+		result.Templates++
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestImportIssues_Templates(t *testing.T) {
+	ctx := context.Background()
+	source := newTestStore(t)
+	if err := source.SaveTemplate(ctx, &types.Template{
+		ID:          "release",
+		Name:        "Release checklist",
+		Title:       "Release vX",
+		Description: "Tag, build, publish.",
+		IssueType:   types.TypeChore,
+		Priority:    1,
+		Labels:      []string{"release"},
+	}); err != nil {
+		t.Fatalf("SaveTemplate failed: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := source.ExportTemplates(ctx, &buf); err != nil {
+		t.Fatalf("ExportTemplates failed: %v", err)
+	}
+
+	// Issue lines in the same file are skipped
+	data := `{"id":"test-1","title":"Not a template","status":"open","priority":2,"issue_type":"task"}` + "\n" + buf.String()
+	templates, err := ParseTemplates(strings.NewReader(data), 0)
+	if err != nil {
+		t.Fatalf("ParseTemplates failed: %v", err)
+	}
+	if len(templates) != 1 || templates[0].ID != "release" {
+		t.Fatalf("ParseTemplates = %+v, want the release template", templates)
+	}
+
+	target := newTestStore(t)
+	result, err := ImportIssues(ctx, "", target, nil, Options{Templates: templates})
+	if err != nil {
+		t.Fatalf("ImportIssues failed: %v", err)
+	}
+	if result.Templates != 1 {
+		t.Errorf("Templates = %d, want 1", result.Templates)
+	}
+
+	issue, err := target.CreateFromTemplate(ctx, "release", map[string]interface{}{"title": "Release v1.2"}, "test-user")
+	if err != nil {
+		t.Fatalf("CreateFromTemplate failed: %v", err)
+	}
+	got, err := target.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Title != "Release v1.2" || got.Description != "Tag, build, publish." || got.IssueType != types.TypeChore || got.Priority != 1 {
+		t.Errorf("instantiated issue = %+v", got)
+	}
+	if len(got.Labels) != 1 || got.Labels[0] != "release" {
+		t.Errorf("labels = %v, want the template's", got.Labels)
+	}
+
+	if _, err := ParseTemplates(strings.NewReader(`{"_template":{"title":"no id"}}`), 0); err == nil {
+		t.Error("expected a template without an ID to be rejected")
+	}
+}
@@ -0,0 +1,25 @@
+package importer
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+)
+
+// newTestStore opens a fresh SQLite store with issue_prefix "test" in a
+// temporary directory, closed when the test ends.
+func newTestStore(t *testing.T) *sqlite.SQLiteStorage {
+	t.Helper()
+	ctx := context.Background()
+	store, err := sqlite.New(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	if err := store.SetConfig(ctx, "issue_prefix", "test"); err != nil {
+		t.Fatalf("Failed to set prefix: %v", err)
+	}
+	return store
+}
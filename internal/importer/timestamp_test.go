@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -478,3 +479,61 @@ func TestMain(m *testing.M) {
 	code := m.Run()
 	os.Exit(code)
 }
+
+func TestImportIssues_NormalizeTimestampsUTC(t *testing.T) {
+	ctx := context.Background()
+	store, err := sqlite.New(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	defer store.Close()
+	if err := store.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+		t.Fatalf("Failed to set prefix: %v", err)
+	}
+
+	india := time.FixedZone("IST", 5*3600+1800)
+	pacific := time.FixedZone("PST", -8*3600)
+	created := time.Date(2025, 3, 1, 9, 0, 0, 0, india) // 03:30Z
+	updated := time.Date(2025, 2, 28, 20, 0, 0, 0, pacific) // 04:00Z next day, later than created
+	issues := []*types.Issue{
+		{ID: "bd-1", Title: "Closed elsewhere", Status: types.StatusClosed, Priority: 2, IssueType: types.TypeTask,
+			CreatedAt: created, UpdatedAt: updated},
+		{ID: "bd-2", Title: "Already UTC", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask,
+			CreatedAt: created.UTC(), UpdatedAt: created.UTC()},
+	}
+
+	if _, err := ImportIssues(ctx, "", store, issues, Options{NormalizeTimestampsUTC: true}); err != nil {
+		t.Fatalf("ImportIssues failed: %v", err)
+	}
+
+	var createdAt, updatedAt, closedAt string
+	err = store.UnderlyingDB().QueryRowContext(ctx,
+		`SELECT created_at, updated_at, closed_at FROM issues WHERE id = ?`, "bd-1").Scan(&createdAt, &updatedAt, &closedAt)
+	if err != nil {
+		t.Fatalf("Failed to read stored timestamps: %v", err)
+	}
+	for name, value := range map[string]string{"created_at": createdAt, "updated_at": updatedAt, "closed_at": closedAt} {
+		if !strings.HasSuffix(value, "Z") {
+			t.Errorf("expected stored %s in UTC, got %q", name, value)
+		}
+	}
+
+	got, err := store.GetIssue(ctx, "bd-1")
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if !got.CreatedAt.Equal(created) || !got.UpdatedAt.Equal(updated) {
+		t.Errorf("expected instants preserved, got created %v updated %v", got.CreatedAt, got.UpdatedAt)
+	}
+	if want := updated.Add(time.Second); got.ClosedAt == nil || !got.ClosedAt.Equal(want) {
+		t.Errorf("expected closed_at synthesized as %v, got %v", want.UTC(), got.ClosedAt)
+	}
+
+	plain, err := store.GetIssue(ctx, "bd-2")
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if !plain.CreatedAt.Equal(created) {
+		t.Errorf("expected UTC timestamp untouched, got %v", plain.CreatedAt)
+	}
+}
@@ -0,0 +1,98 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// TypeFilterHandling decides what an import does with issues whose type is
+// not in Options.AllowedTypes, mirroring OrphanSkip and OrphanStrict.
+type TypeFilterHandling string
+
+const (
+	TypeFilterSkip   TypeFilterHandling = "skip"   // Leave the issue out and count it in Result.TypeFiltered (default)
+	TypeFilterStrict TypeFilterHandling = "strict" // Fail the import with ErrTypeNotAllowed
+)
+
+// ErrTypeNotAllowed is returned (wrapped) under TypeFilterStrict.
+var ErrTypeNotAllowed = errors.New("issue type not allowed")
+
+// applyTypeAllowList returns the issues allowed by opts.AllowedTypes. Parents
+// of allowed issues (by hierarchical ID or parent-child dependency) are kept
+// whatever their type, transitively, so accepted children are never orphaned
+// by the filter. Everything else is skipped or, under TypeFilterStrict, fails
+// the import.
+func applyTypeAllowList(issues []*types.Issue, opts Options, result *Result) ([]*types.Issue, error) {
+	if len(opts.AllowedTypes) == 0 {
+		return issues, nil
+	}
+	switch opts.DisallowedTypes {
+	case "", TypeFilterSkip, TypeFilterStrict:
+	default:
+		return nil, fmt.Errorf("unknown disallowed type handling %q (want skip or strict)", opts.DisallowedTypes)
+	}
+
+	allowed := make(map[types.IssueType]bool, len(opts.AllowedTypes))
+	names := make([]string, 0, len(opts.AllowedTypes))
+	for _, t := range opts.AllowedTypes {
+		allowed[t] = true
+		names = append(names, string(t))
+	}
+
+	byID := make(map[string]*types.Issue, len(issues))
+	keep := make(map[*types.Issue]bool, len(issues))
+	var queue []*types.Issue
+	for _, issue := range issues {
+		byID[issue.ID] = issue
+		issueType := issue.IssueType
+		if issueType == "" {
+			issueType = types.TypeTask
+		}
+		if allowed[issueType] {
+			keep[issue] = true
+			queue = append(queue, issue)
+		}
+	}
+
+	// Retain the parents of everything kept
+	for len(queue) > 0 {
+		issue := queue[0]
+		queue = queue[1:]
+		var parents []string
+		if isHier, parentID := isHierarchicalID(issue.ID); isHier {
+			parents = append(parents, parentID)
+		}
+		for _, dep := range issue.Dependencies {
+			if dep.Type == types.DepParentChild && (dep.IssueID == "" || dep.IssueID == issue.ID) {
+				parents = append(parents, dep.DependsOnID)
+			}
+		}
+		for _, id := range parents {
+			if parent := byID[id]; parent != nil && !keep[parent] {
+				keep[parent] = true
+				queue = append(queue, parent)
+			}
+		}
+	}
+
+	filtered := make([]*types.Issue, 0, len(keep))
+	for _, issue := range issues {
+		if keep[issue] {
+			filtered = append(filtered, issue)
+			continue
+		}
+		if opts.DisallowedTypes == TypeFilterStrict {
+			return nil, fmt.Errorf("%w: %s has type %q (allowed: %s)", ErrTypeNotAllowed, issue.ID, issue.IssueType, strings.Join(names, ", "))
+		}
+		result.TypeFiltered++
+		result.note(ImportEventSkipped, issue.ID)
+	}
+	if result.TypeFiltered > 0 {
+		fmt.Fprintf(os.Stderr, "Skipped %d issue(s) with types outside the allow-list (%s)\n", result.TypeFiltered, strings.Join(names, ", "))
+	}
+	return filtered, nil
+}
@@ -0,0 +1,81 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func typeFilterInput() []*types.Issue {
+	issue := func(id string, issueType types.IssueType) *types.Issue {
+		return &types.Issue{ID: id, Title: "Issue " + id, Status: types.StatusOpen, Priority: 2, IssueType: issueType}
+	}
+	epic := issue("test-epic", types.TypeEpic)
+	child := issue("test-epic.1", types.TypeTask)
+	feature := issue("test-feat", types.TypeFeature)
+	linked := issue("test-linked", types.TypeBug)
+	linked.Dependencies = []*types.Dependency{{IssueID: "test-linked", DependsOnID: "test-feat", Type: types.DepParentChild}}
+	return []*types.Issue{
+		epic, child, feature, linked,
+		issue("test-bug", types.TypeBug),
+		issue("test-chore", types.TypeChore),
+		issue("test-other", types.TypeFeature),
+	}
+}
+
+func TestImportIssues_AllowedTypesSkip(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	result, err := ImportIssues(ctx, "", store, typeFilterInput(), Options{AllowedTypes: []types.IssueType{types.TypeBug, types.TypeTask}})
+	if err != nil {
+		t.Fatalf("ImportIssues failed: %v", err)
+	}
+	if result.TypeFiltered != 2 || result.Skipped != 2 {
+		t.Errorf("expected 2 issues filtered and skipped, got TypeFiltered=%d Skipped=%d", result.TypeFiltered, result.Skipped)
+	}
+	if result.Created != 5 {
+		t.Errorf("expected 5 created, got %d", result.Created)
+	}
+
+	// Parents of accepted children are kept whatever their type
+	for _, id := range []string{"test-bug", "test-epic.1", "test-epic", "test-linked", "test-feat"} {
+		if issue, _ := store.GetIssue(ctx, id); issue == nil {
+			t.Errorf("expected %s imported", id)
+		}
+	}
+	for _, id := range []string{"test-chore", "test-other"} {
+		if issue, _ := store.GetIssue(ctx, id); issue != nil {
+			t.Errorf("expected %s skipped by the type allow-list", id)
+		}
+	}
+}
+
+func TestImportIssues_AllowedTypesStrict(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	opts := Options{AllowedTypes: []types.IssueType{types.TypeBug, types.TypeTask}, DisallowedTypes: TypeFilterStrict}
+	if _, err := ImportIssues(ctx, "", store, typeFilterInput(), opts); !errors.Is(err, ErrTypeNotAllowed) {
+		t.Fatalf("expected ErrTypeNotAllowed, got %v", err)
+	}
+	if issue, _ := store.GetIssue(ctx, "test-bug"); issue != nil {
+		t.Error("strict: expected nothing imported")
+	}
+
+	// Needed parents don't trip strict mode
+	input := typeFilterInput()[:4]
+	result, err := ImportIssues(ctx, "", store, input, opts)
+	if err != nil {
+		t.Fatalf("strict with only needed parents: %v", err)
+	}
+	if result.Created != 4 {
+		t.Errorf("expected 4 created, got %d", result.Created)
+	}
+
+	if _, err := ImportIssues(ctx, "", store, typeFilterInput(), Options{AllowedTypes: opts.AllowedTypes, DisallowedTypes: "drop"}); err == nil {
+		t.Error("expected error for unknown handling")
+	}
+}
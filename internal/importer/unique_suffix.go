@@ -0,0 +1,53 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ErrIDSuffixCollision is matched (via errors.Is) by every IDSuffixError.
+var ErrIDSuffixCollision = errors.New("issue ID suffix is not unique across prefixes")
+
+// IDSuffixError reports an import rolled back under
+// Options.UniqueIDSuffixes because a created issue's ID differs from other
+// issues' only by prefix.
+type IDSuffixError struct {
+	IssueID     string   // Created issue
+	ConflictIDs []string // Other issues with the same suffix
+}
+
+func (e *IDSuffixError) Error() string {
+	return issueErrorString(e.IssueID, fmt.Errorf("%w: same suffix as %s", ErrIDSuffixCollision, strings.Join(e.ConflictIDs, ", ")))
+}
+
+func (e *IDSuffixError) Unwrap() error { return ErrIDSuffixCollision }
+
+// checkIDSuffixes enforces opts.UniqueIDSuffixes within tx after the import's
+// writes: every issue in created must be the only issue with its ID suffix.
+// Checking after the writes catches collisions within the import as well as
+// with existing issues, and holding the import's write lock keeps it
+// race-free. Only created issues are checked, since updates keep their IDs.
+func checkIDSuffixes(ctx context.Context, tx storage.Transaction, created []*types.Issue, opts Options) error {
+	if !opts.UniqueIDSuffixes || len(created) == 0 {
+		return nil
+	}
+	index, ok := tx.(storage.IDSuffixIndex)
+	if !ok {
+		return fmt.Errorf("storage backend does not support UniqueIDSuffixes")
+	}
+	for _, issue := range created {
+		conflicts, err := index.IDSuffixConflicts(ctx, issue.ID)
+		if err != nil {
+			return err
+		}
+		if len(conflicts) > 0 {
+			return &IDSuffixError{IssueID: issue.ID, ConflictIDs: conflicts}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestImportIssues_UniqueIDSuffixes(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	newIssue := func(id string) *types.Issue {
+		return &types.Issue{ID: id, Title: "Issue " + id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now}
+	}
+	strict := Options{SkipPrefixValidation: true, UniqueIDSuffixes: true}
+
+	t.Run("collision within the import", func(t *testing.T) {
+		store := newTestStore(t)
+		_, err := ImportIssues(ctx, "", store, []*types.Issue{newIssue("test-a3f8"), newIssue("ops-a3f8"), newIssue("ops-b7c9")}, strict)
+		var suffixErr *IDSuffixError
+		if !errors.As(err, &suffixErr) || !errors.Is(err, ErrIDSuffixCollision) {
+			t.Fatalf("expected an IDSuffixError, got %v", err)
+		}
+		if got, err := store.GetIssue(ctx, "ops-b7c9"); err != nil || got != nil {
+			t.Errorf("the import should have rolled back: %v, %v", got, err)
+		}
+
+		// Without the option the same IDs import fine
+		if _, err := ImportIssues(ctx, "", store, []*types.Issue{newIssue("test-a3f8"), newIssue("ops-a3f8")}, Options{SkipPrefixValidation: true}); err != nil {
+			t.Fatalf("ImportIssues without UniqueIDSuffixes failed: %v", err)
+		}
+	})
+
+	t.Run("collision with an existing issue", func(t *testing.T) {
+		store := newTestStore(t)
+		if _, err := ImportIssues(ctx, "", store, []*types.Issue{newIssue("test-a3f8")}, Options{}); err != nil {
+			t.Fatalf("ImportIssues failed: %v", err)
+		}
+		_, err := ImportIssues(ctx, "", store, []*types.Issue{newIssue("ops-a3f8")}, strict)
+		var suffixErr *IDSuffixError
+		if !errors.As(err, &suffixErr) {
+			t.Fatalf("expected an IDSuffixError, got %v", err)
+		}
+		if suffixErr.IssueID != "ops-a3f8" || !reflect.DeepEqual(suffixErr.ConflictIDs, []string{"test-a3f8"}) {
+			t.Errorf("IDSuffixError = %+v, want ops-a3f8 conflicting with test-a3f8", suffixErr)
+		}
+
+		// Re-importing the existing issue is an update, not a collision
+		changed := newIssue("test-a3f8")
+		changed.Title = "Renamed"
+		changed.UpdatedAt = now.Add(time.Minute)
+		if _, err := ImportIssues(ctx, "", store, []*types.Issue{changed, newIssue("ops-c1d2")}, strict); err != nil {
+			t.Errorf("expected unique suffixes to import, got %v", err)
+		}
+	})
+
+	t.Run("batched imports check each batch", func(t *testing.T) {
+		store := newTestStore(t)
+		opts := strict
+		opts.BatchSize = 1
+		if _, err := ImportIssues(ctx, "", store, []*types.Issue{newIssue("test-a3f8"), newIssue("ops-a3f8")}, opts); !errors.Is(err, ErrIDSuffixCollision) {
+			t.Errorf("expected ErrIDSuffixCollision, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,93 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// UnknownFieldsPolicy says what decoding does with JSON fields an issue record
+// carries that this version of types.Issue does not know, as written by a newer
+// beads.
+type UnknownFieldsPolicy string
+
+const (
+	UnknownFieldsDrop     UnknownFieldsPolicy = "drop"     // Discard them silently (default)
+	UnknownFieldsError    UnknownFieldsPolicy = "error"    // Fail with ErrUnknownField
+	UnknownFieldsPreserve UnknownFieldsPolicy = "preserve" // Keep them in Issue.CustomFields, so they survive export
+)
+
+// ErrUnknownField is returned (wrapped) when a record has a field the decoder
+// does not know and the policy is UnknownFieldsError.
+var ErrUnknownField = errors.New("unknown issue field")
+
+// ParseUnknownFieldsPolicy validates a policy name as given on the command
+// line; "" is UnknownFieldsDrop.
+func ParseUnknownFieldsPolicy(name string) (UnknownFieldsPolicy, error) {
+	switch policy := UnknownFieldsPolicy(name); policy {
+	case "":
+		return UnknownFieldsDrop, nil
+	case UnknownFieldsDrop, UnknownFieldsError, UnknownFieldsPreserve:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid unknown fields policy %q (valid: drop, error, preserve)", name)
+	}
+}
+
+// DecodeIssue decodes one issue record, handling fields types.Issue does not
+// know according to policy. A ContentHashField sets ContentHash. Defaults are
+// not applied.
+func DecodeIssue(data []byte, policy UnknownFieldsPolicy) (*types.Issue, error) {
+	var issue types.Issue
+	if err := json.Unmarshal(data, &issue); err != nil {
+		return nil, err
+	}
+	if bytes.Contains(data, []byte(`"`+ContentHashField+`"`)) {
+		var hashed struct {
+			ContentHash string `json:"content_hash"`
+		}
+		if err := json.Unmarshal(data, &hashed); err != nil {
+			return nil, err
+		}
+		issue.ContentHash = hashed.ContentHash
+	}
+	if policy == "" || policy == UnknownFieldsDrop {
+		return &issue, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	var unknown []string
+	for name := range fields {
+		if !types.IsIssueJSONField(name) && name != QuarantineErrorField && name != ContentHashField {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) == 0 {
+		return &issue, nil
+	}
+	sort.Strings(unknown)
+
+	switch policy {
+	case UnknownFieldsError:
+		return nil, fmt.Errorf("%w %q in issue %s", ErrUnknownField, unknown[0], issue.ID)
+	case UnknownFieldsPreserve:
+		issue.CustomFields = make(map[string]json.RawMessage, len(unknown))
+		for _, name := range unknown {
+			var value bytes.Buffer
+			if err := json.Compact(&value, fields[name]); err != nil {
+				return nil, err
+			}
+			issue.CustomFields[name] = value.Bytes()
+		}
+		return &issue, nil
+	default:
+		return nil, fmt.Errorf("invalid unknown fields policy %q", policy)
+	}
+}
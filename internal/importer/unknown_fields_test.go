@@ -0,0 +1,106 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+const newerRecord = `{"id":"test-1","title":"From a newer beads","status":"open","priority":2,"issue_type":"task","created_at":"2026-01-01T00:00:00Z","updated_at":"2026-01-01T00:00:00Z","story_points":5,"sprint":{"name":"s1", "week": 3}}`
+
+func TestDecodeIssue_UnknownFields(t *testing.T) {
+	t.Run("drop is the default", func(t *testing.T) {
+		for _, policy := range []UnknownFieldsPolicy{"", UnknownFieldsDrop} {
+			issue, err := DecodeIssue([]byte(newerRecord), policy)
+			if err != nil {
+				t.Fatalf("DecodeIssue(%q) failed: %v", policy, err)
+			}
+			if issue.Title != "From a newer beads" || issue.CustomFields != nil {
+				t.Errorf("DecodeIssue(%q) = %+v, want known fields only", policy, issue)
+			}
+		}
+	})
+
+	t.Run("error names the first unknown field", func(t *testing.T) {
+		_, err := DecodeIssue([]byte(newerRecord), UnknownFieldsError)
+		if !errors.Is(err, ErrUnknownField) {
+			t.Fatalf("expected ErrUnknownField, got %v", err)
+		}
+		if !strings.Contains(err.Error(), `"sprint"`) || !strings.Contains(err.Error(), "test-1") {
+			t.Errorf("error should name the field and issue: %v", err)
+		}
+	})
+
+	t.Run("error accepts known records", func(t *testing.T) {
+		data := []byte(`{"id":"test-2","title":"Known","status":"open","priority":1,"issue_type":"bug"}`)
+		if _, err := DecodeIssue(data, UnknownFieldsError); err != nil {
+			t.Fatalf("DecodeIssue failed on a known record: %v", err)
+		}
+	})
+
+	t.Run("preserve keeps compacted values", func(t *testing.T) {
+		issue, err := DecodeIssue([]byte(newerRecord), UnknownFieldsPreserve)
+		if err != nil {
+			t.Fatalf("DecodeIssue failed: %v", err)
+		}
+		if len(issue.CustomFields) != 2 {
+			t.Fatalf("custom fields = %v, want story_points and sprint", issue.CustomFields)
+		}
+		if got := string(issue.CustomFields["sprint"]); got != `{"name":"s1","week":3}` {
+			t.Errorf("sprint = %s", got)
+		}
+		if got := string(issue.CustomFields["story_points"]); got != "5" {
+			t.Errorf("story_points = %s", got)
+		}
+	})
+
+	t.Run("invalid policy names are rejected", func(t *testing.T) {
+		if _, err := ParseUnknownFieldsPolicy("keep"); err == nil {
+			t.Error("expected an error for an unknown policy")
+		}
+		if policy, err := ParseUnknownFieldsPolicy(""); err != nil || policy != UnknownFieldsDrop {
+			t.Errorf("ParseUnknownFieldsPolicy(\"\") = %q, %v", policy, err)
+		}
+	})
+}
+
+func TestImportIssues_PreservedFieldsRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	issue, err := DecodeIssue([]byte(newerRecord), UnknownFieldsPreserve)
+	if err != nil {
+		t.Fatalf("DecodeIssue failed: %v", err)
+	}
+	issue.SetDefaults()
+	if _, err := ImportIssues(ctx, "", store, []*types.Issue{issue}, Options{}); err != nil {
+		t.Fatalf("ImportIssues failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := store.StreamExport(ctx, &buf, types.IssueFilter{}); err != nil {
+		t.Fatalf("StreamExport failed: %v", err)
+	}
+	line := strings.SplitN(buf.String(), "\n", 2)[0]
+	if !strings.HasSuffix(line, `,"sprint":{"name":"s1","week":3},"story_points":5}`) {
+		t.Fatalf("export lost the preserved fields: %s", line)
+	}
+
+	// Re-importing the export changes nothing
+	again, err := DecodeIssue([]byte(line), UnknownFieldsPreserve)
+	if err != nil {
+		t.Fatalf("DecodeIssue of the export failed: %v", err)
+	}
+	again.SetDefaults()
+	result, err := ImportIssues(ctx, "", store, []*types.Issue{again}, Options{})
+	if err != nil {
+		t.Fatalf("ImportIssues failed: %v", err)
+	}
+	if result.Unchanged != 1 {
+		t.Errorf("expected the round-trip to be unchanged, got %+v", result)
+	}
+}
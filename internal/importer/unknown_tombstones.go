@@ -0,0 +1,51 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// UnknownTombstonePolicy decides what an import does with an incoming
+// tombstone for an issue the database has never had, as delta imports carry
+// for issues deleted upstream.
+type UnknownTombstonePolicy string
+
+const (
+	UnknownTombstoneCreate UnknownTombstonePolicy = "create" // Store the tombstone, keeping the deletion for onward sync (default)
+	UnknownTombstoneSkip   UnknownTombstonePolicy = "skip"   // Leave it out and record it in Result.SkippedTombstones
+	UnknownTombstoneError  UnknownTombstonePolicy = "error"  // Fail the import with ErrUnknownTombstone
+)
+
+// ErrUnknownTombstone is matched (via errors.Is) by the ValidationError
+// returned for a tombstone of an absent issue under UnknownTombstoneError.
+var ErrUnknownTombstone = errors.New("tombstone for an issue that does not exist")
+
+func validateUnknownTombstonePolicy(policy UnknownTombstonePolicy) error {
+	switch policy {
+	case "", UnknownTombstoneCreate, UnknownTombstoneSkip, UnknownTombstoneError:
+		return nil
+	default:
+		return fmt.Errorf("unknown tombstone policy %q (want create, skip or error)", policy)
+	}
+}
+
+// admitNewIssue applies opts.UnknownTombstones to incoming, which matched no
+// existing issue, and reports whether it should be created. Only tombstones
+// are affected.
+func admitNewIssue(incoming *types.Issue, opts Options, result *Result) (bool, error) {
+	if incoming.Status != types.StatusTombstone {
+		return true, nil
+	}
+	switch opts.UnknownTombstones {
+	case UnknownTombstoneSkip:
+		result.SkippedTombstones = append(result.SkippedTombstones, incoming.ID)
+		result.note(ImportEventSkipped, incoming.ID)
+		return false, nil
+	case UnknownTombstoneError:
+		return false, &ValidationError{IssueID: incoming.ID, Err: ErrUnknownTombstone}
+	default:
+		return true, nil
+	}
+}
@@ -0,0 +1,75 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestImportIssues_UnknownTombstones(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+	input := func() []*types.Issue {
+		deletedAt := now
+		return []*types.Issue{
+			{ID: "test-1", Title: "Live", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now},
+			{ID: "test-2", Title: "Deleted upstream", Status: types.StatusTombstone, Priority: 2, IssueType: types.TypeTask,
+				OriginalType: string(types.TypeTask), DeletedAt: &deletedAt, DeletedBy: "upstream", CreatedAt: now, UpdatedAt: now},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		policy        UnknownTombstonePolicy
+		wantTombstone bool
+		wantErr       error
+	}{
+		{"default", "", true, nil},
+		{"create", UnknownTombstoneCreate, true, nil},
+		{"skip", UnknownTombstoneSkip, false, nil},
+		{"error", UnknownTombstoneError, false, ErrUnknownTombstone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestStore(t)
+			result, err := ImportIssues(ctx, "", store, input(), Options{UnknownTombstones: tt.policy})
+			if tt.wantErr != nil {
+				var valErr *ValidationError
+				if !errors.Is(err, tt.wantErr) || !errors.As(err, &valErr) || valErr.IssueID != "test-2" {
+					t.Fatalf("err = %v, want a ValidationError for test-2 wrapping %v", err, tt.wantErr)
+				}
+				if live, _ := store.GetIssue(ctx, "test-1"); live != nil {
+					t.Error("a failed import should not create its other issues")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ImportIssues failed: %v", err)
+			}
+
+			got, err := store.GetIssue(ctx, "test-2")
+			if err != nil {
+				t.Fatalf("GetIssue failed: %v", err)
+			}
+			if tt.wantTombstone {
+				if got == nil || got.Status != types.StatusTombstone || result.Created != 2 {
+					t.Errorf("tombstone = %+v, created %d; want it stored", got, result.Created)
+				}
+				return
+			}
+			if got != nil {
+				t.Errorf("tombstone = %+v, want it skipped", got)
+			}
+			if result.Created != 1 || result.Skipped != 1 || len(result.SkippedTombstones) != 1 || result.SkippedTombstones[0] != "test-2" {
+				t.Errorf("created %d, skipped %d, skipped tombstones %v; want test-2 skipped", result.Created, result.Skipped, result.SkippedTombstones)
+			}
+		})
+	}
+
+	if _, err := ImportIssues(ctx, "", newTestStore(t), input(), Options{UnknownTombstones: "maybe"}); err == nil {
+		t.Error("expected an unknown policy to be rejected")
+	}
+}
@@ -1,9 +1,13 @@
 package importer
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"sort"
 	"strings"
+	"time"
+	"unicode"
 
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/utils"
@@ -20,6 +24,20 @@ func IssueDataChanged(existing *types.Issue, updates map[string]interface{}) boo
 	return false
 }
 
+// ChangedFields returns the keys of updates whose values differ from existing,
+// sorted by name. Keys without a known comparison are never reported.
+func ChangedFields(existing *types.Issue, updates map[string]interface{}) []string {
+	fc := newFieldComparator()
+	var changed []string
+	for key, newVal := range updates {
+		if fc.checkFieldChanged(key, existing, newVal) {
+			changed = append(changed, key)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
 // fieldComparator handles comparison logic for different field types
 type fieldComparator struct {
 	strFrom func(v interface{}) (string, bool)
@@ -112,6 +130,11 @@ func (fc *fieldComparator) equalPriority(existing int, newVal interface{}) bool
 	return ok && int64(existing) == newPriority
 }
 
+func (fc *fieldComparator) equalInt(existing int, newVal interface{}) bool {
+	n, ok := fc.intFrom(newVal)
+	return ok && int64(existing) == n
+}
+
 func (fc *fieldComparator) equalBool(existingVal bool, newVal interface{}) bool {
 	switch t := newVal.(type) {
 	case bool:
@@ -121,6 +144,30 @@ func (fc *fieldComparator) equalBool(existingVal bool, newVal interface{}) bool
 	}
 }
 
+func (fc *fieldComparator) equalTimePtr(existing *time.Time, newVal interface{}) bool {
+	t, ok := newVal.(*time.Time)
+	if !ok {
+		return false
+	}
+	if existing == nil || t == nil {
+		return existing == nil && t == nil
+	}
+	return existing.Equal(*t)
+}
+
+func (fc *fieldComparator) equalCustomFields(existing map[string]json.RawMessage, newVal interface{}) bool {
+	t, ok := newVal.(map[string]json.RawMessage)
+	if !ok || len(existing) != len(t) {
+		return false
+	}
+	for name, raw := range t {
+		if !bytes.Equal(existing[name], raw) {
+			return false
+		}
+	}
+	return true
+}
+
 func (fc *fieldComparator) checkFieldChanged(key string, existing *types.Issue, newVal interface{}) bool {
 	switch key {
 	case "title":
@@ -145,6 +192,22 @@ func (fc *fieldComparator) checkFieldChanged(key string, existing *types.Issue,
 		return !fc.equalPtrStr(existing.ExternalRef, newVal)
 	case "pinned":
 		return !fc.equalBool(existing.Pinned, newVal)
+	case "due_at":
+		return !fc.equalTimePtr(existing.DueAt, newVal)
+	case "expires_at":
+		return !fc.equalTimePtr(existing.ExpiresAt, newVal)
+	case "color":
+		return !fc.equalStr(existing.Color, newVal)
+	case "display_order":
+		return !fc.equalInt(existing.DisplayOrder, newVal)
+	case "rank":
+		return !fc.equalStr(existing.Rank, newVal)
+	case "milestone_id":
+		return !fc.equalStr(existing.MilestoneID, newVal)
+	case "source_system":
+		return !fc.equalStr(existing.SourceSystem, newVal)
+	case "custom_fields":
+		return !fc.equalCustomFields(existing.CustomFields, newVal)
 	default:
 		return false
 	}
@@ -170,7 +233,7 @@ func RenameImportedIssuePrefixes(issues []*types.Issue, targetPrefix string) err
 	for _, issue := range issues {
 		oldPrefix := utils.ExtractIssuePrefix(issue.ID)
 		if oldPrefix == "" {
-			return fmt.Errorf("cannot rename issue %s: malformed ID (no hyphen found)", issue.ID)
+			return &PrefixError{IssueID: issue.ID, Expected: targetPrefix, Err: fmt.Errorf("cannot rename: malformed ID (no hyphen found)")}
 		}
 
 		if oldPrefix != targetPrefix {
@@ -179,7 +242,7 @@ func RenameImportedIssuePrefixes(issues []*types.Issue, targetPrefix string) err
 
 			// Validate that the suffix is valid (alphanumeric + dots for hierarchy)
 			if suffix == "" || !isValidIDSuffix(suffix) {
-				return fmt.Errorf("cannot rename issue %s: invalid suffix '%s'", issue.ID, suffix)
+				return &PrefixError{IssueID: issue.ID, Prefix: oldPrefix, Expected: targetPrefix, Err: fmt.Errorf("cannot rename: invalid suffix '%s'", suffix)}
 			}
 
 			newID := fmt.Sprintf("%s-%s", targetPrefix, suffix)
@@ -188,6 +251,14 @@ func RenameImportedIssuePrefixes(issues []*types.Issue, targetPrefix string) err
 	}
 
 	// Now update all issues and their references
+	applyIDMapping(issues, idMapping)
+	return nil
+}
+
+// applyIDMapping renames the issues whose IDs are keys of idMapping and
+// rewrites every reference to them within issues: text fields, dependency
+// endpoints and comment text.
+func applyIDMapping(issues []*types.Issue, idMapping map[string]string) {
 	for _, issue := range issues {
 		// Update the issue ID itself if it needs renaming
 		if newID, ok := idMapping[issue.ID]; ok {
@@ -222,8 +293,6 @@ func RenameImportedIssuePrefixes(issues []*types.Issue, targetPrefix string) err
 			issue.Comments[i].Text = replaceIDReferences(issue.Comments[i].Text, idMapping)
 		}
 	}
-
-	return nil
 }
 
 // replaceIDReferences replaces all old issue ID references with new ones in text
@@ -316,3 +385,80 @@ func isValidIDSuffix(s string) bool {
 	}
 	return true
 }
+
+// normalizeTimestampsUTC converts the timestamps of issues and their
+// dependencies and comments to UTC in place. Missing created_at/updated_at are
+// set to now, and a missing closed_at (or deleted_at for tombstones) is
+// synthesized as max(created_at, updated_at) + 1s, as storage would, so the
+// synthesized value is UTC as well.
+func normalizeTimestampsUTC(issues []*types.Issue, now time.Time) {
+	utc := func(t *time.Time) {
+		if t != nil && !t.IsZero() {
+			*t = t.UTC()
+		}
+	}
+	for _, issue := range issues {
+		for _, t := range []*time.Time{&issue.CreatedAt, &issue.UpdatedAt, issue.ClosedAt, issue.DueAt,
+			issue.DeferUntil, issue.ExpiresAt, issue.CompactedAt, issue.DeletedAt, issue.LastActivity} {
+			utc(t)
+		}
+		for _, dep := range issue.Dependencies {
+			utc(&dep.CreatedAt)
+		}
+		for _, comment := range issue.Comments {
+			utc(&comment.CreatedAt)
+		}
+
+		if issue.CreatedAt.IsZero() {
+			issue.CreatedAt = now
+		}
+		if issue.UpdatedAt.IsZero() {
+			issue.UpdatedAt = now
+		}
+		synthesized := issue.CreatedAt
+		if issue.UpdatedAt.After(synthesized) {
+			synthesized = issue.UpdatedAt
+		}
+		synthesized = synthesized.Add(time.Second)
+		if issue.Status == types.StatusClosed && issue.ClosedAt == nil {
+			closedAt := synthesized
+			issue.ClosedAt = &closedAt
+		}
+		if issue.Status == types.StatusTombstone && issue.DeletedAt == nil {
+			deletedAt := synthesized
+			issue.DeletedAt = &deletedAt
+		}
+	}
+}
+
+// normalizeIssueText rewrites the free-text fields of issues and their
+// comments in place: newlines converts CRLF and lone CR line endings to LF,
+// and trim strips trailing whitespace from every line and from the end of the
+// text. Both are cosmetic, so applying them before hashing keeps text that
+// differs only by platform line endings from looking like a content change.
+func normalizeIssueText(issues []*types.Issue, newlines, trim bool) {
+	normalize := func(s *string) {
+		if *s == "" {
+			return
+		}
+		if newlines {
+			*s = strings.ReplaceAll(*s, "\r\n", "\n")
+			*s = strings.ReplaceAll(*s, "\r", "\n")
+		}
+		if trim {
+			lines := strings.Split(*s, "\n")
+			for i, line := range lines {
+				lines[i] = strings.TrimRight(line, " \t\r")
+			}
+			*s = strings.TrimRightFunc(strings.Join(lines, "\n"), unicode.IsSpace)
+		}
+	}
+	for _, issue := range issues {
+		for _, s := range []*string{&issue.Title, &issue.Description, &issue.Design, &issue.AcceptanceCriteria, &issue.Notes} {
+			normalize(s)
+		}
+		for _, comment := range issue.Comments {
+			normalize(&comment.Text)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// VerifyLevel selects how many newly created issues an import re-reads inside
+// its transaction to confirm they were stored as computed.
+type VerifyLevel string
+
+const (
+	VerifyNone   VerifyLevel = "none"   // No read-back (default)
+	VerifySample VerifyLevel = "sample" // Re-read up to verifySampleSize issues spread across the batch
+	VerifyFull   VerifyLevel = "full"   // Re-read every created issue
+)
+
+// verifySampleSize bounds the issues re-read per transaction under VerifySample.
+const verifySampleSize = 32
+
+// ErrVerifyMismatch is returned (wrapped) when a re-read issue does not match
+// the content hash computed before it was written. The import transaction is
+// rolled back.
+var ErrVerifyMismatch = errors.New("import verification failed")
+
+// validateVerifyLevel rejects an unknown Options.Verify up front, before any
+// issue is written.
+func validateVerifyLevel(level VerifyLevel) error {
+	switch level {
+	case "", VerifyNone, VerifySample, VerifyFull:
+		return nil
+	default:
+		return fmt.Errorf("unknown verify level %q (want none, sample or full)", level)
+	}
+}
+
+// verifyCreatedTx re-reads issues created in the current transaction and
+// checks both the stored content_hash and a hash recomputed from the stored
+// fields against the hash computed from the incoming record. Catches driver or
+// serialization bugs before commit.
+func verifyCreatedTx(ctx context.Context, tx storage.Transaction, created []*types.Issue, level VerifyLevel, hashOpts types.ContentHashOptions) error {
+	if level == "" || level == VerifyNone || len(created) == 0 {
+		return nil
+	}
+
+	sample := created
+	if level == VerifySample && len(created) > verifySampleSize {
+		sample = make([]*types.Issue, 0, verifySampleSize)
+		for i := 0; i < verifySampleSize; i++ {
+			sample = append(sample, created[i*(len(created)-1)/(verifySampleSize-1)])
+		}
+	}
+
+	for _, want := range sample {
+		stored, err := tx.GetIssue(ctx, want.ID)
+		if err != nil {
+			return fmt.Errorf("failed to re-read %s for verification: %w", want.ID, err)
+		}
+		if stored == nil {
+			return fmt.Errorf("%w: issue %s not found after insert", ErrVerifyMismatch, want.ID)
+		}
+		if stored.ContentHash != want.ContentHash {
+			return fmt.Errorf("%w: issue %s stored content_hash %s, expected %s", ErrVerifyMismatch, want.ID, stored.ContentHash, want.ContentHash)
+		}
+		if got := stored.ComputeContentHashWith(hashOpts); got != want.ContentHash {
+			return fmt.Errorf("%w: issue %s stored content hashes to %s, expected %s", ErrVerifyMismatch, want.ID, got, want.ContentHash)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// corruptingStore hands out transactions whose reads of one issue come back
+// altered, simulating a serialization bug between write and read.
+type corruptingStore struct {
+	storage.Storage
+	corruptID string
+}
+
+func (s *corruptingStore) RunInTransaction(ctx context.Context, fn func(tx storage.Transaction) error) error {
+	return s.Storage.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		return fn(&corruptingTx{Transaction: tx, corruptID: s.corruptID})
+	})
+}
+
+type corruptingTx struct {
+	storage.Transaction
+	corruptID string
+}
+
+func (t *corruptingTx) GetIssue(ctx context.Context, id string) (*types.Issue, error) {
+	issue, err := t.Transaction.GetIssue(ctx, id)
+	if issue != nil && id == t.corruptID {
+		issue.Title += " (truncated)"
+	}
+	return issue, err
+}
+
+func TestImportIssues_Verify(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	now := time.Now()
+	inputs := func() []*types.Issue {
+		var issues []*types.Issue
+		for _, id := range []string{"test-a", "test-b", "test-c"} {
+			issues = append(issues, &types.Issue{ID: id, Title: "Issue " + id, Status: types.StatusOpen,
+				Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now})
+		}
+		return issues
+	}
+	corrupt := &corruptingStore{Storage: store, corruptID: "test-b"}
+
+	for _, level := range []VerifyLevel{VerifySample, VerifyFull} {
+		_, err := ImportIssues(ctx, "", corrupt, inputs(), Options{Verify: level})
+		if !errors.Is(err, ErrVerifyMismatch) {
+			t.Fatalf("%s: expected ErrVerifyMismatch, got %v", level, err)
+		}
+		for _, id := range []string{"test-a", "test-b", "test-c"} {
+			if issue, _ := store.GetIssue(ctx, id); issue != nil {
+				t.Errorf("%s: expected %s rolled back", level, id)
+			}
+		}
+	}
+
+	// Batched imports verify each batch before committing it
+	if _, err := ImportIssues(ctx, "", corrupt, inputs(), Options{Verify: VerifyFull, BatchSize: 1}); !errors.Is(err, ErrVerifyMismatch) {
+		t.Fatalf("batched: expected ErrVerifyMismatch, got %v", err)
+	}
+	if issue, _ := store.GetIssue(ctx, "test-b"); issue != nil {
+		t.Error("batched: expected the mismatched batch rolled back")
+	}
+
+	// Without verification the same reads go unchecked
+	result, err := ImportIssues(ctx, "", corrupt, inputs(), Options{Verify: VerifyNone})
+	if err != nil {
+		t.Fatalf("ImportIssues failed: %v", err)
+	}
+	if result.Created+result.Unchanged != 3 {
+		t.Errorf("expected all 3 issues imported, got %+v", result)
+	}
+}
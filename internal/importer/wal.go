@@ -0,0 +1,23 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// truncateWAL implements Options.CheckpointWAL once the import has committed:
+// it checkpoints and truncates store's write-ahead log if the backend keeps
+// one. The import's writes are durable either way, so a checkpoint that cannot
+// complete (e.g. because a reader holds an old snapshot) is only a warning.
+func truncateWAL(ctx context.Context, store storage.Storage) {
+	truncater, ok := store.(storage.WALTruncater)
+	if !ok {
+		return
+	}
+	if err := truncater.TruncateWAL(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to checkpoint WAL after import: %v\n", err)
+	}
+}
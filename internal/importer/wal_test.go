@@ -0,0 +1,55 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestImportIssues_CheckpointWAL(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	dbPath := store.Path()
+
+	now := time.Now().Truncate(time.Second)
+	batch := func(from int) []*types.Issue {
+		var issues []*types.Issue
+		for i := from; i < from+200; i++ {
+			issues = append(issues, &types.Issue{ID: fmt.Sprintf("test-%d", i), Title: fmt.Sprintf("Issue %d", i),
+				Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now})
+		}
+		return issues
+	}
+	walSize := func() int64 {
+		t.Helper()
+		info, err := os.Stat(dbPath + "-wal")
+		if os.IsNotExist(err) {
+			return 0
+		}
+		if err != nil {
+			t.Fatalf("stat WAL: %v", err)
+		}
+		return info.Size()
+	}
+
+	if _, err := ImportIssues(ctx, "", store, batch(1), Options{}); err != nil {
+		t.Fatalf("ImportIssues failed: %v", err)
+	}
+	if walSize() == 0 {
+		t.Fatal("expected an import without CheckpointWAL to leave a non-empty WAL")
+	}
+
+	if _, err := ImportIssues(ctx, "", store, batch(201), Options{CheckpointWAL: true}); err != nil {
+		t.Fatalf("ImportIssues failed: %v", err)
+	}
+	if size := walSize(); size != 0 {
+		t.Errorf("WAL is %d bytes after CheckpointWAL, want it truncated", size)
+	}
+	if got, err := store.GetIssue(ctx, "test-400"); err != nil || got == nil {
+		t.Errorf("GetIssue(test-400) = %v, %v; want the checkpointed import readable", got, err)
+	}
+}
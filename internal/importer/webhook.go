@@ -0,0 +1,150 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+)
+
+// WebhookEventImportCompleted is the event named in every WebhookPayload.
+const WebhookEventImportCompleted = "import.completed"
+
+// Defaults for the zero fields of an ImportWebhook.
+const (
+	DefaultWebhookTimeout    = 10 * time.Second
+	DefaultWebhookMaxRetries = 2
+	DefaultWebhookRetryDelay = time.Second
+)
+
+// ImportWebhook is an endpoint notified of every successful import (see
+// Options.Webhook).
+type ImportWebhook struct {
+	URL        string        // Receives the payload as a JSON POST
+	Timeout    time.Duration // Limit on each attempt (default DefaultWebhookTimeout)
+	MaxRetries int           // Further attempts after a failed one (default DefaultWebhookMaxRetries; negative for none)
+	RetryDelay time.Duration // Wait before the first retry, doubling for each one after it (default DefaultWebhookRetryDelay)
+	Client     *http.Client  // Client used for the POST (default http.DefaultClient)
+}
+
+// WebhookPayload is the JSON body posted to an ImportWebhook.
+type WebhookPayload struct {
+	Event       string         `json:"event"`        // WebhookEventImportCompleted
+	CompletedAt time.Time      `json:"completed_at"` // When the import committed
+	Stats       ImportStats    `json:"stats"`        // Final counts of the import
+	Conflicts   ConflictCounts `json:"conflicts"`    // Sizes of the lists in the import's ConflictReport
+}
+
+// ConflictCounts are the sizes of the lists in a ConflictReport.
+type ConflictCounts struct {
+	HashCollisions      int `json:"hash_collisions"`
+	IDCollisions        int `json:"id_collisions"`
+	Remaps              int `json:"remaps"`
+	SkippedDependencies int `json:"skipped_dependencies"`
+	DependencyConflicts int `json:"dependency_conflicts"`
+	MismatchPrefixes    int `json:"mismatch_prefixes"` // Issues with a foreign prefix, over all prefixes
+}
+
+// Counts returns the sizes of the lists in report.
+func (report *ConflictReport) Counts() ConflictCounts {
+	counts := ConflictCounts{
+		HashCollisions:      len(report.HashCollisions),
+		IDCollisions:        len(report.IDCollisions),
+		Remaps:              len(report.Remaps),
+		SkippedDependencies: len(report.SkippedDependencies),
+		DependencyConflicts: len(report.DependencyConflicts),
+	}
+	for _, n := range report.MismatchPrefixes {
+		counts.MismatchPrefixes += n
+	}
+	return counts
+}
+
+// validateWebhook rejects an Options.Webhook without a URL.
+func validateWebhook(hook *ImportWebhook) error {
+	if hook != nil && hook.URL == "" {
+		return fmt.Errorf("import webhook has no URL")
+	}
+	return nil
+}
+
+// notifyWebhook implements Options.Webhook once the import has committed. The
+// import stands whatever happens here, so a webhook that cannot be reached
+// after every retry is only a warning, and the caller's cancellation does not
+// cut delivery short (each attempt still has its timeout).
+func notifyWebhook(ctx context.Context, hook *ImportWebhook, result *Result) {
+	payload := WebhookPayload{
+		Event:       WebhookEventImportCompleted,
+		CompletedAt: time.Now().UTC(),
+		Stats:       result.Stats(),
+		Conflicts:   result.ConflictReport().Counts(),
+	}
+	if err := postWebhook(context.WithoutCancel(ctx), hook, payload); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to notify import webhook: %v\n", err)
+	}
+}
+
+// postWebhook posts payload to hook, retrying with exponential backoff until
+// an attempt gets a 2xx response or the retries run out.
+func postWebhook(ctx context.Context, hook *ImportWebhook, payload WebhookPayload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+	timeout, retries, delay, client := hook.Timeout, hook.MaxRetries, hook.RetryDelay, hook.Client
+	if timeout <= 0 {
+		timeout = DefaultWebhookTimeout
+	}
+	if retries == 0 {
+		retries = DefaultWebhookMaxRetries
+	} else if retries < 0 {
+		retries = 0
+	}
+	if delay <= 0 {
+		delay = DefaultWebhookRetryDelay
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(delay << (attempt - 1)):
+			}
+		}
+		if lastErr = postWebhookOnce(ctx, client, hook.URL, timeout, body); lastErr == nil {
+			return nil
+		}
+		lastErr = fmt.Errorf("attempt %d/%d: %w", attempt+1, retries+1, lastErr)
+	}
+	return lastErr
+}
+
+// postWebhookOnce makes a single POST of body to url within timeout.
+func postWebhookOnce(ctx context.Context, client *http.Client, url string, timeout time.Duration, body []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestImportIssues_Webhook(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+	input := func() []*types.Issue {
+		return []*types.Issue{
+			{ID: "test-1", Title: "One", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now},
+			{ID: "test-2", Title: "Two", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now},
+		}
+	}
+	// The endpoint fails its first request, so delivery needs a retry
+	var mu sync.Mutex
+	var bodies []map[string]interface{}
+	var statuses []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		var body map[string]interface{}
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" || json.NewDecoder(r.Body).Decode(&body) != nil {
+			t.Errorf("unexpected request %s %s", r.Method, r.Header.Get("Content-Type"))
+		}
+		bodies = append(bodies, body)
+		status := http.StatusOK
+		if len(bodies) == 1 {
+			status = http.StatusServiceUnavailable
+		}
+		statuses = append(statuses, status)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	hook := &ImportWebhook{URL: server.URL, RetryDelay: time.Millisecond}
+	store := newTestStore(t)
+	if _, err := ImportIssues(ctx, "", store, input(), Options{Webhook: hook}); err != nil {
+		t.Fatalf("ImportIssues failed: %v", err)
+	}
+	mu.Lock()
+	if len(bodies) != 2 || statuses[1] != http.StatusOK {
+		t.Fatalf("requests = %d (%v), want a failed attempt and a retry", len(bodies), statuses)
+	}
+	payload := bodies[1]
+	mu.Unlock()
+	if payload["event"] != WebhookEventImportCompleted {
+		t.Errorf("event = %v, want %s", payload["event"], WebhookEventImportCompleted)
+	}
+	if at, ok := payload["completed_at"].(string); !ok || at == "" {
+		t.Errorf("completed_at = %v, want a timestamp", payload["completed_at"])
+	}
+	stats, _ := payload["stats"].(map[string]interface{})
+	if stats["created"] != 2.0 || stats["updated"] != 0.0 || stats["unchanged"] != 0.0 || stats["skipped"] != 0.0 || stats["deleted"] != 0.0 {
+		t.Errorf("stats = %v, want 2 created", stats)
+	}
+	conflicts, _ := payload["conflicts"].(map[string]interface{})
+	for _, key := range []string{"hash_collisions", "id_collisions", "remaps", "skipped_dependencies", "dependency_conflicts", "mismatch_prefixes"} {
+		if conflicts[key] != 0.0 {
+			t.Errorf("conflicts[%s] = %v, want 0", key, conflicts[key])
+		}
+	}
+
+	t.Run("undeliverable", func(t *testing.T) {
+		var mu sync.Mutex
+		attempts := 0
+		failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			attempts++
+			mu.Unlock()
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer failing.Close()
+
+		store := newTestStore(t)
+		hook := &ImportWebhook{URL: failing.URL, MaxRetries: 1, RetryDelay: time.Millisecond}
+		if _, err := ImportIssues(ctx, "", store, input(), Options{Webhook: hook}); err != nil {
+			t.Fatalf("a failed webhook should not fail the import: %v", err)
+		}
+		if got, _ := store.GetIssue(ctx, "test-1"); got == nil {
+			t.Error("a failed webhook should not roll the import back")
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if attempts != 2 {
+			t.Errorf("attempts = %d, want 2", attempts)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		release := make(chan struct{})
+		slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+		}))
+		defer slow.Close()
+		defer close(release)
+
+		hook := &ImportWebhook{URL: slow.URL, Timeout: 20 * time.Millisecond, MaxRetries: -1}
+		if err := postWebhook(ctx, hook, WebhookPayload{}); err == nil {
+			t.Error("expected a hung endpoint to time out")
+		}
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		mu.Lock()
+		before := len(bodies)
+		mu.Unlock()
+		if _, err := ImportIssues(ctx, "", newTestStore(t), input(), Options{Webhook: hook, DryRun: true}); err != nil {
+			t.Fatalf("ImportIssues failed: %v", err)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if len(bodies) != before {
+			t.Error("a dry run should not notify the webhook")
+		}
+	})
+
+	if _, err := ImportIssues(ctx, "", newTestStore(t), input(), Options{Webhook: &ImportWebhook{}}); err == nil {
+		t.Error("expected a webhook without a URL to be rejected")
+	}
+}
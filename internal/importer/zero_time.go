@@ -0,0 +1,49 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ErrZeroTimestamp is matched (via errors.Is) by the ValidationError returned
+// for an issue carrying a zero ("0001-01-01T00:00:00Z") optional timestamp.
+var ErrZeroTimestamp = errors.New("zero timestamp")
+
+// rejectZeroTimestamps makes the encodings of an unset optional timestamp
+// import the same way. JSON encoders write an unset closed_at (and the other
+// optional timestamps) as null, leave it out, or write Go's zero time. Null
+// and absent decode to nil and mean "unset", so storage may synthesize the
+// value (closed_at and deleted_at); a zero time would decode to a real
+// timestamp and skip that synthesis, so it is rejected instead. The required
+// created_at and updated_at cannot tell zero from absent and are both
+// treated as unset. Under Options.ContinueOnError an offending issue is
+// rejected instead of failing the import.
+func rejectZeroTimestamps(ctx context.Context, issues []*types.Issue, opts Options, result *Result) error {
+	for _, issue := range issues {
+		for _, field := range []struct {
+			name string
+			t    *time.Time
+		}{
+			{"closed_at", issue.ClosedAt},
+			{"deleted_at", issue.DeletedAt},
+			{"due_at", issue.DueAt},
+			{"defer_until", issue.DeferUntil},
+			{"expires_at", issue.ExpiresAt},
+			{"compacted_at", issue.CompactedAt},
+			{"last_activity", issue.LastActivity},
+		} {
+			if field.t != nil && field.t.IsZero() {
+				err := &ValidationError{IssueID: issue.ID, Err: fmt.Errorf("%w: %s is 0001-01-01 (write null or omit it when unset)", ErrZeroTimestamp, field.name)}
+				if err := rejectIssue(ctx, issue, err, opts, result); err != nil {
+					return err
+				}
+				break
+			}
+		}
+	}
+	return nil
+}
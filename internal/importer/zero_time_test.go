@@ -0,0 +1,74 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestImportIssues_UnsetTimestampEncodings(t *testing.T) {
+	ctx := context.Background()
+	decode := func(closedAt string) *types.Issue {
+		t.Helper()
+		line := `{"id":"test-1","title":"Done","status":"closed","priority":2,"issue_type":"task",` +
+			`"created_at":"2025-03-01T10:00:00Z","updated_at":"2025-03-02T10:00:00Z"` + closedAt + `}`
+		var issue types.Issue
+		if err := json.Unmarshal([]byte(line), &issue); err != nil {
+			t.Fatalf("failed to decode %s: %v", line, err)
+		}
+		return &issue
+	}
+	synthesized := time.Date(2025, 3, 2, 10, 0, 1, 0, time.UTC)
+
+	// null and absent both mean unset: closed_at is synthesized the same way
+	for name, closedAt := range map[string]string{"null": `,"closed_at":null`, "absent": ``} {
+		t.Run(name, func(t *testing.T) {
+			store := newTestStore(t)
+			if _, err := ImportIssues(ctx, "", store, []*types.Issue{decode(closedAt)}, Options{}); err != nil {
+				t.Fatalf("ImportIssues failed: %v", err)
+			}
+			got, err := store.GetIssue(ctx, "test-1")
+			if err != nil {
+				t.Fatalf("GetIssue failed: %v", err)
+			}
+			if got.ClosedAt == nil || !got.ClosedAt.Equal(synthesized) {
+				t.Errorf("closed_at = %v, want synthesized %v", got.ClosedAt, synthesized)
+			}
+		})
+	}
+
+	t.Run("zero time", func(t *testing.T) {
+		zero := `,"closed_at":"0001-01-01T00:00:00Z"`
+		store := newTestStore(t)
+		_, err := ImportIssues(ctx, "", store, []*types.Issue{decode(zero)}, Options{})
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) || !errors.Is(err, ErrZeroTimestamp) || validationErr.IssueID != "test-1" {
+			t.Fatalf("expected a zero timestamp ValidationError for test-1, got %v", err)
+		}
+		if got, err := store.GetIssue(ctx, "test-1"); err != nil || got != nil {
+			t.Errorf("the issue should not have been imported: %v, %v", got, err)
+		}
+
+		// The transactional entry point behaves the same
+		err = store.RunInTransaction(ctx, func(tx storage.Transaction) error {
+			_, err := ImportIssuesTx(ctx, store, tx, []*types.Issue{decode(zero)}, Options{})
+			return err
+		})
+		if !errors.Is(err, ErrZeroTimestamp) {
+			t.Errorf("ImportIssuesTx: expected ErrZeroTimestamp, got %v", err)
+		}
+	})
+
+	t.Run("zero optional date on an open issue", func(t *testing.T) {
+		zero := time.Time{}
+		issue := &types.Issue{ID: "test-2", Title: "Open", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, DueAt: &zero}
+		if _, err := ImportIssues(ctx, "", newTestStore(t), []*types.Issue{issue}, Options{}); !errors.Is(err, ErrZeroTimestamp) {
+			t.Errorf("expected ErrZeroTimestamp for due_at, got %v", err)
+		}
+	})
+}
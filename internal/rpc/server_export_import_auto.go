@@ -153,7 +153,7 @@ func (s *Server) handleExport(req *Request) Response {
 	}()
 
 	// Write JSONL
-	encoder := json.NewEncoder(tempFile)
+	encoder := types.NewIssueEncoder(tempFile)
 	exportedIDs := make([]string, 0, len(issues))
 	var encodingWarnings []string
 	for _, issue := range issues {
@@ -547,7 +547,7 @@ func (s *Server) triggerExport(ctx context.Context, store storage.Storage, dbPat
 		_ = os.Remove(tempPath)
 	}()
 
-	encoder := json.NewEncoder(tempFile)
+	encoder := types.NewIssueEncoder(tempFile)
 	for _, issue := range allIssues {
 		if err := encoder.Encode(issue); err != nil {
 			return fmt.Errorf("failed to encode issue %s: %w", issue.ID, err)
@@ -0,0 +1,31 @@
+package storage
+
+// Config keys shared by backends and the packages that read a backend's
+// config through GetConfig, such as the importer.
+
+// IDSeparatorConfigKey is the config key for the separator used to compose
+// issue IDs: between the prefix and the hash ("bd-a3f8e9") and between the
+// configured prefix and an issue's IDPrefix ("bd-wisp-a3f8e9"). Defaults to "-".
+//
+// Teams whose prefix contains a hyphen ("web-app") can set a separator such as
+// "_" so IDs like "web-app_a3f8e9" parse unambiguously.
+const IDSeparatorConfigKey = "id.separator"
+
+// HashSaltConfigKey is the config key for the database's content hash salt.
+// When set, every content hash the store computes mixes it in (see
+// types.Issue.ComputeSaltedContentHash), so hashes from differently salted
+// databases never match and Reconcile reports their shared issues as hash
+// mismatches instead of treating them as in sync. The salt is set when the
+// database is initialized: it can only be set while there are no issues, and
+// never changed or deleted afterwards, since the stored hashes would go stale.
+const HashSaltConfigKey = "hash.salt"
+
+// HashDisplayFieldsConfigKey is the config key that, set to "true", has the
+// content hashes the store computes include the cosmetic display metadata
+// (color, display order and rank; see types.ContentHashOptions). Stored
+// hashes go stale when it changes, until the backend rehashes them.
+const HashDisplayFieldsConfigKey = "hash.display_fields"
+
+// SchemaVersionConfigKey holds the number of migrations applied to the
+// database, so importers and tools can check which columns and tables it has.
+const SchemaVersionConfigKey = "schema_version"
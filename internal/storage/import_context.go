@@ -0,0 +1,65 @@
+package storage
+
+import "context"
+
+type importContextKey struct{}
+
+// WithImport marks ctx as carrying an authoritative import (e.g. JSONL sync).
+// Backends skip checks that only apply to interactive edits, such as status
+// transition rules, for writes made with the returned context.
+func WithImport(ctx context.Context) context.Context {
+	return context.WithValue(ctx, importContextKey{}, true)
+}
+
+// IsImport reports whether ctx was marked with WithImport.
+func IsImport(ctx context.Context) bool {
+	v, _ := ctx.Value(importContextKey{}).(bool)
+	return v
+}
+
+type typeStatusBypassKey struct{}
+
+// WithoutTypeStatusRules marks ctx so backends accept issue statuses that the
+// configured type-status allowlist forbids for the issue's type, for writes
+// made with the returned context. Imports use it to stay authoritative.
+func WithoutTypeStatusRules(ctx context.Context) context.Context {
+	return context.WithValue(ctx, typeStatusBypassKey{}, true)
+}
+
+// SkipsTypeStatusRules reports whether ctx was marked with
+// WithoutTypeStatusRules.
+func SkipsTypeStatusRules(ctx context.Context) bool {
+	v, _ := ctx.Value(typeStatusBypassKey{}).(bool)
+	return v
+}
+
+type parentTypeBypassKey struct{}
+
+// WithoutParentTypeRules marks ctx so backends accept parent-child
+// dependencies that the configured parent type matrix forbids, for writes made
+// with the returned context. Imports use it to stay authoritative.
+func WithoutParentTypeRules(ctx context.Context) context.Context {
+	return context.WithValue(ctx, parentTypeBypassKey{}, true)
+}
+
+// SkipsParentTypeRules reports whether ctx was marked with
+// WithoutParentTypeRules.
+func SkipsParentTypeRules(ctx context.Context) bool {
+	v, _ := ctx.Value(parentTypeBypassKey{}).(bool)
+	return v
+}
+
+type cycleCheckBypassKey struct{}
+
+// WithoutCycleCheck marks ctx so backends accept a dependency that closes a
+// cycle, for writes made with the returned context. Imports use it to keep
+// both edges of a contradictory pair they have flagged as a conflict.
+func WithoutCycleCheck(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cycleCheckBypassKey{}, true)
+}
+
+// SkipsCycleCheck reports whether ctx was marked with WithoutCycleCheck.
+func SkipsCycleCheck(ctx context.Context) bool {
+	v, _ := ctx.Value(cycleCheckBypassKey{}).(bool)
+	return v
+}
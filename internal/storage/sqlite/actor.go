@@ -0,0 +1,28 @@
+package sqlite
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// RequireActorConfigKey makes issue creation, including imports, fail with
+// ErrActorRequired when the actor is empty, so every created issue and its
+// events carry an author. Unset or "false" accepts an empty actor.
+const RequireActorConfigKey = "storage.require_actor"
+
+// checkActor returns ErrActorRequired if actor is blank and
+// RequireActorConfigKey is enabled.
+func checkActor(ctx context.Context, db dbExecutor, actor string) error {
+	if strings.TrimSpace(actor) != "" {
+		return nil
+	}
+	var value string
+	if err := db.QueryRowContext(ctx, `SELECT value FROM config WHERE key = ?`, RequireActorConfigKey).Scan(&value); err != nil {
+		return nil
+	}
+	if required, _ := strconv.ParseBool(strings.TrimSpace(value)); required {
+		return ErrActorRequired
+	}
+	return nil
+}
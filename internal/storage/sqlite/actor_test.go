@@ -0,0 +1,40 @@
+package sqlite
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestRequireActor(t *testing.T) {
+	env := newTestEnv(t)
+	newIssue := func(title string) *types.Issue {
+		return &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	}
+
+	if err := env.Store.CreateIssue(env.Ctx, newIssue("Anonymous"), ""); err != nil {
+		t.Fatalf("expected empty actor accepted by default, got %v", err)
+	}
+
+	if err := env.Store.SetConfig(env.Ctx, RequireActorConfigKey, "true"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	if err := env.Store.CreateIssue(env.Ctx, newIssue("Anonymous"), ""); !errors.Is(err, ErrActorRequired) {
+		t.Errorf("CreateIssue: expected ErrActorRequired, got %v", err)
+	}
+	if err := env.Store.CreateIssuesWithFullOptions(env.Ctx, []*types.Issue{newIssue("Imported")}, " ", BatchCreateOptions{SkipPrefixValidation: true}); !errors.Is(err, ErrActorRequired) {
+		t.Errorf("CreateIssuesWithFullOptions: expected ErrActorRequired, got %v", err)
+	}
+	err := env.Store.RunInTransaction(env.Ctx, func(tx storage.Transaction) error {
+		return tx.CreateIssue(env.Ctx, newIssue("In tx"), "")
+	})
+	if !errors.Is(err, ErrActorRequired) {
+		t.Errorf("tx CreateIssue: expected ErrActorRequired, got %v", err)
+	}
+
+	if err := env.Store.CreateIssue(env.Ctx, newIssue("Attributed"), "alice"); err != nil {
+		t.Errorf("expected non-empty actor accepted, got %v", err)
+	}
+}
@@ -96,13 +96,14 @@ func getAdaptiveConfig(ctx context.Context, conn *sql.Conn) AdaptiveIDConfig {
 // countTopLevelIssues returns the number of top-level issues (excluding child issues)
 func countTopLevelIssues(ctx context.Context, conn *sql.Conn, prefix string) (int, error) {
 	var count int
+	idPrefix := prefix + getIDSeparator(ctx, conn)
 	// Count only top-level issues (no dot in ID after prefix)
 	err := conn.QueryRowContext(ctx, `
 		SELECT COUNT(*)
 		FROM issues
-		WHERE id LIKE ? || '-%'
-		  AND instr(substr(id, length(?) + 2), '.') = 0
-	`, prefix, prefix).Scan(&count)
+		WHERE id LIKE ? ESCAPE '\'
+		  AND instr(substr(id, length(?) + 1), '.') = 0
+	`, escapeLike(idPrefix)+"%", idPrefix).Scan(&count)
 	if err != nil {
 		return 0, err
 	}
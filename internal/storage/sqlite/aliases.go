@@ -0,0 +1,56 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// AddIDAlias records alias as an alternate ID for issueID, typically the
+// original ID of an issue remapped during import. Re-adding an alias points
+// it at the new issue.
+func (s *SQLiteStorage) AddIDAlias(ctx context.Context, alias, issueID string) error {
+	return addIDAlias(ctx, s.db, alias, issueID)
+}
+
+// AddIDAlias records an alias within the transaction.
+func (t *sqliteTxStorage) AddIDAlias(ctx context.Context, alias, issueID string) error {
+	return addIDAlias(ctx, t.conn, alias, issueID)
+}
+
+func addIDAlias(ctx context.Context, exec dbExecutor, alias, issueID string) error {
+	if alias == "" || alias == issueID {
+		return fmt.Errorf("invalid alias %q for issue %s", alias, issueID)
+	}
+	_, err := exec.ExecContext(ctx, `
+		INSERT INTO id_aliases (alias_id, issue_id) VALUES (?, ?)
+		ON CONFLICT(alias_id) DO UPDATE SET issue_id = excluded.issue_id
+	`, alias, issueID)
+	if err != nil {
+		if IsForeignKeyConstraintError(err) {
+			return fmt.Errorf("failed to add alias %s: issue %s: %w", alias, issueID, ErrNotFound)
+		}
+		return fmt.Errorf("failed to add alias %s: %w", alias, err)
+	}
+	return nil
+}
+
+// ResolveAlias returns the current ID of the issue originally known as
+// originalID. Returns an error wrapping ErrNotFound if no alias exists.
+func (s *SQLiteStorage) ResolveAlias(ctx context.Context, originalID string) (string, error) {
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	var issueID string
+	err := s.db.QueryRowContext(ctx, `
+		SELECT issue_id FROM id_aliases WHERE alias_id = ?
+	`, originalID).Scan(&issueID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("alias %s: %w", originalID, ErrNotFound)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve alias %s: %w", originalID, err)
+	}
+	return issueID, nil
+}
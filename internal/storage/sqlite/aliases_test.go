@@ -0,0 +1,75 @@
+package sqlite
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+func TestResolveAlias(t *testing.T) {
+	env := newTestEnv(t)
+	issue := env.CreateIssueWithID("bd-new1", "Remapped")
+
+	if err := env.Store.AddIDAlias(env.Ctx, "ext-old1", issue.ID); err != nil {
+		t.Fatalf("AddIDAlias failed: %v", err)
+	}
+
+	got, err := env.Store.ResolveAlias(env.Ctx, "ext-old1")
+	if err != nil {
+		t.Fatalf("ResolveAlias failed: %v", err)
+	}
+	if got != issue.ID {
+		t.Errorf("expected alias to resolve to %s, got %s", issue.ID, got)
+	}
+
+	if _, err := env.Store.ResolveAlias(env.Ctx, "ext-unknown"); !IsNotFound(err) {
+		t.Errorf("expected ErrNotFound for unknown alias, got %v", err)
+	}
+}
+
+func TestResolveAlias_FollowsRename(t *testing.T) {
+	env := newTestEnv(t)
+	issue := env.CreateIssueWithID("bd-a1", "Remapped")
+
+	if err := env.Store.AddIDAlias(env.Ctx, "ext-a1", issue.ID); err != nil {
+		t.Fatalf("AddIDAlias failed: %v", err)
+	}
+
+	issue.ID = "bd-b1"
+	if err := env.Store.UpdateIssueID(env.Ctx, "bd-a1", "bd-b1", issue, "test-user"); err != nil {
+		t.Fatalf("UpdateIssueID failed: %v", err)
+	}
+
+	got, err := env.Store.ResolveAlias(env.Ctx, "ext-a1")
+	if err != nil {
+		t.Fatalf("ResolveAlias failed: %v", err)
+	}
+	if got != "bd-b1" {
+		t.Errorf("expected alias to follow rename to bd-b1, got %s", got)
+	}
+}
+
+func TestAddIDAlias_InTransactionRollsBack(t *testing.T) {
+	env := newTestEnv(t)
+	issue := env.CreateIssue("Remapped")
+
+	_ = env.Store.RunInTransaction(env.Ctx, func(tx storage.Transaction) error {
+		if err := tx.(storage.IDAliasRecorder).AddIDAlias(env.Ctx, "ext-rollback", issue.ID); err != nil {
+			t.Fatalf("AddIDAlias in transaction failed: %v", err)
+		}
+		return errors.New("abort import")
+	})
+
+	if _, err := env.Store.ResolveAlias(env.Ctx, "ext-rollback"); !IsNotFound(err) {
+		t.Errorf("expected alias to be rolled back, got %v", err)
+	}
+}
+
+func TestAddIDAlias_UnknownIssue(t *testing.T) {
+	env := newTestEnv(t)
+
+	if err := env.Store.AddIDAlias(env.Ctx, "ext-x", "bd-missing"); err == nil {
+		t.Fatal("expected error for alias to a missing issue")
+	}
+}
@@ -0,0 +1,426 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ArchiveAfterDaysConfigKey is the config key holding how many days after
+// closing ArchiveClosedIssuesByRetention moves an issue to the archive. Unset
+// or 0 disables archival.
+const ArchiveAfterDaysConfigKey = "archive.after_days"
+
+// archiveRecord is the archived_issues.data payload: the issue as it was
+// when archived, with its labels, dependencies, comments, watchers and
+// checklist. Custom fields, ID aliases and field provenance are kept
+// alongside because Issue does not encode them itself.
+type archiveRecord struct {
+	Issue        *types.Issue               `json:"issue"`
+	CustomFields map[string]json.RawMessage `json:"custom_fields,omitempty"`
+	Aliases      []string                   `json:"aliases,omitempty"`    // Alternate IDs that resolved to the issue
+	Provenance   []FieldProvenance          `json:"provenance,omitempty"` // Field provenance, by field
+}
+
+// ArchiveClosedIssues moves the issues closed before olderThan out of the
+// active tables into archived_issues, with their labels, dependencies and
+// comments, and their events into archived_events, all in one transaction.
+// Active queries, exports and the ready work computation no longer see them;
+// SearchArchive and GetArchivedEvents read them back.
+//
+// An issue that an issue staying active still depends on, or that has a
+// hierarchical child staying active, is kept so no active issue is left with
+// a dangling reference. Returns the IDs archived, in ID order.
+func (s *SQLiteStorage) ArchiveClosedIssues(ctx context.Context, olderThan time.Time) ([]string, error) {
+	var archived []string
+	err := s.withTx(ctx, func(conn *sql.Conn) error {
+		ids, err := archiveCandidates(ctx, conn, olderThan)
+		if err != nil {
+			return err
+		}
+		tx := &sqliteTxStorage{conn: conn, parent: s}
+		now := time.Now().UTC()
+		for _, id := range ids {
+			if err := archiveIssue(ctx, tx, id, now); err != nil {
+				return err
+			}
+		}
+		archived = ids
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return archived, nil
+}
+
+// ArchiveClosedIssuesByRetention archives the issues closed longer ago than
+// ArchiveAfterDaysConfigKey days (see ArchiveClosedIssues). Returns nil
+// without archiving when no window is configured.
+func (s *SQLiteStorage) ArchiveClosedIssuesByRetention(ctx context.Context) ([]string, error) {
+	value, err := s.GetConfig(ctx, ArchiveAfterDaysConfigKey)
+	if err != nil {
+		return nil, err
+	}
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	days, err := strconv.Atoi(value)
+	if err != nil || days < 0 {
+		return nil, fmt.Errorf("invalid %s value %q: must be a non-negative number of days", ArchiveAfterDaysConfigKey, value)
+	}
+	if days == 0 {
+		return nil, nil
+	}
+	return s.ArchiveClosedIssues(ctx, time.Now().AddDate(0, 0, -days))
+}
+
+// SearchArchive returns the archived issues whose ID, title or description
+// contains query (case-insensitively; an empty query matches all), most
+// recently closed first, as they were when archived. limit caps the number
+// returned when > 0.
+func (s *SQLiteStorage) SearchArchive(ctx context.Context, query string, limit int) ([]*types.Issue, error) {
+	s.checkFreshness()
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	pattern := "%" + escapeLike(query) + "%"
+	args := []interface{}{pattern, pattern, pattern}
+	limitSQL := ""
+	if limit > 0 {
+		limitSQL = limitClause
+		args = append(args, limit)
+	}
+	// #nosec G201 - safe SQL with controlled formatting
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT data FROM archived_issues
+		WHERE id LIKE ? ESCAPE '\' OR title LIKE ? ESCAPE '\' OR description LIKE ? ESCAPE '\'
+		ORDER BY closed_at DESC, id
+		%s
+	`, limitSQL), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search archive: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	issues := []*types.Issue{}
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("failed to scan archived issue: %w", err)
+		}
+		var record archiveRecord
+		if err := json.Unmarshal([]byte(data), &record); err != nil {
+			return nil, fmt.Errorf("failed to decode archived issue: %w", err)
+		}
+		if record.Issue == nil {
+			return nil, fmt.Errorf("archived issue record without an issue")
+		}
+		record.Issue.CustomFields = record.CustomFields
+		issues = append(issues, record.Issue)
+	}
+	return issues, wrapDBError("iterate archived issues", rows.Err())
+}
+
+// GetArchivedEvents returns the events of an archived issue, oldest first.
+func (s *SQLiteStorage) GetArchivedEvents(ctx context.Context, issueID string) ([]*types.Event, error) {
+	s.checkFreshness()
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, issue_id, event_type, actor, old_value, new_value, comment, created_at
+		FROM archived_events
+		WHERE issue_id = ?
+		ORDER BY created_at, id
+	`, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get archived events: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var events []*types.Event
+	for rows.Next() {
+		var event types.Event
+		var oldValue, newValue, comment sql.NullString
+		if err := rows.Scan(&event.ID, &event.IssueID, &event.EventType, &event.Actor, &oldValue, &newValue, &comment, &event.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan archived event: %w", err)
+		}
+		if oldValue.Valid {
+			event.OldValue = &oldValue.String
+		}
+		if newValue.Valid {
+			event.NewValue = &newValue.String
+		}
+		if comment.Valid {
+			event.Comment = &comment.String
+		}
+		events = append(events, &event)
+	}
+	return events, wrapDBError("iterate archived events", rows.Err())
+}
+
+// FilterArchivedIDs returns the subset of ids held in the archive, in input
+// order without duplicates.
+func (s *SQLiteStorage) FilterArchivedIDs(ctx context.Context, ids []string) ([]string, error) {
+	s.checkFreshness()
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	found := make(map[string]bool)
+	for start := 0; start < len(ids); start += idChunkSize {
+		chunk := ids[start:min(start+idChunkSize, len(ids))]
+		args := make([]interface{}, len(chunk))
+		for i, id := range chunk {
+			args[i] = id
+		}
+		// #nosec G201 - only placeholders are interpolated
+		rows, err := s.db.QueryContext(ctx, fmt.Sprintf(`SELECT id FROM archived_issues WHERE id IN (%s)`, buildPlaceholders(len(chunk))), args...)
+		if err != nil {
+			return nil, wrapDBError("filter archived IDs", err)
+		}
+		for rows.Next() {
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				_ = rows.Close()
+				return nil, wrapDBError("scan archived ID", err)
+			}
+			found[id] = true
+		}
+		if err := rows.Close(); err != nil {
+			return nil, wrapDBError("filter archived IDs", err)
+		}
+		if err := rows.Err(); err != nil {
+			return nil, wrapDBError("filter archived IDs", err)
+		}
+	}
+
+	var archived []string
+	for _, id := range ids {
+		if found[id] {
+			archived = append(archived, id)
+			delete(found, id)
+		}
+	}
+	return archived, nil
+}
+
+// archiveCandidates returns the IDs of the issues closed before olderThan,
+// less those that an issue staying active still needs: a candidate with a
+// dependent or hierarchical descendant outside the set is kept, and so is
+// everything it depends on or descends from, transitively.
+//
+// Both lookups are set-based, so the cost does not grow with the square of
+// the number of candidates while the write lock is held: descendants are an
+// ID range over the primary key ("bd-1." <= id < "bd-1/").
+func archiveCandidates(ctx context.Context, conn *sql.Conn, olderThan time.Time) ([]string, error) {
+	cutoff := olderThan.UTC().Format("2006-01-02 15:04:05")
+	const candidatesCTE = `
+		WITH candidates AS (
+			SELECT id FROM issues
+			WHERE status = ? AND closed_at IS NOT NULL AND julianday(closed_at) < julianday(?)
+		)`
+
+	// Candidates flagged pinned when something outside the set needs them
+	rows, err := conn.QueryContext(ctx, candidatesCTE+`
+		SELECT c.id,
+		       EXISTS (
+		           SELECT 1 FROM dependencies d
+		           WHERE d.depends_on_id = c.id AND d.issue_id != c.id
+		             AND d.issue_id NOT IN (SELECT id FROM candidates)
+		       ) OR EXISTS (
+		           SELECT 1 FROM issues i
+		           WHERE i.id >= c.id || '.' AND i.id < c.id || '/'
+		             AND i.id NOT IN (SELECT id FROM candidates)
+		       )
+		FROM candidates c
+	`, types.StatusClosed, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find closed issues to archive: %w", err)
+	}
+	set := make(map[string]bool)
+	var pinned []string
+	for rows.Next() {
+		var id string
+		var needed bool
+		if err := rows.Scan(&id, &needed); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("failed to scan closed issue: %w", err)
+		}
+		set[id] = true
+		if needed {
+			pinned = append(pinned, id)
+		}
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate closed issues: %w", err)
+	}
+	if len(pinned) == 0 {
+		return sortedKeys(set), nil
+	}
+
+	// needs[id] lists the candidates that id depends on or descends from
+	needs := make(map[string][]string)
+	rows, err = conn.QueryContext(ctx, candidatesCTE+`
+		SELECT d.issue_id, d.depends_on_id FROM dependencies d
+		WHERE d.issue_id != d.depends_on_id
+		  AND d.issue_id IN (SELECT id FROM candidates)
+		  AND d.depends_on_id IN (SELECT id FROM candidates)
+	`, types.StatusClosed, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find dependencies between closed issues: %w", err)
+	}
+	for rows.Next() {
+		var id, dependsOn string
+		if err := rows.Scan(&id, &dependsOn); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("failed to scan dependency: %w", err)
+		}
+		needs[id] = append(needs[id], dependsOn)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate dependencies: %w", err)
+	}
+	for id := range set {
+		for i := strings.LastIndex(id, "."); i > 0; i = strings.LastIndex(id[:i], ".") {
+			if ancestor := id[:i]; set[ancestor] {
+				needs[id] = append(needs[id], ancestor)
+			}
+		}
+	}
+
+	for len(pinned) > 0 {
+		id := pinned[len(pinned)-1]
+		pinned = pinned[:len(pinned)-1]
+		if !set[id] {
+			continue
+		}
+		delete(set, id)
+		for _, needed := range needs[id] {
+			if set[needed] {
+				pinned = append(pinned, needed)
+			}
+		}
+	}
+	return sortedKeys(set), nil
+}
+
+// sortedKeys returns the keys of set in ascending order.
+func sortedKeys(set map[string]bool) []string {
+	ids := make([]string, 0, len(set))
+	for id := range set {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// archiveIssue copies one issue and its events into the archive tables and
+// removes it from the active ones, as DeleteIssue does.
+func archiveIssue(ctx context.Context, tx *sqliteTxStorage, id string, now time.Time) error {
+	issue, err := tx.GetIssue(ctx, id)
+	if err != nil {
+		return err
+	}
+	if issue == nil {
+		return fmt.Errorf("issue %s: %w", id, ErrNotFound)
+	}
+	if issue.Dependencies, err = tx.GetDependencyRecords(ctx, id); err != nil {
+		return err
+	}
+	if issue.Comments, err = tx.GetIssueComments(ctx, id); err != nil {
+		return err
+	}
+	record := archiveRecord{Issue: issue, CustomFields: issue.CustomFields}
+	conn := tx.conn
+	if record.Aliases, err = archiveAliases(ctx, conn, id); err != nil {
+		return err
+	}
+	if record.Provenance, err = archiveProvenance(ctx, conn, id); err != nil {
+		return err
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode issue %s for the archive: %w", id, err)
+	}
+
+	if _, err := conn.ExecContext(ctx, `
+		INSERT INTO archived_issues (id, title, description, closed_at, archived_at, data)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			title = excluded.title, description = excluded.description, closed_at = excluded.closed_at,
+			archived_at = excluded.archived_at, data = excluded.data
+	`, id, issue.Title, issue.Description, issue.ClosedAt, now, string(data)); err != nil {
+		return fmt.Errorf("failed to archive issue %s: %w", id, err)
+	}
+	if _, err := conn.ExecContext(ctx, `
+		INSERT OR IGNORE INTO archived_events (id, issue_id, event_type, actor, old_value, new_value, comment, created_at)
+		SELECT id, issue_id, event_type, actor, old_value, new_value, comment, created_at
+		FROM events WHERE issue_id = ?
+	`, id); err != nil {
+		return fmt.Errorf("failed to archive events of %s: %w", id, err)
+	}
+
+	if _, err := conn.ExecContext(ctx, `DELETE FROM dependencies WHERE issue_id = ? OR depends_on_id = ?`, id, id); err != nil {
+		return fmt.Errorf("failed to delete dependencies of %s: %w", id, err)
+	}
+	// Comments have no FK cascade; the other per-issue tables cascade
+	for _, table := range []string{"events", "comments", "dirty_issues"} {
+		// #nosec G201 - table names are constants
+		if _, err := conn.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s WHERE issue_id = ?`, table), id); err != nil {
+			return fmt.Errorf("failed to delete %s of %s: %w", table, id, err)
+		}
+	}
+	if _, err := conn.ExecContext(ctx, `DELETE FROM issues WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete archived issue %s: %w", id, err)
+	}
+	return nil
+}
+
+// archiveAliases returns the ID aliases of issue id, which the issue's
+// deletion cascades away, for its archive record.
+func archiveAliases(ctx context.Context, conn *sql.Conn, id string) ([]string, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT alias_id FROM id_aliases WHERE issue_id = ? ORDER BY alias_id`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get aliases of %s: %w", id, err)
+	}
+	defer func() { _ = rows.Close() }()
+	var aliases []string
+	for rows.Next() {
+		var alias string
+		if err := rows.Scan(&alias); err != nil {
+			return nil, fmt.Errorf("failed to scan alias of %s: %w", id, err)
+		}
+		aliases = append(aliases, alias)
+	}
+	return aliases, wrapDBError("iterate aliases", rows.Err())
+}
+
+// archiveProvenance returns the field provenance of issue id, which the
+// issue's deletion cascades away, for its archive record.
+func archiveProvenance(ctx context.Context, conn *sql.Conn, id string) ([]FieldProvenance, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT field, source, updated_at FROM field_provenance WHERE issue_id = ? ORDER BY field`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get field provenance of %s: %w", id, err)
+	}
+	defer func() { _ = rows.Close() }()
+	var provenance []FieldProvenance
+	for rows.Next() {
+		var p FieldProvenance
+		if err := rows.Scan(&p.Field, &p.Source, &p.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan field provenance of %s: %w", id, err)
+		}
+		provenance = append(provenance, p)
+	}
+	return provenance, wrapDBError("iterate field provenance", rows.Err())
+}
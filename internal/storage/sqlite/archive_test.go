@@ -0,0 +1,207 @@
+package sqlite
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestArchiveClosedIssues(t *testing.T) {
+	env := newTestEnv(t)
+	longAgo := time.Now().AddDate(0, 0, -100)
+
+	// closeAt closes issue and backdates its closing to at
+	closeAt := func(issue *types.Issue, at time.Time) {
+		t.Helper()
+		env.Close(issue, "done")
+		if _, err := env.Store.db.ExecContext(env.Ctx, `UPDATE issues SET closed_at = ? WHERE id = ?`, at, issue.ID); err != nil {
+			t.Fatalf("failed to backdate %s: %v", issue.ID, err)
+		}
+	}
+
+	old := env.CreateIssueWithID("bd-old", "Old login bug")
+	older := env.CreateIssueWithID("bd-older", "Older schema work")
+	recent := env.CreateIssueWithID("bd-recent", "Recent fix")
+	needed := env.CreateIssueWithID("bd-needed", "Still referenced")
+	active := env.CreateIssueWithID("bd-active", "Open work")
+	parent := env.CreateIssueWithID("bd-parent", "Closed epic")
+	env.CreateIssueWithID("bd-parent.1", "Open child")
+
+	env.AddDep(old, older)
+	env.AddDep(active, needed)
+	if err := env.Store.AddLabel(env.Ctx, old.ID, "auth", "test"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+	if _, err := env.Store.AddIssueComment(env.Ctx, old.ID, "alice", "fixed by rotating keys"); err != nil {
+		t.Fatalf("AddIssueComment failed: %v", err)
+	}
+	for _, issue := range []*types.Issue{older, old, needed, parent} {
+		closeAt(issue, longAgo)
+	}
+	closeAt(recent, time.Now().Add(-time.Hour))
+
+	archived, err := env.Store.ArchiveClosedIssues(env.Ctx, time.Now().AddDate(0, 0, -30))
+	if err != nil {
+		t.Fatalf("ArchiveClosedIssues failed: %v", err)
+	}
+	if got := strings.Join(archived, ","); got != "bd-old,bd-older" {
+		t.Fatalf("archived = %s, want bd-old,bd-older (bd-needed and bd-parent are still needed)", got)
+	}
+
+	for _, id := range []string{"bd-old", "bd-older"} {
+		if got, err := env.Store.GetIssue(env.Ctx, id); err != nil || got != nil {
+			t.Errorf("GetIssue(%s) = %v, %v; want it gone from the active table", id, got, err)
+		}
+	}
+	for _, id := range []string{"bd-recent", "bd-needed", "bd-parent", "bd-active"} {
+		if got, _ := env.Store.GetIssue(env.Ctx, id); got == nil {
+			t.Errorf("%s should have stayed active", id)
+		}
+	}
+	var activeEvents int
+	if err := env.Store.db.QueryRowContext(env.Ctx, `SELECT COUNT(*) FROM events WHERE issue_id = ?`, old.ID).Scan(&activeEvents); err != nil {
+		t.Fatalf("failed to count events: %v", err)
+	}
+	if activeEvents != 0 {
+		t.Errorf("%d events of bd-old left in the active table", activeEvents)
+	}
+
+	t.Run("search", func(t *testing.T) {
+		found, err := env.Store.SearchArchive(env.Ctx, "LOGIN", 0)
+		if err != nil {
+			t.Fatalf("SearchArchive failed: %v", err)
+		}
+		if len(found) != 1 || found[0].ID != old.ID {
+			t.Fatalf("search = %v, want bd-old", found)
+		}
+		got := found[0]
+		if got.Status != types.StatusClosed || got.ClosedAt == nil || strings.Join(got.Labels, ",") != "auth" {
+			t.Errorf("archived issue = %+v, want it closed with its labels", got)
+		}
+		if len(got.Dependencies) != 1 || got.Dependencies[0].DependsOnID != older.ID {
+			t.Errorf("dependencies = %+v, want the one on bd-older", got.Dependencies)
+		}
+		if len(got.Comments) != 1 || got.Comments[0].Text != "fixed by rotating keys" {
+			t.Errorf("comments = %+v, want the archived comment", got.Comments)
+		}
+
+		all, err := env.Store.SearchArchive(env.Ctx, "", 1)
+		if err != nil || len(all) != 1 {
+			t.Errorf("SearchArchive with limit 1 = %v, %v", all, err)
+		}
+		if none, _ := env.Store.SearchArchive(env.Ctx, "%", 0); len(none) != 0 {
+			t.Errorf("a literal %% matched %v", none)
+		}
+	})
+
+	t.Run("events", func(t *testing.T) {
+		events, err := env.Store.GetArchivedEvents(env.Ctx, old.ID)
+		if err != nil {
+			t.Fatalf("GetArchivedEvents failed: %v", err)
+		}
+		if len(events) < 2 || events[0].EventType != types.EventCreated || events[len(events)-1].EventType != types.EventClosed {
+			t.Errorf("archived events = %+v, want the history from creation to close", events)
+		}
+	})
+
+	t.Run("filter", func(t *testing.T) {
+		got, err := env.Store.FilterArchivedIDs(env.Ctx, []string{"bd-recent", "bd-older", "bd-old", "bd-older"})
+		if err != nil {
+			t.Fatalf("FilterArchivedIDs failed: %v", err)
+		}
+		if strings.Join(got, ",") != "bd-older,bd-old" {
+			t.Errorf("FilterArchivedIDs = %v, want bd-older,bd-old", got)
+		}
+	})
+
+	t.Run("retention", func(t *testing.T) {
+		if got, err := env.Store.ArchiveClosedIssuesByRetention(env.Ctx); err != nil || got != nil {
+			t.Errorf("without a window = %v, %v; want nothing archived", got, err)
+		}
+		// Once bd-active is closed and archived, bd-needed can follow
+		closeAt(active, longAgo)
+		if err := env.Store.SetConfig(env.Ctx, ArchiveAfterDaysConfigKey, "30"); err != nil {
+			t.Fatalf("SetConfig failed: %v", err)
+		}
+		got, err := env.Store.ArchiveClosedIssuesByRetention(env.Ctx)
+		if err != nil {
+			t.Fatalf("ArchiveClosedIssuesByRetention failed: %v", err)
+		}
+		if strings.Join(got, ",") != "bd-active,bd-needed" {
+			t.Errorf("archived = %v, want bd-active,bd-needed", got)
+		}
+		if err := env.Store.SetConfig(env.Ctx, ArchiveAfterDaysConfigKey, "soon"); err != nil {
+			t.Fatalf("SetConfig failed: %v", err)
+		}
+		if _, err := env.Store.ArchiveClosedIssuesByRetention(env.Ctx); err == nil {
+			t.Error("expected an invalid window to fail")
+		}
+	})
+}
+
+func TestArchiveClosedIssues_KeepsWhatActiveIssuesNeed(t *testing.T) {
+	env := newTestEnv(t)
+	longAgo := time.Now().AddDate(0, 0, -100)
+	closed := func(id string) *types.Issue {
+		t.Helper()
+		issue := env.CreateIssueWithID(id, "Issue "+id)
+		env.Close(issue, "done")
+		if _, err := env.Store.db.ExecContext(env.Ctx, `UPDATE issues SET closed_at = ? WHERE id = ?`, longAgo, id); err != nil {
+			t.Fatalf("failed to backdate %s: %v", id, err)
+		}
+		return issue
+	}
+
+	// An open issue needing bd-mid keeps bd-mid and, through it, bd-deep
+	deep := closed("bd-deep")
+	mid := closed("bd-mid")
+	env.AddDep(mid, deep)
+	env.AddDep(env.CreateIssueWithID("bd-open", "Open"), mid)
+	// An open grandchild keeps its closed parent and grandparent
+	closed("bd-gp")
+	closed("bd-gp.1")
+	env.CreateIssueWithID("bd-gp.1.1", "Open grandchild")
+	// bd-gpx shares a prefix with bd-gp but is not its descendant
+	closed("bd-gpx")
+
+	target := closed("bd-target")
+	if err := env.Store.AddWatcher(env.Ctx, target.ID, "alice", "test"); err != nil {
+		t.Fatalf("AddWatcher failed: %v", err)
+	}
+	if err := env.Store.AddIDAlias(env.Ctx, "old-7", target.ID); err != nil {
+		t.Fatalf("AddIDAlias failed: %v", err)
+	}
+	if err := env.Store.RecordFieldProvenance(env.Ctx, target.ID, []string{"title"}, "jira", longAgo); err != nil {
+		t.Fatalf("RecordFieldProvenance failed: %v", err)
+	}
+
+	archived, err := env.Store.ArchiveClosedIssues(env.Ctx, time.Now().AddDate(0, 0, -30))
+	if err != nil {
+		t.Fatalf("ArchiveClosedIssues failed: %v", err)
+	}
+	if got := strings.Join(archived, ","); got != "bd-gpx,bd-target" {
+		t.Fatalf("archived = %s, want bd-gpx,bd-target", got)
+	}
+
+	// Rows the deletion cascades away are kept in the archive record
+	var data string
+	if err := env.Store.db.QueryRowContext(env.Ctx, `SELECT data FROM archived_issues WHERE id = ?`, target.ID).Scan(&data); err != nil {
+		t.Fatalf("failed to read archive record: %v", err)
+	}
+	var record archiveRecord
+	if err := json.Unmarshal([]byte(data), &record); err != nil {
+		t.Fatalf("failed to decode archive record: %v", err)
+	}
+	if strings.Join(record.Issue.Watchers, ",") != "alice" {
+		t.Errorf("watchers = %v, want alice", record.Issue.Watchers)
+	}
+	if strings.Join(record.Aliases, ",") != "old-7" {
+		t.Errorf("aliases = %v, want old-7", record.Aliases)
+	}
+	if len(record.Provenance) != 1 || record.Provenance[0].Field != "title" || record.Provenance[0].Source != "jira" {
+		t.Errorf("provenance = %+v, want title from jira", record.Provenance)
+	}
+}
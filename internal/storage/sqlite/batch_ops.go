@@ -14,12 +14,12 @@ import (
 // validateBatchIssues validates all issues in a batch and sets timestamps if not provided
 // Uses built-in statuses and types only for backward compatibility.
 func validateBatchIssues(issues []*types.Issue) error {
-	return validateBatchIssuesWithCustom(issues, nil, nil)
+	return validateBatchIssuesWithCustom(issues, nil, nil, types.DefaultFieldLimits(), nil)
 }
 
 // validateBatchIssuesWithCustom validates all issues in a batch,
 // allowing custom statuses and types in addition to built-in ones.
-func validateBatchIssuesWithCustom(issues []*types.Issue, customStatuses, customTypes []string) error {
+func validateBatchIssuesWithCustom(issues []*types.Issue, customStatuses, customTypes []string, limits types.FieldLimits, custom IssueValidator) error {
 	now := time.Now()
 	for i, issue := range issues {
 		if issue == nil {
@@ -55,7 +55,7 @@ func validateBatchIssuesWithCustom(issues []*types.Issue, customStatuses, custom
 			issue.DeletedAt = &deletedAt
 		}
 
-		if err := issue.ValidateWithCustom(customStatuses, customTypes); err != nil {
+		if err := validateIssueWithLimits(issue, customStatuses, customTypes, limits, custom); err != nil {
 			return fmt.Errorf("validation failed for issue %d: %w", i, err)
 		}
 	}
@@ -73,6 +73,9 @@ func (s *SQLiteStorage) generateBatchIDs(ctx context.Context, conn *sql.Conn, is
 	} else if err != nil {
 		return fmt.Errorf("failed to get config: %w", err)
 	}
+	if err := checkConfiguredPrefix(ctx, conn, prefix); err != nil {
+		return err
+	}
 
 	// Generate or validate IDs for all issues
 	if err := EnsureIDs(ctx, conn, prefix, issues, actor, orphanHandling, skipPrefixValidation); err != nil {
@@ -80,9 +83,10 @@ func (s *SQLiteStorage) generateBatchIDs(ctx context.Context, conn *sql.Conn, is
 	}
 	
 	// Compute content hashes
+	hashOpts := getHashOptions(ctx, conn)
 	for i := range issues {
 		if issues[i].ContentHash == "" {
-			issues[i].ContentHash = issues[i].ComputeContentHash()
+			issues[i].ContentHash = issues[i].ComputeContentHashWith(hashOpts)
 		}
 	}
 	return nil
@@ -156,24 +160,28 @@ func checkForExistingIDs(ctx context.Context, conn *sql.Conn, issues []*types.Is
 		return nil
 	}
 
-	// Check for existing IDs in database using a single query with IN clause
-	placeholders := make([]string, len(ids))
-	args := make([]interface{}, len(ids))
-	for i, id := range ids {
-		placeholders[i] = "?"
-		args[i] = id
-	}
+	// Check for existing IDs in database with one IN query per chunk of IDs,
+	// so large batches stay under SQLite's host parameter limit
+	for start := 0; start < len(ids); start += idChunkSize {
+		chunk := ids[start:min(start+idChunkSize, len(ids))]
+		placeholders := make([]string, len(chunk))
+		args := make([]interface{}, len(chunk))
+		for i, id := range chunk {
+			placeholders[i] = "?"
+			args[i] = id
+		}
 
-	query := fmt.Sprintf("SELECT id FROM issues WHERE id IN (%s) LIMIT 1", strings.Join(placeholders, ","))
-	var existingID string
-	err := conn.QueryRowContext(ctx, query, args...).Scan(&existingID)
-	if err == nil {
-		// Found an existing ID
-		return fmt.Errorf("issue ID %s already exists", existingID)
-	}
-	if err != sql.ErrNoRows {
-		// Unexpected error
-		return fmt.Errorf("failed to check for existing IDs: %w", err)
+		query := fmt.Sprintf("SELECT id FROM issues WHERE id IN (%s) LIMIT 1", strings.Join(placeholders, ","))
+		var existingID string
+		err := conn.QueryRowContext(ctx, query, args...).Scan(&existingID)
+		if err == nil {
+			// Found an existing ID
+			return fmt.Errorf("issue ID %s already exists", existingID)
+		}
+		if err != sql.ErrNoRows {
+			// Unexpected error
+			return fmt.Errorf("failed to check for existing IDs: %w", err)
+		}
 	}
 
 	return nil
@@ -249,6 +257,9 @@ func (s *SQLiteStorage) CreateIssuesWithFullOptions(ctx context.Context, issues
 	if len(issues) == 0 {
 		return nil
 	}
+	if err := checkActor(ctx, s.db, actor); err != nil {
+		return err
+	}
 
 	// Fetch custom statuses and types for validation
 	customStatuses, err := s.GetCustomStatuses(ctx)
@@ -261,7 +272,10 @@ func (s *SQLiteStorage) CreateIssuesWithFullOptions(ctx context.Context, issues
 	}
 
 	// Phase 1: Validate all issues first (fail-fast, with custom status and type support)
-	if err := validateBatchIssuesWithCustom(issues, customStatuses, customTypes); err != nil {
+	if err := validateBatchIssuesWithCustom(issues, customStatuses, customTypes, getFieldLimits(ctx, s.db), s.issueValidator()); err != nil {
+		return err
+	}
+	if err := assignExternalRefs(ctx, s.db, issues...); err != nil {
 		return err
 	}
 
@@ -275,7 +289,7 @@ func (s *SQLiteStorage) CreateIssuesWithFullOptions(ctx context.Context, issues
 	// Start IMMEDIATE transaction to acquire write lock early.
 	// The connection's busy_timeout pragma (30s) handles retries if locked.
 	if _, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
-		return fmt.Errorf("failed to begin immediate transaction: %w", err)
+		return fmt.Errorf("failed to begin immediate transaction: %w", markBusy(err))
 	}
 
 	committed := false
@@ -318,7 +332,7 @@ func (s *SQLiteStorage) CreateIssuesWithFullOptions(ctx context.Context, issues
 
 	// Phase 7: Commit transaction
 	if _, err := conn.ExecContext(ctx, "COMMIT"); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+		return fmt.Errorf("failed to commit transaction: %w", markBusy(err))
 	}
 	committed = true
 	return nil
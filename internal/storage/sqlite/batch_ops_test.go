@@ -2,6 +2,7 @@ package sqlite
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -686,3 +687,84 @@ func TestGH956_BatchCreateExistingID(t *testing.T) {
 		}
 	})
 }
+
+func TestBatchCreateIssues_ChunksIDCheck(t *testing.T) {
+	s, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	// Enough issues for the existing-ID check to span three IN queries
+	n := 2*idChunkSize + 1
+	batch := func(tag string) []*types.Issue {
+		issues := make([]*types.Issue, n)
+		for i := range issues {
+			issues[i] = &types.Issue{
+				ID:        fmt.Sprintf("bd-%s%04d", tag, i),
+				Title:     fmt.Sprintf("Chunked %d", i),
+				Priority:  2,
+				IssueType: types.TypeTask,
+				Status:    types.StatusOpen,
+			}
+		}
+		return issues
+	}
+
+	if err := s.CreateIssues(ctx, batch("c"), "test"); err != nil {
+		t.Fatalf("CreateIssues failed: %v", err)
+	}
+	var count int
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM issues WHERE id LIKE 'bd-c%'`).Scan(&count); err != nil {
+		t.Fatalf("failed to count issues: %v", err)
+	}
+	if count != n {
+		t.Errorf("stored %d issues, want %d", count, n)
+	}
+	last, err := s.GetIssue(ctx, fmt.Sprintf("bd-c%04d", n-1))
+	if err != nil || last == nil || last.Title != fmt.Sprintf("Chunked %d", n-1) {
+		t.Errorf("last issue = %+v, %v; want it stored intact", last, err)
+	}
+
+	// An ID that already exists in the last chunk is still caught
+	again := batch("d")
+	again[n-1].ID = fmt.Sprintf("bd-c%04d", n-1)
+	err = s.CreateIssues(ctx, again, "test")
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("err = %v, want the existing ID in the last chunk reported", err)
+	}
+	if got, _ := s.GetIssue(ctx, "bd-d0000"); got != nil {
+		t.Error("a rejected batch should not create any of its issues")
+	}
+
+	// With the blob store enabled, every description in the batch is packed
+	// and each one reads back intact
+	if err := s.SetConfig(ctx, DescriptionBlobThresholdConfigKey, "16"); err != nil {
+		t.Fatalf("SetConfig(%s) failed: %v", DescriptionBlobThresholdConfigKey, err)
+	}
+	blobbed := batch("e")
+	for i, issue := range blobbed {
+		issue.Description = fmt.Sprintf("Long enough description %d", i)
+	}
+	if err := s.CreateIssues(ctx, blobbed, "test"); err != nil {
+		t.Fatalf("CreateIssues with blob threshold failed: %v", err)
+	}
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM issues WHERE id LIKE 'bd-e%' AND description LIKE ?`, descriptionBlobRefPrefix+"%").Scan(&count); err != nil {
+		t.Fatalf("failed to count blob references: %v", err)
+	}
+	if count != n {
+		t.Errorf("packed %d descriptions, want %d", count, n)
+	}
+	found, err := s.SearchIssues(ctx, "", types.IssueFilter{IDPrefix: "bd-e"})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(found) != n {
+		t.Fatalf("found %d issues, want %d", len(found), n)
+	}
+	for _, issue := range found {
+		var i int
+		if _, err := fmt.Sscanf(issue.ID, "bd-e%04d", &i); err != nil || issue.Description != fmt.Sprintf("Long enough description %d", i) {
+			t.Errorf("issue %s description = %q, want it hydrated", issue.ID, issue.Description)
+			break
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ListIssuesWithBlockedStatus returns the issues matching filter, ordered like
+// SearchIssues, each flagged Blocked when it has a 'blocks' dependency on an
+// issue that is neither closed nor tombstoned. Tombstoned blockers count as
+// satisfied, as do dependencies on issues not in this database (external or
+// dangling refs). The flags come from one query over the dependencies rather
+// than a lookup per issue.
+func (s *SQLiteStorage) ListIssuesWithBlockedStatus(ctx context.Context, filter types.IssueFilter) ([]*types.IssueWithBlockedStatus, error) {
+	issues, err := s.SearchIssues(ctx, "", filter)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*types.IssueWithBlockedStatus, len(issues))
+	for i, issue := range issues {
+		results[i] = &types.IssueWithBlockedStatus{Issue: issue}
+	}
+	if len(issues) == 0 {
+		return results, nil
+	}
+
+	blocked, err := s.blockedAmong(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range results {
+		r.Blocked = blocked[r.ID]
+	}
+	return results, nil
+}
+
+// blockedAmong returns the IDs of the issues matching filter that have an open
+// 'blocks' dependency. filter.Limit is ignored; callers pick the flags they need.
+func (s *SQLiteStorage) blockedAmong(ctx context.Context, filter types.IssueFilter) (map[string]bool, error) {
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	whereClauses, args := buildIssueFilterClauses("", filter)
+	whereSQL := ""
+	if len(whereClauses) > 0 {
+		whereSQL = "WHERE " + strings.Join(whereClauses, " AND ")
+	}
+
+	// #nosec G201 - safe SQL with controlled formatting
+	query := fmt.Sprintf(`
+		SELECT DISTINCT d.issue_id
+		FROM dependencies d
+		JOIN issues blocker ON blocker.id = d.depends_on_id
+		WHERE d.type = ?
+		  AND blocker.status NOT IN ('closed', 'tombstone')
+		  AND d.issue_id IN (SELECT id FROM issues %s)
+	`, whereSQL)
+
+	rows, err := s.db.QueryContext(ctx, query, append([]interface{}{types.DepBlocks}, args...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute blocked status: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	blocked := make(map[string]bool)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan blocked issue: %w", err)
+		}
+		blocked[id] = true
+	}
+	return blocked, rows.Err()
+}
@@ -0,0 +1,69 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestListIssuesWithBlockedStatus(t *testing.T) {
+	env := newTestEnv(t)
+
+	// chain: c blocks b blocks a; d is blocked only by a tombstone; e only
+	// relates to an open issue
+	a := env.CreateIssueWithID("bd-a", "Top")
+	b := env.CreateIssueWithID("bd-b", "Middle")
+	c := env.CreateIssueWithID("bd-c", "Bottom")
+	d := env.CreateIssueWithID("bd-d", "Behind tombstone")
+	e := env.CreateIssueWithID("bd-e", "Related only")
+	gone := env.CreateIssueWithID("bd-gone", "Deleted blocker")
+	env.AddDep(a, b)
+	env.AddDep(b, c)
+	env.AddDep(d, gone)
+	env.AddDepType(e, c, types.DepRelated)
+	if err := env.Store.CreateTombstone(env.Ctx, gone.ID, "test-user", "obsolete"); err != nil {
+		t.Fatalf("CreateTombstone failed: %v", err)
+	}
+
+	flags := func(filter types.IssueFilter) map[string]bool {
+		t.Helper()
+		results, err := env.Store.ListIssuesWithBlockedStatus(env.Ctx, filter)
+		if err != nil {
+			t.Fatalf("ListIssuesWithBlockedStatus failed: %v", err)
+		}
+		got := make(map[string]bool, len(results))
+		for _, r := range results {
+			got[r.ID] = r.Blocked
+		}
+		return got
+	}
+
+	want := map[string]bool{"bd-a": true, "bd-b": true, "bd-c": false, "bd-d": false, "bd-e": false}
+	got := flags(types.IssueFilter{})
+	for id, blocked := range want {
+		if got[id] != blocked {
+			t.Errorf("%s: expected blocked=%v, got %v", id, blocked, got[id])
+		}
+	}
+
+	// Closing the bottom of the chain unblocks only its direct dependent
+	env.Close(c, "done")
+	got = flags(types.IssueFilter{})
+	if got["bd-b"] || !got["bd-a"] {
+		t.Errorf("after closing bd-c expected bd-b unblocked and bd-a blocked, got %v", got)
+	}
+
+	// Filters narrow the listing without changing the flags
+	got = flags(types.IssueFilter{IDs: []string{"bd-a", "bd-d"}})
+	if len(got) != 2 || !got["bd-a"] || got["bd-d"] {
+		t.Errorf("expected only bd-a (blocked) and bd-d (unblocked), got %v", got)
+	}
+
+	results, err := env.Store.ListIssuesWithBlockedStatus(env.Ctx, types.IssueFilter{IDs: []string{"bd-missing"}})
+	if err != nil {
+		t.Fatalf("ListIssuesWithBlockedStatus failed: %v", err)
+	}
+	if results == nil || len(results) != 0 {
+		t.Errorf("expected empty non-nil result, got %v", results)
+	}
+}
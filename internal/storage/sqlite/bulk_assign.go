@@ -0,0 +1,62 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// BulkAssign assigns every issue in ids to assignee in one transaction, as
+// UpdateIssue would one at a time: content hashes are recomputed, and each
+// updated issue gets its own update event and is marked dirty.
+//
+// assignee is validated before anything is read: it must be non-empty and
+// carry no surrounding whitespace (unassigning is left to UpdateIssue).
+// Issues already assigned to assignee are reported Unchanged and left alone;
+// missing IDs and tombstones are reported NotFound. Any other failure rolls
+// back the whole batch.
+func (s *SQLiteStorage) BulkAssign(ctx context.Context, ids []string, assignee, actor string) (*types.BulkAssignResult, error) {
+	if assignee == "" {
+		return nil, fmt.Errorf("assignee is required")
+	}
+	if strings.TrimSpace(assignee) != assignee {
+		return nil, fmt.Errorf("assignee %q has surrounding whitespace", assignee)
+	}
+
+	result := &types.BulkAssignResult{}
+	err := s.withTx(ctx, func(conn *sql.Conn) error {
+		tx := &sqliteTxStorage{conn: conn, parent: s}
+		seen := make(map[string]bool, len(ids))
+		for _, id := range ids {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+
+			issue, err := tx.GetIssue(ctx, id)
+			if err != nil {
+				return fmt.Errorf("failed to get issue %s: %w", id, err)
+			}
+			switch {
+			case issue == nil || issue.IsTombstone():
+				result.NotFound = append(result.NotFound, id)
+				continue
+			case issue.Assignee == assignee:
+				result.Unchanged = append(result.Unchanged, id)
+				continue
+			}
+			if err := tx.updateIssue(ctx, id, map[string]interface{}{"assignee": assignee}, actor, false); err != nil {
+				return fmt.Errorf("failed to assign %s: %w", id, err)
+			}
+			result.Updated = append(result.Updated, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
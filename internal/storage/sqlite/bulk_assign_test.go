@@ -0,0 +1,77 @@
+package sqlite
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestBulkAssign(t *testing.T) {
+	env := newTestEnv(t)
+
+	a := env.CreateIssueWithID("bd-a", "First")
+	b := env.CreateIssueWithID("bd-b", "Second")
+	owned := env.CreateIssueWithID("bd-owned", "Already theirs")
+	if err := env.Store.UpdateIssue(env.Ctx, owned.ID, map[string]interface{}{"assignee": "newhire"}, "setup"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if err := env.Store.ClearDirtyIssuesByID(env.Ctx, []string{a.ID, b.ID, owned.ID}); err != nil {
+		t.Fatalf("ClearDirtyIssuesByID failed: %v", err)
+	}
+
+	events := func(id string) int {
+		t.Helper()
+		var n int
+		if err := env.Store.db.QueryRowContext(env.Ctx, `SELECT COUNT(*) FROM events WHERE issue_id = ? AND event_type = ? AND actor = ?`, id, types.EventUpdated, "admin").Scan(&n); err != nil {
+			t.Fatalf("failed to count events: %v", err)
+		}
+		return n
+	}
+
+	result, err := env.Store.BulkAssign(env.Ctx, []string{"bd-a", "bd-b", "bd-owned", "bd-missing", "bd-a"}, "newhire", "admin")
+	if err != nil {
+		t.Fatalf("BulkAssign failed: %v", err)
+	}
+	if got := strings.Join(result.Updated, ","); got != "bd-a,bd-b" {
+		t.Errorf("updated = %s, want bd-a,bd-b", got)
+	}
+	if got := strings.Join(result.Unchanged, ","); got != "bd-owned" {
+		t.Errorf("unchanged = %s, want bd-owned", got)
+	}
+	if got := strings.Join(result.NotFound, ","); got != "bd-missing" {
+		t.Errorf("not found = %s, want bd-missing", got)
+	}
+
+	for _, before := range []*types.Issue{a, b} {
+		issue, err := env.Store.GetIssue(env.Ctx, before.ID)
+		if err != nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		if issue.Assignee != "newhire" {
+			t.Errorf("%s: assignee = %q, want newhire", issue.ID, issue.Assignee)
+		}
+		if issue.ContentHash == before.ContentHash || issue.ContentHash != issue.ComputeContentHash() {
+			t.Errorf("%s: expected a recomputed content hash", issue.ID)
+		}
+		if n := events(issue.ID); n != 1 {
+			t.Errorf("%s: expected one update event, got %d", issue.ID, n)
+		}
+	}
+	if n := events("bd-owned"); n != 0 {
+		t.Errorf("expected the unchanged issue to get no event, got %d", n)
+	}
+	dirty, err := env.Store.GetDirtyIssues(env.Ctx)
+	if err != nil {
+		t.Fatalf("GetDirtyIssues failed: %v", err)
+	}
+	if got := strings.Join(dirty, ","); got != "bd-a,bd-b" {
+		t.Errorf("dirty = %s, want bd-a,bd-b", got)
+	}
+
+	for _, assignee := range []string{"", " padded "} {
+		if _, err := env.Store.BulkAssign(env.Ctx, []string{"bd-a"}, assignee, "admin"); err == nil {
+			t.Errorf("expected assignee %q rejected", assignee)
+		}
+	}
+}
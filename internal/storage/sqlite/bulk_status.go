@@ -0,0 +1,68 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// BulkUpdateStatus moves every issue in ids to newStatus in one transaction,
+// as UpdateIssue would one at a time: ClosedAt is set when an issue is closed
+// and cleared when it leaves closed, content hashes are recomputed, and each
+// updated issue gets its own status event and is marked dirty. The blocked
+// issues cache is rebuilt once at the end rather than per issue.
+//
+// newStatus is validated (built in or custom, never tombstone) before anything
+// is read. Issues already in newStatus are reported Unchanged and left alone;
+// missing IDs and tombstones are reported NotFound. Any other failure rolls
+// back the whole batch.
+func (s *SQLiteStorage) BulkUpdateStatus(ctx context.Context, ids []string, newStatus types.Status, actor string) (*types.BulkStatusResult, error) {
+	customStatuses, err := s.GetCustomStatuses(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get custom statuses: %w", err)
+	}
+	if err := validateStatusWithCustom(string(newStatus), customStatuses); err != nil {
+		return nil, err
+	}
+
+	result := &types.BulkStatusResult{}
+	err = s.withTx(ctx, func(conn *sql.Conn) error {
+		tx := &sqliteTxStorage{conn: conn, parent: s}
+		seen := make(map[string]bool, len(ids))
+		for _, id := range ids {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+
+			issue, err := tx.GetIssue(ctx, id)
+			if err != nil {
+				return fmt.Errorf("failed to get issue %s: %w", id, err)
+			}
+			switch {
+			case issue == nil || issue.IsTombstone():
+				result.NotFound = append(result.NotFound, id)
+				continue
+			case issue.Status == newStatus:
+				result.Unchanged = append(result.Unchanged, id)
+				continue
+			}
+			// A fresh map per issue: the update adds closed_at to it
+			updates := map[string]interface{}{"status": string(newStatus)}
+			if err := tx.updateIssue(ctx, id, updates, actor, false); err != nil {
+				return fmt.Errorf("failed to update status of %s: %w", id, err)
+			}
+			result.Updated = append(result.Updated, id)
+		}
+		if len(result.Updated) == 0 {
+			return nil
+		}
+		return s.invalidateBlockedCache(ctx, conn)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,109 @@
+package sqlite
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestBulkUpdateStatus(t *testing.T) {
+	env := newTestEnv(t)
+
+	a := env.CreateIssueWithID("bd-a", "First")
+	b := env.CreateIssueWithID("bd-b", "Second")
+	done := env.CreateIssueWithID("bd-done", "Already closed")
+	blocked := env.CreateIssueWithID("bd-blocked", "Waits on bd-a")
+	env.AddDep(blocked, a)
+	env.Close(done, "earlier")
+	if err := env.Store.ClearDirtyIssuesByID(env.Ctx, []string{a.ID, b.ID, done.ID, blocked.ID}); err != nil {
+		t.Fatalf("ClearDirtyIssuesByID failed: %v", err)
+	}
+	env.AssertBlocked(blocked)
+
+	events := func(id string, eventType types.EventType) int {
+		t.Helper()
+		var n int
+		if err := env.Store.db.QueryRowContext(env.Ctx, `SELECT COUNT(*) FROM events WHERE issue_id = ? AND event_type = ?`, id, eventType).Scan(&n); err != nil {
+			t.Fatalf("failed to count events: %v", err)
+		}
+		return n
+	}
+
+	t.Run("bulk close sets ClosedAt", func(t *testing.T) {
+		result, err := env.Store.BulkUpdateStatus(env.Ctx, []string{"bd-a", "bd-b", "bd-done", "bd-missing", "bd-a"}, types.StatusClosed, "triager")
+		if err != nil {
+			t.Fatalf("BulkUpdateStatus failed: %v", err)
+		}
+		if got := strings.Join(result.Updated, ","); got != "bd-a,bd-b" {
+			t.Errorf("updated = %s, want bd-a,bd-b", got)
+		}
+		if got := strings.Join(result.Unchanged, ","); got != "bd-done" {
+			t.Errorf("unchanged = %s, want bd-done", got)
+		}
+		if got := strings.Join(result.NotFound, ","); got != "bd-missing" {
+			t.Errorf("not found = %s, want bd-missing", got)
+		}
+		for _, id := range result.Updated {
+			issue, err := env.Store.GetIssue(env.Ctx, id)
+			if err != nil {
+				t.Fatalf("GetIssue failed: %v", err)
+			}
+			if issue.Status != types.StatusClosed || issue.ClosedAt == nil {
+				t.Errorf("%s: expected closed with ClosedAt, got %s/%v", id, issue.Status, issue.ClosedAt)
+			}
+			if issue.ContentHash != issue.ComputeContentHash() {
+				t.Errorf("%s: expected recomputed content hash", id)
+			}
+			if n := events(id, types.EventClosed); n != 1 {
+				t.Errorf("%s: expected one closed event, got %d", id, n)
+			}
+		}
+		if n := events("bd-done", types.EventClosed); n != 1 {
+			t.Errorf("expected the unchanged issue to get no new event, got %d closed events", n)
+		}
+		dirty, err := env.Store.GetDirtyIssues(env.Ctx)
+		if err != nil {
+			t.Fatalf("GetDirtyIssues failed: %v", err)
+		}
+		if got := strings.Join(dirty, ","); got != "bd-a,bd-b" {
+			t.Errorf("dirty = %s, want bd-a,bd-b", got)
+		}
+		env.AssertReady(blocked)
+	})
+
+	t.Run("bulk reopen clears ClosedAt", func(t *testing.T) {
+		result, err := env.Store.BulkUpdateStatus(env.Ctx, []string{"bd-a", "bd-done"}, types.StatusOpen, "triager")
+		if err != nil {
+			t.Fatalf("BulkUpdateStatus failed: %v", err)
+		}
+		if len(result.Updated) != 2 {
+			t.Fatalf("expected both issues reopened, got %+v", result)
+		}
+		for _, id := range result.Updated {
+			issue, err := env.Store.GetIssue(env.Ctx, id)
+			if err != nil {
+				t.Fatalf("GetIssue failed: %v", err)
+			}
+			if issue.Status != types.StatusOpen || issue.ClosedAt != nil {
+				t.Errorf("%s: expected open without ClosedAt, got %s/%v", id, issue.Status, issue.ClosedAt)
+			}
+			if n := events(id, types.EventReopened); n != 1 {
+				t.Errorf("%s: expected one reopened event, got %d", id, n)
+			}
+		}
+		env.AssertBlocked(blocked)
+	})
+
+	t.Run("invalid status is rejected before any write", func(t *testing.T) {
+		for _, status := range []types.Status{"bogus", types.StatusTombstone} {
+			if _, err := env.Store.BulkUpdateStatus(env.Ctx, []string{"bd-b"}, status, "triager"); err == nil {
+				t.Errorf("expected status %q rejected", status)
+			}
+		}
+		issue, _ := env.Store.GetIssue(env.Ctx, "bd-b")
+		if issue.Status != types.StatusClosed {
+			t.Errorf("expected bd-b untouched, got %s", issue.Status)
+		}
+	})
+}
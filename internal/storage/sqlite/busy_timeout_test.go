@@ -0,0 +1,87 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestNewWithTimeoutAppliesBusyTimeout(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		path    string
+		timeout time.Duration
+		want    int64
+	}{
+		{"zero", filepath.Join(dir, "zero.db"), 0, 0},
+		{"custom", filepath.Join(dir, "custom.db"), 1500 * time.Millisecond, 1500},
+		{"uri with foreign keys", "file:" + filepath.Join(dir, "uri.db") + "?_pragma=foreign_keys(ON)&_time_format=sqlite", 2 * time.Second, 2000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, err := NewWithTimeout(ctx, tt.path, tt.timeout)
+			if err != nil {
+				t.Fatalf("NewWithTimeout failed: %v", err)
+			}
+			defer store.Close()
+
+			var got int64
+			if err := store.db.QueryRowContext(ctx, "PRAGMA busy_timeout").Scan(&got); err != nil {
+				t.Fatalf("failed to read busy_timeout: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("busy_timeout = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLockContentionReturnsErrBusy(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "beads.db")
+
+	holder, err := New(ctx, path)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer holder.Close()
+	if err := holder.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+
+	waiter, err := NewWithTimeout(ctx, path, 0)
+	if err != nil {
+		t.Fatalf("NewWithTimeout failed: %v", err)
+	}
+	defer waiter.Close()
+
+	conn, err := holder.db.Conn(ctx)
+	if err != nil {
+		t.Fatalf("failed to acquire connection: %v", err)
+	}
+	defer conn.Close()
+	if _, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
+		t.Fatalf("failed to take write lock: %v", err)
+	}
+	defer func() { _, _ = conn.ExecContext(ctx, "ROLLBACK") }()
+
+	issue := &types.Issue{Title: "Blocked", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	start := time.Now()
+	err = waiter.CreateIssue(ctx, issue, "tester")
+	if !errors.Is(err, ErrBusy) {
+		t.Fatalf("expected ErrBusy, got %v", err)
+	}
+	if !IsBusyError(err) {
+		t.Errorf("IsBusyError(%v) = false", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("busy error took %v with a zero timeout", elapsed)
+	}
+}
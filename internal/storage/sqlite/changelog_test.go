@@ -0,0 +1,70 @@
+package sqlite
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestExportChangelog(t *testing.T) {
+	env := newTestEnv(t)
+
+	flaky := env.CreateIssueWithID("bd-flaky", "Flaky test")
+	old := env.CreateIssueWithID("bd-old", "Old bug")
+	late := env.CreateIssueWithID("bd-late", "After the release")
+	env.CreateIssueWithID("bd-quiet", "Only commented")
+	env.Close(flaky, "fixed")
+	if err := env.Store.UpdateIssue(env.Ctx, flaky.ID, map[string]interface{}{"status": string(types.StatusOpen)}, "test-user"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	env.Close(old, "wontfix")
+	if err := env.Store.AddComment(env.Ctx, "bd-quiet", "test-user", "still here"); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+
+	// Date every event explicitly
+	at := func(issueID string, eventType types.EventType, when string) {
+		t.Helper()
+		if _, err := env.Store.db.ExecContext(env.Ctx, `UPDATE events SET created_at = ? WHERE issue_id = ? AND event_type = ?`, when, issueID, eventType); err != nil {
+			t.Fatalf("failed to date event: %v", err)
+		}
+	}
+	at(old.ID, types.EventCreated, "2026-01-01 09:00:00")
+	at(flaky.ID, types.EventCreated, "2026-02-02 10:00:00")
+	at(flaky.ID, types.EventClosed, "2026-02-03 11:00:00")
+	at(flaky.ID, types.EventReopened, "2026-02-05 12:00:00")
+	at(old.ID, types.EventClosed, "2026-02-04 08:30:00")
+	at(late.ID, types.EventCreated, "2026-03-01 00:00:00")
+	at("bd-quiet", types.EventCreated, "2025-12-01 00:00:00")
+	at("bd-quiet", types.EventCommented, "2026-02-10 00:00:00")
+
+	from := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	var buf bytes.Buffer
+	if err := env.Store.ExportChangelog(env.Ctx, from, to, &buf); err != nil {
+		t.Fatalf("ExportChangelog failed: %v", err)
+	}
+	want := `# Changelog 2026-02-01 00:00 to 2026-03-01 00:00
+
+## bd-flaky: Flaky test
+- 2026-02-02 10:00 created
+- 2026-02-03 11:00 closed: fixed
+- 2026-02-05 12:00 reopened
+
+## bd-old: Old bug
+- 2026-02-04 08:30 closed: wontfix
+`
+	if got := buf.String(); got != want {
+		t.Errorf("unexpected changelog:\n%s\nwant:\n%s", got, want)
+	}
+
+	buf.Reset()
+	if err := env.Store.ExportChangelog(env.Ctx, to, to.AddDate(0, 0, -1), &buf); err != nil {
+		t.Fatalf("ExportChangelog failed: %v", err)
+	}
+	if want := "# Changelog 2026-03-01 00:00 to 2026-02-28 00:00\n\nNo issues were created, closed or reopened.\n"; buf.String() != want {
+		t.Errorf("unexpected empty changelog:\n%s", buf.String())
+	}
+}
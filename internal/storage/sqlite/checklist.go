@@ -0,0 +1,232 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Checklist items belong to an issue's content: they are hashed by
+// ComputeContentHash, so every change to them recomputes the issue's
+// content_hash. Positions are kept dense (1..n) so callers can address items
+// by position and reorder them with MoveChecklistItem.
+
+// AddChecklistItem appends an item to the end of an issue's checklist and
+// returns it with its position.
+func (s *SQLiteStorage) AddChecklistItem(ctx context.Context, issueID, text, actor string) (*types.ChecklistItem, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, fmt.Errorf("checklist item text cannot be empty")
+	}
+	var item *types.ChecklistItem
+	err := s.editChecklist(ctx, issueID, actor, func(items []*types.ChecklistItem) ([]*types.ChecklistItem, string, error) {
+		item = &types.ChecklistItem{IssueID: issueID, Position: len(items) + 1, Text: text}
+		return append(items, item), fmt.Sprintf("Added checklist item %d: %s", item.Position, text), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// ToggleChecklistItem flips the done flag of the item at position and returns
+// the new state.
+func (s *SQLiteStorage) ToggleChecklistItem(ctx context.Context, issueID string, position int, actor string) (bool, error) {
+	var done bool
+	err := s.editChecklist(ctx, issueID, actor, func(items []*types.ChecklistItem) ([]*types.ChecklistItem, string, error) {
+		if position < 1 || position > len(items) {
+			return nil, "", fmt.Errorf("checklist item %d of %s: %w", position, issueID, ErrNotFound)
+		}
+		item := items[position-1]
+		item.Done = !item.Done
+		done = item.Done
+		state := "undone"
+		if done {
+			state = "done"
+		}
+		return items, fmt.Sprintf("Marked checklist item %d %s: %s", position, state, item.Text), nil
+	})
+	return done, err
+}
+
+// MoveChecklistItem moves the item at position from to position to, shifting
+// the items in between.
+func (s *SQLiteStorage) MoveChecklistItem(ctx context.Context, issueID string, from, to int, actor string) error {
+	return s.editChecklist(ctx, issueID, actor, func(items []*types.ChecklistItem) ([]*types.ChecklistItem, string, error) {
+		if from < 1 || from > len(items) || to < 1 || to > len(items) {
+			return nil, "", fmt.Errorf("cannot move checklist item %d to %d: %s has %d items", from, to, issueID, len(items))
+		}
+		item := items[from-1]
+		items = append(items[:from-1], items[from:]...)
+		items = append(items[:to-1], append([]*types.ChecklistItem{item}, items[to-1:]...)...)
+		return items, fmt.Sprintf("Moved checklist item %d to %d: %s", from, to, item.Text), nil
+	})
+}
+
+// editChecklist applies edit to an issue's checklist in a transaction,
+// rewriting it, recording a checklist_changed event and bumping updated_at.
+func (s *SQLiteStorage) editChecklist(ctx context.Context, issueID, actor string, edit func([]*types.ChecklistItem) ([]*types.ChecklistItem, string, error)) error {
+	return s.withTx(ctx, func(conn *sql.Conn) error {
+		var exists int
+		if err := conn.QueryRowContext(ctx, `SELECT COUNT(*) FROM issues WHERE id = ?`, issueID).Scan(&exists); err != nil {
+			return fmt.Errorf("failed to check issue existence: %w", err)
+		}
+		if exists == 0 {
+			return fmt.Errorf("issue %s: %w", issueID, ErrNotFound)
+		}
+		items, err := getChecklist(ctx, conn, issueID)
+		if err != nil {
+			return err
+		}
+		items, comment, err := edit(items)
+		if err != nil {
+			return err
+		}
+		tx := &sqliteTxStorage{conn: conn, parent: s}
+		return tx.writeChecklist(ctx, issueID, items, actor, comment, time.Now())
+	})
+}
+
+// SetChecklist replaces an issue's checklist, as imports do. See
+// sqliteTxStorage.SetChecklist.
+func (s *SQLiteStorage) SetChecklist(ctx context.Context, issueID string, items []*types.ChecklistItem, actor string) error {
+	return s.withTx(ctx, func(conn *sql.Conn) error {
+		tx := &sqliteTxStorage{conn: conn, parent: s}
+		return tx.SetChecklist(ctx, issueID, items, actor)
+	})
+}
+
+// SetChecklist replaces an issue's checklist within the transaction. Items are
+// stored in Position order and renumbered 1..n. Unlike the edit methods it
+// leaves updated_at alone, so imports keep the timestamps they carry.
+func (t *sqliteTxStorage) SetChecklist(ctx context.Context, issueID string, items []*types.ChecklistItem, actor string) error {
+	sorted := make([]*types.ChecklistItem, 0, len(items))
+	for _, item := range items {
+		if item != nil {
+			sorted = append(sorted, item)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Position < sorted[j].Position })
+	return t.writeChecklist(ctx, issueID, sorted, actor, fmt.Sprintf("Set checklist (%d items)", len(sorted)), time.Time{})
+}
+
+// GetChecklist retrieves an issue's checklist within the transaction.
+func (t *sqliteTxStorage) GetChecklist(ctx context.Context, issueID string) ([]*types.ChecklistItem, error) {
+	return getChecklist(ctx, t.conn, issueID)
+}
+
+// writeChecklist stores items as issueID's checklist, then records the event,
+// marks the issue dirty and recomputes its content hash. A non-zero updatedAt
+// is also written to the issue.
+func (t *sqliteTxStorage) writeChecklist(ctx context.Context, issueID string, items []*types.ChecklistItem, actor, comment string, updatedAt time.Time) error {
+	if _, err := t.conn.ExecContext(ctx, `DELETE FROM checklist_items WHERE issue_id = ?`, issueID); err != nil {
+		return fmt.Errorf("failed to clear checklist: %w", err)
+	}
+	for i, item := range items {
+		item.IssueID = issueID
+		item.Position = i + 1
+		if _, err := t.conn.ExecContext(ctx, `
+			INSERT INTO checklist_items (issue_id, position, text, done) VALUES (?, ?, ?, ?)
+		`, issueID, item.Position, item.Text, item.Done); err != nil {
+			return fmt.Errorf("failed to add checklist item: %w", err)
+		}
+	}
+
+	issue, err := t.GetIssue(ctx, issueID)
+	if err != nil {
+		return err
+	}
+	if issue == nil {
+		return fmt.Errorf("issue %s: %w", issueID, ErrNotFound)
+	}
+	if updatedAt.IsZero() {
+		_, err = t.conn.ExecContext(ctx, `UPDATE issues SET content_hash = ? WHERE id = ?`, contentHash(ctx, t.conn, issue), issueID)
+	} else {
+		_, err = t.conn.ExecContext(ctx, `UPDATE issues SET content_hash = ?, updated_at = ? WHERE id = ?`, contentHash(ctx, t.conn, issue), updatedAt, issueID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update content hash: %w", err)
+	}
+
+	_, err = t.conn.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, comment)
+		VALUES (?, ?, ?, ?)
+	`, issueID, types.EventChecklistChanged, actor, comment)
+	if err != nil {
+		return fmt.Errorf("failed to record event: %w", err)
+	}
+	if err := markDirty(ctx, t.conn, issueID); err != nil {
+		return fmt.Errorf("failed to mark issue dirty: %w", err)
+	}
+	return nil
+}
+
+// GetChecklist returns an issue's checklist in position order.
+// Like GetWatchers, this is called from GetIssue under reconnectMu.RLock(),
+// so it does not take the lock itself.
+func (s *SQLiteStorage) GetChecklist(ctx context.Context, issueID string) ([]*types.ChecklistItem, error) {
+	return getChecklist(ctx, s.db, issueID)
+}
+
+// GetChecklistsForIssues fetches the checklists of multiple issues in a single
+// query. Returns a map of issue_id -> items in position order.
+func (s *SQLiteStorage) GetChecklistsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.ChecklistItem, error) {
+	result := make(map[string][]*types.ChecklistItem)
+	if len(issueIDs) == 0 {
+		return result, nil
+	}
+
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	args := make([]interface{}, len(issueIDs))
+	for i, id := range issueIDs {
+		args[i] = id
+	}
+	// #nosec G201 -- placeholders are generated internally
+	query := fmt.Sprintf(`
+		SELECT issue_id, position, text, done FROM checklist_items
+		WHERE issue_id IN (%s)
+		ORDER BY issue_id, position
+	`, buildPlaceholders(len(issueIDs)))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to batch get checklists: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var item types.ChecklistItem
+		if err := rows.Scan(&item.IssueID, &item.Position, &item.Text, &item.Done); err != nil {
+			return nil, err
+		}
+		result[item.IssueID] = append(result[item.IssueID], &item)
+	}
+	return result, rows.Err()
+}
+
+func getChecklist(ctx context.Context, db dbExecutor, issueID string) ([]*types.ChecklistItem, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT position, text, done FROM checklist_items WHERE issue_id = ? ORDER BY position
+	`, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get checklist: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var items []*types.ChecklistItem
+	for rows.Next() {
+		item := &types.ChecklistItem{IssueID: issueID}
+		if err := rows.Scan(&item.Position, &item.Text, &item.Done); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
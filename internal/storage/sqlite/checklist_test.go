@@ -0,0 +1,77 @@
+package sqlite
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestChecklist_AddToggleMove(t *testing.T) {
+	env := newTestEnv(t)
+	issue := env.CreateIssueWithID("bd-1", "With checklist")
+
+	for _, text := range []string{"design", "build", "ship"} {
+		if _, err := env.Store.AddChecklistItem(env.Ctx, issue.ID, text, "test-user"); err != nil {
+			t.Fatalf("AddChecklistItem failed: %v", err)
+		}
+	}
+	done, err := env.Store.ToggleChecklistItem(env.Ctx, issue.ID, 2, "test-user")
+	if err != nil || !done {
+		t.Fatalf("ToggleChecklistItem = %v, %v; want true", done, err)
+	}
+	if err := env.Store.MoveChecklistItem(env.Ctx, issue.ID, 3, 1, "test-user"); err != nil {
+		t.Fatalf("MoveChecklistItem failed: %v", err)
+	}
+
+	got, err := env.Store.GetIssue(env.Ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	want := []types.ChecklistItem{
+		{IssueID: "bd-1", Position: 1, Text: "ship"},
+		{IssueID: "bd-1", Position: 2, Text: "design"},
+		{IssueID: "bd-1", Position: 3, Text: "build", Done: true},
+	}
+	if len(got.Checklist) != len(want) {
+		t.Fatalf("expected %d items, got %+v", len(want), got.Checklist)
+	}
+	for i, item := range got.Checklist {
+		if *item != want[i] {
+			t.Errorf("item %d = %+v, want %+v", i, *item, want[i])
+		}
+	}
+	if got.ContentHash != got.ComputeContentHash() || got.ContentHash == issue.ContentHash {
+		t.Errorf("expected content hash to be recomputed over the checklist")
+	}
+
+	if _, err := env.Store.ToggleChecklistItem(env.Ctx, issue.ID, 9, "test-user"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for a missing item, got %v", err)
+	}
+	if _, err := env.Store.AddChecklistItem(env.Ctx, "bd-missing", "x", "test-user"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for a missing issue, got %v", err)
+	}
+}
+
+func TestChecklist_ExportedInOrder(t *testing.T) {
+	env := newTestEnv(t)
+	issue := env.CreateIssueWithID("bd-1", "With checklist")
+	items := []*types.ChecklistItem{
+		{Position: 5, Text: "second", Done: true},
+		{Position: 2, Text: "first"},
+	}
+	if err := env.Store.SetChecklist(env.Ctx, issue.ID, items, "test-user"); err != nil {
+		t.Fatalf("SetChecklist failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := env.Store.StreamExport(env.Ctx, &buf, types.IssueFilter{}); err != nil {
+		t.Fatalf("StreamExport failed: %v", err)
+	}
+	exported := decodeExport(t, buf.Bytes())[0]
+	if len(exported.Checklist) != 2 || exported.Checklist[0].Text != "first" || exported.Checklist[1].Text != "second" ||
+		!exported.Checklist[1].Done || exported.Checklist[1].Position != 2 {
+		t.Errorf("expected renumbered checklist in position order, got %+v %+v", exported.Checklist[0], exported.Checklist[1])
+	}
+}
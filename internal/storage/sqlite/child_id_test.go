@@ -565,3 +565,42 @@ func TestGetNextChildID_ResurrectParentChain(t *testing.T) {
 		t.Errorf("expected resurrected parent to be closed, got %s", parent2.Status)
 	}
 }
+
+func TestGetIDCounter(t *testing.T) {
+	env := newTestEnv(t)
+	parent := env.CreateIssueWithID("bd-a3f8e9", "Parent")
+
+	counter := func() int {
+		t.Helper()
+		n, err := env.Store.GetIDCounter(env.Ctx, parent.ID)
+		if err != nil {
+			t.Fatalf("GetIDCounter failed: %v", err)
+		}
+		return n
+	}
+	if got := counter(); got != 1 {
+		t.Fatalf("expected counter 1 before any children, got %d", got)
+	}
+	// Reading does not consume
+	if got := counter(); got != 1 {
+		t.Fatalf("expected counter to stay at 1, got %d", got)
+	}
+
+	childID, err := env.Store.GetNextChildID(env.Ctx, parent.ID)
+	if err != nil {
+		t.Fatalf("GetNextChildID failed: %v", err)
+	}
+	if childID != "bd-a3f8e9.1" {
+		t.Fatalf("expected bd-a3f8e9.1, got %s", childID)
+	}
+	env.CreateIssueWithID(childID, "Child")
+	if got := counter(); got != 2 {
+		t.Errorf("expected counter 2 after creating a child, got %d", got)
+	}
+
+	// An explicit child ID advances the counter past it
+	env.CreateIssueWithID("bd-a3f8e9.2", "Explicit child")
+	if got := counter(); got != 3 {
+		t.Errorf("expected counter 3 after an explicit child, got %d", got)
+	}
+}
@@ -0,0 +1,74 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"sync/atomic"
+
+	sqlite3 "github.com/ncruces/go-sqlite3"
+	"github.com/ncruces/go-sqlite3/driver"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// IDCollationConfigKey selects how ID-ordered queries sort issue IDs:
+// "lexicographic" (default, byte order) or "natural" (numeric-aware, so
+// "bd-2" sorts before "bd-10").
+const IDCollationConfigKey = "sort.id_collation"
+
+const (
+	IDCollationLexicographic = "lexicographic"
+	IDCollationNatural       = "natural"
+)
+
+// naturalCollation is the SQLite collating sequence registered on every
+// connection for natural ID ordering.
+const naturalCollation = "NATURAL_ID"
+
+// openDB opens connStr with the beads collations registered on each new
+// connection, plus the foreign key enforcement mode in foreignKeys when it is
+// non-nil (see SetForeignKeyEnforcement).
+func openDB(connStr string, foreignKeys *atomic.Int32) (*sql.DB, error) {
+	return driver.Open(connStr, func(conn *sqlite3.Conn) error {
+		if err := registerCollations(conn); err != nil {
+			return err
+		}
+		if foreignKeys == nil {
+			return nil
+		}
+		return applyForeignKeyMode(conn, foreignKeys.Load())
+	})
+}
+
+func registerCollations(conn *sqlite3.Conn) error {
+	return conn.CreateCollation(naturalCollation, func(a, b []byte) int {
+		return utils.CompareNaturalIDs(string(a), string(b))
+	})
+}
+
+// getIDCollation returns the configured ID collation, falling back to
+// lexicographic for unset or unknown values.
+func getIDCollation(ctx context.Context, db dbExecutor) string {
+	var value string
+	if err := db.QueryRowContext(ctx, `SELECT value FROM config WHERE key = ?`, IDCollationConfigKey).Scan(&value); err == nil && value == IDCollationNatural {
+		return IDCollationNatural
+	}
+	return IDCollationLexicographic
+}
+
+// idCollateClause returns the COLLATE clause to append to id comparisons and
+// ORDER BY id for the given collation.
+func idCollateClause(collation string) string {
+	if collation == IDCollationNatural {
+		return " COLLATE " + naturalCollation
+	}
+	return ""
+}
+
+// compareIDs compares issue IDs under the given collation.
+func compareIDs(collation, a, b string) int {
+	if collation == IDCollationNatural {
+		return utils.CompareNaturalIDs(a, b)
+	}
+	return strings.Compare(a, b)
+}
@@ -0,0 +1,70 @@
+package sqlite
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestStreamExport_IDCollation(t *testing.T) {
+	oldPageSize := streamExportPageSize
+	streamExportPageSize = 2
+	defer func() { streamExportPageSize = oldPageSize }()
+
+	env := newTestEnv(t)
+	for _, id := range []string{"bd-10", "bd-2", "bd-1", "bd-1.10", "bd-1.2"} {
+		env.CreateIssueWithID(id, "Issue "+id)
+	}
+
+	exportIDs := func() string {
+		t.Helper()
+		var buf bytes.Buffer
+		if err := env.Store.StreamExport(env.Ctx, &buf, types.IssueFilter{}); err != nil {
+			t.Fatalf("StreamExport failed: %v", err)
+		}
+		var ids []string
+		for _, issue := range decodeExport(t, buf.Bytes()) {
+			if issue.ID != "" { // skip the summary line
+				ids = append(ids, issue.ID)
+			}
+		}
+		return strings.Join(ids, " ")
+	}
+
+	if got, want := exportIDs(), "bd-1 bd-1.10 bd-1.2 bd-10 bd-2"; got != want {
+		t.Errorf("lexicographic order: got %q, want %q", got, want)
+	}
+
+	if err := env.Store.SetConfig(env.Ctx, IDCollationConfigKey, IDCollationNatural); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	// Pages of 2 also exercise the keyset comparison under the collation
+	if got, want := exportIDs(), "bd-1 bd-1.2 bd-1.10 bd-2 bd-10"; got != want {
+		t.Errorf("natural order: got %q, want %q", got, want)
+	}
+}
+
+func TestExportSubtree_NaturalSiblingOrder(t *testing.T) {
+	env := newTestEnv(t)
+	root := env.CreateIssueWithID("bd-r", "Root")
+	for _, id := range []string{"bd-r.10", "bd-r.2", "bd-r.1"} {
+		env.CreateIssueWithID(id, "Child "+id)
+	}
+	if err := env.Store.SetConfig(env.Ctx, IDCollationConfigKey, IDCollationNatural); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := env.Store.ExportSubtree(env.Ctx, root.ID, &buf, ExportSubtreeOptions{}); err != nil {
+		t.Fatalf("ExportSubtree failed: %v", err)
+	}
+	var ids []string
+	for _, issue := range decodeExport(t, buf.Bytes()) {
+		ids = append(ids, issue.ID)
+	}
+	if got, want := strings.Join(ids, " "), "bd-r bd-r.1 bd-r.2 bd-r.10"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -10,6 +10,8 @@ import (
 
 // AddIssueComment adds a comment to an issue
 func (s *SQLiteStorage) AddIssueComment(ctx context.Context, issueID, author, text string) (*types.Comment, error) {
+	issueID = canonicalIssueID(ctx, s.db, issueID)
+
 	// Verify issue exists
 	var exists bool
 	err := s.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM issues WHERE id = ?)`, issueID).Scan(&exists)
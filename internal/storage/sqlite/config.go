@@ -3,6 +3,7 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"strings"
 
 	"github.com/steveyegge/beads/internal/config"
@@ -16,10 +17,20 @@ func (s *SQLiteStorage) SetConfig(ctx context.Context, key, value string) error
 	s.reconnectMu.RLock()
 	defer s.reconnectMu.RUnlock()
 
+	if err := checkPrefixConfig(ctx, s.db, key, value); err != nil {
+		return fmt.Errorf("invalid config %s: %w", key, err)
+	}
+	if err := checkHashSaltConfig(ctx, s.db, key, value, false); err != nil {
+		return fmt.Errorf("invalid config %s: %w", key, err)
+	}
+
 	_, err := s.db.ExecContext(ctx, `
 		INSERT INTO config (key, value) VALUES (?, ?)
 		ON CONFLICT (key) DO UPDATE SET value = excluded.value
 	`, key, value)
+	if isCustomConfigKey(key) {
+		s.customCache.invalidate()
+	}
 	return wrapDBError("set config", err)
 }
 
@@ -71,7 +82,13 @@ func (s *SQLiteStorage) DeleteConfig(ctx context.Context, key string) error {
 	s.reconnectMu.RLock()
 	defer s.reconnectMu.RUnlock()
 
+	if err := checkHashSaltConfig(ctx, s.db, key, "", true); err != nil {
+		return fmt.Errorf("cannot delete config %s: %w", key, err)
+	}
 	_, err := s.db.ExecContext(ctx, `DELETE FROM config WHERE key = ?`, key)
+	if isCustomConfigKey(key) {
+		s.customCache.invalidate()
+	}
 	return wrapDBError("delete config", err)
 }
 
@@ -144,7 +161,7 @@ const CustomTypeConfigKey = "types.custom"
 // Custom statuses are stored as comma-separated values in the "status.custom" config key.
 // Returns an empty slice if no custom statuses are configured.
 func (s *SQLiteStorage) GetCustomStatuses(ctx context.Context) ([]string, error) {
-	value, err := s.GetConfig(ctx, CustomStatusConfigKey)
+	value, err := s.getCustomConfig(ctx, CustomStatusConfigKey)
 	if err != nil {
 		return nil, err
 	}
@@ -161,7 +178,7 @@ func (s *SQLiteStorage) GetCustomStatuses(ctx context.Context) ([]string, error)
 // but auto-import needs to validate issues with custom types (GH#1225).
 // Returns an empty slice if no custom types are configured.
 func (s *SQLiteStorage) GetCustomTypes(ctx context.Context) ([]string, error) {
-	value, err := s.GetConfig(ctx, CustomTypeConfigKey)
+	value, err := s.getCustomConfig(ctx, CustomTypeConfigKey)
 	if err != nil {
 		return nil, err
 	}
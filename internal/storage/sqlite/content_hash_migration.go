@@ -0,0 +1,186 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// contentHashAlgorithms maps content hash algorithm versions to their
+// implementations, each taking the database's hash settings. Every write
+// hashes with types.ContentHashVersion.
+var contentHashAlgorithms = map[int]func(issue *types.Issue, opts types.ContentHashOptions) string{
+	types.ContentHashVersion: (*types.Issue).ComputeContentHashWith,
+}
+
+// contentHashMigrationBatchSize is the number of issues rehashed per
+// transaction by MigrateContentHashes.
+var contentHashMigrationBatchSize = 500
+
+// Metadata keys written by MigrateContentHashes.
+const (
+	// ContentHashVersionMetadataKey holds the algorithm version every stored
+	// hash was last migrated to.
+	ContentHashVersionMetadataKey = "content_hash.version"
+	// ContentHashMigrationMetadataKey holds the summary of the last completed
+	// migration as a JSON ContentHashMigration.
+	ContentHashMigrationMetadataKey = "content_hash.last_migration"
+	// contentHashCursorKey holds an interrupted migration's position.
+	contentHashCursorKey = "content_hash.migration_cursor"
+)
+
+// ContentHashMigration summarizes a MigrateContentHashes run. It is also
+// reported after each chunk, with Complete unset until the last.
+type ContentHashMigration struct {
+	Version     int       `json:"version"`      // Algorithm the hashes were migrated to
+	FromVersion int       `json:"from_version"` // Version recorded before the migration (0 if none)
+	Rehashed    int       `json:"rehashed"`     // Issues whose hash was recomputed so far
+	Changed     int       `json:"changed"`      // Of those, issues whose stored hash differed
+	Total       int       `json:"total"`        // Issues in the database as of the latest chunk
+	Complete    bool      `json:"complete"`     // Every issue has been rehashed
+	FinishedAt  time.Time `json:"finished_at"`  // When the migration completed
+}
+
+// contentHashCursor is the resumable position of a migration.
+type contentHashCursor struct {
+	Version  int    `json:"version"`
+	After    string `json:"after"`
+	Rehashed int    `json:"rehashed"`
+	Changed  int    `json:"changed"`
+}
+
+// MigrateContentHashes recomputes every issue's stored content hash (tombstones
+// included) under algorithm toVersion, normally types.ContentHashVersion, so
+// hashes written by older versions match hashes computed now. Issues are
+// rehashed in ID order, one transaction per chunk, and the position is saved
+// with each chunk: an interrupted or canceled migration to the same version
+// resumes where it stopped. On completion the version is recorded under
+// ContentHashVersionMetadataKey, the summary under
+// ContentHashMigrationMetadataKey, and a MaintenanceContentHashMigration event
+// in the maintenance log, all in the final chunk's transaction.
+func (s *SQLiteStorage) MigrateContentHashes(ctx context.Context, toVersion int) (*ContentHashMigration, error) {
+	return s.MigrateContentHashesWithProgress(ctx, toVersion, nil)
+}
+
+// MigrateContentHashesWithProgress is MigrateContentHashes, calling progress
+// (if non-nil) after each committed chunk.
+func (s *SQLiteStorage) MigrateContentHashesWithProgress(ctx context.Context, toVersion int, progress func(ContentHashMigration)) (*ContentHashMigration, error) {
+	algorithm, ok := contentHashAlgorithms[toVersion]
+	if !ok {
+		return nil, fmt.Errorf("unknown content hash version %d", toVersion)
+	}
+
+	var cursor contentHashCursor
+	raw, err := s.GetMetadata(ctx, contentHashCursorKey)
+	if err != nil {
+		return nil, err
+	}
+	if raw == "" || json.Unmarshal([]byte(raw), &cursor) != nil || cursor.Version != toVersion {
+		cursor = contentHashCursor{Version: toVersion}
+	}
+
+	fromVersion := 0
+	if recorded, err := s.GetMetadata(ctx, ContentHashVersionMetadataKey); err != nil {
+		return nil, err
+	} else if recorded != "" {
+		if fromVersion, err = strconv.Atoi(recorded); err != nil {
+			return nil, fmt.Errorf("invalid %s metadata %q: %w", ContentHashVersionMetadataKey, recorded, err)
+		}
+	}
+
+	summary := &ContentHashMigration{Version: toVersion, FromVersion: fromVersion, Rehashed: cursor.Rehashed, Changed: cursor.Changed}
+	for !summary.Complete {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		err := s.withTx(ctx, func(conn *sql.Conn) error {
+			if err := conn.QueryRowContext(ctx, `SELECT COUNT(*) FROM issues`).Scan(&summary.Total); err != nil {
+				return wrapDBError("count issues", err)
+			}
+			n, err := rehashContentChunk(ctx, &sqliteTxStorage{conn: conn, parent: s}, algorithm, &cursor)
+			if err != nil {
+				return err
+			}
+			summary.Rehashed, summary.Changed = cursor.Rehashed, cursor.Changed
+			if n > 0 {
+				state, _ := json.Marshal(cursor)
+				_, err = conn.ExecContext(ctx, `
+					INSERT INTO metadata (key, value) VALUES (?, ?)
+					ON CONFLICT (key) DO UPDATE SET value = excluded.value
+				`, contentHashCursorKey, string(state))
+				return wrapDBError("save content hash migration cursor", err)
+			}
+
+			summary.Complete = true
+			summary.FinishedAt = time.Now().UTC()
+			record, _ := json.Marshal(summary)
+			if _, err := conn.ExecContext(ctx, `DELETE FROM metadata WHERE key = ?`, contentHashCursorKey); err != nil {
+				return wrapDBError("clear content hash migration cursor", err)
+			}
+			_, err = conn.ExecContext(ctx, `
+				INSERT INTO metadata (key, value) VALUES (?, ?), (?, ?)
+				ON CONFLICT (key) DO UPDATE SET value = excluded.value
+			`, ContentHashVersionMetadataKey, fmt.Sprint(toVersion), ContentHashMigrationMetadataKey, string(record))
+			if err != nil {
+				return wrapDBError("record content hash migration", err)
+			}
+			return recordMaintenanceEvent(ctx, conn, MaintenanceContentHashMigration, summary, summary.FinishedAt)
+		})
+		if err != nil {
+			summary.Complete = false
+			return nil, err
+		}
+		if progress != nil {
+			progress(*summary)
+		}
+	}
+	return summary, nil
+}
+
+// rehashContentChunk rehashes the next chunk of issues after cursor.After and
+// advances cursor. It returns the number of issues in the chunk; 0 means the
+// migration is done.
+func rehashContentChunk(ctx context.Context, tx *sqliteTxStorage, algorithm func(*types.Issue, types.ContentHashOptions) string, cursor *contentHashCursor) (int, error) {
+	rows, err := tx.conn.QueryContext(ctx, `SELECT id FROM issues WHERE id > ? ORDER BY id LIMIT ?`, cursor.After, contentHashMigrationBatchSize)
+	if err != nil {
+		return 0, wrapDBError("list issues to rehash", err)
+	}
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			_ = rows.Close()
+			return 0, wrapDBError("scan issue id", err)
+		}
+		ids = append(ids, id)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, wrapDBError("list issues to rehash", err)
+	}
+
+	hashOpts := getHashOptions(ctx, tx.conn)
+	for _, id := range ids {
+		issue, err := tx.GetIssue(ctx, id)
+		if err != nil {
+			return 0, err
+		}
+		if issue == nil {
+			continue
+		}
+		if hash := algorithm(issue, hashOpts); hash != issue.ContentHash {
+			if _, err := tx.conn.ExecContext(ctx, `UPDATE issues SET content_hash = ? WHERE id = ?`, hash, id); err != nil {
+				return 0, wrapDBError("update content hash", err)
+			}
+			cursor.Changed++
+		}
+		cursor.Rehashed++
+		cursor.After = id
+	}
+	return len(ids), nil
+}
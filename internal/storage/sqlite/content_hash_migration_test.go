@@ -0,0 +1,117 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestMigrateContentHashes(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := env.Ctx
+
+	// Two older algorithms and the one being adopted
+	origAlgorithms, origBatch := contentHashAlgorithms, contentHashMigrationBatchSize
+	t.Cleanup(func() { contentHashAlgorithms, contentHashMigrationBatchSize = origAlgorithms, origBatch })
+	versioned := func(version int) func(*types.Issue, types.ContentHashOptions) string {
+		return func(issue *types.Issue, opts types.ContentHashOptions) string {
+			return issue.ComputeSaltedContentHash(fmt.Sprintf("v%d%s", version, opts.Salt))
+		}
+	}
+	contentHashAlgorithms = map[int]func(*types.Issue, types.ContentHashOptions) string{1: versioned(1), 2: versioned(2), 3: versioned(3)}
+	contentHashMigrationBatchSize = 2
+
+	var issues []*types.Issue
+	for i := 0; i < 5; i++ {
+		issue := env.CreateIssue(fmt.Sprintf("Issue %d", i))
+		legacy := contentHashAlgorithms[1+i%2](issue, types.ContentHashOptions{})
+		if _, err := env.Store.db.ExecContext(ctx, `UPDATE issues SET content_hash = ? WHERE id = ?`, legacy, issue.ID); err != nil {
+			t.Fatalf("failed to seed hash: %v", err)
+		}
+		issues = append(issues, issue)
+	}
+
+	if _, err := env.Store.MigrateContentHashes(ctx, 4); err == nil {
+		t.Error("expected an unknown version to fail")
+	}
+
+	// Interrupt after the first chunk
+	canceled, cancel := context.WithCancel(ctx)
+	_, err := env.Store.MigrateContentHashesWithProgress(canceled, 3, func(ContentHashMigration) { cancel() })
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected cancellation, got %v", err)
+	}
+
+	var reports []ContentHashMigration
+	summary, err := env.Store.MigrateContentHashesWithProgress(ctx, 3, func(p ContentHashMigration) {
+		reports = append(reports, p)
+	})
+	if err != nil {
+		t.Fatalf("MigrateContentHashes failed: %v", err)
+	}
+	// The resumed run starts after the two issues already rehashed
+	if len(reports) != 3 || reports[0].Rehashed != 4 || !reports[2].Complete {
+		t.Errorf("progress = %+v, want chunks ending at 4, 5 and completion", reports)
+	}
+	if summary.Version != 3 || summary.Rehashed != 5 || summary.Changed != 5 || summary.Total != 5 || !summary.Complete {
+		t.Errorf("summary = %+v, want all 5 issues rehashed to version 3", summary)
+	}
+
+	for _, issue := range issues {
+		got, err := env.Store.GetIssue(ctx, issue.ID)
+		if err != nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		if want := contentHashAlgorithms[3](got, types.ContentHashOptions{}); got.ContentHash != want {
+			t.Errorf("%s hash = %s, want the version 3 hash %s", issue.ID, got.ContentHash, want)
+		}
+	}
+	if version, _ := env.Store.GetMetadata(ctx, ContentHashVersionMetadataKey); version != "3" {
+		t.Errorf("recorded version = %q, want 3", version)
+	}
+	raw, _ := env.Store.GetMetadata(ctx, ContentHashMigrationMetadataKey)
+	var recorded ContentHashMigration
+	if err := json.Unmarshal([]byte(raw), &recorded); err != nil || recorded.Changed != 5 || recorded.FinishedAt.IsZero() {
+		t.Errorf("recorded summary = %q (%v)", raw, err)
+	}
+	if cursor, _ := env.Store.GetMetadata(ctx, contentHashCursorKey); cursor != "" {
+		t.Errorf("cursor left behind: %q", cursor)
+	}
+
+	// Hashes already at the version are left alone
+	again, err := env.Store.MigrateContentHashes(ctx, 3)
+	if err != nil || again.Rehashed != 5 || again.Changed != 0 {
+		t.Errorf("second migration = %+v, %v; want nothing changed", again, err)
+	}
+
+	// Each completed migration is logged once, with its counts and versions;
+	// the interrupted run is not
+	events, err := env.Store.GetMaintenanceLog(ctx, MaintenanceContentHashMigration)
+	if err != nil {
+		t.Fatalf("GetMaintenanceLog failed: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("expected 2 maintenance events, got %d", len(events))
+	}
+	want := []ContentHashMigration{
+		{Version: 3, FromVersion: 0, Rehashed: 5, Changed: 5, Total: 5},
+		{Version: 3, FromVersion: 3, Rehashed: 5, Changed: 0, Total: 5},
+	}
+	for i, event := range events {
+		var logged ContentHashMigration
+		if err := json.Unmarshal(event.Details, &logged); err != nil {
+			t.Fatalf("event %d details = %s: %v", i, event.Details, err)
+		}
+		if logged.Version != want[i].Version || logged.FromVersion != want[i].FromVersion || logged.Rehashed != want[i].Rehashed ||
+			logged.Changed != want[i].Changed || logged.Total != want[i].Total || !logged.Complete {
+			t.Errorf("event %d = %+v, want %+v", i, logged, want[i])
+		}
+		if event.RecordedAt.IsZero() {
+			t.Errorf("event %d has no timestamp", i)
+		}
+	}
+}
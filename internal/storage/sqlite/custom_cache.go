@@ -0,0 +1,82 @@
+package sqlite
+
+import (
+	"context"
+	"sync"
+)
+
+// customConfigCache keeps the raw status.custom and types.custom config
+// values so validation on every create doesn't query them again. Each
+// invalidation bumps gen; a value loaded under an older generation is
+// discarded instead of cached, so a reload racing a change can't cache the
+// value the change replaced.
+//
+// Invalidation covers writes made through this SQLiteStorage (directly or in
+// a committed transaction) and reconnects after the file is replaced. Other
+// processes writing the same database are not seen until then; the daemon,
+// the long-lived user of the cache, is normally the only writer.
+type customConfigCache struct {
+	mu       sync.Mutex
+	disabled bool
+	gen      uint64
+	values   map[string]string
+}
+
+func isCustomConfigKey(key string) bool {
+	return key == CustomStatusConfigKey || key == CustomTypeConfigKey
+}
+
+func (c *customConfigCache) get(key string) (value string, gen uint64, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.disabled {
+		return "", c.gen, false
+	}
+	value, ok = c.values[key]
+	return value, c.gen, ok
+}
+
+func (c *customConfigCache) put(key, value string, gen uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.disabled || gen != c.gen {
+		return
+	}
+	if c.values == nil {
+		c.values = make(map[string]string)
+	}
+	c.values[key] = value
+}
+
+func (c *customConfigCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.gen++
+	c.values = nil
+}
+
+// SetCustomConfigCacheEnabled turns the custom status/type cache on (the
+// default) or off. Disabled, every GetCustomStatuses/GetCustomTypes reads the
+// database; tests that edit config behind the storage's back use this.
+func (s *SQLiteStorage) SetCustomConfigCacheEnabled(enabled bool) {
+	s.customCache.mu.Lock()
+	s.customCache.disabled = !enabled
+	s.customCache.mu.Unlock()
+	s.customCache.invalidate()
+}
+
+// getCustomConfig returns the config value of a custom status/type key,
+// from the cache when possible.
+func (s *SQLiteStorage) getCustomConfig(ctx context.Context, key string) (string, error) {
+	s.checkFreshness()
+	value, gen, ok := s.customCache.get(key)
+	if ok {
+		return value, nil
+	}
+	value, err := s.GetConfig(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	s.customCache.put(key, value, gen)
+	return value, nil
+}
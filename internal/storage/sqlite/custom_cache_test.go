@@ -0,0 +1,106 @@
+package sqlite
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+func TestCustomConfigCacheConsistency(t *testing.T) {
+	env := newTestEnv(t)
+
+	var (
+		writeMu sync.Mutex // serializes a write with its read-back
+		readers sync.WaitGroup
+		writers sync.WaitGroup
+		done    = make(chan struct{})
+	)
+	for r := 0; r < 4; r++ {
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				if _, err := env.Store.GetCustomStatuses(env.Ctx); err != nil {
+					t.Errorf("GetCustomStatuses failed: %v", err)
+					return
+				}
+				if _, err := env.Store.GetCustomTypes(env.Ctx); err != nil {
+					t.Errorf("GetCustomTypes failed: %v", err)
+					return
+				}
+			}
+		}()
+	}
+
+	for w := 0; w < 3; w++ {
+		writers.Add(1)
+		go func(w int) {
+			defer writers.Done()
+			for i := 0; i < 40; i++ {
+				status := fmt.Sprintf("s%d_%d", w, i)
+				issueType := fmt.Sprintf("t%d_%d", w, i)
+				writeMu.Lock()
+				var err error
+				switch i % 3 {
+				case 0:
+					err = env.Store.SetConfig(env.Ctx, CustomStatusConfigKey, status)
+					if err == nil {
+						err = env.Store.SetConfig(env.Ctx, CustomTypeConfigKey, issueType)
+					}
+				case 1:
+					err = env.Store.RunInTransaction(env.Ctx, func(tx storage.Transaction) error {
+						if err := tx.SetConfig(env.Ctx, CustomStatusConfigKey, status); err != nil {
+							return err
+						}
+						return tx.SetConfig(env.Ctx, CustomTypeConfigKey, issueType)
+					})
+				default:
+					err = env.Store.DeleteConfig(env.Ctx, CustomStatusConfigKey)
+					status = ""
+					if err == nil {
+						err = env.Store.SetConfig(env.Ctx, CustomTypeConfigKey, issueType)
+					}
+				}
+				if err != nil {
+					writeMu.Unlock()
+					t.Errorf("write failed: %v", err)
+					return
+				}
+				statuses, err1 := env.Store.GetCustomStatuses(env.Ctx)
+				types, err2 := env.Store.GetCustomTypes(env.Ctx)
+				writeMu.Unlock()
+				if err1 != nil || err2 != nil {
+					t.Errorf("read-back failed: %v / %v", err1, err2)
+					return
+				}
+				if got := strings.Join(statuses, ","); got != status {
+					t.Errorf("statuses after write = %q, want %q", got, status)
+				}
+				if got := strings.Join(types, ","); got != issueType {
+					t.Errorf("types after write = %q, want %q", got, issueType)
+				}
+			}
+		}(w)
+	}
+
+	writers.Wait()
+	close(done)
+	readers.Wait()
+
+	// With the cache disabled, changes made behind the storage's back are seen
+	env.Store.SetCustomConfigCacheEnabled(false)
+	if _, err := env.Store.db.ExecContext(env.Ctx, `UPDATE config SET value = 'raw' WHERE key = ?`, CustomTypeConfigKey); err != nil {
+		t.Fatalf("raw update failed: %v", err)
+	}
+	if types, err := env.Store.GetCustomTypes(env.Ctx); err != nil || strings.Join(types, ",") != "raw" {
+		t.Errorf("expected uncached read to see raw update, got %v (err %v)", types, err)
+	}
+}
@@ -0,0 +1,42 @@
+package sqlite
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// formatCustomFields encodes an issue's preserved unknown fields for the
+// custom_fields column; no fields is stored as the empty string. A value that
+// is not valid JSON is an error rather than an empty column, which would
+// silently drop every preserved field.
+func formatCustomFields(fields map[string]json.RawMessage) (string, error) {
+	if len(fields) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode custom fields: %w", err)
+	}
+	return string(data), nil
+}
+
+// parseCustomFields decodes the custom_fields column.
+func parseCustomFields(value string) (map[string]json.RawMessage, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(value), &fields); err != nil {
+		return nil, fmt.Errorf("failed to parse custom fields: %w", err)
+	}
+	return fields, nil
+}
+
+// customFieldsUpdateValue converts a custom_fields update, a field map or its
+// JSON encoding, to the column value.
+func customFieldsUpdateValue(value interface{}) (interface{}, error) {
+	if fields, ok := value.(map[string]json.RawMessage); ok {
+		return formatCustomFields(fields)
+	}
+	return value, nil
+}
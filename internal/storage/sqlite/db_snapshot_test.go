@@ -0,0 +1,108 @@
+package sqlite
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSnapshotRestore(t *testing.T) {
+	env := newTestEnv(t)
+	parent := env.CreateIssueWithID("bd-a1", "Parent")
+	child := env.CreateIssueWithID("bd-a1.1", "Child")
+	env.AddParentChild(child, parent)
+	if err := env.Store.AddLabel(env.Ctx, parent.ID, "backend", "test-user"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+	if _, err := env.Store.AddIssueComment(env.Ctx, child.ID, "test-user", "Looks good"); err != nil {
+		t.Fatalf("AddIssueComment failed: %v", err)
+	}
+	export := func() string {
+		t.Helper()
+		var buf bytes.Buffer
+		if err := env.Store.StreamExport(env.Ctx, &buf, types.IssueFilter{}); err != nil {
+			t.Fatalf("StreamExport failed: %v", err)
+		}
+		return buf.String()
+	}
+	before := export()
+
+	data, err := env.Store.Snapshot(env.Ctx)
+	if err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+	if !bytes.HasPrefix(data, []byte("SQLite format 3\x00")) {
+		t.Fatalf("snapshot is not an SQLite database image")
+	}
+
+	// Diverge, then restore
+	env.CreateIssueWithID("bd-b2", "Created after the snapshot")
+	env.Close(parent, "done")
+	if err := env.Store.SetConfig(env.Ctx, "issue_prefix", "xx"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+
+	if err := env.Store.RestoreSnapshot(env.Ctx, data); err != nil {
+		t.Fatalf("RestoreSnapshot failed: %v", err)
+	}
+	if after := export(); after != before {
+		t.Errorf("restored export differs:\nbefore:\n%s\nafter:\n%s", before, after)
+	}
+	if prefix, err := env.Store.GetConfig(env.Ctx, "issue_prefix"); err != nil || prefix != "bd" {
+		t.Errorf("issue_prefix = %q, %v; want the snapshot's bd", prefix, err)
+	}
+	events, err := env.Store.GetEvents(env.Ctx, parent.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	for _, event := range events {
+		if event.EventType == types.EventClosed {
+			t.Errorf("the close after the snapshot should be gone from the history")
+		}
+	}
+
+	// The restored store keeps working
+	env.CreateIssueWithID("bd-c3", "Created after the restore")
+
+	// Another store gets an identical copy
+	other := newTestEnv(t)
+	if err := other.Store.RestoreSnapshot(other.Ctx, data); err != nil {
+		t.Fatalf("RestoreSnapshot into a second store failed: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := other.Store.StreamExport(other.Ctx, &buf, types.IssueFilter{}); err != nil {
+		t.Fatalf("StreamExport failed: %v", err)
+	}
+	if buf.String() != before {
+		t.Errorf("second store's export differs from the snapshot:\n%s", buf.String())
+	}
+}
+
+func TestRestoreSnapshot_Validation(t *testing.T) {
+	env := newTestEnv(t)
+	env.CreateIssueWithID("bd-keep", "Kept")
+
+	if err := env.Store.RestoreSnapshot(env.Ctx, []byte("not a database")); err == nil || !strings.Contains(err.Error(), "invalid snapshot") {
+		t.Errorf("expected garbage to be rejected as an invalid snapshot, got %v", err)
+	}
+
+	// A snapshot from another schema version is refused
+	source := newTestEnv(t)
+	if err := source.Store.SetConfig(source.Ctx, storage.SchemaVersionConfigKey, "1"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	data, err := source.Store.Snapshot(source.Ctx)
+	if err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+	if err := env.Store.RestoreSnapshot(env.Ctx, data); err == nil || !strings.Contains(err.Error(), "schema version 1") {
+		t.Errorf("expected a schema version mismatch, got %v", err)
+	}
+
+	if got, err := env.Store.GetIssue(env.Ctx, "bd-keep"); err != nil || got == nil {
+		t.Errorf("a rejected snapshot should leave the database untouched: %v, %v", got, err)
+	}
+}
@@ -0,0 +1,109 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// DeferredMigration is a data backfill split out of a schema migration, so
+// that adding an indexed column does not populate it for every row inside the
+// migration's exclusive transaction. The schema migration adds the column (and
+// its index) and new writes fill it in; RunDeferredMigrations backfills the
+// existing rows afterwards, one short transaction per chunk, so the database
+// stays usable meanwhile.
+type DeferredMigration struct {
+	Name string
+	// Step backfills up to limit more rows and returns how many it did; 0
+	// means the backfill is complete. It must select rows by what is still
+	// missing, so an interrupted backfill resumes where it stopped.
+	Step func(ctx context.Context, conn *sql.Conn, limit int) (int, error)
+	// Pending, if set, counts the rows still to backfill, for progress reports.
+	Pending func(ctx context.Context, conn *sql.Conn) (int, error)
+}
+
+// deferredMigrationsList holds the deferred backfills, in the order they run.
+// Each should follow the schema migration it belongs to in migrationsList.
+var deferredMigrationsList = []DeferredMigration{}
+
+// deferredMigrationBatchSize is the chunk size passed to DeferredMigration.Step.
+var deferredMigrationBatchSize = 1000
+
+// deferredMigrationKeyPrefix prefixes the metadata key marking a deferred
+// migration as complete.
+const deferredMigrationKeyPrefix = "deferred_migration."
+
+// DeferredMigrationProgress is reported after each chunk of a deferred backfill.
+type DeferredMigrationProgress struct {
+	Name      string // The deferred migration
+	Done      int    // Rows backfilled so far by this run
+	Remaining int    // Rows still to backfill, or -1 if the migration cannot count them
+	Complete  bool   // The backfill has finished
+}
+
+// RunDeferredMigrations runs every deferred backfill that has not completed
+// yet (see DeferredMigration). It is safe to call while the database is in
+// use, including from a background goroutine, and to cancel: a later call
+// picks up where the canceled one stopped.
+func (s *SQLiteStorage) RunDeferredMigrations(ctx context.Context) error {
+	return s.RunDeferredMigrationsWithProgress(ctx, nil)
+}
+
+// RunDeferredMigrationsWithProgress is RunDeferredMigrations, calling progress
+// (if non-nil) after each committed chunk.
+func (s *SQLiteStorage) RunDeferredMigrationsWithProgress(ctx context.Context, progress func(DeferredMigrationProgress)) error {
+	for _, m := range deferredMigrationsList {
+		if err := s.runDeferredMigration(ctx, m, progress); err != nil {
+			return fmt.Errorf("deferred migration %s failed: %w", m.Name, err)
+		}
+	}
+	return nil
+}
+
+func (s *SQLiteStorage) runDeferredMigration(ctx context.Context, m DeferredMigration, progress func(DeferredMigrationProgress)) error {
+	key := deferredMigrationKeyPrefix + m.Name
+	state, err := s.GetMetadata(ctx, key)
+	if err != nil {
+		return err
+	}
+	if state == "done" {
+		return nil
+	}
+
+	done := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		n, remaining := 0, -1
+		err := s.withTx(ctx, func(conn *sql.Conn) error {
+			var err error
+			if n, err = m.Step(ctx, conn, deferredMigrationBatchSize); err != nil {
+				return err
+			}
+			if m.Pending != nil {
+				if remaining, err = m.Pending(ctx, conn); err != nil {
+					return err
+				}
+			}
+			if n > 0 {
+				return nil
+			}
+			_, err = conn.ExecContext(ctx, `
+				INSERT INTO metadata (key, value) VALUES (?, 'done')
+				ON CONFLICT (key) DO UPDATE SET value = excluded.value
+			`, key)
+			return wrapDBError("mark deferred migration complete", err)
+		})
+		if err != nil {
+			return err
+		}
+		done += n
+		if progress != nil {
+			progress(DeferredMigrationProgress{Name: m.Name, Done: done, Remaining: remaining, Complete: n == 0})
+		}
+		if n == 0 {
+			return nil
+		}
+	}
+}
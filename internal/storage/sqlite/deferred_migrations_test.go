@@ -0,0 +1,114 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestRunDeferredMigrations(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := env.Ctx
+	db := env.Store.db
+
+	// The schema half: an indexed column the existing rows lack
+	var ids []string
+	for i := 0; i < 25; i++ {
+		ids = append(ids, env.CreateIssue(fmt.Sprintf("Issue %d", i)).ID)
+	}
+	if _, err := db.ExecContext(ctx, `ALTER TABLE issues ADD COLUMN id_upper TEXT`); err != nil {
+		t.Fatalf("failed to add column: %v", err)
+	}
+	if _, err := db.ExecContext(ctx, `CREATE INDEX idx_issues_id_upper ON issues(id_upper)`); err != nil {
+		t.Fatalf("failed to add index: %v", err)
+	}
+
+	origList, origBatch := deferredMigrationsList, deferredMigrationBatchSize
+	t.Cleanup(func() { deferredMigrationsList, deferredMigrationBatchSize = origList, origBatch })
+	deferredMigrationBatchSize = 10
+	deferredMigrationsList = []DeferredMigration{{
+		Name: "id_upper_backfill",
+		Step: func(ctx context.Context, conn *sql.Conn, limit int) (int, error) {
+			res, err := conn.ExecContext(ctx, `
+				UPDATE issues SET id_upper = upper(id)
+				WHERE rowid IN (SELECT rowid FROM issues WHERE id_upper IS NULL LIMIT ?)
+			`, limit)
+			if err != nil {
+				return 0, err
+			}
+			n, err := res.RowsAffected()
+			return int(n), err
+		},
+		Pending: func(ctx context.Context, conn *sql.Conn) (int, error) {
+			var n int
+			err := conn.QueryRowContext(ctx, `SELECT COUNT(*) FROM issues WHERE id_upper IS NULL`).Scan(&n)
+			return n, err
+		},
+	}}
+
+	var reports []DeferredMigrationProgress
+	if err := env.Store.RunDeferredMigrationsWithProgress(ctx, func(p DeferredMigrationProgress) {
+		reports = append(reports, p)
+	}); err != nil {
+		t.Fatalf("RunDeferredMigrations failed: %v", err)
+	}
+	want := []DeferredMigrationProgress{
+		{Name: "id_upper_backfill", Done: 10, Remaining: 15},
+		{Name: "id_upper_backfill", Done: 20, Remaining: 5},
+		{Name: "id_upper_backfill", Done: 25, Remaining: 0},
+		{Name: "id_upper_backfill", Done: 25, Remaining: 0, Complete: true},
+	}
+	if fmt.Sprint(reports) != fmt.Sprint(want) {
+		t.Errorf("progress = %v, want %v", reports, want)
+	}
+
+	// Every row is reachable through the new index
+	for _, id := range ids {
+		var got string
+		err := db.QueryRowContext(ctx, `SELECT id FROM issues INDEXED BY idx_issues_id_upper WHERE id_upper = ?`, strings.ToUpper(id)).Scan(&got)
+		if err != nil || got != id {
+			t.Errorf("index lookup of %s = %q, %v", strings.ToUpper(id), got, err)
+		}
+	}
+	var check string
+	if err := db.QueryRowContext(ctx, `PRAGMA integrity_check`).Scan(&check); err != nil || check != "ok" {
+		t.Errorf("integrity_check = %q, %v", check, err)
+	}
+
+	// A completed backfill is not run again
+	reports = nil
+	if err := env.Store.RunDeferredMigrationsWithProgress(ctx, func(p DeferredMigrationProgress) {
+		reports = append(reports, p)
+	}); err != nil {
+		t.Fatalf("second RunDeferredMigrations failed: %v", err)
+	}
+	if len(reports) != 0 {
+		t.Errorf("completed backfill ran again: %v", reports)
+	}
+}
+
+func TestRunDeferredMigrations_Canceled(t *testing.T) {
+	env := newTestEnv(t)
+	origList := deferredMigrationsList
+	t.Cleanup(func() { deferredMigrationsList = origList })
+	deferredMigrationsList = []DeferredMigration{{
+		Name: "never_done",
+		Step: func(context.Context, *sql.Conn, int) (int, error) { return 1, nil },
+	}}
+
+	ctx, cancel := context.WithCancel(env.Ctx)
+	err := env.Store.RunDeferredMigrationsWithProgress(ctx, func(p DeferredMigrationProgress) {
+		if p.Done == 3 {
+			cancel()
+		}
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected cancellation, got %v", err)
+	}
+	if state, _ := env.Store.GetMetadata(env.Ctx, deferredMigrationKeyPrefix+"never_done"); state != "" {
+		t.Errorf("canceled backfill marked %q", state)
+	}
+}
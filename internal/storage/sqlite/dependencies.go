@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -32,6 +33,7 @@ func (s *SQLiteStorage) AddDependency(ctx context.Context, dep *types.Dependency
 	if issueExists == nil {
 		return fmt.Errorf("issue %s not found", dep.IssueID)
 	}
+	dep.IssueID = issueExists.ID // canonical casing when IDs are case-insensitive
 
 	// External refs (external:<project>:<capability>) don't need target validation
 	// They are resolved lazily at query time by CheckExternalDep
@@ -46,6 +48,7 @@ func (s *SQLiteStorage) AddDependency(ctx context.Context, dep *types.Dependency
 		if dependsOnExists == nil {
 			return fmt.Errorf("dependency target %s not found", dep.DependsOnID)
 		}
+		dep.DependsOnID = dependsOnExists.ID
 
 		// Prevent self-dependency (only for local deps)
 		if dep.IssueID == dep.DependsOnID {
@@ -65,6 +68,9 @@ func (s *SQLiteStorage) AddDependency(ctx context.Context, dep *types.Dependency
 				return fmt.Errorf("invalid parent-child dependency: parent (%s) cannot depend on child (%s). Use: bd dep add %s %s --type parent-child",
 					dep.IssueID, dep.DependsOnID, dep.DependsOnID, dep.IssueID)
 			}
+			if err := validateParentType(ctx, s.db, issueExists, dependsOnExists); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -102,8 +108,9 @@ func (s *SQLiteStorage) AddDependency(ctx context.Context, dep *types.Dependency
 		// The traversal is depth-limited to maxDependencyDepth (100) to prevent infinite loops
 		// and excessive query cost. We check before inserting to avoid unnecessary write on failure.
 
-		// Skip cycle detection for relates-to (inherently bidirectional)
-		if dep.Type != types.DepRelatesTo {
+		// Skip cycle detection for relates-to (inherently bidirectional) and for
+		// imports keeping both edges of a flagged inversion
+		if dep.Type != types.DepRelatesTo && !storage.SkipsCycleCheck(ctx) {
 			var cycleExists bool
 			err = conn.QueryRowContext(ctx, `
 				WITH RECURSIVE paths AS (
@@ -505,7 +512,7 @@ func (s *SQLiteStorage) GetDependencyRecordsForIssues(ctx context.Context, issue
 		       COALESCE(metadata, '{}') as metadata, COALESCE(thread_id, '') as thread_id
 		FROM dependencies
 		WHERE issue_id IN (%s)
-		ORDER BY issue_id, created_at ASC
+		ORDER BY issue_id, created_at ASC, depends_on_id, type
 	`, inClause)
 
 	rows, err := s.db.QueryContext(ctx, query, args...)
@@ -705,6 +712,14 @@ func (s *SQLiteStorage) GetDependencyTree(ctx context.Context, issueID string, m
 		nodes = append(nodes, &node)
 	}
 
+	treeIssues := make([]*types.Issue, len(nodes))
+	for i, n := range nodes {
+		treeIssues[i] = &n.Issue
+	}
+	if err := hydrateDescriptions(ctx, s.db, treeIssues...); err != nil {
+		return nil, err
+	}
+
 	// Fetch external dependencies for all issues in the tree
 	// External deps like "external:project:capability" don't exist in the issues
 	// table, so the recursive CTE above doesn't find them. We add them as
@@ -982,6 +997,9 @@ func (s *SQLiteStorage) scanIssues(ctx context.Context, rows *sql.Rows) ([]*type
 		// Time-based scheduling fields
 		var dueAt sql.NullTime
 		var deferUntil sql.NullTime
+		var expiresAt sql.NullTime
+		var sourceSystem sql.NullString
+		var customFields string
 
 		err := rows.Scan(
 			&issue.ID, &contentHash, &issue.Title, &issue.Description, &issue.Design,
@@ -992,7 +1010,7 @@ func (s *SQLiteStorage) scanIssues(ctx context.Context, rows *sql.Rows) ([]*type
 			&sender, &wisp, &pinned, &isTemplate, &crystallizes,
 			&awaitType, &awaitID, &timeoutNs, &waiters,
 			&hookBead, &roleBead, &agentState, &lastActivity, &roleType, &rig, &molType,
-			&dueAt, &deferUntil,
+			&dueAt, &deferUntil, &expiresAt, &issue.Color, &issue.DisplayOrder, &sourceSystem, &customFields, &issue.Rank, &issue.MilestoneID,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan issue: %w", err)
@@ -1102,6 +1120,15 @@ func (s *SQLiteStorage) scanIssues(ctx context.Context, rows *sql.Rows) ([]*type
 		if deferUntil.Valid {
 			issue.DeferUntil = &deferUntil.Time
 		}
+		if expiresAt.Valid {
+			issue.ExpiresAt = &expiresAt.Time
+		}
+		if sourceSystem.Valid {
+			issue.SourceSystem = sourceSystem.String
+		}
+		if issue.CustomFields, err = parseCustomFields(customFields); err != nil {
+			return nil, err
+		}
 
 		issues = append(issues, &issue)
 		issueIDs = append(issueIDs, issue.ID)
@@ -1125,6 +1152,10 @@ func (s *SQLiteStorage) scanIssues(ctx context.Context, rows *sql.Rows) ([]*type
 		}
 	}
 
+	if err := hydrateDescriptions(ctx, s.db, issues...); err != nil {
+		return nil, err
+	}
+
 	return issues, nil
 }
 
@@ -1255,6 +1286,9 @@ func (s *SQLiteStorage) scanIssuesWithDependencyType(ctx context.Context, rows *
 			return nil, fmt.Errorf("failed to get labels for issue %s: %w", issue.ID, err)
 		}
 		issue.Labels = labels
+		if err := hydrateDescriptions(ctx, s.db, &issue); err != nil {
+			return nil, err
+		}
 
 		result := &types.IssueWithDependencyMetadata{
 			Issue:          issue,
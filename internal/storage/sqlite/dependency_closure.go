@@ -0,0 +1,85 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// GetDependencyClosure returns every issue reachable from id by following
+// dependency edges of any type in direction, each at its shortest depth,
+// ordered by depth and then ID. The starting issue is not included, even
+// when a cycle leads back to it.
+//
+// The walk is breadth-first, one query per depth over the whole frontier, and
+// keeps a set of visited issues, so each issue is read once however many
+// cycles or paths lead to it. Dependencies on external references appear as
+// nodes but are not followed further.
+func (s *SQLiteStorage) GetDependencyClosure(ctx context.Context, id string, direction types.ClosureDirection) ([]*types.ClosureNode, error) {
+	nodes, _, err := s.dependencyClosure(ctx, id, direction)
+	return nodes, err
+}
+
+// dependencyClosure implements GetDependencyClosure, also returning the number
+// of edge rows read.
+func (s *SQLiteStorage) dependencyClosure(ctx context.Context, id string, direction types.ClosureDirection) ([]*types.ClosureNode, int, error) {
+	var next, from string
+	switch direction {
+	case types.ClosureDependencies:
+		next, from = "depends_on_id", "issue_id"
+	case types.ClosureDependents:
+		next, from = "issue_id", "depends_on_id"
+	default:
+		return nil, 0, fmt.Errorf("unknown closure direction %q (want dependencies or dependents)", direction)
+	}
+
+	s.checkFreshness()
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	nodes := []*types.ClosureNode{}
+	seen := map[string]bool{id: true}
+	frontier := []string{id}
+	rowsRead := 0
+	for depth := 1; len(frontier) > 0; depth++ {
+		var level []string
+		for start := 0; start < len(frontier); start += idChunkSize {
+			chunk := frontier[start:min(start+idChunkSize, len(frontier))]
+			args := make([]interface{}, len(chunk))
+			for i, chunkID := range chunk {
+				args[i] = chunkID
+			}
+			// #nosec G201 - column names are constants, only placeholders are interpolated
+			rows, err := s.db.QueryContext(ctx, fmt.Sprintf(`
+				SELECT DISTINCT %s FROM dependencies WHERE %s IN (%s)
+			`, next, from, buildPlaceholders(len(chunk))), args...)
+			if err != nil {
+				return nil, rowsRead, fmt.Errorf("failed to get dependency closure: %w", err)
+			}
+			for rows.Next() {
+				var reached string
+				if err := rows.Scan(&reached); err != nil {
+					_ = rows.Close()
+					return nil, rowsRead, fmt.Errorf("failed to scan closure node: %w", err)
+				}
+				rowsRead++
+				if !seen[reached] {
+					seen[reached] = true
+					level = append(level, reached)
+				}
+			}
+			_ = rows.Close()
+			if err := rows.Err(); err != nil {
+				return nil, rowsRead, wrapDBError("iterate dependency closure", err)
+			}
+		}
+		sort.Strings(level)
+		for _, reached := range level {
+			nodes = append(nodes, &types.ClosureNode{ID: reached, Depth: depth})
+		}
+		frontier = level
+	}
+	return nodes, rowsRead, nil
+}
@@ -0,0 +1,112 @@
+package sqlite
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestGetDependencyClosure(t *testing.T) {
+	env := newTestEnv(t)
+
+	// bd-e → bd-a → bd-b → bd-c → bd-d, with a shortcut bd-a → bd-c and a
+	// cycle back from bd-d to bd-b
+	a := env.CreateIssueWithID("bd-a", "A")
+	b := env.CreateIssueWithID("bd-b", "B")
+	c := env.CreateIssueWithID("bd-c", "C")
+	d := env.CreateIssueWithID("bd-d", "D")
+	e := env.CreateIssueWithID("bd-e", "E")
+	env.AddDep(e, a)
+	env.AddDep(a, b)
+	env.AddDep(b, c)
+	env.AddDep(c, d)
+	env.AddDepType(a, c, types.DepRelated)
+	// AddDependency refuses cycles, so close this one directly
+	if _, err := env.Store.db.ExecContext(env.Ctx, `
+		INSERT INTO dependencies (issue_id, depends_on_id, type, created_at, created_by)
+		VALUES (?, ?, 'related', CURRENT_TIMESTAMP, 'test')
+	`, d.ID, b.ID); err != nil {
+		t.Fatalf("failed to add cycle edge: %v", err)
+	}
+
+	format := func(nodes []*types.ClosureNode) string {
+		parts := make([]string, len(nodes))
+		for i, n := range nodes {
+			parts[i] = fmt.Sprintf("%s:%d", n.ID, n.Depth)
+		}
+		return strings.Join(parts, " ")
+	}
+
+	tests := []struct {
+		name      string
+		id        string
+		direction types.ClosureDirection
+		want      string
+	}{
+		{"dependencies of root", a.ID, types.ClosureDependencies, "bd-b:1 bd-c:1 bd-d:2"},
+		{"dependencies inside cycle", b.ID, types.ClosureDependencies, "bd-c:1 bd-d:2"},
+		{"dependents inside cycle", c.ID, types.ClosureDependents, "bd-a:1 bd-b:1 bd-d:2 bd-e:2"},
+		{"dependents of top", e.ID, types.ClosureDependents, ""},
+		{"unknown issue", "bd-missing", types.ClosureDependencies, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodes, err := env.Store.GetDependencyClosure(env.Ctx, tt.id, tt.direction)
+			if err != nil {
+				t.Fatalf("GetDependencyClosure failed: %v", err)
+			}
+			if got := format(nodes); got != tt.want {
+				t.Errorf("closure = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := env.Store.GetDependencyClosure(env.Ctx, a.ID, "sideways"); err == nil {
+		t.Error("expected an unknown direction to fail")
+	}
+}
+
+func TestGetDependencyClosure_CycleReadsEachEdgeOnce(t *testing.T) {
+	env := newTestEnv(t)
+
+	// A ring bd-r0 → bd-r1 → … → bd-r0 with chords i → i+2: every node sits
+	// on many cycles
+	const n = 30
+	for i := 0; i < n; i++ {
+		env.CreateIssueWithID(fmt.Sprintf("bd-r%d", i), "Ring node")
+	}
+	edges := 0
+	for i := 0; i < n; i++ {
+		for _, step := range []int{1, 2} {
+			if _, err := env.Store.db.ExecContext(env.Ctx, `
+				INSERT INTO dependencies (issue_id, depends_on_id, type, created_at, created_by)
+				VALUES (?, ?, 'related', CURRENT_TIMESTAMP, 'test')
+			`, fmt.Sprintf("bd-r%d", i), fmt.Sprintf("bd-r%d", (i+step)%n)); err != nil {
+				t.Fatalf("failed to add ring edge: %v", err)
+			}
+			edges++
+		}
+	}
+
+	nodes, rowsRead, err := env.Store.dependencyClosure(env.Ctx, "bd-r0", types.ClosureDependencies)
+	if err != nil {
+		t.Fatalf("dependencyClosure failed: %v", err)
+	}
+	if len(nodes) != n-1 {
+		t.Fatalf("got %d nodes, want %d", len(nodes), n-1)
+	}
+	for _, node := range nodes {
+		var i int
+		if _, err := fmt.Sscanf(node.ID, "bd-r%d", &i); err != nil {
+			t.Fatalf("unexpected node %s", node.ID)
+		}
+		if want := (i + 1) / 2; node.Depth != want {
+			t.Errorf("%s at depth %d, want %d", node.ID, node.Depth, want)
+		}
+	}
+	if rowsRead > edges {
+		t.Errorf("read %d edge rows for %d edges; each edge should be read at most once", rowsRead, edges)
+	}
+}
@@ -0,0 +1,171 @@
+package sqlite
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// DescriptionBlobThresholdConfigKey enables the description blob store: issue
+// descriptions at least this many bytes long are stored once in
+// description_blobs, keyed by content hash, and the issue row holds only a
+// reference. Identical bodies share one blob. Unset or 0 stores every
+// description inline.
+const DescriptionBlobThresholdConfigKey = "storage.description_blob_threshold"
+
+// descriptionBlobRefPrefix marks a description column value as a blob
+// reference. U+FFFC (OBJECT REPLACEMENT CHARACTER) keeps it from colliding
+// with real description text.
+const descriptionBlobRefPrefix = "\uFFFCblob:"
+
+// getDescriptionBlobThreshold returns the configured blob threshold, or 0 when
+// the blob store is disabled.
+func getDescriptionBlobThreshold(ctx context.Context, db dbExecutor) int {
+	var value string
+	if err := db.QueryRowContext(ctx, `SELECT value FROM config WHERE key = ?`, DescriptionBlobThresholdConfigKey).Scan(&value); err != nil {
+		return 0
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
+
+// packDescriptions moves inline descriptions of issueIDs that meet the blob
+// threshold into description_blobs. It is a no-op when the blob store is
+// disabled. Call it after any write of the description column.
+func packDescriptions(ctx context.Context, db dbExecutor, issueIDs ...string) error {
+	if len(issueIDs) == 0 {
+		return nil
+	}
+	threshold := getDescriptionBlobThreshold(ctx, db)
+	if threshold <= 0 {
+		return nil
+	}
+
+	bodies := make(map[string]string)
+	for start := 0; start < len(issueIDs); start += idChunkSize {
+		chunk := issueIDs[start:min(start+idChunkSize, len(issueIDs))]
+		args := make([]interface{}, 0, len(chunk)+2)
+		args = append(args, threshold, descriptionBlobRefPrefix+"%")
+		for _, id := range chunk {
+			args = append(args, id)
+		}
+		// #nosec G201 -- placeholders are generated internally
+		query := fmt.Sprintf(`
+			SELECT id, description FROM issues
+			WHERE length(CAST(description AS BLOB)) >= ? AND description NOT LIKE ?
+			  AND id IN (%s)
+		`, buildPlaceholders(len(chunk)))
+
+		rows, err := db.QueryContext(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("failed to find large descriptions: %w", err)
+		}
+		for rows.Next() {
+			var id, description string
+			if err := rows.Scan(&id, &description); err != nil {
+				_ = rows.Close()
+				return fmt.Errorf("failed to scan description: %w", err)
+			}
+			bodies[id] = description
+		}
+		_ = rows.Close()
+		if err := rows.Err(); err != nil {
+			return wrapDBError("iterate large descriptions", err)
+		}
+	}
+
+	for id, body := range bodies {
+		sum := sha256.Sum256([]byte(body))
+		hash := hex.EncodeToString(sum[:])
+		if _, err := db.ExecContext(ctx, `
+			INSERT OR IGNORE INTO description_blobs (hash, content) VALUES (?, ?)
+		`, hash, body); err != nil {
+			return fmt.Errorf("failed to store description blob: %w", err)
+		}
+		if _, err := db.ExecContext(ctx, `
+			UPDATE issues SET description = ? WHERE id = ?
+		`, descriptionBlobRefPrefix+hash, id); err != nil {
+			return fmt.Errorf("failed to reference description blob for %s: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// hydrateDescriptions replaces blob references in the descriptions of issues
+// with the blob content. Issues without references cost no query.
+func hydrateDescriptions(ctx context.Context, db dbExecutor, issues ...*types.Issue) error {
+	byHash := make(map[string][]*types.Issue)
+	for _, issue := range issues {
+		if issue == nil {
+			continue
+		}
+		if hash, ok := strings.CutPrefix(issue.Description, descriptionBlobRefPrefix); ok {
+			byHash[hash] = append(byHash[hash], issue)
+		}
+	}
+	if len(byHash) == 0 {
+		return nil
+	}
+
+	hashes := make([]string, 0, len(byHash))
+	for hash := range byHash {
+		hashes = append(hashes, hash)
+	}
+	for start := 0; start < len(hashes); start += idChunkSize {
+		chunk := hashes[start:min(start+idChunkSize, len(hashes))]
+		args := make([]interface{}, len(chunk))
+		for i, hash := range chunk {
+			args[i] = hash
+		}
+		// #nosec G201 -- placeholders are generated internally
+		query := fmt.Sprintf(`SELECT hash, content FROM description_blobs WHERE hash IN (%s)`, buildPlaceholders(len(args)))
+
+		rows, err := db.QueryContext(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("failed to load description blobs: %w", err)
+		}
+		for rows.Next() {
+			var hash, content string
+			if err := rows.Scan(&hash, &content); err != nil {
+				_ = rows.Close()
+				return fmt.Errorf("failed to scan description blob: %w", err)
+			}
+			for _, issue := range byHash[hash] {
+				issue.Description = content
+			}
+			delete(byHash, hash)
+		}
+		_ = rows.Close()
+		if err := rows.Err(); err != nil {
+			return wrapDBError("iterate description blobs", err)
+		}
+	}
+	for hash, missing := range byHash {
+		return fmt.Errorf("description blob %s for issue %s: %w", hash, missing[0].ID, ErrNotFound)
+	}
+	return nil
+}
+
+// descriptionLikeClause returns a WHERE fragment matching descriptions LIKE
+// pattern, including descriptions stored as blobs.
+func descriptionLikeClause(pattern string) (string, []interface{}) {
+	return "(description LIKE ? OR description IN (SELECT ? || hash FROM description_blobs WHERE content LIKE ?))",
+		[]interface{}{pattern, descriptionBlobRefPrefix, pattern}
+}
+
+// packIssueDescriptions is packDescriptions for the IDs of issues.
+func packIssueDescriptions(ctx context.Context, db dbExecutor, issues []*types.Issue) error {
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	return packDescriptions(ctx, db, ids...)
+}
@@ -0,0 +1,109 @@
+package sqlite
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestDescriptionBlobs(t *testing.T) {
+	env := newTestEnv(t)
+	if err := env.Store.SetConfig(env.Ctx, DescriptionBlobThresholdConfigKey, "64"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+
+	boilerplate := strings.Repeat("Standard release checklist. ", 10)
+	newIssue := func(title, description string) *types.Issue {
+		issue := &types.Issue{Title: title, Description: description, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := env.Store.CreateIssue(env.Ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue
+	}
+	first := newIssue("Release 1", boilerplate)
+	second := newIssue("Release 2", boilerplate)
+	small := newIssue("Small", "short body")
+
+	storedDescription := func(id string) string {
+		t.Helper()
+		var description string
+		if err := env.Store.db.QueryRowContext(env.Ctx, `SELECT description FROM issues WHERE id = ?`, id).Scan(&description); err != nil {
+			t.Fatalf("failed to read stored description: %v", err)
+		}
+		return description
+	}
+	blobCount := func() int {
+		t.Helper()
+		var n int
+		if err := env.Store.db.QueryRowContext(env.Ctx, `SELECT COUNT(*) FROM description_blobs`).Scan(&n); err != nil {
+			t.Fatalf("failed to count blobs: %v", err)
+		}
+		return n
+	}
+
+	if got := blobCount(); got != 1 {
+		t.Errorf("expected identical bodies to share 1 blob, got %d", got)
+	}
+	if stored := storedDescription(first.ID); !strings.HasPrefix(stored, descriptionBlobRefPrefix) {
+		t.Errorf("expected large description stored as a reference, got %q", stored)
+	}
+	if stored := storedDescription(small.ID); stored != "short body" {
+		t.Errorf("expected small description stored inline, got %q", stored)
+	}
+
+	for _, want := range []*types.Issue{first, second, small} {
+		got, err := env.Store.GetIssue(env.Ctx, want.ID)
+		if err != nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		if !got.Equal(want) {
+			t.Errorf("issue %s did not round-trip: description %q", want.ID, got.Description)
+		}
+	}
+
+	found, err := env.Store.SearchIssues(env.Ctx, "", types.IssueFilter{DescriptionContains: "release checklist"})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(found) != 2 {
+		t.Errorf("expected search to match both blob descriptions, got %d", len(found))
+	}
+	for _, issue := range found {
+		if issue.Description != boilerplate {
+			t.Errorf("expected hydrated description in search results for %s", issue.ID)
+		}
+	}
+
+	updated := strings.Repeat("Rewritten body. ", 10)
+	if err := env.Store.UpdateIssue(env.Ctx, second.ID, map[string]interface{}{"description": updated}, "test-user"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if got := blobCount(); got != 2 {
+		t.Errorf("expected a second blob after update, got %d", got)
+	}
+	got, err := env.Store.GetIssue(env.Ctx, second.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Description != updated {
+		t.Errorf("expected updated description, got %q", got.Description)
+	}
+}
+
+func TestDescriptionBlobs_DisabledByDefault(t *testing.T) {
+	env := newTestEnv(t)
+	body := strings.Repeat("x", 10000)
+	issue := &types.Issue{Title: "Large", Description: body, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := env.Store.CreateIssue(env.Ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	var stored string
+	if err := env.Store.db.QueryRowContext(env.Ctx, `SELECT description FROM issues WHERE id = ?`, issue.ID).Scan(&stored); err != nil {
+		t.Fatalf("failed to read stored description: %v", err)
+	}
+	if stored != body {
+		t.Error("expected description stored inline when blobs are disabled")
+	}
+}
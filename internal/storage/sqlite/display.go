@@ -0,0 +1,37 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ListIssuesByDisplayOrder returns issues in board order: by DisplayOrder,
+// lowest first, then by ID. With a parentID only that issue's children (via
+// parent-child dependencies) are listed, e.g. the issues of one epic.
+// Tombstones are excluded. The scan uses idx_issues_display_order.
+func (s *SQLiteStorage) ListIssuesByDisplayOrder(ctx context.Context, parentID string) ([]*types.Issue, error) {
+	s.checkFreshness()
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	where := `status != 'tombstone'`
+	var args []interface{}
+	if parentID != "" {
+		where += ` AND id IN (SELECT issue_id FROM dependencies WHERE depends_on_id = ? AND type = ?)`
+		args = append(args, parentID, types.DepParentChild)
+	}
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+rangeIssueColumns+`
+		FROM issues
+		WHERE `+where+`
+		ORDER BY display_order, id
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issues by display order: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return scanIssueList(ctx, s, rows)
+}
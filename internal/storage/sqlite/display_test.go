@@ -0,0 +1,119 @@
+package sqlite
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestDisplayMetadata_RoundTrip(t *testing.T) {
+	env := newTestEnv(t)
+
+	epic := &types.Issue{ID: "bd-epic", Title: "Board", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeEpic, Color: "#0e8a16"}
+	if err := env.Store.CreateIssue(env.Ctx, epic, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	cards := []*types.Issue{
+		{ID: "bd-c", Title: "Third", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, DisplayOrder: 3},
+		{ID: "bd-a", Title: "First", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, DisplayOrder: 1, Color: "#d73a4a"},
+		{ID: "bd-b", Title: "Second", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, DisplayOrder: 2},
+	}
+	for _, card := range cards {
+		if err := env.Store.CreateIssue(env.Ctx, card, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		if err := env.Store.AddDependency(env.Ctx, &types.Dependency{IssueID: card.ID, DependsOnID: epic.ID, Type: types.DepParentChild}, "test-user"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+
+	got, err := env.Store.GetIssue(env.Ctx, "bd-a")
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Color != "#d73a4a" || got.DisplayOrder != 1 {
+		t.Errorf("expected color and display order persisted, got %q/%d", got.Color, got.DisplayOrder)
+	}
+
+	// Updates write the columns and keep the content hash current
+	if err := env.Store.UpdateIssue(env.Ctx, "bd-c", map[string]interface{}{"display_order": 0, "color": "#fbca04"}, "test-user"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	got, err = env.Store.GetIssue(env.Ctx, "bd-c")
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Color != "#fbca04" || got.DisplayOrder != 0 || got.ContentHash != got.ComputeContentHash() {
+		t.Errorf("expected updated display fields with a matching hash, got %q/%d", got.Color, got.DisplayOrder)
+	}
+
+	board, err := env.Store.ListIssuesByDisplayOrder(env.Ctx, epic.ID)
+	if err != nil {
+		t.Fatalf("ListIssuesByDisplayOrder failed: %v", err)
+	}
+	var order []string
+	for _, issue := range board {
+		order = append(order, issue.ID)
+	}
+	if want := "bd-c bd-a bd-b"; strings.Join(order, " ") != want {
+		t.Errorf("board order = %s, want %s", strings.Join(order, " "), want)
+	}
+	all, err := env.Store.ListIssuesByDisplayOrder(env.Ctx, "")
+	if err != nil {
+		t.Fatalf("ListIssuesByDisplayOrder failed: %v", err)
+	}
+	if len(all) != 4 {
+		t.Errorf("expected every issue without a parent filter, got %d", len(all))
+	}
+
+	var buf bytes.Buffer
+	if err := env.Store.StreamExport(env.Ctx, &buf, types.IssueFilter{}); err != nil {
+		t.Fatalf("StreamExport failed: %v", err)
+	}
+	exported := make(map[string]*types.Issue)
+	for _, issue := range decodeExport(t, buf.Bytes()) {
+		exported[issue.ID] = issue
+	}
+	if e := exported["bd-a"]; e == nil || e.Color != "#d73a4a" || e.DisplayOrder != 1 {
+		t.Errorf("expected display fields exported, got %+v", e)
+	}
+	if e := exported["bd-epic"]; e == nil || e.Color != "#0e8a16" {
+		t.Errorf("expected epic color exported, got %+v", e)
+	}
+}
+
+func TestDisplayMetadata_ContentHashSetting(t *testing.T) {
+	for _, hashed := range []bool{false, true} {
+		env := newTestEnv(t)
+		if hashed {
+			if err := env.Store.SetConfig(env.Ctx, storage.HashDisplayFieldsConfigKey, "true"); err != nil {
+				t.Fatalf("SetConfig failed: %v", err)
+			}
+		}
+		card := &types.Issue{ID: "bd-1", Title: "Card", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := env.Store.CreateIssue(env.Ctx, card, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		plain := card.ContentHash
+
+		if err := env.Store.UpdateIssue(env.Ctx, "bd-1", map[string]interface{}{"color": "#fbca04", "display_order": 4}, "test-user"); err != nil {
+			t.Fatalf("UpdateIssue failed: %v", err)
+		}
+		got, err := env.Store.GetIssue(env.Ctx, "bd-1")
+		if err != nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		if want := got.ComputeContentHashWith(types.ContentHashOptions{DisplayFields: hashed}); got.ContentHash != want {
+			t.Errorf("hashed=%v: content hash %s, want %s", hashed, got.ContentHash, want)
+		}
+		if moved := got.ContentHash != plain; moved != hashed {
+			t.Errorf("hashed=%v: recoloring changed the hash = %v", hashed, moved)
+		}
+		if stale, err := env.Store.ListIssuesWithStaleHash(env.Ctx, 0); err != nil || len(stale) != 0 {
+			t.Errorf("hashed=%v: stale hashes = %v, %v; want none", hashed, stale, err)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// DuplicateGroup is a set of issues whose content hashes are identical.
+type DuplicateGroup struct {
+	ContentHash string   `json:"content_hash"`
+	IssueIDs    []string `json:"issue_ids"` // Oldest first
+}
+
+// FindDuplicatesByHash groups non-tombstone issues that share a content hash.
+// The content hash excludes the ID, so each group is a set of issues with the
+// same substantive content under different IDs. Groups are ordered by hash.
+func (s *SQLiteStorage) FindDuplicatesByHash(ctx context.Context) ([]*DuplicateGroup, error) {
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT content_hash, id FROM issues
+		WHERE content_hash IN (
+			SELECT content_hash FROM issues
+			WHERE content_hash IS NOT NULL AND content_hash != '' AND status != ?
+			GROUP BY content_hash
+			HAVING COUNT(*) > 1
+		) AND status != ?
+		ORDER BY content_hash, created_at, id
+	`, types.StatusTombstone, types.StatusTombstone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find duplicate issues: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var groups []*DuplicateGroup
+	for rows.Next() {
+		var hash, id string
+		if err := rows.Scan(&hash, &id); err != nil {
+			return nil, fmt.Errorf("failed to scan duplicate issue: %w", err)
+		}
+		if len(groups) == 0 || groups[len(groups)-1].ContentHash != hash {
+			groups = append(groups, &DuplicateGroup{ContentHash: hash})
+		}
+		last := groups[len(groups)-1]
+		last.IssueIDs = append(last.IssueIDs, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, wrapDBError("iterate duplicate issues", err)
+	}
+	return groups, nil
+}
+
+// MergeDuplicates folds mergeIDs into keepID in a single transaction.
+//
+// Dependencies on each merged issue, and its own dependencies, are repointed to
+// the kept issue (dropping any that would duplicate an existing edge or point
+// the kept issue at itself). A merged issue's parent is carried over only if
+// the kept issue has none. Hierarchical children (bd-x.1 under bd-x) are
+// renamed under the kept issue as with ReparentIssues. Each merged issue is
+// then tombstoned, and a "merged" event is recorded on both sides.
+func (s *SQLiteStorage) MergeDuplicates(ctx context.Context, keepID string, mergeIDs []string, actor string) error {
+	if len(mergeIDs) == 0 {
+		return nil
+	}
+	seen := make(map[string]bool, len(mergeIDs))
+	for _, id := range mergeIDs {
+		if id == keepID {
+			return fmt.Errorf("cannot merge %s into itself", keepID)
+		}
+		if seen[id] {
+			return fmt.Errorf("duplicate merge ID %s", id)
+		}
+		if strings.HasPrefix(keepID, id+".") {
+			return fmt.Errorf("cannot merge %s into its own descendant %s", id, keepID)
+		}
+		seen[id] = true
+	}
+
+	return s.withTx(ctx, func(conn *sql.Conn) error {
+		// Defer FK checks to commit so child IDs can be rewritten table by table.
+		if _, err := conn.ExecContext(ctx, `PRAGMA defer_foreign_keys = ON`); err != nil {
+			return fmt.Errorf("failed to defer foreign keys: %w", err)
+		}
+
+		issueTypes := make(map[string]string, len(mergeIDs))
+		for _, id := range append([]string{keepID}, mergeIDs...) {
+			var status, issueType string
+			err := conn.QueryRowContext(ctx, `SELECT status, issue_type FROM issues WHERE id = ?`, id).Scan(&status, &issueType)
+			if err == sql.ErrNoRows {
+				return fmt.Errorf("issue %s not found", id)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to check issue %s: %w", id, err)
+			}
+			if types.Status(status) == types.StatusTombstone {
+				return fmt.Errorf("issue %s is already deleted", id)
+			}
+			issueTypes[id] = issueType
+		}
+
+		dirty := map[string]bool{keepID: true}
+		for _, mergeID := range mergeIDs {
+			children, err := hierarchicalChildIDs(ctx, conn, mergeID)
+			if err != nil {
+				return err
+			}
+			for _, child := range children {
+				renames, err := reparentHierarchicalID(ctx, conn, child, keepID, actor)
+				if err != nil {
+					return err
+				}
+				for _, newID := range renames {
+					dirty[newID] = true
+				}
+			}
+
+			dependents, err := dependentIssueIDs(ctx, conn, mergeID)
+			if err != nil {
+				return err
+			}
+			for _, id := range dependents {
+				dirty[id] = true
+			}
+			if err := repointDependencies(ctx, conn, mergeID, keepID); err != nil {
+				return err
+			}
+
+			if err := tombstoneIssue(ctx, conn, mergeID, issueTypes[mergeID], actor, "merged into "+keepID); err != nil {
+				return err
+			}
+			for _, id := range []string{keepID, mergeID} {
+				if _, err := conn.ExecContext(ctx, `
+					INSERT INTO events (issue_id, event_type, actor, old_value, new_value)
+					VALUES (?, ?, ?, ?, ?)
+				`, id, types.EventMerged, actor, mergeID, keepID); err != nil {
+					return fmt.Errorf("failed to record merge event for %s: %w", id, err)
+				}
+			}
+		}
+		for _, id := range mergeIDs {
+			delete(dirty, id) // already marked by the tombstone
+		}
+
+		for id := range dirty {
+			if err := markDirty(ctx, conn, id); err != nil {
+				return err
+			}
+		}
+		return s.invalidateBlockedCache(ctx, conn)
+	})
+}
+
+// hierarchicalChildIDs returns the direct hierarchical children of parentID
+// (bd-x.1 but not bd-x.1.1).
+func hierarchicalChildIDs(ctx context.Context, conn *sql.Conn, parentID string) ([]string, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT id FROM issues WHERE id LIKE ? ESCAPE '\'`, escapeLike(parentID)+".%")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list children of %s: %w", parentID, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var children []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan child id: %w", err)
+		}
+		if !strings.Contains(strings.TrimPrefix(id, parentID+"."), ".") {
+			children = append(children, id)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list children of %s: %w", parentID, err)
+	}
+	return children, nil
+}
+
+// dependentIssueIDs returns the issues that depend on id.
+func dependentIssueIDs(ctx context.Context, conn *sql.Conn, id string) ([]string, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT DISTINCT issue_id FROM dependencies WHERE depends_on_id = ?`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dependents of %s: %w", id, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var ids []string
+	for rows.Next() {
+		var depID string
+		if err := rows.Scan(&depID); err != nil {
+			return nil, fmt.Errorf("failed to scan dependent id: %w", err)
+		}
+		ids = append(ids, depID)
+	}
+	return ids, rows.Err()
+}
+
+// repointDependencies moves every dependency edge touching fromID to toID.
+// Edges that would duplicate an existing one or become self-loops are dropped,
+// and fromID's parent is only carried over when toID has no parent.
+func repointDependencies(ctx context.Context, conn *sql.Conn, fromID, toID string) error {
+	statements := []struct {
+		query string
+		args  []interface{}
+	}{
+		{`UPDATE OR IGNORE dependencies SET depends_on_id = ? WHERE depends_on_id = ? AND issue_id != ?`,
+			[]interface{}{toID, fromID, toID}},
+		{`UPDATE OR IGNORE dependencies SET issue_id = ?
+		  WHERE issue_id = ? AND depends_on_id != ?
+		    AND (type != ? OR NOT EXISTS (SELECT 1 FROM dependencies WHERE issue_id = ? AND type = ?))`,
+			[]interface{}{toID, fromID, toID, types.DepParentChild, toID, types.DepParentChild}},
+		{`DELETE FROM dependencies WHERE issue_id = ? OR depends_on_id = ?`,
+			[]interface{}{fromID, fromID}},
+	}
+	for _, stmt := range statements {
+		if _, err := conn.ExecContext(ctx, stmt.query, stmt.args...); err != nil {
+			return fmt.Errorf("failed to repoint dependencies of %s: %w", fromID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestFindDuplicatesByHash(t *testing.T) {
+	env := newTestEnv(t)
+	first := env.CreateIssueWithID("bd-a", "Login page broken")
+	second := env.CreateIssueWithID("bd-b", "Login page broken")
+	env.CreateIssueWithID("bd-c", "Unrelated")
+	deleted := env.CreateIssueWithID("bd-d", "Login page broken")
+	if err := env.Store.CreateTombstone(env.Ctx, deleted.ID, "test-user", "cleanup"); err != nil {
+		t.Fatalf("CreateTombstone failed: %v", err)
+	}
+
+	groups, err := env.Store.FindDuplicatesByHash(env.Ctx)
+	if err != nil {
+		t.Fatalf("FindDuplicatesByHash failed: %v", err)
+	}
+	if len(groups) != 1 {
+		t.Fatalf("expected 1 duplicate group, got %d", len(groups))
+	}
+	group := groups[0]
+	if group.ContentHash != first.ContentHash {
+		t.Errorf("expected hash %s, got %s", first.ContentHash, group.ContentHash)
+	}
+	if len(group.IssueIDs) != 2 || group.IssueIDs[0] != first.ID || group.IssueIDs[1] != second.ID {
+		t.Errorf("expected [%s %s] (tombstones excluded), got %v", first.ID, second.ID, group.IssueIDs)
+	}
+}
+
+func TestMergeDuplicates(t *testing.T) {
+	env := newTestEnv(t)
+	keep := env.CreateIssueWithID("bd-k", "Login page broken")
+	dup := env.CreateIssueWithID("bd-m", "Login page broken")
+	child := env.CreateIssueWithID("bd-m.1", "Check session cookie")
+	blocker := env.CreateIssue("Upgrade auth library")
+	dependent := env.CreateIssue("Release 2.0")
+	env.AddParentChild(child, dup)
+	env.AddDep(dup, blocker)
+	env.AddDep(dependent, dup)
+	env.AddDep(dependent, keep) // already points at keep; must not be duplicated
+
+	if err := env.Store.MergeDuplicates(env.Ctx, keep.ID, []string{dup.ID}, "test-user"); err != nil {
+		t.Fatalf("MergeDuplicates failed: %v", err)
+	}
+
+	merged, err := env.Store.GetIssue(env.Ctx, dup.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if merged.Status != types.StatusTombstone {
+		t.Errorf("expected merged issue tombstoned, got %s", merged.Status)
+	}
+
+	assertDeps := func(id string, want ...string) {
+		t.Helper()
+		deps, err := env.Store.GetDependencyRecords(env.Ctx, id)
+		if err != nil {
+			t.Fatalf("GetDependencyRecords failed: %v", err)
+		}
+		got := make(map[string]bool)
+		for _, dep := range deps {
+			got[dep.DependsOnID] = true
+		}
+		if len(deps) != len(want) {
+			t.Errorf("%s: expected deps %v, got %d records", id, want, len(deps))
+		}
+		for _, w := range want {
+			if !got[w] {
+				t.Errorf("%s: expected dependency on %s", id, w)
+			}
+		}
+	}
+	assertDeps(keep.ID, blocker.ID)
+	assertDeps(dependent.ID, keep.ID)
+	assertDeps(dup.ID)
+
+	if old, err := env.Store.GetIssue(env.Ctx, child.ID); err != nil || old != nil {
+		t.Errorf("expected child renamed away from %s, got %v (err %v)", child.ID, old, err)
+	}
+	assertDeps("bd-k.1", keep.ID)
+
+	for _, id := range []string{keep.ID, dup.ID} {
+		events, err := env.Store.GetEvents(env.Ctx, id, 0)
+		if err != nil {
+			t.Fatalf("GetEvents failed: %v", err)
+		}
+		found := false
+		for _, e := range events {
+			if e.EventType == types.EventMerged {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected merged event on %s", id)
+		}
+	}
+}
+
+func TestMergeDuplicates_Validation(t *testing.T) {
+	env := newTestEnv(t)
+	keep := env.CreateIssueWithID("bd-k", "Keep")
+	dup := env.CreateIssueWithID("bd-m", "Dup")
+	descendant := env.CreateIssueWithID("bd-m.1", "Descendant")
+
+	cases := map[string]struct {
+		keep  string
+		merge []string
+	}{
+		"self":       {keep.ID, []string{keep.ID}},
+		"missing":    {keep.ID, []string{"bd-nope"}},
+		"repeated":   {keep.ID, []string{dup.ID, dup.ID}},
+		"descendant": {descendant.ID, []string{dup.ID}},
+	}
+	for name, tc := range cases {
+		if err := env.Store.MergeDuplicates(env.Ctx, tc.keep, tc.merge, "test-user"); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+	if got, _ := env.Store.GetIssue(env.Ctx, dup.ID); got == nil || got.Status == types.StatusTombstone {
+		t.Error("expected rejected merges to leave issues untouched")
+	}
+}
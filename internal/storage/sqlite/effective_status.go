@@ -0,0 +1,105 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// RollupRule decides an issue's effective status from the statuses of its
+// descendants (see GetEffectiveStatus). Each rule yields closed, in_progress
+// or open.
+type RollupRule string
+
+const (
+	// RollupAllClosed is closed once every descendant is closed, in_progress
+	// once any descendant is closed or started, and open otherwise (default).
+	RollupAllClosed RollupRule = "all-closed"
+	// RollupAnyOpen is open while any descendant is still open (not started),
+	// closed once every descendant is closed, and in_progress otherwise.
+	RollupAnyOpen RollupRule = "any-open"
+	// RollupMajority is closed when more than half the descendants are
+	// closed, open when more than half are open, and in_progress otherwise.
+	RollupMajority RollupRule = "majority"
+)
+
+// GetEffectiveStatus returns id's status rolled up from its subtree under
+// rule: all of its descendants through parent-child dependencies, found with
+// a single recursive query. Tombstoned descendants and everything below them
+// are excluded. An issue with no descendants has its own status.
+func (s *SQLiteStorage) GetEffectiveStatus(ctx context.Context, id string, rule RollupRule) (types.Status, error) {
+	switch rule {
+	case "":
+		rule = RollupAllClosed
+	case RollupAllClosed, RollupAnyOpen, RollupMajority:
+	default:
+		return "", fmt.Errorf("unknown roll-up rule %q (want all-closed, any-open or majority)", rule)
+	}
+
+	s.checkFreshness()
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	var own types.Status
+	var total, closed, open int
+	err := s.db.QueryRowContext(ctx, `
+		WITH RECURSIVE subtree(id, status) AS (
+			SELECT i.id, i.status
+			FROM dependencies d
+			JOIN issues i ON i.id = d.issue_id
+			WHERE d.depends_on_id = ? AND d.type = ? AND i.status != ?
+			UNION
+			SELECT i.id, i.status
+			FROM subtree st
+			JOIN dependencies d ON d.depends_on_id = st.id AND d.type = ?
+			JOIN issues i ON i.id = d.issue_id
+			WHERE i.status != ?
+		)
+		SELECT root.status,
+			COUNT(st.id),
+			COALESCE(SUM(st.status = ?), 0),
+			COALESCE(SUM(st.status = ?), 0)
+		FROM issues root
+		LEFT JOIN subtree st ON st.id != root.id
+		WHERE root.id = ?
+		GROUP BY root.id
+	`, id, types.DepParentChild, types.StatusTombstone, types.DepParentChild, types.StatusTombstone,
+		types.StatusClosed, types.StatusOpen, id).Scan(&own, &total, &closed, &open)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("issue %s: %w", id, ErrNotFound)
+	}
+	if err != nil {
+		return "", wrapDBError("get effective status", err)
+	}
+	if total == 0 {
+		return own, nil
+	}
+
+	switch rule {
+	case RollupAnyOpen:
+		switch {
+		case open > 0:
+			return types.StatusOpen, nil
+		case closed == total:
+			return types.StatusClosed, nil
+		}
+	case RollupMajority:
+		switch {
+		case 2*closed > total:
+			return types.StatusClosed, nil
+		case 2*open > total:
+			return types.StatusOpen, nil
+		}
+	default:
+		switch {
+		case closed == total:
+			return types.StatusClosed, nil
+		case open == total:
+			return types.StatusOpen, nil
+		}
+	}
+	return types.StatusInProgress, nil
+}
@@ -0,0 +1,67 @@
+package sqlite
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestGetEffectiveStatus(t *testing.T) {
+	env := newTestEnv(t)
+	epic := env.CreateEpic("Epic")
+	closed1 := env.CreateIssue("Closed 1")
+	closed2 := env.CreateIssue("Closed 2")
+	open := env.CreateIssue("Open")
+	started := env.CreateIssueWith("Started", types.StatusInProgress, 2, types.TypeTask)
+	grandchild := env.CreateIssue("Grandchild")
+	deleted := env.CreateIssue("Deleted")
+	underDeleted := env.CreateIssueWith("Under deleted", types.StatusInProgress, 2, types.TypeTask)
+	for _, child := range []*types.Issue{closed1, closed2, open, started, deleted} {
+		env.AddParentChild(child, epic)
+	}
+	env.AddParentChild(grandchild, started)
+	env.AddParentChild(underDeleted, deleted)
+	for _, issue := range []*types.Issue{closed1, closed2, grandchild} {
+		env.Close(issue, "done")
+	}
+	if err := env.Store.CreateTombstone(env.Ctx, deleted.ID, "test-user", "gone"); err != nil {
+		t.Fatalf("CreateTombstone failed: %v", err)
+	}
+
+	// Counted: three closed, one open, one in progress
+	for rule, want := range map[RollupRule]types.Status{
+		RollupAllClosed: types.StatusInProgress,
+		RollupAnyOpen:   types.StatusOpen,
+		RollupMajority:  types.StatusClosed,
+	} {
+		got, err := env.Store.GetEffectiveStatus(env.Ctx, epic.ID, rule)
+		if err != nil {
+			t.Fatalf("GetEffectiveStatus(%s) failed: %v", rule, err)
+		}
+		if got != want {
+			t.Errorf("GetEffectiveStatus(%s) = %s, want %s", rule, got, want)
+		}
+	}
+
+	// A subtree of its own: the started issue's only descendant is closed
+	if got, _ := env.Store.GetEffectiveStatus(env.Ctx, started.ID, RollupMajority); got != types.StatusClosed {
+		t.Errorf("only child closed: majority = %s, want closed", got)
+	}
+	env.Close(open, "done")
+	env.Close(started, "done")
+	if got, _ := env.Store.GetEffectiveStatus(env.Ctx, epic.ID, ""); got != types.StatusClosed {
+		t.Errorf("all children closed: default rule = %s, want closed", got)
+	}
+
+	// A leaf has its own status
+	if got, _ := env.Store.GetEffectiveStatus(env.Ctx, underDeleted.ID, RollupAllClosed); got != types.StatusInProgress {
+		t.Errorf("leaf = %s, want its own status", got)
+	}
+	if _, err := env.Store.GetEffectiveStatus(env.Ctx, epic.ID, "unanimous"); err == nil {
+		t.Error("expected unknown rule to fail")
+	}
+	if _, err := env.Store.GetEffectiveStatus(env.Ctx, "bd-missing", RollupAllClosed); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
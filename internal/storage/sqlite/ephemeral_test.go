@@ -0,0 +1,184 @@
+package sqlite
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// exportJSONLForTest writes every issue (including tombstones) as one JSON line, sorted by ID.
+func exportJSONLForTest(t *testing.T, ctx context.Context, s *SQLiteStorage) []byte {
+	t.Helper()
+	issues, err := s.SearchIssues(ctx, "", types.IssueFilter{IncludeTombstones: true})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	sort.Slice(issues, func(i, j int) bool { return issues[i].ID < issues[j].ID })
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, issue := range issues {
+		if err := enc.Encode(issue); err != nil {
+			t.Fatalf("encode %s: %v", issue.ID, err)
+		}
+	}
+	return buf.Bytes()
+}
+
+func TestNewEphemeralStorage_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	// Source database with a mix of open and closed issues
+	src := newTestStore(t, "")
+	closedAt := time.Date(2025, 3, 2, 10, 0, 0, 0, time.UTC)
+	seed := []*types.Issue{
+		{ID: "bd-1", Title: "First", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask},
+		{ID: "bd-2", Title: "Second", Description: "details", Status: types.StatusInProgress, Priority: 2, IssueType: types.TypeBug, Assignee: "alice"},
+		{ID: "bd-3", Title: "Third", Status: types.StatusClosed, Priority: 3, IssueType: types.TypeFeature, ClosedAt: &closedAt},
+	}
+	for _, issue := range seed {
+		if err := src.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue(%s) failed: %v", issue.ID, err)
+		}
+	}
+	exported := exportJSONLForTest(t, ctx, src)
+
+	eph, err := NewEphemeralStorage(ctx, "bd")
+	if err != nil {
+		t.Fatalf("NewEphemeralStorage failed: %v", err)
+	}
+	defer func() { _ = eph.Close() }()
+
+	// Import the export into the ephemeral store
+	err = eph.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		scanner := bufio.NewScanner(bytes.NewReader(exported))
+		for scanner.Scan() {
+			var issue types.Issue
+			if err := json.Unmarshal(scanner.Bytes(), &issue); err != nil {
+				return err
+			}
+			if err := tx.(*sqliteTxStorage).CreateIssueImport(ctx, &issue, "import", false); err != nil {
+				return err
+			}
+		}
+		return scanner.Err()
+	})
+	if err != nil {
+		t.Fatalf("import into ephemeral store failed: %v", err)
+	}
+
+	// Transform in process, then export again
+	if err := eph.UpdateIssue(ctx, "bd-1", map[string]interface{}{"priority": 0}, "transform"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	roundTripped := exportJSONLForTest(t, ctx, eph)
+
+	var before, after []map[string]interface{}
+	for _, data := range [][]byte{exported, roundTripped} {
+		var decoded []map[string]interface{}
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		for scanner.Scan() {
+			var m map[string]interface{}
+			if err := json.Unmarshal(scanner.Bytes(), &m); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			delete(m, "updated_at")
+			decoded = append(decoded, m)
+		}
+		if before == nil {
+			before = decoded
+		} else {
+			after = decoded
+		}
+	}
+	if len(after) != len(before) {
+		t.Fatalf("expected %d issues after round trip, got %d", len(before), len(after))
+	}
+	for i := range before {
+		if before[i]["id"] == "bd-1" {
+			if after[i]["priority"] != float64(0) {
+				t.Errorf("expected transformed priority 0 for bd-1, got %v", after[i]["priority"])
+			}
+			before[i]["priority"] = after[i]["priority"]
+		}
+		b, _ := json.Marshal(before[i])
+		a, _ := json.Marshal(after[i])
+		if !bytes.Equal(a, b) {
+			t.Errorf("round-trip mismatch for %v:\nbefore: %s\nafter:  %s", before[i]["id"], b, a)
+		}
+	}
+
+	// The source database must be untouched by the transform
+	orig, err := src.GetIssue(ctx, "bd-1")
+	if err != nil || orig == nil {
+		t.Fatalf("GetIssue on source failed: %v", err)
+	}
+	if orig.Priority != 1 {
+		t.Errorf("source issue was modified: priority = %d", orig.Priority)
+	}
+}
+
+func TestNewEphemeralStorage_Isolated(t *testing.T) {
+	ctx := context.Background()
+
+	a, err := NewEphemeralStorage(ctx, "aa")
+	if err != nil {
+		t.Fatalf("NewEphemeralStorage(aa) failed: %v", err)
+	}
+	defer func() { _ = a.Close() }()
+	b, err := NewEphemeralStorage(ctx, "bb")
+	if err != nil {
+		t.Fatalf("NewEphemeralStorage(bb) failed: %v", err)
+	}
+	defer func() { _ = b.Close() }()
+
+	issue := &types.Issue{Title: "Only in a", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := a.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	inB, err := b.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(inB) != 0 {
+		t.Errorf("expected ephemeral stores to be isolated, found %d issues in b", len(inB))
+	}
+
+	prefix, err := b.GetConfig(ctx, "issue_prefix")
+	if err != nil {
+		t.Fatalf("GetConfig failed: %v", err)
+	}
+	if prefix != "bb" {
+		t.Errorf("expected issue_prefix bb, got %q", prefix)
+	}
+}
+
+func TestNewEphemeralStorage_RequiresPrefix(t *testing.T) {
+	if _, err := NewEphemeralStorage(context.Background(), " "); err == nil {
+		t.Fatal("expected error for empty prefix")
+	}
+}
+
+func TestNewEphemeralStorage_ReportsMemoryPath(t *testing.T) {
+	eph, err := NewEphemeralStorage(context.Background(), "bd")
+	if err != nil {
+		t.Fatalf("NewEphemeralStorage failed: %v", err)
+	}
+	defer func() { _ = eph.Close() }()
+
+	if got := eph.Path(); got != ephemeralDBPath {
+		t.Errorf("Path() = %q, want in-memory URI %q kept as-is", got, ephemeralDBPath)
+	}
+	eph.EnableFreshnessChecking()
+	if eph.freshness != nil {
+		t.Error("expected freshness checking to stay off for an in-memory store")
+	}
+}
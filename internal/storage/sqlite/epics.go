@@ -81,6 +81,16 @@ func (s *SQLiteStorage) GetEpicsEligibleForClosure(ctx context.Context) ([]*type
 			EligibleForClose: eligibleForClose,
 		})
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 
-	return results, rows.Err()
+	epics := make([]*types.Issue, len(results))
+	for i, r := range results {
+		epics[i] = r.Epic
+	}
+	if err := hydrateDescriptions(ctx, s.db, epics...); err != nil {
+		return nil, err
+	}
+	return results, nil
 }
@@ -4,6 +4,9 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
 )
 
 // Sentinel errors for common database conditions
@@ -19,8 +22,67 @@ var (
 
 	// ErrCycle indicates a dependency cycle would be created
 	ErrCycle = errors.New("dependency cycle detected")
+
+	// ErrStaleWrite indicates an optimistic update lost the race: the issue's
+	// content hash changed between the caller's read and its write
+	ErrStaleWrite = errors.New("stale write: issue was modified since it was read")
+
+	// ErrIllegalTransition indicates a status change not permitted by the
+	// configured status transition rules
+	ErrIllegalTransition = errors.New("illegal status transition")
+
+	// ErrActorRequired indicates a write with an empty actor while
+	// RequireActorConfigKey is enabled
+	ErrActorRequired = errors.New("actor is required")
+
+	// ErrBusy indicates the database stayed locked by another connection for
+	// longer than the busy timeout
+	ErrBusy = errors.New("database is busy")
+
+	// ErrReadOnly indicates a write to a database that cannot be written: one
+	// opened with NewReadOnly, or a file the process lacks permission to write
+	ErrReadOnly = errors.New("database is read-only")
+
+	// ErrUpdatedAtRegression indicates an update that would move an issue's
+	// updated_at backwards while UpdatedAtPolicyConfigKey is "reject"
+	ErrUpdatedAtRegression = errors.New("updated_at would move backwards")
 )
 
+// IllegalTransitionError reports a status change rejected by the transition
+// rules. It matches ErrIllegalTransition with errors.Is.
+type IllegalTransitionError struct {
+	IssueID string
+	From    types.Status
+	To      types.Status
+}
+
+func (e *IllegalTransitionError) Error() string {
+	return fmt.Sprintf("%s: %s -> %s not allowed for issue %s", ErrIllegalTransition, e.From, e.To, e.IssueID)
+}
+
+// Unwrap returns ErrIllegalTransition so errors.Is matches.
+func (e *IllegalTransitionError) Unwrap() error {
+	return ErrIllegalTransition
+}
+
+// UpdatedAtRegressionError reports an update rejected because its updated_at
+// predates the stored one. It matches ErrUpdatedAtRegression with errors.Is.
+type UpdatedAtRegressionError struct {
+	IssueID  string
+	Stored   time.Time
+	Incoming time.Time
+}
+
+func (e *UpdatedAtRegressionError) Error() string {
+	return fmt.Sprintf("%s: issue %s was updated at %s, update is at %s", ErrUpdatedAtRegression, e.IssueID,
+		e.Stored.Format(time.RFC3339Nano), e.Incoming.Format(time.RFC3339Nano))
+}
+
+// Unwrap returns ErrUpdatedAtRegression so errors.Is matches.
+func (e *UpdatedAtRegressionError) Unwrap() error {
+	return ErrUpdatedAtRegression
+}
+
 // wrapDBError wraps a database error with operation context
 // It converts sql.ErrNoRows to ErrNotFound for consistent error handling
 func wrapDBError(op string, err error) error {
@@ -30,7 +92,7 @@ func wrapDBError(op string, err error) error {
 	if errors.Is(err, sql.ErrNoRows) {
 		return fmt.Errorf("%s: %w", op, ErrNotFound)
 	}
-	return fmt.Errorf("%s: %w", op, err)
+	return fmt.Errorf("%s: %w", op, markReadOnly(markBusy(err)))
 }
 
 // wrapDBErrorf wraps a database error with formatted operation context
@@ -43,7 +105,7 @@ func wrapDBErrorf(err error, format string, args ...interface{}) error {
 	if errors.Is(err, sql.ErrNoRows) {
 		return fmt.Errorf("%s: %w", op, ErrNotFound)
 	}
-	return fmt.Errorf("%s: %w", op, err)
+	return fmt.Errorf("%s: %w", op, markReadOnly(markBusy(err)))
 }
 
 // IsNotFound checks if an error is or wraps ErrNotFound
@@ -51,6 +113,15 @@ func IsNotFound(err error) bool {
 	return errors.Is(err, ErrNotFound)
 }
 
+// markBusy tags SQLITE_BUSY errors with ErrBusy so callers can detect lock
+// contention with errors.Is while keeping the driver error in the chain.
+func markBusy(err error) error {
+	if errors.Is(err, ErrBusy) || !IsBusyError(err) {
+		return err
+	}
+	return fmt.Errorf("%w: %w", ErrBusy, err)
+}
+
 // IsConflict checks if an error is or wraps ErrConflict
 func IsConflict(err error) bool {
 	return errors.Is(err, ErrConflict)
@@ -60,3 +131,13 @@ func IsConflict(err error) bool {
 func IsCycle(err error) bool {
 	return errors.Is(err, ErrCycle)
 }
+
+// IsStaleWrite checks if an error is or wraps ErrStaleWrite
+func IsStaleWrite(err error) bool {
+	return errors.Is(err, ErrStaleWrite)
+}
+
+// IsIllegalTransition checks if an error is or wraps ErrIllegalTransition
+func IsIllegalTransition(err error) bool {
+	return errors.Is(err, ErrIllegalTransition)
+}
@@ -0,0 +1,153 @@
+package sqlite
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// deltaRedactBytes is the longest text value an update event's field delta
+// records verbatim; longer values (descriptions, notes, payloads) are stored as
+// a types.RedactedValue.
+const deltaRedactBytes = 1024
+
+// MaxEventPayloadBytesConfigKey is the config key holding the largest update
+// event payload (the encoded field delta) stored in full. Over the limit, the
+// largest changes are replaced by summaries, flagged as truncated, until the
+// payload fits or every change is summarized. Unset or 0 means unbounded.
+const MaxEventPayloadBytesConfigKey = "events.max_payload_bytes"
+
+// updateFieldJSONNames maps update keys whose types.Issue JSON name differs.
+var updateFieldJSONNames = map[string]string{
+	"wisp":           "ephemeral",
+	"event_category": "event_kind",
+	"event_actor":    "actor",
+	"event_target":   "target",
+	"event_payload":  "payload",
+}
+
+// fieldDelta returns the fields of updates whose values differ from
+// oldIssue, with their before and after values normalized to JSON. Nil
+// pointers (unset timestamps, external_ref) compare equal to absent values.
+func fieldDelta(oldIssue *types.Issue, updates map[string]interface{}) types.FieldDelta {
+	before := make(map[string]json.RawMessage)
+	if data, err := json.Marshal(oldIssue); err == nil {
+		_ = json.Unmarshal(data, &before)
+	}
+
+	delta := make(types.FieldDelta)
+	for key, value := range updates {
+		name := key
+		if mapped, ok := updateFieldJSONNames[key]; ok {
+			name = mapped
+		}
+		oldJSON := before[name]
+		newJSON, err := json.Marshal(value)
+		if err != nil {
+			continue
+		}
+		if bytes.Equal(normalizeJSON(oldJSON), normalizeJSON(newJSON)) {
+			continue
+		}
+		delta[key] = types.FieldChange{Old: deltaValue(oldJSON), New: deltaValue(newJSON)}
+	}
+	return delta
+}
+
+// normalizeJSON maps absent values and zero values to null, since types.Issue
+// omits zero fields from its JSON.
+func normalizeJSON(raw json.RawMessage) json.RawMessage {
+	switch string(raw) {
+	case "", `""`, "null", "[]", "{}", "0", "false":
+		return json.RawMessage("null")
+	}
+	return raw
+}
+
+func deltaValue(raw json.RawMessage) interface{} {
+	if len(raw) == 0 {
+		return nil
+	}
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil
+	}
+	if s, ok := value.(string); ok && len(s) > deltaRedactBytes {
+		return redactedValue([]byte(s))
+	}
+	return value
+}
+
+func redactedValue(data []byte) types.RedactedValue {
+	sum := sha256.Sum256(data)
+	return types.RedactedValue{SHA256: hex.EncodeToString(sum[:]), Bytes: len(data)}
+}
+
+// summarizeChange replaces both sides of c with their length and hash. Text
+// is summarized as deltaValue would; other values by their JSON encoding.
+func summarizeChange(c types.FieldChange) types.FieldChange {
+	summarize := func(value interface{}) interface{} {
+		switch v := value.(type) {
+		case nil, types.RedactedValue:
+			return v
+		case string:
+			return redactedValue([]byte(v))
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil
+		}
+		return redactedValue(data)
+	}
+	return types.FieldChange{Old: summarize(c.Old), New: summarize(c.New), Truncated: true}
+}
+
+// updateEventValue encodes the field delta recorded as an update event's
+// new_value, within MaxEventPayloadBytesConfigKey.
+func updateEventValue(ctx context.Context, db dbExecutor, oldIssue *types.Issue, updates map[string]interface{}) string {
+	delta := fieldDelta(oldIssue, updates)
+	data, err := json.Marshal(delta)
+	if err != nil {
+		return "{}"
+	}
+
+	var raw string
+	if err := db.QueryRowContext(ctx, `SELECT value FROM config WHERE key = ?`, MaxEventPayloadBytesConfigKey).Scan(&raw); err != nil {
+		return string(data)
+	}
+	limit, err := strconv.Atoi(raw)
+	if err != nil || limit <= 0 || len(data) <= limit {
+		return string(data)
+	}
+
+	// Summarize the largest changes first, so small ones stay readable
+	sizes := make(map[string]int, len(delta))
+	keys := make([]string, 0, len(delta))
+	for key, change := range delta {
+		encoded, _ := json.Marshal(change)
+		sizes[key] = len(encoded)
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if sizes[keys[i]] != sizes[keys[j]] {
+			return sizes[keys[i]] > sizes[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	for _, key := range keys {
+		delta[key] = summarizeChange(delta[key])
+		if data, err = json.Marshal(delta); err != nil {
+			return "{}"
+		}
+		if len(data) <= limit {
+			break
+		}
+	}
+	return string(data)
+}
@@ -0,0 +1,137 @@
+package sqlite
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestUpdateEventRecordsFieldDelta(t *testing.T) {
+	env := newTestEnv(t)
+	due := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	issue := &types.Issue{Title: "Before", Description: "short", Status: types.StatusOpen, Priority: 2,
+		IssueType: types.TypeTask, Assignee: "alice", DueAt: &due}
+	if err := env.Store.CreateIssue(env.Ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	longBody := strings.Repeat("x", deltaRedactBytes+1)
+	updates := map[string]interface{}{
+		"title":       "After",
+		"priority":    0,
+		"assignee":    "alice", // unchanged, left out of the delta
+		"description": longBody,
+		"due_at":      (*time.Time)(nil),
+		"defer_until": (*time.Time)(nil), // nil to nil, unchanged
+		"waiters":     []string{"ops@example.com"},
+	}
+	if err := env.Store.UpdateIssue(env.Ctx, issue.ID, updates, "bob"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+
+	latest := func() *types.Event {
+		t.Helper()
+		events, err := env.Store.getEventHistory(env.Ctx, issue.ID)
+		if err != nil || len(events) == 0 {
+			t.Fatalf("getEventHistory failed: %v (%d events)", err, len(events))
+		}
+		return events[len(events)-1]
+	}
+	event := latest()
+	if event.EventType != types.EventUpdated || event.Actor != "bob" {
+		t.Errorf("unexpected event %s by %s", event.EventType, event.Actor)
+	}
+	delta, err := types.ParseFieldDelta(event)
+	if err != nil {
+		t.Fatalf("ParseFieldDelta failed: %v", err)
+	}
+
+	if len(delta) != 5 {
+		t.Errorf("expected 5 changed fields, got %v", delta)
+	}
+	if c := delta["title"]; c.Old != "Before" || c.New != "After" {
+		t.Errorf("title delta = %+v", c)
+	}
+	if c := delta["priority"]; c.Old != float64(2) || c.New != float64(0) {
+		t.Errorf("priority delta = %+v", c)
+	}
+	if c := delta["due_at"]; c.Old != due.Format(time.RFC3339) || c.New != nil {
+		t.Errorf("due_at delta = %+v", c)
+	}
+	if c, ok := delta["waiters"].New.([]interface{}); !ok || len(c) != 1 || c[0] != "ops@example.com" {
+		t.Errorf("waiters delta = %+v", delta["waiters"])
+	}
+	redacted, ok := delta["description"].New.(map[string]interface{})
+	if !ok || redacted["bytes"] != float64(len(longBody)) || len(redacted["sha256"].(string)) != 64 {
+		t.Errorf("expected long description redacted to a hash, got %+v", delta["description"].New)
+	}
+	if delta["description"].Old != "short" {
+		t.Errorf("description old = %v", delta["description"].Old)
+	}
+	for _, field := range []string{"assignee", "defer_until"} {
+		if _, ok := delta[field]; ok {
+			t.Errorf("unchanged field %s recorded in delta", field)
+		}
+	}
+
+	// Transactional updates record the same payload
+	if err := env.Store.RunInTransaction(env.Ctx, func(tx storage.Transaction) error {
+		return tx.UpdateIssue(env.Ctx, issue.ID, map[string]interface{}{"status": string(types.StatusInProgress)}, "carol")
+	}); err != nil {
+		t.Fatalf("tx UpdateIssue failed: %v", err)
+	}
+	delta, err = types.ParseFieldDelta(latest())
+	if err != nil {
+		t.Fatalf("ParseFieldDelta failed: %v", err)
+	}
+	if c := delta["status"]; c.Old != "open" || c.New != "in_progress" {
+		t.Errorf("status delta = %+v", delta)
+	}
+}
+
+func TestUpdateEventPayloadLimit(t *testing.T) {
+	env := newTestEnv(t)
+	issue := env.CreateIssue("Before")
+	if err := env.Store.SetConfig(env.Ctx, MaxEventPayloadBytesConfigKey, "600"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+
+	// Each text stays under deltaRedactBytes, but together they overflow the limit
+	description := strings.Repeat("d", 900)
+	updates := map[string]interface{}{
+		"title":       "After",
+		"description": description,
+		"notes":       strings.Repeat("n", 800),
+	}
+	if err := env.Store.UpdateIssue(env.Ctx, issue.ID, updates, "bob"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	events, err := env.Store.getEventHistory(env.Ctx, issue.ID)
+	if err != nil || len(events) == 0 {
+		t.Fatalf("getEventHistory failed: %v (%d events)", err, len(events))
+	}
+	event := events[len(events)-1]
+	if len(*event.NewValue) > 600 {
+		t.Errorf("payload is %d bytes, want at most 600", len(*event.NewValue))
+	}
+	delta, err := types.ParseFieldDelta(event)
+	if err != nil {
+		t.Fatalf("ParseFieldDelta failed: %v", err)
+	}
+
+	for _, field := range []string{"description", "notes"} {
+		if !delta[field].Truncated {
+			t.Errorf("%s change not flagged as truncated: %+v", field, delta[field])
+		}
+	}
+	summary, ok := delta["description"].New.(map[string]interface{})
+	if !ok || summary["bytes"] != float64(len(description)) || summary["sha256"] != redactedValue([]byte(description)).SHA256 {
+		t.Errorf("description summary = %+v, want its length and hash", delta["description"].New)
+	}
+	if c := delta["title"]; c.Truncated || c.Old != "Before" || c.New != "After" {
+		t.Errorf("title change = %+v, want it kept in full", c)
+	}
+}
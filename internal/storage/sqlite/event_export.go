@@ -0,0 +1,72 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ExportEventsSince writes every event with an ID greater than afterEventID
+// to w as NDJSON, one types.EventRecord line per event in ID order, which is
+// the order they were committed, so a consumer can replay them (see
+// ImportEvents) to follow changes without re-reading issue snapshots. It
+// returns the ID of the last event written, to pass as afterEventID next
+// time, or afterEventID itself when there was nothing new. On error it is
+// the ID of the last event already written.
+//
+// The events are read from a single snapshot. Event IDs are never reused and
+// writers are serialized, so every commit lands wholly before or after the
+// snapshot: successive calls chained by the returned token see each event
+// exactly once, however writes interleave with them.
+func (s *SQLiteStorage) ExportEventsSince(ctx context.Context, afterEventID int64, w io.Writer) (int64, error) {
+	last := afterEventID
+	err := s.withReadTx(ctx, func(conn *sql.Conn) error {
+		rows, err := conn.QueryContext(ctx, `
+			SELECT id, issue_id, event_type, actor, old_value, new_value, comment, created_at
+			FROM events
+			WHERE id > ?
+			ORDER BY id ASC
+		`, afterEventID)
+		if err != nil {
+			return fmt.Errorf("failed to get events: %w", err)
+		}
+		defer func() { _ = rows.Close() }()
+
+		enc := json.NewEncoder(w)
+		for rows.Next() {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			var event types.Event
+			var oldValue, newValue, comment sql.NullString
+			if err := rows.Scan(
+				&event.ID, &event.IssueID, &event.EventType, &event.Actor,
+				&oldValue, &newValue, &comment, &event.CreatedAt,
+			); err != nil {
+				return fmt.Errorf("failed to scan event: %w", err)
+			}
+			if oldValue.Valid {
+				event.OldValue = &oldValue.String
+			}
+			if newValue.Valid {
+				event.NewValue = &newValue.String
+			}
+			if comment.Valid {
+				event.Comment = &comment.String
+			}
+			if err := enc.Encode(types.EventRecord{Event: &event}); err != nil {
+				return fmt.Errorf("failed to write event %d: %w", event.ID, err)
+			}
+			if err := flushExport(w); err != nil {
+				return err
+			}
+			last = event.ID
+		}
+		return rows.Err()
+	})
+	return last, err
+}
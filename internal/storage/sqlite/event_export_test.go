@@ -0,0 +1,144 @@
+package sqlite
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// decodeEventRecords parses the output of ExportEventsSince.
+func decodeEventRecords(t *testing.T, data []byte) []*types.Event {
+	t.Helper()
+	var events []*types.Event
+	dec := json.NewDecoder(bytes.NewReader(data))
+	for dec.More() {
+		var record types.EventRecord
+		if err := dec.Decode(&record); err != nil {
+			t.Fatalf("decode event record: %v", err)
+		}
+		events = append(events, record.Event)
+	}
+	return events
+}
+
+func TestExportEventsSince(t *testing.T) {
+	env := newTestEnv(t)
+	issue := env.CreateIssue("Task")
+
+	var buf bytes.Buffer
+	token, err := env.Store.ExportEventsSince(env.Ctx, 0, &buf)
+	if err != nil {
+		t.Fatalf("ExportEventsSince failed: %v", err)
+	}
+	before := decodeEventRecords(t, buf.Bytes())
+	if len(before) != 1 || before[0].EventType != types.EventCreated || token != before[0].ID {
+		t.Fatalf("initial export = %+v (token %d), want the creation event", before, token)
+	}
+
+	if err := env.Store.UpdateIssue(env.Ctx, issue.ID, map[string]interface{}{"title": "Renamed"}, "test-user"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if err := env.Store.AddComment(env.Ctx, issue.ID, "test-user", "looks good"); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+
+	buf.Reset()
+	next, err := env.Store.ExportEventsSince(env.Ctx, token, &buf)
+	if err != nil {
+		t.Fatalf("ExportEventsSince(%d) failed: %v", token, err)
+	}
+	delta := decodeEventRecords(t, buf.Bytes())
+	if len(delta) != 2 || delta[0].ID <= token || delta[1].ID <= delta[0].ID || next != delta[1].ID {
+		t.Fatalf("delta = %+v (token %d), want the two new events in ID order", delta, next)
+	}
+	if delta[0].EventType != types.EventUpdated || delta[1].EventType != types.EventCommented {
+		t.Errorf("delta types = %s, %s; want updated, commented", delta[0].EventType, delta[1].EventType)
+	}
+
+	// Nothing new keeps the token
+	buf.Reset()
+	if again, err := env.Store.ExportEventsSince(env.Ctx, next, &buf); err != nil || again != next || buf.Len() != 0 {
+		t.Errorf("export after %d = %q (token %d, %v), want nothing", next, buf.String(), again, err)
+	}
+
+	// Replay the delta onto a copy of the issue
+	replica := newTestEnv(t)
+	copyIssue := &types.Issue{ID: issue.ID, Title: "Task", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := replica.Store.CreateIssue(replica.Ctx, copyIssue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue on replica failed: %v", err)
+	}
+	if err := replica.Store.ImportEvents(replica.Ctx, issue.ID, delta, false); err != nil {
+		t.Fatalf("ImportEvents failed: %v", err)
+	}
+	got, err := replica.Store.GetEvents(replica.Ctx, issue.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	replayed := make(map[types.EventType]bool)
+	for _, event := range got {
+		replayed[event.EventType] = true
+	}
+	if !replayed[types.EventUpdated] || !replayed[types.EventCommented] {
+		t.Errorf("replica events = %+v, want the replayed update and comment", got)
+	}
+}
+
+func TestExportEventsSince_ConcurrentWrites(t *testing.T) {
+	env := newTestEnv(t)
+	issue := env.CreateIssue("Task")
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 30; i++ {
+			if err := env.Store.UpdateIssue(env.Ctx, issue.ID, map[string]interface{}{"title": fmt.Sprintf("Title %d", i)}, "test-user"); err != nil {
+				t.Errorf("UpdateIssue failed: %v", err)
+				return
+			}
+		}
+	}()
+
+	var token int64
+	var streamed []int64
+	pull := func() {
+		var buf bytes.Buffer
+		next, err := env.Store.ExportEventsSince(env.Ctx, token, &buf)
+		if err != nil {
+			t.Fatalf("ExportEventsSince(%d) failed: %v", token, err)
+		}
+		for _, event := range decodeEventRecords(t, buf.Bytes()) {
+			streamed = append(streamed, event.ID)
+		}
+		token = next
+	}
+	done := make(chan struct{})
+	go func() { wg.Wait(); close(done) }()
+	for waiting := true; waiting; {
+		select {
+		case <-done:
+			waiting = false
+		default:
+			pull()
+		}
+	}
+	pull()
+
+	var buf bytes.Buffer
+	if _, err := env.Store.ExportEventsSince(env.Ctx, 0, &buf); err != nil {
+		t.Fatalf("full export failed: %v", err)
+	}
+	all := decodeEventRecords(t, buf.Bytes())
+	if len(streamed) != len(all) {
+		t.Fatalf("streamed %d events, want %d", len(streamed), len(all))
+	}
+	for i, event := range all {
+		if streamed[i] != event.ID {
+			t.Fatalf("streamed event %d is %d, want %d (gap or duplicate)", i, streamed[i], event.ID)
+		}
+	}
+}
@@ -14,6 +14,7 @@ const limitClause = " LIMIT ?"
 // AddComment adds a comment to an issue
 func (s *SQLiteStorage) AddComment(ctx context.Context, issueID, actor, comment string) error {
 	return s.withTx(ctx, func(conn *sql.Conn) error {
+		issueID := canonicalIssueID(ctx, conn, issueID)
 		// Update issue updated_at timestamp first to verify issue exists
 		now := time.Now()
 		res, err := conn.ExecContext(ctx, `
@@ -0,0 +1,64 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EventRetentionDaysConfigKey is the config key holding how many days of event
+// history PruneEventsByRetention keeps. Unset or 0 disables pruning.
+const EventRetentionDaysConfigKey = "events.retention_days"
+
+// PruneEvents deletes events created before olderThan, except that the most
+// recent event of every issue is always kept so each issue retains its latest
+// recorded state change. Returns the number of events deleted.
+func (s *SQLiteStorage) PruneEvents(ctx context.Context, olderThan time.Time) (int, error) {
+	var pruned int64
+	err := s.withTx(ctx, func(conn *sql.Conn) error {
+		// Event IDs are AUTOINCREMENT, so MAX(id) is the latest event per issue
+		// even when several share a created_at second.
+		result, err := conn.ExecContext(ctx, `
+			DELETE FROM events
+			WHERE julianday(created_at) < julianday(?)
+			  AND id NOT IN (SELECT MAX(id) FROM events GROUP BY issue_id)
+		`, olderThan.UTC().Format("2006-01-02 15:04:05"))
+		if err != nil {
+			return fmt.Errorf("failed to prune events: %w", err)
+		}
+		pruned, err = result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(pruned), nil
+}
+
+// PruneEventsByRetention prunes events older than the retention window set in
+// EventRetentionDaysConfigKey (see PruneEvents). Returns 0 without pruning
+// when no retention is configured.
+func (s *SQLiteStorage) PruneEventsByRetention(ctx context.Context) (int, error) {
+	value, err := s.GetConfig(ctx, EventRetentionDaysConfigKey)
+	if err != nil {
+		return 0, err
+	}
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	days, err := strconv.Atoi(value)
+	if err != nil || days < 0 {
+		return 0, fmt.Errorf("invalid %s value %q: must be a non-negative number of days", EventRetentionDaysConfigKey, value)
+	}
+	if days == 0 {
+		return 0, nil
+	}
+	return s.PruneEvents(ctx, time.Now().AddDate(0, 0, -days))
+}
@@ -0,0 +1,124 @@
+package sqlite
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ageEvents backdates every event of issueID by the given duration.
+func ageEvents(t *testing.T, env *testEnv, issueID string, age time.Duration) {
+	t.Helper()
+	_, err := env.Store.db.ExecContext(env.Ctx, `
+		UPDATE events SET created_at = datetime(created_at, ?) WHERE issue_id = ?
+	`, "-"+strconv.Itoa(int(age.Seconds()))+" seconds", issueID)
+	if err != nil {
+		t.Fatalf("failed to backdate events: %v", err)
+	}
+}
+
+func TestPruneEvents_KeepsLatestPerIssue(t *testing.T) {
+	env := newTestEnv(t)
+	old := env.CreateIssue("Old history")
+	quiet := env.CreateIssue("Single event")
+	for _, status := range []types.Status{types.StatusInProgress, types.StatusBlocked, types.StatusOpen} {
+		if err := env.Store.UpdateIssue(env.Ctx, old.ID, map[string]interface{}{"status": status}, "alice"); err != nil {
+			t.Fatalf("UpdateIssue failed: %v", err)
+		}
+	}
+	ageEvents(t, env, old.ID, 60*24*time.Hour)
+	ageEvents(t, env, quiet.ID, 60*24*time.Hour)
+
+	before, err := env.Store.GetEvents(env.Ctx, old.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	if len(before) != 4 {
+		t.Fatalf("expected 4 events before pruning, got %d", len(before))
+	}
+	latestID := before[0].ID
+	for _, e := range before {
+		if e.ID > latestID {
+			latestID = e.ID
+		}
+	}
+
+	pruned, err := env.Store.PruneEvents(env.Ctx, time.Now().AddDate(0, 0, -30))
+	if err != nil {
+		t.Fatalf("PruneEvents failed: %v", err)
+	}
+	if pruned != 3 {
+		t.Errorf("expected 3 events pruned, got %d", pruned)
+	}
+
+	after, err := env.Store.GetEvents(env.Ctx, old.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	if len(after) != 1 || after[0].ID != latestID {
+		t.Fatalf("expected only the latest event %d to survive, got %+v", latestID, after)
+	}
+	if after[0].NewValue == nil || *after[0].NewValue == "" {
+		t.Error("expected the surviving event to keep its status change payload")
+	}
+
+	// An issue whose only event is old keeps it
+	quietEvents, err := env.Store.GetEvents(env.Ctx, quiet.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	if len(quietEvents) != 1 {
+		t.Errorf("expected single old event to survive, got %d", len(quietEvents))
+	}
+}
+
+func TestPruneEvents_KeepsRecentEvents(t *testing.T) {
+	env := newTestEnv(t)
+	issue := env.CreateIssue("Recent")
+	if err := env.Store.UpdateIssue(env.Ctx, issue.ID, map[string]interface{}{"priority": 0}, "alice"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+
+	pruned, err := env.Store.PruneEvents(env.Ctx, time.Now().AddDate(0, 0, -30))
+	if err != nil {
+		t.Fatalf("PruneEvents failed: %v", err)
+	}
+	if pruned != 0 {
+		t.Errorf("expected no recent events pruned, got %d", pruned)
+	}
+}
+
+func TestPruneEventsByRetention(t *testing.T) {
+	env := newTestEnv(t)
+	issue := env.CreateIssue("Configured")
+	if err := env.Store.UpdateIssue(env.Ctx, issue.ID, map[string]interface{}{"priority": 0}, "alice"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	ageEvents(t, env, issue.ID, 10*24*time.Hour)
+
+	// No retention configured: nothing is pruned
+	pruned, err := env.Store.PruneEventsByRetention(env.Ctx)
+	if err != nil || pruned != 0 {
+		t.Fatalf("expected no pruning without config, got %d (err=%v)", pruned, err)
+	}
+
+	if err := env.Store.SetConfig(env.Ctx, EventRetentionDaysConfigKey, "7"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	pruned, err = env.Store.PruneEventsByRetention(env.Ctx)
+	if err != nil {
+		t.Fatalf("PruneEventsByRetention failed: %v", err)
+	}
+	if pruned != 1 {
+		t.Errorf("expected 1 event pruned, got %d", pruned)
+	}
+
+	if err := env.Store.SetConfig(env.Ctx, EventRetentionDaysConfigKey, "forever"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	if _, err := env.Store.PruneEventsByRetention(env.Ctx); err == nil {
+		t.Error("expected error for invalid retention value")
+	}
+}
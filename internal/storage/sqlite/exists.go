@@ -0,0 +1,75 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// idChunkSize bounds the IDs bound into one IN list, well under SQLite's
+// host parameter limit (SQLITE_MAX_VARIABLE_NUMBER: 999 before SQLite 3.32,
+// 32766 since), so lookups over a large import never fail with "too many SQL
+// variables".
+const idChunkSize = 500
+
+// FilterExistingIDs returns the subset of ids present in the database, in
+// input order without duplicates. Tombstones count as present, since their IDs
+// cannot be reused. Large sets are checked in chunks within one read
+// transaction, so the result reflects a single snapshot.
+func (s *SQLiteStorage) FilterExistingIDs(ctx context.Context, ids []string) ([]string, error) {
+	var existing []string
+	if len(ids) == 0 {
+		return existing, nil
+	}
+
+	unique := make([]string, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	found := make(map[string]bool)
+	err := s.withReadTx(ctx, func(conn *sql.Conn) error {
+		for start := 0; start < len(unique); start += idChunkSize {
+			chunk := unique[start:min(start+idChunkSize, len(unique))]
+			args := make([]interface{}, len(chunk))
+			for i, id := range chunk {
+				args[i] = id
+			}
+			placeholders := buildPlaceholders(len(chunk))
+			// #nosec G201 - only placeholders are interpolated
+			rows, err := conn.QueryContext(ctx, fmt.Sprintf(`SELECT id FROM issues WHERE id IN (%s)`, placeholders), args...)
+			if err != nil {
+				return wrapDBError("filter existing IDs", err)
+			}
+			for rows.Next() {
+				var id string
+				if err := rows.Scan(&id); err != nil {
+					_ = rows.Close()
+					return wrapDBError("scan existing ID", err)
+				}
+				found[id] = true
+			}
+			if err := rows.Close(); err != nil {
+				return wrapDBError("filter existing IDs", err)
+			}
+			if err := rows.Err(); err != nil {
+				return wrapDBError("filter existing IDs", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range unique {
+		if found[id] {
+			existing = append(existing, id)
+		}
+	}
+	return existing, nil
+}
@@ -0,0 +1,51 @@
+package sqlite
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestFilterExistingIDs(t *testing.T) {
+	env := newTestEnv(t)
+
+	var issues []*types.Issue
+	for i := 0; i < 1500; i++ {
+		issues = append(issues, &types.Issue{ID: fmt.Sprintf("bd-%d", i*2), Title: "Present", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask})
+	}
+	if err := env.Store.CreateIssues(env.Ctx, issues, "test"); err != nil {
+		t.Fatalf("CreateIssues failed: %v", err)
+	}
+	if err := env.Store.CreateTombstone(env.Ctx, "bd-0", "test", "gone"); err != nil {
+		t.Fatalf("CreateTombstone failed: %v", err)
+	}
+
+	// 3000 candidates alternating present (even) and absent (odd), plus a repeat
+	var ids []string
+	for i := 2999; i >= 0; i-- {
+		ids = append(ids, fmt.Sprintf("bd-%d", i))
+	}
+	ids = append(ids, "bd-2")
+
+	existing, err := env.Store.FilterExistingIDs(env.Ctx, ids)
+	if err != nil {
+		t.Fatalf("FilterExistingIDs failed: %v", err)
+	}
+	if len(existing) != 1500 {
+		t.Fatalf("expected 1500 existing IDs, got %d", len(existing))
+	}
+	if existing[0] != "bd-2998" || existing[len(existing)-1] != "bd-0" {
+		t.Errorf("expected input order with the tombstone included, got first %s last %s", existing[0], existing[len(existing)-1])
+	}
+	for _, id := range existing {
+		var n int
+		if _, err := fmt.Sscanf(id, "bd-%d", &n); err != nil || n%2 != 0 {
+			t.Errorf("unexpected ID %s reported as existing", id)
+		}
+	}
+
+	if none, err := env.Store.FilterExistingIDs(env.Ctx, nil); err != nil || len(none) != 0 {
+		t.Errorf("expected no IDs for empty input, got %v (err %v)", none, err)
+	}
+}
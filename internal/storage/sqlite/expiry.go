@@ -0,0 +1,64 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// expiryActor and expiryReason are recorded on the tombstones (and deletion
+// events) of expired issues.
+const (
+	expiryActor  = "expiry"
+	expiryReason = "expired"
+)
+
+// ExpireIssues tombstones every issue whose ExpiresAt is at or before now,
+// recording a deletion event for each, and returns their IDs in expiry order.
+// Issues that are already tombstones are left alone. Meant to be run
+// periodically as maintenance.
+func (s *SQLiteStorage) ExpireIssues(ctx context.Context, now time.Time) ([]string, error) {
+	var expired []string
+	err := s.withTx(ctx, func(conn *sql.Conn) error {
+		type candidate struct{ id, issueType string }
+		var candidates []candidate
+		rows, err := conn.QueryContext(ctx, `
+			SELECT id, issue_type FROM issues
+			WHERE expires_at <= ? AND status != 'tombstone'
+			ORDER BY expires_at, id
+		`, now.UTC())
+		if err != nil {
+			return fmt.Errorf("failed to find expired issues: %w", err)
+		}
+		for rows.Next() {
+			var c candidate
+			if err := rows.Scan(&c.id, &c.issueType); err != nil {
+				_ = rows.Close()
+				return fmt.Errorf("failed to scan expired issue: %w", err)
+			}
+			candidates = append(candidates, c)
+		}
+		_ = rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("failed to find expired issues: %w", err)
+		}
+
+		for _, c := range candidates {
+			if err := tombstoneIssue(ctx, conn, c.id, c.issueType, expiryActor, expiryReason); err != nil {
+				return err
+			}
+			expired = append(expired, c.id)
+		}
+		if len(expired) > 0 {
+			if err := s.invalidateBlockedCache(ctx, conn); err != nil {
+				return fmt.Errorf("failed to invalidate blocked cache: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return expired, nil
+}
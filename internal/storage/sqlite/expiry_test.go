@@ -0,0 +1,83 @@
+package sqlite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestExpireIssues(t *testing.T) {
+	env := newTestEnv(t)
+	now := time.Now()
+	seed := func(title string, expiresAt *time.Time) *types.Issue {
+		t.Helper()
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, ExpiresAt: expiresAt}
+		if err := env.Store.CreateIssue(env.Ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue
+	}
+	at := func(d time.Duration) *time.Time { v := now.Add(d); return &v }
+
+	older := seed("Expired yesterday", at(-24*time.Hour))
+	recent := seed("Expired an hour ago", at(-time.Hour))
+	future := seed("Expires tomorrow", at(24*time.Hour))
+	forever := seed("Never expires", nil)
+
+	stored, err := env.Store.GetIssue(env.Ctx, future.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if stored.ExpiresAt == nil || !stored.ExpiresAt.Equal(*future.ExpiresAt) {
+		t.Errorf("expected ExpiresAt %v persisted, got %v", future.ExpiresAt, stored.ExpiresAt)
+	}
+
+	expired, err := env.Store.ExpireIssues(env.Ctx, now)
+	if err != nil {
+		t.Fatalf("ExpireIssues failed: %v", err)
+	}
+	if len(expired) != 2 || expired[0] != older.ID || expired[1] != recent.ID {
+		t.Fatalf("expected [%s %s] expired, got %v", older.ID, recent.ID, expired)
+	}
+
+	for _, id := range expired {
+		issue, err := env.Store.GetIssue(env.Ctx, id)
+		if err != nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		if issue.Status != types.StatusTombstone || issue.DeleteReason != expiryReason || issue.OriginalType != string(types.TypeTask) {
+			t.Errorf("%s: expected expired tombstone, got status=%s reason=%q original_type=%q", id, issue.Status, issue.DeleteReason, issue.OriginalType)
+		}
+		events, err := env.Store.getEventHistory(env.Ctx, id)
+		if err != nil {
+			t.Fatalf("getEventHistory failed: %v", err)
+		}
+		last := events[len(events)-1]
+		if last.EventType != "deleted" || last.Actor != expiryActor {
+			t.Errorf("%s: expected deleted event by %s, got %s by %s", id, expiryActor, last.EventType, last.Actor)
+		}
+	}
+	for _, issue := range []*types.Issue{future, forever} {
+		if got, _ := env.Store.GetIssue(env.Ctx, issue.ID); got.Status != types.StatusOpen {
+			t.Errorf("%s should not have expired, status %s", issue.ID, got.Status)
+		}
+	}
+
+	// Already-expired tombstones are not swept again
+	again, err := env.Store.ExpireIssues(env.Ctx, now)
+	if err != nil {
+		t.Fatalf("ExpireIssues failed: %v", err)
+	}
+	if len(again) != 0 {
+		t.Errorf("expected nothing on second sweep, got %v", again)
+	}
+
+	later, err := env.Store.ExpireIssues(env.Ctx, now.Add(48*time.Hour))
+	if err != nil {
+		t.Fatalf("ExpireIssues failed: %v", err)
+	}
+	if len(later) != 1 || later[0] != future.ID {
+		t.Errorf("expected %s to expire later, got %v", future.ID, later)
+	}
+}
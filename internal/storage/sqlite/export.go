@@ -0,0 +1,456 @@
+package sqlite
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// streamExportPageSize is how many issues StreamExport reads per query.
+// Each page is a separate short read so a slow consumer never holds a
+// long-lived cursor open against the database.
+var streamExportPageSize = 500
+
+// ExportSummary is the trailing line written by StreamExport so consumers can
+// verify they received the complete export.
+type ExportSummary struct {
+	Summary bool `json:"_summary"`
+	Count   int  `json:"count"`
+}
+
+// flusher matches writers that can push buffered output to the client,
+// such as http.Flusher. bufio.Writer style Flush() error is handled too.
+type flusher interface{ Flush() }
+type errFlusher interface{ Flush() error }
+
+// StreamExport writes issues matching filter to w as NDJSON, one issue per line
+// ordered by ID (under the configured IDCollationConfigKey), followed by an ExportSummary line with the issue count.
+//
+// The output is deterministic: the same data always exports to the same bytes.
+// Records are ordered by ID (unique, so there are no ties), fields appear in
+// types.Issue declaration order, labels are sorted by name, and dependencies
+// by creation time, then target and type. Exports can therefore be compared,
+// checksummed (see ExportWithChecksum) and signed.
+//
+// Issues are read in ID-ordered pages and each line is flushed as soon as it is
+// written (when w supports flushing), so output is incremental and memory use
+// is bounded by the page size. Writes block on a slow consumer, and the export
+// stops with ctx.Err() when the context is canceled (e.g. client disconnect).
+// filter.Limit caps the total number of issues written.
+func (s *SQLiteStorage) StreamExport(ctx context.Context, w io.Writer, filter types.IssueFilter) error {
+	return s.streamExport(ctx, w, filter, nil, nil)
+}
+
+// StreamExportRedacted writes the same records as StreamExport with the named
+// fields blanked on every issue (see types.Issue.Redact), for sharing an export
+// outside the team. Redaction happens before encoding, so each exported issue
+// hashes to its redacted content and the file imports cleanly on its own.
+func (s *SQLiteStorage) StreamExportRedacted(ctx context.Context, w io.Writer, filter types.IssueFilter, redact []string) error {
+	if err := types.ValidateRedactFields(redact); err != nil {
+		return err
+	}
+	return s.streamExport(ctx, w, filter, redact, nil)
+}
+
+// ExportWithChecksum writes the same records as StreamExport followed by a
+// types.ExportChecksum line holding the SHA-256 of everything before it, and
+// returns the checksum. importer.VerifyExportChecksum checks it on the way in.
+func (s *SQLiteStorage) ExportWithChecksum(ctx context.Context, w io.Writer, filter types.IssueFilter) (string, error) {
+	hw := &hashingWriter{w: w, h: sha256.New()}
+	if err := s.streamExport(ctx, hw, filter, nil, nil); err != nil {
+		return "", err
+	}
+	sum := hex.EncodeToString(hw.h.Sum(nil))
+	if err := json.NewEncoder(w).Encode(types.ExportChecksum{Checksum: sum, Algorithm: "sha256"}); err != nil {
+		return "", fmt.Errorf("failed to write export checksum: %w", err)
+	}
+	return sum, flushWriter(w)
+}
+
+// hashingWriter hashes everything written to w, passing flushes through so
+// streaming exports stay incremental.
+type hashingWriter struct {
+	w io.Writer
+	h hash.Hash
+}
+
+func (hw *hashingWriter) Write(p []byte) (int, error) {
+	n, err := hw.w.Write(p)
+	hw.h.Write(p[:n])
+	return n, err
+}
+
+func (hw *hashingWriter) Flush() error {
+	return flushWriter(hw.w)
+}
+
+// streamExport implements StreamExport. Each issue has the redact fields
+// blanked before it is written. If afterIssue is set, it is called after each
+// issue line to write that issue's trailing records.
+func (s *SQLiteStorage) streamExport(ctx context.Context, w io.Writer, filter types.IssueFilter, redact []string, afterIssue func(enc *json.Encoder, issue *types.Issue) error) error {
+	enc := json.NewEncoder(w)
+	count := 0
+	hashOpts := getHashOptions(ctx, s.db)
+	err := s.forEachExportIssue(ctx, filter, func(issue *types.Issue) error {
+		if err := issue.Redact(redact, hashOpts); err != nil {
+			return err
+		}
+		if err := writeExportIssue(w, issue); err != nil {
+			return err
+		}
+		if afterIssue != nil {
+			if err := afterIssue(enc, issue); err != nil {
+				return err
+			}
+		}
+		count++
+		return flushExport(w)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := enc.Encode(ExportSummary{Summary: true, Count: count}); err != nil {
+		return fmt.Errorf("failed to write export summary: %w", err)
+	}
+	return flushWriter(w)
+}
+
+// forEachExportIssue calls fn with each issue matching filter in export order,
+// reading them in pages of streamExportPageSize. filter.Limit caps the number
+// of issues, and a canceled ctx stops the loop with ctx.Err().
+func (s *SQLiteStorage) forEachExportIssue(ctx context.Context, filter types.IssueFilter, fn func(issue *types.Issue) error) error {
+	count := 0
+	afterID := ""
+	collation := getIDCollation(ctx, s.db)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		pageSize := streamExportPageSize
+		if filter.Limit > 0 {
+			if remaining := filter.Limit - count; remaining < pageSize {
+				pageSize = remaining
+			}
+			if pageSize <= 0 {
+				return nil
+			}
+		}
+
+		page, err := s.exportPage(ctx, filter, collation, afterID, pageSize)
+		if err != nil {
+			return err
+		}
+
+		for _, issue := range page {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := fn(issue); err != nil {
+				return err
+			}
+			count++
+		}
+
+		if len(page) < pageSize {
+			return nil
+		}
+		afterID = page[len(page)-1].ID
+	}
+}
+
+// writeExportIssue writes issue to w as one export line.
+func writeExportIssue(w io.Writer, issue *types.Issue) error {
+	// MarshalIssue writes preserved unknown fields back at the top level
+	line, err := types.MarshalIssue(issue)
+	if err != nil {
+		return fmt.Errorf("failed to encode issue %s: %w", issue.ID, err)
+	}
+	if _, err := w.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write issue %s: %w", issue.ID, err)
+	}
+	return nil
+}
+
+// flushExport flushes w after an issue's records, so output is incremental.
+func flushExport(w io.Writer) error {
+	if err := flushWriter(w); err != nil {
+		return fmt.Errorf("failed to flush export: %w", err)
+	}
+	return nil
+}
+
+// exportPage returns up to limit issues matching filter with ID > afterID,
+// ordered by ID under collation, with dependencies, labels, comments,
+// watchers and checklists populated.
+func (s *SQLiteStorage) exportPage(ctx context.Context, filter types.IssueFilter, collation, afterID string, limit int) ([]*types.Issue, error) {
+	s.checkFreshness()
+
+	whereClauses, args := buildIssueFilterClauses("", filter)
+	if afterID != "" {
+		whereClauses = append(whereClauses, "id"+idCollateClause(collation)+" > ?")
+		args = append(args, afterID)
+	}
+	whereSQL := ""
+	if len(whereClauses) > 0 {
+		whereSQL = "WHERE " + strings.Join(whereClauses, " AND ")
+	}
+	args = append(args, limit)
+
+	// #nosec G201 - safe SQL with controlled formatting
+	querySQL := fmt.Sprintf(`
+		SELECT id, content_hash, title, description, design, acceptance_criteria, notes,
+		       status, priority, issue_type, assignee, estimated_minutes,
+		       created_at, created_by, owner, updated_at, closed_at, external_ref, source_repo, close_reason,
+		       deleted_at, deleted_by, delete_reason, original_type,
+		       sender, ephemeral, pinned, is_template, crystallizes,
+		       await_type, await_id, timeout_ns, waiters,
+		       hook_bead, role_bead, agent_state, last_activity, role_type, rig, mol_type,
+		       due_at, defer_until, expires_at, color, display_order, source_system, custom_fields, rank, milestone_id
+		FROM issues
+		%s
+		ORDER BY id%s
+		LIMIT ?
+	`, whereSQL, idCollateClause(collation))
+
+	// Release the read lock before loading dependencies, which takes its own.
+	issues, err := func() ([]*types.Issue, error) {
+		s.reconnectMu.RLock()
+		defer s.reconnectMu.RUnlock()
+
+		rows, err := s.db.QueryContext(ctx, querySQL, args...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query export page: %w", err)
+		}
+		defer func() { _ = rows.Close() }()
+		return s.scanIssues(ctx, rows)
+	}()
+	if err != nil {
+		return nil, err
+	}
+	if len(issues) == 0 {
+		return issues, nil
+	}
+
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	deps, err := s.GetDependencyRecordsForIssues(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dependencies: %w", err)
+	}
+	comments, err := s.GetCommentsForIssues(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comments: %w", err)
+	}
+	watchers, err := s.GetWatchersForIssues(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get watchers: %w", err)
+	}
+	checklists, err := s.GetChecklistsForIssues(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get checklists: %w", err)
+	}
+	for _, issue := range issues {
+		issue.Dependencies = deps[issue.ID]
+		issue.Comments = comments[issue.ID]
+		issue.Watchers = watchers[issue.ID]
+		issue.Checklist = checklists[issue.ID]
+	}
+	if err := s.loadRowIDs(ctx, issues); err != nil {
+		return nil, err
+	}
+	return issues, nil
+}
+
+// loadRowIDs sets RowID on issues, so an export can be re-imported with
+// Options.PreserveRowIDs without renumbering rows external caches refer to.
+func (s *SQLiteStorage) loadRowIDs(ctx context.Context, issues []*types.Issue) error {
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	byID := make(map[string]*types.Issue, len(issues))
+	args := make([]interface{}, len(issues))
+	for i, issue := range issues {
+		byID[issue.ID] = issue
+		args[i] = issue.ID
+	}
+	// #nosec G201 -- placeholders are generated internally
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf(`SELECT id, rowid FROM issues WHERE id IN (%s)`, buildPlaceholders(len(args))), args...)
+	if err != nil {
+		return fmt.Errorf("failed to load rowids: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+		var id string
+		var rowID int64
+		if err := rows.Scan(&id, &rowID); err != nil {
+			return fmt.Errorf("failed to scan rowid: %w", err)
+		}
+		byID[id].RowID = rowID
+	}
+	return rows.Err()
+}
+
+// flushWriter flushes w if it supports flushing.
+func flushWriter(w io.Writer) error {
+	switch f := w.(type) {
+	case errFlusher:
+		return f.Flush()
+	case flusher:
+		f.Flush()
+	}
+	return nil
+}
+
+// ExportSubtreeOptions controls what ExportSubtree includes.
+type ExportSubtreeOptions struct {
+	IncludeDependencies bool // Also export non parent-child dependencies between exported issues
+	IncludeTombstones   bool // Export tombstoned descendants instead of pruning them (and their children)
+}
+
+// ExportSubtree writes rootID and all of its descendants to w as JSONL, one
+// issue per line in depth order (parents before children, siblings by ID), so
+// the output can be imported into another database as-is.
+//
+// Descendants are found through parent-child dependencies and hierarchical IDs.
+// To keep the output self-contained, only dependencies between exported issues
+// are written: parent-child edges always, other types when
+// opts.IncludeDependencies is set. Labels, comments, watchers and checklists
+// are included.
+func (s *SQLiteStorage) ExportSubtree(ctx context.Context, rootID string, w io.Writer, opts ExportSubtreeOptions) error {
+	root, err := s.GetIssue(ctx, rootID)
+	if err != nil {
+		return err
+	}
+	if root == nil {
+		return fmt.Errorf("subtree root %s: %w", rootID, ErrNotFound)
+	}
+
+	parents, err := s.readParentMap(ctx)
+	if err != nil {
+		return err
+	}
+	children := make(map[string][]string)
+	for child, parent := range parents {
+		children[parent] = append(children[parent], child)
+	}
+
+	// Breadth-first walk yields depth order
+	collation := getIDCollation(ctx, s.db)
+	ids := []string{rootID}
+	seen := map[string]bool{rootID: true}
+	for level := []string{rootID}; len(level) > 0; {
+		var next []string
+		for _, id := range level {
+			for _, child := range children[id] {
+				if !seen[child] {
+					seen[child] = true
+					next = append(next, child)
+				}
+			}
+		}
+		sort.Slice(next, func(i, j int) bool { return compareIDs(collation, next[i], next[j]) < 0 })
+		ids = append(ids, next...)
+		level = next
+	}
+
+	found, err := s.SearchIssues(ctx, "", types.IssueFilter{IDs: ids, IncludeTombstones: true})
+	if err != nil {
+		return fmt.Errorf("failed to load subtree: %w", err)
+	}
+	byID := make(map[string]*types.Issue, len(found))
+	for _, issue := range found {
+		byID[issue.ID] = issue
+	}
+
+	// Pruning a tombstone prunes everything below it, since parents come first
+	exported := make(map[string]bool, len(ids))
+	var issues []*types.Issue
+	for _, id := range ids {
+		issue := byID[id]
+		if issue == nil {
+			continue
+		}
+		if id != rootID {
+			if !exported[parents[id]] || (issue.IsTombstone() && !opts.IncludeTombstones) {
+				continue
+			}
+		}
+		exported[id] = true
+		issues = append(issues, issue)
+	}
+
+	exportedIDs := make([]string, len(issues))
+	for i, issue := range issues {
+		exportedIDs[i] = issue.ID
+	}
+	deps, err := s.GetDependencyRecordsForIssues(ctx, exportedIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get dependencies: %w", err)
+	}
+	labels, err := s.GetLabelsForIssues(ctx, exportedIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get labels: %w", err)
+	}
+	comments, err := s.GetCommentsForIssues(ctx, exportedIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get comments: %w", err)
+	}
+	watchers, err := s.GetWatchersForIssues(ctx, exportedIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get watchers: %w", err)
+	}
+	checklists, err := s.GetChecklistsForIssues(ctx, exportedIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get checklists: %w", err)
+	}
+
+	enc := types.NewIssueEncoder(w)
+	for _, issue := range issues {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		issue.Dependencies = nil
+		for _, dep := range deps[issue.ID] {
+			if !exported[dep.DependsOnID] {
+				continue
+			}
+			if dep.Type == types.DepParentChild || opts.IncludeDependencies {
+				issue.Dependencies = append(issue.Dependencies, dep)
+			}
+		}
+		issue.Labels = labels[issue.ID]
+		issue.Comments = comments[issue.ID]
+		issue.Watchers = watchers[issue.ID]
+		issue.Checklist = checklists[issue.ID]
+		if err := enc.Encode(issue); err != nil {
+			return fmt.Errorf("failed to write issue %s: %w", issue.ID, err)
+		}
+	}
+	return flushWriter(w)
+}
+
+// readParentMap is loadParentMap on a pooled connection, for read-only callers.
+func (s *SQLiteStorage) readParentMap(ctx context.Context) (map[string]string, error) {
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+	return loadParentMap(ctx, conn)
+}
@@ -0,0 +1,42 @@
+package sqlite
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ExportChunked writes the issues StreamExport would, in the same order, to fn
+// in chunks of at most chunkSize issues. Each chunk is complete JSONL lines,
+// so it can be parsed on its own, and the consumer can checkpoint between
+// chunks. Memory use is bounded by the chunk and page sizes. No summary line
+// is written; the export is complete when ExportChunked returns nil.
+//
+// The chunk slice is reused and only valid until fn returns. An error from fn
+// stops the export and is returned as is.
+func (s *SQLiteStorage) ExportChunked(ctx context.Context, chunkSize int, fn func(chunk []byte) error) error {
+	if chunkSize <= 0 {
+		return fmt.Errorf("chunk size must be positive, got %d", chunkSize)
+	}
+
+	var buf bytes.Buffer
+	count := 0
+	err := s.forEachExportIssue(ctx, types.IssueFilter{}, func(issue *types.Issue) error {
+		if err := writeExportIssue(&buf, issue); err != nil {
+			return err
+		}
+		if count++; count < chunkSize {
+			return nil
+		}
+		err := fn(buf.Bytes())
+		buf.Reset()
+		count = 0
+		return err
+	})
+	if err != nil || count == 0 {
+		return err
+	}
+	return fn(buf.Bytes())
+}
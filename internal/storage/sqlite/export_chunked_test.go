@@ -0,0 +1,73 @@
+package sqlite
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestExportChunked(t *testing.T) {
+	env := newTestEnv(t)
+	for i := 0; i < 7; i++ {
+		env.CreateIssue(fmt.Sprintf("Issue %d", i))
+	}
+
+	var sizes []int
+	var ids []string
+	err := env.Store.ExportChunked(env.Ctx, 3, func(chunk []byte) error {
+		n := 0
+		scanner := bufio.NewScanner(bytes.NewReader(chunk))
+		for scanner.Scan() {
+			var issue types.Issue
+			if err := json.Unmarshal(scanner.Bytes(), &issue); err != nil {
+				t.Fatalf("chunk %d is not valid JSONL: %v", len(sizes), err)
+			}
+			ids = append(ids, issue.ID)
+			n++
+		}
+		sizes = append(sizes, n)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ExportChunked failed: %v", err)
+	}
+	if fmt.Sprint(sizes) != "[3 3 1]" {
+		t.Errorf("chunk sizes = %v, want [3 3 1]", sizes)
+	}
+
+	// Together the chunks hold exactly the streamed export
+	var stream bytes.Buffer
+	if err := env.Store.StreamExport(env.Ctx, &stream, types.IssueFilter{}); err != nil {
+		t.Fatalf("StreamExport failed: %v", err)
+	}
+	var want []string
+	for _, line := range strings.Split(strings.TrimSpace(stream.String()), "\n") {
+		var issue types.Issue
+		if err := json.Unmarshal([]byte(line), &issue); err == nil && issue.ID != "" {
+			want = append(want, issue.ID)
+		}
+	}
+	if strings.Join(ids, ",") != strings.Join(want, ",") {
+		t.Errorf("chunked IDs = %v, want %v", ids, want)
+	}
+
+	// A callback error stops the export
+	stop := errors.New("stop")
+	calls := 0
+	err = env.Store.ExportChunked(env.Ctx, 2, func([]byte) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Errorf("expected export to stop after the first chunk, got %v after %d calls", err, calls)
+	}
+	if err := env.Store.ExportChunked(env.Ctx, 0, func([]byte) error { return nil }); err == nil {
+		t.Errorf("expected a zero chunk size to be rejected")
+	}
+}
@@ -0,0 +1,137 @@
+package sqlite
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ExportToDirectory writes every issue, tombstones included, to dir as its
+// own file named by ID (see types.IssueFileName), so issues kept in git diff
+// and merge one file at a time. Each file holds the record StreamExport would
+// write, indented, with fields in types.Issue declaration order and a
+// trailing newline; the same issue always exports to the same bytes.
+//
+// Files are replaced atomically. A file left from an earlier export whose
+// issue has since been deleted outright is rewritten as a tombstone, so
+// importing the directory deletes the issue elsewhere too; the files of
+// archived issues are removed, as the archive keeps them. Other files in dir
+// are left alone.
+func (s *SQLiteStorage) ExportToDirectory(ctx context.Context, dir string) error {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("failed to create export directory: %w", err)
+	}
+
+	written := make(map[string]bool)
+	err := s.forEachExportIssue(ctx, types.IssueFilter{IncludeTombstones: true}, func(issue *types.Issue) error {
+		name, err := types.IssueFileName(issue.ID)
+		if err != nil {
+			return err
+		}
+		if err := writeIssueFile(dir, name, issue); err != nil {
+			return err
+		}
+		written[name] = true
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read export directory: %w", err)
+	}
+	stale := make(map[string]*types.Issue)
+	var staleIDs []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.Type().IsRegular() || !strings.HasSuffix(name, types.IssueFileExt) || written[name] {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, name)) // #nosec G304 -- file in caller-supplied export directory
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		var issue types.Issue
+		if err := json.Unmarshal(data, &issue); err != nil || issue.ID+types.IssueFileExt != name {
+			continue // not an issue file
+		}
+		stale[issue.ID] = &issue
+		staleIDs = append(staleIDs, issue.ID)
+	}
+	if len(stale) == 0 {
+		return nil
+	}
+
+	archived, err := s.FilterArchivedIDs(ctx, staleIDs)
+	if err != nil {
+		return err
+	}
+	for _, id := range archived {
+		if err := os.Remove(filepath.Join(dir, id+types.IssueFileExt)); err != nil {
+			return fmt.Errorf("failed to remove archived issue file %s: %w", id, err)
+		}
+		delete(stale, id)
+	}
+
+	now := time.Now().UTC()
+	for _, id := range staleIDs {
+		issue := stale[id]
+		if issue == nil || issue.IsTombstone() {
+			continue
+		}
+		issue.OriginalType = string(issue.IssueType)
+		issue.Status = types.StatusTombstone
+		issue.ClosedAt = nil
+		issue.DeletedAt = &now
+		issue.UpdatedAt = now
+		if err := writeIssueFile(dir, id+types.IssueFileExt, issue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeIssueFile atomically replaces dir/name with issue's export record.
+func writeIssueFile(dir, name string, issue *types.Issue) error {
+	line, err := types.MarshalIssue(issue)
+	if err != nil {
+		return fmt.Errorf("failed to encode issue %s: %w", issue.ID, err)
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, line, "", "  "); err != nil {
+		return fmt.Errorf("failed to encode issue %s: %w", issue.ID, err)
+	}
+	buf.WriteByte('\n')
+
+	tempFile, err := os.CreateTemp(dir, name+".tmp.*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file for %s: %w", name, err)
+	}
+	tempPath := tempFile.Name()
+	defer func() {
+		_ = tempFile.Close()
+		_ = os.Remove(tempPath)
+	}()
+	if _, err := tempFile.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	if err := tempFile.Chmod(0o644); err != nil { // #nosec G302 -- exported issues are meant to be committed
+		return fmt.Errorf("failed to set permissions on %s: %w", name, err)
+	}
+	if err := tempFile.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	if err := os.Rename(tempPath, filepath.Join(dir, name)); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", name, err)
+	}
+	return nil
+}
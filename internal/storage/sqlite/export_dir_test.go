@@ -0,0 +1,72 @@
+package sqlite
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestExportToDirectory(t *testing.T) {
+	env := newTestEnv(t)
+	epic := env.CreateEpic("Epic")
+	task := env.CreateIssue("Task")
+	env.AddParentChild(task, epic)
+	gone := env.CreateIssue("Deleted outright")
+	removed := env.CreateIssue("Tombstoned")
+	if err := env.Store.CreateTombstone(env.Ctx, removed.ID, "test-user", "duplicate"); err != nil {
+		t.Fatalf("CreateTombstone failed: %v", err)
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("notes\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if err := env.Store.ExportToDirectory(env.Ctx, dir); err != nil {
+		t.Fatalf("ExportToDirectory failed: %v", err)
+	}
+	read := func(id string) (*types.Issue, []byte) {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join(dir, id+types.IssueFileExt))
+		if err != nil {
+			t.Fatalf("ReadFile(%s) failed: %v", id, err)
+		}
+		var issue types.Issue
+		if err := json.Unmarshal(data, &issue); err != nil {
+			t.Fatalf("decode %s: %v", id, err)
+		}
+		return &issue, data
+	}
+
+	got, first := read(task.ID)
+	if got.Title != "Task" || len(got.Dependencies) != 1 || got.Dependencies[0].DependsOnID != epic.ID {
+		t.Errorf("task file = %+v, want the task with its parent", got)
+	}
+	if !bytes.HasPrefix(first, []byte("{\n  \"id\": ")) || !bytes.HasSuffix(first, []byte("}\n")) {
+		t.Errorf("task file is not an indented record:\n%s", first)
+	}
+	if got, _ := read(removed.ID); got.Status != types.StatusTombstone || got.DeleteReason != "duplicate" {
+		t.Errorf("tombstoned issue exported as %s (%q), want a tombstone", got.Status, got.DeleteReason)
+	}
+
+	// Exports are stable, and deleting an issue outright leaves a tombstone file
+	if err := env.Store.DeleteIssue(env.Ctx, gone.ID); err != nil {
+		t.Fatalf("DeleteIssue failed: %v", err)
+	}
+	if err := env.Store.ExportToDirectory(env.Ctx, dir); err != nil {
+		t.Fatalf("second ExportToDirectory failed: %v", err)
+	}
+	if _, again := read(task.ID); !bytes.Equal(first, again) {
+		t.Errorf("re-export changed %s:\n%s\nvs\n%s", task.ID, first, again)
+	}
+	got, _ = read(gone.ID)
+	if got.Status != types.StatusTombstone || got.DeletedAt == nil || got.OriginalType != string(types.TypeTask) {
+		t.Errorf("deleted issue file = %+v, want a tombstone", got)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "README.md")); err != nil {
+		t.Errorf("unrelated file was touched: %v", err)
+	}
+}
@@ -0,0 +1,79 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// ExportByPrefix splits an export by ID prefix in a single pass: each issue
+// StreamExport would write goes to writerFor(prefix) instead, so a multi-repo
+// database exports to one file per repo. It is the producing counterpart of
+// importer.Options.IsolatePrefixes.
+//
+// writerFor is called once per prefix, when the first issue with that prefix
+// is reached; returning nil drops that prefix's issues. Every stream is a
+// complete export in its own right: issues in ID order followed by an
+// ExportSummary line with that stream's count. Hierarchical children
+// ("bd-a3f8.1") are routed by their root ID, so they always land with their
+// parent. Prefixes are split on the configured storage.IDSeparatorConfigKey.
+func (s *SQLiteStorage) ExportByPrefix(ctx context.Context, writerFor func(prefix string) io.Writer) error {
+	type stream struct {
+		w     io.Writer
+		count int
+	}
+	streams := make(map[string]*stream)
+	sep := getIDSeparator(ctx, s.db)
+
+	err := s.forEachExportIssue(ctx, types.IssueFilter{}, func(issue *types.Issue) error {
+		prefix := exportPrefix(issue.ID, sep)
+		st, ok := streams[prefix]
+		if !ok {
+			st = &stream{w: writerFor(prefix)}
+			streams[prefix] = st
+		}
+		if st.w == nil {
+			return nil
+		}
+		if err := writeExportIssue(st.w, issue); err != nil {
+			return fmt.Errorf("prefix %s: %w", prefix, err)
+		}
+		st.count++
+		return flushExport(st.w)
+	})
+	if err != nil {
+		return err
+	}
+
+	prefixes := make([]string, 0, len(streams))
+	for prefix := range streams {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+	for _, prefix := range prefixes {
+		st := streams[prefix]
+		if st.w == nil {
+			continue
+		}
+		if err := json.NewEncoder(st.w).Encode(ExportSummary{Summary: true, Count: st.count}); err != nil {
+			return fmt.Errorf("failed to write export summary for prefix %s: %w", prefix, err)
+		}
+		if err := flushWriter(st.w); err != nil {
+			return fmt.Errorf("failed to flush export for prefix %s: %w", prefix, err)
+		}
+	}
+	return nil
+}
+
+// exportPrefix returns the prefix of id's root issue, so hierarchical
+// children share their parent's prefix.
+func exportPrefix(id, sep string) string {
+	root, _, _ := strings.Cut(id, ".")
+	return utils.ExtractIssuePrefixWithSeparator(root, sep)
+}
@@ -0,0 +1,90 @@
+package sqlite
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestExportByPrefix(t *testing.T) {
+	env := newTestEnv(t)
+	env.CreateIssueWithID("bd-a1b2", "Parent")
+	env.CreateIssueWithID("bd-a1b2.1", "Child")
+	env.CreateIssueWithID("bd-a1b2.1.1", "Grandchild")
+	// Imports skip prefix validation, bringing in issues from other repos
+	var imported []*types.Issue
+	for _, id := range []string{"api-x9y8", "api-dead", "ops-c3d4", "ops-c3d4.1"} {
+		imported = append(imported, &types.Issue{ID: id, Title: "Issue " + id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask})
+	}
+	if err := env.Store.CreateIssuesWithFullOptions(env.Ctx, imported, "import", BatchCreateOptions{SkipPrefixValidation: true}); err != nil {
+		t.Fatalf("CreateIssuesWithFullOptions failed: %v", err)
+	}
+	if err := env.Store.CreateTombstone(env.Ctx, "api-dead", "test-user", "obsolete"); err != nil {
+		t.Fatalf("CreateTombstone failed: %v", err)
+	}
+
+	streams := make(map[string]*bytes.Buffer)
+	var asked []string
+	err := env.Store.ExportByPrefix(env.Ctx, func(prefix string) io.Writer {
+		asked = append(asked, prefix)
+		if prefix == "ops" {
+			return nil // dropped
+		}
+		streams[prefix] = &bytes.Buffer{}
+		return streams[prefix]
+	})
+	if err != nil {
+		t.Fatalf("ExportByPrefix failed: %v", err)
+	}
+	if want := []string{"api", "bd", "ops"}; !reflect.DeepEqual(asked, want) {
+		t.Errorf("writers requested for %v, want %v (once each, in ID order)", asked, want)
+	}
+
+	idsIn := func(data string) ([]string, int) {
+		t.Helper()
+		issues := decodeExport(t, []byte(data))
+		ids := []string{}
+		for _, issue := range issues {
+			if issue.ID != "" { // the summary line
+				ids = append(ids, issue.ID)
+			}
+		}
+		return ids, strings.Count(data, `"_summary":true`)
+	}
+	for prefix, want := range map[string][]string{
+		"bd":  {"bd-a1b2", "bd-a1b2.1", "bd-a1b2.1.1"},
+		"api": {"api-x9y8"}, // tombstones are not exported,
+	} {
+		ids, summaries := idsIn(streams[prefix].String())
+		if !reflect.DeepEqual(ids, want) {
+			t.Errorf("%s stream = %v, want %v", prefix, ids, want)
+		}
+		if summaries != 1 || !strings.Contains(streams[prefix].String(), fmt.Sprintf(`"count":%d}`, len(want))) {
+			t.Errorf("%s stream should end with its own summary:\n%s", prefix, streams[prefix].String())
+		}
+	}
+	if _, ok := streams["ops"]; ok {
+		t.Error("the dropped prefix should have no stream")
+	}
+}
+
+func TestExportPrefix(t *testing.T) {
+	for id, want := range map[string]string{
+		"bd-a1b2":        "bd",
+		"bd-a1b2.3.4":    "bd",
+		"web-app-a3f8e9": "web-app",
+		"vc-baseline.1":  "vc",
+	} {
+		if got := exportPrefix(id, "-"); got != want {
+			t.Errorf("exportPrefix(%q) = %q, want %q", id, got, want)
+		}
+	}
+	if got := exportPrefix("web-app_a3f8.1", "_"); got != "web-app" {
+		t.Errorf("exportPrefix with separator _ = %q, want web-app", got)
+	}
+}
@@ -0,0 +1,413 @@
+package sqlite
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestStreamExport_IncrementalOutput(t *testing.T) {
+	env := newTestEnv(t)
+	for i := 1; i <= 5; i++ {
+		env.CreateIssueWithID(fmt.Sprintf("bd-%d", i), fmt.Sprintf("Issue %d", i))
+	}
+
+	// Force several pages so keyset pagination is exercised
+	oldPageSize := streamExportPageSize
+	streamExportPageSize = 2
+	defer func() { streamExportPageSize = oldPageSize }()
+
+	pr, pw := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		err := env.Store.StreamExport(env.Ctx, pw, types.IssueFilter{})
+		_ = pw.CloseWithError(err)
+		done <- err
+	}()
+
+	reader := bufio.NewReader(pr)
+	first, err := reader.ReadBytes('\n')
+	if err != nil {
+		t.Fatalf("reading first line: %v", err)
+	}
+	var issue types.Issue
+	if err := json.Unmarshal(first, &issue); err != nil {
+		t.Fatalf("first line is not an issue: %v", err)
+	}
+	if issue.ID != "bd-1" {
+		t.Errorf("expected first issue bd-1, got %s", issue.ID)
+	}
+
+	// The pipe is unbuffered, so the export can't have finished yet
+	select {
+	case err := <-done:
+		t.Fatalf("export finished before the consumer read everything (err=%v)", err)
+	default:
+	}
+
+	var ids []string
+	var summary ExportSummary
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("reading export: %v", err)
+		}
+		var probe map[string]interface{}
+		if err := json.Unmarshal(line, &probe); err != nil {
+			t.Fatalf("invalid JSON line %q: %v", line, err)
+		}
+		if _, ok := probe["_summary"]; ok {
+			if err := json.Unmarshal(line, &summary); err != nil {
+				t.Fatalf("invalid summary line: %v", err)
+			}
+			continue
+		}
+		ids = append(ids, probe["id"].(string))
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("StreamExport failed: %v", err)
+	}
+
+	if len(ids) != 4 {
+		t.Errorf("expected 4 more issues after the first, got %v", ids)
+	}
+	if !summary.Summary || summary.Count != 5 {
+		t.Errorf("expected summary count 5, got %+v", summary)
+	}
+}
+
+func TestStreamExport_Filter(t *testing.T) {
+	env := newTestEnv(t)
+	env.CreateIssueWith("Open", types.StatusOpen, 1, types.TypeTask)
+	env.CreateIssueWith("Bug", types.StatusOpen, 1, types.TypeBug)
+
+	pr, pw := io.Pipe()
+	go func() {
+		bug := types.TypeBug
+		_ = pw.CloseWithError(env.Store.StreamExport(env.Ctx, pw, types.IssueFilter{IssueType: &bug}))
+	}()
+
+	var lines []map[string]interface{}
+	scanner := bufio.NewScanner(pr)
+	for scanner.Scan() {
+		var m map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &m); err != nil {
+			t.Fatalf("invalid JSON line: %v", err)
+		}
+		lines = append(lines, m)
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("StreamExport failed: %v", err)
+	}
+	if len(lines) != 2 {
+		t.Fatalf("expected one issue and a summary, got %d lines", len(lines))
+	}
+	if lines[0]["title"] != "Bug" {
+		t.Errorf("expected only the bug, got %v", lines[0]["title"])
+	}
+	if lines[1]["count"] != float64(1) {
+		t.Errorf("expected summary count 1, got %v", lines[1]["count"])
+	}
+}
+
+func TestStreamExport_CustomFieldFilter(t *testing.T) {
+	env := newTestEnv(t)
+	create := func(title string, fields map[string]json.RawMessage) {
+		t.Helper()
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CustomFields: fields}
+		if err := env.Store.CreateIssue(env.Ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue(%s) failed: %v", title, err)
+		}
+	}
+	create("Storage", map[string]json.RawMessage{"component": json.RawMessage(`"storage"`), "team": json.RawMessage(`"core"`)})
+	create("Storage elsewhere", map[string]json.RawMessage{"component": json.RawMessage(`"storage"`), "team": json.RawMessage(`"web"`)})
+	create("UI", map[string]json.RawMessage{"component": json.RawMessage(`"ui"`)})
+	create("No fields", nil)
+	create("Odd keys", map[string]json.RawMessage{`say "hi"`: json.RawMessage(`"yes"`), `back\slash`: json.RawMessage(`"yes"`)})
+
+	export := func(fields map[string]string) []string {
+		t.Helper()
+		var buf bytes.Buffer
+		if err := env.Store.StreamExport(env.Ctx, &buf, types.IssueFilter{CustomFields: fields}); err != nil {
+			t.Fatalf("StreamExport failed: %v", err)
+		}
+		var titles []string
+		scanner := bufio.NewScanner(&buf)
+		for scanner.Scan() {
+			var m map[string]interface{}
+			if err := json.Unmarshal(scanner.Bytes(), &m); err != nil {
+				t.Fatalf("invalid JSON line: %v", err)
+			}
+			if title, ok := m["title"].(string); ok {
+				titles = append(titles, title)
+			}
+		}
+		sort.Strings(titles)
+		return titles
+	}
+
+	if got := export(map[string]string{"component": "storage"}); fmt.Sprint(got) != "[Storage Storage elsewhere]" {
+		t.Errorf("component=storage exported %v", got)
+	}
+	if got := export(map[string]string{"component": "storage", "team": "core"}); fmt.Sprint(got) != "[Storage]" {
+		t.Errorf("component=storage, team=core exported %v", got)
+	}
+	if got := export(map[string]string{"component": "backend"}); len(got) != 0 {
+		t.Errorf("component=backend exported %v, want nothing", got)
+	}
+	// Quotes and backslashes in a key are escaped in the JSON path
+	if got := export(map[string]string{`say "hi"`: "yes", `back\slash`: "yes"}); fmt.Sprint(got) != "[Odd keys]" {
+		t.Errorf("keys with quote and backslash exported %v", got)
+	}
+	if got := export(map[string]string{`say "`: "yes"}); len(got) != 0 {
+		t.Errorf("key prefix ending in a quote exported %v, want nothing", got)
+	}
+}
+
+func TestCustomFields_InvalidValueRejected(t *testing.T) {
+	env := newTestEnv(t)
+	bad := map[string]json.RawMessage{"component": json.RawMessage(`{"unterminated`)}
+
+	issue := &types.Issue{Title: "Bad", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CustomFields: bad}
+	if err := env.Store.CreateIssue(env.Ctx, issue, "test-user"); err == nil {
+		t.Error("expected an invalid custom field value to fail the create")
+	}
+
+	good := &types.Issue{Title: "Good", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask,
+		CustomFields: map[string]json.RawMessage{"component": json.RawMessage(`"storage"`)}}
+	if err := env.Store.CreateIssue(env.Ctx, good, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := env.Store.UpdateIssue(env.Ctx, good.ID, map[string]interface{}{"custom_fields": bad}, "test-user"); err == nil {
+		t.Error("expected an invalid custom field value to fail the update")
+	}
+	got, err := env.Store.GetIssue(env.Ctx, good.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if string(got.CustomFields["component"]) != `"storage"` {
+		t.Errorf("expected the stored custom fields kept, got %v", got.CustomFields)
+	}
+}
+
+func TestStreamExport_CommentsAndWatchers(t *testing.T) {
+	env := newTestEnv(t)
+	watched := env.CreateIssue("Watched")
+	quiet := env.CreateIssue("Quiet")
+	for _, watcher := range []string{"bob", "alice"} {
+		if err := env.Store.AddWatcher(env.Ctx, watched.ID, watcher, "test-user"); err != nil {
+			t.Fatalf("AddWatcher failed: %v", err)
+		}
+	}
+	if _, err := env.Store.AddIssueComment(env.Ctx, watched.ID, "alice", "Looking into it"); err != nil {
+		t.Fatalf("AddIssueComment failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := env.Store.StreamExport(env.Ctx, &buf, types.IssueFilter{}); err != nil {
+		t.Fatalf("StreamExport failed: %v", err)
+	}
+	exported := make(map[string]*types.Issue)
+	for _, issue := range decodeExport(t, buf.Bytes()) {
+		exported[issue.ID] = issue
+	}
+	got := exported[watched.ID]
+	if got == nil || fmt.Sprint(got.Watchers) != "[alice bob]" {
+		t.Fatalf("exported %s = %+v, want watchers [alice bob]", watched.ID, got)
+	}
+	if len(got.Comments) != 1 || got.Comments[0].Author != "alice" || got.Comments[0].Text != "Looking into it" {
+		t.Errorf("exported comments = %+v, want alice's comment", got.Comments)
+	}
+	if q := exported[quiet.ID]; q == nil || len(q.Watchers) != 0 || len(q.Comments) != 0 {
+		t.Errorf("exported %s = %+v, want no watchers or comments", quiet.ID, q)
+	}
+}
+
+func TestStreamExport_StopsOnCancel(t *testing.T) {
+	env := newTestEnv(t)
+	for i := 1; i <= 3; i++ {
+		env.CreateIssueWithID(fmt.Sprintf("bd-%d", i), fmt.Sprintf("Issue %d", i))
+	}
+
+	ctx, cancel := context.WithCancel(env.Ctx)
+	defer cancel()
+
+	pr, pw := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		err := env.Store.StreamExport(ctx, pw, types.IssueFilter{})
+		_ = pw.CloseWithError(err)
+		done <- err
+	}()
+
+	reader := bufio.NewReader(pr)
+	if _, err := reader.ReadBytes('\n'); err != nil {
+		t.Fatalf("reading first line: %v", err)
+	}
+
+	// Simulate a client disconnect
+	cancel()
+	_, _ = io.Copy(io.Discard, reader)
+
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+// decodeExport parses JSONL export output into issues.
+func decodeExport(t *testing.T, data []byte) []*types.Issue {
+	t.Helper()
+	var issues []*types.Issue
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var issue types.Issue
+		if err := json.Unmarshal(scanner.Bytes(), &issue); err != nil {
+			t.Fatalf("invalid JSON line %q: %v", scanner.Text(), err)
+		}
+		issues = append(issues, &issue)
+	}
+	return issues
+}
+
+func TestExportSubtree(t *testing.T) {
+	env := newTestEnv(t)
+	root := env.CreateIssueWithID("bd-root", "Root")
+	child := env.CreateIssueWithID("bd-root.1", "Hierarchical child")
+	linked := env.CreateIssueWithID("bd-linked", "Linked child")
+	grandchild := env.CreateIssueWithID("bd-grand", "Grandchild")
+	gone := env.CreateIssueWithID("bd-gone", "Tombstoned child")
+	underGone := env.CreateIssueWithID("bd-under", "Under tombstone")
+	outside := env.CreateIssueWithID("bd-outside", "Not in subtree")
+	env.AddParentChild(linked, root)
+	env.AddParentChild(grandchild, linked)
+	env.AddParentChild(gone, root)
+	env.AddParentChild(underGone, gone)
+	env.AddParentChild(root, outside)
+	env.AddDep(child, grandchild)
+	env.AddDep(linked, outside)
+	if err := env.Store.AddLabel(env.Ctx, grandchild.ID, "feature-x", "test-user"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+	if err := env.Store.CreateTombstone(env.Ctx, gone.ID, "test-user", "obsolete"); err != nil {
+		t.Fatalf("CreateTombstone failed: %v", err)
+	}
+	team := map[string]json.RawMessage{"team": json.RawMessage(`"core"`)}
+	if err := env.Store.UpdateIssue(env.Ctx, grandchild.ID, map[string]interface{}{"custom_fields": team}, "test-user"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := env.Store.ExportSubtree(env.Ctx, root.ID, &buf, ExportSubtreeOptions{}); err != nil {
+		t.Fatalf("ExportSubtree failed: %v", err)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte(`"team":"core"`)) {
+		t.Errorf("expected preserved custom fields in the subtree export, got:\n%s", buf.String())
+	}
+	issues := decodeExport(t, buf.Bytes())
+	var ids []string
+	for _, issue := range issues {
+		ids = append(ids, issue.ID)
+	}
+	if want := []string{"bd-root", "bd-linked", "bd-root.1", "bd-grand"}; fmt.Sprint(ids) != fmt.Sprint(want) {
+		t.Fatalf("expected %v in depth order, got %v", want, ids)
+	}
+
+	if len(issues[0].Dependencies) != 0 {
+		t.Errorf("root's edge to its outside parent should be dropped, got %v", issues[0].Dependencies)
+	}
+	if deps := issues[1].Dependencies; len(deps) != 1 || deps[0].DependsOnID != root.ID {
+		t.Errorf("expected only the parent-child edge for bd-linked, got %v", deps)
+	}
+	if len(issues[2].Dependencies) != 0 {
+		t.Errorf("blocks edge should be omitted without IncludeDependencies, got %v", issues[2].Dependencies)
+	}
+	if labels := issues[3].Labels; len(labels) != 1 || labels[0] != "feature-x" {
+		t.Errorf("expected grandchild labels, got %v", labels)
+	}
+
+	buf.Reset()
+	opts := ExportSubtreeOptions{IncludeDependencies: true, IncludeTombstones: true}
+	if err := env.Store.ExportSubtree(env.Ctx, root.ID, &buf, opts); err != nil {
+		t.Fatalf("ExportSubtree failed: %v", err)
+	}
+	issues = decodeExport(t, buf.Bytes())
+	byID := make(map[string]*types.Issue)
+	for _, issue := range issues {
+		byID[issue.ID] = issue
+	}
+	if byID[gone.ID] == nil || byID[underGone.ID] == nil {
+		t.Errorf("expected tombstoned subtree with IncludeTombstones, got %d issues", len(issues))
+	}
+	if deps := byID[child.ID].Dependencies; len(deps) != 1 || deps[0].DependsOnID != grandchild.ID {
+		t.Errorf("expected blocks edge with IncludeDependencies, got %v", deps)
+	}
+	for _, dep := range byID[linked.ID].Dependencies {
+		if dep.DependsOnID == outside.ID {
+			t.Error("dependency on an issue outside the subtree should be dropped")
+		}
+	}
+
+	if err := env.Store.ExportSubtree(env.Ctx, "bd-missing", &buf, opts); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for missing root, got %v", err)
+	}
+}
+
+func TestExportWithChecksum_Deterministic(t *testing.T) {
+	env := newTestEnv(t)
+	a := env.CreateIssueWithID("bd-a", "Issue A")
+	b := env.CreateIssueWithID("bd-b", "Issue B")
+	env.AddDep(a, b)
+	for _, label := range []string{"zeta", "alpha"} {
+		if err := env.Store.AddLabel(env.Ctx, a.ID, label, "test-user"); err != nil {
+			t.Fatalf("AddLabel failed: %v", err)
+		}
+	}
+
+	export := func() ([]byte, string) {
+		var buf bytes.Buffer
+		sum, err := env.Store.ExportWithChecksum(env.Ctx, &buf, types.IssueFilter{})
+		if err != nil {
+			t.Fatalf("ExportWithChecksum failed: %v", err)
+		}
+		return buf.Bytes(), sum
+	}
+	first, sum1 := export()
+	second, sum2 := export()
+	if sum1 != sum2 || !bytes.Equal(first, second) {
+		t.Fatalf("repeated exports differ: %s vs %s", sum1, sum2)
+	}
+
+	body := first[:bytes.LastIndexByte(first[:len(first)-1], '\n')+1]
+	want := sha256.Sum256(body)
+	if sum1 != hex.EncodeToString(want[:]) {
+		t.Errorf("checksum %s does not hash the export body", sum1)
+	}
+	var trailer types.ExportChecksum
+	if err := json.Unmarshal(first[len(body):], &trailer); err != nil {
+		t.Fatalf("last line is not a checksum: %v", err)
+	}
+	if trailer.Checksum != sum1 || trailer.Algorithm != "sha256" {
+		t.Errorf("unexpected trailer %+v", trailer)
+	}
+
+	issues := decodeExport(t, body)
+	if len(issues) == 0 || issues[0].ID != "bd-a" {
+		t.Fatalf("expected bd-a first, got %+v", issues)
+	}
+	if got := issues[0].Labels; len(got) != 2 || got[0] != "alpha" || got[1] != "zeta" {
+		t.Errorf("expected labels sorted by name, got %v", got)
+	}
+}
@@ -0,0 +1,96 @@
+package sqlite
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ExternalRefGeneratorConfigKey names the ExternalRefGenerator that fills in
+// external_ref on issues created or imported without one, giving each issue a
+// globally unique reference alongside its human-readable ID. Built in: "uuid"
+// (random UUIDv4) and "content" (a UUID derived from the issue's content and
+// creation time). Unset leaves external_ref empty.
+const ExternalRefGeneratorConfigKey = "external_ref.generator"
+
+// ExternalRefGenerator returns the external_ref for an issue being created.
+// The issue's ID may not be assigned yet.
+type ExternalRefGenerator func(issue *types.Issue) string
+
+var (
+	externalRefGeneratorsMu sync.RWMutex
+	externalRefGenerators   = map[string]ExternalRefGenerator{
+		"uuid":    randomExternalRef,
+		"content": contentExternalRef,
+	}
+)
+
+// RegisterExternalRefGenerator makes gen selectable by name through
+// ExternalRefGeneratorConfigKey, replacing any generator of the same name.
+func RegisterExternalRefGenerator(name string, gen ExternalRefGenerator) {
+	externalRefGeneratorsMu.Lock()
+	defer externalRefGeneratorsMu.Unlock()
+	externalRefGenerators[name] = gen
+}
+
+// assignExternalRefs sets external_ref on the issues that lack one using the
+// configured generator, recomputing their content hashes to include it. An
+// incoming external_ref is always preserved.
+func assignExternalRefs(ctx context.Context, db dbExecutor, issues ...*types.Issue) error {
+	var name string
+	if err := db.QueryRowContext(ctx, `SELECT value FROM config WHERE key = ?`, ExternalRefGeneratorConfigKey).Scan(&name); err != nil {
+		return nil
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil
+	}
+	externalRefGeneratorsMu.RLock()
+	gen, ok := externalRefGenerators[name]
+	externalRefGeneratorsMu.RUnlock()
+	if !ok {
+		return fmt.Errorf("unknown %s %q", ExternalRefGeneratorConfigKey, name)
+	}
+
+	for _, issue := range issues {
+		if issue.ExternalRef != nil && *issue.ExternalRef != "" {
+			continue
+		}
+		ref := gen(issue)
+		if ref == "" {
+			continue
+		}
+		issue.ExternalRef = &ref
+		issue.ContentHash = contentHash(ctx, db, issue)
+	}
+	return nil
+}
+
+func randomExternalRef(*types.Issue) string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40 // version 4
+	b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+	return formatUUID(b)
+}
+
+// contentExternalRef derives a name-based UUID from the issue's content hash
+// and creation time, so the same record always maps to the same reference.
+func contentExternalRef(issue *types.Issue) string {
+	sum := sha256.Sum256([]byte(issue.ComputeContentHash() + "\x00" + issue.CreatedAt.UTC().Format(time.RFC3339Nano)))
+	var b [16]byte
+	copy(b[:], sum[:16])
+	b[6] = b[6]&0x0f | 0x50 // version 5 layout (SHA-based)
+	b[8] = b[8]&0x3f | 0x80
+	return formatUUID(b)
+}
+
+func formatUUID(b [16]byte) string {
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -2,6 +2,7 @@ package sqlite
 
 import (
 	"context"
+	"regexp"
 	"testing"
 	"time"
 
@@ -95,7 +96,7 @@ func TestDetectCollisionsWithExternalRef(t *testing.T) {
 		Status:      types.StatusInProgress,
 		Priority:    2,
 		IssueType:   types.TypeBug,
-		ExternalRef: &externalRef, // Same external_ref
+		ExternalRef: &externalRef,                  // Same external_ref
 		UpdatedAt:   time.Now().Add(1 * time.Hour), // Newer timestamp
 	}
 
@@ -199,7 +200,7 @@ func TestDetectCollisionsExternalRefPriorityOverID(t *testing.T) {
 	}
 
 	collision := result.Collisions[0]
-	
+
 	// The existing issue matched should be bd-test-1 (by external_ref), not bd-test-2 (by ID)
 	if collision.ExistingIssue.ID != existing.ID {
 		t.Errorf("Expected external_ref match with %s, but got %s", existing.ID, collision.ExistingIssue.ID)
@@ -323,11 +324,11 @@ func TestExternalRefIndexUsage(t *testing.T) {
 			t.Fatalf("Failed to scan query plan row: %v", err)
 		}
 		planFound = true
-		
+
 		if detail == "SEARCH TABLE issues USING INDEX idx_issues_external_ref (external_ref=?)" ||
-		   detail == "SEARCH issues USING INDEX idx_issues_external_ref (external_ref=?)" ||
-		   detail == "SEARCH TABLE issues USING INDEX idx_issues_external_ref_unique (external_ref=?)" ||
-		   detail == "SEARCH issues USING INDEX idx_issues_external_ref_unique (external_ref=?)" {
+			detail == "SEARCH issues USING INDEX idx_issues_external_ref (external_ref=?)" ||
+			detail == "SEARCH TABLE issues USING INDEX idx_issues_external_ref_unique (external_ref=?)" ||
+			detail == "SEARCH issues USING INDEX idx_issues_external_ref_unique (external_ref=?)" {
 			indexUsed = true
 		}
 	}
@@ -344,3 +345,94 @@ func TestExternalRefIndexUsage(t *testing.T) {
 		t.Error("Expected query planner to use idx_issues_external_ref index, but it didn't")
 	}
 }
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[45][0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func TestExternalRefGenerator(t *testing.T) {
+	env := newTestEnv(t)
+	newIssue := func(title string) *types.Issue {
+		return &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	}
+
+	plain := newIssue("No generator")
+	if err := env.Store.CreateIssue(env.Ctx, plain, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if plain.ExternalRef != nil {
+		t.Errorf("expected no external_ref without a generator, got %q", *plain.ExternalRef)
+	}
+
+	for _, name := range []string{"uuid", "content"} {
+		if err := env.Store.SetConfig(env.Ctx, ExternalRefGeneratorConfigKey, name); err != nil {
+			t.Fatalf("SetConfig failed: %v", err)
+		}
+
+		single := newIssue("Single " + name)
+		if err := env.Store.CreateIssue(env.Ctx, single, "test"); err != nil {
+			t.Fatalf("%s: CreateIssue failed: %v", name, err)
+		}
+		batch := []*types.Issue{newIssue("Batch 1 " + name), newIssue("Batch 2 " + name)}
+		if err := env.Store.CreateIssues(env.Ctx, batch, "test"); err != nil {
+			t.Fatalf("%s: CreateIssues failed: %v", name, err)
+		}
+
+		seen := make(map[string]bool)
+		for _, issue := range append(batch, single) {
+			stored, err := env.Store.GetIssue(env.Ctx, issue.ID)
+			if err != nil || stored == nil {
+				t.Fatalf("%s: GetIssue(%s) failed: %v", name, issue.ID, err)
+			}
+			if stored.ExternalRef == nil || !uuidPattern.MatchString(*stored.ExternalRef) {
+				t.Fatalf("%s: expected a generated UUID external_ref on %s, got %v", name, issue.ID, stored.ExternalRef)
+			}
+			if seen[*stored.ExternalRef] {
+				t.Errorf("%s: duplicate external_ref %s", name, *stored.ExternalRef)
+			}
+			seen[*stored.ExternalRef] = true
+			if stored.ContentHash != stored.ComputeContentHash() {
+				t.Errorf("%s: content_hash of %s does not include the generated external_ref", name, issue.ID)
+			}
+		}
+
+		ref := "jira-ABC-" + name
+		kept := newIssue("Has ref " + name)
+		kept.ExternalRef = &ref
+		if err := env.Store.CreateIssue(env.Ctx, kept, "test"); err != nil {
+			t.Fatalf("%s: CreateIssue failed: %v", name, err)
+		}
+		if stored, _ := env.Store.GetIssue(env.Ctx, kept.ID); stored.ExternalRef == nil || *stored.ExternalRef != ref {
+			t.Errorf("%s: expected incoming external_ref %s preserved, got %v", name, ref, stored.ExternalRef)
+		}
+	}
+
+	RegisterExternalRefGenerator("fixed", func(*types.Issue) string { return "fixed-ref" })
+	if err := env.Store.SetConfig(env.Ctx, ExternalRefGeneratorConfigKey, "fixed"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	custom := newIssue("Custom generator")
+	if err := env.Store.CreateIssue(env.Ctx, custom, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if custom.ExternalRef == nil || *custom.ExternalRef != "fixed-ref" {
+		t.Errorf("expected registered generator used, got %v", custom.ExternalRef)
+	}
+
+	if err := env.Store.SetConfig(env.Ctx, ExternalRefGeneratorConfigKey, "bogus"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	if err := env.Store.CreateIssue(env.Ctx, newIssue("Bogus"), "test"); err == nil {
+		t.Error("expected an unknown generator to fail creation")
+	}
+}
+
+func TestContentExternalRefIsStable(t *testing.T) {
+	issue := &types.Issue{Title: "Same", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if a, b := contentExternalRef(issue), contentExternalRef(issue); a != b {
+		t.Errorf("content-derived refs differ: %s vs %s", a, b)
+	}
+	other := *issue
+	other.Title = "Different"
+	if contentExternalRef(issue) == contentExternalRef(&other) {
+		t.Error("expected different content to derive a different ref")
+	}
+}
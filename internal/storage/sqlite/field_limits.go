@@ -0,0 +1,169 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Config keys for maximum text field lengths (in bytes; 0 = unbounded) and
+// the policy for over-long fields ("error" or "truncate").
+const (
+	MaxTitleLengthConfigKey              = "validation.max_title_length"
+	MaxDescriptionLengthConfigKey        = "validation.max_description_length"
+	MaxDesignLengthConfigKey             = "validation.max_design_length"
+	MaxAcceptanceCriteriaLengthConfigKey = "validation.max_acceptance_criteria_length"
+	MaxNotesLengthConfigKey              = "validation.max_notes_length"
+	FieldLengthPolicyConfigKey           = "validation.field_length_policy"
+)
+
+// RejectBlankTitleConfigKey, when "true", rejects whitespace-only titles.
+// Empty titles are rejected regardless.
+const RejectBlankTitleConfigKey = "validation.reject_blank_title"
+
+// TypeStatusesConfigKey holds the statuses allowed per issue type, as a JSON
+// object mapping each restricted type to its statuses, e.g.
+// {"epic": ["open", "in_progress", "blocked"]}. Unlisted types may have any
+// status (see types.TypeStatuses). Writes made with a context marked by
+// storage.WithoutTypeStatusRules are not checked.
+const TypeStatusesConfigKey = "validation.type_statuses"
+
+// ParentTypesConfigKey holds the child issue types allowed per parent type in
+// parent-child dependencies, as a JSON object mapping each restricted parent
+// type to its child types, e.g. {"epic": ["feature", "task"], "task": []}.
+// Unlisted parent types may have children of any type (see types.ParentTypes).
+// Writes made with a context marked by storage.WithoutParentTypeRules are not
+// checked.
+const ParentTypesConfigKey = "validation.parent_types"
+
+// getFieldLimits reads field length limits and type-status rules from config,
+// falling back to types.DefaultFieldLimits for unset or malformed values.
+func getFieldLimits(ctx context.Context, db dbExecutor) types.FieldLimits {
+	limits := types.DefaultFieldLimits()
+
+	read := func(key string) string {
+		var value string
+		if err := db.QueryRowContext(ctx, `SELECT value FROM config WHERE key = ?`, key).Scan(&value); err != nil {
+			return ""
+		}
+		return value
+	}
+	for key, field := range map[string]*int{
+		MaxTitleLengthConfigKey:              &limits.Title,
+		MaxDescriptionLengthConfigKey:        &limits.Description,
+		MaxDesignLengthConfigKey:             &limits.Design,
+		MaxAcceptanceCriteriaLengthConfigKey: &limits.AcceptanceCriteria,
+		MaxNotesLengthConfigKey:              &limits.Notes,
+	} {
+		if n, err := strconv.Atoi(read(key)); err == nil && n >= 0 {
+			*field = n
+		}
+	}
+	if policy := types.FieldLengthPolicy(read(FieldLengthPolicyConfigKey)); policy != "" && policy.IsValid() {
+		limits.Policy = policy
+	}
+	if reject, err := strconv.ParseBool(read(RejectBlankTitleConfigKey)); err == nil {
+		limits.RejectBlankTitle = reject
+	}
+	if raw := read(TypeStatusesConfigKey); raw != "" && !storage.SkipsTypeStatusRules(ctx) {
+		var rules types.TypeStatuses
+		if err := json.Unmarshal([]byte(raw), &rules); err == nil {
+			limits.TypeStatuses = rules
+		}
+	}
+	return limits
+}
+
+// validateIssueWithLimits validates issue like ValidateWithCustom, using the
+// configured field limits, and then with custom (see SetIssueValidator), which
+// may be nil. Truncation warnings are written to stderr, and the content hash
+// is cleared so it is recomputed from the truncated content.
+func validateIssueWithLimits(issue *types.Issue, customStatuses, customTypes []string, limits types.FieldLimits, custom IssueValidator) error {
+	warnings, err := issue.ValidateWithLimits(customStatuses, customTypes, limits)
+	if err != nil {
+		return err
+	}
+	if custom != nil {
+		if err := custom(issue); err != nil {
+			return err
+		}
+	}
+	if len(warnings) > 0 {
+		issue.ContentHash = ""
+		label := "new issue"
+		if issue.ID != "" {
+			label = "issue " + issue.ID
+		}
+		for _, w := range warnings {
+			fmt.Fprintf(os.Stderr, "Warning: %s: %s\n", label, w)
+		}
+	}
+	return nil
+}
+
+// validateTitleUpdate applies RejectBlankTitleConfigKey to a title in updates.
+// Update values are otherwise checked field by field without config.
+func validateTitleUpdate(ctx context.Context, db dbExecutor, updates map[string]interface{}) error {
+	title, ok := updates["title"].(string)
+	if !ok || title == "" || strings.TrimSpace(title) != "" {
+		return nil
+	}
+	if getFieldLimits(ctx, db).RejectBlankTitle {
+		return fmt.Errorf("title must not be blank")
+	}
+	return nil
+}
+
+// validateTypeStatusUpdate applies TypeStatusesConfigKey to the status and
+// issue type oldIssue would have after updates.
+func validateTypeStatusUpdate(ctx context.Context, db dbExecutor, oldIssue *types.Issue, updates map[string]interface{}) error {
+	_, hasStatus := updates["status"]
+	_, hasType := updates["issue_type"]
+	if !hasStatus && !hasType {
+		return nil
+	}
+	rules := getFieldLimits(ctx, db).TypeStatuses
+	if rules == nil {
+		return nil
+	}
+	status, issueType := oldIssue.Status, oldIssue.IssueType
+	switch v := updates["status"].(type) {
+	case types.Status:
+		status = v
+	case string:
+		status = types.Status(v)
+	}
+	switch v := updates["issue_type"].(type) {
+	case types.IssueType:
+		issueType = v
+	case string:
+		issueType = types.IssueType(v)
+	}
+	return rules.Check(issueType, status)
+}
+
+// validateParentType applies ParentTypesConfigKey to a parent-child dependency
+// making child a child of parent.
+func validateParentType(ctx context.Context, db dbExecutor, child, parent *types.Issue) error {
+	if storage.SkipsParentTypeRules(ctx) {
+		return nil
+	}
+	var raw string
+	if err := db.QueryRowContext(ctx, `SELECT value FROM config WHERE key = ?`, ParentTypesConfigKey).Scan(&raw); err != nil || raw == "" {
+		return nil
+	}
+	var rules types.ParentTypes
+	if err := json.Unmarshal([]byte(raw), &rules); err != nil {
+		return nil
+	}
+	if err := rules.Check(parent.IssueType, child.IssueType); err != nil {
+		return fmt.Errorf("invalid parent-child dependency %s → %s: %w", child.ID, parent.ID, err)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package sqlite
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestCreateIssue_FieldLengthLimits(t *testing.T) {
+	env := newTestEnv(t)
+	if err := env.Store.SetConfig(env.Ctx, MaxDescriptionLengthConfigKey, "10"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+
+	long := &types.Issue{Title: "Long", Description: strings.Repeat("d", 20), Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	err := env.Store.CreateIssue(env.Ctx, long, "test-user")
+	if err == nil || !strings.Contains(err.Error(), "description") {
+		t.Fatalf("expected description length error, got %v", err)
+	}
+
+	if err := env.Store.SetConfig(env.Ctx, FieldLengthPolicyConfigKey, string(types.FieldLengthTruncate)); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	long.ContentHash = long.ComputeContentHash()
+	if err := env.Store.CreateIssue(env.Ctx, long, "test-user"); err != nil {
+		t.Fatalf("expected truncate policy to allow create: %v", err)
+	}
+
+	got, err := env.Store.GetIssue(env.Ctx, long.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Description != strings.Repeat("d", 10) {
+		t.Errorf("expected description truncated to 10 bytes, got %q", got.Description)
+	}
+	if got.ContentHash != got.ComputeContentHash() {
+		t.Error("expected content hash to match the truncated content")
+	}
+}
+
+func TestRejectBlankTitle(t *testing.T) {
+	env := newTestEnv(t)
+	issue := &types.Issue{Title: "  ", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := env.Store.CreateIssue(env.Ctx, issue, "test-user"); err != nil {
+		t.Fatalf("expected blank title to be accepted by default: %v", err)
+	}
+
+	if err := env.Store.SetConfig(env.Ctx, RejectBlankTitleConfigKey, "true"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	err := env.Store.CreateIssue(env.Ctx, &types.Issue{Title: " \t", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}, "test-user")
+	if err == nil || !strings.Contains(err.Error(), "title must not be blank") {
+		t.Errorf("expected blank title error on create, got %v", err)
+	}
+	err = env.Store.UpdateIssue(env.Ctx, issue.ID, map[string]interface{}{"title": "   "}, "test-user")
+	if err == nil || !strings.Contains(err.Error(), "title must not be blank") {
+		t.Errorf("expected blank title error on update, got %v", err)
+	}
+	if err := env.Store.UpdateIssue(env.Ctx, issue.ID, map[string]interface{}{"title": "Real title"}, "test-user"); err != nil {
+		t.Errorf("expected non-blank title update to pass: %v", err)
+	}
+}
+
+func TestCreateIssue_TypeStatuses(t *testing.T) {
+	env := newTestEnv(t)
+	if err := env.Store.SetConfig(env.Ctx, TypeStatusesConfigKey, `{"epic": ["open", "in_progress"]}`); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+
+	blocked := &types.Issue{Title: "Blocked epic", Status: types.StatusBlocked, Priority: 2, IssueType: types.TypeEpic}
+	err := env.Store.CreateIssue(env.Ctx, blocked, "test-user")
+	if err == nil || !strings.Contains(err.Error(), "not allowed for issue type epic") {
+		t.Fatalf("expected type-status error, got %v", err)
+	}
+
+	epic := &types.Issue{Title: "Epic", Status: types.StatusInProgress, Priority: 2, IssueType: types.TypeEpic}
+	if err := env.Store.CreateIssue(env.Ctx, epic, "test-user"); err != nil {
+		t.Fatalf("expected allowed status to create: %v", err)
+	}
+	err = env.Store.UpdateIssue(env.Ctx, epic.ID, map[string]interface{}{"status": string(types.StatusBlocked)}, "test-user")
+	if err == nil || !strings.Contains(err.Error(), "not allowed for issue type epic") {
+		t.Errorf("expected update to a disallowed status to fail, got %v", err)
+	}
+	task := env.CreateIssue("Task")
+	if err := env.Store.UpdateIssue(env.Ctx, task.ID, map[string]interface{}{"status": string(types.StatusBlocked)}, "test-user"); err != nil {
+		t.Errorf("expected unlisted type to allow any status: %v", err)
+	}
+
+	// Authoritative writes skip the rules
+	blocked.ContentHash = ""
+	if err := env.Store.CreateIssue(storage.WithoutTypeStatusRules(env.Ctx), blocked, "test-user"); err != nil {
+		t.Errorf("expected bypassed create to succeed: %v", err)
+	}
+}
+
+func TestAddDependency_ParentTypes(t *testing.T) {
+	env := newTestEnv(t)
+	if err := env.Store.SetConfig(env.Ctx, ParentTypesConfigKey, `{"epic": ["feature", "task"]}`); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	epic := env.CreateEpic("Epic")
+	task := env.CreateIssue("Task")
+	bug := env.CreateBug("Bug", 1)
+
+	// Compatible pairing
+	if err := env.Store.AddDependency(env.Ctx, &types.Dependency{IssueID: task.ID, DependsOnID: epic.ID, Type: types.DepParentChild}, "test-user"); err != nil {
+		t.Fatalf("expected task under epic to be allowed: %v", err)
+	}
+
+	// Incompatible pairing, in and out of a transaction
+	dep := &types.Dependency{IssueID: bug.ID, DependsOnID: epic.ID, Type: types.DepParentChild}
+	err := env.Store.AddDependency(env.Ctx, dep, "test-user")
+	if err == nil || !strings.Contains(err.Error(), "issue type bug cannot be a child of issue type epic") {
+		t.Fatalf("expected parent type error, got %v", err)
+	}
+	err = env.Store.RunInTransaction(env.Ctx, func(tx storage.Transaction) error {
+		return tx.AddDependency(env.Ctx, dep, "test-user")
+	})
+	if err == nil || !strings.Contains(err.Error(), "issue type bug cannot be a child of issue type epic") {
+		t.Fatalf("expected parent type error in transaction, got %v", err)
+	}
+
+	// Other dependency types are unrestricted
+	if err := env.Store.AddDependency(env.Ctx, &types.Dependency{IssueID: bug.ID, DependsOnID: epic.ID, Type: types.DepBlocks}, "test-user"); err != nil {
+		t.Errorf("expected blocks dependency to be allowed: %v", err)
+	}
+
+	// Authoritative writes skip the rules
+	if err := env.Store.AddDependency(storage.WithoutParentTypeRules(env.Ctx), dep, "test-user"); err != nil {
+		t.Errorf("expected bypassed dependency to succeed: %v", err)
+	}
+}
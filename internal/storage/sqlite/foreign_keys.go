@@ -0,0 +1,133 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	sqlite3 "github.com/ncruces/go-sqlite3"
+)
+
+// Foreign key enforcement modes stored in SQLiteStorage.foreignKeys.
+const (
+	foreignKeysDefault int32 = iota // Leave the connection's setting alone
+	foreignKeysOn
+	foreignKeysOff
+)
+
+// ForeignKeyViolation describes a row whose foreign key points at a missing
+// parent row, as reported by PRAGMA foreign_key_check.
+type ForeignKeyViolation struct {
+	Table       string // Table containing the dangling reference
+	RowID       int64  // rowid of the offending row (0 for WITHOUT ROWID tables)
+	ParentTable string // Table the reference should point into
+}
+
+// SetForeignKeyEnforcement sets PRAGMA foreign_keys on every connection the
+// store uses, overriding the connection string's default. The connection pool
+// is reopened so that connections already open pick the setting up too; an
+// in-memory database keeps its single connection, which is updated in place.
+//
+// New enables foreign keys in its connection string, but databases opened with
+// a custom URI may not enforce them. Enabling enforcement makes dangling issue
+// references fail the write instead of being stored silently.
+func (s *SQLiteStorage) SetForeignKeyEnforcement(enabled bool) error {
+	mode := foreignKeysOff
+	if enabled {
+		mode = foreignKeysOn
+	}
+	s.foreignKeys.Store(mode)
+
+	if s.isInMemory() {
+		s.reconnectMu.RLock()
+		defer s.reconnectMu.RUnlock()
+		_, err := s.db.Exec(foreignKeysPragma(mode))
+		return wrapDBError("set foreign key enforcement", err)
+	}
+	if err := s.reconnect(); err != nil {
+		return fmt.Errorf("failed to apply foreign key enforcement: %w", err)
+	}
+	return nil
+}
+
+// ForeignKeysEnabled reports whether a freshly acquired connection enforces
+// foreign keys.
+func (s *SQLiteStorage) ForeignKeysEnabled(ctx context.Context) (bool, error) {
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	var enabled bool
+	if err := s.db.QueryRowContext(ctx, `PRAGMA foreign_keys`).Scan(&enabled); err != nil {
+		return false, fmt.Errorf("failed to read foreign_keys pragma: %w", err)
+	}
+	return enabled, nil
+}
+
+// CheckForeignKeys returns every foreign key violation in the database.
+// It works regardless of whether enforcement is enabled.
+func (s *SQLiteStorage) CheckForeignKeys(ctx context.Context) ([]ForeignKeyViolation, error) {
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	rows, err := s.db.QueryContext(ctx, `PRAGMA foreign_key_check`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check foreign keys: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var violations []ForeignKeyViolation
+	for rows.Next() {
+		var v ForeignKeyViolation
+		var rowID sql.NullInt64
+		var fkid int
+		if err := rows.Scan(&v.Table, &rowID, &v.ParentTable, &fkid); err != nil {
+			return nil, fmt.Errorf("failed to scan foreign key violation: %w", err)
+		}
+		v.RowID = rowID.Int64
+		violations = append(violations, v)
+	}
+	return violations, rows.Err()
+}
+
+// foreignKeysPragma returns the statement that applies mode, or "" for
+// foreignKeysDefault.
+func foreignKeysPragma(mode int32) string {
+	switch mode {
+	case foreignKeysOn:
+		return `PRAGMA foreign_keys = ON`
+	case foreignKeysOff:
+		return `PRAGMA foreign_keys = OFF`
+	default:
+		return ""
+	}
+}
+
+// applyForeignKeyMode applies mode to a newly opened connection.
+func applyForeignKeyMode(conn *sqlite3.Conn, mode int32) error {
+	pragma := foreignKeysPragma(mode)
+	if pragma == "" {
+		return nil
+	}
+	if err := conn.Exec(pragma); err != nil {
+		return fmt.Errorf("failed to set foreign key enforcement: %w", err)
+	}
+	return nil
+}
+
+// disableForeignKeys turns foreign key enforcement off on conn, which must not
+// be in a transaction, and returns a function that restores the setting conn
+// had before.
+func disableForeignKeys(ctx context.Context, conn *sql.Conn) (func(), error) {
+	var enabled bool
+	if err := conn.QueryRowContext(ctx, `PRAGMA foreign_keys`).Scan(&enabled); err != nil {
+		return nil, fmt.Errorf("failed to read foreign_keys pragma: %w", err)
+	}
+	if _, err := conn.ExecContext(ctx, `PRAGMA foreign_keys = OFF`); err != nil {
+		return nil, fmt.Errorf("failed to disable foreign keys: %w", err)
+	}
+	return func() {
+		if enabled {
+			_, _ = conn.ExecContext(context.Background(), `PRAGMA foreign_keys = ON`)
+		}
+	}, nil
+}
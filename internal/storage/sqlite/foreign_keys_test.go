@@ -0,0 +1,95 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+func TestSetForeignKeyEnforcement(t *testing.T) {
+	env := newTestEnv(t)
+	target := env.CreateIssue("Target")
+
+	insertDangling := func() error {
+		return env.Store.RunInTransaction(env.Ctx, func(tx storage.Transaction) error {
+			_, err := tx.(*sqliteTxStorage).conn.ExecContext(env.Ctx, `
+				INSERT INTO dependencies (issue_id, depends_on_id, type, created_by)
+				VALUES ('bd-missing', ?, 'blocks', 'test')
+			`, target.ID)
+			return err
+		})
+	}
+
+	// Enforcement off: the dangling edge is stored and only found by a check
+	if err := env.Store.SetForeignKeyEnforcement(false); err != nil {
+		t.Fatalf("SetForeignKeyEnforcement failed: %v", err)
+	}
+	checkConns(t, env, false)
+	if err := insertDangling(); err != nil {
+		t.Fatalf("insert without enforcement failed: %v", err)
+	}
+	violations, err := env.Store.CheckForeignKeys(env.Ctx)
+	if err != nil {
+		t.Fatalf("CheckForeignKeys failed: %v", err)
+	}
+	if len(violations) != 1 || violations[0].Table != "dependencies" || violations[0].ParentTable != "issues" {
+		t.Fatalf("expected one dependencies -> issues violation, got %+v", violations)
+	}
+	if _, err := env.Store.db.ExecContext(env.Ctx, `DELETE FROM dependencies WHERE issue_id = 'bd-missing'`); err != nil {
+		t.Fatalf("cleanup failed: %v", err)
+	}
+
+	// Enforcement on: the same write is rejected
+	if err := env.Store.SetForeignKeyEnforcement(true); err != nil {
+		t.Fatalf("SetForeignKeyEnforcement failed: %v", err)
+	}
+	checkConns(t, env, true)
+	err = insertDangling()
+	if !IsForeignKeyConstraintError(err) {
+		t.Fatalf("expected foreign key constraint error, got %v", err)
+	}
+}
+
+func TestUpdateIssueID_RestoresForeignKeySetting(t *testing.T) {
+	env := newTestEnv(t)
+	if err := env.Store.SetForeignKeyEnforcement(false); err != nil {
+		t.Fatalf("SetForeignKeyEnforcement failed: %v", err)
+	}
+	issue := env.CreateIssue("Renamed")
+	if err := env.Store.UpdateIssueID(env.Ctx, issue.ID, "bd-renamed", issue, "test"); err != nil {
+		t.Fatalf("UpdateIssueID failed: %v", err)
+	}
+	// The connection UpdateIssueID used must not come back with enforcement on
+	checkConns(t, env, false)
+}
+
+// checkConns holds two pooled connections at once and checks that each
+// reports the foreign key setting want.
+func checkConns(t *testing.T, env *testEnv, want bool) {
+	t.Helper()
+	if enabled, err := env.Store.ForeignKeysEnabled(env.Ctx); err != nil || enabled != want {
+		t.Fatalf("ForeignKeysEnabled = %v (err=%v), want %v", enabled, err, want)
+	}
+	var conns []*sql.Conn
+	defer func() {
+		for _, conn := range conns {
+			_ = conn.Close()
+		}
+	}()
+	for i := 0; i < 2; i++ { // the smallest pool a file database gets
+		conn, err := env.Store.db.Conn(context.Background())
+		if err != nil {
+			t.Fatalf("Conn failed: %v", err)
+		}
+		conns = append(conns, conn)
+		var enabled bool
+		if err := conn.QueryRowContext(env.Ctx, `PRAGMA foreign_keys`).Scan(&enabled); err != nil {
+			t.Fatalf("PRAGMA foreign_keys failed: %v", err)
+		}
+		if enabled != want {
+			t.Errorf("connection %d: foreign_keys = %v, want %v", i, enabled, want)
+		}
+	}
+}
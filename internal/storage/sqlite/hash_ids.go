@@ -73,6 +73,24 @@ func (s *SQLiteStorage) GetNextChildID(ctx context.Context, parentID string) (st
 	return childID, nil
 }
 
+// GetIDCounter returns the child number GetNextChildID would use next under
+// prefix, without consuming it. Top-level IDs are hash-based and have no
+// counter, so prefix is the parent ID that hierarchical children are numbered
+// under (e.g. "bd-a3f8e9" for bd-a3f8e9.1, bd-a3f8e9.2, ...). It reads the
+// same child_counters row GetNextChildID increments, so it returns 1 for a
+// parent with no numbered children yet.
+func (s *SQLiteStorage) GetIDCounter(ctx context.Context, prefix string) (int, error) {
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	var lastChild int
+	err := s.db.QueryRowContext(ctx, `SELECT last_child FROM child_counters WHERE parent_id = ?`, prefix).Scan(&lastChild)
+	if err != nil && err != sql.ErrNoRows {
+		return 0, fmt.Errorf("failed to read child counter for parent %s: %w", prefix, err)
+	}
+	return lastChild + 1, nil
+}
+
 // ensureChildCounterUpdated ensures the child_counters table has a value for parentID
 // that is at least childNum. This prevents ID collisions when children are created
 // with explicit IDs (via --id flag or import) rather than GetNextChildID.
@@ -0,0 +1,60 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ErrHashSaltImmutable is returned (wrapped) when a config change would alter
+// the hash salt of a database that already has one or already has issues.
+var ErrHashSaltImmutable = errors.New("hash salt is immutable")
+
+// getHashSalt returns the configured hash salt, or "" if unset.
+func getHashSalt(ctx context.Context, db dbExecutor) string {
+	var salt string
+	if err := db.QueryRowContext(ctx, `SELECT value FROM config WHERE key = ?`, storage.HashSaltConfigKey).Scan(&salt); err != nil {
+		return ""
+	}
+	return salt
+}
+
+// getHashOptions returns the content hash settings configured for the
+// database: its salt and whether display metadata is hashed.
+func getHashOptions(ctx context.Context, db dbExecutor) types.ContentHashOptions {
+	var display string
+	_ = db.QueryRowContext(ctx, `SELECT value FROM config WHERE key = ?`, storage.HashDisplayFieldsConfigKey).Scan(&display)
+	return types.ContentHashOptions{Salt: getHashSalt(ctx, db), DisplayFields: display == "true"}
+}
+
+// contentHash computes issue's content hash under the configured settings.
+func contentHash(ctx context.Context, db dbExecutor, issue *types.Issue) string {
+	return issue.ComputeContentHashWith(getHashOptions(ctx, db))
+}
+
+// checkHashSaltConfig rejects setting the hash salt to value (or deleting it,
+// for deleting) unless that leaves it as it is or the database is still empty
+// and unsalted.
+func checkHashSaltConfig(ctx context.Context, db dbExecutor, key, value string, deleting bool) error {
+	if key != storage.HashSaltConfigKey {
+		return nil
+	}
+	current := getHashSalt(ctx, db)
+	if deleting && current == "" || !deleting && value == current {
+		return nil
+	}
+	if current != "" {
+		return fmt.Errorf("%w: the database is already salted", ErrHashSaltImmutable)
+	}
+	var hasIssues bool
+	if err := db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM issues)`).Scan(&hasIssues); err != nil {
+		return wrapDBError("check for issues", err)
+	}
+	if hasIssues {
+		return fmt.Errorf("%w: set it when initializing the database, before any issues exist", ErrHashSaltImmutable)
+	}
+	return nil
+}
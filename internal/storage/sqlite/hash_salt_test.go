@@ -0,0 +1,85 @@
+package sqlite
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+func TestHashSalt(t *testing.T) {
+	t.Run("hashes mix in the salt", func(t *testing.T) {
+		env := newTestEnv(t)
+		if err := env.Store.SetConfig(env.Ctx, storage.HashSaltConfigKey, "salt-a"); err != nil {
+			t.Fatalf("SetConfig failed: %v", err)
+		}
+		issue := env.CreateIssue("Salted")
+		stored, err := env.Store.GetIssue(env.Ctx, issue.ID)
+		if err != nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		if want := stored.ComputeSaltedContentHash("salt-a"); stored.ContentHash != want {
+			t.Errorf("content_hash = %s, want salted %s", stored.ContentHash, want)
+		}
+
+		if err := env.Store.UpdateIssue(env.Ctx, issue.ID, map[string]interface{}{"title": "Renamed"}, "test-user"); err != nil {
+			t.Fatalf("UpdateIssue failed: %v", err)
+		}
+		if stored, err = env.Store.GetIssue(env.Ctx, issue.ID); err != nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		if want := stored.ComputeSaltedContentHash("salt-a"); stored.ContentHash != want {
+			t.Errorf("content_hash after update = %s, want salted %s", stored.ContentHash, want)
+		}
+	})
+
+	t.Run("salt is immutable", func(t *testing.T) {
+		env := newTestEnv(t)
+		if err := env.Store.SetConfig(env.Ctx, storage.HashSaltConfigKey, "salt-a"); err != nil {
+			t.Fatalf("SetConfig failed: %v", err)
+		}
+		if err := env.Store.SetConfig(env.Ctx, storage.HashSaltConfigKey, "salt-a"); err != nil {
+			t.Errorf("re-setting the same salt should succeed: %v", err)
+		}
+		if err := env.Store.SetConfig(env.Ctx, storage.HashSaltConfigKey, "salt-b"); !errors.Is(err, ErrHashSaltImmutable) {
+			t.Errorf("changing the salt: got %v, want ErrHashSaltImmutable", err)
+		}
+		if err := env.Store.DeleteConfig(env.Ctx, storage.HashSaltConfigKey); !errors.Is(err, ErrHashSaltImmutable) {
+			t.Errorf("deleting the salt: got %v, want ErrHashSaltImmutable", err)
+		}
+		if salt, _ := env.Store.GetConfig(env.Ctx, storage.HashSaltConfigKey); salt != "salt-a" {
+			t.Errorf("salt = %q, want salt-a", salt)
+		}
+	})
+
+	t.Run("salt can only be set before issues exist", func(t *testing.T) {
+		env := newTestEnv(t)
+		env.CreateIssue("Unsalted")
+		if err := env.Store.SetConfig(env.Ctx, storage.HashSaltConfigKey, "salt-a"); !errors.Is(err, ErrHashSaltImmutable) {
+			t.Errorf("salting a populated database: got %v, want ErrHashSaltImmutable", err)
+		}
+	})
+
+	t.Run("differently salted databases do not reconcile", func(t *testing.T) {
+		local := newTestEnv(t)
+		other := newTestEnv(t)
+		for env, salt := range map[*testEnv]string{local: "salt-a", other: "salt-b"} {
+			if err := env.Store.SetConfig(env.Ctx, storage.HashSaltConfigKey, salt); err != nil {
+				t.Fatalf("SetConfig failed: %v", err)
+			}
+			env.CreateIssueWithID("bd-1", "Same content")
+		}
+
+		report, err := local.Store.Reconcile(local.Ctx, other.Store)
+		if err != nil {
+			t.Fatalf("Reconcile failed: %v", err)
+		}
+		if want := []string{"bd-1"}; !reflect.DeepEqual(report.HashMismatch, want) {
+			t.Errorf("HashMismatch = %v, want %v", report.HashMismatch, want)
+		}
+		if report.InSync() {
+			t.Error("differently salted databases should not be in sync")
+		}
+	})
+}
@@ -0,0 +1,130 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ExportWithHistory writes every issue to w as NDJSON like StreamExport, with
+// each issue line followed by one types.EventRecord line per event in the
+// issue's history, oldest first. The import side (importer.ParseHistory and
+// Options.Events) replays the records so audit trails survive a migration.
+func (s *SQLiteStorage) ExportWithHistory(ctx context.Context, w io.Writer) error {
+	return s.streamExport(ctx, w, types.IssueFilter{}, nil, func(enc *json.Encoder, issue *types.Issue) error {
+		events, err := s.getEventHistory(ctx, issue.ID)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			if err := enc.Encode(types.EventRecord{Event: event}); err != nil {
+				return fmt.Errorf("failed to write event for %s: %w", issue.ID, err)
+			}
+		}
+		return nil
+	})
+}
+
+// getEventHistory returns the events of issueID in the order they happened.
+func (s *SQLiteStorage) getEventHistory(ctx context.Context, issueID string) ([]*types.Event, error) {
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, issue_id, event_type, actor, old_value, new_value, comment, created_at
+		FROM events
+		WHERE issue_id = ?
+		ORDER BY created_at, id
+	`, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event history: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var events []*types.Event
+	for rows.Next() {
+		var event types.Event
+		var oldValue, newValue, comment sql.NullString
+		if err := rows.Scan(
+			&event.ID, &event.IssueID, &event.EventType, &event.Actor,
+			&oldValue, &newValue, &comment, &event.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+		if oldValue.Valid {
+			event.OldValue = &oldValue.String
+		}
+		if newValue.Valid {
+			event.NewValue = &newValue.String
+		}
+		if comment.Valid {
+			event.Comment = &comment.String
+		}
+		events = append(events, &event)
+	}
+	return events, rows.Err()
+}
+
+// ImportEvents records events on issueID, keeping their type, actor, values
+// and timestamps. With replace, the issue's existing events (such as the single
+// "created" event synthesized when an import creates it) are deleted first;
+// otherwise events already recorded identically are skipped, so re-importing
+// the same history is idempotent.
+func (s *SQLiteStorage) ImportEvents(ctx context.Context, issueID string, events []*types.Event, replace bool) error {
+	return s.withTx(ctx, func(conn *sql.Conn) error {
+		return importEvents(ctx, conn, issueID, events, replace)
+	})
+}
+
+// ImportEvents records events on issueID within the transaction.
+func (t *sqliteTxStorage) ImportEvents(ctx context.Context, issueID string, events []*types.Event, replace bool) error {
+	return importEvents(ctx, t.conn, issueID, events, replace)
+}
+
+// SetCreatedEventTime dates issueID's creation event at at.
+func (s *SQLiteStorage) SetCreatedEventTime(ctx context.Context, issueID string, at time.Time) error {
+	return setCreatedEventTime(ctx, s.db, issueID, at)
+}
+
+// SetCreatedEventTime dates issueID's creation event at at within the
+// transaction.
+func (t *sqliteTxStorage) SetCreatedEventTime(ctx context.Context, issueID string, at time.Time) error {
+	return setCreatedEventTime(ctx, t.conn, issueID, at)
+}
+
+func setCreatedEventTime(ctx context.Context, db dbExecutor, issueID string, at time.Time) error {
+	if _, err := db.ExecContext(ctx, `
+		UPDATE events SET created_at = ? WHERE issue_id = ? AND event_type = ?
+	`, at, issueID, types.EventCreated); err != nil {
+		return fmt.Errorf("failed to date creation event for %s: %w", issueID, err)
+	}
+	return nil
+}
+
+func importEvents(ctx context.Context, db dbExecutor, issueID string, events []*types.Event, replace bool) error {
+	if replace {
+		if _, err := db.ExecContext(ctx, `DELETE FROM events WHERE issue_id = ?`, issueID); err != nil {
+			return fmt.Errorf("failed to clear events for %s: %w", issueID, err)
+		}
+	}
+	for _, event := range events {
+		if _, err := db.ExecContext(ctx, `
+			INSERT INTO events (issue_id, event_type, actor, old_value, new_value, comment, created_at)
+			SELECT ?, ?, ?, ?, ?, ?, ?
+			WHERE NOT EXISTS (
+				SELECT 1 FROM events
+				WHERE issue_id = ? AND event_type = ? AND actor = ? AND created_at = ?
+				  AND old_value IS ? AND new_value IS ? AND comment IS ?
+			)
+		`, issueID, event.EventType, event.Actor, event.OldValue, event.NewValue, event.Comment, event.CreatedAt,
+			issueID, event.EventType, event.Actor, event.CreatedAt, event.OldValue, event.NewValue, event.Comment); err != nil {
+			return fmt.Errorf("failed to import %s event for %s: %w", event.EventType, issueID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// IDCaseInsensitiveConfigKey, when "true", makes issue IDs case-insensitive:
+// "bd-A1" and "bd-a1" name the same issue. The casing an issue was created
+// with stays canonical; lookups in another casing resolve to it, and creating
+// a case variant of an existing ID fails. Set it with SetIDCaseInsensitive,
+// which also installs the constraint.
+const IDCaseInsensitiveConfigKey = "id.case_insensitive"
+
+// idNocaseIndex enforces case-insensitive uniqueness of issue IDs.
+const idNocaseIndex = "idx_issues_id_nocase"
+
+// SetIDCaseInsensitive switches case-insensitive issue IDs on or off. Turning
+// it on is the migration path for existing databases: it fails with an
+// ErrConflict error listing the offending IDs if any issues differ only by
+// case (rename or delete them first), and otherwise adds a unique
+// COLLATE NOCASE index over the ID column. Turning it off drops the index.
+func (s *SQLiteStorage) SetIDCaseInsensitive(ctx context.Context, enabled bool) error {
+	return s.withTx(ctx, func(conn *sql.Conn) error {
+		setEnabled := func(value string) error {
+			_, err := conn.ExecContext(ctx, `
+				INSERT INTO config (key, value) VALUES (?, ?)
+				ON CONFLICT (key) DO UPDATE SET value = excluded.value
+			`, IDCaseInsensitiveConfigKey, value)
+			return wrapDBError("set config", err)
+		}
+		if !enabled {
+			if _, err := conn.ExecContext(ctx, `DROP INDEX IF EXISTS `+idNocaseIndex); err != nil {
+				return fmt.Errorf("failed to drop %s: %w", idNocaseIndex, err)
+			}
+			return setEnabled("false")
+		}
+
+		rows, err := conn.QueryContext(ctx, `
+			SELECT group_concat(id, ', ') FROM issues
+			GROUP BY id COLLATE NOCASE
+			HAVING COUNT(*) > 1
+			ORDER BY id COLLATE NOCASE
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to find case-variant IDs: %w", err)
+		}
+		var variants []string
+		for rows.Next() {
+			var ids string
+			if err := rows.Scan(&ids); err != nil {
+				_ = rows.Close()
+				return fmt.Errorf("failed to scan case-variant IDs: %w", err)
+			}
+			variants = append(variants, "["+ids+"]")
+		}
+		_ = rows.Close()
+		if err := rows.Err(); err != nil {
+			return wrapDBError("iterate case-variant IDs", err)
+		}
+		if len(variants) > 0 {
+			return fmt.Errorf("%w: issue IDs differ only by case: %s", ErrConflict, strings.Join(variants, " "))
+		}
+
+		if _, err := conn.ExecContext(ctx, `CREATE UNIQUE INDEX IF NOT EXISTS `+idNocaseIndex+` ON issues(id COLLATE NOCASE)`); err != nil {
+			return fmt.Errorf("failed to create %s: %w", idNocaseIndex, err)
+		}
+		return setEnabled("true")
+	})
+}
+
+// caseVariantID returns the stored ID matching id case-insensitively when
+// case-insensitive IDs are enabled, or "" otherwise. Callers use it after an
+// exact lookup misses, so the common path costs nothing extra.
+func caseVariantID(ctx context.Context, db dbExecutor, id string) string {
+	var enabled string
+	if err := db.QueryRowContext(ctx, `SELECT value FROM config WHERE key = ?`, IDCaseInsensitiveConfigKey).Scan(&enabled); err != nil || enabled != "true" {
+		return ""
+	}
+	var canonical string
+	if err := db.QueryRowContext(ctx, `SELECT id FROM issues WHERE id = ? COLLATE NOCASE`, id).Scan(&canonical); err != nil || canonical == id {
+		return ""
+	}
+	return canonical
+}
+
+// canonicalIssueID returns the stored casing of id when it is a case variant
+// of an existing issue, and id unchanged otherwise. Writers resolve IDs with it
+// so rows and events are keyed by the canonical ID.
+func canonicalIssueID(ctx context.Context, db dbExecutor, id string) string {
+	if canonical := caseVariantID(ctx, db, id); canonical != "" {
+		return canonical
+	}
+	return id
+}
@@ -0,0 +1,124 @@
+package sqlite
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestCaseInsensitiveIDs(t *testing.T) {
+	env := newTestEnv(t)
+	create := func(id string) error {
+		return env.Store.CreateIssue(env.Ctx, &types.Issue{ID: id, Title: "Issue " + id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}, "test-user")
+	}
+
+	// Case-sensitive by default: variants are distinct issues.
+	if err := create("bd-abc"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := create("bd-ABC"); err != nil {
+		t.Fatalf("expected case variant allowed by default, got %v", err)
+	}
+	if issue, err := env.Store.GetIssue(env.Ctx, "bd-Abc"); err != nil || issue != nil {
+		t.Fatalf("expected no case-insensitive lookup by default, got %v, %v", issue, err)
+	}
+
+	// Existing variants block the migration.
+	err := env.Store.SetIDCaseInsensitive(env.Ctx, true)
+	if !errors.Is(err, ErrConflict) || !strings.Contains(err.Error(), "bd-ABC") {
+		t.Fatalf("expected ErrConflict naming the variants, got %v", err)
+	}
+	if err := env.Store.DeleteIssue(env.Ctx, "bd-ABC"); err != nil {
+		t.Fatalf("DeleteIssue failed: %v", err)
+	}
+	if err := env.Store.SetIDCaseInsensitive(env.Ctx, true); err != nil {
+		t.Fatalf("SetIDCaseInsensitive failed: %v", err)
+	}
+
+	if err := create("bd-ABC"); err == nil {
+		t.Error("expected a case variant of an existing ID to collide")
+	}
+	for _, id := range []string{"bd-ABC", "BD-abc"} {
+		issue, err := env.Store.GetIssue(env.Ctx, id)
+		if err != nil || issue == nil || issue.ID != "bd-abc" {
+			t.Errorf("GetIssue(%q): expected canonical bd-abc, got %v, %v", id, issue, err)
+		}
+	}
+	err = env.Store.RunInTransaction(env.Ctx, func(tx storage.Transaction) error {
+		issue, err := tx.GetIssue(env.Ctx, "BD-ABC")
+		if err != nil || issue == nil || issue.ID != "bd-abc" {
+			t.Errorf("tx GetIssue: expected canonical bd-abc, got %v, %v", issue, err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("RunInTransaction failed: %v", err)
+	}
+
+	// Turning it off restores case-sensitive IDs.
+	if err := env.Store.SetIDCaseInsensitive(env.Ctx, false); err != nil {
+		t.Fatalf("SetIDCaseInsensitive(false) failed: %v", err)
+	}
+	if err := create("bd-ABC"); err != nil {
+		t.Errorf("expected case variant allowed after disabling, got %v", err)
+	}
+}
+
+func TestCaseInsensitiveIDWrites(t *testing.T) {
+	env := newTestEnv(t)
+	if err := env.Store.SetIDCaseInsensitive(env.Ctx, true); err != nil {
+		t.Fatalf("SetIDCaseInsensitive failed: %v", err)
+	}
+	for _, id := range []string{"bd-abc", "bd-def"} {
+		if err := env.Store.CreateIssue(env.Ctx, &types.Issue{ID: id, Title: "Issue " + id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}, "test-user"); err != nil {
+			t.Fatalf("CreateIssue(%s) failed: %v", id, err)
+		}
+	}
+
+	if err := env.Store.UpdateIssue(env.Ctx, "BD-ABC", map[string]interface{}{"title": "Renamed"}, "test-user"); err != nil {
+		t.Fatalf("UpdateIssue through variant failed: %v", err)
+	}
+	if err := env.Store.AddLabel(env.Ctx, "Bd-Abc", "storage", "test-user"); err != nil {
+		t.Fatalf("AddLabel through variant failed: %v", err)
+	}
+	if err := env.Store.CloseIssue(env.Ctx, "bd-ABC", "done", "test-user", ""); err != nil {
+		t.Fatalf("CloseIssue through variant failed: %v", err)
+	}
+	err := env.Store.RunInTransaction(env.Ctx, func(tx storage.Transaction) error {
+		if err := tx.UpdateIssue(env.Ctx, "BD-DEF", map[string]interface{}{"title": "Renamed in tx"}, "test-user"); err != nil {
+			return err
+		}
+		return tx.CloseIssue(env.Ctx, "bd-DEF", "done", "test-user", "")
+	})
+	if err != nil {
+		t.Fatalf("RunInTransaction through variants failed: %v", err)
+	}
+
+	for id, title := range map[string]string{"bd-abc": "Renamed", "bd-def": "Renamed in tx"} {
+		issue, err := env.Store.GetIssue(env.Ctx, id)
+		if err != nil || issue == nil {
+			t.Fatalf("GetIssue(%s) failed: %v", id, err)
+		}
+		if issue.Title != title || issue.Status != types.StatusClosed {
+			t.Errorf("%s = %q (%s), want %q closed", id, issue.Title, issue.Status, title)
+		}
+		events, err := env.Store.GetEvents(env.Ctx, id, 0)
+		if err != nil {
+			t.Fatalf("GetEvents(%s) failed: %v", id, err)
+		}
+		var updated, closed bool
+		for _, event := range events {
+			updated = updated || event.EventType == types.EventUpdated
+			closed = closed || event.EventType == types.EventClosed
+		}
+		if !updated || !closed {
+			t.Errorf("%s events = %+v, want update and close recorded under the canonical ID", id, events)
+		}
+	}
+	if labels, err := env.Store.GetLabels(env.Ctx, "bd-abc"); err != nil || len(labels) != 1 || labels[0] != "storage" {
+		t.Errorf("labels = %v, %v; want [storage]", labels, err)
+	}
+}
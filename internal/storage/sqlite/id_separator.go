@@ -0,0 +1,112 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// getIDSeparator returns the configured ID separator, or "-" if unset.
+func getIDSeparator(ctx context.Context, db dbExecutor) string {
+	var sep string
+	err := db.QueryRowContext(ctx, `SELECT value FROM config WHERE key = ?`, storage.IDSeparatorConfigKey).Scan(&sep)
+	if err != nil || sep == "" {
+		return utils.DefaultIDSeparator
+	}
+	return sep
+}
+
+// validateIDSeparator checks that sep can't be confused with the rest of an ID.
+// Letters and digits would run into the hash, and "." marks hierarchical children.
+func validateIDSeparator(sep string) error {
+	if sep == "" {
+		return fmt.Errorf("ID separator cannot be empty")
+	}
+	for _, c := range sep {
+		if unicode.IsLetter(c) || unicode.IsDigit(c) || unicode.IsSpace(c) || c == '.' {
+			return fmt.Errorf("invalid ID separator %q: must not contain letters, digits, whitespace, or '.'", sep)
+		}
+	}
+	return nil
+}
+
+// validatePrefixSeparator rejects a prefix that contains a non-default
+// separator, since the prefix/hash boundary would be ambiguous. Hyphenated
+// prefixes are still accepted with the default "-" for backward compatibility.
+func validatePrefixSeparator(prefix, sep string) error {
+	if sep == utils.DefaultIDSeparator {
+		return nil
+	}
+	if strings.Contains(prefix, sep) {
+		return fmt.Errorf("prefix '%s' contains the ID separator '%s'", prefix, sep)
+	}
+	return nil
+}
+
+// validateIDPrefix rejects an issue's IDPrefix that contains the ID separator,
+// including the default "-": the configured prefix, IDPrefix and hash are
+// joined with it ("bd-wisp-a3f8e9"), so a separator inside IDPrefix would make
+// the composed ID parse with the wrong prefix.
+func validateIDPrefix(idPrefix, sep string) error {
+	if strings.Contains(idPrefix, sep) {
+		return fmt.Errorf("ID prefix '%s' contains the ID separator '%s'", idPrefix, sep)
+	}
+	return nil
+}
+
+// checkConfiguredPrefix validates the issue_prefix read from config before it
+// is used to compose or validate IDs, so a hand-edited or corrupt value fails
+// up front instead of as a confusing ID validation error later.
+func checkConfiguredPrefix(ctx context.Context, db dbExecutor, prefix string) error {
+	if err := validateConfiguredPrefix(prefix, getIDSeparator(ctx, db)); err != nil {
+		return fmt.Errorf("configured issue_prefix is invalid: %w (run 'bd config set issue_prefix <prefix>' to fix)", err)
+	}
+	return nil
+}
+
+// validateConfiguredPrefix rejects prefixes that can't form a parseable ID.
+// It is deliberately lenient (dots, underscores and case are allowed, as
+// existing databases use them) and only catches values that are malformed.
+func validateConfiguredPrefix(prefix, sep string) error {
+	if !utf8.ValidString(prefix) {
+		return fmt.Errorf("%q is not valid UTF-8", prefix)
+	}
+	hasAlnum := false
+	for _, c := range prefix {
+		if unicode.IsSpace(c) || unicode.IsControl(c) {
+			return fmt.Errorf("%q contains whitespace or control characters", prefix)
+		}
+		if unicode.IsLetter(c) || unicode.IsDigit(c) {
+			hasAlnum = true
+		}
+	}
+	if !hasAlnum {
+		return fmt.Errorf("%q has no letters or digits", prefix)
+	}
+	return validatePrefixSeparator(prefix, sep)
+}
+
+// checkPrefixConfig validates a config change that affects ID composition:
+// the new separator against the current prefix, or the new prefix against
+// the current separator.
+func checkPrefixConfig(ctx context.Context, db dbExecutor, key, value string) error {
+	switch key {
+	case storage.IDSeparatorConfigKey:
+		if err := validateIDSeparator(value); err != nil {
+			return err
+		}
+		var prefix string
+		err := db.QueryRowContext(ctx, `SELECT value FROM config WHERE key = ?`, "issue_prefix").Scan(&prefix)
+		if err == nil {
+			return validatePrefixSeparator(prefix, value)
+		}
+	case "issue_prefix":
+		return validatePrefixSeparator(value, getIDSeparator(ctx, db))
+	}
+	return nil
+}
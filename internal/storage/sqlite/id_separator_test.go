@@ -0,0 +1,123 @@
+package sqlite
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestIDSeparator_NonDefault(t *testing.T) {
+	env := newTestEnv(t)
+	if err := env.Store.SetConfig(env.Ctx, storage.IDSeparatorConfigKey, "_"); err != nil {
+		t.Fatalf("SetConfig(%s) failed: %v", storage.IDSeparatorConfigKey, err)
+	}
+	if err := env.Store.SetConfig(env.Ctx, "issue_prefix", "web-app"); err != nil {
+		t.Fatalf("SetConfig(issue_prefix) failed: %v", err)
+	}
+
+	issue := env.CreateIssue("Generated")
+	if !strings.HasPrefix(issue.ID, "web-app_") {
+		t.Errorf("expected generated ID to start with web-app_, got %s", issue.ID)
+	}
+
+	sub := &types.Issue{Title: "Wisp", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, IDPrefix: "wisp"}
+	if err := env.Store.CreateIssue(env.Ctx, sub, "test-user"); err != nil {
+		t.Fatalf("CreateIssue with IDPrefix failed: %v", err)
+	}
+	if !strings.HasPrefix(sub.ID, "web-app_wisp_") {
+		t.Errorf("expected sub-prefixed ID to start with web-app_wisp_, got %s", sub.ID)
+	}
+
+	explicit := &types.Issue{ID: "web-app_abc1", Title: "Explicit", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := env.Store.CreateIssue(env.Ctx, explicit, "test-user"); err != nil {
+		t.Errorf("expected explicit ID with separator to be accepted: %v", err)
+	}
+
+	wrong := &types.Issue{ID: "web-app-abc2", Title: "Wrong", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := env.Store.CreateIssue(env.Ctx, wrong, "test-user"); err == nil {
+		t.Error("expected ID using the default separator to be rejected")
+	}
+}
+
+func TestIDSeparator_RejectsAmbiguousPrefix(t *testing.T) {
+	env := newTestEnv(t)
+
+	// Under the default separator, base "web" with sub-prefix "app" and base
+	// "web-app" both produce IDs starting with "web-app-".
+	collide := &types.Issue{Title: "Collide", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, IDPrefix: "app"}
+	if err := env.Store.SetConfig(env.Ctx, "issue_prefix", "web"); err != nil {
+		t.Fatalf("SetConfig(issue_prefix) failed: %v", err)
+	}
+	if err := env.Store.CreateIssue(env.Ctx, collide, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if !strings.HasPrefix(collide.ID, "web-app-") {
+		t.Fatalf("expected default composition web-app-, got %s", collide.ID)
+	}
+
+	// A sub-prefix containing the default separator is just as ambiguous
+	dashed := &types.Issue{Title: "Dashed", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, IDPrefix: "my-sub"}
+	if err := env.Store.CreateIssue(env.Ctx, dashed, "test-user"); err == nil {
+		t.Error("expected sub-prefix containing the default separator to be rejected")
+	}
+	err := env.Store.RunInTransaction(env.Ctx, func(tx storage.Transaction) error {
+		return tx.(*sqliteTxStorage).CreateIssueImport(env.Ctx, dashed, "test-user", false)
+	})
+	if err == nil {
+		t.Error("expected imported sub-prefix containing the default separator to be rejected")
+	}
+
+	if err := env.Store.SetConfig(env.Ctx, storage.IDSeparatorConfigKey, "_"); err != nil {
+		t.Fatalf("SetConfig(%s) failed: %v", storage.IDSeparatorConfigKey, err)
+	}
+	if err := env.Store.SetConfig(env.Ctx, "issue_prefix", "web_app"); err == nil {
+		t.Error("expected prefix containing the separator to be rejected")
+	}
+	bad := &types.Issue{Title: "Bad", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, IDPrefix: "my_sub"}
+	if err := env.Store.CreateIssue(env.Ctx, bad, "test-user"); err == nil {
+		t.Error("expected sub-prefix containing the separator to be rejected")
+	}
+
+	// Switching to a separator the current prefix already contains is rejected too
+	if err := env.Store.SetConfig(env.Ctx, "issue_prefix", "web.app"); err != nil {
+		t.Fatalf("SetConfig(issue_prefix) failed: %v", err)
+	}
+	for _, sep := range []string{"", ".", "x", "1", " "} {
+		if err := env.Store.SetConfig(env.Ctx, storage.IDSeparatorConfigKey, sep); err == nil {
+			t.Errorf("expected separator %q to be rejected", sep)
+		}
+	}
+	if err := env.Store.SetConfig(env.Ctx, "issue_prefix", "a:b"); err != nil {
+		t.Fatalf("SetConfig(issue_prefix) failed: %v", err)
+	}
+	if err := env.Store.SetConfig(env.Ctx, storage.IDSeparatorConfigKey, ":"); err == nil {
+		t.Error("expected separator contained in the current prefix to be rejected")
+	}
+}
+
+func TestConfiguredPrefixMalformed(t *testing.T) {
+	for _, bad := range []string{"bd proj", " ", "---", "bd\x00", "\xff\xfe"} {
+		t.Run(bad, func(t *testing.T) {
+			env := newTestEnv(t)
+			// Write the row directly, as a hand-edited or corrupt database would
+			if _, err := env.Store.db.ExecContext(env.Ctx, `UPDATE config SET value = ? WHERE key = 'issue_prefix'`, bad); err != nil {
+				t.Fatalf("failed to corrupt issue_prefix: %v", err)
+			}
+
+			issue := &types.Issue{ID: "bd-abc1", Title: "Imported", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+			err := env.Store.RunInTransaction(env.Ctx, func(tx storage.Transaction) error {
+				return tx.(*sqliteTxStorage).CreateIssueImport(env.Ctx, issue, "test-user", false)
+			})
+			if err == nil || !strings.Contains(err.Error(), "configured issue_prefix is invalid") {
+				t.Errorf("CreateIssueImport: expected invalid prefix error, got %v", err)
+			}
+
+			generated := &types.Issue{Title: "Generated", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+			if err := env.Store.CreateIssue(env.Ctx, generated, "test-user"); err == nil || !strings.Contains(err.Error(), "configured issue_prefix is invalid") {
+				t.Errorf("CreateIssue: expected invalid prefix error, got %v", err)
+			}
+		})
+	}
+}
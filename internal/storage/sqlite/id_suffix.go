@@ -0,0 +1,66 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+)
+
+// idSuffixChars are the characters of an ID suffix: base-36 hash or number
+// digits and the dots of a hierarchical child path.
+const idSuffixChars = "0123456789abcdefghijklmnopqrstuvwxyz."
+
+// idSuffixExpr computes idSuffix in SQL. It is the expression of
+// idx_issues_id_suffix (migration id_suffix_index) and must stay identical
+// to it for lookups to use the index.
+const idSuffixExpr = `substr(id, length(rtrim(id, '` + idSuffixChars + `')) + 1)`
+
+// idSuffix returns the part of id after its prefix and separator: the
+// trailing run of idSuffixChars, e.g. "a3f8.1" for "bd-a3f8.1". It does not
+// depend on the configured separator, since neither "-" nor "_" is a suffix
+// character.
+func idSuffix(id string) string {
+	return id[len(strings.TrimRight(id, idSuffixChars)):]
+}
+
+// IDSuffixConflicts returns the IDs of other issues, under any prefix and
+// including tombstones, whose ID suffix equals id's ("ops-a3f8" for
+// "bd-a3f8"), in ID order. An ID without a suffix has no conflicts. The lookup
+// uses idx_issues_id_suffix.
+func (s *SQLiteStorage) IDSuffixConflicts(ctx context.Context, id string) ([]string, error) {
+	s.checkFreshness()
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+	return idSuffixConflicts(ctx, s.db, id)
+}
+
+// IDSuffixConflicts returns the IDs sharing id's suffix within the
+// transaction, so an import sees the issues it has just created.
+func (t *sqliteTxStorage) IDSuffixConflicts(ctx context.Context, id string) ([]string, error) {
+	return idSuffixConflicts(ctx, t.conn, id)
+}
+
+func idSuffixConflicts(ctx context.Context, db dbExecutor, id string) ([]string, error) {
+	suffix := idSuffix(id)
+	if suffix == "" {
+		return nil, nil
+	}
+	rows, err := db.QueryContext(ctx, `
+		SELECT id FROM issues
+		WHERE `+idSuffixExpr+` = ? AND id != ?
+		ORDER BY id
+	`, suffix, id)
+	if err != nil {
+		return nil, wrapDBError("find id suffix conflicts", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var ids []string
+	for rows.Next() {
+		var other string
+		if err := rows.Scan(&other); err != nil {
+			return nil, wrapDBError("scan id suffix conflict", err)
+		}
+		ids = append(ids, other)
+	}
+	return ids, wrapDBError("find id suffix conflicts", rows.Err())
+}
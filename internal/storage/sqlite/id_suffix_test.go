@@ -0,0 +1,58 @@
+package sqlite
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestIDSuffixConflicts(t *testing.T) {
+	env := newTestEnv(t)
+	env.CreateIssueWithID("bd-a3f8", "Local")
+	env.CreateIssueWithID("bd-a3f8.1", "Local child")
+	imported := []*types.Issue{
+		{ID: "ops-a3f8", Title: "Same suffix", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+		{ID: "web-app-a3f8", Title: "Same suffix, hyphenated prefix", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+		{ID: "ops-b7c9", Title: "Other suffix", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+	}
+	if err := env.Store.CreateIssuesWithFullOptions(env.Ctx, imported, "import", BatchCreateOptions{SkipPrefixValidation: true}); err != nil {
+		t.Fatalf("CreateIssuesWithFullOptions failed: %v", err)
+	}
+
+	for id, want := range map[string][]string{
+		"bd-a3f8":    {"ops-a3f8", "web-app-a3f8"},
+		"new-a3f8":   {"bd-a3f8", "ops-a3f8", "web-app-a3f8"},
+		"ops-a3f8.1": {"bd-a3f8.1"},
+		"bd-b7c9":    {"ops-b7c9"},
+		"bd-zzzz":    nil,
+	} {
+		got, err := env.Store.IDSuffixConflicts(env.Ctx, id)
+		if err != nil {
+			t.Fatalf("IDSuffixConflicts(%s) failed: %v", id, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("IDSuffixConflicts(%s) = %v, want %v", id, got, want)
+		}
+	}
+
+	// The lookup is an index search, not a table scan
+	var plan strings.Builder
+	rows, err := env.Store.db.QueryContext(env.Ctx, `EXPLAIN QUERY PLAN SELECT id FROM issues WHERE `+idSuffixExpr+` = ? AND id != ?`, "a3f8", "bd-a3f8")
+	if err != nil {
+		t.Fatalf("EXPLAIN failed: %v", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id, parent, unused int
+		var detail string
+		if err := rows.Scan(&id, &parent, &unused, &detail); err != nil {
+			t.Fatalf("scan failed: %v", err)
+		}
+		plan.WriteString(detail + "\n")
+	}
+	if !strings.Contains(plan.String(), "idx_issues_id_suffix") {
+		t.Errorf("expected the suffix lookup to use idx_issues_id_suffix, plan:\n%s", plan.String())
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/steveyegge/beads/internal/idgen"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
 )
 
 // isValidBase36 checks if a string contains only base36 characters
@@ -93,7 +94,13 @@ func ParseHierarchicalID(id string) (parentID string, childNum int, ok bool) {
 // ValidateIssueIDPrefix validates that an issue ID matches the configured prefix
 // Supports both top-level (bd-a3f8e9) and hierarchical (bd-a3f8e9.1) IDs
 func ValidateIssueIDPrefix(id, prefix string) error {
-	expectedPrefix := prefix + "-"
+	return ValidateIssueIDPrefixWithSeparator(id, prefix, utils.DefaultIDSeparator)
+}
+
+// ValidateIssueIDPrefixWithSeparator validates that an issue ID starts with
+// prefix followed by the configured ID separator (e.g. "web-app_a3f8e9").
+func ValidateIssueIDPrefixWithSeparator(id, prefix, sep string) error {
+	expectedPrefix := prefix + sep
 	if !strings.HasPrefix(id, expectedPrefix) {
 		return fmt.Errorf("issue ID '%s' does not match configured prefix '%s'", id, prefix)
 	}
@@ -110,6 +117,8 @@ func GenerateIssueID(ctx context.Context, conn *sql.Conn, prefix string, issue *
 		baseLength = 6
 	}
 
+	sep := getIDSeparator(ctx, conn)
+
 	// Try baseLength, baseLength+1, baseLength+2, up to max of 8
 	maxLength := 8
 	if baseLength > maxLength {
@@ -119,7 +128,7 @@ func GenerateIssueID(ctx context.Context, conn *sql.Conn, prefix string, issue *
 	for length := baseLength; length <= maxLength; length++ {
 		// Try up to 10 nonces at each length
 		for nonce := 0; nonce < 10; nonce++ {
-			candidate := generateHashID(prefix, issue.Title, issue.Description, actor, issue.CreatedAt, length, nonce)
+			candidate := idgen.GenerateHashIDWithSeparator(prefix, sep, issue.Title, issue.Description, actor, issue.CreatedAt, length, nonce)
 
 			// Check if this ID already exists
 			var count int
@@ -147,6 +156,8 @@ func GenerateBatchIssueIDs(ctx context.Context, conn *sql.Conn, prefix string, i
 		baseLength = 6
 	}
 
+	sep := getIDSeparator(ctx, conn)
+
 	// Try baseLength, baseLength+1, baseLength+2, up to max of 8
 	maxLength := 8
 	if baseLength > maxLength {
@@ -159,7 +170,7 @@ func GenerateBatchIssueIDs(ctx context.Context, conn *sql.Conn, prefix string, i
 			// Try lengths from baseLength to maxLength with progressive fallback
 			for length := baseLength; length <= maxLength && !generated; length++ {
 				for nonce := 0; nonce < 10; nonce++ {
-					candidate := generateHashID(prefix, issues[i].Title, issues[i].Description, actor, issues[i].CreatedAt, length, nonce)
+					candidate := idgen.GenerateHashIDWithSeparator(prefix, sep, issues[i].Title, issues[i].Description, actor, issues[i].CreatedAt, length, nonce)
 
 					// Check if this ID is already used in this batch or in the database
 					if usedIDs[candidate] {
@@ -207,6 +218,7 @@ func tryResurrectParent(parentID string, issues []*types.Issue) bool {
 // When skipPrefixValidation is true, existing IDs are not validated against the prefix (used during import)
 func EnsureIDs(ctx context.Context, conn *sql.Conn, prefix string, issues []*types.Issue, actor string, orphanHandling OrphanHandling, skipPrefixValidation bool) error {
 	usedIDs := make(map[string]bool)
+	sep := getIDSeparator(ctx, conn)
 
 	// First pass: record explicitly provided IDs and check for duplicates within batch
 	for i := range issues {
@@ -229,7 +241,7 @@ func EnsureIDs(ctx context.Context, conn *sql.Conn, prefix string, issues []*typ
 			// Validate that explicitly provided ID matches the configured prefix (bd-177)
 			// Skip validation during import to allow issues with different prefixes (e.g., from renamed repos)
 			if !skipPrefixValidation {
-				if err := ValidateIssueIDPrefix(issues[i].ID, prefix); err != nil {
+				if err := ValidateIssueIDPrefixWithSeparator(issues[i].ID, prefix, sep); err != nil {
 					return wrapDBErrorf(err, "validate ID prefix for %s", issues[i].ID)
 				}
 			}
@@ -292,3 +304,9 @@ func EnsureIDs(ctx context.Context, conn *sql.Conn, prefix string, issues []*typ
 func generateHashID(prefix, title, description, creator string, timestamp time.Time, length, nonce int) string {
 	return idgen.GenerateHashID(prefix, title, description, creator, timestamp, length, nonce)
 }
+
+// GenerateIssueID generates an unused hash-based ID for issue under prefix
+// within the transaction, so the check sees the transaction's own writes.
+func (t *sqliteTxStorage) GenerateIssueID(ctx context.Context, prefix string, issue *types.Issue, actor string) (string, error) {
+	return GenerateIssueID(ctx, t.conn, prefix, issue, actor)
+}
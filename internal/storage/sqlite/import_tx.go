@@ -11,7 +11,13 @@ import (
 
 // CreateIssueImport creates an issue inside an existing sqlite transaction, optionally skipping
 // prefix validation. This is used by JSONL import to support multi-repo mode (GH#686).
+// To make a whole import part of a larger transaction, run importer.ImportIssuesTx
+// inside RunInTransaction; it creates issues through this method.
 func (t *sqliteTxStorage) CreateIssueImport(ctx context.Context, issue *types.Issue, actor string, skipPrefixValidation bool) error {
+	if err := checkActor(ctx, t.conn, actor); err != nil {
+		return err
+	}
+
 	// Fetch custom statuses and types for validation
 	customStatuses, err := t.GetCustomStatuses(ctx)
 	if err != nil {
@@ -51,13 +57,17 @@ func (t *sqliteTxStorage) CreateIssueImport(ctx context.Context, issue *types.Is
 	}
 
 	// Validate issue before creating
-	if err := issue.ValidateWithCustom(customStatuses, customTypes); err != nil {
+	if err := validateIssueWithLimits(issue, customStatuses, customTypes, getFieldLimits(ctx, t.conn), t.parent.issueValidator()); err != nil {
 		return fmt.Errorf("validation failed: %w", err)
 	}
 
+	if err := assignExternalRefs(ctx, t.conn, issue); err != nil {
+		return err
+	}
+
 	// Compute content hash
 	if issue.ContentHash == "" {
-		issue.ContentHash = issue.ComputeContentHash()
+		issue.ContentHash = contentHash(ctx, t.conn, issue)
 	}
 
 	// Get configured prefix for validation and ID generation behavior
@@ -68,10 +78,17 @@ func (t *sqliteTxStorage) CreateIssueImport(ctx context.Context, issue *types.Is
 	} else if err != nil {
 		return fmt.Errorf("failed to get config: %w", err)
 	}
+	if err := checkConfiguredPrefix(ctx, t.conn, configPrefix); err != nil {
+		return err
+	}
 
+	sep := getIDSeparator(ctx, t.conn)
 	prefix := configPrefix
 	if issue.IDPrefix != "" {
-		prefix = configPrefix + "-" + issue.IDPrefix
+		if err := validateIDPrefix(issue.IDPrefix, sep); err != nil {
+			return fmt.Errorf("failed to validate ID prefix: %w", err)
+		}
+		prefix = configPrefix + sep + issue.IDPrefix
 	}
 
 	if issue.ID == "" {
@@ -82,7 +99,7 @@ func (t *sqliteTxStorage) CreateIssueImport(ctx context.Context, issue *types.Is
 		}
 		issue.ID = generatedID
 	} else if !skipPrefixValidation {
-		if err := ValidateIssueIDPrefix(issue.ID, prefix); err != nil {
+		if err := ValidateIssueIDPrefixWithSeparator(issue.ID, prefix, sep); err != nil {
 			return fmt.Errorf("failed to validate issue ID prefix: %w", err)
 		}
 	}
@@ -0,0 +1,38 @@
+package sqlite
+
+import (
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// IssueValidator is a team-specific rule checked after the built-in
+// validation (status, type, field limits, type-status rules), e.g. "security
+// issues must have an assignee". A non-nil error rejects the issue.
+type IssueValidator func(issue *types.Issue) error
+
+// SetIssueValidator installs fn as the custom rule for every issue this store
+// creates, imports or updates, in or out of transactions; nil removes it.
+// New issues are checked after defaults are filled in and before their
+// content hash is computed, so a rejected issue is never inserted. Updates
+// are checked against the issue as it would be after the update. fn must not
+// modify the issue and must be safe for concurrent use.
+func (s *SQLiteStorage) SetIssueValidator(fn IssueValidator) {
+	s.validator.Store(fn)
+}
+
+// issueValidator returns the SetIssueValidator rule, or nil if there is none.
+func (s *SQLiteStorage) issueValidator() IssueValidator {
+	fn, _ := s.validator.Load().(IssueValidator)
+	return fn
+}
+
+// validateUpdatedIssue checks the SetIssueValidator rule against oldIssue
+// with updates applied.
+func (s *SQLiteStorage) validateUpdatedIssue(oldIssue *types.Issue, updates map[string]interface{}) error {
+	fn := s.issueValidator()
+	if fn == nil {
+		return nil
+	}
+	updated := *oldIssue
+	applyUpdatesToIssue(&updated, updates)
+	return fn(&updated)
+}
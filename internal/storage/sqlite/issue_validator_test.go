@@ -0,0 +1,89 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+var errUnassignedSecurity = errors.New("security issues must have an assignee")
+
+// requireSecurityAssignee rejects issues labeled "security" that nobody owns.
+func requireSecurityAssignee(issue *types.Issue) error {
+	for _, label := range issue.Labels {
+		if label == "security" && issue.Assignee == "" {
+			return errUnassignedSecurity
+		}
+	}
+	return nil
+}
+
+func TestSetIssueValidator(t *testing.T) {
+	env := newTestEnv(t)
+	env.Store.SetIssueValidator(requireSecurityAssignee)
+
+	issue := func(id, assignee string) *types.Issue {
+		return &types.Issue{ID: id, Title: id, Status: types.StatusOpen, Priority: 1, IssueType: types.TypeBug, Assignee: assignee, Labels: []string{"security"}}
+	}
+	assertAbsent := func(t *testing.T, id string) {
+		t.Helper()
+		if got, err := env.Store.GetIssue(env.Ctx, id); err != nil || got != nil {
+			t.Errorf("GetIssue(%s) = %v, %v; a rejected issue must not be inserted", id, got, err)
+		}
+	}
+
+	t.Run("create", func(t *testing.T) {
+		if err := env.Store.CreateIssue(env.Ctx, issue("bd-open", ""), "test"); !errors.Is(err, errUnassignedSecurity) {
+			t.Fatalf("err = %v, want the custom rule's error", err)
+		}
+		assertAbsent(t, "bd-open")
+		if err := env.Store.CreateIssue(env.Ctx, issue("bd-owned", "alice"), "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		err := env.Store.CreateIssues(env.Ctx, []*types.Issue{issue("bd-batch-ok", "bob"), issue("bd-batch-open", "")}, "test")
+		if !errors.Is(err, errUnassignedSecurity) {
+			t.Fatalf("err = %v, want the custom rule's error", err)
+		}
+		assertAbsent(t, "bd-batch-ok")
+		assertAbsent(t, "bd-batch-open")
+	})
+
+	t.Run("import", func(t *testing.T) {
+		err := env.Store.RunInTransaction(env.Ctx, func(tx storage.Transaction) error {
+			return tx.(*sqliteTxStorage).CreateIssueImport(env.Ctx, issue("bd-imported", ""), "import", false)
+		})
+		if !errors.Is(err, errUnassignedSecurity) {
+			t.Fatalf("err = %v, want the custom rule's error", err)
+		}
+		assertAbsent(t, "bd-imported")
+	})
+
+	t.Run("update", func(t *testing.T) {
+		if err := env.Store.AddLabel(env.Ctx, "bd-owned", "security", "test"); err != nil {
+			t.Fatalf("AddLabel failed: %v", err)
+		}
+		err := env.Store.UpdateIssue(env.Ctx, "bd-owned", map[string]interface{}{"assignee": ""}, "test")
+		if !errors.Is(err, errUnassignedSecurity) {
+			t.Fatalf("err = %v, want the custom rule's error", err)
+		}
+		if got, _ := env.Store.GetIssue(env.Ctx, "bd-owned"); got == nil || got.Assignee != "alice" {
+			t.Errorf("assignee = %v, want the rejected update left out", got)
+		}
+		if err := env.Store.UpdateIssue(env.Ctx, "bd-owned", map[string]interface{}{"priority": 0}, "test"); err != nil {
+			t.Errorf("UpdateIssue keeping the rule failed: %v", err)
+		}
+	})
+
+	t.Run("cleared", func(t *testing.T) {
+		env.Store.SetIssueValidator(nil)
+		if err := env.Store.CreateIssue(context.Background(), issue("bd-unguarded", ""), "test"); err != nil {
+			t.Fatalf("CreateIssue without a validator failed: %v", err)
+		}
+	})
+}
@@ -20,6 +20,33 @@ func isUniqueConstraintError(err error) bool {
 		strings.Contains(errMsg, "constraint failed: UNIQUE")
 }
 
+// issueRowID returns the rowid to insert issue under: its RowID when set
+// (preserved from an export), otherwise NULL so SQLite assigns one.
+func issueRowID(issue *types.Issue) interface{} {
+	if issue.RowID == 0 {
+		return nil
+	}
+	return issue.RowID
+}
+
+// checkRowIDFree returns an ErrConflict error when issue carries a RowID
+// already held by a different issue. INSERT OR IGNORE would otherwise drop
+// the issue silently.
+func checkRowIDFree(ctx context.Context, conn *sql.Conn, issue *types.Issue) error {
+	if issue.RowID == 0 {
+		return nil
+	}
+	var holder string
+	err := conn.QueryRowContext(ctx, `SELECT id FROM issues WHERE rowid = ?`, issue.RowID).Scan(&holder)
+	if err == sql.ErrNoRows || (err == nil && holder == issue.ID) {
+		return nil
+	}
+	if err != nil {
+		return wrapDBError("check rowid", err)
+	}
+	return fmt.Errorf("%w: rowid %d of issue %s is already used by %s", ErrConflict, issue.RowID, issue.ID, holder)
+}
+
 // insertIssue inserts a single issue into the database.
 // Uses INSERT OR IGNORE for backward compatibility with imports.
 // For fresh issue creation, use insertIssueStrict instead.
@@ -45,20 +72,28 @@ func insertIssue(ctx context.Context, conn *sql.Conn, issue *types.Issue) error
 	if issue.Crystallizes {
 		crystallizes = 1
 	}
+	customFields, err := formatCustomFields(issue.CustomFields)
+	if err != nil {
+		return fmt.Errorf("failed to insert issue %s: %w", issue.ID, err)
+	}
+
+	if err := checkRowIDFree(ctx, conn, issue); err != nil {
+		return err
+	}
 
-	_, err := conn.ExecContext(ctx, `
+	_, err = conn.ExecContext(ctx, `
 		INSERT OR IGNORE INTO issues (
-			id, content_hash, title, description, design, acceptance_criteria, notes,
+			rowid, id, content_hash, title, description, design, acceptance_criteria, notes,
 			status, priority, issue_type, assignee, estimated_minutes,
 			created_at, created_by, owner, updated_at, closed_at, external_ref, source_repo, close_reason,
 			deleted_at, deleted_by, delete_reason, original_type,
 			sender, ephemeral, pinned, is_template, crystallizes,
 			await_type, await_id, timeout_ns, waiters, mol_type,
 			event_kind, actor, target, payload,
-			due_at, defer_until
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			due_at, defer_until, expires_at, color, display_order, source_system, custom_fields, rank, milestone_id
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`,
-		issue.ID, issue.ContentHash, issue.Title, issue.Description, issue.Design,
+		issueRowID(issue), issue.ID, issue.ContentHash, issue.Title, issue.Description, issue.Design,
 		issue.AcceptanceCriteria, issue.Notes, issue.Status,
 		issue.Priority, issue.IssueType, issue.Assignee,
 		issue.EstimatedMinutes, issue.CreatedAt, issue.CreatedBy, issue.Owner, issue.UpdatedAt,
@@ -68,7 +103,7 @@ func insertIssue(ctx context.Context, conn *sql.Conn, issue *types.Issue) error
 		issue.AwaitType, issue.AwaitID, int64(issue.Timeout), formatJSONStringArray(issue.Waiters),
 		string(issue.MolType),
 		issue.EventKind, issue.Actor, issue.Target, issue.Payload,
-		issue.DueAt, issue.DeferUntil,
+		issue.DueAt, issue.DeferUntil, issue.ExpiresAt, issue.Color, issue.DisplayOrder, issue.SourceSystem, customFields, issue.Rank, issue.MilestoneID,
 	)
 	if err != nil {
 		// INSERT OR IGNORE should handle duplicates, but driver may still return error
@@ -78,7 +113,7 @@ func insertIssue(ctx context.Context, conn *sql.Conn, issue *types.Issue) error
 		}
 		// Duplicate ID detected and ignored (INSERT OR IGNORE succeeded)
 	}
-	return nil
+	return packDescriptions(ctx, conn, issue.ID)
 }
 
 // insertIssueStrict inserts a single issue into the database, failing on duplicates.
@@ -107,20 +142,28 @@ func insertIssueStrict(ctx context.Context, conn *sql.Conn, issue *types.Issue)
 	if issue.Crystallizes {
 		crystallizes = 1
 	}
+	customFields, err := formatCustomFields(issue.CustomFields)
+	if err != nil {
+		return fmt.Errorf("failed to insert issue %s: %w", issue.ID, err)
+	}
 
-	_, err := conn.ExecContext(ctx, `
+	if err := checkRowIDFree(ctx, conn, issue); err != nil {
+		return err
+	}
+
+	_, err = conn.ExecContext(ctx, `
 		INSERT INTO issues (
-			id, content_hash, title, description, design, acceptance_criteria, notes,
+			rowid, id, content_hash, title, description, design, acceptance_criteria, notes,
 			status, priority, issue_type, assignee, estimated_minutes,
 			created_at, created_by, owner, updated_at, closed_at, external_ref, source_repo, close_reason,
 			deleted_at, deleted_by, delete_reason, original_type,
 			sender, ephemeral, pinned, is_template, crystallizes,
 			await_type, await_id, timeout_ns, waiters, mol_type,
 			event_kind, actor, target, payload,
-			due_at, defer_until
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			due_at, defer_until, expires_at, color, display_order, source_system, custom_fields, rank, milestone_id
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`,
-		issue.ID, issue.ContentHash, issue.Title, issue.Description, issue.Design,
+		issueRowID(issue), issue.ID, issue.ContentHash, issue.Title, issue.Description, issue.Design,
 		issue.AcceptanceCriteria, issue.Notes, issue.Status,
 		issue.Priority, issue.IssueType, issue.Assignee,
 		issue.EstimatedMinutes, issue.CreatedAt, issue.CreatedBy, issue.Owner, issue.UpdatedAt,
@@ -130,27 +173,100 @@ func insertIssueStrict(ctx context.Context, conn *sql.Conn, issue *types.Issue)
 		issue.AwaitType, issue.AwaitID, int64(issue.Timeout), formatJSONStringArray(issue.Waiters),
 		string(issue.MolType),
 		issue.EventKind, issue.Actor, issue.Target, issue.Payload,
-		issue.DueAt, issue.DeferUntil,
+		issue.DueAt, issue.DeferUntil, issue.ExpiresAt, issue.Color, issue.DisplayOrder, issue.SourceSystem, customFields, issue.Rank, issue.MilestoneID,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to insert issue: %w", err)
 	}
-	return nil
+	return packDescriptions(ctx, conn, issue.ID)
+}
+
+// insertIssueIfAbsent is insertIssueStrict with conflict-ignore semantics on
+// the ID: it reports whether the issue was inserted, and returns (false, nil)
+// when an issue with the same ID already exists. Other constraint failures
+// are still errors.
+func insertIssueIfAbsent(ctx context.Context, conn *sql.Conn, issue *types.Issue) (bool, error) {
+	sourceRepo := issue.SourceRepo
+	if sourceRepo == "" {
+		sourceRepo = "." // Default to primary repo
+	}
+
+	wisp := 0
+	if issue.Ephemeral {
+		wisp = 1
+	}
+	pinned := 0
+	if issue.Pinned {
+		pinned = 1
+	}
+	isTemplate := 0
+	if issue.IsTemplate {
+		isTemplate = 1
+	}
+	crystallizes := 0
+	if issue.Crystallizes {
+		crystallizes = 1
+	}
+	customFields, err := formatCustomFields(issue.CustomFields)
+	if err != nil {
+		return false, fmt.Errorf("failed to insert issue %s: %w", issue.ID, err)
+	}
+
+	if err := checkRowIDFree(ctx, conn, issue); err != nil {
+		return false, err
+	}
+
+	res, err := conn.ExecContext(ctx, `
+		INSERT INTO issues (
+			rowid, id, content_hash, title, description, design, acceptance_criteria, notes,
+			status, priority, issue_type, assignee, estimated_minutes,
+			created_at, created_by, owner, updated_at, closed_at, external_ref, source_repo, close_reason,
+			deleted_at, deleted_by, delete_reason, original_type,
+			sender, ephemeral, pinned, is_template, crystallizes,
+			await_type, await_id, timeout_ns, waiters, mol_type,
+			event_kind, actor, target, payload,
+			due_at, defer_until, expires_at, color, display_order, source_system, custom_fields, rank, milestone_id
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO NOTHING
+	`,
+		issueRowID(issue), issue.ID, issue.ContentHash, issue.Title, issue.Description, issue.Design,
+		issue.AcceptanceCriteria, issue.Notes, issue.Status,
+		issue.Priority, issue.IssueType, issue.Assignee,
+		issue.EstimatedMinutes, issue.CreatedAt, issue.CreatedBy, issue.Owner, issue.UpdatedAt,
+		issue.ClosedAt, issue.ExternalRef, sourceRepo, issue.CloseReason,
+		issue.DeletedAt, issue.DeletedBy, issue.DeleteReason, issue.OriginalType,
+		issue.Sender, wisp, pinned, isTemplate, crystallizes,
+		issue.AwaitType, issue.AwaitID, int64(issue.Timeout), formatJSONStringArray(issue.Waiters),
+		string(issue.MolType),
+		issue.EventKind, issue.Actor, issue.Target, issue.Payload,
+		issue.DueAt, issue.DeferUntil, issue.ExpiresAt, issue.Color, issue.DisplayOrder, issue.SourceSystem, customFields, issue.Rank, issue.MilestoneID,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to insert issue: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to check inserted rows: %w", err)
+	}
+	if n == 0 {
+		return false, nil
+	}
+	return true, packDescriptions(ctx, conn, issue.ID)
 }
 
 // insertIssues bulk inserts multiple issues using a prepared statement
 func insertIssues(ctx context.Context, conn *sql.Conn, issues []*types.Issue) error {
 	stmt, err := conn.PrepareContext(ctx, `
 		INSERT OR IGNORE INTO issues (
-			id, content_hash, title, description, design, acceptance_criteria, notes,
+			rowid, id, content_hash, title, description, design, acceptance_criteria, notes,
 			status, priority, issue_type, assignee, estimated_minutes,
 			created_at, created_by, owner, updated_at, closed_at, external_ref, source_repo, close_reason,
 			deleted_at, deleted_by, delete_reason, original_type,
 			sender, ephemeral, pinned, is_template, crystallizes,
 			await_type, await_id, timeout_ns, waiters, mol_type,
 			event_kind, actor, target, payload,
-			due_at, defer_until
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			due_at, defer_until, expires_at, color, display_order, source_system, custom_fields, rank, milestone_id
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`)
 	if err != nil {
 		return fmt.Errorf("failed to prepare statement: %w", err)
@@ -179,9 +295,16 @@ func insertIssues(ctx context.Context, conn *sql.Conn, issues []*types.Issue) er
 		if issue.Crystallizes {
 			crystallizes = 1
 		}
+		customFields, err := formatCustomFields(issue.CustomFields)
+		if err != nil {
+			return fmt.Errorf("failed to insert issue %s: %w", issue.ID, err)
+		}
 
+		if err := checkRowIDFree(ctx, conn, issue); err != nil {
+			return err
+		}
 		_, err = stmt.ExecContext(ctx,
-			issue.ID, issue.ContentHash, issue.Title, issue.Description, issue.Design,
+			issueRowID(issue), issue.ID, issue.ContentHash, issue.Title, issue.Description, issue.Design,
 			issue.AcceptanceCriteria, issue.Notes, issue.Status,
 			issue.Priority, issue.IssueType, issue.Assignee,
 			issue.EstimatedMinutes, issue.CreatedAt, issue.CreatedBy, issue.Owner, issue.UpdatedAt,
@@ -191,7 +314,7 @@ func insertIssues(ctx context.Context, conn *sql.Conn, issues []*types.Issue) er
 			issue.AwaitType, issue.AwaitID, int64(issue.Timeout), formatJSONStringArray(issue.Waiters),
 			string(issue.MolType),
 			issue.EventKind, issue.Actor, issue.Target, issue.Payload,
-			issue.DueAt, issue.DeferUntil,
+			issue.DueAt, issue.DeferUntil, issue.ExpiresAt, issue.Color, issue.DisplayOrder, issue.SourceSystem, customFields, issue.Rank, issue.MilestoneID,
 		)
 		if err != nil {
 			// INSERT OR IGNORE should handle duplicates, but driver may still return error
@@ -202,7 +325,7 @@ func insertIssues(ctx context.Context, conn *sql.Conn, issues []*types.Issue) er
 			// Duplicate ID detected and ignored (INSERT OR IGNORE succeeded)
 		}
 	}
-	return nil
+	return packIssueDescriptions(ctx, conn, issues)
 }
 
 // insertIssuesStrict bulk inserts multiple issues using plain INSERT (no OR IGNORE).
@@ -212,15 +335,15 @@ func insertIssues(ctx context.Context, conn *sql.Conn, issues []*types.Issue) er
 func insertIssuesStrict(ctx context.Context, conn *sql.Conn, issues []*types.Issue) error {
 	stmt, err := conn.PrepareContext(ctx, `
 		INSERT INTO issues (
-			id, content_hash, title, description, design, acceptance_criteria, notes,
+			rowid, id, content_hash, title, description, design, acceptance_criteria, notes,
 			status, priority, issue_type, assignee, estimated_minutes,
 			created_at, created_by, owner, updated_at, closed_at, external_ref, source_repo, close_reason,
 			deleted_at, deleted_by, delete_reason, original_type,
 			sender, ephemeral, pinned, is_template, crystallizes,
 			await_type, await_id, timeout_ns, waiters, mol_type,
 			event_kind, actor, target, payload,
-			due_at, defer_until
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			due_at, defer_until, expires_at, color, display_order, source_system, custom_fields, rank, milestone_id
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`)
 	if err != nil {
 		return fmt.Errorf("failed to prepare statement: %w", err)
@@ -249,9 +372,16 @@ func insertIssuesStrict(ctx context.Context, conn *sql.Conn, issues []*types.Iss
 		if issue.Crystallizes {
 			crystallizes = 1
 		}
+		customFields, err := formatCustomFields(issue.CustomFields)
+		if err != nil {
+			return fmt.Errorf("failed to insert issue %s: %w", issue.ID, err)
+		}
 
+		if err := checkRowIDFree(ctx, conn, issue); err != nil {
+			return err
+		}
 		_, err = stmt.ExecContext(ctx,
-			issue.ID, issue.ContentHash, issue.Title, issue.Description, issue.Design,
+			issueRowID(issue), issue.ID, issue.ContentHash, issue.Title, issue.Description, issue.Design,
 			issue.AcceptanceCriteria, issue.Notes, issue.Status,
 			issue.Priority, issue.IssueType, issue.Assignee,
 			issue.EstimatedMinutes, issue.CreatedAt, issue.CreatedBy, issue.Owner, issue.UpdatedAt,
@@ -261,11 +391,11 @@ func insertIssuesStrict(ctx context.Context, conn *sql.Conn, issues []*types.Iss
 			issue.AwaitType, issue.AwaitID, int64(issue.Timeout), formatJSONStringArray(issue.Waiters),
 			string(issue.MolType),
 			issue.EventKind, issue.Actor, issue.Target, issue.Payload,
-			issue.DueAt, issue.DeferUntil,
+			issue.DueAt, issue.DeferUntil, issue.ExpiresAt, issue.Color, issue.DisplayOrder, issue.SourceSystem, customFields, issue.Rank, issue.MilestoneID,
 		)
 		if err != nil {
 			return fmt.Errorf("failed to insert issue %s: %w", issue.ID, err)
 		}
 	}
-	return nil
+	return packIssueDescriptions(ctx, conn, issues)
 }
@@ -58,6 +58,7 @@ func (s *SQLiteStorage) executeLabelOperation(
 
 // AddLabel adds a label to an issue
 func (s *SQLiteStorage) AddLabel(ctx context.Context, issueID, label, actor string) error {
+	issueID = canonicalIssueID(ctx, s.db, issueID)
 	return s.executeLabelOperation(
 		ctx, issueID, actor,
 		`INSERT OR IGNORE INTO labels (issue_id, label) VALUES (?, ?)`,
@@ -70,6 +71,7 @@ func (s *SQLiteStorage) AddLabel(ctx context.Context, issueID, label, actor stri
 
 // RemoveLabel removes a label from an issue
 func (s *SQLiteStorage) RemoveLabel(ctx context.Context, issueID, label, actor string) error {
+	issueID = canonicalIssueID(ctx, s.db, issueID)
 	return s.executeLabelOperation(
 		ctx, issueID, actor,
 		`DELETE FROM labels WHERE issue_id = ? AND label = ?`,
@@ -175,7 +177,7 @@ func (s *SQLiteStorage) GetIssuesByLabel(ctx context.Context, label string) ([]*
 		       i.sender, i.ephemeral, i.pinned, i.is_template, i.crystallizes,
 		       i.await_type, i.await_id, i.timeout_ns, i.waiters,
 		       i.hook_bead, i.role_bead, i.agent_state, i.last_activity, i.role_type, i.rig, i.mol_type,
-		       i.due_at, i.defer_until
+		       i.due_at, i.defer_until, i.expires_at, i.color, i.display_order, i.source_system, i.custom_fields, i.rank, i.milestone_id
 		FROM issues i
 		JOIN labels l ON i.id = l.issue_id
 		WHERE l.label = ?
@@ -0,0 +1,120 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// LookupIssues finds issues for autocomplete and quick lookup: those whose ID
+// starts with query, then those whose title contains it, both ignoring case,
+// at most limit of them (all when limit is 0 or less).
+//
+// Results are ranked: an exact ID match, then other ID-prefix matches (shorter
+// IDs, such as parents, first), then issues whose title starts with query,
+// then other title matches. ID prefixes are matched with range scans of the
+// primary key; titles are only scanned when the ID matches leave room under
+// limit. Tombstones are skipped unless includeTombstones is set.
+func (s *SQLiteStorage) LookupIssues(ctx context.Context, query string, limit int, includeTombstones bool) ([]*types.Issue, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return []*types.Issue{}, nil
+	}
+	s.checkFreshness()
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	lower := strings.ToLower(query)
+	tombstones := ""
+	if !includeTombstones {
+		tombstones = "AND status != 'tombstone'"
+	}
+
+	// IDs are generated in lower case; also try the query as typed for
+	// configured prefixes that are not.
+	prefixes := []string{lower}
+	if query != lower {
+		prefixes = append(prefixes, query)
+	}
+	var ranges []string
+	var args []interface{}
+	for _, prefix := range prefixes {
+		if upper := prefixUpperBound(prefix); upper != "" {
+			ranges = append(ranges, "(id >= ? AND id < ?)")
+			args = append(args, prefix, upper)
+		} else {
+			ranges = append(ranges, "id >= ?")
+			args = append(args, prefix)
+		}
+	}
+	byID, err := s.lookupQuery(ctx, `
+		SELECT `+rangeIssueColumns+`
+		FROM issues
+		WHERE (`+strings.Join(ranges, " OR ")+`) `+tombstones+`
+		ORDER BY lower(id) != ?, length(id), id
+		LIMIT ?
+	`, append(args, lower, sqlLimit(limit))...)
+	if err != nil {
+		return nil, err
+	}
+	if limit > 0 && len(byID) >= limit {
+		return byID, nil
+	}
+
+	exclude := ""
+	args = []interface{}{lower}
+	if len(byID) > 0 {
+		exclude = "AND id NOT IN (" + buildPlaceholders(len(byID)) + ")"
+		for _, issue := range byID {
+			args = append(args, issue.ID)
+		}
+	}
+	remaining := limit
+	if limit > 0 {
+		remaining = limit - len(byID)
+	}
+	// #nosec G202 -- only placeholders and fixed clauses are concatenated
+	byTitle, err := s.lookupQuery(ctx, `
+		SELECT `+rangeIssueColumns+`
+		FROM issues
+		WHERE instr(lower(title), ?1) > 0 `+exclude+` `+tombstones+`
+		ORDER BY instr(lower(title), ?1) != 1, priority, id
+		LIMIT ?
+	`, append(args, sqlLimit(remaining))...)
+	if err != nil {
+		return nil, err
+	}
+	return append(byID, byTitle...), nil
+}
+
+func (s *SQLiteStorage) lookupQuery(ctx context.Context, query string, args ...interface{}) ([]*types.Issue, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up issues: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	return scanIssueList(ctx, s, rows)
+}
+
+// prefixUpperBound returns the smallest string greater than every string
+// starting with prefix, or "" when there is none.
+func prefixUpperBound(prefix string) string {
+	b := []byte(prefix)
+	for i := len(b) - 1; i >= 0; i-- {
+		if b[i] < 0xff {
+			b[i]++
+			return string(b[:i+1])
+		}
+	}
+	return ""
+}
+
+// sqlLimit maps a limit of 0 or less to SQLite's "no limit".
+func sqlLimit(limit int) int {
+	if limit <= 0 {
+		return -1
+	}
+	return limit
+}
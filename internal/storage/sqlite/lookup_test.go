@@ -0,0 +1,65 @@
+package sqlite
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLookupIssues(t *testing.T) {
+	env := newTestEnv(t)
+	env.CreateIssueWithID("bd-a1", "Parent epic")
+	env.CreateIssueWithID("bd-a1.1", "Child for login")
+	env.CreateIssueWithID("bd-a12", "Unrelated")
+	env.CreateIssueWithID("bd-b2", "Login page crashes")
+	env.CreateIssueWithID("bd-c3", "Fix the LOGIN redirect")
+	gone := env.CreateIssueWithID("bd-a19", "Old login flow")
+	if err := env.Store.CreateTombstone(env.Ctx, gone.ID, "test-user", "obsolete"); err != nil {
+		t.Fatalf("CreateTombstone failed: %v", err)
+	}
+
+	lookup := func(query string, limit int, includeTombstones bool) []string {
+		t.Helper()
+		issues, err := env.Store.LookupIssues(env.Ctx, query, limit, includeTombstones)
+		if err != nil {
+			t.Fatalf("LookupIssues(%q) failed: %v", query, err)
+		}
+		ids := []string{}
+		for _, issue := range issues {
+			ids = append(ids, issue.ID)
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name              string
+		query             string
+		limit             int
+		includeTombstones bool
+		want              []string
+	}{
+		{"ID prefix, shortest first", "bd-a1", 0, false, []string{"bd-a1", "bd-a12", "bd-a1.1"}},
+		{"ID prefix ignores case", "BD-A1", 0, false, []string{"bd-a1", "bd-a12", "bd-a1.1"}},
+		{"exact ID ranks first", "bd-a12", 0, false, []string{"bd-a12"}},
+		{"title substring, prefix matches first", "login", 0, false, []string{"bd-b2", "bd-a1.1", "bd-c3"}},
+		{"limit cuts the ranking", "login", 2, false, []string{"bd-b2", "bd-a1.1"}},
+		{"ID matches fill the limit first", "bd-a", 2, false, []string{"bd-a1", "bd-a12"}},
+		{"tombstones on request", "bd-a1", 0, true, []string{"bd-a1", "bd-a12", "bd-a19", "bd-a1.1"}},
+		{"no match", "zeppelin", 0, false, []string{}},
+		{"blank query", "  ", 0, false, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lookup(tt.query, tt.limit, tt.includeTombstones); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LookupIssues(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrefixUpperBound(t *testing.T) {
+	for prefix, want := range map[string]string{"bd-a": "bd-b", "a\xff": "b", "\xff\xff": ""} {
+		if got := prefixUpperBound(prefix); got != want {
+			t.Errorf("prefixUpperBound(%q) = %q, want %q", prefix, got, want)
+		}
+	}
+}
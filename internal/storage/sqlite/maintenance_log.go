@@ -0,0 +1,69 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Maintenance operations recorded in the maintenance log.
+const (
+	// MaintenanceContentHashMigration is recorded by MigrateContentHashes; its
+	// details are the completed ContentHashMigration.
+	MaintenanceContentHashMigration = "content_hash_migration"
+)
+
+// MaintenanceEvent is one entry in the maintenance log.
+type MaintenanceEvent struct {
+	ID         int64           // Position in the log
+	Operation  string          // What was done, e.g. MaintenanceContentHashMigration
+	Details    json.RawMessage // Operation-specific summary
+	RecordedAt time.Time       // When the operation completed
+}
+
+// recordMaintenanceEvent appends an entry for operation to the maintenance
+// log on conn, so it commits with the operation's own writes.
+func recordMaintenanceEvent(ctx context.Context, conn *sql.Conn, operation string, details interface{}, at time.Time) error {
+	data, err := json.Marshal(details)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s maintenance details: %w", operation, err)
+	}
+	_, err = conn.ExecContext(ctx, `
+		INSERT INTO maintenance_log (operation, details, recorded_at) VALUES (?, ?, ?)
+	`, operation, string(data), at)
+	return wrapDBError("record maintenance event", err)
+}
+
+// GetMaintenanceLog returns the maintenance log entries for operation (every
+// operation when empty), oldest first.
+func (s *SQLiteStorage) GetMaintenanceLog(ctx context.Context, operation string) ([]*MaintenanceEvent, error) {
+	s.checkFreshness()
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, operation, details, recorded_at
+		FROM maintenance_log
+		WHERE ? = '' OR operation = ?
+		ORDER BY id
+	`, operation, operation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read maintenance log: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	events := []*MaintenanceEvent{}
+	for rows.Next() {
+		var event MaintenanceEvent
+		var details, recordedAt string
+		if err := rows.Scan(&event.ID, &event.Operation, &details, &recordedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan maintenance event: %w", err)
+		}
+		event.Details = json.RawMessage(details)
+		event.RecordedAt = parseTimeString(recordedAt)
+		events = append(events, &event)
+	}
+	return events, rows.Err()
+}
@@ -2,6 +2,7 @@
 package sqlite
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 
@@ -57,6 +58,26 @@ var migrationsList = []Migration{
 	{"work_type_column", migrations.MigrateWorkTypeColumn},
 	{"source_system_column", migrations.MigrateSourceSystemColumn},
 	{"quality_score_column", migrations.MigrateQualityScoreColumn},
+	{"status_transitions_table", migrations.MigrateStatusTransitionsTable},
+	{"id_aliases_table", migrations.MigrateIDAliasesTable},
+	{"field_provenance_table", migrations.MigrateFieldProvenanceTable},
+	{"watchers_table", migrations.MigrateWatchersTable},
+	{"description_blobs_table", migrations.MigrateDescriptionBlobsTable},
+	{"closed_at_index", migrations.MigrateClosedAtIndex},
+	{"expires_at_column", migrations.MigrateExpiresAtColumn},
+	{"issues_fts", migrations.MigrateIssuesFTS},
+	{"shadow_import_log", migrations.MigrateShadowImportLog},
+	{"checklist_items", migrations.MigrateChecklistItems},
+	{"updated_at_id_index", migrations.MigrateUpdatedAtIDIndex},
+	{"display_columns", migrations.MigrateDisplayColumns},
+	{"source_system_index", migrations.MigrateSourceSystemIndex},
+	{"custom_fields_column", migrations.MigrateCustomFieldsColumn},
+	{"issue_templates_table", migrations.MigrateIssueTemplatesTable},
+	{"rank_column", migrations.MigrateRankColumn},
+	{"id_suffix_index", migrations.MigrateIDSuffixIndex},
+	{"milestones", migrations.MigrateMilestones},
+	{"archived_issues", migrations.MigrateArchivedIssues},
+	{"maintenance_log", migrations.MigrateMaintenanceLog},
 }
 
 // MigrationInfo contains metadata about a migration for inspection
@@ -121,6 +142,26 @@ func getMigrationDescription(name string) string {
 		"work_type_column":             "Adds work_type column for work assignment model (mutex vs open_competition per Decision 006)",
 		"source_system_column":         "Adds source_system column for federation adapter tracking",
 		"quality_score_column":         "Adds quality_score column for aggregate quality (0.0-1.0) set by Refineries",
+		"status_transitions_table":     "Adds status_transitions table for optional status transition rules",
+		"id_aliases_table":             "Adds id_aliases table mapping original IDs of remapped issues to current IDs",
+		"field_provenance_table":       "Adds field_provenance table recording which import source last set each field",
+		"watchers_table":               "Adds watchers table tracking who watches each issue",
+		"description_blobs_table":      "Adds description_blobs table for content-addressed large descriptions",
+		"closed_at_index":              "Adds partial index on closed_at for closed-in-range queries",
+		"expires_at_column":            "Adds expires_at column for automatic tombstoning of expired issues",
+		"issues_fts":                   "Adds issues_fts full-text index over titles and descriptions, kept current by triggers",
+		"shadow_import_log":            "Adds shadow_import_log table recording operations planned by shadow imports",
+		"checklist_items":              "Adds checklist_items table holding each issue's ordered checklist",
+		"updated_at_id_index":          "Adds index on (updated_at, id) for paging through recently modified issues",
+		"display_columns":              "Adds color and display_order columns for board layout metadata",
+		"source_system_index":          "Adds index on (source_system, id) for listing issues by origin",
+		"custom_fields_column":         "Adds custom_fields column preserving unknown imported fields",
+		"issue_templates_table":        "Adds issue_templates table holding skeletons issues are created from",
+		"rank_column":                  "Adds rank column and index for manually ordered backlogs",
+		"id_suffix_index":              "Adds expression index on issue ID suffixes for cross-prefix uniqueness checks",
+		"milestones":                   "Adds milestones table and milestone_id column grouping issues into sprints and releases",
+		"archived_issues":              "Adds archived_issues and archived_events tables holding closed issues moved out of the active table",
+		"maintenance_log":              "Adds maintenance_log table recording database-wide maintenance such as content hash migrations",
 	}
 
 	if desc, ok := descriptions[name]; ok {
@@ -171,10 +212,23 @@ func RunMigrations(db *sql.DB) error {
 		return fmt.Errorf("failed to capture pre-migration snapshot: %w", err)
 	}
 
-	for _, migration := range migrationsList {
+	// The schema version is written in the same transaction as the migrations,
+	// so it can never describe a schema that failed to commit. A database
+	// already at a newer version (written by a newer build) keeps it.
+	ctx := context.Background()
+	version, err := getSchemaVersion(ctx, db)
+	if err != nil {
+		version = 0 // Unreadable value: rewrite it below
+	}
+	for i, migration := range migrationsList {
 		if err := migration.Func(db); err != nil {
 			return fmt.Errorf("migration %s failed: %w", migration.Name, err)
 		}
+		if i+1 > version {
+			if err := setSchemaVersion(ctx, db, i+1); err != nil {
+				return fmt.Errorf("migration %s failed: %w", migration.Name, err)
+			}
+		}
 	}
 
 	if err := verifyInvariants(db, snapshot); err != nil {
@@ -0,0 +1,36 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateStatusTransitionsTable adds the status_transitions table, which holds
+// optional allowed from -> to status pairs enforced on update paths.
+func MigrateStatusTransitionsTable(db *sql.DB) error {
+	var tableName string
+	err := db.QueryRow(`
+		SELECT name FROM sqlite_master
+		WHERE type='table' AND name='status_transitions'
+	`).Scan(&tableName)
+
+	if err == sql.ErrNoRows {
+		_, err := db.Exec(`
+			CREATE TABLE status_transitions (
+				from_status TEXT NOT NULL,
+				to_status TEXT NOT NULL,
+				PRIMARY KEY (from_status, to_status)
+			)
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create status_transitions table: %w", err)
+		}
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to check for status_transitions table: %w", err)
+	}
+
+	return nil
+}
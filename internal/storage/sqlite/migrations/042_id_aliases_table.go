@@ -0,0 +1,38 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateIDAliasesTable adds the id_aliases table, which maps the original ID
+// of an issue remapped during import to the issue's current ID.
+func MigrateIDAliasesTable(db *sql.DB) error {
+	var tableName string
+	err := db.QueryRow(`
+		SELECT name FROM sqlite_master
+		WHERE type='table' AND name='id_aliases'
+	`).Scan(&tableName)
+
+	if err == sql.ErrNoRows {
+		_, err := db.Exec(`
+			CREATE TABLE id_aliases (
+				alias_id TEXT PRIMARY KEY,
+				issue_id TEXT NOT NULL,
+				created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+			);
+			CREATE INDEX IF NOT EXISTS idx_id_aliases_issue ON id_aliases(issue_id);
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create id_aliases table: %w", err)
+		}
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to check for id_aliases table: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateFieldProvenanceTable adds the field_provenance table, which records
+// the import source that last set each issue field.
+func MigrateFieldProvenanceTable(db *sql.DB) error {
+	var tableName string
+	err := db.QueryRow(`
+		SELECT name FROM sqlite_master
+		WHERE type='table' AND name='field_provenance'
+	`).Scan(&tableName)
+
+	if err == sql.ErrNoRows {
+		_, err := db.Exec(`
+			CREATE TABLE field_provenance (
+				issue_id TEXT NOT NULL,
+				field TEXT NOT NULL,
+				source TEXT NOT NULL,
+				updated_at DATETIME NOT NULL,
+				PRIMARY KEY (issue_id, field),
+				FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+			)
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create field_provenance table: %w", err)
+		}
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to check for field_provenance table: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateWatchersTable adds the watchers table, which tracks who watches
+// (subscribes to notifications for) each issue.
+func MigrateWatchersTable(db *sql.DB) error {
+	var tableName string
+	err := db.QueryRow(`
+		SELECT name FROM sqlite_master
+		WHERE type='table' AND name='watchers'
+	`).Scan(&tableName)
+
+	if err == sql.ErrNoRows {
+		_, err := db.Exec(`
+			CREATE TABLE watchers (
+				issue_id TEXT NOT NULL,
+				watcher TEXT NOT NULL,
+				PRIMARY KEY (issue_id, watcher),
+				FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+			)
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create watchers table: %w", err)
+		}
+		_, err = db.Exec(`CREATE INDEX idx_watchers_watcher ON watchers(watcher)`)
+		if err != nil {
+			return fmt.Errorf("failed to create watchers index: %w", err)
+		}
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to check for watchers table: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateDescriptionBlobsTable adds the description_blobs table, a
+// content-addressed store for large issue descriptions. Issues reference a
+// blob by hash instead of storing the body inline.
+func MigrateDescriptionBlobsTable(db *sql.DB) error {
+	var tableName string
+	err := db.QueryRow(`
+		SELECT name FROM sqlite_master
+		WHERE type='table' AND name='description_blobs'
+	`).Scan(&tableName)
+
+	if err == sql.ErrNoRows {
+		_, err := db.Exec(`
+			CREATE TABLE description_blobs (
+				hash TEXT PRIMARY KEY,
+				content TEXT NOT NULL,
+				created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+			)
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create description_blobs table: %w", err)
+		}
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to check for description_blobs table: %w", err)
+	}
+
+	return nil
+}
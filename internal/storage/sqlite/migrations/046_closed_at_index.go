@@ -0,0 +1,17 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateClosedAtIndex adds a partial index on issues.closed_at so closed-in-
+// range queries scan only closed issues. created_at is already indexed by the
+// base schema.
+func MigrateClosedAtIndex(db *sql.DB) error {
+	_, err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_issues_closed_at ON issues(closed_at) WHERE closed_at IS NOT NULL`)
+	if err != nil {
+		return fmt.Errorf("failed to create closed_at index: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateExpiresAtColumn adds the expires_at column, the time after which the
+// expiry sweep tombstones an issue, with a partial index so the sweep scans
+// only issues that have an expiry.
+func MigrateExpiresAtColumn(db *sql.DB) error {
+	var columnExists bool
+	err := db.QueryRow(`
+		SELECT COUNT(*) > 0
+		FROM pragma_table_info('issues')
+		WHERE name = 'expires_at'
+	`).Scan(&columnExists)
+	if err != nil {
+		return fmt.Errorf("failed to check expires_at column: %w", err)
+	}
+
+	if !columnExists {
+		_, err = db.Exec(`ALTER TABLE issues ADD COLUMN expires_at DATETIME`)
+		if err != nil {
+			return fmt.Errorf("failed to add expires_at column: %w", err)
+		}
+	}
+
+	_, err = db.Exec(`CREATE INDEX IF NOT EXISTS idx_issues_expires_at ON issues(expires_at) WHERE expires_at IS NOT NULL`)
+	if err != nil {
+		return fmt.Errorf("failed to create expires_at index: %w", err)
+	}
+	return nil
+}
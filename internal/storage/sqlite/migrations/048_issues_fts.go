@@ -0,0 +1,71 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// IssuesFTSDescription is the SQL expression, over the issues row alias
+// "{row}", for the description text the search index holds: the blob content
+// when the column holds a description_blobs reference (U+FFFC "blob:" and the
+// hash), otherwise the column itself.
+const IssuesFTSDescription = `CASE WHEN substr({row}.description, 1, 6) = '` + "\uFFFC" + `blob:'
+		THEN COALESCE((SELECT content FROM description_blobs WHERE hash = substr({row}.description, 7)), '')
+		ELSE {row}.description END`
+
+// issuesFTSTriggers keep issues_fts in step with issues, keyed by the issues
+// rowid.
+var issuesFTSTriggers = map[string]string{
+	"issues_fts_insert": `CREATE TRIGGER issues_fts_insert AFTER INSERT ON issues BEGIN
+		INSERT INTO issues_fts (rowid, title, description) VALUES (new.rowid, new.title, {new});
+	END`,
+	"issues_fts_update": `CREATE TRIGGER issues_fts_update AFTER UPDATE OF id, title, description ON issues BEGIN
+		DELETE FROM issues_fts WHERE rowid = old.rowid;
+		INSERT INTO issues_fts (rowid, title, description) VALUES (new.rowid, new.title, {new});
+	END`,
+	"issues_fts_delete": `CREATE TRIGGER issues_fts_delete AFTER DELETE ON issues BEGIN
+		DELETE FROM issues_fts WHERE rowid = old.rowid;
+	END`,
+}
+
+// MigrateIssuesFTS adds issues_fts, an FTS5 index over issue titles and
+// descriptions maintained by triggers on issues. When the table or any
+// trigger had to be created, the index is repopulated from issues, since
+// writes made without the triggers are missing from it.
+func MigrateIssuesFTS(db *sql.DB) error {
+	var count int
+	if err := db.QueryRow(`
+		SELECT COUNT(*) FROM sqlite_master
+		WHERE (type = 'table' AND name = 'issues_fts')
+		   OR (type = 'trigger' AND name IN ('issues_fts_insert', 'issues_fts_update', 'issues_fts_delete'))
+	`).Scan(&count); err != nil {
+		return fmt.Errorf("failed to check for issues_fts: %w", err)
+	}
+	if count == 1+len(issuesFTSTriggers) {
+		return nil
+	}
+
+	if _, err := db.Exec(`CREATE VIRTUAL TABLE IF NOT EXISTS issues_fts USING fts5(title, description)`); err != nil {
+		return fmt.Errorf("failed to create issues_fts table: %w", err)
+	}
+	for name, trigger := range issuesFTSTriggers {
+		if _, err := db.Exec(`DROP TRIGGER IF EXISTS ` + name); err != nil {
+			return fmt.Errorf("failed to drop %s trigger: %w", name, err)
+		}
+		stmt := strings.ReplaceAll(trigger, "{new}", strings.ReplaceAll(IssuesFTSDescription, "{row}", "new"))
+		if _, err := db.Exec(stmt); err != nil {
+			return fmt.Errorf("failed to create %s trigger: %w", name, err)
+		}
+	}
+
+	if _, err := db.Exec(`DELETE FROM issues_fts`); err != nil {
+		return fmt.Errorf("failed to clear issues_fts: %w", err)
+	}
+	_, err := db.Exec(`INSERT INTO issues_fts (rowid, title, description) SELECT rowid, title, ` +
+		strings.ReplaceAll(IssuesFTSDescription, "{row}", "issues") + ` FROM issues`)
+	if err != nil {
+		return fmt.Errorf("failed to populate issues_fts: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateShadowImportLog adds the shadow_import_log table, where shadow
+// imports record the operations they would have applied, one run after
+// another, without touching the issues themselves.
+func MigrateShadowImportLog(db *sql.DB) error {
+	var tableName string
+	err := db.QueryRow(`
+		SELECT name FROM sqlite_master
+		WHERE type='table' AND name='shadow_import_log'
+	`).Scan(&tableName)
+
+	if err == sql.ErrNoRows {
+		_, err := db.Exec(`
+			CREATE TABLE shadow_import_log (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				run INTEGER NOT NULL,
+				source TEXT NOT NULL DEFAULT '',
+				issue_id TEXT NOT NULL,
+				operation TEXT NOT NULL,
+				reason TEXT NOT NULL DEFAULT '',
+				old_hash TEXT NOT NULL DEFAULT '',
+				new_hash TEXT NOT NULL DEFAULT '',
+				payload TEXT,
+				recorded_at DATETIME NOT NULL
+			)
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create shadow_import_log table: %w", err)
+		}
+		_, err = db.Exec(`CREATE INDEX idx_shadow_import_log_source ON shadow_import_log(source, run)`)
+		if err != nil {
+			return fmt.Errorf("failed to create shadow_import_log index: %w", err)
+		}
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to check for shadow_import_log table: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateChecklistItems adds the checklist_items table, which holds the
+// ordered checklist (text and done flag per item) inside each issue.
+func MigrateChecklistItems(db *sql.DB) error {
+	var tableName string
+	err := db.QueryRow(`
+		SELECT name FROM sqlite_master
+		WHERE type='table' AND name='checklist_items'
+	`).Scan(&tableName)
+
+	if err == sql.ErrNoRows {
+		_, err := db.Exec(`
+			CREATE TABLE checklist_items (
+				issue_id TEXT NOT NULL,
+				position INTEGER NOT NULL,
+				text TEXT NOT NULL,
+				done INTEGER NOT NULL DEFAULT 0,
+				PRIMARY KEY (issue_id, position),
+				FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+			)
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create checklist_items table: %w", err)
+		}
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to check for checklist_items table: %w", err)
+	}
+
+	return nil
+}
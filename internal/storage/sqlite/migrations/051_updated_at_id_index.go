@@ -0,0 +1,17 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateUpdatedAtIDIndex adds an index on issues(updated_at, id) so
+// recently-modified pages can be read in (updated_at, id) order, with ID
+// breaking timestamp ties, without sorting.
+func MigrateUpdatedAtIDIndex(db *sql.DB) error {
+	_, err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_issues_updated_at_id ON issues(updated_at, id)`)
+	if err != nil {
+		return fmt.Errorf("failed to create updated_at/id index: %w", err)
+	}
+	return nil
+}
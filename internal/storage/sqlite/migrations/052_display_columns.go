@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateDisplayColumns adds the color and display_order columns, cosmetic
+// metadata boards use to lay out issues, with an index for reading issues in
+// display order.
+func MigrateDisplayColumns(db *sql.DB) error {
+	for _, col := range []struct{ name, def string }{
+		{"color", `TEXT NOT NULL DEFAULT ''`},
+		{"display_order", `INTEGER NOT NULL DEFAULT 0`},
+	} {
+		var columnExists bool
+		err := db.QueryRow(`
+			SELECT COUNT(*) > 0
+			FROM pragma_table_info('issues')
+			WHERE name = ?
+		`, col.name).Scan(&columnExists)
+		if err != nil {
+			return fmt.Errorf("failed to check %s column: %w", col.name, err)
+		}
+		if columnExists {
+			continue
+		}
+		// #nosec G201 - column name and definition are fixed above
+		if _, err := db.Exec(fmt.Sprintf(`ALTER TABLE issues ADD COLUMN %s %s`, col.name, col.def)); err != nil {
+			return fmt.Errorf("failed to add %s column: %w", col.name, err)
+		}
+	}
+
+	_, err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_issues_display_order ON issues(display_order, id)`)
+	if err != nil {
+		return fmt.Errorf("failed to create display_order index: %w", err)
+	}
+	return nil
+}
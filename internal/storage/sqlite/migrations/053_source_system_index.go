@@ -0,0 +1,16 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateSourceSystemIndex adds an index on issues(source_system, id) so the
+// issues of one origin (e.g. "jira") can be listed without a table scan.
+func MigrateSourceSystemIndex(db *sql.DB) error {
+	_, err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_issues_source_system ON issues(source_system, id)`)
+	if err != nil {
+		return fmt.Errorf("failed to create source_system index: %w", err)
+	}
+	return nil
+}
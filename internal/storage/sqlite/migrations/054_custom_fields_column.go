@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateCustomFieldsColumn adds the custom_fields column, a JSON object of
+// the unknown fields an import preserved so they survive export.
+func MigrateCustomFieldsColumn(db *sql.DB) error {
+	var columnExists bool
+	err := db.QueryRow(`
+		SELECT COUNT(*) > 0
+		FROM pragma_table_info('issues')
+		WHERE name = 'custom_fields'
+	`).Scan(&columnExists)
+	if err != nil {
+		return fmt.Errorf("failed to check custom_fields column: %w", err)
+	}
+	if columnExists {
+		return nil
+	}
+
+	_, err = db.Exec(`ALTER TABLE issues ADD COLUMN custom_fields TEXT NOT NULL DEFAULT ''`)
+	if err != nil {
+		return fmt.Errorf("failed to add custom_fields column: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateIssueTemplatesTable adds the issue_templates table, which holds the
+// skeletons issues are instantiated from (see CreateFromTemplate).
+func MigrateIssueTemplatesTable(db *sql.DB) error {
+	var tableName string
+	err := db.QueryRow(`
+		SELECT name FROM sqlite_master
+		WHERE type='table' AND name='issue_templates'
+	`).Scan(&tableName)
+
+	if err == sql.ErrNoRows {
+		_, err := db.Exec(`
+			CREATE TABLE issue_templates (
+				id TEXT PRIMARY KEY,
+				name TEXT NOT NULL DEFAULT '',
+				title TEXT NOT NULL DEFAULT '',
+				description TEXT NOT NULL DEFAULT '',
+				design TEXT NOT NULL DEFAULT '',
+				acceptance_criteria TEXT NOT NULL DEFAULT '',
+				notes TEXT NOT NULL DEFAULT '',
+				issue_type TEXT NOT NULL DEFAULT '',
+				priority INTEGER NOT NULL DEFAULT 2,
+				labels TEXT NOT NULL DEFAULT '',
+				custom_fields TEXT NOT NULL DEFAULT '',
+				created_at DATETIME NOT NULL,
+				updated_at DATETIME NOT NULL
+			)
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create issue_templates table: %w", err)
+		}
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to check for issue_templates table: %w", err)
+	}
+
+	return nil
+}
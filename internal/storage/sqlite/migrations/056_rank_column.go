@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateRankColumn adds the rank column, a lexorank-style string that orders
+// a manually prioritized backlog, with an index for reading issues in rank
+// order.
+func MigrateRankColumn(db *sql.DB) error {
+	var columnExists bool
+	err := db.QueryRow(`
+		SELECT COUNT(*) > 0
+		FROM pragma_table_info('issues')
+		WHERE name = 'rank'
+	`).Scan(&columnExists)
+	if err != nil {
+		return fmt.Errorf("failed to check rank column: %w", err)
+	}
+	if !columnExists {
+		_, err = db.Exec(`ALTER TABLE issues ADD COLUMN rank TEXT NOT NULL DEFAULT ''`)
+		if err != nil {
+			return fmt.Errorf("failed to add rank column: %w", err)
+		}
+	}
+
+	_, err = db.Exec(`CREATE INDEX IF NOT EXISTS idx_issues_rank ON issues(rank, id)`)
+	if err != nil {
+		return fmt.Errorf("failed to create rank index: %w", err)
+	}
+	return nil
+}
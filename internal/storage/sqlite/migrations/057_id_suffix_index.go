@@ -0,0 +1,19 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateIDSuffixIndex adds an expression index on the suffix of each issue ID
+// (the trailing hash or number, with any child path, that follows the
+// prefix), so imports can check that suffixes are unique across prefixes.
+// The expression must match idSuffixExpr in the sqlite package exactly, or
+// queries will not use the index.
+func MigrateIDSuffixIndex(db *sql.DB) error {
+	_, err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_issues_id_suffix ON issues(substr(id, length(rtrim(id, '0123456789abcdefghijklmnopqrstuvwxyz.')) + 1))`)
+	if err != nil {
+		return fmt.Errorf("failed to create id suffix index: %w", err)
+	}
+	return nil
+}
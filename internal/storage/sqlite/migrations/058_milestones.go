@@ -0,0 +1,46 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateMilestones adds the milestones table and the milestone_id column
+// assigning issues to them, with an index for listing a milestone's issues.
+func MigrateMilestones(db *sql.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS milestones (
+			id TEXT PRIMARY KEY,
+			name TEXT NOT NULL,
+			start_at DATETIME,
+			end_at DATETIME,
+			created_at DATETIME NOT NULL,
+			updated_at DATETIME NOT NULL
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create milestones table: %w", err)
+	}
+
+	var columnExists bool
+	err = db.QueryRow(`
+		SELECT COUNT(*) > 0
+		FROM pragma_table_info('issues')
+		WHERE name = 'milestone_id'
+	`).Scan(&columnExists)
+	if err != nil {
+		return fmt.Errorf("failed to check milestone_id column: %w", err)
+	}
+	if !columnExists {
+		_, err = db.Exec(`ALTER TABLE issues ADD COLUMN milestone_id TEXT NOT NULL DEFAULT ''`)
+		if err != nil {
+			return fmt.Errorf("failed to add milestone_id column: %w", err)
+		}
+	}
+
+	_, err = db.Exec(`CREATE INDEX IF NOT EXISTS idx_issues_milestone ON issues(milestone_id, id)`)
+	if err != nil {
+		return fmt.Errorf("failed to create milestone index: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateArchivedIssues adds the archived_issues and archived_events tables
+// that ArchiveClosedIssues moves old closed issues and their history into.
+func MigrateArchivedIssues(db *sql.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS archived_issues (
+			id TEXT PRIMARY KEY,
+			title TEXT NOT NULL,
+			description TEXT NOT NULL DEFAULT '',
+			closed_at DATETIME,
+			archived_at DATETIME NOT NULL,
+			data TEXT NOT NULL
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create archived_issues table: %w", err)
+	}
+	_, err = db.Exec(`CREATE INDEX IF NOT EXISTS idx_archived_issues_closed_at ON archived_issues(closed_at)`)
+	if err != nil {
+		return fmt.Errorf("failed to create archived issue index: %w", err)
+	}
+
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS archived_events (
+			id INTEGER PRIMARY KEY,
+			issue_id TEXT NOT NULL,
+			event_type TEXT NOT NULL,
+			actor TEXT NOT NULL,
+			old_value TEXT,
+			new_value TEXT,
+			comment TEXT,
+			created_at DATETIME NOT NULL,
+			FOREIGN KEY (issue_id) REFERENCES archived_issues(id) ON DELETE CASCADE
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create archived_events table: %w", err)
+	}
+	_, err = db.Exec(`CREATE INDEX IF NOT EXISTS idx_archived_events_issue ON archived_events(issue_id)`)
+	if err != nil {
+		return fmt.Errorf("failed to create archived event index: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateMaintenanceLog adds the maintenance_log table, where database-wide
+// maintenance operations such as content hash migrations record what they
+// did.
+func MigrateMaintenanceLog(db *sql.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS maintenance_log (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			operation TEXT NOT NULL,
+			details TEXT NOT NULL DEFAULT '{}',
+			recorded_at DATETIME NOT NULL
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create maintenance_log table: %w", err)
+	}
+	_, err = db.Exec(`CREATE INDEX IF NOT EXISTS idx_maintenance_log_operation ON maintenance_log(operation, id)`)
+	if err != nil {
+		return fmt.Errorf("failed to create maintenance_log index: %w", err)
+	}
+	return nil
+}
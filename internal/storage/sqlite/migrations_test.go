@@ -509,9 +509,16 @@ func TestMigrateContentHashColumn(t *testing.T) {
 				payload TEXT DEFAULT '',
 				due_at DATETIME,
 				defer_until DATETIME,
+				expires_at DATETIME,
+				color TEXT NOT NULL DEFAULT '',
+				display_order INTEGER NOT NULL DEFAULT 0,
+				source_system TEXT DEFAULT '',
+				custom_fields TEXT NOT NULL DEFAULT '',
+				rank TEXT NOT NULL DEFAULT '',
+				milestone_id TEXT NOT NULL DEFAULT '',
 				CHECK ((status = 'closed') = (closed_at IS NOT NULL))
 			);
-			INSERT INTO issues SELECT id, title, description, design, acceptance_criteria, notes, status, priority, issue_type, assignee, estimated_minutes, created_at, '', '', updated_at, closed_at, '', external_ref, compaction_level, compacted_at, original_size, compacted_at_commit, source_repo, '', NULL, '', '', '', '', 0, 0, 0, 0, '', '', 0, '', '', '', '', NULL, '', '', '', '', '', '', '', NULL, NULL FROM issues_backup;
+			INSERT INTO issues SELECT id, title, description, design, acceptance_criteria, notes, status, priority, issue_type, assignee, estimated_minutes, created_at, '', '', updated_at, closed_at, '', external_ref, compaction_level, compacted_at, original_size, compacted_at_commit, source_repo, '', NULL, '', '', '', '', 0, 0, 0, 0, '', '', 0, '', '', '', '', NULL, '', '', '', '', '', '', '', NULL, NULL, NULL, '', 0, '', '', '', '' FROM issues_backup;
 			DROP TABLE issues_backup;
 		`)
 		if err != nil {
@@ -0,0 +1,160 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+const milestoneColumns = `id, name, start_at, end_at, created_at, updated_at`
+
+// SaveMilestone stores m, replacing any milestone with the same ID, after
+// checking it with Milestone.Validate. Zero timestamps are set to now.
+func (s *SQLiteStorage) SaveMilestone(ctx context.Context, m *types.Milestone) error {
+	return s.withTx(ctx, func(conn *sql.Conn) error {
+		return saveMilestone(ctx, conn, m)
+	})
+}
+
+// SaveMilestone stores m within the transaction.
+func (t *sqliteTxStorage) SaveMilestone(ctx context.Context, m *types.Milestone) error {
+	return saveMilestone(ctx, t.conn, m)
+}
+
+// GetMilestone returns the milestone with the given ID, or nil if there is
+// none.
+func (s *SQLiteStorage) GetMilestone(ctx context.Context, id string) (*types.Milestone, error) {
+	s.checkFreshness()
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+	return getMilestone(ctx, s.db, id)
+}
+
+// GetMilestone returns the milestone with the given ID within the
+// transaction, or nil if there is none.
+func (t *sqliteTxStorage) GetMilestone(ctx context.Context, id string) (*types.Milestone, error) {
+	return getMilestone(ctx, t.conn, id)
+}
+
+// ListMilestones returns every milestone, by ID.
+func (s *SQLiteStorage) ListMilestones(ctx context.Context) ([]*types.Milestone, error) {
+	s.checkFreshness()
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	rows, err := s.db.QueryContext(ctx, `SELECT `+milestoneColumns+` FROM milestones ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list milestones: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	milestones := []*types.Milestone{}
+	for rows.Next() {
+		m, err := scanMilestone(rows)
+		if err != nil {
+			return nil, err
+		}
+		milestones = append(milestones, m)
+	}
+	return milestones, wrapDBError("iterate milestones", rows.Err())
+}
+
+// ExportMilestones writes every milestone to w as one types.MilestoneRecord
+// line each, by ID. importer.ParseMilestones reads them back; the issues'
+// MilestoneID references travel with the issue export.
+func (s *SQLiteStorage) ExportMilestones(ctx context.Context, w io.Writer) error {
+	milestones, err := s.ListMilestones(ctx)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	for _, m := range milestones {
+		if err := enc.Encode(types.MilestoneRecord{Milestone: m}); err != nil {
+			return fmt.Errorf("failed to write milestone %s: %w", m.ID, err)
+		}
+	}
+	return nil
+}
+
+// ListIssuesByMilestone returns the issues planned for milestoneID, ordered
+// by ID. Tombstones are excluded. Issues may reference a milestone that was
+// never stored (see the importer's orphan handling), so an unknown milestone
+// is not an error. The scan uses idx_issues_milestone.
+func (s *SQLiteStorage) ListIssuesByMilestone(ctx context.Context, milestoneID string) ([]*types.Issue, error) {
+	if milestoneID == "" {
+		return nil, fmt.Errorf("milestone id is required")
+	}
+	s.checkFreshness()
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+rangeIssueColumns+`
+		FROM issues
+		WHERE milestone_id = ?
+		  AND status != 'tombstone'
+		ORDER BY milestone_id, id
+	`, milestoneID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issues by milestone: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return scanIssueList(ctx, s, rows)
+}
+
+func saveMilestone(ctx context.Context, db dbExecutor, m *types.Milestone) error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = now
+	}
+	if m.UpdatedAt.IsZero() {
+		m.UpdatedAt = now
+	}
+
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO milestones (`+milestoneColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			name = excluded.name, start_at = excluded.start_at, end_at = excluded.end_at,
+			created_at = excluded.created_at, updated_at = excluded.updated_at
+	`, m.ID, m.Name, m.StartAt, m.EndAt, m.CreatedAt, m.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save milestone %s: %w", m.ID, err)
+	}
+	return nil
+}
+
+func getMilestone(ctx context.Context, db dbExecutor, id string) (*types.Milestone, error) {
+	rows, err := db.QueryContext(ctx, `SELECT `+milestoneColumns+` FROM milestones WHERE id = ?`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get milestone: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	if !rows.Next() {
+		return nil, wrapDBError("get milestone", rows.Err())
+	}
+	return scanMilestone(rows)
+}
+
+func scanMilestone(rows *sql.Rows) (*types.Milestone, error) {
+	var m types.Milestone
+	var startAt, endAt sql.NullString
+	var createdAt, updatedAt string
+	if err := rows.Scan(&m.ID, &m.Name, &startAt, &endAt, &createdAt, &updatedAt); err != nil {
+		return nil, fmt.Errorf("failed to scan milestone: %w", err)
+	}
+	m.StartAt = parseNullableTimeString(startAt)
+	m.EndAt = parseNullableTimeString(endAt)
+	m.CreatedAt = parseTimeString(createdAt)
+	m.UpdatedAt = parseTimeString(updatedAt)
+	return &m, nil
+}
@@ -0,0 +1,92 @@
+package sqlite
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestMilestones(t *testing.T) {
+	env := newTestEnv(t)
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 14)
+	sprint := &types.Milestone{ID: "sprint-1", Name: "Sprint 1", StartAt: &start, EndAt: &end}
+	if err := env.Store.SaveMilestone(env.Ctx, sprint); err != nil {
+		t.Fatalf("SaveMilestone failed: %v", err)
+	}
+
+	t.Run("stored milestones read back", func(t *testing.T) {
+		got, err := env.Store.GetMilestone(env.Ctx, "sprint-1")
+		if err != nil {
+			t.Fatalf("GetMilestone failed: %v", err)
+		}
+		if got == nil || got.Name != "Sprint 1" || got.StartAt == nil || !got.StartAt.Equal(start) || got.EndAt == nil || !got.EndAt.Equal(end) {
+			t.Errorf("GetMilestone = %+v, want %+v", got, sprint)
+		}
+		if missing, err := env.Store.GetMilestone(env.Ctx, "nope"); err != nil || missing != nil {
+			t.Errorf("GetMilestone(nope) = %v, %v, want nil", missing, err)
+		}
+	})
+
+	t.Run("endpoints are validated", func(t *testing.T) {
+		before := start.AddDate(0, 0, -1)
+		err := env.Store.SaveMilestone(env.Ctx, &types.Milestone{ID: "backwards", Name: "Backwards", StartAt: &start, EndAt: &before})
+		if err == nil || !strings.Contains(err.Error(), "before start") {
+			t.Errorf("SaveMilestone(end before start) = %v, want an error", err)
+		}
+		if err := env.Store.SaveMilestone(env.Ctx, &types.Milestone{ID: "someday", Name: "Someday", StartAt: &start}); err != nil {
+			t.Errorf("SaveMilestone(open end) = %v", err)
+		}
+	})
+
+	t.Run("issues are listed by milestone", func(t *testing.T) {
+		planned := &types.Issue{Title: "Planned", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, MilestoneID: "sprint-1"}
+		if err := env.Store.CreateIssue(env.Ctx, planned, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		moved := env.CreateIssue("Moved in")
+		if err := env.Store.UpdateIssue(env.Ctx, moved.ID, map[string]interface{}{"milestone_id": "sprint-1"}, "test-user"); err != nil {
+			t.Fatalf("UpdateIssue failed: %v", err)
+		}
+		env.CreateIssue("Unplanned")
+
+		issues, err := env.Store.ListIssuesByMilestone(env.Ctx, "sprint-1")
+		if err != nil {
+			t.Fatalf("ListIssuesByMilestone failed: %v", err)
+		}
+		if len(issues) != 2 {
+			t.Fatalf("ListIssuesByMilestone = %d issues, want 2", len(issues))
+		}
+		for _, issue := range issues {
+			if issue.MilestoneID != "sprint-1" {
+				t.Errorf("%s milestone = %q, want sprint-1", issue.ID, issue.MilestoneID)
+			}
+		}
+		got, err := env.Store.GetIssue(env.Ctx, moved.ID)
+		if err != nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		if got.ContentHash == moved.ContentHash {
+			t.Error("assigning a milestone should change the content hash")
+		}
+	})
+
+	t.Run("export writes milestone records", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := env.Store.ExportMilestones(env.Ctx, &buf); err != nil {
+			t.Fatalf("ExportMilestones failed: %v", err)
+		}
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		if len(lines) != 2 {
+			t.Fatalf("exported %d lines, want 2: %s", len(lines), buf.String())
+		}
+		var record types.MilestoneRecord
+		if err := json.Unmarshal([]byte(lines[0]), &record); err != nil || record.Milestone == nil || record.Milestone.ID != "someday" {
+			t.Errorf("first record = %s (%v), want someday", lines[0], err)
+		}
+	})
+}
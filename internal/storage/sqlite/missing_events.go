@@ -0,0 +1,71 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// issuesWithoutEventsWhere selects issues that have no events at all, as an
+// anti-join against idx_events_issue. PruneEvents always keeps an issue's last
+// event, so every issue created through the store has at least one.
+const issuesWithoutEventsWhere = `NOT EXISTS (SELECT 1 FROM events e WHERE e.issue_id = issues.id)`
+
+// ListIssuesWithoutEvents returns, in ID order, the IDs of issues that have no
+// events, not even the creation event. That only happens when an issue was
+// written around the store (e.g. by an import that bypassed
+// recordCreatedEvent), so it is a sign of corruption; BackfillCreatedEvents
+// repairs it. Tombstones are included.
+func (s *SQLiteStorage) ListIssuesWithoutEvents(ctx context.Context) ([]string, error) {
+	s.checkFreshness()
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	return listIssuesWithoutEvents(ctx, s.db)
+}
+
+// BackfillCreatedEvents records a synthetic creation event, dated at the
+// issue's created_at and attributed to actor, for every issue
+// ListIssuesWithoutEvents would report, in one transaction. It returns the
+// IDs of the repaired issues. The event carries only the issue's ID and title,
+// since its state at creation is unknown.
+func (s *SQLiteStorage) BackfillCreatedEvents(ctx context.Context, actor string) ([]string, error) {
+	var ids []string
+	err := s.withTx(ctx, func(conn *sql.Conn) error {
+		var err error
+		if ids, err = listIssuesWithoutEvents(ctx, conn); err != nil || len(ids) == 0 {
+			return err
+		}
+		_, err = conn.ExecContext(ctx, `
+			INSERT INTO events (issue_id, event_type, actor, new_value, created_at)
+			SELECT id, ?, ?, json_object('id', id, 'title', title), created_at
+			FROM issues
+			WHERE `+issuesWithoutEventsWhere+`
+		`, types.EventCreated, actor)
+		return wrapDBError("backfill creation events", err)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func listIssuesWithoutEvents(ctx context.Context, db dbExecutor) ([]string, error) {
+	rows, err := db.QueryContext(ctx, `SELECT id FROM issues WHERE `+issuesWithoutEventsWhere+` ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find issues without events: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan issue without events: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, wrapDBError("iterate issues without events", rows.Err())
+}
@@ -0,0 +1,61 @@
+package sqlite
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestIssuesWithoutEvents(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := env.Ctx
+
+	healthy := env.CreateIssue("Has its creation event")
+	created := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	orphan := &types.Issue{Title: "Lost its events", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask,
+		CreatedAt: created, UpdatedAt: created}
+	if err := env.Store.CreateIssue(ctx, orphan, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if _, err := env.Store.db.ExecContext(ctx, `DELETE FROM events WHERE issue_id = ?`, orphan.ID); err != nil {
+		t.Fatalf("failed to delete events: %v", err)
+	}
+
+	ids, err := env.Store.ListIssuesWithoutEvents(ctx)
+	if err != nil {
+		t.Fatalf("ListIssuesWithoutEvents failed: %v", err)
+	}
+	if want := []string{orphan.ID}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("ListIssuesWithoutEvents = %v, want %v", ids, want)
+	}
+
+	repaired, err := env.Store.BackfillCreatedEvents(ctx, "repair")
+	if err != nil {
+		t.Fatalf("BackfillCreatedEvents failed: %v", err)
+	}
+	if want := []string{orphan.ID}; !reflect.DeepEqual(repaired, want) {
+		t.Errorf("BackfillCreatedEvents = %v, want %v", repaired, want)
+	}
+	events, err := env.Store.GetEvents(ctx, orphan.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("expected one backfilled event, got %d", len(events))
+	}
+	if ev := events[0]; ev.EventType != types.EventCreated || ev.Actor != "repair" || !ev.CreatedAt.Equal(created) {
+		t.Errorf("backfilled event = %s by %s at %v, want %s by repair at %v", ev.EventType, ev.Actor, ev.CreatedAt, types.EventCreated, created)
+	}
+	if healthyEvents, err := env.Store.GetEvents(ctx, healthy.ID, 0); err != nil || len(healthyEvents) != 1 {
+		t.Errorf("healthy issue should keep its single event: %d, %v", len(healthyEvents), err)
+	}
+
+	if ids, err := env.Store.ListIssuesWithoutEvents(ctx); err != nil || len(ids) != 0 {
+		t.Errorf("after backfill ListIssuesWithoutEvents = %v, %v; want none", ids, err)
+	}
+	if repaired, err := env.Store.BackfillCreatedEvents(ctx, "repair"); err != nil || len(repaired) != 0 {
+		t.Errorf("second backfill = %v, %v; want nothing to repair", repaired, err)
+	}
+}
@@ -151,10 +151,11 @@ func (s *SQLiteStorage) importJSONLFile(ctx context.Context, jsonlPath, sourceRe
 
 	// Disable foreign keys on this connection to handle out-of-order deps
 	// (issue A may depend on issue B that appears later in the file)
-	_, err = conn.ExecContext(ctx, `PRAGMA foreign_keys = OFF`)
+	restoreForeignKeys, err := disableForeignKeys(ctx, conn)
 	if err != nil {
-		return 0, fmt.Errorf("failed to disable foreign keys: %w", err)
+		return 0, err
 	}
+	defer restoreForeignKeys()
 
 	// Begin transaction for bulk import
 	tx, err := conn.BeginTx(ctx, nil)
@@ -182,7 +183,7 @@ func (s *SQLiteStorage) importJSONLFile(ctx context.Context, jsonlPath, sourceRe
 
 		// Compute content hash if missing
 		if issue.ContentHash == "" {
-			issue.ContentHash = issue.ComputeContentHash()
+			issue.ContentHash = contentHash(ctx, tx, &issue)
 		}
 
 		// Insert or update issue (with federation trust model for types, bd-9ji4z)
@@ -382,6 +383,10 @@ func (s *SQLiteStorage) upsertIssueInTx(ctx context.Context, tx *sql.Tx, issue *
 		}
 	}
 
+	if err := packDescriptions(ctx, tx, issue.ID); err != nil {
+		return err
+	}
+
 	// Import dependencies if present
 	for _, dep := range issue.Dependencies {
 		_, err = tx.ExecContext(ctx, `
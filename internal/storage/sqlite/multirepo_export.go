@@ -3,7 +3,6 @@ package sqlite
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -48,6 +47,16 @@ func (s *SQLiteStorage) ExportToMultiRepo(ctx context.Context) (map[string]int,
 			return nil, fmt.Errorf("failed to get labels for %s: %w", issue.ID, err)
 		}
 		issue.Labels = labels
+		watchers, err := s.GetWatchers(ctx, issue.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get watchers for %s: %w", issue.ID, err)
+		}
+		issue.Watchers = watchers
+		checklist, err := s.GetChecklist(ctx, issue.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get checklist for %s: %w", issue.ID, err)
+		}
+		issue.Checklist = checklist
 	}
 
 	// Filter out wisps - they should never be exported to JSONL (bd-687g)
@@ -167,7 +176,7 @@ func (s *SQLiteStorage) exportToRepo(ctx context.Context, repoPath string, issue
 	}()
 
 	// Write JSONL
-	encoder := json.NewEncoder(f)
+	encoder := types.NewIssueEncoder(f)
 	for _, issue := range issues {
 		if err := encoder.Encode(issue); err != nil {
 			return 0, fmt.Errorf("failed to encode issue %s: %w", issue.ID, err)
@@ -0,0 +1,123 @@
+package sqlite
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+func TestUpdateIssueWithExpectedHash_Succeeds(t *testing.T) {
+	env := newTestEnv(t)
+	issue := env.CreateIssue("Original")
+
+	read, err := env.Store.GetIssue(env.Ctx, issue.ID)
+	if err != nil || read == nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+
+	if err := env.Store.UpdateIssueWithExpectedHash(env.Ctx, issue.ID, read.ContentHash, map[string]interface{}{"title": "Edited"}, "alice"); err != nil {
+		t.Fatalf("UpdateIssueWithExpectedHash failed: %v", err)
+	}
+
+	updated, err := env.Store.GetIssue(env.Ctx, issue.ID)
+	if err != nil || updated == nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if updated.Title != "Edited" {
+		t.Errorf("expected title Edited, got %q", updated.Title)
+	}
+	if updated.ContentHash == read.ContentHash {
+		t.Error("expected content hash to change after a content update")
+	}
+	if updated.ContentHash != updated.ComputeContentHash() {
+		t.Error("stored content hash does not match recomputed hash")
+	}
+}
+
+func TestUpdateIssueWithExpectedHash_ConcurrentModification(t *testing.T) {
+	env := newTestEnv(t)
+	issue := env.CreateIssue("Original")
+
+	// Two editors read the same version
+	aliceRead, err := env.Store.GetIssue(env.Ctx, issue.ID)
+	if err != nil || aliceRead == nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	bobRead, err := env.Store.GetIssue(env.Ctx, issue.ID)
+	if err != nil || bobRead == nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+
+	// Bob writes first
+	if err := env.Store.UpdateIssueWithExpectedHash(env.Ctx, issue.ID, bobRead.ContentHash, map[string]interface{}{"title": "Bob's title"}, "bob"); err != nil {
+		t.Fatalf("bob's update failed: %v", err)
+	}
+
+	// Alice's write is based on a stale read and must be rejected
+	err = env.Store.UpdateIssueWithExpectedHash(env.Ctx, issue.ID, aliceRead.ContentHash, map[string]interface{}{"title": "Alice's title"}, "alice")
+	if !errors.Is(err, ErrStaleWrite) {
+		t.Fatalf("expected ErrStaleWrite, got %v", err)
+	}
+	if !IsStaleWrite(err) {
+		t.Error("IsStaleWrite should report true")
+	}
+
+	final, err := env.Store.GetIssue(env.Ctx, issue.ID)
+	if err != nil || final == nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if final.Title != "Bob's title" {
+		t.Errorf("lost update: expected Bob's title to survive, got %q", final.Title)
+	}
+
+	// The rejected write must not leave an event behind
+	events, err := env.Store.GetEvents(env.Ctx, issue.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	for _, e := range events {
+		if e.Actor == "alice" {
+			t.Errorf("unexpected event recorded for rejected write: %+v", e)
+		}
+	}
+}
+
+func TestUpdateIssueWithExpectedHash_InTransaction(t *testing.T) {
+	env := newTestEnv(t)
+	issue := env.CreateIssue("Original")
+
+	read, err := env.Store.GetIssue(env.Ctx, issue.ID)
+	if err != nil || read == nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+
+	// Concurrent modification between read and write
+	if err := env.Store.UpdateIssue(env.Ctx, issue.ID, map[string]interface{}{"priority": 0}, "bob"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+
+	err = env.Store.RunInTransaction(env.Ctx, func(tx storage.Transaction) error {
+		return tx.(*sqliteTxStorage).UpdateIssueWithExpectedHash(env.Ctx, issue.ID, read.ContentHash, map[string]interface{}{"title": "Stale"}, "alice")
+	})
+	if !IsStaleWrite(err) {
+		t.Fatalf("expected ErrStaleWrite from transaction, got %v", err)
+	}
+
+	final, err := env.Store.GetIssue(env.Ctx, issue.ID)
+	if err != nil || final == nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if final.Title != "Original" {
+		t.Errorf("expected title unchanged, got %q", final.Title)
+	}
+}
+
+func TestUpdateIssueWithExpectedHash_RequiresHash(t *testing.T) {
+	env := newTestEnv(t)
+	issue := env.CreateIssue("Original")
+
+	if err := env.Store.UpdateIssueWithExpectedHash(env.Ctx, issue.ID, "", map[string]interface{}{"title": "x"}, "alice"); err == nil {
+		t.Fatal("expected error for empty expected hash")
+	}
+}
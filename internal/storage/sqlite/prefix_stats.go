@@ -0,0 +1,64 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// PrefixStats computes summary metrics over the issues whose IDs start with
+// prefix and the ID separator ("bd" matches "bd-a1b2" and "bd-web-c3d4"), in
+// SQL aggregates over the issues and events tables. Time to close is taken
+// from the event log: an issue reopened and closed again counts from
+// creation to its latest close event, and closed issues without one (e.g.
+// imported without history) are left out of the median. Open ages are
+// measured against the time of the call.
+func (s *SQLiteStorage) PrefixStats(ctx context.Context, prefix string) (*types.PrefixStats, error) {
+	if prefix == "" {
+		return nil, fmt.Errorf("prefix must not be empty")
+	}
+	s.checkFreshness()
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	pattern := escapeLike(prefix+getIDSeparator(ctx, s.db)) + "%"
+	stats := &types.PrefixStats{Prefix: prefix}
+	var avgOpenAge, medianToClose sql.NullFloat64
+	err := s.db.QueryRowContext(ctx, `
+		SELECT
+			COALESCE(SUM(CASE WHEN status != 'closed' THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN status = 'closed' THEN 1 ELSE 0 END), 0),
+			AVG(CASE WHEN status != 'closed' THEN (julianday(?) - julianday(created_at)) * 24 END)
+		FROM issues
+		WHERE status != 'tombstone' AND id LIKE ? ESCAPE '\'
+	`, time.Now().UTC(), pattern).Scan(&stats.OpenIssues, &stats.ClosedIssues, &avgOpenAge)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get prefix counts: %w", err)
+	}
+
+	// The median is the middle row, or the mean of the middle two
+	err = s.db.QueryRowContext(ctx, `
+		WITH closes AS (
+			SELECT (julianday(MAX(e.created_at)) - julianday(i.created_at)) * 24 AS hours
+			FROM issues i
+			JOIN events e ON e.issue_id = i.id AND e.event_type = ?
+			WHERE i.status = 'closed' AND i.id LIKE ? ESCAPE '\'
+			GROUP BY i.id
+		),
+		ranked AS (
+			SELECT hours, ROW_NUMBER() OVER (ORDER BY hours) AS n, COUNT(*) OVER () AS total
+			FROM closes
+		)
+		SELECT AVG(hours) FROM ranked WHERE n IN ((total + 1) / 2, (total + 2) / 2)
+	`, types.EventClosed, pattern).Scan(&medianToClose)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get median time to close: %w", err)
+	}
+
+	stats.AverageOpenAge = avgOpenAge.Float64
+	stats.MedianTimeToClose = medianToClose.Float64
+	return stats, nil
+}
@@ -0,0 +1,81 @@
+package sqlite
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestPrefixStats(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := env.Ctx
+	exec := func(query string, args ...interface{}) {
+		t.Helper()
+		if _, err := env.Store.db.ExecContext(ctx, query, args...); err != nil {
+			t.Fatalf("seed %q failed: %v", query, err)
+		}
+	}
+
+	now := time.Now().UTC()
+	base := now.Add(-100 * time.Hour)
+	// Open issues aged 10h and 20h
+	for _, age := range []time.Duration{10, 20} {
+		issue := env.CreateIssue("Open")
+		exec(`UPDATE issues SET created_at = ? WHERE id = ?`, now.Add(-age*time.Hour), issue.ID)
+	}
+	// Closed issues whose latest close came 2h, 4h and 10h after creation; the
+	// first was closed once before being reopened
+	for _, closes := range [][]time.Duration{{1, 2}, {4}, {10}} {
+		issue := env.CreateIssue("Closed")
+		if err := env.Store.CloseIssue(ctx, issue.ID, "done", "test-user", ""); err != nil {
+			t.Fatalf("CloseIssue failed: %v", err)
+		}
+		exec(`UPDATE issues SET created_at = ? WHERE id = ?`, base, issue.ID)
+		exec(`DELETE FROM events WHERE issue_id = ? AND event_type = ?`, issue.ID, types.EventClosed)
+		for _, after := range closes {
+			exec(`INSERT INTO events (issue_id, event_type, actor, created_at) VALUES (?, ?, 'test-user', ?)`,
+				issue.ID, types.EventClosed, base.Add(after*time.Hour))
+		}
+	}
+	// Closed without a close event: counted, but not in the median
+	imported := env.CreateIssue("Imported closed")
+	exec(`UPDATE issues SET status = 'closed', closed_at = ? WHERE id = ?`, now, imported.ID)
+	// Neither tombstones nor other prefixes count
+	deleted := env.CreateIssue("Deleted")
+	exec(`UPDATE issues SET status = 'tombstone', deleted_at = ? WHERE id = ?`, now, deleted.ID)
+	exec(`INSERT INTO issues (id, title, status, priority, issue_type, created_at, updated_at) VALUES ('bdx-1', 'Other', 'open', 2, 'task', ?, ?)`, base, base)
+
+	stats, err := env.Store.PrefixStats(ctx, "bd")
+	if err != nil {
+		t.Fatalf("PrefixStats failed: %v", err)
+	}
+	if stats.Prefix != "bd" || stats.OpenIssues != 2 || stats.ClosedIssues != 4 {
+		t.Errorf("counts = %+v, want 2 open and 4 closed", stats)
+	}
+	// The call runs just after now, so open ages are slightly over
+	if stats.AverageOpenAge < 15 || stats.AverageOpenAge > 15.1 {
+		t.Errorf("average open age = %v, want 15h", stats.AverageOpenAge)
+	}
+	if math.Abs(stats.MedianTimeToClose-4) > 1e-6 {
+		t.Errorf("median time to close = %v, want 4h", stats.MedianTimeToClose)
+	}
+
+	// An even number of closes takes the mean of the middle two
+	exec(`DELETE FROM events WHERE issue_id = ? AND event_type = ?`, imported.ID, types.EventClosed)
+	exec(`UPDATE issues SET created_at = ? WHERE id = ?`, base, imported.ID)
+	exec(`INSERT INTO events (issue_id, event_type, actor, created_at) VALUES (?, ?, 'test-user', ?)`,
+		imported.ID, types.EventClosed, base.Add(20*time.Hour))
+	if stats, err = env.Store.PrefixStats(ctx, "bd"); err != nil || math.Abs(stats.MedianTimeToClose-7) > 1e-6 {
+		t.Errorf("median of four = %+v, %v; want 7h", stats, err)
+	}
+
+	empty, err := env.Store.PrefixStats(ctx, "none")
+	if err != nil || empty.OpenIssues != 0 || empty.ClosedIssues != 0 || empty.AverageOpenAge != 0 || empty.MedianTimeToClose != 0 {
+		t.Errorf("unknown prefix = %+v, %v; want zero stats", empty, err)
+	}
+	if _, err := env.Store.PrefixStats(ctx, ""); err == nil {
+		t.Error("expected an empty prefix to be rejected")
+	}
+}
@@ -0,0 +1,122 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// PrefixInfo describes one ID prefix known to the database.
+type PrefixInfo struct {
+	Prefix     string `json:"prefix"`
+	Base       bool   `json:"base,omitempty"`        // The configured issue_prefix
+	SubPrefix  bool   `json:"sub_prefix,omitempty"`  // Extends the base prefix, e.g. "bd-mol" under "bd"
+	Allowed    bool   `json:"allowed,omitempty"`     // Listed in allowed_prefixes
+	IssueCount int    `json:"issue_count"`           // Non-tombstone issues, including hierarchical children
+	LastNumber int    `json:"last_number,omitempty"` // Highest sequential top-level number in use (bd-42 -> 42); 0 for hash-only prefixes
+}
+
+// ListPrefixes returns the base prefix, every prefix listed in
+// allowed_prefixes, and every prefix that appears on a stored issue, with
+// issue counts and sequential counter positions. The base prefix comes first;
+// the rest are sorted by name. Config and issues are read in one snapshot.
+func (s *SQLiteStorage) ListPrefixes(ctx context.Context) ([]*PrefixInfo, error) {
+	byPrefix := make(map[string]*PrefixInfo)
+	var base string
+
+	err := s.withReadTx(ctx, func(conn *sql.Conn) error {
+		config := make(map[string]string)
+		rows, err := conn.QueryContext(ctx, `
+			SELECT key, value FROM config WHERE key IN ('issue_prefix', 'allowed_prefixes', 'id.separator')
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to read prefix config: %w", err)
+		}
+		for rows.Next() {
+			var key, value string
+			if err := rows.Scan(&key, &value); err != nil {
+				_ = rows.Close()
+				return fmt.Errorf("failed to scan config: %w", err)
+			}
+			config[key] = value
+		}
+		_ = rows.Close()
+		if err := rows.Err(); err != nil {
+			return wrapDBError("iterate prefix config", err)
+		}
+
+		sep := config[storage.IDSeparatorConfigKey]
+		if sep == "" {
+			sep = utils.DefaultIDSeparator
+		}
+		get := func(prefix string) *PrefixInfo {
+			info, ok := byPrefix[prefix]
+			if !ok {
+				info = &PrefixInfo{Prefix: prefix}
+				byPrefix[prefix] = info
+			}
+			return info
+		}
+
+		base = strings.TrimSuffix(strings.TrimSpace(config["issue_prefix"]), sep)
+		if base != "" {
+			get(base).Base = true
+		}
+		for _, prefix := range strings.Split(config["allowed_prefixes"], ",") {
+			prefix = strings.TrimSuffix(strings.TrimSpace(prefix), sep)
+			if prefix != "" {
+				get(prefix).Allowed = true
+			}
+		}
+
+		rows, err = conn.QueryContext(ctx, `SELECT id FROM issues WHERE status != ?`, types.StatusTombstone)
+		if err != nil {
+			return fmt.Errorf("failed to list issue IDs: %w", err)
+		}
+		defer func() { _ = rows.Close() }()
+		for rows.Next() {
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				return fmt.Errorf("failed to scan issue ID: %w", err)
+			}
+			prefix := utils.ExtractIssuePrefixWithSeparator(id, sep)
+			info := get(prefix)
+			info.IssueCount++
+			if n, err := strconv.Atoi(strings.TrimPrefix(id, prefix+sep)); err == nil && n > info.LastNumber {
+				info.LastNumber = n
+			}
+		}
+		if err := rows.Err(); err != nil {
+			return wrapDBError("iterate issue IDs", err)
+		}
+
+		if base != "" {
+			for prefix, info := range byPrefix {
+				info.SubPrefix = strings.HasPrefix(prefix, base+sep)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	prefixes := make([]*PrefixInfo, 0, len(byPrefix))
+	for _, info := range byPrefix {
+		prefixes = append(prefixes, info)
+	}
+	sort.Slice(prefixes, func(i, j int) bool {
+		if prefixes[i].Base != prefixes[j].Base {
+			return prefixes[i].Base
+		}
+		return prefixes[i].Prefix < prefixes[j].Prefix
+	})
+	return prefixes, nil
+}
@@ -0,0 +1,56 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestListPrefixes(t *testing.T) {
+	env := newTestEnv(t)
+	if err := env.Store.SetConfig(env.Ctx, "allowed_prefixes", "gt-, mol"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+
+	env.CreateIssueWithID("bd-3", "Sequential")
+	env.CreateIssueWithID("bd-12", "Sequential")
+	env.CreateIssueWithID("bd-12.1", "Child")
+	// Fixed hash ID: a random one can be all digits and count as sequential
+	wisp := &types.Issue{ID: "bd-wisp-x9k2", Title: "Wisp", IDPrefix: "wisp", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := env.Store.CreateIssue(env.Ctx, wisp, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	// Imports skip prefix validation, registering prefixes from other repos
+	imported := []*types.Issue{
+		{ID: "gt-a3f8", Title: "Town issue", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+		{ID: "gt-7", Title: "Town issue", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+		{ID: "ext-b2c4", Title: "External", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+	}
+	if err := env.Store.CreateIssuesWithFullOptions(env.Ctx, imported, "import", BatchCreateOptions{SkipPrefixValidation: true}); err != nil {
+		t.Fatalf("CreateIssuesWithFullOptions failed: %v", err)
+	}
+
+	prefixes, err := env.Store.ListPrefixes(env.Ctx)
+	if err != nil {
+		t.Fatalf("ListPrefixes failed: %v", err)
+	}
+	want := []PrefixInfo{
+		{Prefix: "bd", Base: true, IssueCount: 3, LastNumber: 12},
+		{Prefix: "bd-wisp", SubPrefix: true, IssueCount: 1},
+		{Prefix: "ext", IssueCount: 1},
+		{Prefix: "gt", Allowed: true, IssueCount: 2, LastNumber: 7},
+		{Prefix: "mol", Allowed: true},
+	}
+	if len(prefixes) != len(want) {
+		for _, p := range prefixes {
+			t.Logf("got %+v", *p)
+		}
+		t.Fatalf("expected %d prefixes, got %d", len(want), len(prefixes))
+	}
+	for i, w := range want {
+		if *prefixes[i] != w {
+			t.Errorf("prefix %d: got %+v, want %+v", i, *prefixes[i], w)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// FieldProvenance records which import source last set a field of an issue.
+type FieldProvenance struct {
+	Field     string    `json:"field"`
+	Source    string    `json:"source"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// RecordFieldProvenance records source as the last writer of each of fields
+// on issueID at the given time, replacing earlier provenance for those fields.
+func (s *SQLiteStorage) RecordFieldProvenance(ctx context.Context, issueID string, fields []string, source string, at time.Time) error {
+	return recordFieldProvenance(ctx, s.db, issueID, fields, source, at)
+}
+
+// RecordFieldProvenance records field provenance within the transaction.
+func (t *sqliteTxStorage) RecordFieldProvenance(ctx context.Context, issueID string, fields []string, source string, at time.Time) error {
+	return recordFieldProvenance(ctx, t.conn, issueID, fields, source, at)
+}
+
+func recordFieldProvenance(ctx context.Context, exec dbExecutor, issueID string, fields []string, source string, at time.Time) error {
+	if source == "" {
+		return fmt.Errorf("provenance source is required")
+	}
+	for _, field := range fields {
+		_, err := exec.ExecContext(ctx, `
+			INSERT INTO field_provenance (issue_id, field, source, updated_at)
+			VALUES (?, ?, ?, ?)
+			ON CONFLICT(issue_id, field) DO UPDATE SET
+				source = excluded.source,
+				updated_at = excluded.updated_at
+		`, issueID, field, source, at)
+		if err != nil {
+			return fmt.Errorf("failed to record provenance of %s.%s: %w", issueID, field, err)
+		}
+	}
+	return nil
+}
+
+// GetFieldProvenance returns the recorded provenance for each field of an
+// issue, keyed by field name. Fields never written by a tracked import are absent.
+func (s *SQLiteStorage) GetFieldProvenance(ctx context.Context, issueID string) (map[string]FieldProvenance, error) {
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT field, source, updated_at FROM field_provenance WHERE issue_id = ?
+	`, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get field provenance: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	result := make(map[string]FieldProvenance)
+	for rows.Next() {
+		var p FieldProvenance
+		if err := rows.Scan(&p.Field, &p.Source, &p.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan field provenance: %w", err)
+		}
+		result[p.Field] = p
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,44 @@
+package sqlite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+func TestRecordFieldProvenance(t *testing.T) {
+	env := newTestEnv(t)
+	issue := env.CreateIssue("Tracked")
+
+	first := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	if err := env.Store.RecordFieldProvenance(env.Ctx, issue.ID, []string{"title", "status"}, "jira", first); err != nil {
+		t.Fatalf("RecordFieldProvenance failed: %v", err)
+	}
+
+	second := first.Add(time.Hour)
+	err := env.Store.RunInTransaction(env.Ctx, func(tx storage.Transaction) error {
+		return tx.(*sqliteTxStorage).RecordFieldProvenance(env.Ctx, issue.ID, []string{"title"}, "github", second)
+	})
+	if err != nil {
+		t.Fatalf("RecordFieldProvenance in transaction failed: %v", err)
+	}
+
+	prov, err := env.Store.GetFieldProvenance(env.Ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetFieldProvenance failed: %v", err)
+	}
+	if len(prov) != 2 {
+		t.Fatalf("expected provenance for 2 fields, got %+v", prov)
+	}
+	if prov["title"].Source != "github" || !prov["title"].UpdatedAt.Equal(second) {
+		t.Errorf("expected title written last by github, got %+v", prov["title"])
+	}
+	if prov["status"].Source != "jira" || !prov["status"].UpdatedAt.Equal(first) {
+		t.Errorf("expected status written by jira, got %+v", prov["status"])
+	}
+
+	if err := env.Store.RecordFieldProvenance(env.Ctx, issue.ID, []string{"title"}, "", second); err == nil {
+		t.Error("expected error for empty source")
+	}
+}
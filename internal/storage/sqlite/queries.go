@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -91,14 +92,48 @@ func formatJSONStringArray(arr []string) string {
 
 // CreateIssue creates a new issue
 func (s *SQLiteStorage) CreateIssue(ctx context.Context, issue *types.Issue, actor string) error {
+	_, err := s.createIssue(ctx, issue, actor, false)
+	return err
+}
+
+// CreateOrGetIssue creates issue if no issue with its ID exists, and otherwise
+// returns the existing issue unmodified. created reports which happened, so
+// integrations can safely retry a create. Tombstones count as existing.
+// Issues without an ID always get a fresh one and are created.
+func (s *SQLiteStorage) CreateOrGetIssue(ctx context.Context, issue *types.Issue, actor string) (result *types.Issue, created bool, err error) {
+	created, err = s.createIssue(ctx, issue, actor, true)
+	if err != nil {
+		return nil, false, err
+	}
+	if created {
+		return issue, true, nil
+	}
+	existing, err := s.GetIssue(ctx, issue.ID)
+	if err != nil {
+		return nil, false, err
+	}
+	if existing == nil {
+		return nil, false, fmt.Errorf("issue %s: %w", issue.ID, ErrNotFound)
+	}
+	return existing, false, nil
+}
+
+// createIssue implements CreateIssue. With ifAbsent, an existing issue with
+// the same ID (including a tombstone) is left alone: the transaction is rolled
+// back and createIssue returns false instead of failing on the duplicate.
+func (s *SQLiteStorage) createIssue(ctx context.Context, issue *types.Issue, actor string, ifAbsent bool) (bool, error) {
+	if err := checkActor(ctx, s.db, actor); err != nil {
+		return false, err
+	}
+
 	// Fetch custom statuses and types for validation
 	customStatuses, err := s.GetCustomStatuses(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get custom statuses: %w", err)
+		return false, fmt.Errorf("failed to get custom statuses: %w", err)
 	}
 	customTypes, err := s.GetCustomTypes(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get custom types: %w", err)
+		return false, fmt.Errorf("failed to get custom types: %w", err)
 	}
 
 	// Set timestamps first so defensive fixes can use them
@@ -132,13 +167,17 @@ func (s *SQLiteStorage) CreateIssue(ctx context.Context, issue *types.Issue, act
 	}
 
 	// Validate issue before creating (with custom status and type support)
-	if err := issue.ValidateWithCustom(customStatuses, customTypes); err != nil {
-		return fmt.Errorf("validation failed: %w", err)
+	if err := validateIssueWithLimits(issue, customStatuses, customTypes, getFieldLimits(ctx, s.db), s.issueValidator()); err != nil {
+		return false, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := assignExternalRefs(ctx, s.db, issue); err != nil {
+		return false, err
 	}
 
 	// Compute content hash
 	if issue.ContentHash == "" {
-		issue.ContentHash = issue.ComputeContentHash()
+		issue.ContentHash = contentHash(ctx, s.db, issue)
 	}
 
 	// Acquire a dedicated connection for the transaction.
@@ -147,7 +186,7 @@ func (s *SQLiteStorage) CreateIssue(ctx context.Context, issue *types.Issue, act
 	// use different connections for different queries.
 	conn, err := s.db.Conn(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to acquire connection: %w", err)
+		return false, fmt.Errorf("failed to acquire connection: %w", err)
 	}
 	defer func() { _ = conn.Close() }()
 
@@ -160,7 +199,7 @@ func (s *SQLiteStorage) CreateIssue(ctx context.Context, issue *types.Issue, act
 	//
 	// The connection's busy_timeout pragma (30s) handles retries if locked.
 	if _, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
-		return fmt.Errorf("failed to begin immediate transaction: %w", err)
+		return false, fmt.Errorf("failed to begin immediate transaction: %w", markBusy(err))
 	}
 
 	// Track commit state for defer cleanup
@@ -178,20 +217,27 @@ func (s *SQLiteStorage) CreateIssue(ctx context.Context, issue *types.Issue, act
 	if err == sql.ErrNoRows || configPrefix == "" {
 		// CRITICAL: Reject operation if issue_prefix config is missing
 		// This prevents duplicate issues with wrong prefix
-		return fmt.Errorf("database not initialized: issue_prefix config is missing (run 'bd init --prefix <prefix>' first)")
+		return false, fmt.Errorf("database not initialized: issue_prefix config is missing (run 'bd init --prefix <prefix>' first)")
 	} else if err != nil {
-		return fmt.Errorf("failed to get config: %w", err)
+		return false, fmt.Errorf("failed to get config: %w", err)
+	}
+	if err := checkConfiguredPrefix(ctx, conn, configPrefix); err != nil {
+		return false, err
 	}
 
 	// Determine prefix for ID generation and validation:
 	// 1. PrefixOverride completely replaces config prefix (for cross-rig creation)
 	// 2. IDPrefix appends to config prefix (e.g., "bd" + "wisp" → "bd-wisp")
 	// 3. Otherwise use config prefix as-is
+	sep := getIDSeparator(ctx, conn)
 	prefix := configPrefix
 	if issue.PrefixOverride != "" {
 		prefix = issue.PrefixOverride
 	} else if issue.IDPrefix != "" {
-		prefix = configPrefix + "-" + issue.IDPrefix
+		if err := validateIDPrefix(issue.IDPrefix, sep); err != nil {
+			return false, wrapDBError("validate ID prefix", err)
+		}
+		prefix = configPrefix + sep + issue.IDPrefix
 	}
 
 	// Generate or validate ID
@@ -199,13 +245,13 @@ func (s *SQLiteStorage) CreateIssue(ctx context.Context, issue *types.Issue, act
 		// Generate hash-based ID with adaptive length based on database size
 		generatedID, err := GenerateIssueID(ctx, conn, prefix, issue, actor)
 		if err != nil {
-			return wrapDBError("generate issue ID", err)
+			return false, wrapDBError("generate issue ID", err)
 		}
 		issue.ID = generatedID
 	} else {
 		// Validate that explicitly provided ID matches the configured prefix
-		if err := ValidateIssueIDPrefix(issue.ID, prefix); err != nil {
-			return wrapDBError("validate issue ID prefix", err)
+		if err := ValidateIssueIDPrefixWithSeparator(issue.ID, prefix, sep); err != nil {
+			return false, wrapDBError("validate issue ID prefix", err)
 		}
 
 		// For hierarchical IDs (bd-a3f8e9.1), ensure parent exists
@@ -215,18 +261,18 @@ func (s *SQLiteStorage) CreateIssue(ctx context.Context, issue *types.Issue, act
 			// Use the conn-based version to participate in the same transaction
 			resurrected, err := s.tryResurrectParentChainWithConn(ctx, conn, issue.ID)
 			if err != nil {
-				return fmt.Errorf("failed to resurrect parent chain for %s: %w", issue.ID, err)
+				return false, fmt.Errorf("failed to resurrect parent chain for %s: %w", issue.ID, err)
 			}
 			if !resurrected {
 				// Parent(s) not found in JSONL history - cannot proceed
-				return fmt.Errorf("parent issue %s does not exist and could not be resurrected from JSONL history", parentID)
+				return false, fmt.Errorf("parent issue %s does not exist and could not be resurrected from JSONL history", parentID)
 			}
 
 			// Update child_counters to prevent future ID collisions (GH#728 fix)
 			// When explicit child IDs are used, the counter must be at least the child number
 			if _, childNum, ok := ParseHierarchicalID(issue.ID); ok {
 				if err := ensureChildCounterUpdatedWithConn(ctx, conn, parentID, childNum); err != nil {
-					return fmt.Errorf("failed to update child counter: %w", err)
+					return false, fmt.Errorf("failed to update child counter: %w", err)
 				}
 			}
 		}
@@ -236,46 +282,55 @@ func (s *SQLiteStorage) CreateIssue(ctx context.Context, issue *types.Issue, act
 	// If the user explicitly specifies an ID that matches an existing tombstone,
 	// delete the tombstone first so the new issue can be created.
 	// This enables re-creating issues after hard deletion (e.g., polecat respawn).
-	if issue.ID != "" {
+	if issue.ID != "" && !ifAbsent {
 		var existingStatus string
 		err := conn.QueryRowContext(ctx, `SELECT status FROM issues WHERE id = ?`, issue.ID).Scan(&existingStatus)
 		if err == nil && existingStatus == string(types.StatusTombstone) {
 			// Delete the tombstone record to allow re-creation
 			// Also clean up related tables (events, labels, dependencies, comments, dirty_issues)
 			if _, err := conn.ExecContext(ctx, `DELETE FROM events WHERE issue_id = ?`, issue.ID); err != nil {
-				return fmt.Errorf("failed to delete tombstone events: %w", err)
+				return false, fmt.Errorf("failed to delete tombstone events: %w", err)
 			}
 			if _, err := conn.ExecContext(ctx, `DELETE FROM labels WHERE issue_id = ?`, issue.ID); err != nil {
-				return fmt.Errorf("failed to delete tombstone labels: %w", err)
+				return false, fmt.Errorf("failed to delete tombstone labels: %w", err)
 			}
 			if _, err := conn.ExecContext(ctx, `DELETE FROM dependencies WHERE issue_id = ? OR depends_on_id = ?`, issue.ID, issue.ID); err != nil {
-				return fmt.Errorf("failed to delete tombstone dependencies: %w", err)
+				return false, fmt.Errorf("failed to delete tombstone dependencies: %w", err)
 			}
 			if _, err := conn.ExecContext(ctx, `DELETE FROM comments WHERE issue_id = ?`, issue.ID); err != nil {
-				return fmt.Errorf("failed to delete tombstone comments: %w", err)
+				return false, fmt.Errorf("failed to delete tombstone comments: %w", err)
 			}
 			if _, err := conn.ExecContext(ctx, `DELETE FROM dirty_issues WHERE issue_id = ?`, issue.ID); err != nil {
-				return fmt.Errorf("failed to delete tombstone dirty marker: %w", err)
+				return false, fmt.Errorf("failed to delete tombstone dirty marker: %w", err)
 			}
 			if _, err := conn.ExecContext(ctx, `DELETE FROM issues WHERE id = ?`, issue.ID); err != nil {
-				return fmt.Errorf("failed to delete tombstone: %w", err)
+				return false, fmt.Errorf("failed to delete tombstone: %w", err)
 			}
 			// Note: Tombstone is now gone, proceed with normal creation
 		} else if err != nil && err != sql.ErrNoRows {
-			return fmt.Errorf("failed to check for existing tombstone: %w", err)
+			return false, fmt.Errorf("failed to check for existing tombstone: %w", err)
 		}
 	}
 
 	// Insert issue using strict mode (fails on duplicates)
 	// GH#956: Use insertIssueStrict instead of insertIssue to prevent FK constraint errors
 	// from silent INSERT OR IGNORE failures under concurrent load.
-	if err := insertIssueStrict(ctx, conn, issue); err != nil {
-		return wrapDBError("insert issue", err)
+	if ifAbsent {
+		inserted, err := insertIssueIfAbsent(ctx, conn, issue)
+		if err != nil {
+			return false, wrapDBError("insert issue", err)
+		}
+		if !inserted {
+			// Deferred ROLLBACK discards any parent resurrection or counter updates
+			return false, nil
+		}
+	} else if err := insertIssueStrict(ctx, conn, issue); err != nil {
+		return false, wrapDBError("insert issue", err)
 	}
 
 	// Record creation event
 	if err := recordCreatedEvent(ctx, conn, issue, actor); err != nil {
-		return wrapDBError("record creation event", err)
+		return false, wrapDBError("record creation event", err)
 	}
 
 	// NOTE: Graph edges (replies-to, relates-to, duplicates, supersedes) are now
@@ -283,15 +338,15 @@ func (s *SQLiteStorage) CreateIssue(ctx context.Context, issue *types.Issue, act
 
 	// Mark issue as dirty for incremental export
 	if err := markDirty(ctx, conn, issue.ID); err != nil {
-		return wrapDBError("mark issue dirty", err)
+		return false, wrapDBError("mark issue dirty", err)
 	}
 
 	// Commit the transaction
 	if _, err := conn.ExecContext(ctx, "COMMIT"); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+		return false, fmt.Errorf("failed to commit transaction: %w", markBusy(err))
 	}
 	committed = true
-	return nil
+	return true, nil
 }
 
 // validateBatchIssues validates all issues in a batch and sets timestamps
@@ -353,11 +408,15 @@ func (s *SQLiteStorage) GetIssue(ctx context.Context, id string) (*types.Issue,
 	// Time-based scheduling fields (GH#820)
 	var dueAt sql.NullTime
 	var deferUntil sql.NullTime
+	var expiresAt sql.NullTime
+	var sourceSystem sql.NullString
+	var customFields string
 
 	var contentHash sql.NullString
 	var compactedAtCommit sql.NullString
 	var owner sql.NullString
-	err := s.db.QueryRowContext(ctx, `
+	lookup := func(id string) error {
+		return s.db.QueryRowContext(ctx, `
 		SELECT id, content_hash, title, description, design, acceptance_criteria, notes,
 		       status, priority, issue_type, assignee, estimated_minutes,
 		       created_at, created_by, owner, updated_at, closed_at, external_ref,
@@ -367,22 +426,29 @@ func (s *SQLiteStorage) GetIssue(ctx context.Context, id string) (*types.Issue,
 		       await_type, await_id, timeout_ns, waiters,
 		       hook_bead, role_bead, agent_state, last_activity, role_type, rig, mol_type,
 		       event_kind, actor, target, payload,
-		       due_at, defer_until
+		       due_at, defer_until, expires_at, color, display_order, source_system, custom_fields, rank, milestone_id
 		FROM issues
 		WHERE id = ?
 	`, id).Scan(
-		&issue.ID, &contentHash, &issue.Title, &issue.Description, &issue.Design,
-		&issue.AcceptanceCriteria, &issue.Notes, &issue.Status,
-		&issue.Priority, &issue.IssueType, &assignee, &estimatedMinutes,
-		&createdAtStr, &issue.CreatedBy, &owner, &updatedAtStr, &closedAt, &externalRef,
-		&issue.CompactionLevel, &compactedAt, &compactedAtCommit, &originalSize, &sourceRepo, &closeReason,
-		&deletedAt, &deletedBy, &deleteReason, &originalType,
-		&sender, &wisp, &pinned, &isTemplate, &crystallizes,
-		&awaitType, &awaitID, &timeoutNs, &waiters,
-		&hookBead, &roleBead, &agentState, &lastActivity, &roleType, &rig, &molType,
-		&eventKind, &actor, &target, &payload,
-		&dueAt, &deferUntil,
-	)
+			&issue.ID, &contentHash, &issue.Title, &issue.Description, &issue.Design,
+			&issue.AcceptanceCriteria, &issue.Notes, &issue.Status,
+			&issue.Priority, &issue.IssueType, &assignee, &estimatedMinutes,
+			&createdAtStr, &issue.CreatedBy, &owner, &updatedAtStr, &closedAt, &externalRef,
+			&issue.CompactionLevel, &compactedAt, &compactedAtCommit, &originalSize, &sourceRepo, &closeReason,
+			&deletedAt, &deletedBy, &deleteReason, &originalType,
+			&sender, &wisp, &pinned, &isTemplate, &crystallizes,
+			&awaitType, &awaitID, &timeoutNs, &waiters,
+			&hookBead, &roleBead, &agentState, &lastActivity, &roleType, &rig, &molType,
+			&eventKind, &actor, &target, &payload,
+			&dueAt, &deferUntil, &expiresAt, &issue.Color, &issue.DisplayOrder, &sourceSystem, &customFields, &issue.Rank, &issue.MilestoneID,
+		)
+	}
+	err := lookup(id)
+	if err == sql.ErrNoRows {
+		if canonical := caseVariantID(ctx, s.db, id); canonical != "" {
+			err = lookup(canonical)
+		}
+	}
 
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -518,6 +584,19 @@ func (s *SQLiteStorage) GetIssue(ctx context.Context, id string) (*types.Issue,
 	if deferUntil.Valid {
 		issue.DeferUntil = &deferUntil.Time
 	}
+	if expiresAt.Valid {
+		issue.ExpiresAt = &expiresAt.Time
+	}
+	if sourceSystem.Valid {
+		issue.SourceSystem = sourceSystem.String
+	}
+	if issue.CustomFields, err = parseCustomFields(customFields); err != nil {
+		return nil, err
+	}
+
+	if err := hydrateDescriptions(ctx, s.db, &issue); err != nil {
+		return nil, err
+	}
 
 	// Fetch labels for this issue
 	labels, err := s.GetLabels(ctx, issue.ID)
@@ -526,6 +605,18 @@ func (s *SQLiteStorage) GetIssue(ctx context.Context, id string) (*types.Issue,
 	}
 	issue.Labels = labels
 
+	watchers, err := s.GetWatchers(ctx, issue.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get watchers: %w", err)
+	}
+	issue.Watchers = watchers
+
+	checklist, err := s.GetChecklist(ctx, issue.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get checklist: %w", err)
+	}
+	issue.Checklist = checklist
+
 	return &issue, nil
 }
 
@@ -752,6 +843,10 @@ func (s *SQLiteStorage) GetIssueByExternalRef(ctx context.Context, externalRef s
 		issue.Waiters = parseJSONStringArray(waiters.String)
 	}
 
+	if err := hydrateDescriptions(ctx, s.db, &issue); err != nil {
+		return nil, err
+	}
+
 	// Fetch labels for this issue
 	labels, err := s.GetLabels(ctx, issue.ID)
 	if err != nil {
@@ -759,6 +854,18 @@ func (s *SQLiteStorage) GetIssueByExternalRef(ctx context.Context, externalRef s
 	}
 	issue.Labels = labels
 
+	watchers, err := s.GetWatchers(ctx, issue.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get watchers: %w", err)
+	}
+	issue.Watchers = watchers
+
+	checklist, err := s.GetChecklist(ctx, issue.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get checklist: %w", err)
+	}
+	issue.Checklist = checklist
+
 	return &issue, nil
 }
 
@@ -802,6 +909,19 @@ var allowedUpdateFields = map[string]bool{
 	// Time-based scheduling fields (GH#820)
 	"due_at":      true,
 	"defer_until": true,
+	"expires_at":  true,
+	// Display metadata
+	"color":         true,
+	"display_order": true,
+	"rank":          true,
+	// Planning
+	"milestone_id": true,
+	// Origin of the issue in multi-source databases
+	"source_system": true,
+	// Unknown fields preserved by imports
+	"custom_fields": true,
+	// Explicit modification time (see UpdatedAtPolicyConfigKey)
+	"updated_at": true,
 	// Gate fields (bd-z6kw: support await_id updates for gate discovery)
 	"await_id": true,
 	"waiters":  true,
@@ -878,6 +998,28 @@ func manageClosedAt(oldIssue *types.Issue, updates map[string]interface{}, setCl
 
 // UpdateIssue updates fields on an issue
 func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[string]interface{}, actor string) error {
+	return s.updateIssue(ctx, id, "", updates, actor)
+}
+
+// UpdateIssueWithExpectedHash updates fields on an issue using optimistic locking.
+// expectedHash is the ContentHash the caller read; if the stored content_hash no
+// longer matches (someone else changed the issue), the update is rejected with
+// ErrStaleWrite and nothing is written. The check and the write happen in a
+// single guarded UPDATE, so a concurrent modification between read and write
+// cannot be lost.
+//
+// ContentHash only covers substantive content, so updates that touch none of the
+// hashed fields (e.g. defer_until) do not invalidate other callers' tokens.
+func (s *SQLiteStorage) UpdateIssueWithExpectedHash(ctx context.Context, id, expectedHash string, updates map[string]interface{}, actor string) error {
+	if expectedHash == "" {
+		return fmt.Errorf("expected hash is required for optimistic update of %s", id)
+	}
+	return s.updateIssue(ctx, id, expectedHash, updates, actor)
+}
+
+// updateIssue implements UpdateIssue. When expectedHash is non-empty the write
+// only applies if the stored content_hash still equals it.
+func (s *SQLiteStorage) updateIssue(ctx context.Context, id, expectedHash string, updates map[string]interface{}, actor string) error {
 	// Get old issue for event
 	oldIssue, err := s.GetIssue(ctx, id)
 	if err != nil {
@@ -886,6 +1028,13 @@ func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[
 	if oldIssue == nil {
 		return fmt.Errorf("issue %s not found", id)
 	}
+	id = oldIssue.ID // canonical casing when IDs are case-insensitive
+	if expectedHash != "" && oldIssue.ContentHash != expectedHash {
+		return fmt.Errorf("%w: issue %s", ErrStaleWrite, id)
+	}
+	if err := checkStatusUpdate(ctx, s.db, oldIssue, updates); err != nil {
+		return err
+	}
 
 	// Fetch custom statuses and types for validation
 	customStatuses, err := s.GetCustomStatuses(ctx)
@@ -898,15 +1047,32 @@ func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[
 		return wrapDBError("get custom types", err)
 	}
 
+	if err := validateTitleUpdate(ctx, s.db, updates); err != nil {
+		return wrapDBError("validate field update", err)
+	}
+	if err := validateTypeStatusUpdate(ctx, s.db, oldIssue, updates); err != nil {
+		return wrapDBError("validate field update", err)
+	}
+	if err := s.validateUpdatedIssue(oldIssue, updates); err != nil {
+		return wrapDBError("validate field update", err)
+	}
+	updatedAt, err := resolveUpdatedAt(ctx, s.db, oldIssue, updates)
+	if err != nil {
+		return err
+	}
+
 	// Build update query with validated field names
 	setClauses := []string{"updated_at = ?"}
-	args := []interface{}{time.Now()}
+	args := []interface{}{updatedAt}
 
 	for key, value := range updates {
 		// Prevent SQL injection by validating field names
 		if !allowedUpdateFields[key] {
 			return fmt.Errorf("invalid field for update: %s", key)
 		}
+		if key == "updated_at" {
+			continue // resolved above
+		}
 
 		// Validate field values (with custom status and type support)
 		if err := validateFieldUpdateWithCustom(key, value, customStatuses, customTypes); err != nil {
@@ -924,6 +1090,12 @@ func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[
 		if key == "waiters" {
 			waitersJSON, _ := json.Marshal(value)
 			args = append(args, string(waitersJSON))
+		} else if key == "custom_fields" {
+			customFields, err := customFieldsUpdateValue(value)
+			if err != nil {
+				return wrapDBError("update custom fields", err)
+			}
+			args = append(args, customFields)
 		} else {
 			args = append(args, value)
 		}
@@ -934,7 +1106,7 @@ func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[
 
 	// Recompute content_hash if any content fields changed
 	contentChanged := false
-	contentFields := []string{"title", "description", "design", "acceptance_criteria", "notes", "status", "priority", "issue_type", "assignee", "external_ref"}
+	contentFields := []string{"title", "description", "design", "acceptance_criteria", "notes", "status", "priority", "issue_type", "assignee", "external_ref", "color", "display_order", "source_system", "custom_fields", "rank", "milestone_id"}
 	for _, field := range contentFields {
 		if _, exists := updates[field]; exists {
 			contentChanged = true
@@ -978,6 +1150,30 @@ func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[
 				} else {
 					updatedIssue.Assignee = value.(string)
 				}
+			case "color":
+				if s, ok := value.(string); ok {
+					updatedIssue.Color = s
+				}
+			case "display_order":
+				if n, ok := value.(int); ok {
+					updatedIssue.DisplayOrder = n
+				}
+			case "rank":
+				if s, ok := value.(string); ok {
+					updatedIssue.Rank = s
+				}
+			case "milestone_id":
+				if s, ok := value.(string); ok {
+					updatedIssue.MilestoneID = s
+				}
+			case "source_system":
+				if s, ok := value.(string); ok {
+					updatedIssue.SourceSystem = s
+				}
+			case "custom_fields":
+				if fields, ok := value.(map[string]json.RawMessage); ok {
+					updatedIssue.CustomFields = fields
+				}
 			case "external_ref":
 				if value == nil {
 					updatedIssue.ExternalRef = nil
@@ -994,26 +1190,16 @@ func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[
 				}
 			}
 		}
-		newHash := updatedIssue.ComputeContentHash()
+		newHash := contentHash(ctx, s.db, &updatedIssue)
 		setClauses = append(setClauses, "content_hash = ?")
 		args = append(args, newHash)
 	}
 
 	args = append(args, id)
 
-	// Prepare event data before transaction
-	oldData, err := json.Marshal(oldIssue)
-	if err != nil {
-		// Fall back to minimal description if marshaling fails
-		oldData = []byte(fmt.Sprintf(`{"id":"%s"}`, id))
-	}
-	newData, err := json.Marshal(updates)
-	if err != nil {
-		// Fall back to minimal description if marshaling fails
-		newData = []byte(`{}`)
-	}
-	oldDataStr := string(oldData)
-	newDataStr := string(newData)
+	// Prepare event data before transaction: the changed fields with their
+	// before/after values
+	deltaStr := updateEventValue(ctx, s.db, oldIssue, updates)
 	eventType := determineEventType(oldIssue, updates)
 	statusChanged := false
 	if _, ok := updates["status"]; ok {
@@ -1022,18 +1208,37 @@ func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[
 
 	// Execute in transaction using BEGIN IMMEDIATE (GH#1272 fix)
 	return s.withTx(ctx, func(conn *sql.Conn) error {
-		// Update issue
+		// Update issue (guarded by the expected hash for optimistic updates)
 		query := fmt.Sprintf("UPDATE issues SET %s WHERE id = ?", strings.Join(setClauses, ", ")) // #nosec G201 - safe SQL with controlled column names
-		_, err := conn.ExecContext(ctx, query, args...)
+		queryArgs := args
+		if expectedHash != "" {
+			query += " AND content_hash = ?"
+			queryArgs = append(append([]interface{}{}, args...), expectedHash)
+		}
+		res, err := conn.ExecContext(ctx, query, queryArgs...)
 		if err != nil {
 			return fmt.Errorf("failed to update issue: %w", err)
 		}
+		if _, ok := updates["description"]; ok {
+			if err := packDescriptions(ctx, conn, id); err != nil {
+				return err
+			}
+		}
+		if expectedHash != "" {
+			rows, err := res.RowsAffected()
+			if err != nil {
+				return fmt.Errorf("failed to get rows affected: %w", err)
+			}
+			if rows == 0 {
+				return fmt.Errorf("%w: issue %s", ErrStaleWrite, id)
+			}
+		}
 
 		// Record event
 		_, err = conn.ExecContext(ctx, `
-			INSERT INTO events (issue_id, event_type, actor, old_value, new_value)
-			VALUES (?, ?, ?, ?, ?)
-		`, id, eventType, actor, oldDataStr, newDataStr)
+			INSERT INTO events (issue_id, event_type, actor, new_value)
+			VALUES (?, ?, ?, ?)
+		`, id, eventType, actor, deltaStr)
 		if err != nil {
 			return fmt.Errorf("failed to record event: %w", err)
 		}
@@ -1066,11 +1271,13 @@ func (s *SQLiteStorage) UpdateIssueID(ctx context.Context, oldID, newID string,
 	}
 	defer func() { _ = conn.Close() }()
 
-	// Disable foreign keys on this specific connection
-	_, err = conn.ExecContext(ctx, `PRAGMA foreign_keys = OFF`)
+	// Disable foreign keys on this specific connection, restoring its own
+	// setting before it returns to the pool
+	restoreForeignKeys, err := disableForeignKeys(ctx, conn)
 	if err != nil {
-		return fmt.Errorf("failed to disable foreign keys: %w", err)
+		return err
 	}
+	defer restoreForeignKeys()
 
 	tx, err := conn.BeginTx(ctx, nil)
 	if err != nil {
@@ -1094,6 +1301,9 @@ func (s *SQLiteStorage) UpdateIssueID(ctx context.Context, oldID, newID string,
 	if rows == 0 {
 		return fmt.Errorf("issue not found: %s", oldID)
 	}
+	if err := packDescriptions(ctx, tx, newID); err != nil {
+		return err
+	}
 
 	_, err = tx.ExecContext(ctx, `UPDATE dependencies SET issue_id = ? WHERE issue_id = ?`, newID, oldID)
 	if err != nil {
@@ -1137,6 +1347,26 @@ func (s *SQLiteStorage) UpdateIssueID(ctx context.Context, oldID, newID string,
 		return fmt.Errorf("failed to update compaction_snapshots: %w", err)
 	}
 
+	_, err = tx.ExecContext(ctx, `UPDATE id_aliases SET issue_id = ? WHERE issue_id = ?`, newID, oldID)
+	if err != nil {
+		return fmt.Errorf("failed to update id_aliases: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE field_provenance SET issue_id = ? WHERE issue_id = ?`, newID, oldID)
+	if err != nil {
+		return fmt.Errorf("failed to update field_provenance: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE watchers SET issue_id = ? WHERE issue_id = ?`, newID, oldID)
+	if err != nil {
+		return fmt.Errorf("failed to update watchers: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE checklist_items SET issue_id = ? WHERE issue_id = ?`, newID, oldID)
+	if err != nil {
+		return fmt.Errorf("failed to update checklist items: %w", err)
+	}
+
 	_, err = tx.ExecContext(ctx, `
 		INSERT INTO dirty_issues (issue_id, marked_at)
 		VALUES (?, ?)
@@ -1210,6 +1440,15 @@ func (s *SQLiteStorage) CloseIssue(ctx context.Context, id string, reason string
 
 	// Execute in transaction using BEGIN IMMEDIATE (GH#1272 fix)
 	return s.withTx(ctx, func(conn *sql.Conn) error {
+		id := canonicalIssueID(ctx, conn, id)
+		from, err := currentStatus(ctx, conn, id)
+		if err != nil {
+			return err
+		}
+		if err := checkStatusTransition(ctx, conn, id, from, types.StatusClosed); err != nil {
+			return err
+		}
+
 		// NOTE: close_reason is stored in two places:
 		// 1. issues.close_reason - for direct queries (bd show --json, exports)
 		// 2. events.comment - for audit history (when was it closed, by whom)
@@ -1340,41 +1579,10 @@ func (s *SQLiteStorage) CreateTombstone(ctx context.Context, id string, actor st
 		return fmt.Errorf("issue not found: %s", id)
 	}
 
-	now := time.Now()
-	originalType := string(issue.IssueType)
-
 	// Execute in transaction using BEGIN IMMEDIATE (GH#1272 fix)
 	return s.withTx(ctx, func(conn *sql.Conn) error {
-		// Convert issue to tombstone
-		// Note: closed_at must be set to NULL because of CHECK constraint:
-		// (status = 'closed') = (closed_at IS NOT NULL)
-		_, err := conn.ExecContext(ctx, `
-			UPDATE issues
-			SET status = ?,
-			    closed_at = NULL,
-			    deleted_at = ?,
-			    deleted_by = ?,
-			    delete_reason = ?,
-			    original_type = ?,
-			    updated_at = ?
-			WHERE id = ?
-		`, types.StatusTombstone, now, actor, reason, originalType, now, id)
-		if err != nil {
-			return fmt.Errorf("failed to create tombstone: %w", err)
-		}
-
-		// Record tombstone creation event
-		_, err = conn.ExecContext(ctx, `
-			INSERT INTO events (issue_id, event_type, actor, comment)
-			VALUES (?, ?, ?, ?)
-		`, id, "deleted", actor, reason)
-		if err != nil {
-			return fmt.Errorf("failed to record tombstone event: %w", err)
-		}
-
-		// Mark issue as dirty for incremental export
-		if err := markDirty(ctx, conn, id); err != nil {
-			return fmt.Errorf("failed to mark issue dirty: %w", err)
+		if err := tombstoneIssue(ctx, conn, id, string(issue.IssueType), actor, reason); err != nil {
+			return err
 		}
 
 		// Invalidate blocked issues cache since status changed
@@ -1387,9 +1595,69 @@ func (s *SQLiteStorage) CreateTombstone(ctx context.Context, id string, actor st
 	})
 }
 
+// CreateTombstone converts an existing issue to a tombstone within the
+// transaction. As with SQLiteStorage.CreateTombstone, dependencies are left to
+// the caller.
+func (t *sqliteTxStorage) CreateTombstone(ctx context.Context, id string, actor string, reason string) error {
+	issue, err := t.GetIssue(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get issue: %w", err)
+	}
+	if issue == nil {
+		return fmt.Errorf("issue not found: %s", id)
+	}
+	if err := tombstoneIssue(ctx, t.conn, id, string(issue.IssueType), actor, reason); err != nil {
+		return err
+	}
+	if err := t.parent.invalidateBlockedCache(ctx, t.conn); err != nil {
+		return fmt.Errorf("failed to invalidate blocked cache: %w", err)
+	}
+	return nil
+}
+
+// tombstoneIssue converts an issue to a tombstone on conn, records the
+// deletion event and marks it dirty. The caller invalidates the blocked cache.
+func tombstoneIssue(ctx context.Context, conn *sql.Conn, id, originalType, actor, reason string) error {
+	now := time.Now()
+
+	// Convert issue to tombstone
+	// Note: closed_at must be set to NULL because of CHECK constraint:
+	// (status = 'closed') = (closed_at IS NOT NULL)
+	_, err := conn.ExecContext(ctx, `
+		UPDATE issues
+		SET status = ?,
+		    closed_at = NULL,
+		    deleted_at = ?,
+		    deleted_by = ?,
+		    delete_reason = ?,
+		    original_type = ?,
+		    updated_at = ?
+		WHERE id = ?
+	`, types.StatusTombstone, now, actor, reason, originalType, now, id)
+	if err != nil {
+		return fmt.Errorf("failed to create tombstone: %w", err)
+	}
+
+	// Record tombstone creation event
+	_, err = conn.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, comment)
+		VALUES (?, ?, ?, ?)
+	`, id, "deleted", actor, reason)
+	if err != nil {
+		return fmt.Errorf("failed to record tombstone event: %w", err)
+	}
+
+	// Mark issue as dirty for incremental export
+	if err := markDirty(ctx, conn, id); err != nil {
+		return fmt.Errorf("failed to mark issue dirty: %w", err)
+	}
+	return nil
+}
+
 // DeleteIssue permanently removes an issue from the database
 func (s *SQLiteStorage) DeleteIssue(ctx context.Context, id string) error {
 	return s.withTx(ctx, func(conn *sql.Conn) error {
+		id := canonicalIssueID(ctx, conn, id)
 		// Mark issues that depend on this one as dirty so they get re-exported
 		// without the stale dependency reference (fixes orphan deps in JSONL)
 		rows, err := conn.QueryContext(ctx, `SELECT issue_id FROM dependencies WHERE depends_on_id = ?`, id)
@@ -1784,13 +2052,58 @@ func (s *SQLiteStorage) SearchIssues(ctx context.Context, query string, filter t
 	s.reconnectMu.RLock()
 	defer s.reconnectMu.RUnlock()
 
+	whereClauses, args := buildIssueFilterClauses(query, filter)
+
+	whereSQL := ""
+	if len(whereClauses) > 0 {
+		whereSQL = "WHERE " + strings.Join(whereClauses, " AND ")
+	}
+
+	limitSQL := ""
+	if filter.Limit > 0 {
+		limitSQL = " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+
+	// #nosec G201 - safe SQL with controlled formatting
+	querySQL := fmt.Sprintf(`
+		SELECT id, content_hash, title, description, design, acceptance_criteria, notes,
+		       status, priority, issue_type, assignee, estimated_minutes,
+		       created_at, created_by, owner, updated_at, closed_at, external_ref, source_repo, close_reason,
+		       deleted_at, deleted_by, delete_reason, original_type,
+		       sender, ephemeral, pinned, is_template, crystallizes,
+		       await_type, await_id, timeout_ns, waiters,
+		       hook_bead, role_bead, agent_state, last_activity, role_type, rig, mol_type,
+		       due_at, defer_until, expires_at, color, display_order, source_system, custom_fields, rank, milestone_id
+		FROM issues
+		%s
+		ORDER BY priority ASC, created_at DESC
+		%s
+	`, whereSQL, limitSQL)
+
+	rows, err := s.db.QueryContext(ctx, querySQL, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search issues: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return s.scanIssues(ctx, rows)
+}
+
+// buildIssueFilterClauses translates a text query and IssueFilter into SQL WHERE
+// clauses (to be joined with AND) and their positional arguments.
+// filter.Limit is not applied here.
+func buildIssueFilterClauses(query string, filter types.IssueFilter) ([]string, []interface{}) {
 	whereClauses := []string{}
 	args := []interface{}{}
 
 	if query != "" {
-		whereClauses = append(whereClauses, "(title LIKE ? OR description LIKE ? OR id LIKE ?)")
 		pattern := "%" + query + "%"
-		args = append(args, pattern, pattern, pattern)
+		descClause, descArgs := descriptionLikeClause(pattern)
+		whereClauses = append(whereClauses, "(title LIKE ? OR "+descClause+" OR id LIKE ?)")
+		args = append(args, pattern)
+		args = append(args, descArgs...)
+		args = append(args, pattern)
 	}
 
 	if filter.TitleSearch != "" {
@@ -1805,8 +2118,9 @@ func (s *SQLiteStorage) SearchIssues(ctx context.Context, query string, filter t
 		args = append(args, "%"+filter.TitleContains+"%")
 	}
 	if filter.DescriptionContains != "" {
-		whereClauses = append(whereClauses, "description LIKE ?")
-		args = append(args, "%"+filter.DescriptionContains+"%")
+		descClause, descArgs := descriptionLikeClause("%" + filter.DescriptionContains + "%")
+		whereClauses = append(whereClauses, descClause)
+		args = append(args, descArgs...)
 	}
 	if filter.NotesContains != "" {
 		whereClauses = append(whereClauses, "notes LIKE ?")
@@ -2002,38 +2316,30 @@ func (s *SQLiteStorage) SearchIssues(ctx context.Context, query string, filter t
 		args = append(args, time.Now().Format(time.RFC3339), types.StatusClosed)
 	}
 
-	whereSQL := ""
-	if len(whereClauses) > 0 {
-		whereSQL = "WHERE " + strings.Join(whereClauses, " AND ")
-	}
-
-	limitSQL := ""
-	if filter.Limit > 0 {
-		limitSQL = " LIMIT ?"
-		args = append(args, filter.Limit)
+	// Custom field filtering: evaluated in SQL so large exports don't scan in Go.
+	// Keys are sorted so the generated SQL is stable.
+	if len(filter.CustomFields) > 0 {
+		keys := make([]string, 0, len(filter.CustomFields))
+		for key := range filter.CustomFields {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			// custom_fields is '' for issues without any, which json_extract rejects
+			whereClauses = append(whereClauses, "(CASE WHEN custom_fields = '' THEN NULL ELSE json_extract(custom_fields, ?) END) = ?")
+			args = append(args, customFieldPath(key), filter.CustomFields[key])
+		}
 	}
 
-	// #nosec G201 - safe SQL with controlled formatting
-	querySQL := fmt.Sprintf(`
-		SELECT id, content_hash, title, description, design, acceptance_criteria, notes,
-		       status, priority, issue_type, assignee, estimated_minutes,
-		       created_at, created_by, owner, updated_at, closed_at, external_ref, source_repo, close_reason,
-		       deleted_at, deleted_by, delete_reason, original_type,
-		       sender, ephemeral, pinned, is_template, crystallizes,
-		       await_type, await_id, timeout_ns, waiters,
-		       hook_bead, role_bead, agent_state, last_activity, role_type, rig, mol_type,
-		       due_at, defer_until
-		FROM issues
-		%s
-		ORDER BY priority ASC, created_at DESC
-		%s
-	`, whereSQL, limitSQL)
+	return whereClauses, args
+}
 
-	rows, err := s.db.QueryContext(ctx, querySQL, args...)
-	if err != nil {
-		return nil, fmt.Errorf("failed to search issues: %w", err)
-	}
-	defer func() { _ = rows.Close() }()
+// customFieldPathEscaper escapes the characters that would end or corrupt a
+// quoted JSON path label.
+var customFieldPathEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
 
-	return s.scanIssues(ctx, rows)
+// customFieldPath returns the JSON path of a top-level custom field, quoting
+// the key so names with dots or spaces are not read as nested paths.
+func customFieldPath(key string) string {
+	return `$."` + customFieldPathEscaper.Replace(key) + `"`
 }
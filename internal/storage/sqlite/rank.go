@@ -0,0 +1,193 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// rankDigits are the characters of ranks computed by MoveIssue, in sort order.
+// Imported ranks are stored verbatim whatever their form, but MoveIssue can
+// only place issues next to neighbors ranked with these digits.
+const rankDigits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// ListIssuesByRank returns the ranked backlog: issues with a Rank, in rank
+// order (bytewise, then by ID). Unranked issues and tombstones are excluded.
+// The scan uses idx_issues_rank.
+func (s *SQLiteStorage) ListIssuesByRank(ctx context.Context) ([]*types.Issue, error) {
+	s.checkFreshness()
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+rangeIssueColumns+`
+		FROM issues
+		WHERE rank != '' AND status != 'tombstone'
+		ORDER BY rank, id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issues by rank: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return scanIssueList(ctx, s, rows)
+}
+
+// MoveIssue re-ranks issue id so it sorts directly after beforeID, or
+// directly before afterID when only that is given, and returns the new rank.
+// With neither it goes to the end of the backlog. Passing both checks that
+// they are in order; if other issues sit between them, the issue still lands
+// right after beforeID. The rank is computed in the gap next to the named
+// neighbor inside one transaction, so it never equals another issue's rank,
+// and the change is recorded like any other update.
+func (s *SQLiteStorage) MoveIssue(ctx context.Context, id, beforeID, afterID, actor string) (string, error) {
+	if id == beforeID || id == afterID {
+		return "", fmt.Errorf("cannot move issue %s relative to itself", id)
+	}
+
+	var rank string
+	err := s.withTx(ctx, func(conn *sql.Conn) error {
+		neighborRank := func(neighborID string) (string, error) {
+			var r string
+			err := conn.QueryRowContext(ctx, `SELECT rank FROM issues WHERE id = ?`, neighborID).Scan(&r)
+			if err != nil {
+				return "", wrapDBErrorf(err, "get rank of %s", neighborID)
+			}
+			if r == "" {
+				return "", fmt.Errorf("issue %s has no rank", neighborID)
+			}
+			return r, nil
+		}
+		// closest finds the nearest rank on one side of the gap, ignoring the
+		// issue being moved
+		closest := func(query string, args ...interface{}) (string, bool, error) {
+			var r sql.NullString
+			if err := conn.QueryRowContext(ctx, query, args...).Scan(&r); err != nil {
+				return "", false, wrapDBError("find neighboring rank", err)
+			}
+			return r.String, r.Valid, nil
+		}
+
+		var lo, hi string
+		var err error
+		if beforeID != "" {
+			if lo, err = neighborRank(beforeID); err != nil {
+				return err
+			}
+		}
+		if afterID != "" {
+			if hi, err = neighborRank(afterID); err != nil {
+				return err
+			}
+		}
+		if beforeID != "" && afterID != "" && lo >= hi {
+			return fmt.Errorf("%s (rank %q) does not sort before %s (rank %q)", beforeID, lo, afterID, hi)
+		}
+
+		switch {
+		case beforeID != "":
+			next, ok, err := closest(`SELECT MIN(rank) FROM issues WHERE rank > ? AND id != ?`, lo, id)
+			if err != nil {
+				return err
+			}
+			if ok && (hi == "" || next < hi) {
+				hi = next
+			}
+		case afterID != "":
+			prev, ok, err := closest(`SELECT MAX(rank) FROM issues WHERE rank != '' AND rank < ? AND id != ?`, hi, id)
+			if err != nil {
+				return err
+			}
+			if ok {
+				lo = prev
+			}
+		default:
+			last, ok, err := closest(`SELECT MAX(rank) FROM issues WHERE id != ?`, id)
+			if err != nil {
+				return err
+			}
+			if ok {
+				lo = last
+			}
+		}
+
+		if rank, err = rankBetween(lo, hi); err != nil {
+			return err
+		}
+		tx := &sqliteTxStorage{conn: conn, parent: s}
+		return tx.UpdateIssue(ctx, id, map[string]interface{}{"rank": rank}, actor)
+	})
+	if err != nil {
+		return "", err
+	}
+	return rank, nil
+}
+
+// rankBetween returns a rank that sorts strictly between lo and hi, where an
+// empty lo is the start of the backlog and an empty hi its end. Ranks are read
+// as base-36 fractions (see rankDigits); the result is the short midpoint and
+// never ends in "0", so there is always room to insert below it.
+func rankBetween(lo, hi string) (string, error) {
+	for _, r := range []string{lo, hi} {
+		for i := 0; i < len(r); i++ {
+			if strings.IndexByte(rankDigits, r[i]) < 0 {
+				return "", fmt.Errorf("rank %q is not base-36 (0-9, a-z)", r)
+			}
+		}
+	}
+	if hi != "" && lo >= hi {
+		return "", fmt.Errorf("rank %q does not sort before %q", lo, hi)
+	}
+	rank := midRank(lo, hi)
+	if rank <= lo || (hi != "" && rank >= hi) {
+		return "", fmt.Errorf("no rank fits between %q and %q", lo, hi)
+	}
+	return rank, nil
+}
+
+// midRank is the digit-wise midpoint of lo and hi, reading lo as padded with
+// zeros and an empty hi as one past the largest rank.
+func midRank(lo, hi string) string {
+	digitAt := func(r string, i int) int {
+		if i >= len(r) {
+			return 0
+		}
+		return strings.IndexByte(rankDigits, r[i])
+	}
+
+	if hi != "" {
+		// Keep the common prefix
+		n := 0
+		for n < len(hi) && digitAt(lo, n) == digitAt(hi, n) {
+			n++
+		}
+		if n > 0 {
+			rest := ""
+			if n < len(lo) {
+				rest = lo[n:]
+			}
+			return hi[:n] + midRank(rest, hi[n:])
+		}
+	}
+
+	l, h := digitAt(lo, 0), len(rankDigits)
+	if hi != "" {
+		h = digitAt(hi, 0)
+	}
+	if h-l > 1 {
+		return string(rankDigits[(l+h)/2])
+	}
+	// Adjacent leading digits: hi's first digit alone already fits below hi,
+	// otherwise extend lo by a digit
+	if len(hi) > 1 {
+		return hi[:1]
+	}
+	rest := ""
+	if len(lo) > 1 {
+		rest = lo[1:]
+	}
+	return string(rankDigits[l]) + midRank(rest, "")
+}
@@ -0,0 +1,138 @@
+package sqlite
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestMoveIssue(t *testing.T) {
+	env := newTestEnv(t)
+	for _, issue := range []*types.Issue{
+		{ID: "bd-a", Title: "First", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, Rank: "a"},
+		{ID: "bd-b", Title: "Second", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, Rank: "b"},
+		{ID: "bd-new", Title: "Unranked", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+	} {
+		if err := env.Store.CreateIssue(env.Ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	order := func() []string {
+		t.Helper()
+		issues, err := env.Store.ListIssuesByRank(env.Ctx)
+		if err != nil {
+			t.Fatalf("ListIssuesByRank failed: %v", err)
+		}
+		var ids []string
+		for _, issue := range issues {
+			ids = append(ids, issue.ID)
+		}
+		return ids
+	}
+	if got := fmt.Sprint(order()); got != "[bd-a bd-b]" {
+		t.Fatalf("initial backlog = %s, want the ranked issues only", got)
+	}
+
+	rank, err := env.Store.MoveIssue(env.Ctx, "bd-new", "", "", "test-user")
+	if err != nil {
+		t.Fatalf("MoveIssue to the end failed: %v", err)
+	}
+	if got := fmt.Sprint(order()); got != "[bd-a bd-b bd-new]" {
+		t.Errorf("after moving to the end, backlog = %s", got)
+	}
+	moved, err := env.Store.GetIssue(env.Ctx, "bd-new")
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if moved.Rank != rank || moved.ContentHash != moved.ComputeContentHash() {
+		t.Errorf("expected rank %q with a fresh hash, got %q", rank, moved.Rank)
+	}
+
+	if _, err := env.Store.MoveIssue(env.Ctx, "bd-new", "", "bd-a", "test-user"); err != nil {
+		t.Fatalf("MoveIssue to the start failed: %v", err)
+	}
+	if got := fmt.Sprint(order()); got != "[bd-new bd-a bd-b]" {
+		t.Errorf("after moving before bd-a, backlog = %s", got)
+	}
+
+	// Inserting into the same gap over and over never produces a collision
+	want := []string{"bd-a"}
+	for i := 0; i < 40; i++ {
+		id := fmt.Sprintf("bd-i%d", i)
+		env.CreateIssueWithID(id, "Inserted")
+		if _, err := env.Store.MoveIssue(env.Ctx, id, "bd-a", "bd-b", "test-user"); err != nil {
+			t.Fatalf("MoveIssue %s failed: %v", id, err)
+		}
+		want = append(want[:1], append([]string{id}, want[1:]...)...)
+	}
+	got := order()
+	if fmt.Sprint(got[1:len(got)-1]) != fmt.Sprint(want) {
+		t.Errorf("backlog = %v, want each insert directly after bd-a: %v", got, want)
+	}
+	issues, err := env.Store.ListIssuesByRank(env.Ctx)
+	if err != nil {
+		t.Fatalf("ListIssuesByRank failed: %v", err)
+	}
+	seen := make(map[string]string)
+	for _, issue := range issues {
+		if other, ok := seen[issue.Rank]; ok {
+			t.Errorf("%s and %s share rank %q", other, issue.ID, issue.Rank)
+		}
+		seen[issue.Rank] = issue.ID
+	}
+
+	if _, err := env.Store.MoveIssue(env.Ctx, "bd-new", "bd-b", "bd-a", "test-user"); err == nil {
+		t.Error("expected neighbors out of order to be rejected")
+	}
+	env.CreateIssueWithID("bd-plain", "Unranked")
+	if _, err := env.Store.MoveIssue(env.Ctx, "bd-new", "bd-plain", "", "test-user"); err == nil {
+		t.Error("expected an unranked neighbor to be rejected")
+	}
+}
+
+func TestRankBetween(t *testing.T) {
+	tests := []struct {
+		lo, hi string
+	}{
+		{"", ""},
+		{"", "1"},
+		{"", "01"},
+		{"a", "b"},
+		{"a", "a1"},
+		{"az", "b"},
+		{"azz", "b0001"},
+		{"zz", ""},
+		{"i", "i01"},
+	}
+	for _, tt := range tests {
+		got, err := rankBetween(tt.lo, tt.hi)
+		if err != nil {
+			t.Errorf("rankBetween(%q, %q) failed: %v", tt.lo, tt.hi, err)
+			continue
+		}
+		if got <= tt.lo || (tt.hi != "" && got >= tt.hi) || got[len(got)-1] == '0' {
+			t.Errorf("rankBetween(%q, %q) = %q, want a rank strictly between not ending in 0", tt.lo, tt.hi, got)
+		}
+	}
+
+	// Repeatedly halving towards either end stays ordered
+	ranks := []string{"a", "b"}
+	for i := 0; i < 50; i++ {
+		mid, err := rankBetween(ranks[0], ranks[1])
+		if err != nil {
+			t.Fatalf("rankBetween(%q, %q) failed: %v", ranks[0], ranks[1], err)
+		}
+		ranks = append([]string{ranks[0], mid}, ranks[1:]...)
+	}
+	if !sort.StringsAreSorted(ranks) {
+		t.Errorf("ranks out of order: %v", ranks)
+	}
+
+	for _, bad := range [][2]string{{"b", "a"}, {"a", "a"}, {"a", "a0"}, {"0|hzz:", ""}} {
+		if got, err := rankBetween(bad[0], bad[1]); err == nil {
+			t.Errorf("rankBetween(%q, %q) = %q, want an error", bad[0], bad[1], got)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// CheckWritable returns ErrReadOnly (wrapped) if writes to the database would
+// fail because it is read-only, so callers can report it and back off before
+// doing any work. Transactions check the open mode themselves; CheckWritable
+// also checks the file's permissions.
+func (s *SQLiteStorage) CheckWritable(ctx context.Context) error {
+	if err := s.checkOpenMode(); err != nil {
+		return err
+	}
+	info, err := os.Stat(s.dbPath)
+	if err != nil || !info.Mode().IsRegular() {
+		return nil // in-memory or not yet created: nothing to check
+	}
+	f, err := os.OpenFile(s.dbPath, os.O_WRONLY, 0)
+	if err != nil {
+		if errors.Is(err, os.ErrPermission) {
+			return fmt.Errorf("%w: %v", ErrReadOnly, err)
+		}
+		return nil
+	}
+	_ = f.Close()
+	return nil
+}
+
+// IsReadOnlyError reports whether err is ErrReadOnly or SQLite's
+// SQLITE_READONLY.
+func IsReadOnlyError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, ErrReadOnly) {
+		return true
+	}
+	errStr := err.Error()
+	return strings.Contains(errStr, "readonly database") ||
+		strings.Contains(errStr, "SQLITE_READONLY")
+}
+
+// markReadOnly tags SQLITE_READONLY errors with ErrReadOnly, like markBusy.
+func markReadOnly(err error) error {
+	if errors.Is(err, ErrReadOnly) || !IsReadOnlyError(err) {
+		return err
+	}
+	return fmt.Errorf("%w: %w", ErrReadOnly, err)
+}
+
+// checkOpenMode fails transactions on stores opened with NewReadOnly before
+// they take a connection.
+func (s *SQLiteStorage) checkOpenMode() error {
+	if s.readOnly {
+		return fmt.Errorf("%w: %s was opened read-only", ErrReadOnly, s.dbPath)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestCheckWritable(t *testing.T) {
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	store, err := New(ctx, dbPath)
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	if err := store.SetConfig(ctx, "issue_prefix", "test"); err != nil {
+		t.Fatalf("failed to set issue_prefix: %v", err)
+	}
+	if err := store.CheckWritable(ctx); err != nil {
+		t.Errorf("CheckWritable on a writable store: %v", err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatalf("failed to close store: %v", err)
+	}
+
+	t.Run("opened read-only", func(t *testing.T) {
+		ro, err := NewReadOnly(ctx, dbPath)
+		if err != nil {
+			t.Fatalf("failed to open read-only: %v", err)
+		}
+		defer ro.Close()
+
+		if err := ro.CheckWritable(ctx); !errors.Is(err, ErrReadOnly) {
+			t.Errorf("CheckWritable = %v, want ErrReadOnly", err)
+		}
+		issue := &types.Issue{Title: "Nope", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := ro.CreateIssue(ctx, issue, "test-user"); !errors.Is(err, ErrReadOnly) {
+			t.Errorf("CreateIssue = %v, want ErrReadOnly", err)
+		}
+		if err := ro.SetConfig(ctx, "key", "value"); !errors.Is(err, ErrReadOnly) {
+			t.Errorf("SetConfig = %v, want ErrReadOnly", err)
+		}
+		if _, err := ro.GetConfig(ctx, "issue_prefix"); err != nil {
+			t.Errorf("reads should still work: %v", err)
+		}
+	})
+
+	t.Run("file without write permission", func(t *testing.T) {
+		if os.Geteuid() == 0 {
+			t.Skip("root can write files regardless of permissions")
+		}
+		if err := os.Chmod(dbPath, 0o444); err != nil {
+			t.Fatalf("chmod failed: %v", err)
+		}
+		defer func() { _ = os.Chmod(dbPath, 0o644) }()
+		rw := &SQLiteStorage{dbPath: dbPath}
+		if err := rw.CheckWritable(ctx); !errors.Is(err, ErrReadOnly) {
+			t.Errorf("CheckWritable = %v, want ErrReadOnly", err)
+		}
+	})
+}
+
+func TestIsReadOnlyError(t *testing.T) {
+	raw := errors.New("sqlite3: attempt to write a readonly database")
+	if !IsReadOnlyError(raw) || !errors.Is(wrapDBError("set config", raw), ErrReadOnly) {
+		t.Error("SQLITE_READONLY errors should match ErrReadOnly")
+	}
+	if IsReadOnlyError(errors.New("disk I/O error")) || IsReadOnlyError(nil) {
+		t.Error("other errors should not match")
+	}
+}
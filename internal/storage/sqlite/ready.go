@@ -169,7 +169,7 @@ func (s *SQLiteStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 		i.sender, i.ephemeral, i.pinned, i.is_template, i.crystallizes,
 		i.await_type, i.await_id, i.timeout_ns, i.waiters,
 		i.hook_bead, i.role_bead, i.agent_state, i.last_activity, i.role_type, i.rig, i.mol_type,
-		i.due_at, i.defer_until
+		i.due_at, i.defer_until, i.expires_at, i.color, i.display_order, i.source_system, i.custom_fields, i.rank, i.milestone_id
 		FROM issues i
 		WHERE %s
 		AND NOT EXISTS (
@@ -482,8 +482,14 @@ func (s *SQLiteStorage) GetStaleIssues(ctx context.Context, filter types.StaleFi
 
 		issues = append(issues, &issue)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 
-	return issues, rows.Err()
+	if err := hydrateDescriptions(ctx, s.db, issues...); err != nil {
+		return nil, err
+	}
+	return issues, nil
 }
 
 // GetBlockedIssues returns issues that are blocked by dependencies or have status=blocked
@@ -638,6 +644,14 @@ func (s *SQLiteStorage) GetBlockedIssues(ctx context.Context, filter types.WorkF
 		blocked = append(blocked, &issue)
 	}
 
+	blockedIssues := make([]*types.Issue, len(blocked))
+	for i, b := range blocked {
+		blockedIssues[i] = &b.Issue
+	}
+	if err := hydrateDescriptions(ctx, s.db, blockedIssues...); err != nil {
+		return nil, err
+	}
+
 	// Filter out satisfied external dependencies from BlockedBy lists
 	// Only check if external_projects are configured
 	if len(config.GetExternalProjects()) > 0 && len(blocked) > 0 {
@@ -783,7 +797,7 @@ func (s *SQLiteStorage) GetNewlyUnblockedByClose(ctx context.Context, closedIssu
 		       i.sender, i.ephemeral, i.pinned, i.is_template, i.crystallizes,
 		       i.await_type, i.await_id, i.timeout_ns, i.waiters,
 		       i.hook_bead, i.role_bead, i.agent_state, i.last_activity, i.role_type, i.rig, i.mol_type,
-		       i.due_at, i.defer_until
+		       i.due_at, i.defer_until, i.expires_at, i.color, i.display_order, i.source_system, i.custom_fields, i.rank, i.milestone_id
 		FROM issues i
 		JOIN dependencies d ON i.id = d.issue_id
 		WHERE d.depends_on_id = ?
@@ -0,0 +1,79 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// recentCursor is the position after the last issue of a ListRecentlyModified
+// page. It is handed to callers base64-encoded and opaque.
+type recentCursor struct {
+	UpdatedAt time.Time `json:"u"`
+	ID        string    `json:"id"`
+}
+
+// ListRecentlyModified returns up to limit issues, most recently updated
+// first, and a cursor for the next page ("" after the last page). Pass "" as
+// cursor for the first page. Issues updated at the same instant are ordered by
+// ID (descending), so pages neither repeat nor skip issues, though an issue
+// updated between calls moves to the front and is not seen again by a pager
+// that is already past it. Tombstones are excluded. Pages are read in index
+// order from idx_issues_updated_at_id.
+func (s *SQLiteStorage) ListRecentlyModified(ctx context.Context, limit int, cursor string) ([]*types.Issue, string, error) {
+	if limit <= 0 {
+		return nil, "", fmt.Errorf("limit must be positive (got %d)", limit)
+	}
+	var after *recentCursor
+	if cursor != "" {
+		raw, err := base64.RawURLEncoding.DecodeString(cursor)
+		after = &recentCursor{}
+		if err != nil || json.Unmarshal(raw, after) != nil || after.ID == "" {
+			return nil, "", fmt.Errorf("invalid cursor %q", cursor)
+		}
+	}
+
+	s.checkFreshness()
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	where := `status != 'tombstone'`
+	args := []interface{}{}
+	if after != nil {
+		where += ` AND (updated_at, id) < (?, ?)`
+		args = append(args, after.UpdatedAt.UTC(), after.ID)
+	}
+	// Read one extra row to learn whether another page follows
+	args = append(args, limit+1)
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+rangeIssueColumns+`
+		FROM issues
+		WHERE `+where+`
+		ORDER BY updated_at DESC, id DESC
+		LIMIT ?
+	`, args...)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list recently modified issues: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	issues, err := scanIssueList(ctx, s, rows)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(issues) <= limit {
+		return issues, "", nil
+	}
+	issues = issues[:limit]
+	last := issues[limit-1]
+	raw, err := json.Marshal(recentCursor{UpdatedAt: last.UpdatedAt, ID: last.ID})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to encode cursor: %w", err)
+	}
+	return issues, base64.RawURLEncoding.EncodeToString(raw), nil
+}
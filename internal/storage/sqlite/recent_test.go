@@ -0,0 +1,101 @@
+package sqlite
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestListRecentlyModified_Pagination(t *testing.T) {
+	env := newTestEnv(t)
+	base := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	const total = 23
+	for i := 0; i < total; i++ {
+		issue := env.CreateIssueWithID(fmt.Sprintf("bd-%02d", i), fmt.Sprintf("Issue %d", i))
+		// Groups of three share a timestamp, so ties straddle page boundaries
+		updatedAt := base.Add(time.Duration(i/3) * time.Minute)
+		if _, err := env.Store.db.ExecContext(env.Ctx, `UPDATE issues SET updated_at = ? WHERE id = ?`, updatedAt, issue.ID); err != nil {
+			t.Fatalf("failed to set updated_at: %v", err)
+		}
+	}
+	tomb := env.CreateIssueWithID("bd-tomb", "Deleted")
+	if _, err := env.Store.db.ExecContext(env.Ctx, `UPDATE issues SET status = 'tombstone', deleted_at = ?, updated_at = ? WHERE id = ?`, base, base.Add(time.Hour), tomb.ID); err != nil {
+		t.Fatalf("failed to tombstone: %v", err)
+	}
+
+	seen := make(map[string]bool)
+	var all []*types.Issue
+	cursor := ""
+	for pages := 0; ; pages++ {
+		if pages > total {
+			t.Fatal("pagination did not terminate")
+		}
+		page, next, err := env.Store.ListRecentlyModified(env.Ctx, 4, cursor)
+		if err != nil {
+			t.Fatalf("ListRecentlyModified failed: %v", err)
+		}
+		for _, issue := range page {
+			if seen[issue.ID] {
+				t.Errorf("%s returned twice", issue.ID)
+			}
+			seen[issue.ID] = true
+		}
+		all = append(all, page...)
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	if len(all) != total {
+		t.Fatalf("paged through %d issues, want %d", len(all), total)
+	}
+	if seen[tomb.ID] {
+		t.Error("tombstones should be excluded")
+	}
+	for i := 1; i < len(all); i++ {
+		prev, cur := all[i-1], all[i]
+		if cur.UpdatedAt.After(prev.UpdatedAt) || (cur.UpdatedAt.Equal(prev.UpdatedAt) && cur.ID >= prev.ID) {
+			t.Errorf("out of order at %d: %s (%v) after %s (%v)", i, cur.ID, cur.UpdatedAt, prev.ID, prev.UpdatedAt)
+		}
+	}
+	if all[0].ID != "bd-22" {
+		t.Errorf("expected most recently modified first, got %s", all[0].ID)
+	}
+
+	if _, _, err := env.Store.ListRecentlyModified(env.Ctx, 4, "not-a-cursor"); err == nil || !strings.Contains(err.Error(), "invalid cursor") {
+		t.Errorf("expected invalid cursor error, got %v", err)
+	}
+	if _, _, err := env.Store.ListRecentlyModified(env.Ctx, 0, ""); err == nil {
+		t.Error("expected error for non-positive limit")
+	}
+}
+
+func TestListRecentlyModified_UsesIndex(t *testing.T) {
+	env := newTestEnv(t)
+	rows, err := env.Store.db.QueryContext(env.Ctx, `
+		EXPLAIN QUERY PLAN SELECT id FROM issues
+		WHERE status != 'tombstone' AND (updated_at, id) < (?, ?)
+		ORDER BY updated_at DESC, id DESC LIMIT 5
+	`, time.Now(), "bd-1")
+	if err != nil {
+		t.Fatalf("EXPLAIN QUERY PLAN failed: %v", err)
+	}
+	defer rows.Close()
+	var plan []string
+	for rows.Next() {
+		var id, parent, notused int
+		var detail string
+		if err := rows.Scan(&id, &parent, &notused, &detail); err != nil {
+			t.Fatalf("Failed to scan EXPLAIN output: %v", err)
+		}
+		plan = append(plan, detail)
+	}
+	joined := strings.Join(plan, "\n")
+	if !strings.Contains(joined, "idx_issues_updated_at_id") || strings.Contains(joined, "TEMP B-TREE") {
+		t.Errorf("expected an index-ordered scan of idx_issues_updated_at_id, plan: %v", plan)
+	}
+}
@@ -0,0 +1,144 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// ReconcileReport describes how two databases diverge, by issue ID. Each list
+// is in ID order (binary collation).
+type ReconcileReport struct {
+	Compared     int      // Issues present in both databases
+	OnlyLocal    []string // Issues missing from the other database
+	OnlyOther    []string // Issues missing from this database
+	Differing    []string // Issues whose content differs
+	HashMismatch []string // Issues with the same content but different stored content hashes (a stale hash on one side)
+}
+
+// InSync reports whether the databases hold the same issues with the same
+// content hashes.
+func (r *ReconcileReport) InSync() bool {
+	return len(r.OnlyLocal) == 0 && len(r.OnlyOther) == 0 && len(r.Differing) == 0 && len(r.HashMismatch) == 0
+}
+
+// Reconcile compares the issues of s and other without modifying either. Both
+// tables are walked in ID order side by side, comparing stored content
+// hashes, so memory is bounded by the number of differences rather than the
+// database size. Only issues whose hashes differ are loaded in full, after the
+// walk, to tell real content differences from stale hashes. Tombstones are
+// compared like any other issue.
+func (s *SQLiteStorage) Reconcile(ctx context.Context, other *SQLiteStorage) (*ReconcileReport, error) {
+	report := &ReconcileReport{
+		OnlyLocal:    []string{},
+		OnlyOther:    []string{},
+		Differing:    []string{},
+		HashMismatch: []string{},
+	}
+	var hashDiffs []string
+	if err := s.walkReconcile(ctx, other, report, &hashDiffs); err != nil {
+		return nil, err
+	}
+
+	for _, id := range hashDiffs {
+		local, err := s.GetIssue(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load local issue %s: %w", id, err)
+		}
+		remote, err := other.GetIssue(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load other issue %s: %w", id, err)
+		}
+		if local.Equal(remote) {
+			report.HashMismatch = append(report.HashMismatch, id)
+		} else {
+			report.Differing = append(report.Differing, id)
+		}
+	}
+	return report, nil
+}
+
+// walkReconcile merges the ID-ordered (id, content_hash) streams of s and
+// other, recording one-sided IDs in report and IDs whose hashes differ in
+// hashDiffs.
+func (s *SQLiteStorage) walkReconcile(ctx context.Context, other *SQLiteStorage, report *ReconcileReport, hashDiffs *[]string) error {
+	s.checkFreshness()
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+	if other != s {
+		other.checkFreshness()
+		other.reconnectMu.RLock()
+		defer other.reconnectMu.RUnlock()
+	}
+
+	local, err := openReconcileCursor(ctx, s.db)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = local.rows.Close() }()
+	remote, err := openReconcileCursor(ctx, other.db)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = remote.rows.Close() }()
+
+	for local.ok || remote.ok {
+		switch {
+		case !remote.ok || (local.ok && local.id < remote.id):
+			report.OnlyLocal = append(report.OnlyLocal, local.id)
+			err = local.next()
+		case !local.ok || remote.id < local.id:
+			report.OnlyOther = append(report.OnlyOther, remote.id)
+			err = remote.next()
+		default:
+			report.Compared++
+			if local.hash != remote.hash {
+				*hashDiffs = append(*hashDiffs, local.id)
+			}
+			if err = local.next(); err == nil {
+				err = remote.next()
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcileCursor steps through the issues of one database in ID order.
+type reconcileCursor struct {
+	rows *sql.Rows
+	ok   bool // id and hash hold the current row
+	id   string
+	hash string
+}
+
+func openReconcileCursor(ctx context.Context, db *sql.DB) (*reconcileCursor, error) {
+	rows, err := db.QueryContext(ctx, `SELECT id, content_hash FROM issues ORDER BY id COLLATE BINARY`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read issues for reconcile: %w", err)
+	}
+	c := &reconcileCursor{rows: rows}
+	if err := c.next(); err != nil {
+		_ = rows.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *reconcileCursor) next() error {
+	c.ok = c.rows.Next()
+	if !c.ok {
+		if err := c.rows.Err(); err != nil {
+			return wrapDBError("iterate issues for reconcile", err)
+		}
+		return nil
+	}
+	var hash sql.NullString
+	if err := c.rows.Scan(&c.id, &hash); err != nil {
+		return fmt.Errorf("failed to scan issue for reconcile: %w", err)
+	}
+	c.hash = hash.String
+	return nil
+}
@@ -0,0 +1,67 @@
+package sqlite
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestReconcile(t *testing.T) {
+	local := newTestEnv(t)
+	other := newTestEnv(t)
+	ctx := local.Ctx
+
+	now := time.Now().Truncate(time.Second)
+	create := func(env *testEnv, id, title string) {
+		t.Helper()
+		issue := &types.Issue{ID: id, Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask,
+			CreatedAt: now, UpdatedAt: now}
+		if err := env.Store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue %s failed: %v", id, err)
+		}
+	}
+	for _, env := range []*testEnv{local, other} {
+		create(env, "bd-same", "Same everywhere")
+		create(env, "bd-stale", "Same content")
+	}
+	create(local, "bd-diff", "Local title")
+	create(other, "bd-diff", "Other title")
+	create(local, "bd-local", "Only here")
+	create(other, "bd-other", "Only there")
+	create(other, "bd-zzz", "Sorts last")
+	if _, err := other.Store.db.ExecContext(ctx, `UPDATE issues SET content_hash = 'stale' WHERE id = 'bd-stale'`); err != nil {
+		t.Fatalf("failed to corrupt hash: %v", err)
+	}
+
+	report, err := local.Store.Reconcile(ctx, other.Store)
+	if err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	want := &ReconcileReport{
+		Compared:     3,
+		OnlyLocal:    []string{"bd-local"},
+		OnlyOther:    []string{"bd-other", "bd-zzz"},
+		Differing:    []string{"bd-diff"},
+		HashMismatch: []string{"bd-stale"},
+	}
+	if !reflect.DeepEqual(report, want) {
+		t.Errorf("got report %+v, want %+v", report, want)
+	}
+	if report.InSync() {
+		t.Error("expected divergent databases not to be in sync")
+	}
+
+	if issue, err := other.Store.GetIssue(ctx, "bd-diff"); err != nil || issue.Title != "Other title" {
+		t.Errorf("expected other database untouched, got %+v (%v)", issue, err)
+	}
+
+	same, err := local.Store.Reconcile(ctx, local.Store)
+	if err != nil {
+		t.Fatalf("Reconcile with itself failed: %v", err)
+	}
+	if !same.InSync() || same.Compared != 4 {
+		t.Errorf("expected a database to be in sync with itself, got %+v", same)
+	}
+}
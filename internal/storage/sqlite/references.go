@@ -0,0 +1,61 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// GetIncomingReferences returns the dependency records that point at id,
+// grouped by dependency type: issues that are blocked by it, its children
+// (parent-child), issues that relate to it, and so on. Each group is ordered by
+// the referencing issue's ID. An issue nothing references yields an empty map.
+//
+// Use it for impact analysis before deleting or reworking an issue. The lookup
+// is served by the dependencies (depends_on_id, type, issue_id) index, so it
+// does not scan the table.
+func (s *SQLiteStorage) GetIncomingReferences(ctx context.Context, id string) (map[types.DependencyType][]*types.Dependency, error) {
+	// Check for external database file modifications (daemon mode)
+	s.checkFreshness()
+
+	// Hold read lock during database operations to prevent reconnect() from
+	// closing the connection mid-query (GH#607 race condition fix)
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT issue_id, depends_on_id, type, created_at, created_by,
+		       COALESCE(metadata, '{}') as metadata, COALESCE(thread_id, '') as thread_id
+		FROM dependencies
+		WHERE depends_on_id = ?
+		ORDER BY type, issue_id
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get incoming references: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	refs := make(map[types.DependencyType][]*types.Dependency)
+	for rows.Next() {
+		var dep types.Dependency
+		err := rows.Scan(
+			&dep.IssueID,
+			&dep.DependsOnID,
+			&dep.Type,
+			&dep.CreatedAt,
+			&dep.CreatedBy,
+			&dep.Metadata,
+			&dep.ThreadID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan dependency: %w", err)
+		}
+		refs[dep.Type] = append(refs[dep.Type], &dep)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate incoming references: %w", err)
+	}
+
+	return refs, nil
+}
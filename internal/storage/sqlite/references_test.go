@@ -0,0 +1,59 @@
+package sqlite
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestGetIncomingReferences(t *testing.T) {
+	env := newTestEnv(t)
+	target := env.CreateEpic("Target")
+	blockedA := env.CreateIssue("Blocked A")
+	blockedB := env.CreateIssue("Blocked B")
+	child := env.CreateIssue("Child")
+	related := env.CreateIssue("Related")
+	unrelated := env.CreateIssue("Unrelated")
+
+	env.AddDep(blockedB, target)
+	env.AddDep(blockedA, target)
+	env.AddParentChild(child, target)
+	env.AddDepType(related, target, types.DepRelatesTo)
+	env.AddDep(target, unrelated) // outgoing, must not be reported
+
+	refs, err := env.Store.GetIncomingReferences(env.Ctx, target.ID)
+	if err != nil {
+		t.Fatalf("GetIncomingReferences failed: %v", err)
+	}
+
+	blockers := []string{blockedA.ID, blockedB.ID}
+	sort.Strings(blockers)
+	want := map[types.DependencyType][]string{
+		types.DepBlocks:      blockers,
+		types.DepParentChild: {child.ID},
+		types.DepRelatesTo:   {related.ID},
+	}
+	if len(refs) != len(want) {
+		t.Fatalf("got %d groups (%v), want %d", len(refs), refs, len(want))
+	}
+	for depType, ids := range want {
+		group := refs[depType]
+		if len(group) != len(ids) {
+			t.Fatalf("%s: got %d references, want %d", depType, len(group), len(ids))
+		}
+		for i, dep := range group {
+			if dep.IssueID != ids[i] || dep.DependsOnID != target.ID || dep.Type != depType {
+				t.Errorf("%s[%d] = %s -> %s (%s), want %s -> %s", depType, i, dep.IssueID, dep.DependsOnID, dep.Type, ids[i], target.ID)
+			}
+		}
+	}
+
+	refs, err = env.Store.GetIncomingReferences(env.Ctx, unrelated.ID)
+	if err != nil {
+		t.Fatalf("GetIncomingReferences(%s) failed: %v", unrelated.ID, err)
+	}
+	if len(refs[types.DepBlocks]) != 1 || refs[types.DepBlocks][0].IssueID != target.ID {
+		t.Errorf("references to %s = %v, want only %s", unrelated.ID, refs, target.ID)
+	}
+}
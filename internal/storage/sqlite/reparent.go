@@ -0,0 +1,294 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// issueIDReferenceColumns lists every table/column that stores an issue ID and
+// must follow an issue when its ID changes.
+var issueIDReferenceColumns = []struct{ table, column string }{
+	{"dependencies", "issue_id"},
+	{"dependencies", "depends_on_id"},
+	{"events", "issue_id"},
+	{"labels", "issue_id"},
+	{"comments", "issue_id"},
+	{"dirty_issues", "issue_id"},
+	{"export_hashes", "issue_id"},
+	{"issue_snapshots", "issue_id"},
+	{"compaction_snapshots", "issue_id"},
+	{"child_counters", "parent_id"},
+	{"id_aliases", "issue_id"},
+	{"field_provenance", "issue_id"},
+	{"watchers", "issue_id"},
+	{"checklist_items", "issue_id"},
+}
+
+// ReparentIssues moves issues to new parents in a single transaction.
+// moves maps child ID to new parent ID; an empty parent ID detaches the child.
+//
+// For each move the child's parent-child dependency is replaced and a
+// "reparented" event is recorded with the old and new parent. If the child's ID
+// encodes its old parent (hierarchical IDs like bd-a.1), the child and all of
+// its hierarchical descendants are renamed under the new parent (e.g. bd-b.3,
+// bd-b.3.1), and every reference to the old IDs is rewritten.
+//
+// The whole batch is rejected if any move would make an issue its own ancestor
+// (returns an error wrapping ErrCycle). Returns a map of renamed IDs (old -> new).
+func (s *SQLiteStorage) ReparentIssues(ctx context.Context, moves map[string]string, actor string) (map[string]string, error) {
+	renamed := make(map[string]string)
+	if len(moves) == 0 {
+		return renamed, nil
+	}
+
+	err := s.withTx(ctx, func(conn *sql.Conn) error {
+		// Defer FK checks to commit so IDs can be rewritten table by table.
+		if _, err := conn.ExecContext(ctx, `PRAGMA defer_foreign_keys = ON`); err != nil {
+			return fmt.Errorf("failed to defer foreign keys: %w", err)
+		}
+
+		for child, parent := range moves {
+			if child == parent {
+				return fmt.Errorf("%w: cannot make %s its own parent", ErrCycle, child)
+			}
+			for _, id := range []string{child, parent} {
+				if id == "" {
+					continue
+				}
+				var exists bool
+				if err := conn.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM issues WHERE id = ?)`, id).Scan(&exists); err != nil {
+					return fmt.Errorf("failed to check issue %s: %w", id, err)
+				}
+				if !exists {
+					return fmt.Errorf("issue %s not found", id)
+				}
+			}
+		}
+
+		parents, err := loadParentMap(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		// Reject the batch if the resulting hierarchy contains a cycle.
+		proposed := make(map[string]string, len(parents)+len(moves))
+		for child, parent := range parents {
+			proposed[child] = parent
+		}
+		for child, parent := range moves {
+			proposed[child] = parent
+		}
+		for child := range moves {
+			seen := map[string]bool{child: true}
+			for p := proposed[child]; p != ""; p = proposed[p] {
+				if seen[p] {
+					return fmt.Errorf("%w: moving %s under %s would make it its own ancestor", ErrCycle, child, moves[child])
+				}
+				seen[p] = true
+			}
+		}
+
+		// Apply parents before children so renames of moved ancestors are
+		// visible when resolving descendants.
+		children := make([]string, 0, len(moves))
+		for child := range moves {
+			children = append(children, child)
+		}
+		sort.Slice(children, func(i, j int) bool {
+			di, dj := strings.Count(children[i], "."), strings.Count(children[j], ".")
+			if di != dj {
+				return di < dj
+			}
+			return children[i] < children[j]
+		})
+
+		resolve := func(id string) string {
+			if newID, ok := renamed[id]; ok {
+				return newID
+			}
+			return id
+		}
+
+		now := time.Now()
+		for _, origChild := range children {
+			child := resolve(origChild)
+			newParent := resolve(moves[origChild])
+			oldParent := resolve(parents[origChild])
+			if oldParent == newParent {
+				continue
+			}
+
+			if _, err := conn.ExecContext(ctx, `
+				DELETE FROM dependencies WHERE issue_id = ? AND type = ?
+			`, child, types.DepParentChild); err != nil {
+				return fmt.Errorf("failed to remove parent of %s: %w", child, err)
+			}
+
+			// Rename hierarchical IDs that encode the old parent.
+			if isHier, hierParent := IsHierarchicalID(child); isHier && hierParent == oldParent && newParent != "" {
+				subtree, err := reparentHierarchicalID(ctx, conn, child, newParent, actor)
+				if err != nil {
+					return err
+				}
+				for oldID, newID := range subtree {
+					for orig, cur := range renamed {
+						if cur == oldID {
+							renamed[orig] = newID
+						}
+					}
+					if _, ok := renamed[oldID]; !ok {
+						renamed[oldID] = newID
+					}
+				}
+				child = subtree[child]
+			}
+
+			if newParent != "" {
+				if _, err := conn.ExecContext(ctx, `
+					INSERT INTO dependencies (issue_id, depends_on_id, type, created_at, created_by)
+					VALUES (?, ?, ?, ?, ?)
+				`, child, newParent, types.DepParentChild, now, actor); err != nil {
+					return fmt.Errorf("failed to set parent of %s: %w", child, err)
+				}
+			}
+
+			if _, err := conn.ExecContext(ctx, `UPDATE issues SET updated_at = ? WHERE id = ?`, now, child); err != nil {
+				return fmt.Errorf("failed to update timestamp for %s: %w", child, err)
+			}
+			if _, err := conn.ExecContext(ctx, `
+				INSERT INTO events (issue_id, event_type, actor, old_value, new_value)
+				VALUES (?, ?, ?, ?, ?)
+			`, child, types.EventReparented, actor, oldParent, newParent); err != nil {
+				return fmt.Errorf("failed to record reparent event for %s: %w", child, err)
+			}
+			if err := markDirty(ctx, conn, child); err != nil {
+				return err
+			}
+		}
+
+		return s.invalidateBlockedCache(ctx, conn)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return renamed, nil
+}
+
+// loadParentMap returns child -> parent for every issue with a parent, using
+// parent-child dependencies and falling back to the parent encoded in
+// hierarchical IDs.
+func loadParentMap(ctx context.Context, conn *sql.Conn) (map[string]string, error) {
+	parents := make(map[string]string)
+
+	rows, err := conn.QueryContext(ctx, `SELECT id FROM issues`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issues: %w", err)
+	}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("failed to scan issue id: %w", err)
+		}
+		if isHier, parent := IsHierarchicalID(id); isHier {
+			parents[id] = parent
+		}
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list issues: %w", err)
+	}
+
+	rows, err = conn.QueryContext(ctx, `
+		SELECT issue_id, depends_on_id FROM dependencies WHERE type = ?
+	`, types.DepParentChild)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load parent-child dependencies: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+		var child, parent string
+		if err := rows.Scan(&child, &parent); err != nil {
+			return nil, fmt.Errorf("failed to scan parent-child dependency: %w", err)
+		}
+		parents[child] = parent
+	}
+	return parents, rows.Err()
+}
+
+// reparentHierarchicalID renames childID and its hierarchical descendants to a
+// fresh child slot under newParent. Returns old -> new for every renamed ID.
+func reparentHierarchicalID(ctx context.Context, conn *sql.Conn, childID, newParent, actor string) (map[string]string, error) {
+	var nextChild int
+	err := conn.QueryRowContext(ctx, `
+		INSERT INTO child_counters (parent_id, last_child)
+		VALUES (?, 1)
+		ON CONFLICT(parent_id) DO UPDATE SET
+			last_child = last_child + 1
+		RETURNING last_child
+	`, newParent).Scan(&nextChild)
+	if err != nil {
+		return nil, fmt.Errorf("failed to allocate child number under %s: %w", newParent, err)
+	}
+	newChildID := fmt.Sprintf("%s.%d", newParent, nextChild)
+
+	rows, err := conn.QueryContext(ctx, `SELECT id FROM issues WHERE id = ? OR id LIKE ? ESCAPE '\'`,
+		childID, escapeLike(childID)+".%")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subtree of %s: %w", childID, err)
+	}
+	renames := make(map[string]string)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("failed to scan subtree id: %w", err)
+		}
+		renames[id] = newChildID + strings.TrimPrefix(id, childID)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list subtree of %s: %w", childID, err)
+	}
+
+	for oldID, newID := range renames {
+		if err := renameIssueIDWithConn(ctx, conn, oldID, newID, actor); err != nil {
+			return nil, err
+		}
+	}
+	return renames, nil
+}
+
+// renameIssueIDWithConn rewrites an issue's ID and every reference to it.
+// The caller must be inside a transaction with deferred foreign keys.
+func renameIssueIDWithConn(ctx context.Context, conn *sql.Conn, oldID, newID, actor string) error {
+	if _, err := conn.ExecContext(ctx, `UPDATE issues SET id = ? WHERE id = ?`, newID, oldID); err != nil {
+		return fmt.Errorf("failed to rename %s to %s: %w", oldID, newID, err)
+	}
+	for _, ref := range issueIDReferenceColumns {
+		// #nosec G201 - table and column names come from a fixed list
+		query := fmt.Sprintf(`UPDATE %s SET %s = ? WHERE %s = ?`, ref.table, ref.column, ref.column)
+		if _, err := conn.ExecContext(ctx, query, newID, oldID); err != nil {
+			return fmt.Errorf("failed to update %s.%s for %s: %w", ref.table, ref.column, oldID, err)
+		}
+	}
+	if _, err := conn.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, old_value, new_value)
+		VALUES (?, 'renamed', ?, ?, ?)
+	`, newID, actor, oldID, newID); err != nil {
+		return fmt.Errorf("failed to record rename event: %w", err)
+	}
+	return markDirty(ctx, conn, newID)
+}
+
+// escapeLike escapes LIKE wildcards so s matches literally (with ESCAPE '\').
+func escapeLike(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return r.Replace(s)
+}
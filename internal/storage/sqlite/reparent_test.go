@@ -0,0 +1,146 @@
+package sqlite
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// parentOf returns the parent-child target of issueID, or "" if it has none.
+func parentOf(t *testing.T, env *testEnv, issueID string) string {
+	t.Helper()
+	deps, err := env.Store.GetDependencyRecords(env.Ctx, issueID)
+	if err != nil {
+		t.Fatalf("GetDependencyRecords(%s) failed: %v", issueID, err)
+	}
+	for _, dep := range deps {
+		if dep.Type == types.DepParentChild {
+			return dep.DependsOnID
+		}
+	}
+	return ""
+}
+
+func TestReparentIssues_MovesChildren(t *testing.T) {
+	env := newTestEnv(t)
+	epicA := env.CreateEpic("Epic A")
+	epicB := env.CreateEpic("Epic B")
+	task1 := env.CreateIssue("Task 1")
+	task2 := env.CreateIssue("Task 2")
+	env.AddParentChild(task1, epicA)
+	env.AddParentChild(task2, epicA)
+
+	renamed, err := env.Store.ReparentIssues(env.Ctx, map[string]string{
+		task1.ID: epicB.ID,
+		task2.ID: "",
+	}, "alice")
+	if err != nil {
+		t.Fatalf("ReparentIssues failed: %v", err)
+	}
+	if len(renamed) != 0 {
+		t.Errorf("expected no renames for flat IDs, got %v", renamed)
+	}
+
+	if got := parentOf(t, env, task1.ID); got != epicB.ID {
+		t.Errorf("expected %s parent %s, got %q", task1.ID, epicB.ID, got)
+	}
+	if got := parentOf(t, env, task2.ID); got != "" {
+		t.Errorf("expected %s detached, got parent %q", task2.ID, got)
+	}
+
+	events, err := env.Store.GetEvents(env.Ctx, task1.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	found := false
+	for _, e := range events {
+		if e.EventType == types.EventReparented {
+			found = true
+			if e.OldValue == nil || *e.OldValue != epicA.ID || e.NewValue == nil || *e.NewValue != epicB.ID {
+				t.Errorf("unexpected reparent event values: old=%v new=%v", e.OldValue, e.NewValue)
+			}
+			if e.Actor != "alice" {
+				t.Errorf("expected actor alice, got %q", e.Actor)
+			}
+		}
+	}
+	if !found {
+		t.Error("expected a reparented event")
+	}
+}
+
+func TestReparentIssues_RenamesHierarchicalSubtree(t *testing.T) {
+	env := newTestEnv(t)
+	epicA := env.CreateIssueWithID("bd-a", "Epic A")
+	epicB := env.CreateIssueWithID("bd-b", "Epic B")
+	child := env.CreateIssueWithID("bd-a.1", "Child")
+	grandchild := env.CreateIssueWithID("bd-a.1.1", "Grandchild")
+	env.AddParentChild(child, epicA)
+	env.AddParentChild(grandchild, child)
+	if err := env.Store.AddLabel(env.Ctx, grandchild.ID, "keep", "test-user"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+
+	renamed, err := env.Store.ReparentIssues(env.Ctx, map[string]string{child.ID: epicB.ID}, "alice")
+	if err != nil {
+		t.Fatalf("ReparentIssues failed: %v", err)
+	}
+	if renamed["bd-a.1"] != "bd-b.1" || renamed["bd-a.1.1"] != "bd-b.1.1" {
+		t.Fatalf("unexpected renames: %v", renamed)
+	}
+
+	if old, _ := env.Store.GetIssue(env.Ctx, "bd-a.1"); old != nil {
+		t.Error("old child ID should no longer exist")
+	}
+	if got := parentOf(t, env, "bd-b.1"); got != epicB.ID {
+		t.Errorf("expected bd-b.1 parent %s, got %q", epicB.ID, got)
+	}
+	if got := parentOf(t, env, "bd-b.1.1"); got != "bd-b.1" {
+		t.Errorf("expected grandchild to follow its parent, got %q", got)
+	}
+	labels, err := env.Store.GetLabels(env.Ctx, "bd-b.1.1")
+	if err != nil {
+		t.Fatalf("GetLabels failed: %v", err)
+	}
+	if len(labels) != 1 || labels[0] != "keep" {
+		t.Errorf("expected labels to follow rename, got %v", labels)
+	}
+}
+
+func TestReparentIssues_RejectsCycle(t *testing.T) {
+	env := newTestEnv(t)
+	epic := env.CreateEpic("Epic")
+	task := env.CreateIssue("Task")
+	other := env.CreateIssue("Other")
+	env.AddParentChild(task, epic)
+
+	// Moving the epic under its own child would create a cycle; the valid
+	// move of other in the same batch must be rolled back too.
+	_, err := env.Store.ReparentIssues(env.Ctx, map[string]string{
+		epic.ID:  task.ID,
+		other.ID: epic.ID,
+	}, "alice")
+	if !errors.Is(err, ErrCycle) {
+		t.Fatalf("expected ErrCycle, got %v", err)
+	}
+
+	if got := parentOf(t, env, epic.ID); got != "" {
+		t.Errorf("expected epic to keep no parent, got %q", got)
+	}
+	if got := parentOf(t, env, other.ID); got != "" {
+		t.Errorf("expected batch to be rolled back, but %s has parent %q", other.ID, got)
+	}
+	if got := parentOf(t, env, task.ID); got != epic.ID {
+		t.Errorf("expected task to keep parent %s, got %q", epic.ID, got)
+	}
+}
+
+func TestReparentIssues_UnknownIssue(t *testing.T) {
+	env := newTestEnv(t)
+	epic := env.CreateEpic("Epic")
+
+	if _, err := env.Store.ReparentIssues(env.Ctx, map[string]string{"bd-missing": epic.ID}, "alice"); err == nil {
+		t.Fatal("expected error for unknown child")
+	}
+}
@@ -219,6 +219,141 @@ CREATE TABLE IF NOT EXISTS repo_mtimes (
 
 CREATE INDEX IF NOT EXISTS idx_repo_mtimes_checked ON repo_mtimes(last_checked);
 
+-- Status transition rules (optional state machine)
+-- When a from_status has rows here, only the listed to_status values are allowed
+CREATE TABLE IF NOT EXISTS status_transitions (
+    from_status TEXT NOT NULL,
+    to_status TEXT NOT NULL,
+    PRIMARY KEY (from_status, to_status)
+);
+
+-- ID aliases table (original IDs of issues remapped on import)
+CREATE TABLE IF NOT EXISTS id_aliases (
+    alias_id TEXT PRIMARY KEY,
+    issue_id TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_id_aliases_issue ON id_aliases(issue_id);
+
+-- Field provenance table (opt-in import provenance tracking)
+-- Records which import source last set each field of an issue
+CREATE TABLE IF NOT EXISTS field_provenance (
+    issue_id TEXT NOT NULL,
+    field TEXT NOT NULL,
+    source TEXT NOT NULL,
+    updated_at DATETIME NOT NULL,
+    PRIMARY KEY (issue_id, field),
+    FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+);
+
+-- Watchers table (who is notified about changes to each issue)
+CREATE TABLE IF NOT EXISTS watchers (
+    issue_id TEXT NOT NULL,
+    watcher TEXT NOT NULL,
+    PRIMARY KEY (issue_id, watcher),
+    FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_watchers_watcher ON watchers(watcher);
+
+-- Description blobs (large descriptions stored once, referenced by hash)
+CREATE TABLE IF NOT EXISTS description_blobs (
+    hash TEXT PRIMARY KEY,
+    content TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Shadow import log (operations a shadow import would have applied)
+CREATE TABLE IF NOT EXISTS shadow_import_log (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    run INTEGER NOT NULL,
+    source TEXT NOT NULL DEFAULT '',
+    issue_id TEXT NOT NULL,
+    operation TEXT NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    old_hash TEXT NOT NULL DEFAULT '',
+    new_hash TEXT NOT NULL DEFAULT '',
+    payload TEXT,
+    recorded_at DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_shadow_import_log_source ON shadow_import_log(source, run);
+
+-- Checklist items (ordered checklist inside each issue)
+CREATE TABLE IF NOT EXISTS checklist_items (
+    issue_id TEXT NOT NULL,
+    position INTEGER NOT NULL,
+    text TEXT NOT NULL,
+    done INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (issue_id, position),
+    FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+);
+
+-- Issue templates (skeletons issues are instantiated from)
+CREATE TABLE IF NOT EXISTS issue_templates (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL DEFAULT '',
+    title TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    design TEXT NOT NULL DEFAULT '',
+    acceptance_criteria TEXT NOT NULL DEFAULT '',
+    notes TEXT NOT NULL DEFAULT '',
+    issue_type TEXT NOT NULL DEFAULT '',
+    priority INTEGER NOT NULL DEFAULT 2,
+    labels TEXT NOT NULL DEFAULT '',
+    custom_fields TEXT NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL
+);
+
+-- Milestones (sprints, releases) issues are planned for
+CREATE TABLE IF NOT EXISTS milestones (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    start_at DATETIME,
+    end_at DATETIME,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL
+);
+
+-- Closed issues moved out of the active table, with their event history
+CREATE TABLE IF NOT EXISTS archived_issues (
+    id TEXT PRIMARY KEY,
+    title TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    closed_at DATETIME,
+    archived_at DATETIME NOT NULL,
+    data TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_archived_issues_closed_at ON archived_issues(closed_at);
+
+CREATE TABLE IF NOT EXISTS archived_events (
+    id INTEGER PRIMARY KEY,
+    issue_id TEXT NOT NULL,
+    event_type TEXT NOT NULL,
+    actor TEXT NOT NULL,
+    old_value TEXT,
+    new_value TEXT,
+    comment TEXT,
+    created_at DATETIME NOT NULL,
+    FOREIGN KEY (issue_id) REFERENCES archived_issues(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_archived_events_issue ON archived_events(issue_id);
+
+-- Database-wide maintenance operations, such as content hash migrations
+CREATE TABLE IF NOT EXISTS maintenance_log (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    operation TEXT NOT NULL,
+    details TEXT NOT NULL DEFAULT '{}',
+    recorded_at DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_maintenance_log_operation ON maintenance_log(operation, id);
+
 -- Ready work view (with hierarchical blocking)
 -- Uses recursive CTE to propagate blocking through parent-child hierarchy
 CREATE VIEW IF NOT EXISTS ready_issues AS
@@ -34,6 +34,19 @@ var expectedSchema = map[string][]string{
 	"issue_snapshots":      {"id", "issue_id", "snapshot_time", "compaction_level", "original_size", "compressed_size", "original_content", "archived_events"},
 	"compaction_snapshots": {"id", "issue_id", "compaction_level", "snapshot_json", "created_at"},
 	"repo_mtimes":          {"repo_path", "jsonl_path", "mtime_ns", "last_checked"},
+	"status_transitions":   {"from_status", "to_status"},
+	"id_aliases":           {"alias_id", "issue_id", "created_at"},
+	"field_provenance":     {"issue_id", "field", "source", "updated_at"},
+	"watchers":             {"issue_id", "watcher"},
+	"description_blobs":    {"hash", "content", "created_at"},
+	"issues_fts":           {"title", "description"},
+	"shadow_import_log":    {"id", "run", "source", "issue_id", "operation", "reason", "old_hash", "new_hash", "payload", "recorded_at"},
+	"checklist_items":      {"issue_id", "position", "text", "done"},
+	"issue_templates":      {"id", "name", "title", "description", "design", "acceptance_criteria", "notes", "issue_type", "priority", "labels", "custom_fields", "created_at", "updated_at"},
+	"milestones":           {"id", "name", "start_at", "end_at", "created_at", "updated_at"},
+	"archived_issues":      {"id", "title", "description", "closed_at", "archived_at", "data"},
+	"archived_events":      {"id", "issue_id", "event_type", "actor", "old_value", "new_value", "comment", "created_at"},
+	"maintenance_log":      {"id", "operation", "details", "recorded_at"},
 }
 
 // SchemaProbeResult contains the results of a schema compatibility check
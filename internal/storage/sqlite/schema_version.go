@@ -0,0 +1,69 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// CurrentSchemaVersion is the schema version this build migrates databases
+// to: one per entry in migrationsList.
+func CurrentSchemaVersion() int {
+	return len(migrationsList)
+}
+
+// SupportedSchemaVersion returns CurrentSchemaVersion, for callers that hold
+// the store only as a storage.Storage.
+func (s *SQLiteStorage) SupportedSchemaVersion() int {
+	return CurrentSchemaVersion()
+}
+
+// SchemaVersion returns the database's schema version, or 0 for a database
+// last opened before versions were recorded (migrations record it on open).
+func (s *SQLiteStorage) SchemaVersion(ctx context.Context) (int, error) {
+	s.checkFreshness()
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+	return getSchemaVersion(ctx, s.db)
+}
+
+// SchemaVersion returns the database's schema version within the transaction.
+func (t *sqliteTxStorage) SchemaVersion(ctx context.Context) (int, error) {
+	return getSchemaVersion(ctx, t.conn)
+}
+
+// SetSchemaVersion records version within the transaction, so it commits or
+// rolls back with the schema change it describes.
+func (t *sqliteTxStorage) SetSchemaVersion(ctx context.Context, version int) error {
+	return setSchemaVersion(ctx, t.conn, version)
+}
+
+func getSchemaVersion(ctx context.Context, db dbExecutor) (int, error) {
+	var value string
+	err := db.QueryRowContext(ctx, `SELECT value FROM config WHERE key = ?`, storage.SchemaVersionConfigKey).Scan(&value)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, wrapDBError("get schema version", err)
+	}
+	version, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", storage.SchemaVersionConfigKey, value, err)
+	}
+	return version, nil
+}
+
+func setSchemaVersion(ctx context.Context, db dbExecutor, version int) error {
+	if version < 0 {
+		return fmt.Errorf("schema version cannot be negative (got %d)", version)
+	}
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO config (key, value) VALUES (?, ?)
+		ON CONFLICT (key) DO UPDATE SET value = excluded.value
+	`, storage.SchemaVersionConfigKey, strconv.Itoa(version))
+	return wrapDBError("set schema version", err)
+}
@@ -0,0 +1,70 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+func TestSchemaVersion(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := env.Ctx
+	db := env.Store.db
+
+	version, err := env.Store.SchemaVersion(ctx)
+	if err != nil {
+		t.Fatalf("SchemaVersion failed: %v", err)
+	}
+	if version != CurrentSchemaVersion() {
+		t.Fatalf("fresh database at version %d, want %d", version, CurrentSchemaVersion())
+	}
+
+	// A new migration bumps the version in the same transaction...
+	saved := migrationsList
+	t.Cleanup(func() { migrationsList = saved })
+	migrationsList = append(append([]Migration{}, saved...), Migration{"test_widgets_table", func(db *sql.DB) error {
+		_, err := db.Exec(`CREATE TABLE IF NOT EXISTS test_widgets (id TEXT PRIMARY KEY)`)
+		return err
+	}})
+	if err := RunMigrations(db); err != nil {
+		t.Fatalf("RunMigrations failed: %v", err)
+	}
+	if version, _ = env.Store.SchemaVersion(ctx); version != len(saved)+1 {
+		t.Errorf("after migration at version %d, want %d", version, len(saved)+1)
+	}
+
+	// ...so a failing one leaves both schema and version as they were
+	migrationsList = append(append([]Migration{}, migrationsList...), Migration{"test_broken", func(db *sql.DB) error {
+		if _, err := db.Exec(`CREATE TABLE test_gadgets (id TEXT PRIMARY KEY)`); err != nil {
+			return err
+		}
+		return errors.New("boom")
+	}})
+	if err := RunMigrations(db); err == nil {
+		t.Fatal("expected failing migration to fail")
+	}
+	if version, _ = env.Store.SchemaVersion(ctx); version != len(saved)+1 {
+		t.Errorf("after failed migration at version %d, want %d", version, len(saved)+1)
+	}
+	var n int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE name = 'test_gadgets'`).Scan(&n); err != nil || n != 0 {
+		t.Errorf("expected failed migration to be rolled back (tables: %d, err: %v)", n, err)
+	}
+
+	// Versions written by a newer build are never lowered
+	migrationsList = saved
+	err = env.Store.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		return tx.(*sqliteTxStorage).SetSchemaVersion(ctx, len(saved)+5)
+	})
+	if err != nil {
+		t.Fatalf("SetSchemaVersion failed: %v", err)
+	}
+	if err := RunMigrations(db); err != nil {
+		t.Fatalf("RunMigrations failed: %v", err)
+	}
+	if version, _ = env.Store.SchemaVersion(ctx); version != len(saved)+5 {
+		t.Errorf("newer version lowered to %d", version)
+	}
+}
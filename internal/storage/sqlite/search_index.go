@@ -0,0 +1,73 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite/migrations"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// RebuildSearchIndex regenerates the derived tables from the issues table:
+// the issues_fts full-text index and the blocked issues cache. Triggers keep
+// both current for normal writes; run it after bulk loads that bypassed them.
+// It is idempotent and returns the number of issues indexed.
+func (s *SQLiteStorage) RebuildSearchIndex(ctx context.Context) (int, error) {
+	var indexed int64
+	err := s.withTx(ctx, func(conn *sql.Conn) error {
+		if _, err := conn.ExecContext(ctx, `DELETE FROM issues_fts`); err != nil {
+			return fmt.Errorf("failed to clear search index: %w", err)
+		}
+		res, err := conn.ExecContext(ctx, `
+			INSERT INTO issues_fts (rowid, title, description)
+			SELECT rowid, title, `+strings.ReplaceAll(migrations.IssuesFTSDescription, "{row}", "issues")+`
+			FROM issues
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to rebuild search index: %w", err)
+		}
+		if indexed, err = res.RowsAffected(); err != nil {
+			return fmt.Errorf("failed to count indexed issues: %w", err)
+		}
+		if err := s.rebuildBlockedCache(ctx, conn); err != nil {
+			return fmt.Errorf("failed to rebuild blocked cache: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(indexed), nil
+}
+
+// SearchIssuesFullText returns the issues whose title or description matches
+// the FTS5 query, best match first, at most limit of them (all when limit is
+// 0 or less). Tombstones are excluded.
+func (s *SQLiteStorage) SearchIssuesFullText(ctx context.Context, query string, limit int) ([]*types.Issue, error) {
+	if strings.TrimSpace(query) == "" {
+		return []*types.Issue{}, nil
+	}
+	s.checkFreshness()
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	if limit <= 0 {
+		limit = -1
+	}
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+rangeIssueColumns+`
+		FROM (SELECT rowid AS fts_rowid, rank AS fts_rank FROM issues_fts WHERE issues_fts MATCH ?) f
+		JOIN issues ON issues.rowid = f.fts_rowid
+		WHERE status != 'tombstone'
+		ORDER BY f.fts_rank, id
+		LIMIT ?
+	`, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search issues: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return scanIssueList(ctx, s, rows)
+}
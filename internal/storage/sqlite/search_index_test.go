@@ -0,0 +1,85 @@
+package sqlite
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSearchIndex(t *testing.T) {
+	env := newTestEnv(t)
+	if err := env.Store.SetConfig(env.Ctx, DescriptionBlobThresholdConfigKey, "64"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+
+	newIssue := func(title, description string) *types.Issue {
+		issue := &types.Issue{Title: title, Description: description, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := env.Store.CreateIssue(env.Ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue
+	}
+	login := newIssue("Login page crashes", "Stack trace in the auth handler")
+	blob := newIssue("Release checklist", strings.Repeat("Verify the zeppelin rollout. ", 5))
+	gone := newIssue("Old login flow", "")
+	if err := env.Store.CreateTombstone(env.Ctx, gone.ID, "test-user", "obsolete"); err != nil {
+		t.Fatalf("CreateTombstone failed: %v", err)
+	}
+
+	search := func(query string) []string {
+		t.Helper()
+		issues, err := env.Store.SearchIssuesFullText(env.Ctx, query, 0)
+		if err != nil {
+			t.Fatalf("SearchIssuesFullText(%q) failed: %v", query, err)
+		}
+		ids := make([]string, 0, len(issues))
+		for _, issue := range issues {
+			ids = append(ids, issue.ID)
+		}
+		return ids
+	}
+	expect := func(query string, want ...string) {
+		t.Helper()
+		if got := search(query); strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("search %q: got %v, want %v", query, got, want)
+		}
+	}
+
+	expect("login", login.ID)
+	expect("auth", login.ID)
+	expect("zeppelin", blob.ID)
+
+	if err := env.Store.UpdateIssue(env.Ctx, login.ID, map[string]interface{}{"title": "Signin page crashes"}, "test-user"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	expect("login")
+	expect("signin", login.ID)
+
+	// Simulate a bulk load that bypassed the triggers.
+	if _, err := env.Store.db.ExecContext(env.Ctx, `DELETE FROM issues_fts`); err != nil {
+		t.Fatalf("failed to clear index: %v", err)
+	}
+	expect("signin")
+
+	for i := 0; i < 2; i++ {
+		indexed, err := env.Store.RebuildSearchIndex(env.Ctx)
+		if err != nil {
+			t.Fatalf("RebuildSearchIndex failed: %v", err)
+		}
+		if indexed != 3 {
+			t.Errorf("rebuild %d: expected 3 issues indexed, got %d", i+1, indexed)
+		}
+	}
+	expect("signin", login.ID)
+	expect("zeppelin", blob.ID)
+	expect("flow")
+
+	var rows int
+	if err := env.Store.db.QueryRowContext(env.Ctx, `SELECT COUNT(*) FROM issues_fts`).Scan(&rows); err != nil {
+		t.Fatalf("failed to count index rows: %v", err)
+	}
+	if rows != 3 {
+		t.Errorf("expected rebuilds to leave 3 index rows, got %d", rows)
+	}
+}
@@ -0,0 +1,86 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// RecordShadowRun appends ops to the shadow import log as a new run for
+// source and returns the run number. Runs are numbered from 1 across all
+// sources. Each op's Run, Source and RecordedAt are set from the run.
+func (s *SQLiteStorage) RecordShadowRun(ctx context.Context, source string, ops []*types.ShadowOperation) (int64, error) {
+	var run int64
+	err := s.withTx(ctx, func(conn *sql.Conn) error {
+		if err := conn.QueryRowContext(ctx, `SELECT COALESCE(MAX(run), 0) + 1 FROM shadow_import_log`).Scan(&run); err != nil {
+			return fmt.Errorf("failed to number shadow run: %w", err)
+		}
+		now := time.Now().UTC()
+		for _, op := range ops {
+			var payload interface{}
+			if op.Issue != nil {
+				data, err := json.Marshal(op.Issue)
+				if err != nil {
+					return fmt.Errorf("failed to encode shadow payload for %s: %w", op.IssueID, err)
+				}
+				payload = string(data)
+			}
+			if _, err := conn.ExecContext(ctx, `
+				INSERT INTO shadow_import_log (run, source, issue_id, operation, reason, old_hash, new_hash, payload, recorded_at)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+			`, run, source, op.IssueID, string(op.Operation), op.Reason, op.OldHash, op.NewHash, payload, now); err != nil {
+				return fmt.Errorf("failed to record shadow operation for %s: %w", op.IssueID, err)
+			}
+			op.Run, op.Source, op.RecordedAt = run, source, now
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return run, nil
+}
+
+// GetShadowOperations returns the shadow import log for source (every source
+// when empty), oldest run first and in recording order within a run.
+func (s *SQLiteStorage) GetShadowOperations(ctx context.Context, source string) ([]*types.ShadowOperation, error) {
+	s.checkFreshness()
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT run, source, issue_id, operation, reason, old_hash, new_hash, payload, recorded_at
+		FROM shadow_import_log
+		WHERE ? = '' OR source = ?
+		ORDER BY run, id
+	`, source, source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read shadow import log: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	ops := []*types.ShadowOperation{}
+	for rows.Next() {
+		var op types.ShadowOperation
+		var operation string
+		var payload sql.NullString
+		var recordedAt string
+		if err := rows.Scan(&op.Run, &op.Source, &op.IssueID, &operation, &op.Reason, &op.OldHash, &op.NewHash, &payload, &recordedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan shadow operation: %w", err)
+		}
+		op.Operation = types.ShadowOp(operation)
+		op.RecordedAt = parseTimeString(recordedAt)
+		if payload.Valid {
+			op.Issue = &types.Issue{}
+			if err := json.Unmarshal([]byte(payload.String), op.Issue); err != nil {
+				return nil, fmt.Errorf("failed to decode shadow payload for %s: %w", op.IssueID, err)
+			}
+		}
+		ops = append(ops, &op)
+	}
+	return ops, wrapDBError("iterate shadow import log", rows.Err())
+}
@@ -0,0 +1,46 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestShadowImportLog(t *testing.T) {
+	env := newTestEnv(t)
+
+	run, err := env.Store.RecordShadowRun(env.Ctx, "jira", []*types.ShadowOperation{
+		{IssueID: "bd-1", Operation: types.ShadowCreate, NewHash: "h1", Issue: &types.Issue{ID: "bd-1", Title: "Planned"}},
+		{IssueID: "bd-2", Operation: types.ShadowDelete, OldHash: "h2"},
+	})
+	if err != nil || run != 1 {
+		t.Fatalf("RecordShadowRun: got run %d, err %v", run, err)
+	}
+	if run, err = env.Store.RecordShadowRun(env.Ctx, "github", []*types.ShadowOperation{
+		{IssueID: "bd-3", Operation: types.ShadowSkip, Reason: "updates disabled"},
+	}); err != nil || run != 2 {
+		t.Fatalf("RecordShadowRun: got run %d, err %v", run, err)
+	}
+
+	all, err := env.Store.GetShadowOperations(env.Ctx, "")
+	if err != nil {
+		t.Fatalf("GetShadowOperations failed: %v", err)
+	}
+	if len(all) != 3 {
+		t.Fatalf("expected 3 entries across sources, got %d", len(all))
+	}
+	if got := all[0]; got.Source != "jira" || got.Issue == nil || got.Issue.Title != "Planned" || got.RecordedAt.IsZero() {
+		t.Errorf("expected first entry with decoded payload, got %+v", got)
+	}
+	if got := all[1]; got.Issue != nil || got.OldHash != "h2" {
+		t.Errorf("expected delete entry without payload, got %+v", got)
+	}
+	if got := all[2]; got.Run != 2 || got.Reason != "updates disabled" {
+		t.Errorf("expected second run's skip entry, got %+v", got)
+	}
+
+	jira, err := env.Store.GetShadowOperations(env.Ctx, "jira")
+	if err != nil || len(jira) != 2 {
+		t.Errorf("expected 2 jira entries, got %d (%v)", len(jira), err)
+	}
+}
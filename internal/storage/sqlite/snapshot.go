@@ -0,0 +1,62 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ExportSnapshot writes every issue to w like StreamExport, preceded by a
+// types.SnapshotHeader line naming the format version, export time, issue
+// prefix, issue count and source (free-form, e.g. a repository path). The
+// count is taken when the export starts; the trailing ExportSummary carries
+// the number of issues actually written.
+func (s *SQLiteStorage) ExportSnapshot(ctx context.Context, w io.Writer, source string) error {
+	prefix, err := s.GetConfig(ctx, "issue_prefix")
+	if err != nil {
+		return err
+	}
+	count, err := s.countExportIssues(ctx, types.IssueFilter{})
+	if err != nil {
+		return err
+	}
+
+	header := types.SnapshotHeader{
+		Header:        true,
+		SchemaVersion: types.SnapshotFormatVersion,
+		ExportedAt:    time.Now().UTC(),
+		Prefix:        prefix,
+		IssueCount:    count,
+		Source:        source,
+	}
+	if err := json.NewEncoder(w).Encode(header); err != nil {
+		return fmt.Errorf("failed to write snapshot header: %w", err)
+	}
+	return s.streamExport(ctx, w, types.IssueFilter{}, nil, nil)
+}
+
+// countExportIssues counts the issues StreamExport would write for filter.
+func (s *SQLiteStorage) countExportIssues(ctx context.Context, filter types.IssueFilter) (int, error) {
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	whereClauses, args := buildIssueFilterClauses("", filter)
+	whereSQL := ""
+	if len(whereClauses) > 0 {
+		whereSQL = "WHERE " + strings.Join(whereClauses, " AND ")
+	}
+	var count int
+	// #nosec G201 - safe SQL with controlled formatting
+	if err := s.db.QueryRowContext(ctx, fmt.Sprintf(`SELECT COUNT(*) FROM issues %s`, whereSQL), args...).Scan(&count); err != nil {
+		return 0, wrapDBError("count export issues", err)
+	}
+	if filter.Limit > 0 && count > filter.Limit {
+		count = filter.Limit
+	}
+	return count, nil
+}
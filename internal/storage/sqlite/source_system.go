@@ -0,0 +1,31 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ListIssuesBySource returns the issues whose SourceSystem is source, ordered
+// by ID. An empty source lists the issues with no recorded origin. Tombstones
+// are excluded. The scan uses idx_issues_source_system.
+func (s *SQLiteStorage) ListIssuesBySource(ctx context.Context, source string) ([]*types.Issue, error) {
+	s.checkFreshness()
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+rangeIssueColumns+`
+		FROM issues
+		WHERE source_system = ?
+		  AND status != 'tombstone'
+		ORDER BY source_system, id
+	`, source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issues by source: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return scanIssueList(ctx, s, rows)
+}
@@ -0,0 +1,79 @@
+package sqlite
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSourceSystem_RoundTripAndFilter(t *testing.T) {
+	env := newTestEnv(t)
+
+	for _, issue := range []*types.Issue{
+		{ID: "bd-j2", Title: "From Jira", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, SourceSystem: "jira"},
+		{ID: "bd-j1", Title: "Also Jira", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, SourceSystem: "jira"},
+		{ID: "bd-g1", Title: "From GitHub", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, SourceSystem: "github"},
+		{ID: "bd-m1", Title: "Manual", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+	} {
+		if err := env.Store.CreateIssue(env.Ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	got, err := env.Store.GetIssue(env.Ctx, "bd-g1")
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.SourceSystem != "github" {
+		t.Errorf("expected source system persisted, got %q", got.SourceSystem)
+	}
+
+	ids := func(source string) string {
+		t.Helper()
+		issues, err := env.Store.ListIssuesBySource(env.Ctx, source)
+		if err != nil {
+			t.Fatalf("ListIssuesBySource failed: %v", err)
+		}
+		var out []string
+		for _, issue := range issues {
+			out = append(out, issue.ID)
+		}
+		return strings.Join(out, " ")
+	}
+	if got := ids("jira"); got != "bd-j1 bd-j2" {
+		t.Errorf("jira issues = %q, want bd-j1 bd-j2", got)
+	}
+	if got := ids(""); got != "bd-m1" {
+		t.Errorf("issues without a source = %q, want bd-m1", got)
+	}
+	if got := ids("linear"); got != "" {
+		t.Errorf("expected no linear issues, got %q", got)
+	}
+
+	// Rerouting an issue moves it between sources and keeps its hash current
+	if err := env.Store.UpdateIssue(env.Ctx, "bd-j2", map[string]interface{}{"source_system": "github"}, "test-user"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	got, err = env.Store.GetIssue(env.Ctx, "bd-j2")
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.SourceSystem != "github" || got.ContentHash != got.ComputeContentHash() {
+		t.Errorf("expected updated source with a matching hash, got %q", got.SourceSystem)
+	}
+	if got := ids("github"); got != "bd-g1 bd-j2" {
+		t.Errorf("github issues = %q, want bd-g1 bd-j2", got)
+	}
+
+	var buf bytes.Buffer
+	if err := env.Store.StreamExport(env.Ctx, &buf, types.IssueFilter{}); err != nil {
+		t.Fatalf("StreamExport failed: %v", err)
+	}
+	for _, issue := range decodeExport(t, buf.Bytes()) {
+		if issue.ID == "bd-j1" && issue.SourceSystem != "jira" {
+			t.Errorf("expected source system exported, got %q", issue.SourceSystem)
+		}
+	}
+}
@@ -1637,3 +1637,43 @@ func TestIsClosed(t *testing.T) {
 		t.Error("Store should be closed after calling Close()")
 	}
 }
+
+func TestCreateOrGetIssue(t *testing.T) {
+	env := newTestEnv(t)
+
+	issue := &types.Issue{ID: "bd-retry1", Title: "First attempt", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	got, created, err := env.Store.CreateOrGetIssue(env.Ctx, issue, "integration")
+	if err != nil {
+		t.Fatalf("CreateOrGetIssue failed: %v", err)
+	}
+	if !created || got != issue {
+		t.Fatalf("expected the issue to be created, got created=%v", created)
+	}
+
+	// A retry with different content returns the stored issue untouched
+	retry := &types.Issue{ID: "bd-retry1", Title: "Second attempt", Status: types.StatusOpen, Priority: 0, IssueType: types.TypeBug}
+	got, created, err = env.Store.CreateOrGetIssue(env.Ctx, retry, "integration")
+	if err != nil {
+		t.Fatalf("CreateOrGetIssue retry failed: %v", err)
+	}
+	if created {
+		t.Fatal("expected existing issue to be returned, not created")
+	}
+	if got.Title != "First attempt" || got.Priority != 2 || got.IssueType != types.TypeTask {
+		t.Errorf("expected existing issue unmodified, got %+v", got)
+	}
+
+	events, err := env.Store.GetEvents(env.Ctx, "bd-retry1", 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	if len(events) != 1 {
+		t.Errorf("expected only the original creation event, got %d events", len(events))
+	}
+
+	// Without an ID there is nothing to match, so a new issue is always created
+	fresh := &types.Issue{Title: "No ID", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if _, created, err := env.Store.CreateOrGetIssue(env.Ctx, fresh, "integration"); err != nil || !created || fresh.ID == "" {
+		t.Errorf("expected generated-ID issue to be created, got created=%v id=%q err=%v", created, fresh.ID, err)
+	}
+}
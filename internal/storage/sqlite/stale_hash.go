@@ -0,0 +1,73 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// staleHashBatchSize is the number of issues ListIssuesWithStaleHash loads
+// per batch.
+var staleHashBatchSize = 500
+
+// ListIssuesWithStaleHash recomputes the content hash of every issue
+// (tombstones included) under the database's hash settings and returns, in ID order,
+// those whose stored hash differs: rows written by buggy code or under an
+// older algorithm. Nothing is modified; each returned issue's ContentHash is
+// the stale stored value, and MigrateContentHashes fixes them. Issues are
+// read in ID-ordered batches so memory is bounded by the batch size plus the
+// issues returned. limit stops the scan after that many when > 0.
+func (s *SQLiteStorage) ListIssuesWithStaleHash(ctx context.Context, limit int) ([]*types.Issue, error) {
+	stale := []*types.Issue{}
+	after := ""
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		ids, err := s.issueIDsAfter(ctx, after, staleHashBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		hashOpts := getHashOptions(ctx, s.db)
+		for _, id := range ids {
+			issue, err := s.GetIssue(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			if issue != nil && issue.ComputeContentHashWith(hashOpts) != issue.ContentHash {
+				stale = append(stale, issue)
+				if limit > 0 && len(stale) >= limit {
+					return stale, nil
+				}
+			}
+		}
+		if len(ids) < staleHashBatchSize {
+			return stale, nil
+		}
+		after = ids[len(ids)-1]
+	}
+}
+
+// issueIDsAfter returns up to n issue IDs greater than after, in ID order.
+func (s *SQLiteStorage) issueIDsAfter(ctx context.Context, after string, n int) ([]string, error) {
+	s.checkFreshness()
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	rows, err := s.db.QueryContext(ctx, `SELECT id FROM issues WHERE id > ? ORDER BY id LIMIT ?`, after, n)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issues: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan issue id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, wrapDBError("list issues", rows.Err())
+}
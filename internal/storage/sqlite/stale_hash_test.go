@@ -0,0 +1,50 @@
+package sqlite
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+)
+
+func TestListIssuesWithStaleHash(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := env.Ctx
+
+	origBatch := staleHashBatchSize
+	t.Cleanup(func() { staleHashBatchSize = origBatch })
+	staleHashBatchSize = 2
+
+	stale, err := env.Store.ListIssuesWithStaleHash(ctx, 0)
+	if err != nil || len(stale) != 0 {
+		t.Fatalf("empty database = %v, %v; want none", stale, err)
+	}
+
+	var ids []string
+	for i := 0; i < 5; i++ {
+		ids = append(ids, env.CreateIssue(fmt.Sprintf("Issue %d", i)).ID)
+	}
+	sort.Strings(ids)
+	stale, err = env.Store.ListIssuesWithStaleHash(ctx, 0)
+	if err != nil || len(stale) != 0 {
+		t.Fatalf("fresh hashes = %v, %v; want none stale", stale, err)
+	}
+
+	// Corrupt one hash in the first batch and one in the last, by ID order
+	for _, id := range []string{ids[0], ids[4]} {
+		if _, err := env.Store.db.ExecContext(ctx, `UPDATE issues SET content_hash = 'bogus' WHERE id = ?`, id); err != nil {
+			t.Fatalf("failed to seed hash: %v", err)
+		}
+	}
+	stale, err = env.Store.ListIssuesWithStaleHash(ctx, 0)
+	if err != nil {
+		t.Fatalf("ListIssuesWithStaleHash failed: %v", err)
+	}
+	if len(stale) != 2 || stale[0].ID != ids[0] || stale[1].ID != ids[4] || stale[0].ContentHash != "bogus" {
+		t.Errorf("stale = %v, want %s and %s with their stored hash", stale, ids[0], ids[4])
+	}
+
+	stale, err = env.Store.ListIssuesWithStaleHash(ctx, 1)
+	if err != nil || len(stale) != 1 || stale[0].ID != ids[0] {
+		t.Errorf("limit 1 = %v, %v; want only %s", stale, err, ids[0])
+	}
+}
@@ -0,0 +1,134 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// StatusTransition is an allowed from -> to status change.
+type StatusTransition struct {
+	From types.Status `json:"from"`
+	To   types.Status `json:"to"`
+}
+
+// AddStatusTransition allows issues in status from to move to status to.
+//
+// Transition rules are opt-in per source status: a status with no rules may
+// move anywhere, while a status with at least one rule may only move to the
+// statuses listed for it. Both statuses must be built-in or configured custom
+// statuses. Rules apply to UpdateIssue and CloseIssue, but not to imports
+// (see storage.WithImport), which are authoritative.
+func (s *SQLiteStorage) AddStatusTransition(ctx context.Context, from, to types.Status) error {
+	customStatuses, err := s.GetCustomStatuses(ctx)
+	if err != nil {
+		return wrapDBError("get custom statuses", err)
+	}
+	for _, status := range []types.Status{from, to} {
+		if !status.IsValidWithCustom(customStatuses) {
+			return fmt.Errorf("invalid status for transition rule: %s", status)
+		}
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		INSERT OR IGNORE INTO status_transitions (from_status, to_status) VALUES (?, ?)
+	`, from, to)
+	if err != nil {
+		return fmt.Errorf("failed to add status transition: %w", err)
+	}
+	return nil
+}
+
+// RemoveStatusTransition deletes an allowed transition. Removing the last rule
+// for a status makes that status unrestricted again.
+func (s *SQLiteStorage) RemoveStatusTransition(ctx context.Context, from, to types.Status) error {
+	_, err := s.db.ExecContext(ctx, `
+		DELETE FROM status_transitions WHERE from_status = ? AND to_status = ?
+	`, from, to)
+	if err != nil {
+		return fmt.Errorf("failed to remove status transition: %w", err)
+	}
+	return nil
+}
+
+// GetStatusTransitions returns all configured transition rules, ordered by
+// from and to status.
+func (s *SQLiteStorage) GetStatusTransitions(ctx context.Context) ([]StatusTransition, error) {
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT from_status, to_status FROM status_transitions ORDER BY from_status, to_status
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status transitions: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var transitions []StatusTransition
+	for rows.Next() {
+		var t StatusTransition
+		if err := rows.Scan(&t.From, &t.To); err != nil {
+			return nil, fmt.Errorf("failed to scan status transition: %w", err)
+		}
+		transitions = append(transitions, t)
+	}
+	return transitions, rows.Err()
+}
+
+// checkStatusTransition returns an *IllegalTransitionError if the rules forbid
+// moving issueID from one status to another. Unchanged statuses and imports
+// (contexts marked with storage.WithImport) always pass.
+func checkStatusTransition(ctx context.Context, db dbExecutor, issueID string, from, to types.Status) error {
+	if from == to || storage.IsImport(ctx) {
+		return nil
+	}
+
+	var restricted, allowed bool
+	err := db.QueryRowContext(ctx, `
+		SELECT
+			EXISTS(SELECT 1 FROM status_transitions WHERE from_status = ?),
+			EXISTS(SELECT 1 FROM status_transitions WHERE from_status = ? AND to_status = ?)
+	`, from, from, to).Scan(&restricted, &allowed)
+	if err != nil {
+		return fmt.Errorf("failed to check status transition: %w", err)
+	}
+	if restricted && !allowed {
+		return &IllegalTransitionError{IssueID: issueID, From: from, To: to}
+	}
+	return nil
+}
+
+// checkStatusUpdate applies checkStatusTransition when updates change status.
+func checkStatusUpdate(ctx context.Context, db dbExecutor, oldIssue *types.Issue, updates map[string]interface{}) error {
+	value, ok := updates["status"]
+	if !ok {
+		return nil
+	}
+	var to types.Status
+	switch v := value.(type) {
+	case types.Status:
+		to = v
+	case string:
+		to = types.Status(v)
+	default:
+		return nil
+	}
+	return checkStatusTransition(ctx, db, oldIssue.ID, oldIssue.Status, to)
+}
+
+// currentStatus reads an issue's status, returning "" if it does not exist.
+func currentStatus(ctx context.Context, db dbExecutor, id string) (types.Status, error) {
+	var status types.Status
+	err := db.QueryRowContext(ctx, `SELECT status FROM issues WHERE id = ?`, id).Scan(&status)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get status for %s: %w", id, err)
+	}
+	return status, nil
+}
@@ -0,0 +1,132 @@
+package sqlite
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestStatusTransitions_Allowed(t *testing.T) {
+	env := newTestEnv(t)
+	issue := env.CreateIssue("Task")
+
+	if err := env.Store.AddStatusTransition(env.Ctx, types.StatusOpen, types.StatusInProgress); err != nil {
+		t.Fatalf("AddStatusTransition failed: %v", err)
+	}
+
+	if err := env.Store.UpdateIssue(env.Ctx, issue.ID, map[string]interface{}{"status": types.StatusInProgress}, "alice"); err != nil {
+		t.Fatalf("allowed transition rejected: %v", err)
+	}
+
+	// in_progress has no rules, so it is unrestricted
+	if err := env.Store.CloseIssue(env.Ctx, issue.ID, "done", "alice", ""); err != nil {
+		t.Fatalf("unrestricted transition rejected: %v", err)
+	}
+
+	// Non-status updates are never checked
+	if err := env.Store.UpdateIssue(env.Ctx, issue.ID, map[string]interface{}{"title": "Renamed"}, "alice"); err != nil {
+		t.Fatalf("non-status update rejected: %v", err)
+	}
+}
+
+func TestStatusTransitions_Forbidden(t *testing.T) {
+	env := newTestEnv(t)
+	issue := env.CreateIssue("Task")
+
+	// open may only move to in_progress
+	if err := env.Store.AddStatusTransition(env.Ctx, types.StatusOpen, types.StatusInProgress); err != nil {
+		t.Fatalf("AddStatusTransition failed: %v", err)
+	}
+
+	err := env.Store.CloseIssue(env.Ctx, issue.ID, "skip ahead", "alice", "")
+	var transErr *IllegalTransitionError
+	if !errors.As(err, &transErr) {
+		t.Fatalf("expected IllegalTransitionError, got %v", err)
+	}
+	if transErr.From != types.StatusOpen || transErr.To != types.StatusClosed || transErr.IssueID != issue.ID {
+		t.Errorf("unexpected transition in error: %+v", transErr)
+	}
+	if !IsIllegalTransition(err) {
+		t.Error("IsIllegalTransition should report true")
+	}
+
+	err = env.Store.UpdateIssue(env.Ctx, issue.ID, map[string]interface{}{"status": string(types.StatusBlocked)}, "alice")
+	if !IsIllegalTransition(err) {
+		t.Fatalf("expected illegal transition for open -> blocked, got %v", err)
+	}
+
+	err = env.Store.RunInTransaction(env.Ctx, func(tx storage.Transaction) error {
+		return tx.UpdateIssue(env.Ctx, issue.ID, map[string]interface{}{"status": types.StatusBlocked}, "alice")
+	})
+	if !IsIllegalTransition(err) {
+		t.Fatalf("expected illegal transition in transaction, got %v", err)
+	}
+
+	got, err := env.Store.GetIssue(env.Ctx, issue.ID)
+	if err != nil || got == nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Status != types.StatusOpen {
+		t.Errorf("expected status to stay open, got %s", got.Status)
+	}
+
+	// Removing the last rule lifts the restriction
+	if err := env.Store.RemoveStatusTransition(env.Ctx, types.StatusOpen, types.StatusInProgress); err != nil {
+		t.Fatalf("RemoveStatusTransition failed: %v", err)
+	}
+	if err := env.Store.UpdateIssue(env.Ctx, issue.ID, map[string]interface{}{"status": types.StatusBlocked}, "alice"); err != nil {
+		t.Fatalf("expected unrestricted transition after removing rules, got %v", err)
+	}
+}
+
+func TestStatusTransitions_CustomStatuses(t *testing.T) {
+	env := newTestEnv(t)
+
+	if err := env.Store.AddStatusTransition(env.Ctx, types.StatusOpen, "review"); err == nil {
+		t.Fatal("expected error for unknown status")
+	}
+
+	if err := env.Store.SetConfig(env.Ctx, CustomStatusConfigKey, "review"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	if err := env.Store.AddStatusTransition(env.Ctx, types.StatusOpen, "review"); err != nil {
+		t.Fatalf("AddStatusTransition with custom status failed: %v", err)
+	}
+
+	issue := env.CreateIssue("Task")
+	if err := env.Store.UpdateIssue(env.Ctx, issue.ID, map[string]interface{}{"status": "review"}, "alice"); err != nil {
+		t.Fatalf("transition to custom status rejected: %v", err)
+	}
+
+	transitions, err := env.Store.GetStatusTransitions(env.Ctx)
+	if err != nil {
+		t.Fatalf("GetStatusTransitions failed: %v", err)
+	}
+	if len(transitions) != 1 || transitions[0].To != "review" {
+		t.Errorf("unexpected transitions: %+v", transitions)
+	}
+}
+
+func TestStatusTransitions_ImportBypassesRules(t *testing.T) {
+	env := newTestEnv(t)
+	issue := env.CreateIssue("Task")
+
+	if err := env.Store.AddStatusTransition(env.Ctx, types.StatusOpen, types.StatusInProgress); err != nil {
+		t.Fatalf("AddStatusTransition failed: %v", err)
+	}
+
+	ctx := storage.WithImport(env.Ctx)
+	if err := env.Store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": types.StatusClosed}, "import"); err != nil {
+		t.Fatalf("import update should bypass transition rules: %v", err)
+	}
+
+	got, err := env.Store.GetIssue(env.Ctx, issue.ID)
+	if err != nil || got == nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Status != types.StatusClosed {
+		t.Errorf("expected imported status closed, got %s", got.Status)
+	}
+}
@@ -68,6 +68,9 @@ type SQLiteStorage struct {
 	readOnly    bool              // True if opened in read-only mode (GH#804)
 	freshness   *FreshnessChecker // Optional freshness checker for daemon mode
 	reconnectMu sync.RWMutex      // Protects reconnection and db access (GH#607)
+	customCache customConfigCache // Cached custom status/type config (see SetCustomConfigCacheEnabled)
+	foreignKeys atomic.Int32      // Foreign key enforcement override for every connection (see SetForeignKeyEnforcement)
+	validator   atomic.Value      // IssueValidator run after built-in validation (see SetIssueValidator)
 }
 
 // setupWASMCache configures WASM compilation caching to reduce SQLite startup time.
@@ -124,6 +127,13 @@ func New(ctx context.Context, path string) (*SQLiteStorage, error) {
 
 // NewWithTimeout creates a new SQLite storage backend with configurable busy timeout.
 // A timeout of 0 means fail immediately if the database is locked.
+//
+// The timeout is applied as PRAGMA busy_timeout on every connection: SQLite
+// itself sleeps and retries a locked database until the timeout expires, so
+// callers need no retry loop of their own for ordinary contention. Once it
+// expires the operation fails with an error wrapping ErrBusy; callers that
+// retry at the application level should back off before doing so, since each
+// attempt may already have waited the full timeout.
 func NewWithTimeout(ctx context.Context, path string, busyTimeout time.Duration) (*SQLiteStorage, error) {
 	// Convert timeout to milliseconds for SQLite pragma
 	timeoutMs := int64(busyTimeout / time.Millisecond)
@@ -140,7 +150,10 @@ func NewWithTimeout(ctx context.Context, path string, busyTimeout time.Duration)
 		// Already a URI - append our pragmas if not present
 		connStr = path
 		if !strings.Contains(path, "_pragma=foreign_keys") {
-			connStr += fmt.Sprintf("&_pragma=foreign_keys(ON)&_pragma=busy_timeout(%d)&_time_format=sqlite", timeoutMs)
+			connStr += "&_pragma=foreign_keys(ON)&_time_format=sqlite"
+		}
+		if !strings.Contains(path, "_pragma=busy_timeout") {
+			connStr += fmt.Sprintf("&_pragma=busy_timeout(%d)", timeoutMs)
 		}
 	} else {
 		// Ensure directory exists for file-based databases
@@ -152,7 +165,7 @@ func NewWithTimeout(ctx context.Context, path string, busyTimeout time.Duration)
 		connStr = fmt.Sprintf("file:%s?_pragma=foreign_keys(ON)&_pragma=busy_timeout(%d)&_time_format=sqlite", path, timeoutMs)
 	}
 
-	db, err := sql.Open("sqlite3", connStr)
+	db, err := openDB(connStr, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
@@ -219,9 +232,10 @@ func NewWithTimeout(ctx context.Context, path string, busyTimeout time.Duration)
 		}
 	}
 
-	// Convert to absolute path for consistency (but keep :memory: as-is)
+	// Convert to absolute path for consistency (but keep in-memory paths,
+	// :memory: and file: URIs with mode=memory, as-is)
 	absPath := path
-	if path != ":memory:" {
+	if !isInMemory {
 		var err error
 		absPath, err = filepath.Abs(path)
 		if err != nil {
@@ -237,8 +251,8 @@ func NewWithTimeout(ctx context.Context, path string, busyTimeout time.Duration)
 	}
 
 	// Hydrate from multi-repo config if configured
-	// Skip for in-memory databases (used in tests)
-	if path != ":memory:" {
+	// Skip for in-memory databases (used in tests and ephemeral stores)
+	if !isInMemory {
 		_, err := storage.HydrateFromMultiRepo(ctx)
 		if err != nil {
 			return nil, fmt.Errorf("failed to hydrate from multi-repo: %w", err)
@@ -248,6 +262,36 @@ func NewWithTimeout(ctx context.Context, path string, busyTimeout time.Duration)
 	return storage, nil
 }
 
+// ephemeralDBPath is a private in-memory database URI. Each connection using it
+// gets its own database, so with the single-connection pool used for in-memory
+// databases every ephemeral store is isolated from the others.
+const ephemeralDBPath = "file::memory:?mode=memory&cache=private"
+
+// NewEphemeralStorage creates an initialized in-memory database for processing
+// an export without persisting it: import, query/transform, and export, all in
+// process. The issue_prefix config is set to prefix so the store is ready for
+// CreateIssueImport without a prior 'bd init'.
+//
+// The database is discarded when the store is closed. Multi-repo hydration is
+// skipped, so only explicitly imported issues are visible.
+func NewEphemeralStorage(ctx context.Context, prefix string) (*SQLiteStorage, error) {
+	if strings.TrimSpace(prefix) == "" {
+		return nil, fmt.Errorf("ephemeral storage requires a non-empty issue prefix")
+	}
+
+	store, err := New(ctx, ephemeralDBPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ephemeral storage: %w", err)
+	}
+
+	if err := store.SetConfig(ctx, "issue_prefix", prefix); err != nil {
+		_ = store.Close()
+		return nil, fmt.Errorf("failed to set issue_prefix for ephemeral storage: %w", err)
+	}
+
+	return store, nil
+}
+
 // NewReadOnly opens an existing database in read-only mode.
 // This prevents any modification to the database file, including:
 // - WAL journal mode changes
@@ -281,7 +325,7 @@ func NewReadOnlyWithTimeout(ctx context.Context, path string, busyTimeout time.D
 	// This prevents any writes to the database file
 	connStr := fmt.Sprintf("file:%s?mode=ro&_pragma=foreign_keys(ON)&_pragma=busy_timeout(%d)&_time_format=sqlite", path, timeoutMs)
 
-	db, err := sql.Open("sqlite3", connStr)
+	db, err := openDB(connStr, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database read-only: %w", err)
 	}
@@ -337,9 +381,7 @@ func (s *SQLiteStorage) Close() error {
 // In-memory databases use a single connection (SQLite isolation requirement).
 // File-based databases use a pool sized for concurrent access.
 func (s *SQLiteStorage) configureConnectionPool(db *sql.DB) {
-	isInMemory := s.dbPath == ":memory:" ||
-		(strings.HasPrefix(s.connStr, "file:") && strings.Contains(s.connStr, "mode=memory"))
-	if isInMemory {
+	if s.isInMemory() {
 		db.SetMaxOpenConns(1)
 		db.SetMaxIdleConns(1)
 	} else {
@@ -351,6 +393,13 @@ func (s *SQLiteStorage) configureConnectionPool(db *sql.DB) {
 	}
 }
 
+// isInMemory reports whether the store is an in-memory database, served by a
+// single connection.
+func (s *SQLiteStorage) isInMemory() bool {
+	return s.dbPath == ":memory:" ||
+		(strings.HasPrefix(s.connStr, "file:") && strings.Contains(s.connStr, "mode=memory"))
+}
+
 // Path returns the absolute path to the database file
 func (s *SQLiteStorage) Path() string {
 	return s.dbPath
@@ -464,6 +513,21 @@ func (s *SQLiteStorage) CheckpointWAL(ctx context.Context) error {
 	return wrapDBError("checkpoint WAL", err)
 }
 
+// TruncateWAL checkpoints the WAL like CheckpointWAL and then truncates the
+// -wal file to zero bytes, reclaiming the space a large write left behind.
+// It fails if another connection keeps the checkpoint from completing.
+func (s *SQLiteStorage) TruncateWAL(ctx context.Context) error {
+	var busy, logFrames, checkpointed int
+	err := s.db.QueryRowContext(ctx, "PRAGMA wal_checkpoint(TRUNCATE)").Scan(&busy, &logFrames, &checkpointed)
+	if err != nil {
+		return wrapDBError("truncate WAL", err)
+	}
+	if busy != 0 {
+		return fmt.Errorf("truncate WAL: checkpoint blocked by another connection (%d of %d frames checkpointed)", checkpointed, logFrames)
+	}
+	return nil
+}
+
 // EnableFreshnessChecking enables detection of external database file modifications.
 // This is used by the daemon to detect when the database file has been replaced
 // (e.g., by git merge) and automatically reconnect.
@@ -472,7 +536,7 @@ func (s *SQLiteStorage) CheckpointWAL(ctx context.Context) error {
 // and trigger a reconnection if necessary. This adds minimal overhead (~1ms per check)
 // but ensures the daemon always sees the latest data.
 func (s *SQLiteStorage) EnableFreshnessChecking() {
-	if s.dbPath == "" || s.dbPath == ":memory:" {
+	if s.dbPath == "" || s.isInMemory() {
 		return
 	}
 
@@ -510,7 +574,7 @@ func (s *SQLiteStorage) reconnect() error {
 	}
 
 	// Open NEW connection FIRST (don't close old one yet)
-	db, err := sql.Open("sqlite3", s.connStr)
+	db, err := openDB(s.connStr, &s.foreignKeys)
 	if err != nil {
 		return fmt.Errorf("failed to open new connection: %w", err)
 	}
@@ -519,9 +583,7 @@ func (s *SQLiteStorage) reconnect() error {
 	s.configureConnectionPool(db)
 
 	// Re-enable WAL mode (or DELETE for WSL2)
-	isInMemory := s.dbPath == ":memory:" ||
-		(strings.HasPrefix(s.connStr, "file:") && strings.Contains(s.connStr, "mode=memory"))
-	if !isInMemory {
+	if !s.isInMemory() {
 		journalMode := "WAL"
 		if isWSL2WindowsPath(s.dbPath) {
 			journalMode = "DELETE" // Fallback for WSL2 Windows filesystem (GH#920)
@@ -541,6 +603,7 @@ func (s *SQLiteStorage) reconnect() error {
 	// SUCCESS: Swap connections (old one can now fail safely)
 	oldDB := s.db
 	s.db = db
+	s.customCache.invalidate()
 
 	// Close old connection (errors are non-fatal since file may be deleted)
 	if err := oldDB.Close(); err != nil {
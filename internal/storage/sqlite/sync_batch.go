@@ -0,0 +1,150 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// SyncBatch is a sync push payload: dirty issues with their current state and
+// the events recorded for them since a given event ID.
+type SyncBatch struct {
+	Entries []*SyncBatchEntry `json:"entries"`
+	// LastEventID is the highest event ID in the database when the batch was
+	// built. Once HasMore is false, pass it as sinceEventID for the next sync.
+	LastEventID int64 `json:"last_event_id"`
+	// HasMore reports that more dirty issues remain beyond the limit.
+	HasMore bool `json:"has_more"`
+}
+
+// SyncBatchEntry is one dirty issue in a SyncBatch. Issue is nil when the
+// issue was deleted after being marked dirty.
+type SyncBatchEntry struct {
+	IssueID string         `json:"issue_id"`
+	Issue   *types.Issue   `json:"issue,omitempty"`
+	Events  []*types.Event `json:"events,omitempty"`
+}
+
+// BuildSyncBatch returns up to limit dirty issues (oldest first, limit <= 0
+// means all) with their labels and watchers, plus each issue's events with an
+// ID greater than sinceEventID in ID order. Everything is read from a single
+// snapshot, so the batch is consistent with concurrent writers.
+func (s *SQLiteStorage) BuildSyncBatch(ctx context.Context, sinceEventID int64, limit int) (*SyncBatch, error) {
+	batch := &SyncBatch{}
+	err := s.withReadTx(ctx, func(conn *sql.Conn) error {
+		ids, hasMore, err := readDirtyIssueIDs(ctx, conn, limit)
+		if err != nil {
+			return err
+		}
+		batch.HasMore = hasMore
+
+		if err := conn.QueryRowContext(ctx, `SELECT COALESCE(MAX(id), 0) FROM events`).Scan(&batch.LastEventID); err != nil {
+			return fmt.Errorf("failed to get last event ID: %w", err)
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		events, err := readEventsSince(ctx, conn, ids, sinceEventID)
+		if err != nil {
+			return err
+		}
+
+		tx := &sqliteTxStorage{conn: conn, parent: s}
+		for _, id := range ids {
+			issue, err := tx.GetIssue(ctx, id)
+			if err != nil {
+				return err
+			}
+			batch.Entries = append(batch.Entries, &SyncBatchEntry{IssueID: id, Issue: issue, Events: events[id]})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
+
+// readDirtyIssueIDs returns up to limit dirty issue IDs in the order they were
+// marked, and whether more remain.
+func readDirtyIssueIDs(ctx context.Context, conn *sql.Conn, limit int) ([]string, bool, error) {
+	query := `SELECT issue_id FROM dirty_issues ORDER BY marked_at ASC, issue_id ASC`
+	var args []interface{}
+	if limit > 0 {
+		// Fetch one extra row to detect whether more remain
+		query += limitClause
+		args = append(args, limit+1)
+	}
+
+	rows, err := conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get dirty issues: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, false, fmt.Errorf("failed to scan issue ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, false, wrapDBError("iterate dirty issues", err)
+	}
+	if limit > 0 && len(ids) > limit {
+		return ids[:limit], true, nil
+	}
+	return ids, false, nil
+}
+
+// readEventsSince returns the events of issueIDs with an ID greater than
+// sinceEventID, grouped by issue and in ID order.
+func readEventsSince(ctx context.Context, conn *sql.Conn, issueIDs []string, sinceEventID int64) (map[string][]*types.Event, error) {
+	args := make([]interface{}, 0, len(issueIDs)+1)
+	args = append(args, sinceEventID)
+	for _, id := range issueIDs {
+		args = append(args, id)
+	}
+
+	// #nosec G201 -- placeholders are generated internally
+	query := fmt.Sprintf(`
+		SELECT id, issue_id, event_type, actor, old_value, new_value, comment, created_at
+		FROM events
+		WHERE id > ? AND issue_id IN (%s)
+		ORDER BY id ASC
+	`, buildPlaceholders(len(issueIDs)))
+
+	rows, err := conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	result := make(map[string][]*types.Event)
+	for rows.Next() {
+		var event types.Event
+		var oldValue, newValue, comment sql.NullString
+		if err := rows.Scan(
+			&event.ID, &event.IssueID, &event.EventType, &event.Actor,
+			&oldValue, &newValue, &comment, &event.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+		if oldValue.Valid {
+			event.OldValue = &oldValue.String
+		}
+		if newValue.Valid {
+			event.NewValue = &newValue.String
+		}
+		if comment.Valid {
+			event.Comment = &comment.String
+		}
+		result[event.IssueID] = append(result[event.IssueID], &event)
+	}
+	return result, rows.Err()
+}
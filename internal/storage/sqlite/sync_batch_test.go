@@ -0,0 +1,79 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestBuildSyncBatch(t *testing.T) {
+	env := newTestEnv(t)
+	a := env.CreateIssueWithID("bd-a", "Issue A")
+	b := env.CreateIssueWithID("bd-b", "Issue B")
+	c := env.CreateIssueWithID("bd-c", "Issue C")
+
+	// Simulate a completed sync: nothing dirty, cursor at the latest event
+	if err := env.Store.ClearDirtyIssuesByID(env.Ctx, []string{a.ID, b.ID, c.ID}); err != nil {
+		t.Fatalf("ClearDirtyIssuesByID failed: %v", err)
+	}
+	initial, err := env.Store.BuildSyncBatch(env.Ctx, 0, 0)
+	if err != nil {
+		t.Fatalf("BuildSyncBatch failed: %v", err)
+	}
+	if len(initial.Entries) != 0 || initial.LastEventID == 0 {
+		t.Fatalf("expected empty batch with a cursor, got %d entries and cursor %d", len(initial.Entries), initial.LastEventID)
+	}
+	since := initial.LastEventID
+
+	for _, issue := range []*types.Issue{a, c} {
+		if err := env.Store.UpdateIssue(env.Ctx, issue.ID, map[string]interface{}{"priority": 0}, "test-user"); err != nil {
+			t.Fatalf("UpdateIssue(%s) failed: %v", issue.ID, err)
+		}
+	}
+	if err := env.Store.AddLabel(env.Ctx, a.ID, "urgent", "test-user"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+
+	batch, err := env.Store.BuildSyncBatch(env.Ctx, since, 0)
+	if err != nil {
+		t.Fatalf("BuildSyncBatch failed: %v", err)
+	}
+	if batch.HasMore {
+		t.Error("expected no more dirty issues")
+	}
+	if batch.LastEventID <= since {
+		t.Errorf("expected cursor to advance past %d, got %d", since, batch.LastEventID)
+	}
+
+	byID := make(map[string]*SyncBatchEntry)
+	for _, entry := range batch.Entries {
+		byID[entry.IssueID] = entry
+	}
+	if len(byID) != 2 || byID[a.ID] == nil || byID[c.ID] == nil {
+		t.Fatalf("expected entries for %s and %s, got %v", a.ID, c.ID, byID)
+	}
+
+	entryA := byID[a.ID]
+	if entryA.Issue == nil || entryA.Issue.Priority != 0 || len(entryA.Issue.Labels) != 1 {
+		t.Errorf("expected current state of %s with its label, got %+v", a.ID, entryA.Issue)
+	}
+	if len(entryA.Events) != 2 {
+		t.Fatalf("expected 2 events for %s since cursor, got %d", a.ID, len(entryA.Events))
+	}
+	if entryA.Events[0].EventType != types.EventUpdated || entryA.Events[1].EventType != types.EventLabelAdded {
+		t.Errorf("expected updated then label_added, got %s, %s", entryA.Events[0].EventType, entryA.Events[1].EventType)
+	}
+	for _, e := range append(entryA.Events, byID[c.ID].Events...) {
+		if e.ID <= since {
+			t.Errorf("event %d predates cursor %d", e.ID, since)
+		}
+	}
+
+	limited, err := env.Store.BuildSyncBatch(env.Ctx, since, 1)
+	if err != nil {
+		t.Fatalf("BuildSyncBatch with limit failed: %v", err)
+	}
+	if len(limited.Entries) != 1 || !limited.HasMore {
+		t.Errorf("expected 1 entry with more remaining, got %d entries (has_more=%v)", len(limited.Entries), limited.HasMore)
+	}
+}
@@ -0,0 +1,116 @@
+package sqlite
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// ExportIndex writes one types.IndexEntry line per issue to w, in the same
+// order and over the same issues (tombstones included) as an unfiltered
+// StreamExport, for sync negotiation: the peer runs DiffAgainstIndex on it
+// and only the issues that differ need a full export. The index is read in
+// one query before anything is written, so a slow w holds no cursor open.
+func (s *SQLiteStorage) ExportIndex(ctx context.Context, w io.Writer) error {
+	entries, err := s.indexEntries(ctx)
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	for _, entry := range entries {
+		if err := enc.Encode(entry); err != nil {
+			return fmt.Errorf("failed to write index entry %s: %w", entry.ID, err)
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("failed to write index: %w", err)
+	}
+	return nil
+}
+
+// DiffAgainstIndex reads a remote index written by ExportIndex from r and
+// compares it with the local issues by content hash. UpdatedAt is carried
+// for the caller (e.g. to pick a direction for Changed issues) but does not
+// affect the comparison. A remote index listing an ID twice is rejected.
+func (s *SQLiteStorage) DiffAgainstIndex(ctx context.Context, r io.Reader) (*types.IndexDiff, error) {
+	remote := make(map[string]string)
+	scanner := utils.NewJSONLScanner(r, 0)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var entry types.IndexEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return nil, fmt.Errorf("index line %d: %w", scanner.Line(), err)
+		}
+		if entry.ID == "" {
+			return nil, fmt.Errorf("index line %d: missing id", scanner.Line())
+		}
+		if _, dup := remote[entry.ID]; dup {
+			return nil, fmt.Errorf("index line %d: duplicate id %s", scanner.Line(), entry.ID)
+		}
+		remote[entry.ID] = entry.ContentHash
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read index: %w", err)
+	}
+
+	local, err := s.indexEntries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	diff := &types.IndexDiff{}
+	for _, entry := range local {
+		hash, ok := remote[entry.ID]
+		switch {
+		case !ok:
+			diff.New = append(diff.New, entry.ID)
+		case hash != entry.ContentHash:
+			diff.Changed = append(diff.Changed, entry.ID)
+		default:
+			diff.Unchanged++
+		}
+		delete(remote, entry.ID)
+	}
+	for id := range remote {
+		diff.Missing = append(diff.Missing, id)
+	}
+	sort.Strings(diff.Missing)
+	return diff, nil
+}
+
+// indexEntries reads the sync index of every issue, ordered like an export.
+func (s *SQLiteStorage) indexEntries(ctx context.Context) ([]*types.IndexEntry, error) {
+	s.checkFreshness()
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, COALESCE(content_hash, ''), updated_at
+		FROM issues
+		ORDER BY id`+idCollateClause(getIDCollation(ctx, s.db)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var entries []*types.IndexEntry
+	for rows.Next() {
+		var entry types.IndexEntry
+		var updatedAt string
+		if err := rows.Scan(&entry.ID, &entry.ContentHash, &updatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan index entry: %w", err)
+		}
+		entry.UpdatedAt = parseTimeString(updatedAt)
+		entries = append(entries, &entry)
+	}
+	return entries, wrapDBError("iterate index", rows.Err())
+}
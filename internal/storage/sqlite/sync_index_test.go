@@ -0,0 +1,82 @@
+package sqlite
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestExportIndexAndDiff(t *testing.T) {
+	env := newTestEnv(t)
+
+	a := env.CreateIssueWithID("bd-a", "Same on both sides")
+	b := env.CreateIssueWithID("bd-b", "Edited remotely")
+	env.CreateIssueWithID("bd-c", "Only local")
+
+	var buf bytes.Buffer
+	if err := env.Store.ExportIndex(env.Ctx, &buf); err != nil {
+		t.Fatalf("ExportIndex failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("index has %d lines, want 3:\n%s", len(lines), buf.String())
+	}
+	var first types.IndexEntry
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatalf("failed to decode index line: %v", err)
+	}
+	stored, err := env.Store.GetIssue(env.Ctx, a.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if first.ID != a.ID || first.ContentHash == "" || first.ContentHash != stored.ContentHash || first.UpdatedAt.IsZero() {
+		t.Errorf("first entry = %+v, want %s with its stored hash %s", first, a.ID, stored.ContentHash)
+	}
+
+	self, err := env.Store.DiffAgainstIndex(env.Ctx, bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("DiffAgainstIndex failed: %v", err)
+	}
+	if self.Unchanged != 3 || len(self.New)+len(self.Changed)+len(self.Missing) != 0 {
+		t.Errorf("diff against own index = %+v, want everything unchanged", self)
+	}
+
+	// The remote has bd-a as is, another version of bd-b, no bd-c and an
+	// issue of its own
+	var remote bytes.Buffer
+	enc := json.NewEncoder(&remote)
+	for _, entry := range []types.IndexEntry{
+		{ID: a.ID, ContentHash: stored.ContentHash, UpdatedAt: time.Now()},
+		{ID: b.ID, ContentHash: "remote-edit", UpdatedAt: time.Now()},
+		{ID: "bd-z", ContentHash: "remote-only", UpdatedAt: time.Now()},
+	} {
+		if err := enc.Encode(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+	diff, err := env.Store.DiffAgainstIndex(env.Ctx, &remote)
+	if err != nil {
+		t.Fatalf("DiffAgainstIndex failed: %v", err)
+	}
+	if got := strings.Join(diff.New, ","); got != "bd-c" {
+		t.Errorf("new = %s, want bd-c", got)
+	}
+	if got := strings.Join(diff.Changed, ","); got != "bd-b" {
+		t.Errorf("changed = %s, want bd-b", got)
+	}
+	if got := strings.Join(diff.Missing, ","); got != "bd-z" {
+		t.Errorf("missing = %s, want bd-z", got)
+	}
+	if diff.Unchanged != 1 {
+		t.Errorf("unchanged = %d, want 1", diff.Unchanged)
+	}
+
+	dup := `{"id":"bd-a","hash":"x"}` + "\n" + `{"id":"bd-a","hash":"y"}` + "\n"
+	if _, err := env.Store.DiffAgainstIndex(env.Ctx, strings.NewReader(dup)); err == nil || !strings.Contains(err.Error(), "duplicate id bd-a") {
+		t.Errorf("err = %v, want a duplicate id error", err)
+	}
+}
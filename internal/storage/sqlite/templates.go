@@ -0,0 +1,218 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+const templateColumns = `id, name, title, description, design, acceptance_criteria, notes,
+	issue_type, priority, labels, custom_fields, created_at, updated_at`
+
+// templateOverrideFields lists the fields CreateFromTemplate overrides may
+// set: the issue fields applyUpdatesToIssue writes, plus labels.
+var templateOverrideFields = map[string]bool{
+	"title":               true,
+	"description":         true,
+	"design":              true,
+	"acceptance_criteria": true,
+	"notes":               true,
+	"status":              true,
+	"priority":            true,
+	"issue_type":          true,
+	"assignee":            true,
+	"color":               true,
+	"external_ref":        true,
+	"source_system":       true,
+	"custom_fields":       true,
+	"labels":              true,
+}
+
+// SaveTemplate stores tmpl, replacing any template with the same ID. Zero
+// timestamps are set to now.
+func (s *SQLiteStorage) SaveTemplate(ctx context.Context, tmpl *types.Template) error {
+	return s.withTx(ctx, func(conn *sql.Conn) error {
+		return saveTemplate(ctx, conn, tmpl)
+	})
+}
+
+// SaveTemplate stores tmpl within the transaction.
+func (t *sqliteTxStorage) SaveTemplate(ctx context.Context, tmpl *types.Template) error {
+	return saveTemplate(ctx, t.conn, tmpl)
+}
+
+// GetTemplate returns the template with the given ID, or nil if there is none.
+func (s *SQLiteStorage) GetTemplate(ctx context.Context, id string) (*types.Template, error) {
+	s.checkFreshness()
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+	return getTemplate(ctx, s.db, id)
+}
+
+// ListTemplates returns every template, by ID.
+func (s *SQLiteStorage) ListTemplates(ctx context.Context) ([]*types.Template, error) {
+	s.checkFreshness()
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	rows, err := s.db.QueryContext(ctx, `SELECT `+templateColumns+` FROM issue_templates ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list templates: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	templates := []*types.Template{}
+	for rows.Next() {
+		tmpl, err := scanTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, tmpl)
+	}
+	return templates, wrapDBError("iterate templates", rows.Err())
+}
+
+// ExportTemplates writes every template to w as one types.TemplateRecord line
+// each, by ID. importer.ParseTemplates reads them back.
+func (s *SQLiteStorage) ExportTemplates(ctx context.Context, w io.Writer) error {
+	templates, err := s.ListTemplates(ctx)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	for _, tmpl := range templates {
+		if err := enc.Encode(types.TemplateRecord{Template: tmpl}); err != nil {
+			return fmt.Errorf("failed to write template %s: %w", tmpl.ID, err)
+		}
+	}
+	return nil
+}
+
+// CreateFromTemplate creates an issue from the template templateID and
+// returns it. overrides replace template fields and are keyed like UpdateIssue
+// updates (title, description, priority, assignee, ...); "labels" takes a
+// []string replacing the template's labels. The issue is validated as
+// CreateIssue validates any other, after overrides are applied.
+func (s *SQLiteStorage) CreateFromTemplate(ctx context.Context, templateID string, overrides map[string]interface{}, actor string) (*types.Issue, error) {
+	for key := range overrides {
+		if !templateOverrideFields[key] {
+			return nil, fmt.Errorf("invalid template override field: %s", key)
+		}
+	}
+
+	var issue *types.Issue
+	err := s.withTx(ctx, func(conn *sql.Conn) error {
+		tmpl, err := getTemplate(ctx, conn, templateID)
+		if err != nil {
+			return err
+		}
+		if tmpl == nil {
+			return fmt.Errorf("template %s: %w", templateID, ErrNotFound)
+		}
+
+		issue = tmpl.NewIssue()
+		if value, ok := overrides["labels"]; ok {
+			labels, ok := value.([]string)
+			if !ok {
+				return fmt.Errorf("labels override must be a []string, got %T", value)
+			}
+			issue.Labels = append([]string(nil), labels...)
+		}
+		applyUpdatesToIssue(issue, overrides)
+
+		tx := &sqliteTxStorage{conn: conn, parent: s}
+		if err := tx.CreateIssue(ctx, issue, actor); err != nil {
+			return err
+		}
+		for _, label := range issue.Labels {
+			if err := tx.AddLabel(ctx, issue.ID, label, actor); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return issue, nil
+}
+
+func saveTemplate(ctx context.Context, db dbExecutor, tmpl *types.Template) error {
+	if err := tmpl.Validate(); err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	if tmpl.CreatedAt.IsZero() {
+		tmpl.CreatedAt = now
+	}
+	if tmpl.UpdatedAt.IsZero() {
+		tmpl.UpdatedAt = now
+	}
+	labels := ""
+	if len(tmpl.Labels) > 0 {
+		data, err := json.Marshal(tmpl.Labels)
+		if err != nil {
+			return fmt.Errorf("failed to encode labels of template %s: %w", tmpl.ID, err)
+		}
+		labels = string(data)
+	}
+	customFields, err := formatCustomFields(tmpl.CustomFields)
+	if err != nil {
+		return fmt.Errorf("failed to save template %s: %w", tmpl.ID, err)
+	}
+
+	_, err = db.ExecContext(ctx, `
+		INSERT INTO issue_templates (`+templateColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			name = excluded.name, title = excluded.title, description = excluded.description,
+			design = excluded.design, acceptance_criteria = excluded.acceptance_criteria,
+			notes = excluded.notes, issue_type = excluded.issue_type, priority = excluded.priority,
+			labels = excluded.labels, custom_fields = excluded.custom_fields,
+			created_at = excluded.created_at, updated_at = excluded.updated_at
+	`, tmpl.ID, tmpl.Name, tmpl.Title, tmpl.Description, tmpl.Design, tmpl.AcceptanceCriteria, tmpl.Notes,
+		string(tmpl.IssueType), tmpl.Priority, labels, customFields, tmpl.CreatedAt, tmpl.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save template %s: %w", tmpl.ID, err)
+	}
+	return nil
+}
+
+func getTemplate(ctx context.Context, db dbExecutor, id string) (*types.Template, error) {
+	rows, err := db.QueryContext(ctx, `SELECT `+templateColumns+` FROM issue_templates WHERE id = ?`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get template: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	if !rows.Next() {
+		return nil, wrapDBError("get template", rows.Err())
+	}
+	return scanTemplate(rows)
+}
+
+func scanTemplate(rows *sql.Rows) (*types.Template, error) {
+	var tmpl types.Template
+	var issueType, labels, customFields, createdAt, updatedAt string
+	if err := rows.Scan(&tmpl.ID, &tmpl.Name, &tmpl.Title, &tmpl.Description, &tmpl.Design, &tmpl.AcceptanceCriteria, &tmpl.Notes,
+		&issueType, &tmpl.Priority, &labels, &customFields, &createdAt, &updatedAt); err != nil {
+		return nil, fmt.Errorf("failed to scan template: %w", err)
+	}
+	tmpl.IssueType = types.IssueType(issueType)
+	if labels != "" {
+		if err := json.Unmarshal([]byte(labels), &tmpl.Labels); err != nil {
+			return nil, fmt.Errorf("failed to parse labels of template %s: %w", tmpl.ID, err)
+		}
+	}
+	var err error
+	if tmpl.CustomFields, err = parseCustomFields(customFields); err != nil {
+		return nil, err
+	}
+	tmpl.CreatedAt = parseTimeString(createdAt)
+	tmpl.UpdatedAt = parseTimeString(updatedAt)
+	return &tmpl, nil
+}
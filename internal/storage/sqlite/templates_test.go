@@ -0,0 +1,122 @@
+package sqlite
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestTemplates(t *testing.T) {
+	env := newTestEnv(t)
+	tmpl := &types.Template{
+		ID:           "bug-report",
+		Name:         "Bug report",
+		Title:        "Bug: ",
+		Description:  "Steps to reproduce:\n\nExpected:\n\nActual:",
+		IssueType:    types.TypeBug,
+		Priority:     1,
+		Labels:       []string{"triage", "bug"},
+		CustomFields: map[string]json.RawMessage{"severity": json.RawMessage(`"unknown"`)},
+	}
+	if err := env.Store.SaveTemplate(env.Ctx, tmpl); err != nil {
+		t.Fatalf("SaveTemplate failed: %v", err)
+	}
+
+	t.Run("stored templates read back", func(t *testing.T) {
+		got, err := env.Store.GetTemplate(env.Ctx, "bug-report")
+		if err != nil {
+			t.Fatalf("GetTemplate failed: %v", err)
+		}
+		if got.Title != tmpl.Title || got.IssueType != types.TypeBug || got.Priority != 1 ||
+			!reflect.DeepEqual(got.Labels, tmpl.Labels) || string(got.CustomFields["severity"]) != `"unknown"` {
+			t.Errorf("GetTemplate = %+v, want %+v", got, tmpl)
+		}
+		if missing, err := env.Store.GetTemplate(env.Ctx, "nope"); err != nil || missing != nil {
+			t.Errorf("GetTemplate(nope) = %v, %v, want nil", missing, err)
+		}
+	})
+
+	t.Run("instantiate with overrides", func(t *testing.T) {
+		issue, err := env.Store.CreateFromTemplate(env.Ctx, "bug-report", map[string]interface{}{
+			"title":    "Bug: login fails",
+			"priority": 0,
+			"assignee": "alice",
+			"labels":   []string{"auth"},
+		}, "test-user")
+		if err != nil {
+			t.Fatalf("CreateFromTemplate failed: %v", err)
+		}
+		got, err := env.Store.GetIssue(env.Ctx, issue.ID)
+		if err != nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		if got.Title != "Bug: login fails" || got.Priority != 0 || got.Assignee != "alice" ||
+			got.IssueType != types.TypeBug || got.Description != tmpl.Description || got.Status != types.StatusOpen {
+			t.Errorf("instantiated issue = %+v", got)
+		}
+		if !reflect.DeepEqual(got.Labels, []string{"auth"}) {
+			t.Errorf("labels = %v, want the override", got.Labels)
+		}
+		if string(got.CustomFields["severity"]) != `"unknown"` {
+			t.Errorf("custom fields = %v, want the template's", got.CustomFields)
+		}
+
+		// The template itself is left alone
+		after, err := env.Store.GetTemplate(env.Ctx, "bug-report")
+		if err != nil {
+			t.Fatalf("GetTemplate failed: %v", err)
+		}
+		if after.Title != "Bug: " || !reflect.DeepEqual(after.Labels, tmpl.Labels) {
+			t.Errorf("template changed: %+v", after)
+		}
+	})
+
+	t.Run("validation applies to the instantiated issue", func(t *testing.T) {
+		skeleton := &types.Template{ID: "blank", Priority: 2}
+		if err := env.Store.SaveTemplate(env.Ctx, skeleton); err != nil {
+			t.Fatalf("SaveTemplate of an untitled template failed: %v", err)
+		}
+		if _, err := env.Store.CreateFromTemplate(env.Ctx, "blank", nil, "test-user"); err == nil {
+			t.Error("expected an untitled issue to fail validation")
+		}
+		issue, err := env.Store.CreateFromTemplate(env.Ctx, "blank", map[string]interface{}{"title": "Filled in"}, "test-user")
+		if err != nil {
+			t.Fatalf("CreateFromTemplate with a title failed: %v", err)
+		}
+		if issue.IssueType != types.TypeTask {
+			t.Errorf("issue type = %q, want the task default", issue.IssueType)
+		}
+	})
+
+	t.Run("bad overrides and missing templates fail", func(t *testing.T) {
+		if _, err := env.Store.CreateFromTemplate(env.Ctx, "bug-report", map[string]interface{}{"rowid": 7}, "test-user"); err == nil {
+			t.Error("expected an unsupported override field to fail")
+		}
+		if _, err := env.Store.CreateFromTemplate(env.Ctx, "nope", nil, "test-user"); !errors.Is(err, ErrNotFound) {
+			t.Errorf("expected ErrNotFound for a missing template, got %v", err)
+		}
+	})
+
+	t.Run("export writes template records", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := env.Store.ExportTemplates(env.Ctx, &buf); err != nil {
+			t.Fatalf("ExportTemplates failed: %v", err)
+		}
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		if len(lines) != 2 {
+			t.Fatalf("expected 2 template lines, got %q", buf.String())
+		}
+		var record types.TemplateRecord
+		if err := json.Unmarshal([]byte(lines[1]), &record); err != nil {
+			t.Fatalf("invalid template line: %v", err)
+		}
+		if record.Template == nil || record.Template.ID != "bug-report" || record.Template.Name != "Bug report" {
+			t.Errorf("second record = %+v, want bug-report", record.Template)
+		}
+	})
+}
@@ -0,0 +1,122 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ListIssuesCreatedBetween returns the issues created in [from, to), oldest
+// first. Tombstones are excluded.
+func (s *SQLiteStorage) ListIssuesCreatedBetween(ctx context.Context, from, to time.Time) ([]*types.Issue, error) {
+	return s.listIssuesInRange(ctx, "created_at", from, to)
+}
+
+// ListIssuesClosedBetween returns the issues closed in [from, to), earliest
+// close first. Issues without a ClosedAt (open or reopened) never match, and
+// tombstones are excluded even if they kept their close time.
+func (s *SQLiteStorage) ListIssuesClosedBetween(ctx context.Context, from, to time.Time) ([]*types.Issue, error) {
+	return s.listIssuesInRange(ctx, "closed_at", from, to)
+}
+
+// ListStaleIssues returns the open issues (neither closed nor tombstoned) not
+// updated within olderThan, stalest first. Unlike GetStaleIssues it compares
+// updated_at directly, so the scan uses idx_issues_updated_at.
+func (s *SQLiteStorage) ListStaleIssues(ctx context.Context, olderThan time.Duration) ([]*types.Issue, error) {
+	s.checkFreshness()
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+rangeIssueColumns+`
+		FROM issues
+		WHERE updated_at < ?
+		  AND status NOT IN ('closed', 'tombstone')
+		ORDER BY updated_at, id
+	`, time.Now().Add(-olderThan).UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stale issues: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return scanIssueList(ctx, s, rows)
+}
+
+// ListOverdueIssues returns the open issues (neither closed nor tombstoned)
+// whose DueAt is before now, most overdue first. An issue due exactly at now is
+// not yet overdue, and issues without a due date never are. The comparison
+// scans idx_issues_due_at.
+func (s *SQLiteStorage) ListOverdueIssues(ctx context.Context, now time.Time) ([]*types.Issue, error) {
+	s.checkFreshness()
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+rangeIssueColumns+`
+		FROM issues
+		WHERE due_at < ?
+		  AND status NOT IN ('closed', 'tombstone')
+		ORDER BY due_at, id
+	`, now.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list overdue issues: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return scanIssueList(ctx, s, rows)
+}
+
+// rangeIssueColumns is the column list scanIssues expects.
+const rangeIssueColumns = `id, content_hash, title, description, design, acceptance_criteria, notes,
+		       status, priority, issue_type, assignee, estimated_minutes,
+		       created_at, created_by, owner, updated_at, closed_at, external_ref, source_repo, close_reason,
+		       deleted_at, deleted_by, delete_reason, original_type,
+		       sender, ephemeral, pinned, is_template, crystallizes,
+		       await_type, await_id, timeout_ns, waiters,
+		       hook_bead, role_bead, agent_state, last_activity, role_type, rig, mol_type,
+		       due_at, defer_until, expires_at, color, display_order, source_system, custom_fields, rank, milestone_id`
+
+// listIssuesInRange scans the index on column for [from, to). The column is
+// compared without wrapping it in a function so SQLite can use the index; the
+// bounds are bound in UTC, matching timestamps stored in UTC.
+func (s *SQLiteStorage) listIssuesInRange(ctx context.Context, column string, from, to time.Time) ([]*types.Issue, error) {
+	if !to.After(from) {
+		return []*types.Issue{}, nil
+	}
+	s.checkFreshness()
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	// #nosec G201 - column is one of two fixed names
+	query := fmt.Sprintf(`
+		SELECT %[2]s
+		FROM issues
+		WHERE %[1]s >= ? AND %[1]s < ?
+		  AND status != 'tombstone'
+		ORDER BY %[1]s, id
+	`, column, rangeIssueColumns)
+
+	rows, err := s.db.QueryContext(ctx, query, from.UTC(), to.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issues by %s: %w", column, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return scanIssueList(ctx, s, rows)
+}
+
+// scanIssueList scans rows like scanIssues but returns an empty slice rather
+// than nil when nothing matched.
+func scanIssueList(ctx context.Context, s *SQLiteStorage, rows *sql.Rows) ([]*types.Issue, error) {
+	issues, err := s.scanIssues(ctx, rows)
+	if err != nil {
+		return nil, err
+	}
+	if issues == nil {
+		issues = []*types.Issue{}
+	}
+	return issues, nil
+}
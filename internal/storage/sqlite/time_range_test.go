@@ -0,0 +1,219 @@
+package sqlite
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestListIssuesBetween(t *testing.T) {
+	env := newTestEnv(t)
+	day := func(d int) time.Time { return time.Date(2025, 3, d, 12, 0, 0, 0, time.UTC) }
+
+	// One issue created per day from March 1 to 10; every even day's issue is
+	// closed two days after creation.
+	byDay := make(map[int]string)
+	for d := 1; d <= 10; d++ {
+		issue := &types.Issue{
+			Title:     "Day issue",
+			Status:    types.StatusOpen,
+			Priority:  2,
+			IssueType: types.TypeTask,
+			CreatedAt: day(d),
+			UpdatedAt: day(d),
+		}
+		if d%2 == 0 {
+			closed := day(d + 2)
+			issue.Status = types.StatusClosed
+			issue.ClosedAt = &closed
+			issue.UpdatedAt = closed
+		}
+		if err := env.Store.CreateIssue(env.Ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		byDay[d] = issue.ID
+	}
+
+	ids := func(issues []*types.Issue) []string {
+		out := make([]string, len(issues))
+		for i, issue := range issues {
+			out[i] = issue.ID
+		}
+		return out
+	}
+	want := func(days ...int) []string {
+		out := make([]string, len(days))
+		for i, d := range days {
+			out[i] = byDay[d]
+		}
+		return out
+	}
+
+	created, err := env.Store.ListIssuesCreatedBetween(env.Ctx, day(3), day(6))
+	if err != nil {
+		t.Fatalf("ListIssuesCreatedBetween failed: %v", err)
+	}
+	if got, exp := strings.Join(ids(created), ","), strings.Join(want(3, 4, 5), ","); got != exp {
+		t.Errorf("created between: got %s, want %s (from inclusive, to exclusive)", got, exp)
+	}
+
+	// Closed on days 4, 6, 8, 10, 12; open issues have no ClosedAt and never match
+	closed, err := env.Store.ListIssuesClosedBetween(env.Ctx, day(1), day(9))
+	if err != nil {
+		t.Fatalf("ListIssuesClosedBetween failed: %v", err)
+	}
+	if got, exp := strings.Join(ids(closed), ","), strings.Join(want(2, 4, 6), ","); got != exp {
+		t.Errorf("closed between: got %s, want %s", got, exp)
+	}
+	for _, issue := range closed {
+		if issue.ClosedAt == nil {
+			t.Errorf("%s returned without ClosedAt", issue.ID)
+		}
+	}
+
+	// Reopening clears ClosedAt, dropping the issue from the range
+	if err := env.Store.UpdateIssue(env.Ctx, byDay[4], map[string]interface{}{"status": string(types.StatusOpen)}, "test"); err != nil {
+		t.Fatalf("reopen failed: %v", err)
+	}
+	closed, err = env.Store.ListIssuesClosedBetween(env.Ctx, day(1), day(9))
+	if err != nil {
+		t.Fatalf("ListIssuesClosedBetween failed: %v", err)
+	}
+	if got, exp := strings.Join(ids(closed), ","), strings.Join(want(2, 6), ","); got != exp {
+		t.Errorf("closed between after reopen: got %s, want %s", got, exp)
+	}
+
+	// Bounds in another zone select the same instants
+	est := time.FixedZone("EST", -5*60*60)
+	created, err = env.Store.ListIssuesCreatedBetween(env.Ctx, day(9).In(est), day(20).In(est))
+	if err != nil {
+		t.Fatalf("ListIssuesCreatedBetween failed: %v", err)
+	}
+	if got, exp := strings.Join(ids(created), ","), strings.Join(want(9, 10), ","); got != exp {
+		t.Errorf("created between (EST bounds): got %s, want %s", got, exp)
+	}
+
+	empty, err := env.Store.ListIssuesCreatedBetween(env.Ctx, day(6), day(3))
+	if err != nil {
+		t.Fatalf("ListIssuesCreatedBetween failed: %v", err)
+	}
+	if len(empty) != 0 {
+		t.Errorf("inverted range should be empty, got %d issues", len(empty))
+	}
+}
+
+func TestListIssuesBetween_UsesIndexes(t *testing.T) {
+	env := newTestEnv(t)
+	for column, index := range map[string]string{"created_at": "idx_issues_created_at", "closed_at": "idx_issues_closed_at", "updated_at": "idx_issues_updated_at", "due_at": "idx_issues_due_at"} {
+		rows, err := env.Store.db.QueryContext(env.Ctx,
+			`EXPLAIN QUERY PLAN SELECT id FROM issues WHERE `+column+` >= ? AND `+column+` < ? AND status != 'tombstone' ORDER BY `+column+`, id`,
+			time.Now().Add(-time.Hour), time.Now())
+		if err != nil {
+			t.Fatalf("EXPLAIN QUERY PLAN failed: %v", err)
+		}
+		var plan []string
+		for rows.Next() {
+			var id, parent, notused int
+			var detail string
+			if err := rows.Scan(&id, &parent, &notused, &detail); err != nil {
+				t.Fatalf("Failed to scan EXPLAIN output: %v", err)
+			}
+			plan = append(plan, detail)
+		}
+		_ = rows.Close()
+		if !strings.Contains(strings.Join(plan, "\n"), index) {
+			t.Errorf("%s range query should use %s, plan: %v", column, index, plan)
+		}
+	}
+}
+
+func TestListStaleIssues(t *testing.T) {
+	env := newTestEnv(t)
+	now := time.Now()
+	seed := func(title string, age time.Duration, status types.Status) string {
+		t.Helper()
+		updated := now.Add(-age)
+		issue := &types.Issue{Title: title, Status: status, Priority: 2, IssueType: types.TypeTask, CreatedAt: updated, UpdatedAt: updated}
+		if status == types.StatusClosed {
+			issue.ClosedAt = &updated
+		}
+		if err := env.Store.CreateIssue(env.Ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue.ID
+	}
+
+	month := seed("Untouched for a month", 30*24*time.Hour, types.StatusOpen)
+	fortnight := seed("In progress, stalled", 14*24*time.Hour, types.StatusInProgress)
+	seed("Updated yesterday", 24*time.Hour, types.StatusOpen)
+	seed("Closed long ago", 60*24*time.Hour, types.StatusClosed)
+
+	stale, err := env.Store.ListStaleIssues(env.Ctx, 7*24*time.Hour)
+	if err != nil {
+		t.Fatalf("ListStaleIssues failed: %v", err)
+	}
+	var got []string
+	for _, issue := range stale {
+		got = append(got, issue.ID)
+	}
+	if strings.Join(got, ",") != month+","+fortnight {
+		t.Errorf("expected stalest-first %s,%s; got %v", month, fortnight, got)
+	}
+
+	none, err := env.Store.ListStaleIssues(env.Ctx, 90*24*time.Hour)
+	if err != nil {
+		t.Fatalf("ListStaleIssues failed: %v", err)
+	}
+	if len(none) != 0 {
+		t.Errorf("expected no issues older than 90 days, got %d", len(none))
+	}
+}
+
+func TestListOverdueIssues(t *testing.T) {
+	env := newTestEnv(t)
+	now := time.Now().UTC().Truncate(time.Second)
+	seed := func(title string, due *time.Time, status types.Status) string {
+		t.Helper()
+		issue := &types.Issue{Title: title, Status: status, Priority: 2, IssueType: types.TypeTask, DueAt: due}
+		if status == types.StatusClosed {
+			closed := now
+			issue.ClosedAt = &closed
+		}
+		if err := env.Store.CreateIssue(env.Ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue.ID
+	}
+	at := func(d time.Duration) *time.Time {
+		due := now.Add(d)
+		return &due
+	}
+
+	week := seed("A week late", at(-7*24*time.Hour), types.StatusInProgress)
+	second := seed("Just missed", at(-time.Second), types.StatusOpen)
+	seed("Due right now", at(0), types.StatusOpen)
+	seed("Due tomorrow", at(24*time.Hour), types.StatusOpen)
+	seed("No due date", nil, types.StatusOpen)
+	seed("Closed late", at(-time.Hour), types.StatusClosed)
+	gone := seed("Deleted late", at(-time.Hour), types.StatusOpen)
+	if err := env.Store.CreateTombstone(env.Ctx, gone, "test", "obsolete"); err != nil {
+		t.Fatalf("CreateTombstone failed: %v", err)
+	}
+
+	overdue, err := env.Store.ListOverdueIssues(env.Ctx, now)
+	if err != nil {
+		t.Fatalf("ListOverdueIssues failed: %v", err)
+	}
+	var got []string
+	for _, issue := range overdue {
+		got = append(got, issue.ID)
+	}
+	if strings.Join(got, ",") != week+","+second {
+		t.Errorf("expected most-overdue-first %s,%s; got %v", week, second, got)
+	}
+	if len(overdue) > 0 && (overdue[0].DueAt == nil || !overdue[0].DueAt.Equal(*at(-7 * 24 * time.Hour))) {
+		t.Errorf("expected DueAt loaded, got %v", overdue[0].DueAt)
+	}
+}
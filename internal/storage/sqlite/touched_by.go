@@ -0,0 +1,37 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ListIssuesTouchedBy returns the issues with at least one event recorded by
+// actor (creation, updates, comments, status changes and so on), the one
+// actor touched most recently first. Tombstones are excluded. The event log is
+// grouped per issue and joined once, not looked up issue by issue.
+func (s *SQLiteStorage) ListIssuesTouchedBy(ctx context.Context, actor string) ([]*types.Issue, error) {
+	s.checkFreshness()
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+rangeIssueColumns+`
+		FROM issues
+		JOIN (
+			SELECT issue_id, MAX(created_at) AS touched_at, MAX(id) AS last_event
+			FROM events
+			WHERE actor = ?
+			GROUP BY issue_id
+		) touched ON touched.issue_id = issues.id
+		WHERE status != 'tombstone'
+		ORDER BY touched.touched_at DESC, touched.last_event DESC, issues.id
+	`, actor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issues touched by %s: %w", actor, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return scanIssueList(ctx, s, rows)
+}
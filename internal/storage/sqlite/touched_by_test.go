@@ -0,0 +1,63 @@
+package sqlite
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestListIssuesTouchedBy(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := env.Ctx
+
+	create := func(title, actor string) *types.Issue {
+		t.Helper()
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := env.Store.CreateIssue(ctx, issue, actor); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue
+	}
+	aliceCreated := create("Created by alice", "alice")
+	bobCreated := create("Created by bob", "bob")
+	untouched := create("Never touched by alice", "carol")
+	deleted := create("Deleted", "alice")
+
+	if err := env.Store.AddComment(ctx, bobCreated.ID, "alice", "Reviewed"); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+	if err := env.Store.UpdateIssue(ctx, aliceCreated.ID, map[string]interface{}{"priority": 1}, "bob"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if err := env.Store.UpdateIssue(ctx, untouched.ID, map[string]interface{}{"priority": 1}, "bob"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if err := env.Store.CreateTombstone(ctx, deleted.ID, "alice", "duplicate"); err != nil {
+		t.Fatalf("CreateTombstone failed: %v", err)
+	}
+
+	ids := func(actor string) []string {
+		t.Helper()
+		issues, err := env.Store.ListIssuesTouchedBy(ctx, actor)
+		if err != nil {
+			t.Fatalf("ListIssuesTouchedBy failed: %v", err)
+		}
+		var got []string
+		for _, issue := range issues {
+			got = append(got, issue.ID)
+		}
+		return got
+	}
+
+	// alice commented on bob's issue after creating her own
+	if got, want := ids("alice"), []string{bobCreated.ID, aliceCreated.ID}; !reflect.DeepEqual(got, want) {
+		t.Errorf("touched by alice = %v, want %v", got, want)
+	}
+	if got, want := ids("bob"), []string{untouched.ID, aliceCreated.ID, bobCreated.ID}; !reflect.DeepEqual(got, want) {
+		t.Errorf("touched by bob = %v, want %v", got, want)
+	}
+	if got := ids("dave"); len(got) != 0 {
+		t.Errorf("touched by dave = %v, want none", got)
+	}
+}
@@ -22,6 +22,8 @@ var _ storage.Transaction = (*sqliteTxStorage)(nil)
 type sqliteTxStorage struct {
 	conn   *sql.Conn      // Dedicated connection for the transaction
 	parent *SQLiteStorage // Parent storage for accessing shared state
+
+	customConfigChanged bool // Custom status/type config written; invalidate the parent's cache on commit
 }
 
 // RunInTransaction executes a function within a database transaction.
@@ -39,6 +41,10 @@ type sqliteTxStorage struct {
 // Panic safety: If the callback panics, the transaction is rolled back
 // and the panic is re-raised to the caller.
 func (s *SQLiteStorage) RunInTransaction(ctx context.Context, fn func(tx storage.Transaction) error) error {
+	if err := s.checkOpenMode(); err != nil {
+		return err
+	}
+
 	// Acquire a dedicated connection for the transaction.
 	// This ensures all operations in the transaction use the same connection.
 	conn, err := s.db.Conn(ctx)
@@ -51,7 +57,7 @@ func (s *SQLiteStorage) RunInTransaction(ctx context.Context, fn func(tx storage
 	// BEGIN IMMEDIATE prevents deadlocks by acquiring the write lock upfront.
 	// The connection's busy_timeout pragma (30s) handles retries if locked.
 	if _, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
+		return fmt.Errorf("failed to begin transaction: %w", markReadOnly(markBusy(err)))
 	}
 
 	// Track commit state for cleanup
@@ -84,14 +90,41 @@ func (s *SQLiteStorage) RunInTransaction(ctx context.Context, fn func(tx storage
 
 	// Commit the transaction
 	if _, err := conn.ExecContext(ctx, "COMMIT"); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+		return fmt.Errorf("failed to commit transaction: %w", markBusy(err))
 	}
 	committed = true
+	// Only committed changes invalidate, so concurrent readers can't re-cache
+	// the value from before the commit
+	if txStorage.customConfigChanged {
+		s.customCache.invalidate()
+	}
 	return nil
 }
 
+// WithSavepoint runs fn inside a savepoint of the transaction. If fn fails, its
+// writes are rolled back while the transaction's earlier writes are kept, and
+// fn's error is returned, so the caller can carry on with the transaction.
+// Savepoints nest.
+func (t *sqliteTxStorage) WithSavepoint(ctx context.Context, fn func() error) error {
+	if _, err := t.conn.ExecContext(ctx, `SAVEPOINT tx_savepoint`); err != nil {
+		return wrapDBError("create savepoint", err)
+	}
+	if err := fn(); err != nil {
+		// Use background context so the rollback completes even if ctx is canceled
+		_, _ = t.conn.ExecContext(context.Background(), `ROLLBACK TO SAVEPOINT tx_savepoint`)
+		_, _ = t.conn.ExecContext(context.Background(), `RELEASE SAVEPOINT tx_savepoint`)
+		return err
+	}
+	_, err := t.conn.ExecContext(ctx, `RELEASE SAVEPOINT tx_savepoint`)
+	return wrapDBError("release savepoint", err)
+}
+
 // CreateIssue creates a new issue within the transaction.
 func (t *sqliteTxStorage) CreateIssue(ctx context.Context, issue *types.Issue, actor string) error {
+	if err := checkActor(ctx, t.conn, actor); err != nil {
+		return err
+	}
+
 	// Fetch custom statuses and types for validation
 	customStatuses, err := t.GetCustomStatuses(ctx)
 	if err != nil {
@@ -133,13 +166,17 @@ func (t *sqliteTxStorage) CreateIssue(ctx context.Context, issue *types.Issue, a
 	}
 
 	// Validate issue before creating (with custom status and type support)
-	if err := issue.ValidateWithCustom(customStatuses, customTypes); err != nil {
+	if err := validateIssueWithLimits(issue, customStatuses, customTypes, getFieldLimits(ctx, t.conn), t.parent.issueValidator()); err != nil {
 		return fmt.Errorf("validation failed: %w", err)
 	}
 
+	if err := assignExternalRefs(ctx, t.conn, issue); err != nil {
+		return err
+	}
+
 	// Compute content hash
 	if issue.ContentHash == "" {
-		issue.ContentHash = issue.ComputeContentHash()
+		issue.ContentHash = contentHash(ctx, t.conn, issue)
 	}
 
 	// Get prefix from config (needed for both ID generation and validation)
@@ -151,18 +188,25 @@ func (t *sqliteTxStorage) CreateIssue(ctx context.Context, issue *types.Issue, a
 	} else if err != nil {
 		return fmt.Errorf("failed to get config: %w", err)
 	}
+	if err := checkConfiguredPrefix(ctx, t.conn, configPrefix); err != nil {
+		return err
+	}
 
 	// Determine prefix for ID generation and validation:
 	// 1. PrefixOverride completely replaces config prefix (for cross-rig creation)
 	// 2. IDPrefix appends to config prefix (e.g., "bd" + "wisp" → "bd-wisp")
 	// 3. Otherwise use config prefix as-is
+	sep := getIDSeparator(ctx, t.conn)
 	prefix := configPrefix
 	skipPrefixValidation := false
 	if issue.PrefixOverride != "" {
 		prefix = issue.PrefixOverride
 		skipPrefixValidation = true // Caller explicitly specified prefix, skip validation
 	} else if issue.IDPrefix != "" {
-		prefix = configPrefix + "-" + issue.IDPrefix
+		if err := validateIDPrefix(issue.IDPrefix, sep); err != nil {
+			return fmt.Errorf("failed to validate ID prefix: %w", err)
+		}
+		prefix = configPrefix + sep + issue.IDPrefix
 	}
 
 	// Generate or validate ID
@@ -177,7 +221,7 @@ func (t *sqliteTxStorage) CreateIssue(ctx context.Context, issue *types.Issue, a
 		// Validate that explicitly provided ID matches the configured prefix
 		// Skip validation when PrefixOverride is set (cross-rig creation)
 		if !skipPrefixValidation {
-			if err := ValidateIssueIDPrefix(issue.ID, prefix); err != nil {
+			if err := ValidateIssueIDPrefixWithSeparator(issue.ID, prefix, sep); err != nil {
 				return fmt.Errorf("failed to validate issue ID prefix: %w", err)
 			}
 		}
@@ -222,6 +266,9 @@ func (t *sqliteTxStorage) CreateIssues(ctx context.Context, issues []*types.Issu
 	if len(issues) == 0 {
 		return nil
 	}
+	if err := checkActor(ctx, t.conn, actor); err != nil {
+		return err
+	}
 
 	// Fetch custom statuses and types for validation
 	customStatuses, err := t.GetCustomStatuses(ctx)
@@ -233,6 +280,9 @@ func (t *sqliteTxStorage) CreateIssues(ctx context.Context, issues []*types.Issu
 		return fmt.Errorf("failed to get custom types: %w", err)
 	}
 
+	limits := getFieldLimits(ctx, t.conn)
+	hashOpts := getHashOptions(ctx, t.conn)
+
 	// Validate and prepare all issues first (with custom status and type support)
 	now := time.Now()
 	for _, issue := range issues {
@@ -265,13 +315,16 @@ func (t *sqliteTxStorage) CreateIssues(ctx context.Context, issues []*types.Issu
 			issue.DeletedAt = &deletedAt
 		}
 
-		if err := issue.ValidateWithCustom(customStatuses, customTypes); err != nil {
+		if err := validateIssueWithLimits(issue, customStatuses, customTypes, limits, t.parent.issueValidator()); err != nil {
 			return fmt.Errorf("validation failed for issue: %w", err)
 		}
 		if issue.ContentHash == "" {
-			issue.ContentHash = issue.ComputeContentHash()
+			issue.ContentHash = issue.ComputeContentHashWith(hashOpts)
 		}
 	}
+	if err := assignExternalRefs(ctx, t.conn, issues...); err != nil {
+		return err
+	}
 
 	// Get prefix from config
 	var prefix string
@@ -281,6 +334,11 @@ func (t *sqliteTxStorage) CreateIssues(ctx context.Context, issues []*types.Issu
 	} else if err != nil {
 		return fmt.Errorf("failed to get config: %w", err)
 	}
+	if err := checkConfiguredPrefix(ctx, t.conn, prefix); err != nil {
+		return err
+	}
+
+	sep := getIDSeparator(ctx, t.conn)
 
 	// Generate IDs for issues that don't have them
 	for _, issue := range issues {
@@ -291,7 +349,7 @@ func (t *sqliteTxStorage) CreateIssues(ctx context.Context, issues []*types.Issu
 			}
 			issue.ID = generatedID
 		} else {
-			if err := ValidateIssueIDPrefix(issue.ID, prefix); err != nil {
+			if err := ValidateIssueIDPrefixWithSeparator(issue.ID, prefix, sep); err != nil {
 				return fmt.Errorf("failed to validate issue ID prefix: %w", err)
 			}
 		}
@@ -344,7 +402,7 @@ func (t *sqliteTxStorage) GetIssue(ctx context.Context, id string) (*types.Issue
 		       sender, ephemeral, pinned, is_template, crystallizes,
 		       await_type, await_id, timeout_ns, waiters,
 		       hook_bead, role_bead, agent_state, last_activity, role_type, rig, mol_type,
-		       due_at, defer_until
+		       due_at, defer_until, expires_at, color, display_order, source_system, custom_fields, rank, milestone_id
 		FROM issues
 		WHERE id = ?
 	`, id)
@@ -352,11 +410,18 @@ func (t *sqliteTxStorage) GetIssue(ctx context.Context, id string) (*types.Issue
 	issue, err := scanIssueRow(row)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
+			if canonical := caseVariantID(ctx, t.conn, id); canonical != "" {
+				return t.GetIssue(ctx, canonical)
+			}
 			return nil, nil
 		}
 		return nil, fmt.Errorf("failed to get issue: %w", err)
 	}
 
+	if err := hydrateDescriptions(ctx, t.conn, issue); err != nil {
+		return nil, err
+	}
+
 	// Fetch labels for this issue using the transaction connection
 	labels, err := t.getLabels(ctx, issue.ID)
 	if err != nil {
@@ -364,6 +429,18 @@ func (t *sqliteTxStorage) GetIssue(ctx context.Context, id string) (*types.Issue
 	}
 	issue.Labels = labels
 
+	watchers, err := t.GetWatchers(ctx, issue.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get watchers: %w", err)
+	}
+	issue.Watchers = watchers
+
+	checklist, err := t.GetChecklist(ctx, issue.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get checklist: %w", err)
+	}
+	issue.Checklist = checklist
+
 	return issue, nil
 }
 
@@ -389,8 +466,35 @@ func (t *sqliteTxStorage) getLabels(ctx context.Context, issueID string) ([]stri
 	return labels, nil
 }
 
+// UpdateIssueWithExpectedHash is the transactional form of
+// SQLiteStorage.UpdateIssueWithExpectedHash. The transaction holds the write
+// lock (BEGIN IMMEDIATE), so checking the stored hash before updating is atomic.
+func (t *sqliteTxStorage) UpdateIssueWithExpectedHash(ctx context.Context, id, expectedHash string, updates map[string]interface{}, actor string) error {
+	if expectedHash == "" {
+		return fmt.Errorf("expected hash is required for optimistic update of %s", id)
+	}
+	var storedHash sql.NullString
+	err := t.conn.QueryRowContext(ctx, `SELECT content_hash FROM issues WHERE id = ?`, id).Scan(&storedHash)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("issue %s not found", id)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read content hash for %s: %w", id, err)
+	}
+	if storedHash.String != expectedHash {
+		return fmt.Errorf("%w: issue %s", ErrStaleWrite, id)
+	}
+	return t.UpdateIssue(ctx, id, updates, actor)
+}
+
 // UpdateIssue updates an issue within the transaction.
 func (t *sqliteTxStorage) UpdateIssue(ctx context.Context, id string, updates map[string]interface{}, actor string) error {
+	return t.updateIssue(ctx, id, updates, actor, true)
+}
+
+// updateIssue implements UpdateIssue. Callers updating many issues pass
+// rebuildCache false and rebuild the blocked issues cache once afterwards.
+func (t *sqliteTxStorage) updateIssue(ctx context.Context, id string, updates map[string]interface{}, actor string, rebuildCache bool) error {
 	// Get old issue for event
 	oldIssue, err := t.GetIssue(ctx, id)
 	if err != nil {
@@ -399,6 +503,10 @@ func (t *sqliteTxStorage) UpdateIssue(ctx context.Context, id string, updates ma
 	if oldIssue == nil {
 		return fmt.Errorf("issue %s not found", id)
 	}
+	id = oldIssue.ID // canonical casing when IDs are case-insensitive
+	if err := checkStatusUpdate(ctx, t.conn, oldIssue, updates); err != nil {
+		return err
+	}
 
 	// Fetch custom statuses and types for validation
 	customStatuses, err := t.GetCustomStatuses(ctx)
@@ -411,15 +519,32 @@ func (t *sqliteTxStorage) UpdateIssue(ctx context.Context, id string, updates ma
 		return fmt.Errorf("failed to get custom types: %w", err)
 	}
 
+	if err := validateTitleUpdate(ctx, t.conn, updates); err != nil {
+		return fmt.Errorf("failed to validate field update: %w", err)
+	}
+	if err := validateTypeStatusUpdate(ctx, t.conn, oldIssue, updates); err != nil {
+		return fmt.Errorf("failed to validate field update: %w", err)
+	}
+	if err := t.parent.validateUpdatedIssue(oldIssue, updates); err != nil {
+		return fmt.Errorf("failed to validate field update: %w", err)
+	}
+	updatedAt, err := resolveUpdatedAt(ctx, t.conn, oldIssue, updates)
+	if err != nil {
+		return err
+	}
+
 	// Build update query with validated field names
 	setClauses := []string{"updated_at = ?"}
-	args := []interface{}{time.Now()}
+	args := []interface{}{updatedAt}
 
 	for key, value := range updates {
 		// Prevent SQL injection by validating field names
 		if !allowedUpdateFields[key] {
 			return fmt.Errorf("invalid field for update: %s", key)
 		}
+		if key == "updated_at" {
+			continue // resolved above
+		}
 
 		// Validate field values (with custom status and type support)
 		if err := validateFieldUpdateWithCustom(key, value, customStatuses, customTypes); err != nil {
@@ -427,6 +552,11 @@ func (t *sqliteTxStorage) UpdateIssue(ctx context.Context, id string, updates ma
 		}
 
 		setClauses = append(setClauses, fmt.Sprintf("%s = ?", key))
+		if key == "custom_fields" {
+			if value, err = customFieldsUpdateValue(value); err != nil {
+				return err
+			}
+		}
 		args = append(args, value)
 	}
 
@@ -435,7 +565,7 @@ func (t *sqliteTxStorage) UpdateIssue(ctx context.Context, id string, updates ma
 
 	// Recompute content_hash if any content fields changed
 	contentChanged := false
-	contentFields := []string{"title", "description", "design", "acceptance_criteria", "notes", "status", "priority", "issue_type", "assignee", "external_ref"}
+	contentFields := []string{"title", "description", "design", "acceptance_criteria", "notes", "status", "priority", "issue_type", "assignee", "external_ref", "color", "display_order", "source_system", "custom_fields", "rank", "milestone_id"}
 	for _, field := range contentFields {
 		if _, exists := updates[field]; exists {
 			contentChanged = true
@@ -445,7 +575,7 @@ func (t *sqliteTxStorage) UpdateIssue(ctx context.Context, id string, updates ma
 	if contentChanged {
 		updatedIssue := *oldIssue
 		applyUpdatesToIssue(&updatedIssue, updates)
-		newHash := updatedIssue.ComputeContentHash()
+		newHash := contentHash(ctx, t.conn, &updatedIssue)
 		setClauses = append(setClauses, "content_hash = ?")
 		args = append(args, newHash)
 	}
@@ -458,23 +588,19 @@ func (t *sqliteTxStorage) UpdateIssue(ctx context.Context, id string, updates ma
 	if err != nil {
 		return fmt.Errorf("failed to update issue: %w", err)
 	}
-
-	// Record event
-	oldData, err := json.Marshal(oldIssue)
-	if err != nil {
-		oldData = []byte(fmt.Sprintf(`{"id":"%s"}`, id))
-	}
-	newData, err := json.Marshal(updates)
-	if err != nil {
-		newData = []byte(`{}`)
+	if _, ok := updates["description"]; ok {
+		if err := packDescriptions(ctx, t.conn, id); err != nil {
+			return err
+		}
 	}
 
+	// Record event with the changed fields' before/after values
 	eventType := determineEventType(oldIssue, updates)
 
 	_, err = t.conn.ExecContext(ctx, `
-		INSERT INTO events (issue_id, event_type, actor, old_value, new_value)
-		VALUES (?, ?, ?, ?, ?)
-	`, id, eventType, actor, string(oldData), string(newData))
+		INSERT INTO events (issue_id, event_type, actor, new_value)
+		VALUES (?, ?, ?, ?)
+	`, id, eventType, actor, updateEventValue(ctx, t.conn, oldIssue, updates))
 	if err != nil {
 		return fmt.Errorf("failed to record event: %w", err)
 	}
@@ -486,7 +612,7 @@ func (t *sqliteTxStorage) UpdateIssue(ctx context.Context, id string, updates ma
 
 	// Invalidate blocked issues cache if status changed
 	// Status changes affect which issues are blocked (blockers must be open/in_progress/blocked)
-	if _, statusChanged := updates["status"]; statusChanged {
+	if _, statusChanged := updates["status"]; statusChanged && rebuildCache {
 		if err := t.parent.invalidateBlockedCache(ctx, t.conn); err != nil {
 			return fmt.Errorf("failed to invalidate blocked cache: %w", err)
 		}
@@ -541,6 +667,30 @@ func applyUpdatesToIssue(issue *types.Issue, updates map[string]interface{}) {
 			} else if s, ok := value.(string); ok {
 				issue.Assignee = s
 			}
+		case "color":
+			if s, ok := value.(string); ok {
+				issue.Color = s
+			}
+		case "display_order":
+			if n, ok := value.(int); ok {
+				issue.DisplayOrder = n
+			}
+		case "rank":
+			if s, ok := value.(string); ok {
+				issue.Rank = s
+			}
+		case "milestone_id":
+			if s, ok := value.(string); ok {
+				issue.MilestoneID = s
+			}
+		case "source_system":
+			if s, ok := value.(string); ok {
+				issue.SourceSystem = s
+			}
+		case "custom_fields":
+			if fields, ok := value.(map[string]json.RawMessage); ok {
+				issue.CustomFields = fields
+			}
 		case "external_ref":
 			if value == nil {
 				issue.ExternalRef = nil
@@ -561,6 +711,15 @@ func applyUpdatesToIssue(issue *types.Issue, updates map[string]interface{}) {
 // The session parameter tracks which Claude Code session closed the issue (can be empty).
 func (t *sqliteTxStorage) CloseIssue(ctx context.Context, id string, reason string, actor string, session string) error {
 	now := time.Now()
+	id = canonicalIssueID(ctx, t.conn, id)
+
+	from, err := currentStatus(ctx, t.conn, id)
+	if err != nil {
+		return err
+	}
+	if err := checkStatusTransition(ctx, t.conn, id, from, types.StatusClosed); err != nil {
+		return err
+	}
 
 	result, err := t.conn.ExecContext(ctx, `
 		UPDATE issues SET status = ?, closed_at = ?, updated_at = ?, close_reason = ?, closed_by_session = ?
@@ -675,6 +834,8 @@ func (t *sqliteTxStorage) CloseIssue(ctx context.Context, id string, reason stri
 
 // DeleteIssue deletes an issue within the transaction.
 func (t *sqliteTxStorage) DeleteIssue(ctx context.Context, id string) error {
+	id = canonicalIssueID(ctx, t.conn, id)
+
 	// Delete dependencies (both directions)
 	_, err := t.conn.ExecContext(ctx, `DELETE FROM dependencies WHERE issue_id = ? OR depends_on_id = ?`, id, id)
 	if err != nil {
@@ -725,6 +886,7 @@ func (t *sqliteTxStorage) AddDependency(ctx context.Context, dep *types.Dependen
 	if issueExists == nil {
 		return fmt.Errorf("issue %s not found", dep.IssueID)
 	}
+	dep.IssueID = issueExists.ID // canonical casing when IDs are case-insensitive
 
 	// External refs (external:<project>:<capability>) don't need target validation
 	// They are resolved lazily at query time by CheckExternalDep
@@ -739,6 +901,7 @@ func (t *sqliteTxStorage) AddDependency(ctx context.Context, dep *types.Dependen
 		if dependsOnExists == nil {
 			return fmt.Errorf("dependency target %s not found", dep.DependsOnID)
 		}
+		dep.DependsOnID = dependsOnExists.ID
 
 		// Prevent self-dependency (only for local deps)
 		if dep.IssueID == dep.DependsOnID {
@@ -751,6 +914,9 @@ func (t *sqliteTxStorage) AddDependency(ctx context.Context, dep *types.Dependen
 				return fmt.Errorf("invalid parent-child dependency: parent (%s) cannot depend on child (%s). Use: bd dep add %s %s --type parent-child",
 					dep.IssueID, dep.DependsOnID, dep.DependsOnID, dep.IssueID)
 			}
+			if err := validateParentType(ctx, t.conn, issueExists, dependsOnExists); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -761,9 +927,10 @@ func (t *sqliteTxStorage) AddDependency(ctx context.Context, dep *types.Dependen
 		dep.CreatedBy = actor
 	}
 
-	// Cycle detection - skip for relates-to (inherently bidirectional)
+	// Cycle detection - skip for relates-to (inherently bidirectional) and for
+	// imports keeping both edges of a flagged inversion
 	// See dependencies.go for full rationale on cycle prevention
-	if dep.Type != types.DepRelatesTo {
+	if dep.Type != types.DepRelatesTo && !storage.SkipsCycleCheck(ctx) {
 		var cycleExists bool
 		err = t.conn.QueryRowContext(ctx, `
 		WITH RECURSIVE paths AS (
@@ -929,6 +1096,8 @@ func (t *sqliteTxStorage) RemoveDependency(ctx context.Context, issueID, depends
 
 // AddLabel adds a label to an issue within the transaction.
 func (t *sqliteTxStorage) AddLabel(ctx context.Context, issueID, label, actor string) error {
+	issueID = canonicalIssueID(ctx, t.conn, issueID)
+
 	result, err := t.conn.ExecContext(ctx, `
 		INSERT OR IGNORE INTO labels (issue_id, label) VALUES (?, ?)
 	`, issueID, label)
@@ -969,6 +1138,8 @@ func (t *sqliteTxStorage) GetLabels(ctx context.Context, issueID string) ([]stri
 
 // RemoveLabel removes a label from an issue within the transaction.
 func (t *sqliteTxStorage) RemoveLabel(ctx context.Context, issueID, label, actor string) error {
+	issueID = canonicalIssueID(ctx, t.conn, issueID)
+
 	result, err := t.conn.ExecContext(ctx, `
 		DELETE FROM labels WHERE issue_id = ? AND label = ?
 	`, issueID, label)
@@ -1004,6 +1175,12 @@ func (t *sqliteTxStorage) RemoveLabel(ctx context.Context, issueID, label, actor
 
 // SetConfig sets a configuration value within the transaction.
 func (t *sqliteTxStorage) SetConfig(ctx context.Context, key, value string) error {
+	if err := checkPrefixConfig(ctx, t.conn, key, value); err != nil {
+		return fmt.Errorf("invalid config %s: %w", key, err)
+	}
+	if err := checkHashSaltConfig(ctx, t.conn, key, value, false); err != nil {
+		return fmt.Errorf("invalid config %s: %w", key, err)
+	}
 	_, err := t.conn.ExecContext(ctx, `
 		INSERT INTO config (key, value) VALUES (?, ?)
 		ON CONFLICT (key) DO UPDATE SET value = excluded.value
@@ -1011,6 +1188,9 @@ func (t *sqliteTxStorage) SetConfig(ctx context.Context, key, value string) erro
 	if err != nil {
 		return fmt.Errorf("failed to set config: %w", err)
 	}
+	if isCustomConfigKey(key) {
+		t.customConfigChanged = true
+	}
 	return nil
 }
 
@@ -1080,6 +1260,8 @@ func (t *sqliteTxStorage) GetMetadata(ctx context.Context, key string) (string,
 
 // AddComment adds a comment to an issue within the transaction.
 func (t *sqliteTxStorage) AddComment(ctx context.Context, issueID, actor, comment string) error {
+	issueID = canonicalIssueID(ctx, t.conn, issueID)
+
 	// Update issue updated_at timestamp first to verify issue exists
 	now := time.Now()
 	res, err := t.conn.ExecContext(ctx, `
@@ -1183,9 +1365,12 @@ func (t *sqliteTxStorage) SearchIssues(ctx context.Context, query string, filter
 	args := []interface{}{}
 
 	if query != "" {
-		whereClauses = append(whereClauses, "(title LIKE ? OR description LIKE ? OR id LIKE ?)")
 		pattern := "%" + query + "%"
-		args = append(args, pattern, pattern, pattern)
+		descClause, descArgs := descriptionLikeClause(pattern)
+		whereClauses = append(whereClauses, "(title LIKE ? OR "+descClause+" OR id LIKE ?)")
+		args = append(args, pattern)
+		args = append(args, descArgs...)
+		args = append(args, pattern)
 	}
 
 	if filter.TitleSearch != "" {
@@ -1200,8 +1385,9 @@ func (t *sqliteTxStorage) SearchIssues(ctx context.Context, query string, filter
 		args = append(args, "%"+filter.TitleContains+"%")
 	}
 	if filter.DescriptionContains != "" {
-		whereClauses = append(whereClauses, "description LIKE ?")
-		args = append(args, "%"+filter.DescriptionContains+"%")
+		descClause, descArgs := descriptionLikeClause("%" + filter.DescriptionContains + "%")
+		whereClauses = append(whereClauses, descClause)
+		args = append(args, descArgs...)
 	}
 	if filter.NotesContains != "" {
 		whereClauses = append(whereClauses, "notes LIKE ?")
@@ -1378,7 +1564,7 @@ func (t *sqliteTxStorage) SearchIssues(ctx context.Context, query string, filter
 		       sender, ephemeral, pinned, is_template, crystallizes,
 		       await_type, await_id, timeout_ns, waiters,
 		       hook_bead, role_bead, agent_state, last_activity, role_type, rig, mol_type,
-		       due_at, defer_until
+		       due_at, defer_until, expires_at, color, display_order, source_system, custom_fields, rank, milestone_id
 		FROM issues
 		%s
 		ORDER BY priority ASC, created_at DESC
@@ -1446,6 +1632,9 @@ func scanIssueRow(row scanner) (*types.Issue, error) {
 	// Time-based scheduling fields
 	var dueAt sql.NullTime
 	var deferUntil sql.NullTime
+	var expiresAt sql.NullTime
+	var sourceSystem sql.NullString
+	var customFields string
 
 	err := row.Scan(
 		&issue.ID, &contentHash, &issue.Title, &issue.Description, &issue.Design,
@@ -1457,7 +1646,7 @@ func scanIssueRow(row scanner) (*types.Issue, error) {
 		&sender, &wisp, &pinned, &isTemplate, &crystallizes,
 		&awaitType, &awaitID, &timeoutNs, &waiters,
 		&hookBead, &roleBead, &agentState, &lastActivity, &roleType, &rig, &molType,
-		&dueAt, &deferUntil,
+		&dueAt, &deferUntil, &expiresAt, &issue.Color, &issue.DisplayOrder, &sourceSystem, &customFields, &issue.Rank, &issue.MilestoneID,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to scan issue: %w", err)
@@ -1576,6 +1765,15 @@ func scanIssueRow(row scanner) (*types.Issue, error) {
 	if deferUntil.Valid {
 		issue.DeferUntil = &deferUntil.Time
 	}
+	if expiresAt.Valid {
+		issue.ExpiresAt = &expiresAt.Time
+	}
+	if sourceSystem.Valid {
+		issue.SourceSystem = sourceSystem.String
+	}
+	if issue.CustomFields, err = parseCustomFields(customFields); err != nil {
+		return nil, err
+	}
 
 	return &issue, nil
 }
@@ -1610,6 +1808,10 @@ func (t *sqliteTxStorage) scanIssues(ctx context.Context, rows *sql.Rows) ([]*ty
 		issue.Labels = labelsMap[issue.ID]
 	}
 
+	if err := hydrateDescriptions(ctx, t.conn, issues...); err != nil {
+		return nil, err
+	}
+
 	return issues, nil
 }
 
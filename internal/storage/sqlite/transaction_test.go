@@ -2007,3 +2007,55 @@ func TestTransactionSearchIssuesWithIDs(t *testing.T) {
 		t.Fatalf("RunInTransaction failed: %v", err)
 	}
 }
+
+// TestTransactionWithSavepoint verifies that a failed savepoint rolls back only
+// its own writes and leaves the transaction usable.
+func TestTransactionWithSavepoint(t *testing.T) {
+	ctx := context.Background()
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	newIssue := func(title string) *types.Issue {
+		return &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	}
+	kept, released, undone := newIssue("Kept"), newIssue("Released"), newIssue("Undone")
+	errPartial := fmt.Errorf("failed partway")
+	err := store.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		sp := tx.(*sqliteTxStorage)
+		if err := tx.CreateIssue(ctx, kept, "test-actor"); err != nil {
+			return err
+		}
+		if err := sp.WithSavepoint(ctx, func() error { return tx.CreateIssue(ctx, released, "test-actor") }); err != nil {
+			return err
+		}
+		err := sp.WithSavepoint(ctx, func() error {
+			if err := tx.CreateIssue(ctx, undone, "test-actor"); err != nil {
+				return err
+			}
+			return errPartial
+		})
+		if err != errPartial {
+			t.Errorf("WithSavepoint returned %v, want the function's error", err)
+		}
+		return tx.SetConfig(ctx, "after_savepoint", "yes")
+	})
+	if err != nil {
+		t.Fatalf("RunInTransaction failed: %v", err)
+	}
+
+	for _, want := range []struct {
+		issue  *types.Issue
+		exists bool
+	}{{kept, true}, {released, true}, {undone, false}} {
+		got, err := store.GetIssue(ctx, want.issue.ID)
+		if err != nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		if (got != nil) != want.exists {
+			t.Errorf("%s exists = %v, want %v", want.issue.Title, got != nil, want.exists)
+		}
+	}
+	if value, _ := store.GetConfig(ctx, "after_savepoint"); value != "yes" {
+		t.Errorf("write after the failed savepoint = %q, want it committed", value)
+	}
+}
@@ -0,0 +1,69 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// GetIssueTree returns rootID with its hierarchical descendants (rootID.1,
+// rootID.1.2, ...) nested as children, each level ordered by ID under the
+// configured collation. All nodes are read in one query ordered by depth.
+// Tombstones are left out unless includeTombstones is set; the children of a
+// left-out node hang from its nearest included ancestor. Returns nil if the
+// root doesn't exist (or is an excluded tombstone).
+func (s *SQLiteStorage) GetIssueTree(ctx context.Context, rootID string, includeTombstones bool) (*types.IssueTree, error) {
+	s.checkFreshness()
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	collation := getIDCollation(ctx, s.db)
+	tombstoneClause := ""
+	if !includeTombstones {
+		tombstoneClause = "AND status != 'tombstone'"
+	}
+
+	// Descendant IDs all sort between "root." and "root/" ('/' follows '.'),
+	// so the range uses the primary key index.
+	// #nosec G201 - safe SQL with controlled formatting
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT %s
+		FROM issues
+		WHERE (id = ? OR (id > ? AND id < ?))
+		  %s
+		ORDER BY length(id) - length(replace(id, '.', '')), id%s
+	`, rangeIssueColumns, tombstoneClause, idCollateClause(collation)), rootID, rootID+".", rootID+"/")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue tree: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	issues, err := s.scanIssues(ctx, rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(issues) == 0 || issues[0].ID != rootID {
+		return nil, nil
+	}
+
+	nodes := make(map[string]*types.IssueTree, len(issues))
+	for _, issue := range issues {
+		node := &types.IssueTree{Issue: *issue}
+		nodes[issue.ID] = node
+		if issue.ID == rootID {
+			continue
+		}
+		// Parents come first (depth order); walk up past missing levels
+		parentID := issue.ID
+		for {
+			parentID = parentID[:strings.LastIndex(parentID, ".")]
+			if parent, ok := nodes[parentID]; ok {
+				parent.Children = append(parent.Children, node)
+				break
+			}
+		}
+	}
+	return nodes[rootID], nil
+}
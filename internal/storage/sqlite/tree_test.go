@@ -0,0 +1,78 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestGetIssueTree(t *testing.T) {
+	env := newTestEnv(t)
+	if err := env.Store.SetConfig(env.Ctx, IDCollationConfigKey, IDCollationNatural); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	for _, id := range []string{"bd-x", "bd-x.1", "bd-x.2", "bd-x.10", "bd-x.1.1", "bd-x.2.1", "bd-xy", "bd-y"} {
+		issue := &types.Issue{ID: id, Title: "Node " + id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := env.Store.CreateIssue(env.Ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue(%s) failed: %v", id, err)
+		}
+	}
+	if err := env.Store.CreateTombstone(env.Ctx, "bd-x.2", "test", "gone"); err != nil {
+		t.Fatalf("CreateTombstone failed: %v", err)
+	}
+
+	// render flattens the tree as "id(children...)"
+	var render func(n *types.IssueTree) string
+	render = func(n *types.IssueTree) string {
+		out := n.ID
+		if len(n.Children) > 0 {
+			out += "("
+			for i, c := range n.Children {
+				if i > 0 {
+					out += " "
+				}
+				out += render(c)
+			}
+			out += ")"
+		}
+		return out
+	}
+
+	tree, err := env.Store.GetIssueTree(env.Ctx, "bd-x", false)
+	if err != nil {
+		t.Fatalf("GetIssueTree failed: %v", err)
+	}
+	// The tombstoned bd-x.2 is left out and its child moves up to the root
+	if got, want := render(tree), "bd-x(bd-x.1(bd-x.1.1) bd-x.10 bd-x.2.1)"; got != want {
+		t.Errorf("tree without tombstones:\n got %s\nwant %s", got, want)
+	}
+	if tree.Title != "Node bd-x" {
+		t.Errorf("expected nodes to carry full issues, got title %q", tree.Title)
+	}
+
+	tree, err = env.Store.GetIssueTree(env.Ctx, "bd-x", true)
+	if err != nil {
+		t.Fatalf("GetIssueTree failed: %v", err)
+	}
+	if got, want := render(tree), "bd-x(bd-x.1(bd-x.1.1) bd-x.2(bd-x.2.1) bd-x.10)"; got != want {
+		t.Errorf("tree with tombstones:\n got %s\nwant %s", got, want)
+	}
+
+	sub, err := env.Store.GetIssueTree(env.Ctx, "bd-x.1", false)
+	if err != nil {
+		t.Fatalf("GetIssueTree failed: %v", err)
+	}
+	if got := render(sub); got != "bd-x.1(bd-x.1.1)" {
+		t.Errorf("subtree: got %s", got)
+	}
+
+	if leaf, _ := env.Store.GetIssueTree(env.Ctx, "bd-y", false); leaf == nil || len(leaf.Children) != 0 {
+		t.Errorf("expected childless leaf, got %+v", leaf)
+	}
+	if missing, err := env.Store.GetIssueTree(env.Ctx, "bd-none", false); err != nil || missing != nil {
+		t.Errorf("expected nil for missing root, got %v, %v", missing, err)
+	}
+	if hidden, err := env.Store.GetIssueTree(env.Ctx, "bd-x.2", false); err != nil || hidden != nil {
+		t.Errorf("expected nil for tombstoned root, got %v, %v", hidden, err)
+	}
+}
@@ -0,0 +1,63 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// UpdatedAtPolicyConfigKey says what an update does when the updated_at it
+// would write predates the stored one, as happens with clock skew between
+// writers or an explicit "updated_at" in the updates: "reject" fails with an
+// *UpdatedAtRegressionError, "clamp" keeps the stored value instead. Unset
+// (or any other value) writes it as is. Imports (contexts marked with
+// storage.WithImport) are authoritative and always write as is.
+const UpdatedAtPolicyConfigKey = "validation.updated_at_policy"
+
+// Values of UpdatedAtPolicyConfigKey.
+const (
+	UpdatedAtReject = "reject"
+	UpdatedAtClamp  = "clamp"
+)
+
+// resolveUpdatedAt returns the updated_at an update of oldIssue writes: the
+// "updated_at" given in updates (a time.Time, *time.Time or RFC 3339 string),
+// or now, checked against the stored value under UpdatedAtPolicyConfigKey.
+func resolveUpdatedAt(ctx context.Context, db dbExecutor, oldIssue *types.Issue, updates map[string]interface{}) (time.Time, error) {
+	updatedAt := time.Now()
+	switch v := updates["updated_at"].(type) {
+	case nil:
+	case time.Time:
+		updatedAt = v
+	case *time.Time:
+		if v != nil {
+			updatedAt = *v
+		}
+	case string:
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid updated_at %q: %w", v, err)
+		}
+		updatedAt = t
+	default:
+		return time.Time{}, fmt.Errorf("invalid updated_at: %v (want a time)", v)
+	}
+	if !updatedAt.Before(oldIssue.UpdatedAt) || storage.IsImport(ctx) {
+		return updatedAt, nil
+	}
+
+	var policy string
+	if err := db.QueryRowContext(ctx, `SELECT value FROM config WHERE key = ?`, UpdatedAtPolicyConfigKey).Scan(&policy); err != nil {
+		return updatedAt, nil
+	}
+	switch policy {
+	case UpdatedAtReject:
+		return time.Time{}, &UpdatedAtRegressionError{IssueID: oldIssue.ID, Stored: oldIssue.UpdatedAt, Incoming: updatedAt}
+	case UpdatedAtClamp:
+		return oldIssue.UpdatedAt, nil
+	}
+	return updatedAt, nil
+}
@@ -0,0 +1,76 @@
+package sqlite
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+func TestUpdateIssue_UpdatedAtPolicy(t *testing.T) {
+	env := newTestEnv(t)
+	issue := env.CreateIssue("Task")
+	stored := issue.UpdatedAt
+	past := stored.Add(-time.Hour)
+	updatedAt := func() time.Time {
+		t.Helper()
+		got, err := env.Store.GetIssue(env.Ctx, issue.ID)
+		if err != nil || got == nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		return got.UpdatedAt
+	}
+	setPolicy := func(policy string) {
+		t.Helper()
+		if err := env.Store.SetConfig(env.Ctx, UpdatedAtPolicyConfigKey, policy); err != nil {
+			t.Fatalf("SetConfig failed: %v", err)
+		}
+	}
+
+	setPolicy(UpdatedAtReject)
+	err := env.Store.UpdateIssue(env.Ctx, issue.ID, map[string]interface{}{"title": "Skewed", "updated_at": past}, "test-user")
+	var regression *UpdatedAtRegressionError
+	if !errors.As(err, &regression) || !errors.Is(err, ErrUpdatedAtRegression) {
+		t.Fatalf("expected UpdatedAtRegressionError, got %v", err)
+	}
+	if regression.IssueID != issue.ID || !regression.Incoming.Equal(past) {
+		t.Errorf("error = %+v, want issue %s at %v", regression, issue.ID, past)
+	}
+	err = env.Store.RunInTransaction(env.Ctx, func(tx storage.Transaction) error {
+		return tx.UpdateIssue(env.Ctx, issue.ID, map[string]interface{}{"updated_at": past}, "test-user")
+	})
+	if !errors.Is(err, ErrUpdatedAtRegression) {
+		t.Fatalf("expected ErrUpdatedAtRegression in transaction, got %v", err)
+	}
+	if got := updatedAt(); !got.Equal(stored) {
+		t.Errorf("rejected update moved updated_at to %v", got)
+	}
+
+	// Forward moves pass under reject
+	future := stored.Add(time.Hour)
+	if err := env.Store.UpdateIssue(env.Ctx, issue.ID, map[string]interface{}{"updated_at": future}, "test-user"); err != nil {
+		t.Fatalf("forward update failed: %v", err)
+	}
+	if got := updatedAt(); !got.Equal(future) {
+		t.Errorf("updated_at = %v, want %v", got, future)
+	}
+
+	setPolicy(UpdatedAtClamp)
+	if err := env.Store.UpdateIssue(env.Ctx, issue.ID, map[string]interface{}{"title": "Clamped", "updated_at": past}, "test-user"); err != nil {
+		t.Fatalf("clamped update failed: %v", err)
+	}
+	got, err := env.Store.GetIssue(env.Ctx, issue.ID)
+	if err != nil || got.Title != "Clamped" || !got.UpdatedAt.Equal(future) {
+		t.Errorf("after clamped update got %q at %v, %v; want the new title at %v", got.Title, got.UpdatedAt, err, future)
+	}
+
+	// Imports are authoritative
+	setPolicy(UpdatedAtReject)
+	if err := env.Store.UpdateIssue(storage.WithImport(env.Ctx), issue.ID, map[string]interface{}{"updated_at": past}, "import"); err != nil {
+		t.Fatalf("import update failed: %v", err)
+	}
+	if got := updatedAt(); !got.Equal(past) {
+		t.Errorf("updated_at after import = %v, want %v", got, past)
+	}
+}
@@ -3,6 +3,7 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"strings"
 )
 
@@ -27,6 +28,10 @@ func (s *SQLiteStorage) BeginTx(ctx context.Context) (*sql.Tx, error) {
 //
 // This fixes GH#1272: database lock errors during concurrent operations.
 func (s *SQLiteStorage) withTx(ctx context.Context, fn func(*sql.Conn) error) error {
+	if err := s.checkOpenMode(); err != nil {
+		return err
+	}
+
 	// Acquire a dedicated connection for the transaction.
 	// This ensures all operations in the transaction use the same connection.
 	conn, err := s.db.Conn(ctx)
@@ -65,6 +70,27 @@ func (s *SQLiteStorage) withTx(ctx context.Context, fn func(*sql.Conn) error) er
 	return nil
 }
 
+// withReadTx executes fn within a read-only (deferred) transaction, so every
+// query in fn sees the same snapshot of the database without taking the write
+// lock. The transaction is always rolled back.
+func (s *SQLiteStorage) withReadTx(ctx context.Context, fn func(*sql.Conn) error) error {
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return wrapDBError("acquire connection", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	if _, err := conn.ExecContext(ctx, "BEGIN"); err != nil {
+		return wrapDBError("begin transaction", err)
+	}
+	defer func() { _, _ = conn.ExecContext(context.Background(), "ROLLBACK") }()
+
+	return fn(conn)
+}
+
 // ExecInTransaction is deprecated. Use withTx instead.
 func (s *SQLiteStorage) ExecInTransaction(ctx context.Context, fn func(*sql.Conn) error) error {
 	return s.withTx(ctx, fn)
@@ -103,6 +129,9 @@ func IsBusyError(err error) bool {
 	if err == nil {
 		return false
 	}
+	if errors.Is(err, ErrBusy) {
+		return true
+	}
 	errStr := err.Error()
 	return strings.Contains(errStr, "database is locked") ||
 		strings.Contains(errStr, "SQLITE_BUSY")
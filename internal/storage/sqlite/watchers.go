@@ -0,0 +1,151 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// normalizeWatcher trims a watcher identity. Watchers are free-form, like
+// assignees: they are not checked against any user directory.
+func normalizeWatcher(watcher string) (string, error) {
+	watcher = strings.TrimSpace(watcher)
+	if watcher == "" {
+		return "", fmt.Errorf("watcher cannot be empty")
+	}
+	return watcher, nil
+}
+
+// AddWatcher subscribes watcher to changes on an issue. Adding an existing
+// watcher is a no-op.
+func (s *SQLiteStorage) AddWatcher(ctx context.Context, issueID, watcher, actor string) error {
+	watcher, err := normalizeWatcher(watcher)
+	if err != nil {
+		return err
+	}
+	return s.executeLabelOperation(
+		ctx, issueID, actor,
+		`INSERT OR IGNORE INTO watchers (issue_id, watcher) VALUES (?, ?)`,
+		[]interface{}{issueID, watcher},
+		types.EventWatcherAdded,
+		fmt.Sprintf("Added watcher: %s", watcher),
+		"failed to add watcher",
+	)
+}
+
+// RemoveWatcher unsubscribes watcher from an issue.
+func (s *SQLiteStorage) RemoveWatcher(ctx context.Context, issueID, watcher, actor string) error {
+	return s.executeLabelOperation(
+		ctx, issueID, actor,
+		`DELETE FROM watchers WHERE issue_id = ? AND watcher = ?`,
+		[]interface{}{issueID, strings.TrimSpace(watcher)},
+		types.EventWatcherRemoved,
+		fmt.Sprintf("Removed watcher: %s", watcher),
+		"failed to remove watcher",
+	)
+}
+
+// GetWatchers returns the watchers of an issue, sorted.
+// Like GetLabels, this is called from GetIssue under reconnectMu.RLock(),
+// so it does not take the lock itself.
+func (s *SQLiteStorage) GetWatchers(ctx context.Context, issueID string) ([]string, error) {
+	return getWatchers(ctx, s.db, issueID)
+}
+
+// GetWatchersForIssues fetches watchers for multiple issues in a single query.
+// Returns a map of issue_id -> []watchers.
+func (s *SQLiteStorage) GetWatchersForIssues(ctx context.Context, issueIDs []string) (map[string][]string, error) {
+	result := make(map[string][]string)
+	if len(issueIDs) == 0 {
+		return result, nil
+	}
+
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+
+	args := make([]interface{}, len(issueIDs))
+	for i, id := range issueIDs {
+		args[i] = id
+	}
+	// #nosec G201 -- placeholders are generated internally
+	query := fmt.Sprintf(`
+		SELECT issue_id, watcher FROM watchers
+		WHERE issue_id IN (%s)
+		ORDER BY issue_id, watcher
+	`, buildPlaceholders(len(issueIDs)))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to batch get watchers: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var issueID, watcher string
+		if err := rows.Scan(&issueID, &watcher); err != nil {
+			return nil, err
+		}
+		result[issueID] = append(result[issueID], watcher)
+	}
+	return result, rows.Err()
+}
+
+// AddWatcher subscribes watcher to an issue within the transaction.
+func (t *sqliteTxStorage) AddWatcher(ctx context.Context, issueID, watcher, actor string) error {
+	watcher, err := normalizeWatcher(watcher)
+	if err != nil {
+		return err
+	}
+	result, err := t.conn.ExecContext(ctx, `
+		INSERT OR IGNORE INTO watchers (issue_id, watcher) VALUES (?, ?)
+	`, issueID, watcher)
+	if err != nil {
+		return fmt.Errorf("failed to add watcher: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rows == 0 {
+		return nil
+	}
+
+	_, err = t.conn.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, comment)
+		VALUES (?, ?, ?, ?)
+	`, issueID, types.EventWatcherAdded, actor, fmt.Sprintf("Added watcher: %s", watcher))
+	if err != nil {
+		return fmt.Errorf("failed to record event: %w", err)
+	}
+	if err := markDirty(ctx, t.conn, issueID); err != nil {
+		return fmt.Errorf("failed to mark issue dirty: %w", err)
+	}
+	return nil
+}
+
+// GetWatchers retrieves watchers for an issue within the transaction.
+func (t *sqliteTxStorage) GetWatchers(ctx context.Context, issueID string) ([]string, error) {
+	return getWatchers(ctx, t.conn, issueID)
+}
+
+func getWatchers(ctx context.Context, db dbExecutor, issueID string) ([]string, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT watcher FROM watchers WHERE issue_id = ? ORDER BY watcher
+	`, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get watchers: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var watchers []string
+	for rows.Next() {
+		var watcher string
+		if err := rows.Scan(&watcher); err != nil {
+			return nil, err
+		}
+		watchers = append(watchers, watcher)
+	}
+	return watchers, rows.Err()
+}
@@ -0,0 +1,109 @@
+package sqlite
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestWatchers_AddRemove(t *testing.T) {
+	env := newTestEnv(t)
+	issue := env.CreateIssue("Watched")
+
+	for _, w := range []string{"bob", " alice ", "bob"} {
+		if err := env.Store.AddWatcher(env.Ctx, issue.ID, w, "test-user"); err != nil {
+			t.Fatalf("AddWatcher(%q) failed: %v", w, err)
+		}
+	}
+	if err := env.Store.AddWatcher(env.Ctx, issue.ID, "  ", "test-user"); err == nil {
+		t.Error("expected error for empty watcher")
+	}
+
+	got, err := env.Store.GetIssue(env.Ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if want := []string{"alice", "bob"}; !reflect.DeepEqual(got.Watchers, want) {
+		t.Errorf("expected watchers %v, got %v", want, got.Watchers)
+	}
+
+	events, err := env.Store.GetEvents(env.Ctx, issue.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	added := 0
+	for _, e := range events {
+		if e.EventType == types.EventWatcherAdded {
+			added++
+		}
+	}
+	if added != 2 {
+		t.Errorf("expected 2 watcher_added events (duplicate is a no-op), got %d", added)
+	}
+
+	if err := env.Store.RemoveWatcher(env.Ctx, issue.ID, "bob", "test-user"); err != nil {
+		t.Fatalf("RemoveWatcher failed: %v", err)
+	}
+	watchers, err := env.Store.GetWatchers(env.Ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetWatchers failed: %v", err)
+	}
+	if want := []string{"alice"}; !reflect.DeepEqual(watchers, want) {
+		t.Errorf("expected watchers %v after removal, got %v", want, watchers)
+	}
+}
+
+func TestWatchers_FollowRename(t *testing.T) {
+	env := newTestEnv(t)
+	issue := env.CreateIssueWithID("bd-w1", "Watched")
+
+	if err := env.Store.AddWatcher(env.Ctx, issue.ID, "alice", "test-user"); err != nil {
+		t.Fatalf("AddWatcher failed: %v", err)
+	}
+
+	issue.ID = "bd-w2"
+	if err := env.Store.UpdateIssueID(env.Ctx, "bd-w1", "bd-w2", issue, "test-user"); err != nil {
+		t.Fatalf("UpdateIssueID failed: %v", err)
+	}
+
+	byIssue, err := env.Store.GetWatchersForIssues(env.Ctx, []string{"bd-w1", "bd-w2"})
+	if err != nil {
+		t.Fatalf("GetWatchersForIssues failed: %v", err)
+	}
+	if len(byIssue["bd-w1"]) != 0 || !reflect.DeepEqual(byIssue["bd-w2"], []string{"alice"}) {
+		t.Errorf("expected watcher to follow rename, got %v", byIssue)
+	}
+}
+
+func TestWatchers_InTransaction(t *testing.T) {
+	env := newTestEnv(t)
+	issue := env.CreateIssue("Watched")
+
+	err := env.Store.RunInTransaction(env.Ctx, func(tx storage.Transaction) error {
+		ws := tx.(storage.WatcherStore)
+		if err := ws.AddWatcher(env.Ctx, issue.ID, "carol", "test-user"); err != nil {
+			return err
+		}
+		watchers, err := ws.GetWatchers(env.Ctx, issue.ID)
+		if err != nil {
+			return err
+		}
+		if !reflect.DeepEqual(watchers, []string{"carol"}) {
+			t.Errorf("expected watcher visible inside transaction, got %v", watchers)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("RunInTransaction failed: %v", err)
+	}
+
+	watchers, err := env.Store.GetWatchers(env.Ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetWatchers failed: %v", err)
+	}
+	if !reflect.DeepEqual(watchers, []string{"carol"}) {
+		t.Errorf("expected committed watcher, got %v", watchers)
+	}
+}
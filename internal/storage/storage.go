@@ -256,6 +256,90 @@ type CompactableStorage interface {
 	MarkIssueDirty(ctx context.Context, issueID string) error
 }
 
+// IDAliasRecorder is implemented by storage backends and transactions that can
+// record an issue's former ID, so references to it survive a remap on import.
+type IDAliasRecorder interface {
+	AddIDAlias(ctx context.Context, alias, issueID string) error
+}
+
+// WatcherStore is implemented by storage backends and transactions that track
+// who watches each issue.
+type WatcherStore interface {
+	AddWatcher(ctx context.Context, issueID, watcher, actor string) error
+	GetWatchers(ctx context.Context, issueID string) ([]string, error)
+}
+
+// ChecklistStore is implemented by storage backends and transactions that
+// keep an ordered checklist inside each issue.
+type ChecklistStore interface {
+	SetChecklist(ctx context.Context, issueID string, items []*types.ChecklistItem, actor string) error
+	GetChecklist(ctx context.Context, issueID string) ([]*types.ChecklistItem, error)
+}
+
+// WriteChecker is implemented by storage backends that can tell up front
+// whether writes would fail, e.g. because the database is read-only.
+type WriteChecker interface {
+	CheckWritable(ctx context.Context) error
+}
+
+// WALTruncater is implemented by storage backends that keep a write-ahead
+// log and can fold it back into the database and truncate it on demand.
+type WALTruncater interface {
+	TruncateWAL(ctx context.Context) error
+}
+
+// IDSuffixIndex is implemented by storage backends and transactions that can
+// find the issues whose ID ends in the same suffix as a given ID under any
+// prefix.
+type IDSuffixIndex interface {
+	IDSuffixConflicts(ctx context.Context, id string) ([]string, error)
+}
+
+// IssueIDGenerator is implemented by transactions that can generate a fresh
+// hash-based ID for an issue under a given prefix, unused by any issue.
+type IssueIDGenerator interface {
+	GenerateIssueID(ctx context.Context, prefix string, issue *types.Issue, actor string) (string, error)
+}
+
+// TemplateStore is implemented by storage backends and transactions that
+// persist issue templates.
+type TemplateStore interface {
+	SaveTemplate(ctx context.Context, tmpl *types.Template) error
+}
+
+// MilestoneStore is implemented by storage backends and transactions that
+// persist milestones.
+type MilestoneStore interface {
+	SaveMilestone(ctx context.Context, m *types.Milestone) error
+	GetMilestone(ctx context.Context, id string) (*types.Milestone, error)
+}
+
+// ArchiveStore is implemented by storage backends that move old closed
+// issues out of the active table into an archive.
+type ArchiveStore interface {
+	FilterArchivedIDs(ctx context.Context, ids []string) ([]string, error)
+}
+
+// EventImporter is implemented by storage backends and transactions that can
+// record an imported event history with its original timestamps.
+type EventImporter interface {
+	ImportEvents(ctx context.Context, issueID string, events []*types.Event, replace bool) error
+}
+
+// CreatedEventDater is implemented by storage backends and transactions that
+// can move an issue's creation event to a given time, so imports can date it
+// at the issue's original CreatedAt.
+type CreatedEventDater interface {
+	SetCreatedEventTime(ctx context.Context, issueID string, at time.Time) error
+}
+
+// ShadowLog is implemented by storage backends that keep a shadow import
+// log: operations an import would have applied, accumulated across runs.
+type ShadowLog interface {
+	RecordShadowRun(ctx context.Context, source string, ops []*types.ShadowOperation) (int64, error)
+	GetShadowOperations(ctx context.Context, source string) ([]*types.ShadowOperation, error)
+}
+
 // BatchDeleter extends Storage with batch delete capabilities.
 // Supports cascade deletion and dry-run mode for safe bulk operations.
 type BatchDeleter interface {
@@ -0,0 +1,128 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	issueJSONFieldsOnce sync.Once
+	issueJSONFields     map[string]bool
+)
+
+// IsIssueJSONField reports whether name is a top-level JSON field of Issue.
+func IsIssueJSONField(name string) bool {
+	loadIssueJSONFields()
+	return issueJSONFields[name]
+}
+
+// IssueJSONFields returns the top-level JSON field names of Issue, sorted.
+func IssueJSONFields() []string {
+	loadIssueJSONFields()
+	names := make([]string, 0, len(issueJSONFields))
+	for name := range issueJSONFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func loadIssueJSONFields() {
+	issueJSONFieldsOnce.Do(func() {
+		issueJSONFields = make(map[string]bool)
+		t := reflect.TypeOf(Issue{})
+		for i := 0; i < t.NumField(); i++ {
+			tag := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+			if tag != "" && tag != "-" {
+				issueJSONFields[tag] = true
+			}
+		}
+	})
+}
+
+// MarshalIssue encodes i as JSON like json.Marshal, then appends its
+// CustomFields as top-level fields in name order, restoring the shape of the
+// record they were read from. Custom fields that clash with a known field are
+// skipped.
+func MarshalIssue(i *Issue) ([]byte, error) {
+	return marshalIssue(i, true)
+}
+
+// IssueEncoder writes issues as JSONL the way json.Encoder does, but through
+// MarshalIssue, so preserved unknown fields survive every export and sync
+// writer. It is a drop-in replacement for json.NewEncoder on issue streams.
+type IssueEncoder struct {
+	w          io.Writer
+	escapeHTML bool
+}
+
+// NewIssueEncoder returns an IssueEncoder writing to w, escaping HTML like
+// json.NewEncoder.
+func NewIssueEncoder(w io.Writer) *IssueEncoder {
+	return &IssueEncoder{w: w, escapeHTML: true}
+}
+
+// SetEscapeHTML is json.Encoder.SetEscapeHTML.
+func (e *IssueEncoder) SetEscapeHTML(on bool) {
+	e.escapeHTML = on
+}
+
+// Encode writes i as one line.
+func (e *IssueEncoder) Encode(i *Issue) error {
+	data, err := marshalIssue(i, e.escapeHTML)
+	if err != nil {
+		return err
+	}
+	_, err = e.w.Write(append(data, '\n'))
+	return err
+}
+
+func marshalIssue(i *Issue, escapeHTML bool) ([]byte, error) {
+	data, err := encodeJSON(i, escapeHTML)
+	if err != nil || len(i.CustomFields) == 0 {
+		return data, err
+	}
+	names := make([]string, 0, len(i.CustomFields))
+	for name := range i.CustomFields {
+		if !IsIssueJSONField(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	buf.Write(data[:len(data)-1]) // drop the closing brace
+	for _, name := range names {
+		key, err := encodeJSON(name, escapeHTML)
+		if err != nil {
+			return nil, err
+		}
+		var value bytes.Buffer
+		if err := json.Compact(&value, i.CustomFields[name]); err != nil {
+			return nil, fmt.Errorf("invalid custom field %s on %s: %w", name, i.ID, err)
+		}
+		buf.WriteByte(',')
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value.Bytes())
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// encodeJSON is json.Marshal with HTML escaping optional.
+func encodeJSON(v interface{}, escapeHTML bool) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(escapeHTML)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
@@ -0,0 +1,18 @@
+package types
+
+// Definitions is the first JSONL line of a self-describing export: the custom
+// issue types and statuses its issues rely on, so an import into a fresh
+// database can register them before the issues (see
+// importer.ParseSelfDescribing and importer.Options.Definitions).
+type Definitions struct {
+	Definitions bool             `json:"_definitions"`
+	Types       []TypeDefinition `json:"types,omitempty"`
+	Statuses    []Status         `json:"statuses,omitempty"` // Custom statuses
+}
+
+// TypeDefinition is one issue type of a Definitions block. Built-in types
+// may appear to carry a status restriction.
+type TypeDefinition struct {
+	Name     IssueType `json:"name"`
+	Statuses []Status  `json:"statuses,omitempty"` // Statuses the type is restricted to (see TypeStatuses); empty states no restriction
+}
@@ -0,0 +1,40 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// FieldChange is the before and after value of one field changed by an
+// update, as JSON values. Text longer than the storage backend's redaction
+// limit is replaced by a RedactedValue. Truncated marks a change whose values
+// were both replaced by RedactedValue summaries to keep the event within the
+// backend's payload limit.
+type FieldChange struct {
+	Old       interface{} `json:"old"`
+	New       interface{} `json:"new"`
+	Truncated bool        `json:"truncated,omitempty"`
+}
+
+// FieldDelta maps the fields an update changed to their before/after values.
+// Update events (updated, status_changed, closed, reopened) store it as JSON
+// in new_value.
+type FieldDelta map[string]FieldChange
+
+// RedactedValue stands in for a large text value in a FieldDelta.
+type RedactedValue struct {
+	SHA256 string `json:"sha256"`
+	Bytes  int    `json:"bytes"`
+}
+
+// ParseFieldDelta decodes the FieldDelta recorded on an update event.
+func ParseFieldDelta(event *Event) (FieldDelta, error) {
+	if event == nil || event.NewValue == nil {
+		return nil, fmt.Errorf("event has no field delta")
+	}
+	var delta FieldDelta
+	if err := json.Unmarshal([]byte(*event.NewValue), &delta); err != nil {
+		return nil, fmt.Errorf("failed to parse field delta of event %d: %w", event.ID, err)
+	}
+	return delta, nil
+}
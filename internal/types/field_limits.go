@@ -0,0 +1,86 @@
+package types
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// FieldLengthPolicy controls what happens when a text field exceeds its limit.
+type FieldLengthPolicy string
+
+const (
+	// FieldLengthError rejects the issue (default).
+	FieldLengthError FieldLengthPolicy = "error"
+	// FieldLengthTruncate cuts the field to its limit and reports a warning.
+	FieldLengthTruncate FieldLengthPolicy = "truncate"
+)
+
+// IsValid checks if the policy value is valid (empty means error).
+func (p FieldLengthPolicy) IsValid() bool {
+	switch p {
+	case "", FieldLengthError, FieldLengthTruncate:
+		return true
+	}
+	return false
+}
+
+// FieldLimits caps the length in bytes of an issue's free-text fields.
+// A zero limit means the field is unbounded.
+type FieldLimits struct {
+	Title              int
+	Description        int
+	Design             int
+	AcceptanceCriteria int
+	Notes              int
+	Policy             FieldLengthPolicy
+	RejectBlankTitle   bool         // Also reject titles that are only whitespace (empty titles are always rejected)
+	TypeStatuses       TypeStatuses // When set, the statuses allowed for each issue type it lists
+}
+
+// DefaultFieldLimits returns the limits applied by ValidateWithCustom: the
+// historical 500-byte title limit, with other fields unbounded.
+func DefaultFieldLimits() FieldLimits {
+	return FieldLimits{Title: 500, Policy: FieldLengthError}
+}
+
+// applyFieldLimits enforces limits on i. Under FieldLengthTruncate, over-long
+// fields are truncated in place (on a UTF-8 boundary) and a warning is
+// returned for each; otherwise the first over-long field is an error.
+func (i *Issue) applyFieldLimits(limits FieldLimits) ([]string, error) {
+	fields := []struct {
+		name  string
+		value *string
+		limit int
+	}{
+		{"title", &i.Title, limits.Title},
+		{"description", &i.Description, limits.Description},
+		{"design", &i.Design, limits.Design},
+		{"acceptance_criteria", &i.AcceptanceCriteria, limits.AcceptanceCriteria},
+		{"notes", &i.Notes, limits.Notes},
+	}
+
+	var warnings []string
+	for _, f := range fields {
+		if f.limit <= 0 || len(*f.value) <= f.limit {
+			continue
+		}
+		if limits.Policy != FieldLengthTruncate {
+			return nil, fmt.Errorf("%s must be %d characters or less (got %d)", f.name, f.limit, len(*f.value))
+		}
+		warnings = append(warnings, fmt.Sprintf("%s truncated from %d to %d characters", f.name, len(*f.value), f.limit))
+		*f.value = truncateUTF8(*f.value, f.limit)
+	}
+	return warnings, nil
+}
+
+// truncateUTF8 returns the longest prefix of s that is at most n bytes and
+// does not split a multi-byte character.
+func truncateUTF8(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
@@ -0,0 +1,90 @@
+package types
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateWithLimits_OverLimitError(t *testing.T) {
+	issue := &Issue{
+		Title:       "Title",
+		Description: strings.Repeat("x", 101),
+		Status:      StatusOpen,
+		Priority:    2,
+		IssueType:   TypeTask,
+	}
+
+	_, err := issue.ValidateWithLimits(nil, nil, FieldLimits{Title: 500, Description: 100})
+	if err == nil {
+		t.Fatal("expected over-limit description to fail validation")
+	}
+	if !strings.Contains(err.Error(), "description") || !strings.Contains(err.Error(), "100") || !strings.Contains(err.Error(), "101") {
+		t.Errorf("expected error naming the field and lengths, got %q", err)
+	}
+	if len(issue.Description) != 101 {
+		t.Error("error policy must not modify the issue")
+	}
+
+	// Defaults keep the historical behavior: long descriptions are fine
+	if err := issue.ValidateWithCustom(nil, nil); err != nil {
+		t.Errorf("expected default limits to accept long description: %v", err)
+	}
+	issue.Title = strings.Repeat("t", 501)
+	if err := issue.ValidateWithCustom(nil, nil); err == nil {
+		t.Error("expected default title limit to still apply")
+	}
+}
+
+func TestValidateWithLimits_Truncate(t *testing.T) {
+	issue := &Issue{
+		Title:     "Title",
+		Notes:     "abcdé", // é is two bytes; a 5-byte cut would split it
+		Status:    StatusOpen,
+		Priority:  2,
+		IssueType: TypeTask,
+	}
+
+	warnings, err := issue.ValidateWithLimits(nil, nil, FieldLimits{Notes: 5, Policy: FieldLengthTruncate})
+	if err != nil {
+		t.Fatalf("expected truncate policy to pass validation: %v", err)
+	}
+	if issue.Notes != "abcd" {
+		t.Errorf("expected notes truncated on a rune boundary, got %q", issue.Notes)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "notes") {
+		t.Errorf("expected one warning about notes, got %v", warnings)
+	}
+}
+
+func TestFieldLengthPolicyIsValid(t *testing.T) {
+	for _, p := range []FieldLengthPolicy{"", FieldLengthError, FieldLengthTruncate} {
+		if !p.IsValid() {
+			t.Errorf("expected %q to be valid", p)
+		}
+	}
+	if FieldLengthPolicy("clip").IsValid() {
+		t.Error("expected unknown policy to be invalid")
+	}
+}
+
+func TestValidateWithLimits_RejectBlankTitle(t *testing.T) {
+	strict := DefaultFieldLimits()
+	strict.RejectBlankTitle = true
+
+	for _, title := range []string{"", "   ", "\t\n"} {
+		issue := &Issue{Title: title, Status: StatusOpen, Priority: 2, IssueType: TypeTask}
+		if _, err := issue.ValidateWithLimits(nil, nil, strict); err == nil || !strings.Contains(err.Error(), "title") {
+			t.Errorf("title %q: expected title error, got %v", title, err)
+		}
+	}
+
+	// Whitespace-only titles stay valid by default; empty ones never were
+	blank := &Issue{Title: "   ", Status: StatusOpen, Priority: 2, IssueType: TypeTask}
+	if err := blank.ValidateWithCustom(nil, nil); err != nil {
+		t.Errorf("expected default validation to accept a blank title: %v", err)
+	}
+	titled := &Issue{Title: " Title ", Status: StatusOpen, Priority: 2, IssueType: TypeTask}
+	if _, err := titled.ValidateWithLimits(nil, nil, strict); err != nil {
+		t.Errorf("expected padded title to pass: %v", err)
+	}
+}
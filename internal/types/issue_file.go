@@ -0,0 +1,20 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// IssueFileExt is the extension of each issue's file in a one-file-per-issue
+// directory export.
+const IssueFileExt = ".json"
+
+// IssueFileName returns the name of the file holding issue id in a
+// one-file-per-issue directory export, or an error if id cannot be used as a
+// file name.
+func IssueFileName(id string) (string, error) {
+	if id == "" || id == "." || id == ".." || strings.ContainsAny(id, "/\\\x00") {
+		return "", fmt.Errorf("issue ID %q cannot be used as a file name", id)
+	}
+	return id + IssueFileExt, nil
+}
@@ -0,0 +1,39 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Milestone is a named span of time, such as a sprint or release, that
+// issues are grouped into through Issue.MilestoneID.
+type Milestone struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	StartAt   *time.Time `json:"start_at,omitempty"`
+	EndAt     *time.Time `json:"end_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// MilestoneRecord is the JSONL line carrying one milestone in a milestone
+// export.
+type MilestoneRecord struct {
+	Milestone *Milestone `json:"_milestone"`
+}
+
+// Validate checks that the milestone can be stored: it needs an ID and a
+// name, and must not end before it starts. Either endpoint may be open.
+func (m *Milestone) Validate() error {
+	if strings.TrimSpace(m.ID) == "" {
+		return fmt.Errorf("milestone id is required")
+	}
+	if strings.TrimSpace(m.Name) == "" {
+		return fmt.Errorf("milestone %s: name is required", m.ID)
+	}
+	if m.StartAt != nil && m.EndAt != nil && m.EndAt.Before(*m.StartAt) {
+		return fmt.Errorf("milestone %s: end %s is before start %s", m.ID, m.EndAt.Format(time.RFC3339), m.StartAt.Format(time.RFC3339))
+	}
+	return nil
+}
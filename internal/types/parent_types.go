@@ -0,0 +1,32 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParentTypes restricts the issue types each parent type may have as children
+// in parent-child dependencies. A parent type with no entry may have children
+// of any type; one mapped to an empty list may have none.
+type ParentTypes map[IssueType][]IssueType
+
+// Check returns an error naming the pairing if an issue of childType may not
+// be a child of an issue of parentType.
+func (r ParentTypes) Check(parentType, childType IssueType) error {
+	allowed, ok := r[parentType]
+	if !ok {
+		return nil
+	}
+	names := make([]string, 0, len(allowed))
+	for _, t := range allowed {
+		if t == childType {
+			return nil
+		}
+		names = append(names, string(t))
+	}
+	list := strings.Join(names, ", ")
+	if list == "" {
+		list = "none"
+	}
+	return fmt.Errorf("issue type %s cannot be a child of issue type %s (allowed children: %s)", childType, parentType, list)
+}
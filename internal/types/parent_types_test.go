@@ -0,0 +1,29 @@
+package types
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParentTypesCheck(t *testing.T) {
+	rules := ParentTypes{
+		TypeEpic: {TypeFeature, TypeTask},
+		TypeTask: {},
+	}
+
+	if err := rules.Check(TypeEpic, TypeTask); err != nil {
+		t.Errorf("expected task under epic to pass: %v", err)
+	}
+	err := rules.Check(TypeEpic, TypeEpic)
+	if err == nil || !strings.Contains(err.Error(), "issue type epic cannot be a child of issue type epic (allowed children: feature, task)") {
+		t.Errorf("expected epic under epic to fail, got %v", err)
+	}
+	err = rules.Check(TypeTask, TypeBug)
+	if err == nil || !strings.Contains(err.Error(), "allowed children: none") {
+		t.Errorf("expected task with no allowed children to fail, got %v", err)
+	}
+	// Unlisted parent types are unrestricted
+	if err := rules.Check(TypeFeature, TypeEpic); err != nil {
+		t.Errorf("expected unlisted parent type to allow any child: %v", err)
+	}
+}
@@ -0,0 +1,13 @@
+package types
+
+// PrefixStats are summary metrics over the issues under one ID prefix (see
+// sqlite.PrefixStats), for per-repo dashboards. Tombstones are not counted.
+// Durations are in hours, like Statistics.AverageLeadTime, and are zero when
+// no issue contributes to them.
+type PrefixStats struct {
+	Prefix            string  `json:"prefix"`
+	OpenIssues        int     `json:"open_issues"`                // Issues in any status other than closed
+	ClosedIssues      int     `json:"closed_issues"`              // Issues in status closed
+	AverageOpenAge    float64 `json:"average_open_age_hours"`     // Mean time since creation of the open issues
+	MedianTimeToClose float64 `json:"median_time_to_close_hours"` // Median time from creation to the latest close event, over closed issues that have one
+}
@@ -0,0 +1,74 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// redactors blank one redactable field, keyed by its JSON name. Identity and
+// structure (ID, title, status, type, priority, timestamps, dependencies) are
+// never redactable, so redacted exports still import and link up.
+var redactors = map[string]func(*Issue){
+	"description":         func(i *Issue) { i.Description = "" },
+	"design":              func(i *Issue) { i.Design = "" },
+	"acceptance_criteria": func(i *Issue) { i.AcceptanceCriteria = "" },
+	"notes":               func(i *Issue) { i.Notes = "" },
+	"assignee":            func(i *Issue) { i.Assignee = "" },
+	"owner":               func(i *Issue) { i.Owner = "" },
+	"created_by":          func(i *Issue) { i.CreatedBy = "" },
+	"close_reason":        func(i *Issue) { i.CloseReason = "" },
+	"external_ref":        func(i *Issue) { i.ExternalRef = nil },
+	"source_system":       func(i *Issue) { i.SourceSystem = "" },
+	"sender":              func(i *Issue) { i.Sender = "" },
+	"deleted_by":          func(i *Issue) { i.DeletedBy = "" },
+	"delete_reason":       func(i *Issue) { i.DeleteReason = "" },
+	"payload":             func(i *Issue) { i.Payload = "" },
+	"labels":              func(i *Issue) { i.Labels = nil },
+	"comments":            func(i *Issue) { i.Comments = nil },
+	"watchers":            func(i *Issue) { i.Watchers = nil },
+	"checklist":           func(i *Issue) { i.Checklist = nil },
+}
+
+// RedactableFields returns the field names Redact accepts, sorted.
+func RedactableFields() []string {
+	names := make([]string, 0, len(redactors))
+	for name := range redactors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidateRedactFields returns an error naming any field Redact does not
+// support.
+func ValidateRedactFields(fields []string) error {
+	var unknown []string
+	for _, field := range fields {
+		if redactors[field] == nil {
+			unknown = append(unknown, field)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("cannot redact field(s) %s (redactable: %s)", strings.Join(unknown, ", "), strings.Join(RedactableFields(), ", "))
+	}
+	return nil
+}
+
+// Redact blanks the named fields (JSON names, see RedactableFields) and
+// recomputes ContentHash under hashOpts, the settings of the database the
+// issue comes from, so a redacted issue hashes the same wherever the redacted
+// form is loaded.
+func (i *Issue) Redact(fields []string, hashOpts ContentHashOptions) error {
+	if err := ValidateRedactFields(fields); err != nil {
+		return err
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	for _, field := range fields {
+		redactors[field](i)
+	}
+	i.ContentHash = i.ComputeContentHashWith(hashOpts)
+	return nil
+}
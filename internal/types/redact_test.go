@@ -0,0 +1,33 @@
+package types
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIssueRedact(t *testing.T) {
+	ref := "jira-SEC-1"
+	issue := &Issue{
+		ID: "bd-1", Title: "Leak", Description: "customer data", Status: StatusOpen, Priority: 1, IssueType: TypeBug,
+		Assignee: "alice@example.com", ExternalRef: &ref, Labels: []string{"security"},
+		Comments: []*Comment{{Author: "bob", Text: "internal"}},
+	}
+	hashOpts := ContentHashOptions{Salt: "pepper"}
+	if err := issue.Redact([]string{"assignee", "description", "external_ref", "comments"}, hashOpts); err != nil {
+		t.Fatalf("Redact failed: %v", err)
+	}
+	if issue.Assignee != "" || issue.Description != "" || issue.ExternalRef != nil || issue.Comments != nil {
+		t.Errorf("expected fields blanked, got %+v", issue)
+	}
+	if issue.Title != "Leak" || len(issue.Labels) != 1 {
+		t.Errorf("expected other fields kept, got %+v", issue)
+	}
+	if issue.ContentHash != issue.ComputeContentHashWith(hashOpts) {
+		t.Error("expected content hash recomputed over the redacted fields with the database's salt")
+	}
+
+	err := issue.Redact([]string{"assignee", "title"}, hashOpts)
+	if err == nil || !strings.Contains(err.Error(), "title") {
+		t.Errorf("expected unredactable title rejected, got %v", err)
+	}
+}
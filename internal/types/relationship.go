@@ -0,0 +1,72 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Relationship is a typed, directed link between two issues that is not
+// hierarchy: FromID blocks, relates to, duplicates (etc.) ToID. Relationships
+// are stored as dependencies, so they export with the FromID issue and
+// re-import from there.
+type Relationship struct {
+	FromID string         `json:"from_id"`
+	ToID   string         `json:"to_id"`
+	Type   DependencyType `json:"type"`
+}
+
+// RelationshipTypes returns the built-in relationship types: every well-known
+// dependency type except parent-child, which is hierarchy.
+func RelationshipTypes() []DependencyType {
+	all := []DependencyType{
+		DepBlocks, DepConditionalBlocks, DepWaitsFor, DepRelated, DepDiscoveredFrom,
+		DepRepliesTo, DepRelatesTo, DepDuplicates, DepSupersedes,
+		DepAuthoredBy, DepAssignedTo, DepApprovedBy, DepAttests, DepTracks,
+		DepUntil, DepCausedBy, DepValidates, DepDelegatedFrom,
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i] < all[j] })
+	return all
+}
+
+// IsRelationshipType reports whether t is a built-in relationship type or one
+// of the custom types in extra.
+func IsRelationshipType(t DependencyType, extra ...DependencyType) bool {
+	if t == DepParentChild {
+		return false
+	}
+	if t.IsWellKnown() {
+		return true
+	}
+	for _, e := range extra {
+		if t == e {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks that both endpoints are set and distinct and that the type
+// is a built-in relationship type or one of the custom types in extra.
+func (r *Relationship) Validate(extra ...DependencyType) error {
+	if r.FromID == "" || r.ToID == "" {
+		return fmt.Errorf("relationship %s → %s: both endpoints are required", r.FromID, r.ToID)
+	}
+	if r.FromID == r.ToID {
+		return fmt.Errorf("relationship %s → %s: an issue cannot relate to itself", r.FromID, r.ToID)
+	}
+	if !IsRelationshipType(r.Type, extra...) {
+		return fmt.Errorf("relationship %s → %s: unregistered relationship type %q", r.FromID, r.ToID, r.Type)
+	}
+	return nil
+}
+
+// Dependency returns the dependency edge that stores r.
+func (r *Relationship) Dependency() *Dependency {
+	return &Dependency{
+		IssueID:     r.FromID,
+		DependsOnID: r.ToID,
+		Type:        r.Type,
+		CreatedAt:   time.Now(),
+	}
+}
@@ -0,0 +1,28 @@
+package types
+
+import "testing"
+
+func TestRelationshipValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		rel   Relationship
+		extra []DependencyType
+		ok    bool
+	}{
+		{"blocks", Relationship{FromID: "bd-1", ToID: "bd-2", Type: DepBlocks}, nil, true},
+		{"duplicates", Relationship{FromID: "bd-1", ToID: "bd-2", Type: DepDuplicates}, nil, true},
+		{"hierarchy is not a relationship", Relationship{FromID: "bd-1", ToID: "bd-2", Type: DepParentChild}, nil, false},
+		{"unregistered custom", Relationship{FromID: "bd-1", ToID: "bd-2", Type: "mentors"}, nil, false},
+		{"registered custom", Relationship{FromID: "bd-1", ToID: "bd-2", Type: "mentors"}, []DependencyType{"mentors"}, true},
+		{"self", Relationship{FromID: "bd-1", ToID: "bd-1", Type: DepRelated}, nil, false},
+		{"missing endpoint", Relationship{FromID: "bd-1", Type: DepRelated}, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rel.Validate(tt.extra...)
+			if (err == nil) != tt.ok {
+				t.Errorf("Validate() error = %v, want ok=%v", err, tt.ok)
+			}
+		})
+	}
+}
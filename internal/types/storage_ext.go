@@ -23,3 +23,31 @@ type DeleteIssuesResult struct {
 	EventsCount       int
 	OrphanedIssues    []string
 }
+
+// BulkStatusResult reports the outcome of a bulk status change per issue ID.
+type BulkStatusResult struct {
+	Updated   []string // Issues moved to the new status
+	Unchanged []string // Issues already in the new status, left untouched
+	NotFound  []string // IDs with no issue (or only a tombstone)
+}
+
+// BulkAssignResult reports the outcome of a bulk reassignment per issue ID.
+type BulkAssignResult struct {
+	Updated   []string // Issues assigned to the new assignee
+	Unchanged []string // Issues already assigned to them, left untouched
+	NotFound  []string // IDs with no issue (or only a tombstone)
+}
+
+// ClosureDirection selects which way GetDependencyClosure follows edges.
+type ClosureDirection string
+
+const (
+	ClosureDependencies ClosureDirection = "dependencies" // What the issue depends on, transitively
+	ClosureDependents   ClosureDirection = "dependents"   // What depends on the issue, transitively
+)
+
+// ClosureNode is one issue in a dependency closure.
+type ClosureNode struct {
+	ID    string `json:"id"`
+	Depth int    `json:"depth"` // Edges on the shortest path from the starting issue (1 = direct)
+}
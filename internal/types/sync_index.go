@@ -0,0 +1,21 @@
+package types
+
+import "time"
+
+// IndexEntry is one line of a sync index (see sqlite.ExportIndex): enough
+// of an issue for two databases to tell which issues differ before either
+// transfers any bodies.
+type IndexEntry struct {
+	ID          string    `json:"id"`
+	ContentHash string    `json:"hash"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// IndexDiff compares the local issues with a remote sync index. Each list
+// is in ID order.
+type IndexDiff struct {
+	New       []string // Local issues the remote index lacks
+	Changed   []string // Issues on both sides whose content hashes differ
+	Missing   []string // Issues in the remote index the local database lacks
+	Unchanged int      // Issues on both sides with the same content hash
+}
@@ -0,0 +1,69 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Template is a skeleton issues are created from: its content, type,
+// priority, labels and custom fields are copied onto every issue
+// instantiated from it. Templates are not validated as issues; only the
+// instantiated issue is.
+type Template struct {
+	ID                 string                     `json:"id"`
+	Name               string                     `json:"name,omitempty"`
+	Title              string                     `json:"title,omitempty"`
+	Description        string                     `json:"description,omitempty"`
+	Design             string                     `json:"design,omitempty"`
+	AcceptanceCriteria string                     `json:"acceptance_criteria,omitempty"`
+	Notes              string                     `json:"notes,omitempty"`
+	IssueType          IssueType                  `json:"issue_type,omitempty"`
+	Priority           int                        `json:"priority"`
+	Labels             []string                   `json:"labels,omitempty"`
+	CustomFields       map[string]json.RawMessage `json:"custom_fields,omitempty"`
+	CreatedAt          time.Time                  `json:"created_at"`
+	UpdatedAt          time.Time                  `json:"updated_at"`
+}
+
+// TemplateRecord is the JSONL line carrying one template in a template
+// export.
+type TemplateRecord struct {
+	Template *Template `json:"_template"`
+}
+
+// Validate checks that the template can be stored.
+func (t *Template) Validate() error {
+	if strings.TrimSpace(t.ID) == "" {
+		return fmt.Errorf("template id is required")
+	}
+	return nil
+}
+
+// NewIssue returns an open issue carrying the template's fields, ready for
+// overrides and validation. Labels and custom fields are copied, so changing
+// the issue leaves the template alone.
+func (t *Template) NewIssue() *Issue {
+	issue := &Issue{
+		Title:              t.Title,
+		Description:        t.Description,
+		Design:             t.Design,
+		AcceptanceCriteria: t.AcceptanceCriteria,
+		Notes:              t.Notes,
+		Status:             StatusOpen,
+		Priority:           t.Priority,
+		IssueType:          t.IssueType,
+		Labels:             append([]string(nil), t.Labels...),
+	}
+	if issue.IssueType == "" {
+		issue.IssueType = TypeTask
+	}
+	if len(t.CustomFields) > 0 {
+		issue.CustomFields = make(map[string]json.RawMessage, len(t.CustomFields))
+		for name, value := range t.CustomFields {
+			issue.CustomFields[name] = append(json.RawMessage(nil), value...)
+		}
+	}
+	return issue
+}
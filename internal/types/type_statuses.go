@@ -0,0 +1,27 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TypeStatuses restricts the statuses each issue type may have. A type with
+// no entry may have any status. Closed and tombstone are always allowed, so
+// every issue can be closed and deleted.
+type TypeStatuses map[IssueType][]Status
+
+// Check returns an error if status is not allowed for issueType.
+func (r TypeStatuses) Check(issueType IssueType, status Status) error {
+	allowed, ok := r[issueType]
+	if !ok || status == StatusClosed || status == StatusTombstone {
+		return nil
+	}
+	names := make([]string, 0, len(allowed))
+	for _, s := range allowed {
+		if s == status {
+			return nil
+		}
+		names = append(names, string(s))
+	}
+	return fmt.Errorf("status %s is not allowed for issue type %s (allowed: %s)", status, issueType, strings.Join(names, ", "))
+}
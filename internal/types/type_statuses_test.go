@@ -0,0 +1,30 @@
+package types
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateWithLimits_TypeStatuses(t *testing.T) {
+	limits := DefaultFieldLimits()
+	limits.TypeStatuses = TypeStatuses{TypeEpic: {StatusOpen, StatusInProgress}}
+	issue := func(issueType IssueType, status Status) *Issue {
+		return &Issue{Title: "Title", Status: status, Priority: 2, IssueType: issueType}
+	}
+
+	_, err := issue(TypeEpic, StatusBlocked).ValidateWithLimits(nil, nil, limits)
+	if err == nil || !strings.Contains(err.Error(), "status blocked is not allowed for issue type epic") {
+		t.Fatalf("expected disallowed epic status to fail, got %v", err)
+	}
+	if _, err := issue(TypeEpic, StatusInProgress).ValidateWithLimits(nil, nil, limits); err != nil {
+		t.Errorf("expected allowed epic status to pass: %v", err)
+	}
+	// Unlisted types are unrestricted
+	if _, err := issue(TypeTask, StatusBlocked).ValidateWithLimits(nil, nil, limits); err != nil {
+		t.Errorf("expected unlisted type to allow any status: %v", err)
+	}
+	// Every issue can be deleted
+	if err := limits.TypeStatuses.Check(TypeEpic, StatusTombstone); err != nil {
+		t.Errorf("expected tombstone to always be allowed: %v", err)
+	}
+}
@@ -2,9 +2,12 @@
 package types
 
 import (
+	"bytes"
 	"crypto/sha256"
+	"encoding/json"
 	"fmt"
-	"hash"
+	"io"
+	"sort"
 	"strings"
 	"time"
 )
@@ -14,7 +17,8 @@ import (
 type Issue struct {
 	// ===== Core Identification =====
 	ID          string `json:"id"`
-	ContentHash string `json:"-"` // Internal: SHA256 of canonical content - NOT exported to JSONL
+	ContentHash string `json:"-"`               // Internal: SHA256 of canonical content - NOT exported to JSONL
+	RowID       int64  `json:"rowid,omitempty"` // SQLite rowid, written by export; kept on import only with PreserveRowIDs. Not part of the content hash
 
 	// ===== Issue Content =====
 	Title              string `json:"title"`
@@ -44,6 +48,15 @@ type Issue struct {
 	// ===== Time-Based Scheduling (GH#820) =====
 	DueAt      *time.Time `json:"due_at,omitempty"`      // When this issue should be completed
 	DeferUntil *time.Time `json:"defer_until,omitempty"` // Hide from bd ready until this time
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`  // Tombstoned by the expiry sweep once this time passes
+
+	// ===== Display Metadata =====
+	Color        string `json:"color,omitempty"`         // Board color, e.g. "#d73a4a"; cosmetic
+	DisplayOrder int    `json:"display_order,omitempty"` // Position on boards, lowest first (see ListIssuesByDisplayOrder)
+	Rank         string `json:"rank,omitempty"`          // Lexorank-style backlog position, compared bytewise (see MoveIssue)
+
+	// ===== Planning =====
+	MilestoneID string `json:"milestone_id,omitempty"` // Milestone (sprint, release) the issue is planned for (see ListIssuesByMilestone)
 
 	// ===== External Integration =====
 	ExternalRef  *string `json:"external_ref,omitempty"`  // e.g., "gh-9", "jira-ABC"
@@ -61,9 +74,11 @@ type Issue struct {
 	PrefixOverride string `json:"-"` // Completely replace config prefix (for cross-rig creation)
 
 	// ===== Relational Data (populated for export/import) =====
-	Labels       []string      `json:"labels,omitempty"`
-	Dependencies []*Dependency `json:"dependencies,omitempty"`
-	Comments     []*Comment    `json:"comments,omitempty"`
+	Labels       []string         `json:"labels,omitempty"`
+	Dependencies []*Dependency    `json:"dependencies,omitempty"`
+	Comments     []*Comment       `json:"comments,omitempty"`
+	Watchers     []string         `json:"watchers,omitempty"`  // Who is notified about changes (free-form identities, like Assignee)
+	Checklist    []*ChecklistItem `json:"checklist,omitempty"` // Ordered checklist items, by Position
 
 	// ===== Tombstone Fields (soft-delete support) =====
 	DeletedAt    *time.Time `json:"deleted_at,omitempty"`    // When deleted
@@ -122,15 +137,69 @@ type Issue struct {
 	Actor     string `json:"actor,omitempty"`      // Entity URI who caused this event
 	Target    string `json:"target,omitempty"`     // Entity URI or bead ID affected
 	Payload   string `json:"payload,omitempty"`    // Event-specific JSON data
+
+	// ===== Unknown Fields (round-trip safety) =====
+	// Top-level JSON fields this version does not know, kept by imports that
+	// preserve unknown fields and written back at the top level on export.
+	CustomFields map[string]json.RawMessage `json:"-"`
 }
 
+// ContentHashVersion numbers the field set and encoding ComputeContentHash
+// implements. Fields are added to the hash only when set, so existing hashes
+// stay valid; the version is bumped when a change alters existing hashes
+// after all, and stored hashes are then migrated to it.
+const ContentHashVersion = 1
+
 // ComputeContentHash creates a deterministic hash of the issue's content.
 // Uses all substantive fields (excluding ID, timestamps, and compaction metadata)
 // to ensure that identical content produces identical hashes across all clones.
 func (i *Issue) ComputeContentHash() string {
+	return i.ComputeSaltedContentHash("")
+}
+
+// ComputeSaltedContentHash is ComputeContentHash with salt mixed in ahead of
+// the content fields, so the same content hashes differently under different
+// salts. An empty salt gives the unsalted ComputeContentHash.
+func (i *Issue) ComputeSaltedContentHash(salt string) string {
+	return i.ComputeContentHashWith(ContentHashOptions{Salt: salt})
+}
+
+// ContentHashOptions are the per-database settings a content hash is computed
+// under. The zero value gives ComputeContentHash.
+type ContentHashOptions struct {
+	Salt          string // Mixed in ahead of the content fields (see ComputeSaltedContentHash)
+	DisplayFields bool   // Also hash Color, DisplayOrder and Rank, which are cosmetic and left out by default
+}
+
+// ComputeContentHashWith is ComputeContentHash under opts.
+func (i *Issue) ComputeContentHashWith(opts ContentHashOptions) string {
 	h := sha256.New()
 	w := hashFieldWriter{h}
+	if opts.Salt != "" {
+		w.str("salt:" + opts.Salt)
+	}
+	i.writeContentFields(w, opts.DisplayFields)
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
 
+// Equal reports whether i and other have the same content, i.e. whether their
+// content hashes would match. Only the fields hashed by ComputeContentHash are
+// compared, so differences in ID, timestamps, or compaction metadata are ignored.
+// This avoids computing SHA-256 for in-memory deduplication.
+func (i *Issue) Equal(other *Issue) bool {
+	if i == nil || other == nil {
+		return i == other
+	}
+	var a, b bytes.Buffer
+	i.writeContentFields(hashFieldWriter{&a}, false)
+	other.writeContentFields(hashFieldWriter{&b}, false)
+	return bytes.Equal(a.Bytes(), b.Bytes())
+}
+
+// writeContentFields writes the fields that define an issue's content identity.
+// This is the single field list shared by ComputeContentHash and Equal. The
+// display metadata is written only with displayFields.
+func (i *Issue) writeContentFields(w hashFieldWriter, displayFields bool) {
 	// Core fields in stable order
 	w.str(i.Title)
 	w.str(i.Description)
@@ -202,13 +271,51 @@ func (i *Issue) ComputeContentHash() string {
 	w.str(i.Target)
 	w.str(i.Payload)
 
-	return fmt.Sprintf("%x", h.Sum(nil))
+	// Scheduling (written only when set, so issues without a due date or
+	// expiry keep the hash they had before these were hashed)
+	w.timePtr("due", i.DueAt)
+	w.timePtr("expires", i.ExpiresAt)
+
+	// Display metadata is cosmetic, so it is hashed only when asked for, and
+	// then likewise written only when set
+	if displayFields {
+		if i.Color != "" {
+			w.str("color:" + i.Color)
+		}
+		if i.DisplayOrder != 0 {
+			w.str(fmt.Sprintf("order:%d", i.DisplayOrder))
+		}
+		if i.Rank != "" {
+			w.str("rank:" + i.Rank)
+		}
+	}
+
+	// Planning, likewise written only when set
+	if i.MilestoneID != "" {
+		w.str("milestone:" + i.MilestoneID)
+	}
+
+	// Preserved unknown fields, by name (likewise written only when present)
+	names := make([]string, 0, len(i.CustomFields))
+	for name := range i.CustomFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		w.str("field:" + name + "=" + string(i.CustomFields[name]))
+	}
+
+	// Checklist items in order (likewise written only when there are any)
+	for _, item := range i.Checklist {
+		w.str("check:" + item.Text)
+		w.flag(item.Done, "done")
+	}
 }
 
 // hashFieldWriter provides helper methods for writing fields to a hash.
 // Each method writes the value followed by a null separator for consistency.
 type hashFieldWriter struct {
-	h hash.Hash
+	h io.Writer
 }
 
 func (w hashFieldWriter) str(s string) {
@@ -240,6 +347,14 @@ func (w hashFieldWriter) duration(d time.Duration) {
 	w.h.Write([]byte{0})
 }
 
+// timePtr writes label and the UTC time at second precision when p is set,
+// and nothing at all when it is nil.
+func (w hashFieldWriter) timePtr(label string, p *time.Time) {
+	if p != nil {
+		w.str(label + ":" + p.UTC().Format(time.RFC3339))
+	}
+}
+
 func (w hashFieldWriter) flag(b bool, label string) {
 	if b {
 		w.h.Write([]byte(label))
@@ -322,13 +437,38 @@ func (i *Issue) ValidateWithCustomStatuses(customStatuses []string) error {
 
 // ValidateWithCustom checks if the issue has valid field values,
 // allowing custom statuses and types in addition to built-in ones.
+// Field lengths are checked against DefaultFieldLimits.
 func (i *Issue) ValidateWithCustom(customStatuses, customTypes []string) error {
+	_, err := i.ValidateWithLimits(customStatuses, customTypes, DefaultFieldLimits())
+	return err
+}
+
+// ValidateWithLimits is ValidateWithCustom with configurable field length
+// limits and type-status rules (see TypeStatuses). Under the truncate
+// policy, over-long fields are truncated in place and described in the
+// returned warnings instead of failing validation.
+func (i *Issue) ValidateWithLimits(customStatuses, customTypes []string, limits FieldLimits) ([]string, error) {
 	if len(i.Title) == 0 {
-		return fmt.Errorf("title is required")
+		return nil, fmt.Errorf("title is required")
 	}
-	if len(i.Title) > 500 {
-		return fmt.Errorf("title must be 500 characters or less (got %d)", len(i.Title))
+	if limits.RejectBlankTitle && strings.TrimSpace(i.Title) == "" {
+		return nil, fmt.Errorf("title must not be blank")
+	}
+	warnings, err := i.applyFieldLimits(limits)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.validateFields(customStatuses, customTypes); err != nil {
+		return nil, err
 	}
+	if err := limits.TypeStatuses.Check(i.IssueType, i.Status); err != nil {
+		return nil, err
+	}
+	return warnings, nil
+}
+
+// validateFields checks everything ValidateWithCustom checks except text lengths.
+func (i *Issue) validateFields(customStatuses, customTypes []string) error {
 	if i.Priority < 0 || i.Priority > 4 {
 		return fmt.Errorf("priority must be between 0 and 4 (got %d)", i.Priority)
 	}
@@ -663,6 +803,12 @@ type IssueWithCounts struct {
 	DependentCount  int `json:"dependent_count"`
 }
 
+// IssueWithBlockedStatus extends Issue with whether an open blocker holds it
+type IssueWithBlockedStatus struct {
+	*Issue
+	Blocked bool `json:"blocked"`
+}
+
 // IssueDetails extends Issue with labels, dependencies, dependents, and comments.
 // Used for JSON serialization in bd show and RPC responses.
 type IssueDetails struct {
@@ -809,6 +955,15 @@ type Label struct {
 	Label   string `json:"label"`
 }
 
+// ChecklistItem is one entry of the ordered checklist inside an issue.
+// Items are ordered by Position; moving an item changes its Position.
+type ChecklistItem struct {
+	IssueID  string `json:"issue_id"`
+	Position int    `json:"position"`
+	Text     string `json:"text"`
+	Done     bool   `json:"done,omitempty"`
+}
+
 // Comment represents a comment on an issue
 type Comment struct {
 	ID        int64     `json:"id"`
@@ -830,6 +985,56 @@ type Event struct {
 	CreatedAt time.Time  `json:"created_at"`
 }
 
+// EventRecord is the JSONL line carrying one event in a history export. Event
+// records follow the issue they belong to, oldest first.
+type EventRecord struct {
+	Event *Event `json:"_event"`
+}
+
+// SnapshotFormatVersion is the snapshot format written in SnapshotHeader.
+// Importers reject snapshots with a newer version.
+const SnapshotFormatVersion = 1
+
+// SnapshotHeader is the first JSONL line of a snapshot export, describing the
+// records that follow so an import can check compatibility and size before
+// reading them.
+type SnapshotHeader struct {
+	Header        bool      `json:"_header"`
+	SchemaVersion int       `json:"schema_version"`
+	ExportedAt    time.Time `json:"exported_at"`
+	Prefix        string    `json:"prefix"`
+	IssueCount    int       `json:"issue_count"` // Issues matching the export when it started
+	Source        string    `json:"source,omitempty"`
+}
+
+// ExportChecksum is the last JSONL line of a checksummed export: the SHA-256
+// of every byte before it, hex encoded, so the export can be verified or
+// signed as a whole.
+type ExportChecksum struct {
+	Checksum  string `json:"_checksum"`
+	Algorithm string `json:"algorithm"` // Always "sha256"
+}
+
+// ArchiveManifest is the manifest.json of an export archive: a tar.gz of
+// JSONL shards, each listed with the SHA-256 of its bytes so the archive can
+// be verified before anything is imported.
+type ArchiveManifest struct {
+	FormatVersion int            `json:"format_version"`
+	ExportedAt    time.Time      `json:"exported_at"`
+	Shards        []ArchiveShard `json:"shards"`
+}
+
+// ArchiveShard describes one JSONL file in an export archive.
+type ArchiveShard struct {
+	Name       string `json:"name"`   // Path of the shard within the archive
+	SHA256     string `json:"sha256"` // Hex-encoded SHA-256 of the shard's bytes
+	IssueCount int    `json:"issue_count"`
+}
+
+// ArchiveFormatVersion is the ArchiveManifest format this version writes and
+// the newest it reads.
+const ArchiveFormatVersion = 1
+
 // EventType categorizes audit trail events
 type EventType string
 
@@ -846,6 +1051,13 @@ const (
 	EventLabelAdded        EventType = "label_added"
 	EventLabelRemoved      EventType = "label_removed"
 	EventCompacted         EventType = "compacted"
+	EventReparented        EventType = "reparented"
+	EventWatcherAdded      EventType = "watcher_added"
+	EventWatcherRemoved    EventType = "watcher_removed"
+	EventChecklistChanged  EventType = "checklist_changed"
+	EventMerged            EventType = "merged"
+	EventTypeRegistered    EventType = "type_registered"
+	EventSynthesized       EventType = "synthesized"
 )
 
 // BlockedIssue extends Issue with blocking information
@@ -863,6 +1075,38 @@ type TreeNode struct {
 	Truncated bool   `json:"truncated"`
 }
 
+// IssueTree is an issue with its hierarchical children (IDs of the form
+// parent.N) nested beneath it, as returned by GetIssueTree.
+type IssueTree struct {
+	Issue
+	Children []*IssueTree `json:"children,omitempty"`
+}
+
+// ShadowOp is the kind of change a shadow import would have made.
+type ShadowOp string
+
+const (
+	ShadowCreate    ShadowOp = "create"
+	ShadowUpdate    ShadowOp = "update"
+	ShadowUnchanged ShadowOp = "unchanged"
+	ShadowSkip      ShadowOp = "skip"
+	ShadowDelete    ShadowOp = "delete"
+)
+
+// ShadowOperation is one entry of the shadow import log: what an import would
+// have done to an issue, recorded for review instead of being applied.
+type ShadowOperation struct {
+	Run        int64     `json:"run"`              // Shadow run that recorded it; runs are numbered from 1
+	Source     string    `json:"source,omitempty"` // Sync source being evaluated
+	IssueID    string    `json:"issue_id"`
+	Operation  ShadowOp  `json:"operation"`
+	Reason     string    `json:"reason,omitempty"`   // Why a ShadowSkip would not be applied
+	OldHash    string    `json:"old_hash,omitempty"` // Content hash of the live issue, if any
+	NewHash    string    `json:"new_hash,omitempty"` // Content hash of the incoming issue
+	Issue      *Issue    `json:"issue,omitempty"`    // Incoming issue (nil for deletes)
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
 // MoleculeProgressStats provides efficient progress info for large molecules.
 // This uses indexed queries instead of loading all steps into memory.
 type MoleculeProgressStats struct {
@@ -957,6 +1201,10 @@ type IssueFilter struct {
 	DueAfter    *time.Time // Filter issues with due_at > this time
 	DueBefore   *time.Time // Filter issues with due_at < this time
 	Overdue     bool       // Filter issues where due_at < now AND status != closed
+
+	// Custom field filtering: issue's custom field must equal the given string
+	// value (AND semantics across keys); issues without the field never match
+	CustomFields map[string]string
 }
 
 // SortPolicy determines how ready work is ordered
@@ -932,6 +932,59 @@ func TestComputeContentHash(t *testing.T) {
 	}
 }
 
+func TestIssueEqual(t *testing.T) {
+	now := time.Now()
+	closed := now.Add(time.Hour)
+	base := Issue{
+		ID:          "test-1",
+		Title:       "Test Issue",
+		Description: "Description",
+		Status:      StatusOpen,
+		Priority:    2,
+		IssueType:   TypeFeature,
+		Labels:      []string{"a"},
+	}
+
+	// Volatile fields (ID, timestamps, hash, compaction metadata) don't count
+	volatile := base
+	volatile.ID = "test-2"
+	volatile.ContentHash = "stale"
+	volatile.CreatedAt = now
+	volatile.UpdatedAt = now
+	volatile.ClosedAt = &closed
+	volatile.CompactionLevel = 2
+	volatile.OriginalSize = 100
+	if !base.Equal(&volatile) {
+		t.Error("expected issues differing only in volatile fields to be equal")
+	}
+	if base.ComputeContentHash() != volatile.ComputeContentHash() {
+		t.Error("expected equal issues to have matching content hashes")
+	}
+
+	changed := base
+	changed.Assignee = "alice"
+	if base.Equal(&changed) {
+		t.Error("expected issues with different assignees to differ")
+	}
+	if base.ComputeContentHash() == changed.ComputeContentHash() {
+		t.Error("expected unequal issues to have different content hashes")
+	}
+
+	pinned := base
+	pinned.Pinned = true
+	if base.Equal(&pinned) {
+		t.Error("expected pinned flag to affect equality")
+	}
+
+	var nilIssue *Issue
+	if nilIssue.Equal(&base) || base.Equal(nil) {
+		t.Error("expected nil to equal only nil")
+	}
+	if !nilIssue.Equal(nil) {
+		t.Error("expected nil to equal nil")
+	}
+}
+
 func TestSortPolicyIsValid(t *testing.T) {
 	tests := []struct {
 		policy SortPolicy
@@ -1574,3 +1627,127 @@ func TestComputeContentHashWithValidations(t *testing.T) {
 		t.Error("Expected different hash when Score is added")
 	}
 }
+
+func TestComputeContentHashWithDueAt(t *testing.T) {
+	due := time.Date(2026, 3, 1, 17, 0, 0, 0, time.UTC)
+	plain := Issue{Title: "Due", Status: StatusOpen, Priority: 2, IssueType: TypeTask}
+
+	// Issues without a due date keep the hash they had before DueAt was hashed
+	if got, want := plain.ComputeContentHash(), "db2d3537afc769f37732c59e1da1724ef90901250007460f45d8d61e5d26512f"; got != want {
+		t.Errorf("hash without DueAt changed: got %s, want %s", got, want)
+	}
+
+	withDue := plain
+	withDue.DueAt = &due
+	if withDue.ComputeContentHash() == plain.ComputeContentHash() {
+		t.Error("Expected different hash when DueAt is set")
+	}
+
+	// The same instant in another zone (or with sub-second noise) hashes the same
+	sameInstant := due.In(time.FixedZone("EST", -5*3600)).Add(300 * time.Millisecond)
+	other := plain
+	other.DueAt = &sameInstant
+	if other.ComputeContentHash() != withDue.ComputeContentHash() {
+		t.Error("Expected the same hash for the same due instant in another zone")
+	}
+
+	later := due.Add(24 * time.Hour)
+	other.DueAt = &later
+	if other.ComputeContentHash() == withDue.ComputeContentHash() {
+		t.Error("Expected different hash for a different due date")
+	}
+}
+
+func TestComputeContentHashWithChecklist(t *testing.T) {
+	plain := Issue{Title: "Checklist", Status: StatusOpen, Priority: 2, IssueType: TypeTask}
+	base := plain.ComputeContentHash()
+
+	withList := plain
+	withList.Checklist = []*ChecklistItem{{Position: 1, Text: "write"}, {Position: 2, Text: "test"}}
+	hash := withList.ComputeContentHash()
+	if hash == base {
+		t.Error("Expected different hash when a checklist is present")
+	}
+
+	// Positions only order the items; renumbering keeps the hash
+	renumbered := plain
+	renumbered.Checklist = []*ChecklistItem{{Position: 10, Text: "write"}, {Position: 20, Text: "test"}}
+	if renumbered.ComputeContentHash() != hash {
+		t.Error("Expected the same hash for the same items in the same order")
+	}
+
+	reordered := plain
+	reordered.Checklist = []*ChecklistItem{{Position: 1, Text: "test"}, {Position: 2, Text: "write"}}
+	if reordered.ComputeContentHash() == hash {
+		t.Error("Expected different hash for reordered items")
+	}
+
+	done := plain
+	done.Checklist = []*ChecklistItem{{Position: 1, Text: "write", Done: true}, {Position: 2, Text: "test"}}
+	if done.ComputeContentHash() == hash {
+		t.Error("Expected different hash when an item is done")
+	}
+}
+
+func TestComputeContentHashWithDisplayMetadata(t *testing.T) {
+	plain := Issue{Title: "Board card", Status: StatusOpen, Priority: 2, IssueType: TypeTask}
+	display := ContentHashOptions{DisplayFields: true}
+
+	moved := plain
+	moved.Color = "#d73a4a"
+	moved.DisplayOrder = 3
+	moved.Rank = "m"
+	if moved.ComputeContentHash() != plain.ComputeContentHash() {
+		t.Error("Expected display metadata to be left out of the hash by default")
+	}
+	if !moved.Equal(&plain) {
+		t.Error("Expected issues differing only in display metadata to be equal")
+	}
+	if plain.ComputeContentHashWith(display) != plain.ComputeContentHash() {
+		t.Error("Expected unset display metadata to keep the default hash")
+	}
+
+	base := plain.ComputeContentHashWith(display)
+	colored := plain
+	colored.Color = "#d73a4a"
+	if colored.ComputeContentHashWith(display) == base {
+		t.Error("Expected different hash when a color is set")
+	}
+	ordered := plain
+	ordered.DisplayOrder = 3
+	if ordered.ComputeContentHashWith(display) == base {
+		t.Error("Expected different hash when a display order is set")
+	}
+	ranked := plain
+	ranked.Rank = "m"
+	if ranked.ComputeContentHashWith(display) == base {
+		t.Error("Expected different hash when a rank is set")
+	}
+	// Color and order must not be confusable
+	if (&Issue{Title: "x", Color: "3"}).ComputeContentHashWith(display) == (&Issue{Title: "x", DisplayOrder: 3}).ComputeContentHashWith(display) {
+		t.Error("Expected color and display order to hash differently")
+	}
+}
+
+func TestComputeSaltedContentHash(t *testing.T) {
+	issue := Issue{Title: "Same content", Description: "Everywhere", Status: StatusOpen, Priority: 2, IssueType: TypeTask}
+
+	if got := issue.ComputeSaltedContentHash(""); got != issue.ComputeContentHash() {
+		t.Errorf("Expected an empty salt to give the unsalted hash, got %s", got)
+	}
+	a := issue.ComputeSaltedContentHash("db-a")
+	b := issue.ComputeSaltedContentHash("db-b")
+	if a == b {
+		t.Error("Expected the same content to hash differently under different salts")
+	}
+	if a == issue.ComputeContentHash() {
+		t.Error("Expected a salted hash to differ from the unsalted one")
+	}
+	if again := issue.ComputeSaltedContentHash("db-a"); again != a {
+		t.Errorf("Expected a deterministic salted hash, got %s and %s", a, again)
+	}
+	// The salt must not be confusable with the title
+	if (&Issue{Title: "x"}).ComputeSaltedContentHash("y") == (&Issue{Title: "salt:y"}).ComputeContentHash() {
+		t.Error("Expected the salt and content fields to hash differently")
+	}
+}
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"cmp"
 	"context"
 	"testing"
 
@@ -444,6 +445,26 @@ func TestExtractIssuePrefix(t *testing.T) {
 	}
 }
 
+func TestExtractIssuePrefixWithSeparator(t *testing.T) {
+	tests := []struct {
+		issueID  string
+		sep      string
+		expected string
+	}{
+		{"web-app_a3f8e9", "_", "web-app"},
+		{"web-app_a3f8e9.1", "_", "web-app"},
+		{"web-app_wisp_a3f8e9", "_", "web-app_wisp"},
+		{"web-app-a3f8e9", "_", ""},
+		{"bd-a3f8e9", "", "bd"},
+	}
+
+	for _, tt := range tests {
+		if got := ExtractIssuePrefixWithSeparator(tt.issueID, tt.sep); got != tt.expected {
+			t.Errorf("ExtractIssuePrefixWithSeparator(%q, %q) = %q; want %q", tt.issueID, tt.sep, got, tt.expected)
+		}
+	}
+}
+
 func TestExtractIssueNumber(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -521,3 +542,15 @@ func findSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+func TestCompareNaturalIDs(t *testing.T) {
+	ordered := []string{"bd-1", "bd-1.2", "bd-1.10", "bd-02", "bd-2", "bd-10", "bd-a", "bd-a2", "bd-a10", "bd-b"}
+	for i := range ordered {
+		for j := range ordered {
+			want := cmp.Compare(i, j)
+			if got := CompareNaturalIDs(ordered[i], ordered[j]); got != want {
+				t.Errorf("CompareNaturalIDs(%q, %q) = %d, want %d", ordered[i], ordered[j], got, want)
+			}
+		}
+	}
+}
@@ -1,10 +1,15 @@
 package utils
 
 import (
+	"cmp"
 	"fmt"
 	"strings"
 )
 
+// DefaultIDSeparator joins a prefix and the hash part of an issue ID ("bd-a3f8e9")
+// unless the database configures a different separator.
+const DefaultIDSeparator = "-"
+
 // ExtractIssuePrefix extracts the prefix from an issue ID like "bd-123" -> "bd"
 // Uses the last hyphen before a numeric or hash-like suffix:
 //   - "beads-vscode-1" -> "beads-vscode" (numeric suffix)
@@ -19,15 +24,26 @@ import (
 // This distinguishes hash IDs (which may contain letters but have digits or are 3 chars)
 // from multi-part IDs where the suffix after the first hyphen is the entire ID.
 func ExtractIssuePrefix(issueID string) string {
-	// Try last hyphen first (handles multi-part prefixes like "beads-vscode-1")
-	lastIdx := strings.LastIndex(issueID, "-")
+	return ExtractIssuePrefixWithSeparator(issueID, DefaultIDSeparator)
+}
+
+// ExtractIssuePrefixWithSeparator is ExtractIssuePrefix for IDs whose prefix and
+// hash are joined by sep instead of "-". With a separator that the prefix itself
+// cannot contain (e.g. "_" for "web-app_a3f8e9"), the split is unambiguous.
+func ExtractIssuePrefixWithSeparator(issueID, sep string) string {
+	if sep == "" {
+		sep = DefaultIDSeparator
+	}
+
+	// Try last separator first (handles multi-part prefixes like "beads-vscode-1")
+	lastIdx := strings.LastIndex(issueID, sep)
 	if lastIdx <= 0 {
 		return ""
 	}
 
-	suffix := issueID[lastIdx+1:]
+	suffix := issueID[lastIdx+len(sep):]
 	if len(suffix) == 0 {
-		// Trailing hyphen like "bd-" - return prefix before the hyphen
+		// Trailing separator like "bd-" - return prefix before the separator
 		return issueID[:lastIdx]
 	}
 
@@ -45,8 +61,8 @@ func ExtractIssuePrefix(issueID string) string {
 	}
 
 	// Suffix looks like an English word (4+ chars, no digits) or contains special chars
-	// Fall back to first hyphen - the entire part after first hyphen is the ID
-	firstIdx := strings.Index(issueID, "-")
+	// Fall back to first separator - the entire part after it is the ID
+	firstIdx := strings.Index(issueID, sep)
 	if firstIdx <= 0 {
 		return ""
 	}
@@ -107,3 +123,51 @@ func ExtractIssueNumber(issueID string) int {
 	_, _ = fmt.Sscanf(issueID[idx+1:], "%d", &num)
 	return num
 }
+
+// CompareNaturalIDs compares issue IDs with runs of digits ordered by numeric
+// value, so "bd-2" sorts before "bd-10" and "bd-1.2" before "bd-1.10".
+// Returns -1, 0 or +1. IDs whose digit runs differ only in leading zeros
+// ("bd-02" and "bd-2") fall back to byte order, keeping the order total.
+func CompareNaturalIDs(a, b string) int {
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if isDigit(a[i]) && isDigit(b[j]) {
+			// Skip leading zeros, then the longer run is the bigger number
+			si, sj := i, j
+			for si < len(a) && a[si] == '0' {
+				si++
+			}
+			for sj < len(b) && b[sj] == '0' {
+				sj++
+			}
+			ei, ej := si, sj
+			for ei < len(a) && isDigit(a[ei]) {
+				ei++
+			}
+			for ej < len(b) && isDigit(b[ej]) {
+				ej++
+			}
+			if c := cmp.Compare(ei-si, ej-sj); c != 0 {
+				return c
+			}
+			if c := strings.Compare(a[si:ei], b[sj:ej]); c != 0 {
+				return c
+			}
+			i, j = ei, ej
+			continue
+		}
+		if a[i] != b[j] {
+			return cmp.Compare(a[i], b[j])
+		}
+		i++
+		j++
+	}
+	if c := cmp.Compare(len(a)-i, len(b)-j); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
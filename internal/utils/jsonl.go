@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// DefaultMaxJSONLLineSize is the largest JSONL record NewJSONLScanner accepts
+// when no limit is configured. It is far above bufio's 64KB default so issues
+// with very large descriptions import without tuning.
+const DefaultMaxJSONLLineSize = 64 * 1024 * 1024
+
+// ErrJSONLLineTooLong reports a JSONL record larger than the scanner limit.
+var ErrJSONLLineTooLong = errors.New("JSONL line exceeds maximum size")
+
+// JSONLScanner is a bufio.Scanner over JSONL input that counts lines and
+// reports oversized records with their line number instead of bufio's bare
+// "token too long".
+type JSONLScanner struct {
+	*bufio.Scanner
+	maxLineSize int
+	line        int
+}
+
+// NewJSONLScanner returns a scanner for r that accepts lines up to maxLineSize
+// bytes. A maxLineSize of 0 or less uses DefaultMaxJSONLLineSize.
+func NewJSONLScanner(r io.Reader, maxLineSize int) *JSONLScanner {
+	if maxLineSize <= 0 {
+		maxLineSize = DefaultMaxJSONLLineSize
+	}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, min(64*1024, maxLineSize)), maxLineSize)
+	return &JSONLScanner{Scanner: scanner, maxLineSize: maxLineSize}
+}
+
+// Scan advances to the next line.
+func (s *JSONLScanner) Scan() bool {
+	if !s.Scanner.Scan() {
+		return false
+	}
+	s.line++
+	return true
+}
+
+// Line returns the 1-based number of the current line.
+func (s *JSONLScanner) Line() int {
+	return s.line
+}
+
+// Err returns the first non-EOF error. An oversized line is reported as
+// ErrJSONLLineTooLong naming the offending line and the limit.
+func (s *JSONLScanner) Err() error {
+	err := s.Scanner.Err()
+	if errors.Is(err, bufio.ErrTooLong) {
+		return fmt.Errorf("line %d: %w (limit %d bytes)", s.line+1, ErrJSONLLineTooLong, s.maxLineSize)
+	}
+	return err
+}
@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestJSONLScanner(t *testing.T) {
+	long := strings.Repeat("x", 100*1024) // exceeds bufio's 64KB default
+	input := "{\"id\":\"bd-1\"}\n{\"id\":\"bd-2\",\"description\":\"" + long + "\"}\n"
+
+	scanner := NewJSONLScanner(strings.NewReader(input), 0)
+	var lines []int
+	for scanner.Scan() {
+		lines = append(lines, scanner.Line())
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("expected default limit to accept a 100KB line, got %v", err)
+	}
+	if len(lines) != 2 || lines[1] != 2 {
+		t.Errorf("expected lines [1 2], got %v", lines)
+	}
+
+	scanner = NewJSONLScanner(strings.NewReader(input), 64*1024)
+	for scanner.Scan() {
+	}
+	err := scanner.Err()
+	if !errors.Is(err, ErrJSONLLineTooLong) {
+		t.Fatalf("expected ErrJSONLLineTooLong, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("expected error to name line 2, got %q", err)
+	}
+}